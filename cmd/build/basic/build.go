@@ -21,6 +21,7 @@ import (
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
@@ -52,17 +53,21 @@ func (ob *Builder) Build(ctx context.Context, options *types.BuildOptions) error
 		path = options.CommandArgs[0]
 	}
 
-	if err := utils.CheckIfDirectory(path); err != nil {
-		return fmt.Errorf("invalid build context: %w", err)
-	}
 	options.Path = path
 
-	if options.File == "" {
-		options.File = filepath.Join(path, "Dockerfile")
-	}
+	// remote contexts are fetched by the builder, the Dockerfile is a path inside of them
+	if !build.IsRemoteContext(path) {
+		if err := utils.CheckIfDirectory(path); err != nil {
+			return fmt.Errorf("invalid build context: %w", err)
+		}
 
-	if exists := filesystem.FileExistsAndNotDir(options.File, afero.NewOsFs()); !exists {
-		return fmt.Errorf("%s: '%s' is not a regular file", oktetoErrors.InvalidDockerfile, options.File)
+		if options.File == "" {
+			options.File = filepath.Join(path, "Dockerfile")
+		}
+
+		if exists := filesystem.FileExistsAndNotDir(options.File, afero.NewOsFs()); !exists {
+			return fmt.Errorf("%s: '%s' is not a regular file", oktetoErrors.InvalidDockerfile, options.File)
+		}
 	}
 
 	var err error
@@ -76,10 +81,13 @@ func (ob *Builder) Build(ctx context.Context, options *types.BuildOptions) error
 		return err
 	}
 
-	if options.Tag == "" {
+	switch {
+	case options.CacheWarm:
+		ob.IoCtrl.Out().Success("Cache successfully exported to '%s'", strings.Join(options.ExportCache, "', '"))
+	case options.Tag == "":
 		ob.IoCtrl.Out().Success("Build succeeded")
 		ob.IoCtrl.Out().Infof("Your image won't be pushed. To push your image specify the flag '-t'.")
-	} else {
+	default:
 		tags := strings.Split(options.Tag, ",")
 		if len(tags) >= 1 {
 			displayTag := tags[0]
@@ -99,6 +99,32 @@ func TestBuildWithNoErrorFromDockerfile(t *testing.T) {
 	buildRunner.AssertExpectations(t)
 }
 
+func TestBuildWithRemoteContext(t *testing.T) {
+	ctx := context.Background()
+
+	buildRunner := &fakeBuildRunner{}
+	bc := &Builder{
+		BuildRunner: buildRunner,
+		IoCtrl:      io.NewIOController(),
+	}
+
+	gitContext := "https://github.com/okteto/movies.git#main:api"
+	options := &types.BuildOptions{
+		CommandArgs: []string{gitContext},
+	}
+
+	expectedOptions := &types.BuildOptions{
+		Path:        gitContext,
+		CommandArgs: []string{gitContext},
+	}
+	buildRunner.On("Run", mock.Anything, expectedOptions, mock.Anything).Return(nil)
+
+	err := bc.Build(ctx, options)
+	assert.NoError(t, err)
+
+	buildRunner.AssertExpectations(t)
+}
+
 func TestBuildWithNoErrorFromDockerfileAndNoTag(t *testing.T) {
 	ctx := context.Background()
 
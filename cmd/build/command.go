@@ -28,9 +28,11 @@ import (
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/build"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/discovery"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -65,6 +67,7 @@ type buildTrackerInterface interface {
 
 type registryInterface interface {
 	GetImageTagWithDigest(imageTag string) (string, error)
+	ResolveImageTagWithDigest(imageTag string) (string, error)
 	IsOktetoRegistry(image string) bool
 	GetImageReference(image string) (registry.OktetoImageReference, error)
 	HasGlobalPushAccess() (bool, error)
@@ -74,6 +77,7 @@ type registryInterface interface {
 	GetRepoNameAndTag(repo string) (string, string)
 	GetDevImageFromGlobal(imageWithDigest string) string
 	Clone(from, to string) (string, error)
+	GetAttestationDigests(image string) ([]string, error)
 }
 
 // NewBuildCommand creates a struct to run all build methods
@@ -97,11 +101,51 @@ const (
 // Build build and optionally push a Docker image
 func Build(ctx context.Context, ioCtrl *io.Controller, at, insights buildTrackerInterface, k8slogger *io.K8sLogger) *cobra.Command {
 	options := &types.BuildOptions{}
+	var buildSecrets []string
 	cmd := &cobra.Command{
 		Use:   "build [service...]",
 		Short: "Build and push the images defined in the 'build' section of your okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.CommandArgs = args
+			options.Secrets = append(options.Secrets, buildSecrets...)
+			if options.File == stdinFile {
+				fs := afero.NewOsFs()
+				file, err := readDockerfileFromStdin(os.Stdin, fs)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := fs.Remove(file); err != nil {
+						ioCtrl.Logger().Infof("failed to remove '%s': %s", file, err)
+					}
+				}()
+				options.File = file
+			}
+			if buildCmd.IsLocalExport(options.Output) {
+				export, err := buildCmd.ParseLocalExport(options.Output)
+				if err != nil {
+					return err
+				}
+				options.LocalExport = export
+				options.Output = oktetoLog.PlainFormat
+			}
+			if err := validateOutput(options.Output); err != nil {
+				return err
+			}
+			provenance, err := buildCmd.ParseProvenanceMode(options.Provenance)
+			if err != nil {
+				return err
+			}
+			options.Provenance = provenance
+			if options.Output == oktetoLog.JSONFormat {
+				// every message is printed as json so the output of the command can be parsed
+				ioCtrl.SetOutputFormat(io.JSONFormat)
+				oktetoLog.SetOutputFormat(oktetoLog.JSONFormat) // TODO: Remove when we fully move to ioController
+				if !cmd.Flags().Changed("progress") {
+					options.OutputMode = oktetoLog.PlainFormat
+				}
+			}
+			model.UseSections(model.BuildSection, model.DeploySection)
 			// The context must be loaded before reading manifest. Otherwise,
 			// secrets will not be resolved when GetManifest is called and
 			// the manifest will load empty values.
@@ -134,18 +178,30 @@ func Build(ctx context.Context, ioCtrl *io.Controller, at, insights buildTracker
 	}
 
 	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the build command is executed")
-	cmd.Flags().StringVarP(&options.File, "file", "f", "", "path to the Okteto Manifest (default is 'okteto.yml')")
+	cmd.Flags().StringVarP(&options.File, "file", "f", "", "path to the Okteto Manifest (default is 'okteto.yml') or to a buildx bake file (.hcl or docker-bake.json). Use '-' to read a Dockerfile from the standard input")
 	cmd.Flags().StringVarP(&options.Tag, "tag", "t", "", "name and optionally a tag in the 'name:tag' format (it is automatically pushed)")
 	cmd.Flags().StringVarP(&options.Target, "target", "", "", "set the target build stage to build")
 	cmd.Flags().BoolVarP(&options.NoCache, "no-cache", "", false, "do not use cache when building the image")
 	cmd.Flags().StringArrayVar(&options.CacheFrom, "cache-from", nil, "cache source images")
 	cmd.Flags().StringArrayVar(&options.ExportCache, "export-cache", nil, "export cache images")
 	cmd.Flags().StringVarP(&options.OutputMode, "progress", "", string(TTYFormat), "show plain/tty build output")
+	cmd.Flags().StringVar(&options.Output, "output", oktetoLog.PlainFormat, "format of the build output: 'plain' or 'json'. 'json' prints an event for each service and a final document with the digests of the images. 'type=oci,dest=image.tar' or 'type=docker,dest=image.tar' write the image to a local tarball instead of pushing it")
 	cmd.Flags().StringArrayVar(&options.BuildArgs, "build-arg", nil, "set build-time variables")
 	cmd.Flags().StringArrayVar(&options.Secrets, "secret", nil, "secret files exposed to the build. Format: id=mysecret,src=/local/secret")
-	cmd.Flags().StringVar(&options.Platform, "platform", "", "set platform if server is multi-platform capable")
+	cmd.Flags().StringArrayVar(&buildSecrets, "build-secret", nil, "secrets exposed to the build without baking them into the image. Format: id=mysecret,src=/local/secret or id=mysecret,env=MY_SECRET")
+	cmd.Flags().StringVar(&options.Platform, "platform", "", "set the platforms of the image, a comma separated list builds a multi-arch image (e.g. linux/amd64,linux/arm64)")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace against which the image will be consumed. Default is the one defined at okteto context or okteto manifest")
 	cmd.Flags().BoolVarP(&options.BuildToGlobal, "global", "", false, "push the image to the global registry")
+	cmd.Flags().StringVar(&options.SBOM, "sbom", "", "attach a software bill of materials to the pushed image as an attestation. Supported formats: spdx")
+	cmd.Flags().StringVar(&options.Provenance, "provenance", "", "attach a SLSA provenance attestation to the pushed image and record it in the pipeline of the manifest. Supported values: mode=min, mode=max")
+	cmd.Flags().Lookup("provenance").NoOptDefVal = "mode=max"
+	cmd.Flags().BoolVarP(&options.Reproducible, "reproducible", "", false, "build the image in reproducible mode: the same source yields the same image digest")
+	cmd.Flags().StringVar(&options.Builder, "builder", "", "backend that builds the images: 'auto', 'buildkit', 'docker' or 'buildx[:<name>]'. 'auto' falls back to the local Docker daemon when the builder of your context is unreachable (default is the one of your context)")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "print the tags, build args, cache configuration and build hash of the images without building them")
+	cmd.Flags().BoolVar(&options.CacheWarm, "cache-warm", false, "build the images only to export their 'export_cache' images, without pushing or tagging them. Images that depend on an image without 'export_cache' fail")
+	cmd.Flags().IntVar(&options.Parallelism, "parallel", 0, "maximum number of images built at the same time, respecting their 'depends_on' (default is 4 or the value of OKTETO_BUILD_PARALLELISM)")
+
+	cmd.AddCommand(Queue(ctx))
 	return cmd
 }
 
@@ -159,6 +215,10 @@ func (bc *Command) getBuilder(options *types.BuildOptions, okCtx *okteto.Context
 	if options.File != "" && isDockerfileValid {
 		return buildv1.NewBuilder(bc.Builder, bc.ioCtrl), nil
 	}
+	// a remote context as argument builds a single image, like a Dockerfile
+	if len(options.CommandArgs) == 1 && build.IsRemoteContext(options.CommandArgs[0]) {
+		return buildv1.NewBuilder(bc.Builder, bc.ioCtrl), nil
+	}
 
 	var builder Builder
 	manifest, err := bc.GetManifest(options.File, afero.NewOsFs())
@@ -193,12 +253,27 @@ func isBuildV2(m *model.Manifest) bool {
 	return m.IsV2 && len(m.Build) != 0
 }
 
+func validateOutput(output string) error {
+	switch output {
+	case "", oktetoLog.PlainFormat, oktetoLog.JSONFormat:
+		return nil
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value '%s' for the '--output' flag", output),
+			Hint: "Supported values are 'plain', 'json', 'type=oci,dest=<path>' and 'type=docker,dest=<path>'",
+		}
+	}
+}
+
 func validateDockerfile(file string) error {
 	dat, err := os.ReadFile(file)
 	if err != nil {
 		return err
 	}
+	return validateDockerfileContent(dat)
+}
 
+func validateDockerfileContent(dat []byte) error {
 	parsedDockerfile, err := parser.Parse(bytes.NewBuffer(dat))
 	if err != nil {
 		return err
@@ -65,10 +65,15 @@ func (fr fakeRegistry) GetImageTagWithDigest(imageTag string) (string, error) {
 	}
 	return imageTag, nil
 }
+
+func (fr fakeRegistry) ResolveImageTagWithDigest(imageTag string) (string, error) {
+	return fr.GetImageTagWithDigest(imageTag)
+}
 func (fr fakeRegistry) IsOktetoRegistry(_ string) bool { return false }
 func (fr fakeRegistry) Clone(from, to string) (string, error) {
 	return from, nil
 }
+func (fr fakeRegistry) GetAttestationDigests(_ string) ([]string, error) { return nil, nil }
 
 func (fr fakeRegistry) AddImageByName(images ...string) error {
 	if fr.errAddImageByName != nil {
@@ -486,3 +491,44 @@ func Test_defaultOktetoClientCfg(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		expectErr bool
+	}{
+		{
+			name:   "empty",
+			output: "",
+		},
+		{
+			name:   "plain",
+			output: "plain",
+		},
+		{
+			name:   "json",
+			output: "json",
+		},
+		{
+			name:      "tty",
+			output:    "tty",
+			expectErr: true,
+		},
+		{
+			name:      "unknown",
+			output:    "yaml",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOutput(tt.output)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
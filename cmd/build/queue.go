@@ -0,0 +1,114 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type queueOptions struct {
+	k8sContext string
+	namespace  string
+	output     string
+}
+
+type queueOutput struct {
+	Running       []buildCmd.RunningBuild `json:"running" yaml:"running"`
+	EstimatedWait string                  `json:"estimatedWait" yaml:"estimatedWait"`
+}
+
+// Queue shows the builds running in the builder of the current context
+func Queue(ctx context.Context) *cobra.Command {
+	options := &queueOptions{}
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Show the builds running in the builder and the estimated wait for a new build",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#build"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.output != "" && options.output != "json" && options.output != "yaml" {
+				return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+			}
+			oktetoContext, err := getOktetoContext(ctx, &types.BuildOptions{
+				K8sContext: options.k8sContext,
+				Namespace:  options.namespace,
+			})
+			if err != nil {
+				return err
+			}
+			queue, err := buildCmd.GetBuildQueue(ctx, oktetoContext)
+			if err != nil {
+				return err
+			}
+			return showQueue(queue, options.output, time.Now(), os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&options.k8sContext, "context", "c", "", "context where the builder is configured")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", "", "namespace used to authenticate with the builder")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	return cmd
+}
+
+func showQueue(queue *buildCmd.BuildQueue, output string, now time.Time, w io.Writer) error {
+	out := queueOutput{
+		Running:       queue.Running,
+		EstimatedWait: queue.EstimatedWait(now).Round(time.Second).String(),
+	}
+
+	switch output {
+	case "json":
+		bytes, err := json.MarshalIndent(out, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(out)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	default:
+		if len(queue.Running) == 0 {
+			oktetoLog.Success("The builder is idle, new builds start immediately")
+			return nil
+		}
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, "Image\tRunning for\tSteps")
+		for _, b := range queue.Running {
+			image := b.Image
+			if image == "" {
+				image = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d/%d\n", image, now.Sub(b.StartedAt).Round(time.Second), b.CompletedSteps, b.TotalSteps)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\n%d builds running in the builder, estimated time until they complete: %s\n", len(queue.Running), out.EstimatedWait)
+	}
+	return nil
+}
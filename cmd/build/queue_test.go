@@ -0,0 +1,49 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowQueue(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	queue := &buildCmd.BuildQueue{
+		Running: []buildCmd.RunningBuild{
+			{Ref: "a", Image: "okteto.dev/api:dev", StartedAt: now.Add(-time.Minute), TotalSteps: 10, CompletedSteps: 4},
+			{Ref: "b", StartedAt: now.Add(-10 * time.Second), TotalSteps: 5},
+		},
+		AverageDuration: 3 * time.Minute,
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, showQueue(queue, "", now, &out))
+	expected := `Image               Running for  Steps
+okteto.dev/api:dev  1m0s         4/10
+-                   10s          0/5
+
+2 builds running in the builder, estimated time until they complete: 2m50s
+`
+	assert.Equal(t, expected, out.String())
+
+	out.Reset()
+	require.NoError(t, showQueue(queue, "json", now, &out))
+	assert.Contains(t, out.String(), `"estimatedWait": "2m50s"`)
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/okteto/okteto/pkg/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// stdinFile is the value of the file flag to read the Dockerfile from the standard input
+const stdinFile = "-"
+
+// readDockerfileFromStdin writes the Dockerfile read from r to a temporary file, so toolchains that
+// generate Dockerfiles don't need to write them into the repository, and returns its path
+func readDockerfileFromStdin(r io.Reader, fs afero.Fs) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the Dockerfile from the standard input: %w", err)
+	}
+	if err := validateDockerfileContent(content); err != nil {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("the standard input is not a valid Dockerfile: %w", err),
+			Hint: "Pipe a Dockerfile to the command, e.g. 'okteto build -f - < Dockerfile'",
+		}
+	}
+	return build.CreateDockerfileFromContent(string(content), fs)
+}
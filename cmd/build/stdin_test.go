@@ -0,0 +1,43 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readDockerfileFromStdin(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	oktetoHome, err := filepath.Abs("./tmp/tests")
+	require.NoError(t, err)
+	require.NoError(t, fs.MkdirAll(oktetoHome, 0700))
+	t.Setenv(constants.OktetoFolderEnvVar, oktetoHome)
+
+	file, err := readDockerfileFromStdin(strings.NewReader("FROM alpine\nRUN echo generated\n"), fs)
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fs, file)
+	require.NoError(t, err)
+	assert.Equal(t, "FROM alpine\nRUN echo generated\n", string(content))
+
+	_, err = readDockerfileFromStdin(strings.NewReader("deploy:\n  - echo hello\n"), fs)
+	assert.ErrorContains(t, err, "not a valid Dockerfile")
+}
@@ -15,9 +15,12 @@ package v1
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/okteto/okteto/cmd/build/basic"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
@@ -59,5 +62,23 @@ func (*OktetoBuilder) IsV1() bool {
 
 // Build builds the images defined by a Dockerfile
 func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions) error {
+	if options.DryRun {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the '--dry-run' flag is only supported when building the images defined in the 'build' section of an okteto manifest"),
+			Hint: "Run 'okteto build --dry-run' from the folder of your okteto manifest or use '--file' to point to it",
+		}
+	}
+	if options.CacheWarm {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the '--cache-warm' flag is only supported when building the images defined in the 'build' section of an okteto manifest"),
+			Hint: "Run 'okteto build --cache-warm' from the folder of your okteto manifest or use '--file' to point to it",
+		}
+	}
+	if options.Output == oktetoLog.JSONFormat {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the '--output json' flag is only supported when building the images defined in the 'build' section of an okteto manifest"),
+			Hint: "Run 'okteto build --output json' from the folder of your okteto manifest or use '--file' to point to it",
+		}
+	}
 	return ob.Builder.Build(ctx, options)
 }
@@ -143,6 +143,26 @@ func TestBuildWithNoErrorFromDockerfileAndNoTag(t *testing.T) {
 	buildRunner.AssertExpectations(t)
 }
 
+func TestBuildWithCacheWarm(t *testing.T) {
+	buildRunner := &fakeBuildRunner{}
+	bc := NewBuilder(buildRunner, io.NewIOController())
+
+	err := bc.Build(context.Background(), &types.BuildOptions{CacheWarm: true})
+	assert.ErrorContains(t, err, "'--cache-warm'")
+
+	buildRunner.AssertNotCalled(t, "Run", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBuildWithJSONOutput(t *testing.T) {
+	buildRunner := &fakeBuildRunner{}
+	bc := NewBuilder(buildRunner, io.NewIOController())
+
+	err := bc.Build(context.Background(), &types.BuildOptions{Output: "json"})
+	assert.ErrorContains(t, err, "'--output json'")
+
+	buildRunner.AssertNotCalled(t, "Run", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestIsV1(t *testing.T) {
 	bc := &OktetoBuilder{}
 	assert.True(t, bc.IsV1())
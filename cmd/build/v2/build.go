@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,11 +29,13 @@ import (
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/build"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -44,6 +47,7 @@ import (
 
 type oktetoRegistryInterface interface {
 	GetImageTagWithDigest(imageTag string) (string, error)
+	ResolveImageTagWithDigest(imageTag string) (string, error)
 	IsOktetoRegistry(image string) bool
 	GetImageReference(image string) (registry.OktetoImageReference, error)
 	HasGlobalPushAccess() (bool, error)
@@ -53,6 +57,7 @@ type oktetoRegistryInterface interface {
 	GetRepoNameAndTag(repo string) (string, string)
 	GetDevImageFromGlobal(imageWithDigest string) string
 	Clone(from, to string) (string, error)
+	GetAttestationDigests(image string) ([]string, error)
 }
 
 // oktetoBuilderConfigInterface returns the configuration that the builder has for the registry and project
@@ -82,6 +87,20 @@ type OktetoBuilder struct {
 
 	onBuildFinish []OnBuildFinish
 
+	// newSigner returns the signer of the images of the services with 'sign' enabled
+	newSigner func() (imageSigner, error)
+
+	// k8sClientProvider provides the client used to record the attestations in the pipeline. It is nil to use the okteto context
+	k8sClientProvider okteto.K8sClientProvider
+
+	// runCustomBuilderCmd runs the commands of the custom builders. It is nil to run them with the CLIs installed locally
+	runCustomBuilderCmd customBuilderRunner
+
+	// tagStrategy is the tag strategy of the okteto context, used when the manifest doesn't define one
+	tagStrategy string
+	// buildTime is the time used by the branch-timestamp tag strategy, shared by all the images of the build
+	buildTime time.Time
+
 	// lock is a mutex to provide buildEnvironments map safe concurrency
 	lock sync.RWMutex
 }
@@ -110,6 +129,8 @@ func NewBuilder(builder buildCmd.OktetoBuilderInterface, registry oktetoRegistry
 		oktetoContext:     okCtx,
 		k8sLogger:         k8sLogger,
 		onBuildFinish:     onBuildFinish,
+		tagStrategy:       okCtx.GetTagStrategy(),
+		buildTime:         time.Now(),
 	}
 }
 
@@ -151,6 +172,8 @@ func NewBuilderFromScratch(ioCtrl *io.Controller, onBuildFinish []OnBuildFinish)
 		ioCtrl:            ioCtrl,
 		smartBuildCtrl:    smartbuild.NewSmartBuildCtrl(gitRepo, reg, config.fs, ioCtrl),
 		oktetoContext:     okCtx,
+		tagStrategy:       okCtx.GetTagStrategy(),
+		buildTime:         time.Now(),
 
 		onBuildFinish: onBuildFinish,
 	}
@@ -200,12 +223,22 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 
 	buildManifest := options.Manifest.Build
 
-	// builtImagesControl represents the controller for the built services
-	// when a service is built we track it here
-	builtImagesControl := make(map[string]bool)
+	// the platform flag overrides the platforms of the manifest so smart builds don't reuse images of other platforms
+	if options.Platform != "" {
+		platforms := build.ParsePlatforms(options.Platform)
+		for _, svc := range toBuildSvcs {
+			buildManifest[svc].Platforms = platforms
+		}
+	}
+
+	if options.DryRun {
+		return ob.dryRun(ctx, toBuildSvcs, options)
+	}
 
 	// send analytics for all builds after Build
 	buildsAnalytics := make([]*analytics.ImageBuildMetadata, 0)
+	// analyticsLock protects buildsAnalytics from the services built at the same time
+	analyticsLock := sync.Mutex{}
 
 	// send all events appended on each build
 	defer func([]*analytics.ImageBuildMetadata) {
@@ -217,111 +250,148 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		}
 	}(buildsAnalytics)
 
-	ob.ioCtrl.Logger().Infof("Images to build: [%s]", strings.Join(toBuildSvcs, ", "))
-	for len(builtImagesControl) != len(toBuildSvcs) {
-		for _, svcToBuild := range toBuildSvcs {
-			// This verifies if the image was already built in this iteration, not checking if it is in the "cache" (global registry)
-			if skipServiceBuild(svcToBuild, builtImagesControl) {
-				ob.ioCtrl.Logger().Infof("skipping image '%s' due to being already built", svcToBuild)
-				continue
-			}
-			if !areAllServicesBuilt(buildManifest[svcToBuild].DependsOn, builtImagesControl) {
-				ob.ioCtrl.Logger().Infof("image '%s' can't be deployed because at least one of its dependent images(%s) are not built", svcToBuild, strings.Join(buildManifest[svcToBuild].DependsOn, ", "))
-				continue
-			}
-			if options.EnableStages {
-				ob.ioCtrl.SetStage(fmt.Sprintf("Building service %s", svcToBuild))
-			}
+	// there are never more builds running at the same time than images to build
+	parallelism := min(getBuildParallelism(options.Parallelism, ob.ioCtrl.Logger()), len(toBuildSvcs))
+	if parallelism > 1 {
+		// the tty progress of several builds at the same time can't be displayed
+		if options.OutputMode == "" || options.OutputMode == oktetoLog.TTYFormat {
+			options.OutputMode = oktetoLog.PlainFormat
+		}
+		if options.EnableStages {
+			ob.ioCtrl.SetStage("Building services")
+		}
+	}
 
-			buildSvcInfo := buildManifest[svcToBuild]
+	reporter := newBuildReporter(options.Output, os.Stdout)
 
-			// create the meta pointer and append it to the analytics slice
-			meta := analytics.NewImageBuildMetadata()
-			buildsAnalytics = append(buildsAnalytics, meta)
+	// attestations are the provenance attestations of the images built, by service
+	attestations := map[string]pipeline.Attestation{}
+	attestationsLock := sync.Mutex{}
 
-			meta.Name = svcToBuild
-			meta.Namespace = ob.oktetoContext.GetNamespace()
-			meta.DevenvName = options.Manifest.Name
-			meta.RepoURL = ob.Config.GetAnonymizedRepo()
+	ob.ioCtrl.Logger().Infof("Images to build: [%s], parallelism: %d", strings.Join(toBuildSvcs, ", "), parallelism)
+	err := buildInDependencyOrder(ctx, toBuildSvcs, buildManifest, parallelism, func(ctx context.Context, svcToBuild string, hasRebuiltDependencies bool) (bool, error) {
+		if options.CacheWarm && len(buildManifest[svcToBuild].ExportCache) == 0 {
+			ob.ioCtrl.Out().Warning("Skipping '%s': it doesn't define 'export_cache' images to warm", svcToBuild)
+			return false, errSvcSkipped
+		}
 
-			repoHashDurationStart := time.Now()
+		if options.EnableStages && parallelism == 1 {
+			ob.ioCtrl.SetStage(fmt.Sprintf("Building service %s", svcToBuild))
+		}
 
-			ob.ioCtrl.Logger().Debugf("getting project hash for analytics")
-			repoHash, err := ob.smartBuildCtrl.GetProjectHash(buildSvcInfo)
-			if err != nil {
-				ob.ioCtrl.Logger().Infof("error getting project commit hash: %s", err)
-			}
-			meta.RepoHash = repoHash
-			meta.RepoHashDuration = time.Since(repoHashDurationStart)
+		svcDurationStart := time.Now()
+		reporter.started(svcToBuild)
+
+		buildSvcInfo := buildManifest[svcToBuild]
+
+		// create the meta pointer and append it to the analytics slice
+		meta := analytics.NewImageBuildMetadata()
+		analyticsLock.Lock()
+		buildsAnalytics = append(buildsAnalytics, meta)
+		analyticsLock.Unlock()
+
+		meta.Name = svcToBuild
+		meta.Namespace = ob.oktetoContext.GetNamespace()
+		meta.DevenvName = options.Manifest.Name
+		meta.RepoURL = ob.Config.GetAnonymizedRepo()
+
+		repoHashDurationStart := time.Now()
+
+		ob.ioCtrl.Logger().Debugf("getting project hash for analytics")
+		repoHash, err := ob.smartBuildCtrl.GetProjectHash(buildSvcInfo)
+		if err != nil {
+			ob.ioCtrl.Logger().Infof("error getting project commit hash: %s", err)
+		}
+		meta.RepoHash = repoHash
+		meta.RepoHashDuration = time.Since(repoHashDurationStart)
 
-			buildContextHashDurationStart := time.Now()
+		buildContextHashDurationStart := time.Now()
 
-			serviceHash := ob.smartBuildCtrl.GetServiceHash(buildSvcInfo, svcToBuild)
-			meta.BuildContextHash = serviceHash
-			meta.BuildContextHashDuration = time.Since(buildContextHashDurationStart)
+		serviceHash := ob.smartBuildCtrl.GetServiceHash(buildSvcInfo, svcToBuild)
+		meta.BuildContextHash = serviceHash
+		meta.BuildContextHashDuration = time.Since(buildContextHashDurationStart)
 
-			// We only check that the image is built in the global registry if the noCache option is not set
-			if !options.NoCache && ob.smartBuildCtrl.IsEnabled() {
-				imageChecker := getImageChecker(ob.Config, ob.Registry, ob.smartBuildCtrl, ob.ioCtrl.Logger())
-				cacheHitDurationStart := time.Now()
+		// The image of a service can't be reused if any of the images it depends on has been rebuilt
+		if hasRebuiltDependencies {
+			ob.ioCtrl.Logger().Infof("image '%s' has to be rebuilt because at least one of its dependent images has been rebuilt", svcToBuild)
+		}
 
-				buildHash := ob.smartBuildCtrl.GetBuildHash(buildSvcInfo, svcToBuild)
-				imageCtrl := registry.NewImageCtrl(ob.oktetoContext)
-				imageWithDigest, isBuilt := imageChecker.checkIfBuildHashIsBuilt(buildSvcInfo.Image, ob.oktetoContext.GetNamespace(), ob.oktetoContext.GetRegistryURL(), options.Manifest.Name, svcToBuild, buildHash, imageCtrl)
+		// We only check that the image is built in the global registry if the noCache option is not set.
+		// Images reused from cache might not have the SBOM or provenance attestations. Cache warm and exported builds always run to produce their outputs
+		if !options.NoCache && options.SBOM == "" && options.Provenance == "" && !options.CacheWarm && options.LocalExport == nil && ob.smartBuildCtrl.IsEnabled() && !hasRebuiltDependencies {
+			imageChecker := getImageChecker(ob.Config, ob.Registry, ob.smartBuildCtrl, ob.ioCtrl.Logger())
+			cacheHitDurationStart := time.Now()
 
-				meta.CacheHit = isBuilt
-				meta.CacheHitDuration = time.Since(cacheHitDurationStart)
+			buildHash := ob.smartBuildCtrl.GetBuildHash(buildSvcInfo, svcToBuild)
+			imageCtrl := registry.NewImageCtrl(ob.oktetoContext)
+			imageWithDigest, isBuilt := imageChecker.checkIfBuildHashIsBuilt(buildSvcInfo.Image, ob.oktetoContext.GetNamespace(), ob.oktetoContext.GetRegistryURL(), options.Manifest.Name, svcToBuild, buildHash, imageCtrl)
 
-				if isBuilt {
-					ob.ioCtrl.Out().Infof("Okteto Smart Builds is skipping build of '%s' because it's already built from cache.", svcToBuild)
+			meta.CacheHit = isBuilt
+			meta.CacheHitDuration = time.Since(cacheHitDurationStart)
 
-					imageWithDigest, err := ob.smartBuildCtrl.CloneGlobalImageToDev(imageWithDigest)
-					if err != nil {
-						return err
-					}
+			if isBuilt {
+				ob.ioCtrl.Out().Infof("Okteto Smart Builds is skipping build of '%s' because it's already built from cache.", svcToBuild)
 
-					ob.SetServiceEnvVars(svcToBuild, imageWithDigest)
-					builtImagesControl[svcToBuild] = true
-					meta.Success = true
-					continue
+				imageWithDigest, err := ob.smartBuildCtrl.CloneGlobalImageToDev(imageWithDigest)
+				if err != nil {
+					reporter.failed(svcToBuild, err, time.Since(svcDurationStart))
+					return false, err
 				}
+
+				ob.SetServiceEnvVars(svcToBuild, imageWithDigest)
+				meta.Success = true
+				reporter.built(svcToBuild, imageWithDigest, true, time.Since(svcDurationStart))
+				return false, nil
 			}
+		}
 
-			if !ob.oktetoContext.IsOktetoCluster() && buildSvcInfo.Image == "" {
-				return fmt.Errorf("'build.%s.image' is required if your context doesn't have Okteto installed", svcToBuild)
+		if !ob.oktetoContext.IsOktetoCluster() && buildSvcInfo.Image == "" {
+			err := oktetoErrors.UserError{
+				E:    fmt.Errorf("'build.%s.image' is required if your context doesn't have Okteto installed", svcToBuild),
+				Hint: "Set it to an image name in a registry your cluster can pull from. The image is built with your local Docker daemon, or with the BuildKit instance set by 'okteto context use --builder'",
 			}
-			buildDurationStart := time.Now()
-			imageTag, err := ob.buildServiceImages(ctx, options.Manifest, svcToBuild, options)
-			if err != nil {
-				return fmt.Errorf("error building service '%s': %w", svcToBuild, err)
+			reporter.failed(svcToBuild, err, time.Since(svcDurationStart))
+			return false, err
+		}
+		buildDurationStart := time.Now()
+		imageTag, err := ob.buildServiceImages(ctx, options.Manifest, svcToBuild, options)
+		if err != nil {
+			reporter.failed(svcToBuild, err, time.Since(svcDurationStart))
+			return false, fmt.Errorf("error building service '%s': %w", svcToBuild, err)
+		}
+		meta.BuildDuration = time.Since(buildDurationStart)
+		meta.Success = true
+
+		ob.SetServiceEnvVars(svcToBuild, imageTag)
+		if options.Provenance != "" && !options.CacheWarm && options.LocalExport == nil {
+			if attestation, ok := ob.getAttestation(svcToBuild, imageTag); ok {
+				attestationsLock.Lock()
+				attestations[svcToBuild] = attestation
+				attestationsLock.Unlock()
 			}
-			meta.BuildDuration = time.Since(buildDurationStart)
-			meta.Success = true
-
-			ob.SetServiceEnvVars(svcToBuild, imageTag)
-			builtImagesControl[svcToBuild] = true
 		}
+		reporter.built(svcToBuild, imageTag, false, time.Since(svcDurationStart))
+		return true, nil
+	})
+	if err != nil {
+		return err
 	}
+	ob.recordAttestations(ctx, options.Manifest.Name, attestations)
+	reporter.result()
 	if options.EnableStages {
 		ob.ioCtrl.SetStage("")
 	}
 	return options.Manifest.ExpandEnvVars()
 }
 
-// areServicesBuilt compares the list of services with the built control
-// when all services are built returns true, when a service is still pending it will return false
-func areAllServicesBuilt(services []string, control map[string]bool) bool {
+// areAnyServicesRebuilt returns true if any of the services has been rebuilt
+func areAnyServicesRebuilt(services []string, rebuilt map[string]bool) bool {
 	for _, service := range services {
-		if _, ok := control[service]; !ok {
-			return false
+		if rebuilt[service] {
+			return true
 		}
 	}
-	return true
-}
-
-// skipServiceBuild returns if a service has been built
-func skipServiceBuild(service string, control map[string]bool) bool {
-	return control[service]
+	return false
 }
 
 // buildServiceImages builds the images for the given service.
@@ -331,7 +401,10 @@ func (bc *OktetoBuilder) buildServiceImages(ctx context.Context, manifest *model
 	buildSvcInfo := manifest.Build[svcName]
 
 	switch {
-	case serviceHasDockerfile(buildSvcInfo):
+	case buildSvcInfo.CustomBuilder != nil && buildSvcInfo.CustomBuilder.Type != build.NixpacksBuilder:
+		return bc.buildSvcWithCustomBuilder(ctx, manifest, svcName, options)
+
+	case serviceHasDockerfile(buildSvcInfo) || buildSvcInfo.CustomBuilder != nil:
 		return bc.buildSvcFromDockerfile(ctx, manifest, svcName, options)
 
 	default:
@@ -342,32 +415,77 @@ func (bc *OktetoBuilder) buildServiceImages(ctx context.Context, manifest *model
 
 func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *model.Manifest, svcName string, options *types.BuildOptions) (string, error) {
 	bc.ioCtrl.Logger().Info(fmt.Sprintf("Building service '%s' from Dockerfile", svcName))
-	isStackManifest := manifest.Type == model.StackType
-	buildSvcInfo := bc.getBuildInfoWithoutVolumeMounts(manifest.Build[svcName], isStackManifest)
-	var buildHash string
-	if bc.smartBuildCtrl.IsEnabled() {
-		buildHash = bc.smartBuildCtrl.GetBuildHash(buildSvcInfo, svcName)
+	buildSvcInfo, _, err := bc.getSvcBuildInfo(manifest, svcName)
+	if err != nil {
+		return "", err
 	}
-	it := newImageTagger(bc.Config, bc.smartBuildCtrl)
-	tagsToBuild := it.getServiceDevImageReference(manifest.Name, svcName, buildSvcInfo)
-	imageCtrl := registry.NewImageCtrl(bc.oktetoContext)
-	globalImage := it.getGlobalTagFromDevIfNeccesary(tagsToBuild, bc.oktetoContext.GetNamespace(), bc.oktetoContext.GetRegistryURL(), buildHash, imageCtrl)
-	if globalImage != "" {
-		tagsToBuild = fmt.Sprintf("%s,%s", tagsToBuild, globalImage)
+
+	if buildSvcInfo.DockerfileInline != "" {
+		fs := afero.NewOsFs()
+		buildSvcInfo.Dockerfile, err = build.CreateDockerfileFromContent(buildSvcInfo.DockerfileInline, fs)
+		if err != nil {
+			return "", fmt.Errorf("failed to create the inline Dockerfile of service '%s': %w", svcName, err)
+		}
+		defer func() {
+			if err := fs.Remove(buildSvcInfo.Dockerfile); err != nil {
+				bc.ioCtrl.Logger().Infof("failed to remove '%s': %s", buildSvcInfo.Dockerfile, err)
+			}
+		}()
 	}
-	buildSvcInfo.Image = tagsToBuild
-	if err := buildSvcInfo.AddArgs(bc.buildEnvironments); err != nil {
-		return "", fmt.Errorf("error expanding build args from service '%s': %w", svcName, err)
+
+	if buildSvcInfo.CustomBuilder != nil && buildSvcInfo.CustomBuilder.Type == build.NixpacksBuilder {
+		buildSvcInfo.Dockerfile, err = bc.generateNixpacksDockerfile(ctx, svcName, buildSvcInfo)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate the Dockerfile of service '%s': %w", svcName, err)
+		}
+		defer func() {
+			if err := os.RemoveAll(filepath.Dir(buildSvcInfo.Dockerfile)); err != nil {
+				bc.ioCtrl.Logger().Infof("failed to remove '%s': %s", filepath.Dir(buildSvcInfo.Dockerfile), err)
+			}
+		}()
+	}
+
+	if buildSvcInfo.TestTarget != "" {
+		if err := bc.runTestTarget(ctx, afero.NewOsFs(), manifest.Name, svcName, buildSvcInfo, options); err != nil {
+			return "", err
+		}
 	}
 
 	buildOptions := buildCmd.OptsFromBuildInfo(manifest.Name, svcName, buildSvcInfo, options, bc.Registry, bc.oktetoContext)
+	if buildOptions.Provenance != "" {
+		buildOptions.VCSRevision = bc.Config.GetGitCommit()
+	}
 
 	if err := bc.Builder.Build(ctx, buildOptions); err != nil {
 		return "", err
 	}
-	var imageTagWithDigest string
 	tags := strings.Split(buildOptions.Tag, ",")
 
+	// cache warm and exported builds don't push the images: the services that depend on this one use the tag it would have
+	if options.CacheWarm || options.LocalExport != nil {
+		return tags[0], nil
+	}
+	return bc.checkPushedImages(ctx, svcName, buildSvcInfo, tags)
+}
+
+// checkPushedImages signs the pushed images of a service if needed and returns the first tag with its digest
+func (bc *OktetoBuilder) checkPushedImages(ctx context.Context, svcName string, buildSvcInfo *build.Info, tags []string) (string, error) {
+	var imageTagWithDigest string
+	if buildSvcInfo.Sign {
+		// signatures are attached to the digest: cosign can't resolve the okteto.dev alias and tags are mutable
+		references := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			reference, err := bc.Registry.ResolveImageTagWithDigest(tag)
+			if err != nil {
+				return "", fmt.Errorf("error accessing image at registry %s: %w", tag, err)
+			}
+			references = append(references, reference)
+		}
+		if err := bc.signImages(ctx, svcName, references, buildSvcInfo.SignKey); err != nil {
+			return "", err
+		}
+	}
+
 	// check that all tags are pushed and return the first one to not break any scenario
 	for idx, tag := range tags {
 		// check if the image is pushed to the dev registry if DevTag is set
@@ -383,9 +501,37 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	return imageTagWithDigest, nil
 }
 
-// serviceHasDockerfile returns true when service BuildInfo Dockerfile is not empty
+// getSvcBuildInfo returns the build info of a service with the tags to build and the build args resolved,
+// and the build hash used by smart builds (empty if smart builds are disabled)
+func (bc *OktetoBuilder) getSvcBuildInfo(manifest *model.Manifest, svcName string) (*build.Info, string, error) {
+	isStackManifest := manifest.Type == model.StackType
+	buildSvcInfo := bc.getBuildInfoWithoutVolumeMounts(manifest.Build[svcName], isStackManifest)
+	var buildHash string
+	if bc.smartBuildCtrl.IsEnabled() {
+		buildHash = bc.smartBuildCtrl.GetBuildHash(buildSvcInfo, svcName)
+	}
+	it := bc.getImageTagger(manifest, svcName, buildSvcInfo, buildHash)
+	tagsToBuild := it.getServiceDevImageReference(manifest.Name, svcName, buildSvcInfo)
+	if bc.shouldCheckBuildProvenance(svcName, manifest.Build[svcName], buildSvcInfo) {
+		// the build hash tag allows to reuse the image in deploy operations only if it was built from the same sources
+		tagsToBuild = fmt.Sprintf("%s,%s", tagsToBuild, it.getServiceDevImageReferenceForHash(manifest.Name, svcName, buildHash))
+	}
+	imageCtrl := registry.NewImageCtrl(bc.oktetoContext)
+	globalImage := it.getGlobalTagFromDevIfNeccesary(tagsToBuild, bc.oktetoContext.GetNamespace(), bc.oktetoContext.GetRegistryURL(), buildHash, imageCtrl)
+	if globalImage != "" {
+		tagsToBuild = fmt.Sprintf("%s,%s", tagsToBuild, globalImage)
+	}
+	buildSvcInfo.Image = tagsToBuild
+	if err := buildSvcInfo.AddArgs(bc.GetBuildEnvVars()); err != nil {
+		return nil, "", fmt.Errorf("error expanding build args from service '%s': %w", svcName, err)
+	}
+	return buildSvcInfo, buildHash, nil
+}
+
+// serviceHasDockerfile returns true when service BuildInfo Dockerfile is not empty.
+// The Dockerfile of a remote context defaults to the one at its root
 func serviceHasDockerfile(buildInfo *build.Info) bool {
-	return buildInfo.Dockerfile != ""
+	return buildInfo.Dockerfile != "" || buildInfo.DockerfileInline != "" || build.IsRemoteContext(buildInfo.Context)
 }
 
 func (bc *OktetoBuilder) getBuildInfoWithoutVolumeMounts(buildInfo *build.Info, isStackManifest bool) *build.Info {
@@ -422,7 +568,7 @@ func validateOptions(manifest *model.Manifest, svcsToBuild []string, options *ty
 		return err
 	}
 
-	if len(svcsToBuild) != 1 && (options.Tag != "" || options.Target != "" || options.CacheFrom != nil || options.Secrets != nil) {
+	if len(svcsToBuild) != 1 && (options.Tag != "" || options.Target != "" || options.CacheFrom != nil || options.Secrets != nil || options.LocalExport != nil) {
 		return oktetoErrors.ErrNoFlagAllowedOnSingleImageBuild
 	}
 
@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,7 +26,9 @@ import (
 	"github.com/okteto/okteto/cmd/build/v2/smartbuild"
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cache"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
@@ -87,6 +90,8 @@ type fakeImage struct {
 	Tag      string
 	ImageRef string
 	Args     []string
+	// Attestations are the digests of the attestation manifests of the image
+	Attestations []string
 }
 
 func newFakeRegistry() fakeRegistry {
@@ -103,6 +108,13 @@ func (fr fakeRegistry) GetImageTagWithDigest(imageTag string) (string, error) {
 	}
 	return imageTag, nil
 }
+
+func (fr fakeRegistry) ResolveImageTagWithDigest(imageTag string) (string, error) {
+	if _, ok := fr.registry[imageTag]; !ok {
+		return "", oktetoErrors.ErrNotFound
+	}
+	return strings.Replace(imageTag, "okteto.dev", "registry.okteto.example.com", 1) + "@sha256:0123", nil
+}
 func (fr fakeRegistry) IsOktetoRegistry(_ string) bool { return false }
 
 func (fr fakeRegistry) AddImageByName(images ...string) error {
@@ -125,6 +137,9 @@ func (fr fakeRegistry) AddImageByOpts(opts *types.BuildOptions) error {
 func (fr fakeRegistry) Clone(from, to string) (string, error) {
 	return from, nil
 }
+func (fr fakeRegistry) GetAttestationDigests(image string) ([]string, error) {
+	return fr.registry[image].Attestations, nil
+}
 func (fr fakeRegistry) getFakeImage(image string) fakeImage {
 	v, ok := fr.registry[image]
 	if ok {
@@ -461,114 +476,201 @@ func TestBuildWithDependsOn(t *testing.T) {
 
 }
 
-func Test_areAllServicesBuilt(t *testing.T) {
-	tests := []struct {
-		name     string
-		control  map[string]bool
-		input    []string
-		expected bool
-	}{
-		{
-			name:     "all built",
-			expected: true,
-			input:    []string{"one", "two", "three"},
-			control: map[string]bool{
-				"one":   true,
-				"two":   true,
-				"three": true,
-			},
-		},
-		{
-			name:     "none built",
-			expected: false,
-			input:    []string{"one", "two", "three"},
-			control:  map[string]bool{},
-		},
-		{
-			name:     "some built",
-			expected: false,
-			input:    []string{"one", "two", "three"},
-			control: map[string]bool{
-				"one": true,
-				"two": true,
-			},
-		},
-		{
-			name:     "nil control",
-			expected: false,
-			input:    []string{"one", "two", "three"},
-		},
-		{
-			name:     "nil input",
-			expected: true,
-			control: map[string]bool{
-				"one": true,
-				"two": true,
-			},
-		},
-		{
-			name:     "empty input",
-			expected: true,
-			input:    []string{},
-			control: map[string]bool{
-				"one": true,
-				"two": true,
+func TestBuildWithRemoteContext(t *testing.T) {
+	image := "okteto/a:test"
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"a": &build.Info{
+				Context: "https://github.com/okteto/movies.git#main:api",
+				Image:   image,
 			},
 		},
 	}
+	err := bc.Build(context.Background(), &types.BuildOptions{
+		Manifest: manifest,
+	})
+	assert.NoError(t, err)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := areAllServicesBuilt(tt.input, tt.control)
-			require.Equal(t, tt.expected, got)
-		})
+	_, err = registry.GetImageTagWithDigest(image)
+	assert.NoError(t, err)
+}
+
+// dockerfileRecorderRegistry records the content of the Dockerfile used to build each image
+type dockerfileRecorderRegistry struct {
+	fakeRegistry
+	dockerfiles map[string]string
+	files       map[string]string
+}
 
+func (fr dockerfileRecorderRegistry) AddImageByOpts(opts *types.BuildOptions) error {
+	content, err := os.ReadFile(opts.File)
+	if err != nil {
+		return err
 	}
+	fr.dockerfiles[opts.Tag] = string(content)
+	fr.files[opts.Tag] = opts.File
+	return fr.fakeRegistry.AddImageByOpts(opts)
 }
 
-func Test_skipServiceBuild(t *testing.T) {
-	tests := []struct {
-		name     string
-		control  map[string]bool
-		input    string
-		expected bool
-	}{
-		{
-			name:     "is built",
-			expected: true,
-			input:    "one",
-			control: map[string]bool{
-				"one":   true,
-				"two":   true,
-				"three": true,
+func TestBuildWithDockerfileInline(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	image := "okteto/a:test"
+	registry := dockerfileRecorderRegistry{
+		fakeRegistry: newFakeRegistry(),
+		dockerfiles:  map[string]string{},
+		files:        map[string]string{},
+	}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"a": &build.Info{
+				Context:          t.TempDir(),
+				DockerfileInline: "FROM alpine\nRUN echo inline\n",
+				Image:            image,
 			},
 		},
-		{
-			name:     "not built",
-			expected: false,
-			input:    "one",
-			control:  map[string]bool{},
-		},
-		{
-			name:     "nil control",
-			expected: false,
-			input:    "one",
+	}
+	err := bc.Build(context.Background(), &types.BuildOptions{
+		Manifest: manifest,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "FROM alpine\nRUN echo inline\n", registry.dockerfiles[image])
+	assert.NoFileExists(t, registry.files[image])
+	assert.Empty(t, manifest.Build["a"].Dockerfile)
+}
+
+// noDigestRegistry fails when the builder checks the digest of the images
+type noDigestRegistry struct {
+	fakeRegistry
+}
+
+func (noDigestRegistry) GetImageTagWithDigest(imageTag string) (string, error) {
+	return "", fmt.Errorf("'%s' was not pushed", imageTag)
+}
+
+func TestBuildWithCacheWarm(t *testing.T) {
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := noDigestRegistry{fakeRegistry: newFakeRegistry()}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:     dir,
+				Dockerfile:  filepath.Join(dir, "Dockerfile"),
+				Image:       "okteto.dev/api:1.0",
+				ExportCache: cache.ExportCache{"okteto.dev/api:cache"},
+			},
+			"worker": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto.dev/worker:1.0",
+			},
 		},
-		{
-			name:     "empty input",
-			expected: false,
-			control: map[string]bool{
-				"one": true,
-				"two": true,
+	}
+	err = bc.Build(context.Background(), &types.BuildOptions{
+		Manifest:  manifest,
+		CacheWarm: true,
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, registry.registry, "okteto.dev/api:1.0")
+	assert.NotContains(t, registry.registry, "okteto.dev/worker:1.0")
+	// the services that depend on a warmed image use the tag it would have
+	assert.Equal(t, "okteto.dev/api:1.0", bc.buildEnvironments["OKTETO_BUILD_API_IMAGE"])
+}
+
+func TestBuildWithLocalExport(t *testing.T) {
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := noDigestRegistry{fakeRegistry: newFakeRegistry()}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto.dev/api:1.0",
 			},
 		},
 	}
+	err = bc.Build(context.Background(), &types.BuildOptions{
+		Manifest:    manifest,
+		LocalExport: &types.LocalExport{Type: buildCmd.ExportTypeOCI, Dest: filepath.Join(dir, "image.tar")},
+	})
+	require.NoError(t, err)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := skipServiceBuild(tt.input, tt.control)
-			require.Equal(t, tt.expected, got)
-		})
+	// the image is not pushed: the services that depend on it use its tag
+	assert.Equal(t, "okteto.dev/api:1.0", bc.buildEnvironments["OKTETO_BUILD_API_IMAGE"])
+}
+
+func Test_areAnyServicesRebuilt(t *testing.T) {
+	rebuilt := map[string]bool{"base": true}
+	assert.True(t, areAnyServicesRebuilt([]string{"db", "base"}, rebuilt))
+	assert.False(t, areAnyServicesRebuilt([]string{"db"}, rebuilt))
+	assert.False(t, areAnyServicesRebuilt(nil, rebuilt))
+}
+
+type fakeSigner struct {
+	err    error
+	signed []string
+	keys   []string
+}
 
+func (fs *fakeSigner) Sign(_ context.Context, image, key string) error {
+	fs.signed = append(fs.signed, image)
+	fs.keys = append(fs.keys, key)
+	return fs.err
+}
+
+func TestBuildWithSign(t *testing.T) {
+	ctx := context.Background()
+
+	dir, err := createDockerfile(t)
+	assert.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	signer := &fakeSigner{}
+	bc.newSigner = func() (imageSigner, error) {
+		return signer, nil
 	}
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"test": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Sign:       true,
+				SignKey:    "cosign.key",
+			},
+		},
+	}
+	image, err := bc.buildServiceImages(ctx, manifest, "test", &types.BuildOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "okteto.dev/test-test:okteto", image)
+	// every pushed tag is signed
+	require.NotEmpty(t, signer.signed)
+	assert.Equal(t, "registry.okteto.example.com/test-test:okteto@sha256:0123", signer.signed[0])
+	for _, key := range signer.keys {
+		assert.Equal(t, "cosign.key", key)
+	}
+
+	signer.err = assert.AnError
+	_, err = bc.buildServiceImages(ctx, manifest, "test", &types.BuildOptions{})
+	assert.ErrorIs(t, err, assert.AnError)
 }
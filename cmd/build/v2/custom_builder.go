@@ -0,0 +1,180 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// nixpacksOutputFolder is the folder of the build context where nixpacks generates the Dockerfile
+const nixpacksOutputFolder = ".nixpacks"
+
+// customBuilderRunner runs a command of a custom builder in the dir folder with additional environment variables
+type customBuilderRunner func(ctx context.Context, dir string, env []string, name string, args ...string) error
+
+// customBuilderCLIs are the CLIs used by each custom builder and the instructions to install them
+var customBuilderCLIs = map[build.CustomBuilderType]struct {
+	name string
+	url  string
+}{
+	build.BuildpacksBuilder: {name: "pack", url: "https://buildpacks.io/docs/tools/pack/"},
+	build.KoBuilder:         {name: "ko", url: "https://ko.build/install/"},
+	build.NixpacksBuilder:   {name: "nixpacks", url: "https://nixpacks.com/docs/install"},
+}
+
+func runCustomBuilderCommand(ctx context.Context, dir string, env []string, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// runCustomBuilder runs the CLI of a custom builder, checking first that it is installed
+func (bc *OktetoBuilder) runCustomBuilder(ctx context.Context, builderType build.CustomBuilderType, dir string, env []string, args ...string) error {
+	cli := customBuilderCLIs[builderType]
+	run := bc.runCustomBuilderCmd
+	if run == nil {
+		if _, err := exec.LookPath(cli.name); err != nil {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the '%s' builder requires the '%s' CLI", builderType, cli.name),
+				Hint: fmt.Sprintf("Install it following the instructions at %s", cli.url),
+			}
+		}
+		run = runCustomBuilderCommand
+	}
+	if err := run(ctx, dir, env, cli.name, args...); err != nil {
+		return fmt.Errorf("'%s %s' failed: %w", cli.name, strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// generateNixpacksDockerfile generates the Dockerfile of the build context with nixpacks.
+// The generated Dockerfile is built like any other, so the build runs in the BuildKit instance of the context
+func (bc *OktetoBuilder) generateNixpacksDockerfile(ctx context.Context, svcName string, buildInfo *build.Info) (string, error) {
+	bc.ioCtrl.Out().Infof("Generating the Dockerfile of service '%s' with nixpacks", svcName)
+	args := []string{"build", ".", "--out", "."}
+	for _, arg := range buildInfo.Args {
+		args = append(args, "--env", arg.String())
+	}
+	if err := bc.runCustomBuilder(ctx, build.NixpacksBuilder, buildInfo.Context, nil, args...); err != nil {
+		return "", err
+	}
+	return filepath.Abs(filepath.Join(buildInfo.Context, nixpacksOutputFolder, "Dockerfile"))
+}
+
+// buildSvcWithCustomBuilder builds and pushes the image of a service with Cloud Native Buildpacks or ko.
+// These builders push the images to the registry themselves using the credentials of the local docker config
+func (bc *OktetoBuilder) buildSvcWithCustomBuilder(ctx context.Context, manifest *model.Manifest, svcName string, options *types.BuildOptions) (string, error) {
+	buildSvcInfo, _, err := bc.getSvcBuildInfo(manifest, svcName)
+	if err != nil {
+		return "", err
+	}
+	builderType := buildSvcInfo.CustomBuilder.Type
+	tags := strings.Split(buildSvcInfo.Image, ",")
+
+	// custom builders don't export the cache: the services that depend on this one use the tag it would have
+	if options.CacheWarm {
+		bc.ioCtrl.Logger().Infof("skipping the build of service '%s': the '%s' builder doesn't support cache warm builds", svcName, builderType)
+		return tags[0], nil
+	}
+	if options.LocalExport != nil {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("service '%s' can't be exported to a local file: the '%s' builder pushes the images itself", svcName, builderType),
+			Hint: "Export the images of services built from a Dockerfile",
+		}
+	}
+
+	bc.ioCtrl.Out().Infof("Building service '%s' with the '%s' builder", svcName, builderType)
+	switch builderType {
+	case build.BuildpacksBuilder:
+		args := getBuildpacksArgs(tags, buildSvcInfo, options.Platform)
+		if err := bc.runCustomBuilder(ctx, builderType, buildSvcInfo.Context, nil, args...); err != nil {
+			return "", err
+		}
+	case build.KoBuilder:
+		for _, repo := range bc.groupTagsByRepository(tags) {
+			env := []string{fmt.Sprintf("KO_DOCKER_REPO=%s", repo.name)}
+			for _, arg := range buildSvcInfo.Args {
+				env = append(env, arg.String())
+			}
+			args := getKoArgs(repo.tags, buildSvcInfo, options.Platform)
+			if err := bc.runCustomBuilder(ctx, builderType, buildSvcInfo.Context, env, args...); err != nil {
+				return "", err
+			}
+		}
+	default:
+		return "", fmt.Errorf("builder '%s' of service '%s' doesn't push images", builderType, svcName)
+	}
+
+	return bc.checkPushedImages(ctx, svcName, buildSvcInfo, tags)
+}
+
+// getBuildpacksArgs returns the arguments of 'pack' to build and push the image with all its tags
+func getBuildpacksArgs(tags []string, buildInfo *build.Info, platform string) []string {
+	args := []string{"build", tags[0], "--path", ".", "--builder", buildInfo.CustomBuilder.GetImage(), "--publish"}
+	for _, tag := range tags[1:] {
+		args = append(args, "--tag", tag)
+	}
+	for _, arg := range buildInfo.Args {
+		args = append(args, "--env", arg.String())
+	}
+	if platform != "" {
+		args = append(args, "--platform", platform)
+	}
+	return args
+}
+
+// getKoArgs returns the arguments of 'ko' to build and push the image to a repository with the given tags
+func getKoArgs(tags []string, buildInfo *build.Info, platform string) []string {
+	args := []string{"build", buildInfo.CustomBuilder.GetPath(), "--bare", "--tags", strings.Join(tags, ",")}
+	if platform != "" {
+		args = append(args, "--platform", platform)
+	}
+	return args
+}
+
+type repositoryTags struct {
+	name string
+	tags []string
+}
+
+// groupTagsByRepository groups the tags of an image by repository, keeping their order.
+// ko pushes an image to a single repository, defined by KO_DOCKER_REPO
+func (bc *OktetoBuilder) groupTagsByRepository(images []string) []repositoryTags {
+	result := []repositoryTags{}
+	indexes := map[string]int{}
+	for _, image := range images {
+		repo, tag := bc.Registry.GetRepoNameAndTag(image)
+		idx, ok := indexes[repo]
+		if !ok {
+			idx = len(result)
+			indexes[repo] = idx
+			result = append(result, repositoryTags{name: repo})
+		}
+		result[idx].tags = append(result[idx].tags, tag)
+	}
+	return result
+}
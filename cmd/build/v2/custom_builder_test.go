@@ -0,0 +1,231 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoTagRegistry splits the images in repository and tag
+type repoTagRegistry struct {
+	fakeRegistry
+}
+
+func (repoTagRegistry) GetRepoNameAndTag(image string) (string, string) {
+	idx := strings.LastIndex(image, ":")
+	return image[:idx], image[idx+1:]
+}
+
+type customBuilderCall struct {
+	dir  string
+	env  []string
+	name string
+	args []string
+}
+
+// fakeCustomBuilderRunner records the commands of the custom builders
+type fakeCustomBuilderRunner struct {
+	err   error
+	run   func(call customBuilderCall)
+	calls []customBuilderCall
+}
+
+func (fr *fakeCustomBuilderRunner) Run(_ context.Context, dir string, env []string, name string, args ...string) error {
+	call := customBuilderCall{dir: dir, env: env, name: name, args: args}
+	fr.calls = append(fr.calls, call)
+	if fr.run != nil {
+		fr.run(call)
+	}
+	return fr.err
+}
+
+func TestGetBuildpacksArgs(t *testing.T) {
+	buildInfo := &build.Info{
+		CustomBuilder: &build.CustomBuilder{Type: build.BuildpacksBuilder},
+		Args:          build.Args{{Name: "BP_NODE_VERSION", Value: "20"}},
+	}
+	args := getBuildpacksArgs([]string{"okteto.dev/api:1.0", "okteto.global/api:1.0"}, buildInfo, "linux/amd64")
+	assert.Equal(t, []string{
+		"build", "okteto.dev/api:1.0", "--path", ".", "--builder", build.DefaultBuildpacksBuilderImage, "--publish",
+		"--tag", "okteto.global/api:1.0",
+		"--env", "BP_NODE_VERSION=20",
+		"--platform", "linux/amd64",
+	}, args)
+
+	buildInfo.CustomBuilder.Image = "heroku/builder:24"
+	args = getBuildpacksArgs([]string{"okteto.dev/api:1.0"}, buildInfo, "")
+	assert.Equal(t, []string{
+		"build", "okteto.dev/api:1.0", "--path", ".", "--builder", "heroku/builder:24", "--publish",
+		"--env", "BP_NODE_VERSION=20",
+	}, args)
+}
+
+func TestGetKoArgs(t *testing.T) {
+	buildInfo := &build.Info{
+		CustomBuilder: &build.CustomBuilder{Type: build.KoBuilder},
+	}
+	assert.Equal(t, []string{"build", ".", "--bare", "--tags", "1.0,latest"}, getKoArgs([]string{"1.0", "latest"}, buildInfo, ""))
+
+	buildInfo.CustomBuilder.Path = "./cmd/api"
+	assert.Equal(t, []string{"build", "./cmd/api", "--bare", "--tags", "1.0", "--platform", "linux/arm64"}, getKoArgs([]string{"1.0"}, buildInfo, "linux/arm64"))
+}
+
+func TestGroupTagsByRepository(t *testing.T) {
+	bc := &OktetoBuilder{Registry: repoTagRegistry{fakeRegistry: newFakeRegistry()}}
+	result := bc.groupTagsByRepository([]string{"okteto.dev/api:1.0", "okteto.global/api:1.0", "okteto.dev/api:hash"})
+	assert.Equal(t, []repositoryTags{
+		{name: "okteto.dev/api", tags: []string{"1.0", "hash"}},
+		{name: "okteto.global/api", tags: []string{"1.0"}},
+	}, result)
+}
+
+func TestBuildWithBuildpacks(t *testing.T) {
+	registry := repoTagRegistry{fakeRegistry: newFakeRegistry()}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	runner := &fakeCustomBuilderRunner{
+		run: func(call customBuilderCall) {
+			// pack publishes the image with the tag of its first argument
+			_ = registry.AddImageByName(call.args[1])
+		},
+	}
+	bc.runCustomBuilderCmd = runner.Run
+	dir := t.TempDir()
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:       dir,
+				Image:         "okteto.dev/api:1.0",
+				CustomBuilder: &build.CustomBuilder{Type: build.BuildpacksBuilder},
+			},
+		},
+	}
+	image, err := bc.buildServiceImages(context.Background(), manifest, "api", &types.BuildOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/api:1.0", image)
+	require.Len(t, runner.calls, 1)
+	assert.Equal(t, "pack", runner.calls[0].name)
+	assert.Equal(t, dir, runner.calls[0].dir)
+
+	runner.err = assert.AnError
+	_, err = bc.buildServiceImages(context.Background(), manifest, "api", &types.BuildOptions{})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestBuildWithKo(t *testing.T) {
+	registry := repoTagRegistry{fakeRegistry: newFakeRegistry()}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	runner := &fakeCustomBuilderRunner{
+		run: func(call customBuilderCall) {
+			repo := strings.TrimPrefix(call.env[0], "KO_DOCKER_REPO=")
+			for _, tag := range strings.Split(call.args[len(call.args)-1], ",") {
+				_ = registry.AddImageByName(repo + ":" + tag)
+			}
+		},
+	}
+	bc.runCustomBuilderCmd = runner.Run
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:       t.TempDir(),
+				Image:         "okteto.dev/api:1.0",
+				CustomBuilder: &build.CustomBuilder{Type: build.KoBuilder, Path: "./cmd/api"},
+				Args:          build.Args{{Name: "CGO_ENABLED", Value: "0"}},
+			},
+		},
+	}
+	image, err := bc.buildServiceImages(context.Background(), manifest, "api", &types.BuildOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/api:1.0", image)
+	require.Len(t, runner.calls, 1)
+	assert.Equal(t, "ko", runner.calls[0].name)
+	assert.Equal(t, []string{"KO_DOCKER_REPO=okteto.dev/api", "CGO_ENABLED=0"}, runner.calls[0].env)
+	assert.Equal(t, []string{"build", "./cmd/api", "--bare", "--tags", "1.0"}, runner.calls[0].args)
+}
+
+func TestBuildWithCustomBuilderCacheWarm(t *testing.T) {
+	registry := repoTagRegistry{fakeRegistry: newFakeRegistry()}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	runner := &fakeCustomBuilderRunner{}
+	bc.runCustomBuilderCmd = runner.Run
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:       t.TempDir(),
+				Image:         "okteto.dev/api:1.0",
+				CustomBuilder: &build.CustomBuilder{Type: build.KoBuilder},
+			},
+		},
+	}
+	image, err := bc.buildServiceImages(context.Background(), manifest, "api", &types.BuildOptions{CacheWarm: true})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.dev/api:1.0", image)
+	assert.Empty(t, runner.calls)
+}
+
+func TestBuildWithNixpacks(t *testing.T) {
+	image := "okteto.dev/api:1.0"
+	registry := dockerfileRecorderRegistry{
+		fakeRegistry: newFakeRegistry(),
+		dockerfiles:  map[string]string{},
+		files:        map[string]string{},
+	}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	dir := t.TempDir()
+	runner := &fakeCustomBuilderRunner{
+		run: func(call customBuilderCall) {
+			out := filepath.Join(call.dir, nixpacksOutputFolder)
+			require.NoError(t, os.MkdirAll(out, 0700))
+			require.NoError(t, os.WriteFile(filepath.Join(out, "Dockerfile"), []byte("FROM nixpacks\n"), 0600))
+		},
+	}
+	bc.runCustomBuilderCmd = runner.Run
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:       dir,
+				Image:         image,
+				CustomBuilder: &build.CustomBuilder{Type: build.NixpacksBuilder},
+				Args:          build.Args{{Name: "NIXPACKS_NODE_VERSION", Value: "20"}},
+			},
+		},
+	}
+	_, err := bc.buildServiceImages(context.Background(), manifest, "api", &types.BuildOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, runner.calls, 1)
+	assert.Equal(t, "nixpacks", runner.calls[0].name)
+	assert.Equal(t, []string{"build", ".", "--out", ".", "--env", "NIXPACKS_NODE_VERSION=20"}, runner.calls[0].args)
+	assert.Equal(t, "FROM nixpacks\n", registry.dockerfiles[image])
+	// the generated Dockerfile is removed after the build
+	assert.NoDirExists(t, filepath.Join(dir, nixpacksOutputFolder))
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// dryRun prints the resolved build configuration of the services in the order they would be built,
+// without building or pushing any image
+func (ob *OktetoBuilder) dryRun(ctx context.Context, toBuildSvcs []string, options *types.BuildOptions) error {
+	imageCtrl := registry.NewImageCtrl(ob.oktetoContext)
+	return buildInDependencyOrder(ctx, toBuildSvcs, options.Manifest.Build, 1, func(_ context.Context, svcToBuild string, _ bool) (bool, error) {
+		if !serviceHasDockerfile(options.Manifest.Build[svcToBuild]) {
+			return false, nil
+		}
+
+		buildSvcInfo, buildHash, err := ob.getSvcBuildInfo(options.Manifest, svcToBuild)
+		if err != nil {
+			return false, err
+		}
+		buildOptions := buildCmd.OptsFromBuildInfo(options.Manifest.Name, svcToBuild, buildSvcInfo, options, ob.Registry, ob.oktetoContext)
+
+		tags := make([]string, 0)
+		for _, tag := range strings.Split(buildOptions.Tag, ",") {
+			if tag == "" {
+				continue
+			}
+			tags = append(tags, imageCtrl.ExpandOktetoGlobalRegistry(imageCtrl.ExpandOktetoDevRegistry(tag)))
+		}
+
+		ob.ioCtrl.Out().Println(formatDryRun(svcToBuild, buildOptions, tags, buildSvcInfo.DependsOn, buildHash))
+
+		// the services that depend on this one resolve its image from the environment
+		if len(tags) > 0 {
+			ob.SetServiceEnvVars(svcToBuild, tags[0])
+		}
+		return false, nil
+	})
+}
+
+func formatDryRun(svcName string, buildOptions *types.BuildOptions, tags, dependsOn []string, buildHash string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Service '%s':\n", svcName)
+	fmt.Fprintf(&sb, "  context: %s\n", buildOptions.Path)
+	fmt.Fprintf(&sb, "  dockerfile: %s\n", buildOptions.File)
+	if buildOptions.Target != "" {
+		fmt.Fprintf(&sb, "  target: %s\n", buildOptions.Target)
+	}
+	if buildOptions.Platform != "" {
+		fmt.Fprintf(&sb, "  platforms: %s\n", buildOptions.Platform)
+	}
+	writeDryRunList(&sb, "tags", tags)
+	writeDryRunList(&sb, "args", buildOptions.BuildArgs)
+	writeDryRunList(&sb, "cache_from", buildOptions.CacheFrom)
+	writeDryRunList(&sb, "export_cache", buildOptions.ExportCache)
+	writeDryRunList(&sb, "depends_on", dependsOn)
+	if buildHash != "" {
+		fmt.Fprintf(&sb, "  build hash: %s\n", buildHash)
+	} else {
+		sb.WriteString("  build hash: smart builds are disabled\n")
+	}
+	return sb.String()
+}
+
+func writeDryRunList(sb *strings.Builder, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "  %s:\n", name)
+	for _, v := range values {
+		fmt.Fprintf(sb, "    - %s\n", v)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDryRun(t *testing.T) {
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"a": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/a:test",
+			},
+			"b": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/b:test",
+				DependsOn:  []string{"a"},
+			},
+		},
+	}
+
+	err = bc.Build(context.Background(), &types.BuildOptions{
+		Manifest: manifest,
+		DryRun:   true,
+	})
+	require.NoError(t, err)
+
+	// nothing is built nor pushed
+	_, err = registry.GetImageTagWithDigest("okteto/a:test")
+	assert.Error(t, err)
+	_, err = registry.GetImageTagWithDigest("okteto/b:test")
+	assert.Error(t, err)
+
+	// dependent services can resolve the images of their dependencies
+	assert.Equal(t, "okteto/a:test", bc.GetBuildEnvVars()["OKTETO_BUILD_A_IMAGE"])
+}
+
+func TestFormatDryRun(t *testing.T) {
+	buildOptions := &types.BuildOptions{
+		Path:        "api",
+		File:        "api/Dockerfile",
+		Target:      "prod",
+		BuildArgs:   []string{"KEY=value"},
+		CacheFrom:   []string{"okteto/api:cache"},
+		ExportCache: []string{"okteto/api:cache"},
+	}
+
+	expected := `Service 'api':
+  context: api
+  dockerfile: api/Dockerfile
+  target: prod
+  tags:
+    - registry/ns/api:okteto
+  args:
+    - KEY=value
+  cache_from:
+    - okteto/api:cache
+  export_cache:
+    - okteto/api:cache
+  depends_on:
+    - db
+  build hash: 1234
+`
+	assert.Equal(t, expected, formatDryRun("api", buildOptions, []string{"registry/ns/api:okteto"}, []string{"db"}, "1234"))
+
+	expected = `Service 'api':
+  context: api
+  dockerfile: api/Dockerfile
+  build hash: smart builds are disabled
+`
+	assert.Equal(t, expected, formatDryRun("api", &types.BuildOptions{Path: "api", File: "api/Dockerfile"}, nil, nil, ""))
+}
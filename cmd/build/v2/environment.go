@@ -71,7 +71,13 @@ func (bc *OktetoBuilder) SetServiceEnvVars(service, reference string) {
 	bc.ioCtrl.Logger().Debug("manifest env vars set")
 }
 
-// GetBuildEnvVars gets okteto build env vars
+// GetBuildEnvVars gets a copy of the okteto build env vars
 func (bc *OktetoBuilder) GetBuildEnvVars() map[string]string {
-	return bc.buildEnvironments
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+	envs := make(map[string]string, len(bc.buildEnvironments))
+	for k, v := range bc.buildEnvironments {
+		envs[k] = v
+	}
+	return envs
 }
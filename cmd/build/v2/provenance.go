@@ -0,0 +1,69 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// getAttestation returns the provenance attestation of the image pushed for a service.
+// It returns false if the registry doesn't have attestations for the image
+func (bc *OktetoBuilder) getAttestation(svcName, imageWithDigest string) (pipeline.Attestation, bool) {
+	digests, err := bc.Registry.GetAttestationDigests(imageWithDigest)
+	if err != nil {
+		bc.ioCtrl.Logger().Infof("failed to get the attestations of service '%s': %s", svcName, err)
+		return pipeline.Attestation{}, false
+	}
+	if len(digests) == 0 {
+		bc.ioCtrl.Logger().Infof("image '%s' of service '%s' doesn't have attestations", imageWithDigest, svcName)
+		return pipeline.Attestation{}, false
+	}
+	return pipeline.Attestation{
+		Image:   imageWithDigest,
+		Commit:  bc.Config.GetGitCommit(),
+		Digests: digests,
+	}, true
+}
+
+// recordAttestations stores the provenance attestations of the images in the configmap of the pipeline,
+// so the images running in the namespace can be verified against the commit of the deployment
+func (bc *OktetoBuilder) recordAttestations(ctx context.Context, manifestName string, attestations map[string]pipeline.Attestation) {
+	if len(attestations) == 0 {
+		return
+	}
+	for svcName, attestation := range attestations {
+		bc.ioCtrl.Out().Infof("Provenance attestation of service '%s': %s", svcName, attestation.Digests[0])
+	}
+
+	provider := bc.k8sClientProvider
+	if provider == nil {
+		provider = okteto.NewK8sClientProviderWithLogger(bc.k8sLogger)
+	}
+	c, _, err := provider.Provide(bc.oktetoContext.GetCurrentCfg())
+	if err != nil {
+		bc.ioCtrl.Logger().Infof("failed to record the attestations: %s", err)
+		return
+	}
+	if err := pipeline.UpdateAttestations(ctx, manifestName, bc.oktetoContext.GetNamespace(), attestations, c); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			bc.ioCtrl.Logger().Infof("'%s' is not deployed, the attestations are not recorded", manifestName)
+			return
+		}
+		bc.ioCtrl.Out().Warning("failed to record the attestations in '%s': %s", manifestName, err)
+	}
+}
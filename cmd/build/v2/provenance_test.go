@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// attestedRegistry is a fake registry where every image has a provenance attestation
+type attestedRegistry struct {
+	fakeRegistry
+}
+
+func (attestedRegistry) GetAttestationDigests(string) ([]string, error) {
+	return []string{"sha256:attestation"}, nil
+}
+
+func TestBuildWithProvenance(t *testing.T) {
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := attestedRegistry{fakeRegistry: newFakeRegistry()}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true, sha: "123"})
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipeline.TranslatePipelineName("test"),
+			Namespace: "test",
+		},
+		Data: map[string]string{},
+	}
+	provider := test.NewFakeK8sProvider(cmap)
+	bc.k8sClientProvider = provider
+
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto.dev/api:1.0",
+			},
+		},
+	}
+	err = bc.Build(context.Background(), &types.BuildOptions{
+		Manifest:   manifest,
+		Provenance: "max",
+	})
+	require.NoError(t, err)
+
+	c, _, err := provider.Provide(nil)
+	require.NoError(t, err)
+	cmap, err = c.CoreV1().ConfigMaps("test").Get(context.Background(), pipeline.TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, cmap.Data[pipeline.AttestationsField], "api")
+	assert.Contains(t, cmap.Data[pipeline.AttestationsField], "sha256:attestation")
+	assert.Contains(t, cmap.Data[pipeline.AttestationsField], "123")
+}
+
+func TestBuildWithProvenanceNotDeployed(t *testing.T) {
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := attestedRegistry{fakeRegistry: newFakeRegistry()}
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true})
+	bc.k8sClientProvider = test.NewFakeK8sProvider()
+
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto.dev/api:1.0",
+			},
+		},
+	}
+	err = bc.Build(context.Background(), &types.BuildOptions{
+		Manifest:   manifest,
+		Provenance: "max",
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	buildEventType  = "build"
+	buildReportType = "result"
+
+	stageStarted = "started"
+	stageBuilt   = "built"
+	stageFailed  = "failed"
+
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+// buildEvent is the machine-readable event emitted when the build of a service changes its stage
+type buildEvent struct {
+	Type    string `json:"type"`
+	Service string `json:"service"`
+	Stage   string `json:"stage"`
+	Image   string `json:"image,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+	Cache   string `json:"cache,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// Duration is the time spent building the service in seconds
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// imageResult is the image built for a service in the final document of the build
+type imageResult struct {
+	Image  string `json:"image"`
+	Digest string `json:"digest"`
+	Cache  string `json:"cache"`
+}
+
+// buildReport is the final document of the build with the images of all the services
+type buildReport struct {
+	Type   string                 `json:"type"`
+	Images map[string]imageResult `json:"images"`
+}
+
+// buildReporter writes the events and the final document of the build when the output is json.
+// It does nothing for any other output
+type buildReporter struct {
+	out    io.Writer
+	images map[string]imageResult
+	// lock protects images and the writes of the services built at the same time
+	lock    sync.Mutex
+	enabled bool
+}
+
+func newBuildReporter(output string, out io.Writer) *buildReporter {
+	return &buildReporter{
+		out:     out,
+		images:  map[string]imageResult{},
+		enabled: output == oktetoLog.JSONFormat,
+	}
+}
+
+func (r *buildReporter) started(svcName string) {
+	r.write(buildEvent{Type: buildEventType, Service: svcName, Stage: stageStarted})
+}
+
+func (r *buildReporter) built(svcName, imageWithDigest string, isCacheHit bool, duration time.Duration) {
+	cache := cacheMiss
+	if isCacheHit {
+		cache = cacheHit
+	}
+	digest := getDigest(imageWithDigest)
+
+	r.lock.Lock()
+	r.images[svcName] = imageResult{Image: imageWithDigest, Digest: digest, Cache: cache}
+	r.lock.Unlock()
+
+	r.write(buildEvent{
+		Type:     buildEventType,
+		Service:  svcName,
+		Stage:    stageBuilt,
+		Image:    imageWithDigest,
+		Digest:   digest,
+		Cache:    cache,
+		Duration: duration.Seconds(),
+	})
+}
+
+func (r *buildReporter) failed(svcName string, err error, duration time.Duration) {
+	r.write(buildEvent{
+		Type:     buildEventType,
+		Service:  svcName,
+		Stage:    stageFailed,
+		Error:    err.Error(),
+		Duration: duration.Seconds(),
+	})
+}
+
+// result writes the final document with the images built for each service. It must be called once all the builds finish
+func (r *buildReporter) result() {
+	r.write(buildReport{Type: buildReportType, Images: r.images})
+}
+
+func (r *buildReporter) write(v any) {
+	if !r.enabled {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		oktetoLog.Infof("failed to marshal build output: %s", err)
+		return
+	}
+	fmt.Fprintln(r.out, string(bytes))
+}
+
+// getDigest returns the digest of an image reference in the 'name@digest' format
+func getDigest(imageWithDigest string) string {
+	_, digest, found := strings.Cut(imageWithDigest, "@")
+	if !found {
+		return ""
+	}
+	return digest
+}
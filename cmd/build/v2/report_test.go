@@ -0,0 +1,84 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReporter(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := newBuildReporter("json", out)
+
+	r.started("api")
+	r.built("api", "okteto.dev/api@sha256:123", false, 2*time.Second)
+	r.started("frontend")
+	r.built("frontend", "okteto.global/frontend@sha256:456", true, time.Second)
+	r.started("worker")
+	r.failed("worker", errors.New("Dockerfile not found"), 500*time.Millisecond)
+	r.result()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 7)
+
+	var events []buildEvent
+	for _, line := range lines[:6] {
+		var e buildEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		events = append(events, e)
+	}
+	expected := []buildEvent{
+		{Type: "build", Service: "api", Stage: "started"},
+		{Type: "build", Service: "api", Stage: "built", Image: "okteto.dev/api@sha256:123", Digest: "sha256:123", Cache: "miss", Duration: 2},
+		{Type: "build", Service: "frontend", Stage: "started"},
+		{Type: "build", Service: "frontend", Stage: "built", Image: "okteto.global/frontend@sha256:456", Digest: "sha256:456", Cache: "hit", Duration: 1},
+		{Type: "build", Service: "worker", Stage: "started"},
+		{Type: "build", Service: "worker", Stage: "failed", Error: "Dockerfile not found", Duration: 0.5},
+	}
+	assert.Equal(t, expected, events)
+
+	var report buildReport
+	require.NoError(t, json.Unmarshal([]byte(lines[6]), &report))
+	assert.Equal(t, buildReport{
+		Type: "result",
+		Images: map[string]imageResult{
+			"api":      {Image: "okteto.dev/api@sha256:123", Digest: "sha256:123", Cache: "miss"},
+			"frontend": {Image: "okteto.global/frontend@sha256:456", Digest: "sha256:456", Cache: "hit"},
+		},
+	}, report)
+}
+
+func TestBuildReporterPlainOutput(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := newBuildReporter("plain", out)
+
+	r.started("api")
+	r.built("api", "okteto.dev/api@sha256:123", false, time.Second)
+	r.result()
+
+	assert.Empty(t, out.String())
+}
+
+func TestGetDigest(t *testing.T) {
+	assert.Equal(t, "sha256:123", getDigest("okteto.dev/api@sha256:123"))
+	assert.Equal(t, "", getDigest("okteto.dev/api:1.0"))
+}
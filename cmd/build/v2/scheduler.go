@@ -0,0 +1,173 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/okteto/okteto/pkg/build"
+)
+
+const (
+	// OktetoBuildParallelismEnvVar defines the maximum number of images built at the same time
+	OktetoBuildParallelismEnvVar = "OKTETO_BUILD_PARALLELISM"
+
+	// defaultBuildParallelism keeps the load of the builder bounded when the manifest has many independent images
+	defaultBuildParallelism = 4
+)
+
+// errSvcSkipped is returned by a buildSvcFunc when the service is not built, so its OKTETO_BUILD_<SVC>_* env vars are not set
+var errSvcSkipped = errors.New("service skipped")
+
+// buildSvcFunc builds the image of a service. hasRebuiltDependencies is true when any of the images the service
+// depends on was built in this execution. It returns true if the image was built instead of reused from cache
+type buildSvcFunc func(ctx context.Context, svc string, hasRebuiltDependencies bool) (bool, error)
+
+type buildResult struct {
+	err     error
+	svc     string
+	rebuilt bool
+}
+
+// getBuildParallelism returns the maximum number of images built at the same time. The build options take
+// precedence over the OKTETO_BUILD_PARALLELISM env var
+func getBuildParallelism(options int, logger loggerInfo) int {
+	if options > 0 {
+		return options
+	}
+	if value := os.Getenv(OktetoBuildParallelismEnvVar); value != "" {
+		parallelism, err := strconv.Atoi(value)
+		if err == nil && parallelism > 0 {
+			return parallelism
+		}
+		logger.Infof("invalid value for %s: '%s', using %d", OktetoBuildParallelismEnvVar, value, defaultBuildParallelism)
+	}
+	return defaultBuildParallelism
+}
+
+// buildInDependencyOrder builds the services as soon as all the services they depend on are built, running at most
+// 'parallelism' builds at the same time. Services are started in the order of svcs.
+// When a build fails, no more builds are started and the error is returned once the running ones finish
+func buildInDependencyOrder(ctx context.Context, svcs []string, manifest build.ManifestBuild, parallelism int, buildSvc buildSvcFunc) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	toBuild := map[string]bool{}
+	for _, svc := range svcs {
+		toBuild[svc] = true
+	}
+
+	pending := append([]string{}, svcs...)
+	built := map[string]bool{}
+	rebuilt := map[string]bool{}
+	skipped := map[string]bool{}
+	results := make(chan buildResult)
+	running := 0
+	var buildErr error
+
+	for {
+		for buildErr == nil && running < parallelism {
+			svc, ok := nextBuildableSvc(pending, manifest, toBuild, built)
+			if !ok {
+				break
+			}
+			pending = removeSvc(pending, svc)
+			// the services that depend on a skipped service would be built without its OKTETO_BUILD_<SVC>_* env vars
+			if dependency, ok := findSkippedDependency(manifest[svc].DependsOn, skipped); ok {
+				buildErr = fmt.Errorf("could not build '%s': it depends on '%s', which was skipped", svc, dependency)
+				cancel()
+				break
+			}
+			running++
+			hasRebuiltDependencies := areAnyServicesRebuilt(manifest[svc].DependsOn, rebuilt)
+			go func(svc string) {
+				isRebuilt, err := buildSvc(ctx, svc, hasRebuiltDependencies)
+				results <- buildResult{svc: svc, rebuilt: isRebuilt, err: err}
+			}(svc)
+		}
+
+		if running == 0 {
+			if buildErr == nil && len(pending) != 0 {
+				sort.Strings(pending)
+				return fmt.Errorf("could not build images %v: their dependencies can't be built", pending)
+			}
+			return buildErr
+		}
+
+		result := <-results
+		running--
+		if errors.Is(result.err, errSvcSkipped) {
+			built[result.svc] = true
+			skipped[result.svc] = true
+			continue
+		}
+		if result.err != nil {
+			if buildErr == nil {
+				buildErr = result.err
+				cancel()
+			}
+			continue
+		}
+		built[result.svc] = true
+		if result.rebuilt {
+			rebuilt[result.svc] = true
+		}
+	}
+}
+
+// nextBuildableSvc returns the first pending service whose dependencies are already built. Dependencies that are
+// not part of the build are ignored
+func nextBuildableSvc(pending []string, manifest build.ManifestBuild, toBuild, built map[string]bool) (string, bool) {
+	for _, svc := range pending {
+		ready := true
+		for _, dependency := range manifest[svc].DependsOn {
+			if toBuild[dependency] && !built[dependency] {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return svc, true
+		}
+	}
+	return "", false
+}
+
+// findSkippedDependency returns the first dependency that was skipped
+func findSkippedDependency(dependencies []string, skipped map[string]bool) (string, bool) {
+	for _, dependency := range dependencies {
+		if skipped[dependency] {
+			return dependency, true
+		}
+	}
+	return "", false
+}
+
+func removeSvc(svcs []string, svc string) []string {
+	result := make([]string, 0, len(svcs))
+	for _, s := range svcs {
+		if s != svc {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBuildParallelism(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		options  int
+		expected int
+	}{
+		{
+			name:     "default",
+			expected: defaultBuildParallelism,
+		},
+		{
+			name:     "from env var",
+			env:      "4",
+			expected: 4,
+		},
+		{
+			name:     "options take precedence",
+			env:      "4",
+			options:  2,
+			expected: 2,
+		},
+		{
+			name:     "invalid env var",
+			env:      "many",
+			expected: defaultBuildParallelism,
+		},
+		{
+			name:     "zero env var",
+			env:      "0",
+			expected: defaultBuildParallelism,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(OktetoBuildParallelismEnvVar, tt.env)
+			assert.Equal(t, tt.expected, getBuildParallelism(tt.options, fakeLogger{}))
+		})
+	}
+}
+
+// buildRecorder records the order of the builds and the maximum number of builds running at the same time
+type buildRecorder struct {
+	failing    map[string]bool
+	skipped    map[string]bool
+	cached     map[string]bool
+	rebuiltDep map[string]bool
+	finished   []string
+	running    int
+	maxRunning int
+	lock       sync.Mutex
+}
+
+func (r *buildRecorder) build(_ context.Context, svc string, hasRebuiltDependencies bool) (bool, error) {
+	r.lock.Lock()
+	r.running++
+	if r.running > r.maxRunning {
+		r.maxRunning = r.running
+	}
+	r.rebuiltDep[svc] = hasRebuiltDependencies
+	r.lock.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.running--
+	if r.failing[svc] {
+		return false, errors.New("build failed")
+	}
+	if r.skipped[svc] {
+		return false, errSvcSkipped
+	}
+	r.finished = append(r.finished, svc)
+	return !r.cached[svc], nil
+}
+
+func newBuildRecorder() *buildRecorder {
+	return &buildRecorder{
+		failing:    map[string]bool{},
+		skipped:    map[string]bool{},
+		cached:     map[string]bool{},
+		rebuiltDep: map[string]bool{},
+	}
+}
+
+func indexOf(svcs []string, svc string) int {
+	for i, s := range svcs {
+		if s == svc {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestBuildInDependencyOrder(t *testing.T) {
+	manifest := build.ManifestBuild{
+		"base":     &build.Info{},
+		"api":      &build.Info{DependsOn: build.DependsOn{"base"}},
+		"worker":   &build.Info{DependsOn: build.DependsOn{"base"}},
+		"frontend": &build.Info{},
+		"e2e":      &build.Info{DependsOn: build.DependsOn{"api", "frontend"}},
+	}
+	svcs := []string{"base", "api", "worker", "frontend", "e2e"}
+
+	t.Run("serial", func(t *testing.T) {
+		r := newBuildRecorder()
+		require.NoError(t, buildInDependencyOrder(context.Background(), svcs, manifest, 1, r.build))
+		assert.Equal(t, []string{"base", "api", "worker", "frontend", "e2e"}, r.finished)
+		assert.Equal(t, 1, r.maxRunning)
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		r := newBuildRecorder()
+		require.NoError(t, buildInDependencyOrder(context.Background(), svcs, manifest, 3, r.build))
+		assert.Len(t, r.finished, len(svcs))
+		assert.LessOrEqual(t, r.maxRunning, 3)
+		assert.Greater(t, r.maxRunning, 1)
+		for svc, info := range manifest {
+			for _, dependency := range info.DependsOn {
+				assert.Less(t, indexOf(r.finished, dependency), indexOf(r.finished, svc), "%s built before %s", svc, dependency)
+			}
+		}
+	})
+
+	t.Run("rebuilt dependencies", func(t *testing.T) {
+		r := newBuildRecorder()
+		r.cached["base"] = true
+		r.cached["frontend"] = true
+		require.NoError(t, buildInDependencyOrder(context.Background(), svcs, manifest, 2, r.build))
+		assert.False(t, r.rebuiltDep["api"])
+		assert.False(t, r.rebuiltDep["worker"])
+		assert.True(t, r.rebuiltDep["e2e"])
+	})
+
+	t.Run("failed build", func(t *testing.T) {
+		r := newBuildRecorder()
+		r.failing["base"] = true
+		err := buildInDependencyOrder(context.Background(), svcs, manifest, 2, r.build)
+		require.Error(t, err)
+		assert.NotContains(t, r.finished, "api")
+		assert.NotContains(t, r.finished, "worker")
+		assert.NotContains(t, r.finished, "e2e")
+	})
+
+	t.Run("skipped dependency", func(t *testing.T) {
+		r := newBuildRecorder()
+		r.skipped["base"] = true
+		err := buildInDependencyOrder(context.Background(), svcs, manifest, 3, r.build)
+		assert.ErrorContains(t, err, "it depends on 'base', which was skipped")
+		for _, svc := range []string{"api", "worker", "e2e"} {
+			_, started := r.rebuiltDep[svc]
+			assert.False(t, started, "%s was started", svc)
+		}
+	})
+
+	t.Run("skipped service without dependents", func(t *testing.T) {
+		r := newBuildRecorder()
+		r.skipped["e2e"] = true
+		r.skipped["worker"] = true
+		require.NoError(t, buildInDependencyOrder(context.Background(), svcs, manifest, 3, r.build))
+		assert.ElementsMatch(t, []string{"base", "api", "frontend"}, r.finished)
+	})
+
+	t.Run("dependency not built", func(t *testing.T) {
+		r := newBuildRecorder()
+		require.NoError(t, buildInDependencyOrder(context.Background(), []string{"api"}, manifest, 2, r.build))
+		assert.Equal(t, []string{"api"}, r.finished)
+	})
+
+	t.Run("cyclic dependencies", func(t *testing.T) {
+		cyclic := build.ManifestBuild{
+			"a": &build.Info{DependsOn: build.DependsOn{"b"}},
+			"b": &build.Info{DependsOn: build.DependsOn{"a"}},
+		}
+		r := newBuildRecorder()
+		err := buildInDependencyOrder(context.Background(), []string{"a", "b"}, cyclic, 2, r.build)
+		assert.ErrorContains(t, err, "could not build images [a b]")
+	})
+}
@@ -20,8 +20,10 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/okteto/okteto/pkg/build"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/registry"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -92,12 +94,13 @@ func (bc *OktetoBuilder) GetServicesToBuildDuringExecution(ctx context.Context,
 		}
 		svcsToBuildList = append(svcsToBuildList, svc)
 	}
-	return svcsToBuildList, nil
+	return addDependentServices(buildManifest, svcsToBuildList, svcToDeployMap), nil
 }
 
 // checkServiceToBuildDuringDeploy looks for the service image reference at the registry and adds it to the buildCh
 // if is not found. This function is called during deploy operations (up, deploy, destroy and compose) to check if
-// images have to be built or not. In that case, we only check the existence of "okteto" tag in the dev registry
+// images have to be built or not. In that case, we only check the existence of the tag given by the tag strategy,
+// or the "okteto" tag, in the dev registry
 func (bc *OktetoBuilder) checkServiceToBuildDuringDeploy(service string, manifest *model.Manifest, buildCh chan string) error {
 	buildInfo := manifest.Build[service].Copy()
 	isStack := manifest.Type == model.StackType
@@ -105,8 +108,14 @@ func (bc *OktetoBuilder) checkServiceToBuildDuringDeploy(service string, manifes
 		buildInfo.Image = ""
 	}
 
-	imageChecker := getImageChecker(bc.Config, bc.Registry, bc.smartBuildCtrl, bc.ioCtrl.Logger())
-	imageWithDigest, err := imageChecker.getImageDigestReferenceForServiceDeploy(manifest.Name, service, buildInfo)
+	var imageWithDigest string
+	var err error
+	if bc.shouldCheckBuildProvenance(service, manifest.Build[service], buildInfo) {
+		imageWithDigest, err = bc.getImageDigestReferenceForBuildHash(service, manifest)
+	} else {
+		imageChecker := newImageChecker(bc.Config, bc.Registry, bc.getImageTagger(manifest, service, buildInfo, ""), bc.ioCtrl.Logger())
+		imageWithDigest, err = imageChecker.getImageDigestReferenceForServiceDeploy(manifest.Name, service, buildInfo)
+	}
 	if oktetoErrors.IsNotFound(err) {
 		bc.ioCtrl.Logger().Debug("image not found, building image")
 		buildCh <- service
@@ -131,6 +140,59 @@ func (bc *OktetoBuilder) checkServiceToBuildDuringDeploy(service string, manifes
 	return nil
 }
 
+// shouldCheckBuildProvenance returns if the image of the service must have been built from its current sources
+// (Dockerfile, build args and build context) instead of just checking that the "okteto" tag exists, which might
+// have been built from another branch. This is only possible for the images inferred by okteto when smart builds
+// are enabled and the git metadata of the build context is available
+func (bc *OktetoBuilder) shouldCheckBuildProvenance(service string, manifestBuildInfo, buildInfo *build.Info) bool {
+	if !bc.smartBuildCtrl.IsEnabled() || !bc.oktetoContext.IsOktetoCluster() {
+		return false
+	}
+	if buildInfo.Image != "" || !serviceHasDockerfile(buildInfo) {
+		return false
+	}
+	return bc.smartBuildCtrl.IsBuildHashDeterministic(manifestBuildInfo, service)
+}
+
+// getImageDigestReferenceForBuildHash returns the image reference with digest of the service tagged with its build hash
+func (bc *OktetoBuilder) getImageDigestReferenceForBuildHash(service string, manifest *model.Manifest) (string, error) {
+	buildHash := bc.smartBuildCtrl.GetBuildHash(manifest.Build[service], service)
+	imageChecker := getImageChecker(bc.Config, bc.Registry, bc.smartBuildCtrl, bc.ioCtrl.Logger())
+	imageCtrl := registry.NewImageCtrl(bc.oktetoContext)
+	imageWithDigest, isBuilt := imageChecker.checkIfBuildHashIsBuilt("", bc.oktetoContext.GetNamespace(), bc.oktetoContext.GetRegistryURL(), manifest.Name, service, buildHash, imageCtrl)
+	if !isBuilt {
+		bc.ioCtrl.Logger().Infof("image of service '%s' not built for its current sources (build hash '%s')", service, buildHash)
+		return "", oktetoErrors.ErrNotFound
+	}
+	return bc.smartBuildCtrl.CloneGlobalImageToDev(imageWithDigest)
+}
+
+// addDependentServices adds to toBuild the services that depend on any of the services to build, as they have to be
+// rebuilt with the new images of their dependencies
+func addDependentServices(buildManifest build.ManifestBuild, toBuild []string, candidates map[string]bool) []string {
+	toBuildSet := map[string]bool{}
+	for _, svc := range toBuild {
+		toBuildSet[svc] = true
+	}
+	for added := true; added; {
+		added = false
+		for svc := range candidates {
+			if toBuildSet[svc] || buildManifest[svc] == nil {
+				continue
+			}
+			for _, dependency := range buildManifest[svc].DependsOn {
+				if toBuildSet[dependency] {
+					toBuildSet[svc] = true
+					toBuild = append(toBuild, svc)
+					added = true
+					break
+				}
+			}
+		}
+	}
+	return toBuild
+}
+
 func (bc *OktetoBuilder) GetSvcToBuildFromRegex(manifest *model.Manifest, imgFinder model.ImageFromManifest) (string, error) {
 	img := imgFinder(manifest)
 	reg := regexp.MustCompile(`OKTETO_BUILD_(\w+)_`)
@@ -15,10 +15,15 @@ package v2
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/okteto/okteto/cmd/build/v2/smartbuild"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,7 +48,12 @@ func TestAllServicesAlreadyBuilt(t *testing.T) {
 		isOkteto: true,
 	}
 	bc := NewFakeBuilder(nil, fakeReg, fakeConfig)
-	alreadyBuilt := []string{"test/test-1", "test/test-2", "okteto.dev/test-test-3:okteto", "okteto.dev/test-test-4:okteto"}
+	alreadyBuilt := []string{
+		"test/test-1",
+		"test/test-2",
+		fmt.Sprintf("okteto.dev/test-test-3:%s", bc.smartBuildCtrl.GetBuildHash(fakeManifest.Build["test-3"], "test-3")),
+		fmt.Sprintf("okteto.dev/test-test-4:%s", bc.smartBuildCtrl.GetBuildHash(fakeManifest.Build["test-4"], "test-4")),
+	}
 	require.NoError(t, fakeReg.AddImageByName(alreadyBuilt...))
 	ctx := context.Background()
 	toBuild, err := bc.GetServicesToBuildDuringExecution(ctx, fakeManifest, []string{})
@@ -215,6 +225,96 @@ func TestNoServiceBuiltWithSubset(t *testing.T) {
 	require.Equal(t, 0, len(toBuild))
 }
 
+func TestServicesBuiltFromOtherSourcesAreRebuilt(t *testing.T) {
+	fakeReg := newFakeRegistry()
+	bc := NewFakeBuilder(nil, fakeReg, fakeConfig{isOkteto: true})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+			},
+		},
+	}
+	// the "okteto" tag exists but it was built from other sources, as the build hash tag doesn't exist
+	require.NoError(t, fakeReg.AddImageByName("okteto.dev/test-api:okteto"))
+
+	toBuild, err := bc.GetServicesToBuildDuringExecution(context.Background(), manifest, []string{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"api"}, toBuild)
+
+	require.NoError(t, fakeReg.AddImageByName(fmt.Sprintf("okteto.dev/test-api:%s", bc.smartBuildCtrl.GetBuildHash(manifest.Build["api"], "api"))))
+	toBuild, err = bc.GetServicesToBuildDuringExecution(context.Background(), manifest, []string{})
+	require.NoError(t, err)
+	require.Empty(t, toBuild)
+}
+
+func TestServicesWithoutGitMetadataOnlyCheckOktetoTag(t *testing.T) {
+	fakeReg := newFakeRegistry()
+	bc := NewFakeBuilder(nil, fakeReg, fakeConfig{isOkteto: true})
+	bc.smartBuildCtrl = smartbuild.NewSmartBuildCtrl(fakeConfigRepo{err: assert.AnError}, fakeReg, afero.NewMemMapFs(), io.NewIOController())
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+			},
+		},
+	}
+	require.NoError(t, fakeReg.AddImageByName("okteto.dev/test-api:okteto"))
+
+	toBuild, err := bc.GetServicesToBuildDuringExecution(context.Background(), manifest, []string{})
+	require.NoError(t, err)
+	require.Empty(t, toBuild)
+}
+
+func TestServicesDependingOnServicesToBuildAreRebuilt(t *testing.T) {
+	fakeReg := newFakeRegistry()
+	bc := NewFakeBuilder(nil, fakeReg, fakeConfig{isOkteto: true})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"base": &build.Info{
+				Image:      "okteto.dev/base:1.0",
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+			},
+			"api": &build.Info{
+				Image:      "okteto.dev/api:1.0",
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+				DependsOn:  []string{"base"},
+			},
+			"frontend": &build.Info{
+				Image:      "okteto.dev/frontend:1.0",
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+			},
+		},
+	}
+	require.NoError(t, fakeReg.AddImageByName("okteto.dev/api:1.0", "okteto.dev/frontend:1.0"))
+
+	toBuild, err := bc.GetServicesToBuildDuringExecution(context.Background(), manifest, []string{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"base", "api"}, toBuild)
+}
+
+func Test_addDependentServices(t *testing.T) {
+	buildManifest := build.ManifestBuild{
+		"a": &build.Info{},
+		"b": &build.Info{DependsOn: []string{"a"}},
+		"c": &build.Info{DependsOn: []string{"b"}},
+		"d": &build.Info{},
+	}
+	candidates := map[string]bool{"a": true, "b": true, "c": true, "d": true}
+
+	require.ElementsMatch(t, []string{"a", "b", "c"}, addDependentServices(buildManifest, []string{"a"}, candidates))
+	require.ElementsMatch(t, []string{"d"}, addDependentServices(buildManifest, []string{"d"}, candidates))
+	require.ElementsMatch(t, []string{"a", "b"}, addDependentServices(buildManifest, []string{"a"}, map[string]bool{"a": true, "b": true}))
+}
+
 type fakeConfig struct {
 	sha                 string
 	repoURL             string
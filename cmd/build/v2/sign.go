@@ -0,0 +1,51 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/cosign"
+)
+
+// imageSigner signs the images once they are pushed
+type imageSigner interface {
+	Sign(ctx context.Context, image, key string) error
+}
+
+func newCosignSigner() (imageSigner, error) {
+	return cosign.New()
+}
+
+// signImages signs every pushed tag of a service, as the signatures are stored per repository
+func (bc *OktetoBuilder) signImages(ctx context.Context, svcName string, tags []string, key string) error {
+	newSigner := bc.newSigner
+	if newSigner == nil {
+		newSigner = newCosignSigner
+	}
+	signer, err := newSigner()
+	if err != nil {
+		return err
+	}
+
+	bc.ioCtrl.Out().Infof("Signing image of service '%s'", svcName)
+	for _, tag := range tags {
+		if err := signer.Sign(ctx, tag, key); err != nil {
+			return fmt.Errorf("error signing image of service '%s': %w", svcName, err)
+		}
+	}
+	bc.ioCtrl.Out().Success("Image of service '%s' signed", svcName)
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -38,10 +39,16 @@ type repositoryCommitRetriever interface {
 type serviceHasher struct {
 	gitRepoCtrl repositoryCommitRetriever
 
+	// siblingRepos resolves the repositories of the build contexts outside of the repository of the manifest
+	siblingRepos *siblingRepositories
+
 	fs afero.Fs
 
 	serviceShaCache map[string]string
 
+	// randomShaServices are the services whose hash was generated randomly
+	randomShaServices map[string]bool
+
 	getCurrentTimestampNano func() int64
 	projectCommit           string
 
@@ -52,7 +59,9 @@ type serviceHasher struct {
 func newServiceHasher(gitRepoCtrl repositoryCommitRetriever, fs afero.Fs) *serviceHasher {
 	return &serviceHasher{
 		gitRepoCtrl:             gitRepoCtrl,
+		siblingRepos:            newSiblingRepositories(),
 		serviceShaCache:         map[string]string{},
+		randomShaServices:       map[string]bool{},
 		fs:                      fs,
 		getCurrentTimestampNano: time.Now().UnixNano,
 	}
@@ -78,42 +87,73 @@ func (sh *serviceHasher) hashProjectCommit(buildInfo *build.Info) (string, error
 
 // hashBuildContext returns the hash of the service using its context tree hash
 func (sh *serviceHasher) hashWithBuildContext(buildInfo *build.Info, service string) string {
+	sh.lock.RLock()
+	hash, ok := sh.serviceShaCache[service]
+	sh.lock.RUnlock()
+	if ok {
+		return hash
+	}
+
 	buildContext := buildInfo.Context
 	if buildContext == "" {
 		buildContext = "."
 	}
-	if _, ok := sh.serviceShaCache[service]; !ok {
-		errorGettingGitInfo := false
-		dirCommit, err := sh.gitRepoCtrl.GetLatestDirSHA(buildContext)
-		if err != nil {
-			errorGettingGitInfo = true
+	// the sources of a remote context are only known by the builder, so its image is always rebuilt
+	if build.IsRemoteContext(buildContext) {
+		oktetoLog.Infof("build context of service '%s' is remote, generating a random sha", service)
+		hash = sh.hash(buildInfo, sh.calculateRandomShaForService(service), "")
+		sh.lock.Lock()
+		sh.serviceShaCache[service] = hash
+		sh.randomShaServices[service] = true
+		sh.lock.Unlock()
+		return hash
+	}
 
-			oktetoLog.Infof("could not get build context sha: %s, generating a random one", err)
-			// In case of error getting the dir commit, we just generate a random one, and it will rebuild the image
-			dirCommit = sh.calculateRandomShaForService(service)
-		}
+	repo, repoContext := sh.getContextRepository(buildContext)
+	errorGettingGitInfo := false
+	dirCommit, err := repo.GetLatestDirSHA(repoContext)
+	if err != nil {
+		errorGettingGitInfo = true
+		oktetoLog.Infof("could not get build context sha: %s, generating a random one", err)
+		// In case of error getting the dir commit, we just generate a random one, and it will rebuild the image
+		dirCommit = sh.calculateRandomShaForService(service)
+	}
+	diffHash, err := repo.GetDiffHash(repoContext)
+	if err != nil {
+		errorGettingGitInfo = true
+		oktetoLog.Infof("could not get build context diff sha: %s, generating a random one", err)
+		// In case of error getting the diff hash, we just generate a random one, and it will rebuild the image
+		diffHash = sh.calculateRandomShaForService(service)
+	}
 
-		diffHash, err := sh.gitRepoCtrl.GetDiffHash(buildContext)
-		if err != nil {
-			errorGettingGitInfo = true
-			oktetoLog.Infof("could not get build context diff sha: %s, generating a random one", err)
-			// In case of error getting the diff hash, we just generate a random one, and it will rebuild the image
-			diffHash = sh.calculateRandomShaForService(service)
-		}
+	// This is to display just one single warning if any of the git operation fails. As we generate random sha
+	// it will imply a new build of image, and we want to warn users
+	if errorGettingGitInfo {
+		oktetoLog.Warning("Smart builds cannot access git metadata, building image %q...", service)
+	}
 
-		// This is to display just one single warning if any of the git operation fails. As we generate random sha
-		// it will imply a new build of image, and we want to warn users
-		if errorGettingGitInfo {
-			oktetoLog.Warning("Smart builds cannot access git metadata, building image %q...", service)
-		}
+	hash = sh.hash(buildInfo, dirCommit, diffHash)
+	sh.lock.Lock()
+	sh.serviceShaCache[service] = hash
+	sh.randomShaServices[service] = errorGettingGitInfo
+	sh.lock.Unlock()
+	return hash
+}
 
-		sh.lock.Lock()
-		hash := sh.hash(buildInfo, dirCommit, diffHash)
-		sh.serviceShaCache[service] = hash
-		sh.lock.Unlock()
+// getContextRepository returns the repository of the build context and the path of the build context inside of it
+func (sh *serviceHasher) getContextRepository(buildContext string) (repositoryCommitRetriever, string) {
+	if sh.siblingRepos == nil {
+		return sh.gitRepoCtrl, buildContext
 	}
+	return sh.siblingRepos.get(sh.gitRepoCtrl, buildContext)
+}
 
-	return sh.serviceShaCache[service]
+// isDeterministic returns false if the hash of the service was generated randomly because the git metadata
+// of its build context was not available
+func (sh *serviceHasher) isDeterministic(service string) bool {
+	sh.lock.RLock()
+	defer sh.lock.RUnlock()
+	return !sh.randomShaServices[service]
 }
 
 // calculateRandomShaForService generates a random sha for the given service taking into account current timestamp
@@ -128,14 +168,16 @@ func (sh *serviceHasher) calculateRandomShaForService(service string) string {
 func (sh *serviceHasher) hash(buildInfo *build.Info, commitHash string, diff string) string {
 	args := []string{}
 	for _, arg := range buildInfo.Args {
-		args = append(args, arg.String())
+		args = append(args, getArgProvenance(arg))
 	}
+	sort.Strings(args)
 	argsText := strings.Join(args, ";")
 
 	secrets := []string{}
 	for key, value := range buildInfo.Secrets {
 		secrets = append(secrets, fmt.Sprintf("%s=%s", key, value))
 	}
+	sort.Strings(secrets)
 	secretsText := strings.Join(secrets, ";")
 
 	// We use a builder to avoid allocations when building the string
@@ -146,9 +188,22 @@ func (sh *serviceHasher) hash(buildInfo *build.Info, commitHash string, diff str
 	fmt.Fprintf(&b, "build_args:%s;", argsText)
 	fmt.Fprintf(&b, "secrets:%s;", secretsText)
 	fmt.Fprintf(&b, "context:%s;", buildInfo.Context)
-	fmt.Fprintf(&b, "dockerfile_content:%s;", sh.getDockerfileContent(buildInfo.Context, buildInfo.Dockerfile))
+	fmt.Fprintf(&b, "dockerfile_content:%s;", sh.getDockerfileHash(buildInfo))
 	fmt.Fprintf(&b, "diff:%s;", diff)
 	fmt.Fprintf(&b, "image:%s;", buildInfo.Image)
+	if buildInfo.Reproducible {
+		b.WriteString("reproducible:true;")
+	}
+	if len(buildInfo.Platforms) != 0 {
+		fmt.Fprintf(&b, "platforms:%s;", strings.Join(buildInfo.Platforms, ","))
+	}
+	if buildInfo.CustomBuilder != nil {
+		fmt.Fprintf(&b, "builder:%s/%s/%s;", buildInfo.CustomBuilder.Type, buildInfo.CustomBuilder.GetImage(), buildInfo.CustomBuilder.GetPath())
+	}
+	if buildInfo.Sign {
+		// images built before enabling signing are not signed, so they can't be reused
+		b.WriteString("sign:true;")
+	}
 
 	hashFrom := b.String()
 	oktetoLog.Infof("hashing build info: %s", hashFrom)
@@ -156,6 +211,25 @@ func (sh *serviceHasher) hash(buildInfo *build.Info, commitHash string, diff str
 	return hex.EncodeToString(oktetoBuildHash[:])
 }
 
+// getArgProvenance returns the build arg with its value expanded. Values referencing the images built by okteto
+// are not expanded, as they might not be available yet when checking if the image is built:
+// changes on the images a service depends on are handled by rebuilding the service
+func getArgProvenance(arg build.Arg) string {
+	if strings.Contains(arg.Value, "OKTETO_BUILD_") {
+		return fmt.Sprintf("%s=%s", arg.Name, arg.Value)
+	}
+	return arg.String()
+}
+
+// getDockerfileHash returns the hash of the content of the Dockerfile of the build, which can be inline
+func (sh *serviceHasher) getDockerfileHash(buildInfo *build.Info) string {
+	if buildInfo.DockerfileInline != "" {
+		encodedFile := sha256.Sum256([]byte(buildInfo.DockerfileInline))
+		return hex.EncodeToString(encodedFile[:])
+	}
+	return sh.getDockerfileContent(buildInfo.Context, buildInfo.Dockerfile)
+}
+
 // getDockerfileContent returns the content of the Dockerfile
 func (sh *serviceHasher) getDockerfileContent(dockerfileContext, dockerfilePath string) string {
 	content, err := afero.ReadFile(sh.fs, dockerfilePath)
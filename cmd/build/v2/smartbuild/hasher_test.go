@@ -62,16 +62,18 @@ func TestServiceHasher_HashBuildContext(t *testing.T) {
 	fakeErr := errors.New("fake error")
 	serviceName := "fake-service"
 	tests := []struct {
-		repoCtrl     repositoryCommitRetriever
-		name         string
-		expectedHash string
+		repoCtrl              repositoryCommitRetriever
+		name                  string
+		expectedHash          string
+		expectedDeterministic bool
 	}{
 		{
 			name: "success",
 			repoCtrl: fakeConfigRepo{
 				sha: "testtreehash",
 			},
-			expectedHash: "b6f9d71cf55933c6e385102e196522fd73c279c1edaa919b565706fb0bc3d8ce",
+			expectedHash:          "b6f9d71cf55933c6e385102e196522fd73c279c1edaa919b565706fb0bc3d8ce",
+			expectedDeterministic: true,
 		},
 		{
 			name: "error",
@@ -90,10 +92,73 @@ func TestServiceHasher_HashBuildContext(t *testing.T) {
 				getCurrentTimestampNano: func() int64 {
 					return int64(12312345252)
 				},
-				serviceShaCache: map[string]string{},
+				serviceShaCache:   map[string]string{},
+				randomShaServices: map[string]bool{},
 			}
 			hash := sh.hashWithBuildContext(&build.Info{}, serviceName)
 			assert.Equal(t, tt.expectedHash, hash)
+			assert.Equal(t, tt.expectedDeterministic, sh.isDeterministic(serviceName))
 		})
 	}
 }
+
+func TestServiceHasher_HashRemoteBuildContext(t *testing.T) {
+	sh := &serviceHasher{
+		gitRepoCtrl: fakeConfigRepo{
+			sha: "testtreehash",
+		},
+		fs: afero.NewMemMapFs(),
+		getCurrentTimestampNano: func() int64 {
+			return int64(12312345252)
+		},
+		serviceShaCache:   map[string]string{},
+		randomShaServices: map[string]bool{},
+	}
+	sh.hashWithBuildContext(&build.Info{Context: "https://github.com/okteto/movies.git#main:api"}, "api")
+	assert.False(t, sh.isDeterministic("api"))
+}
+
+func TestServiceHasher_HashIsIndependentOfArgsOrder(t *testing.T) {
+	sh := newServiceHasher(fakeConfigRepo{}, afero.NewMemMapFs())
+	first := sh.hash(&build.Info{
+		Args: build.Args{
+			{Name: "A", Value: "1"},
+			{Name: "B", Value: "2"},
+		},
+		Secrets: build.Secrets{"s1": "a", "s2": "b"},
+	}, "commit", "")
+	second := sh.hash(&build.Info{
+		Args: build.Args{
+			{Name: "B", Value: "2"},
+			{Name: "A", Value: "1"},
+		},
+		Secrets: build.Secrets{"s2": "b", "s1": "a"},
+	}, "commit", "")
+	assert.Equal(t, first, second)
+}
+
+func TestGetArgProvenance(t *testing.T) {
+	t.Setenv("MY_VALUE", "expanded")
+	t.Setenv("OKTETO_BUILD_API_IMAGE", "okteto.dev/api:1234")
+
+	assert.Equal(t, "A=expanded", getArgProvenance(build.Arg{Name: "A", Value: "$MY_VALUE"}))
+	assert.Equal(t, "B=${OKTETO_BUILD_API_IMAGE}", getArgProvenance(build.Arg{Name: "B", Value: "${OKTETO_BUILD_API_IMAGE}"}))
+}
+
+func TestServiceHasher_HashDependsOnPlatforms(t *testing.T) {
+	sh := newServiceHasher(fakeConfigRepo{}, afero.NewMemMapFs())
+	single := sh.hash(&build.Info{}, "commit", "")
+	amd := sh.hash(&build.Info{Platforms: []string{"linux/amd64"}}, "commit", "")
+	multiArch := sh.hash(&build.Info{Platforms: []string{"linux/amd64", "linux/arm64"}}, "commit", "")
+	assert.NotEqual(t, single, amd)
+	assert.NotEqual(t, amd, multiArch)
+}
+
+func TestServiceHasher_HashDependsOnDockerfileInline(t *testing.T) {
+	sh := newServiceHasher(fakeConfigRepo{}, afero.NewMemMapFs())
+	first := sh.hash(&build.Info{DockerfileInline: "FROM alpine"}, "commit", "")
+	same := sh.hash(&build.Info{DockerfileInline: "FROM alpine"}, "commit", "")
+	second := sh.hash(&build.Info{DockerfileInline: "FROM busybox"}, "commit", "")
+	assert.Equal(t, first, same)
+	assert.NotEqual(t, first, second)
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smartbuild
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/build"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/repository"
+)
+
+// siblingRepositories resolves the git repositories of the build contexts checked out next to the repository
+// of the manifest, so the changes of their build contexts are detected with their own git metadata
+type siblingRepositories struct {
+	findRoot      func(path string) (string, error)
+	newRepository func(path string) repositoryCommitRetriever
+
+	// root is the top level directory of the repository of the manifest
+	root  string
+	repos map[string]repositoryCommitRetriever
+
+	lock sync.Mutex
+}
+
+func newSiblingRepositories() *siblingRepositories {
+	sr := &siblingRepositories{
+		findRoot: repository.FindTopLevelGitDir,
+		newRepository: func(path string) repositoryCommitRetriever {
+			return repository.NewRepository(path)
+		},
+		repos: map[string]repositoryCommitRetriever{},
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		oktetoLog.Infof("could not get working dir: %s", err)
+		return sr
+	}
+	sr.root, err = sr.findRoot(wd)
+	if err != nil {
+		oktetoLog.Infof("could not get top level git dir: %s", err)
+	}
+	return sr
+}
+
+// get returns the repository of the build context and the path of the build context inside of it.
+// Build contexts that are not in a sibling repository use the repository of the manifest
+func (sr *siblingRepositories) get(main repositoryCommitRetriever, buildContext string) (repositoryCommitRetriever, string) {
+	if sr.root == "" || build.IsRemoteContext(buildContext) {
+		return main, buildContext
+	}
+	absContext, err := filepath.Abs(buildContext)
+	if err != nil {
+		return main, buildContext
+	}
+	root, err := sr.findRoot(absContext)
+	if err != nil || root == sr.root {
+		return main, buildContext
+	}
+	relContext, err := filepath.Rel(root, absContext)
+	if err != nil {
+		return main, buildContext
+	}
+
+	sr.lock.Lock()
+	defer sr.lock.Unlock()
+	repo, ok := sr.repos[root]
+	if !ok {
+		oktetoLog.Infof("build context '%s' belongs to the sibling repository '%s'", buildContext, root)
+		repo = sr.newRepository(root)
+		sr.repos[root] = repo
+	}
+	return repo, relContext
+}
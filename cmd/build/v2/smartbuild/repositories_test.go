@@ -0,0 +1,129 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smartbuild
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeSiblingRepositories(root string, siblings ...string) *siblingRepositories {
+	return &siblingRepositories{
+		root: root,
+		findRoot: func(path string) (string, error) {
+			for _, s := range siblings {
+				if path == s || strings.HasPrefix(path, s+string(filepath.Separator)) {
+					return s, nil
+				}
+			}
+			if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+				return root, nil
+			}
+			return "", errors.New("not a git repository")
+		},
+		newRepository: func(path string) repositoryCommitRetriever {
+			return fakeConfigRepo{sha: path}
+		},
+		repos: map[string]repositoryCommitRetriever{},
+	}
+}
+
+func TestSiblingRepositoriesGet(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	main := fakeConfigRepo{sha: "main"}
+	sibling := filepath.Join(filepath.Dir(wd), "api")
+
+	tests := []struct {
+		sr              *siblingRepositories
+		name            string
+		buildContext    string
+		expectedSHA     string
+		expectedContext string
+	}{
+		{
+			name:            "context in the repository of the manifest",
+			sr:              newFakeSiblingRepositories(wd, sibling),
+			buildContext:    "frontend",
+			expectedSHA:     "main",
+			expectedContext: "frontend",
+		},
+		{
+			name:            "context in a sibling repository",
+			sr:              newFakeSiblingRepositories(wd, sibling),
+			buildContext:    "../api/server",
+			expectedSHA:     sibling,
+			expectedContext: "server",
+		},
+		{
+			name:            "context at the root of a sibling repository",
+			sr:              newFakeSiblingRepositories(wd, sibling),
+			buildContext:    "../api",
+			expectedSHA:     sibling,
+			expectedContext: ".",
+		},
+		{
+			name:            "context outside of any repository",
+			sr:              newFakeSiblingRepositories(wd),
+			buildContext:    "../api",
+			expectedSHA:     "main",
+			expectedContext: "../api",
+		},
+		{
+			name:            "remote context",
+			sr:              newFakeSiblingRepositories(wd, sibling),
+			buildContext:    "https://github.com/okteto/api.git",
+			expectedSHA:     "main",
+			expectedContext: "https://github.com/okteto/api.git",
+		},
+		{
+			name:            "manifest without repository",
+			sr:              newFakeSiblingRepositories("", sibling),
+			buildContext:    "../api",
+			expectedSHA:     "main",
+			expectedContext: "../api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, buildContext := tt.sr.get(main, tt.buildContext)
+			sha, err := repo.GetSHA()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSHA, sha)
+			assert.Equal(t, tt.expectedContext, buildContext)
+		})
+	}
+}
+
+func TestSiblingRepositoriesGetIsCached(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	sibling := filepath.Join(filepath.Dir(wd), "api")
+	sr := newFakeSiblingRepositories(wd, sibling)
+	created := 0
+	sr.newRepository = func(path string) repositoryCommitRetriever {
+		created++
+		return fakeConfigRepo{sha: path}
+	}
+
+	sr.get(fakeConfigRepo{}, "../api/server")
+	sr.get(fakeConfigRepo{}, "../api/worker")
+	assert.Equal(t, 1, created)
+}
@@ -45,6 +45,7 @@ type repositoryInterface interface {
 type hasherController interface {
 	hashProjectCommit(*build.Info) (string, error)
 	hashWithBuildContext(*build.Info, string) string
+	isDeterministic(string) bool
 }
 
 // Ctrl is the controller for smart builds
@@ -95,6 +96,13 @@ func (s *Ctrl) GetBuildHash(buildInfo *build.Info, service string) string {
 	return s.hasher.hashWithBuildContext(buildInfo, service)
 }
 
+// IsBuildHashDeterministic returns if the build hash of the service identifies the sources of its image.
+// It is false when the git metadata of the build context is not available and the hash is generated randomly
+func (s *Ctrl) IsBuildHashDeterministic(buildInfo *build.Info, service string) bool {
+	s.hasher.hashWithBuildContext(buildInfo, service)
+	return s.hasher.isDeterministic(service)
+}
+
 // CloneGlobalImageToDev clones the image from the global registry to the dev registry if needed
 // if the built image belongs to global registry we clone it to the dev registry
 // so that in can be used in dev containers (i.e. okteto up)
@@ -48,14 +48,16 @@ func (fr fakeRegistryController) Clone(from, to string) (string, error) {
 }
 
 type fakeHasher struct {
-	err  error
-	hash string
+	err                error
+	hash               string
+	isNotDeterministic bool
 }
 
 func (fh fakeHasher) hashProjectCommit(*build.Info) (string, error) { return fh.hash, fh.err }
 func (fh fakeHasher) hashWithBuildContext(*build.Info, string) string {
 	return fh.hash
 }
+func (fh fakeHasher) isDeterministic(string) bool { return !fh.isNotDeterministic }
 
 func TestNewSmartBuildCtrl(t *testing.T) {
 	type input struct {
@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"os"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// getTagStrategy returns the tag strategy of the manifest, or the one of the okteto context if the manifest doesn't define it
+func (bc *OktetoBuilder) getTagStrategy(manifest *model.Manifest) string {
+	if manifest != nil && manifest.TagStrategy != "" {
+		return manifest.TagStrategy
+	}
+	return bc.tagStrategy
+}
+
+// getImageTagger returns the image tagger for the service, using the tag given by the tag strategy.
+// The branch-timestamp strategy gives a new tag on every build, so its images are never reused by deploy operations
+func (bc *OktetoBuilder) getImageTagger(manifest *model.Manifest, svcName string, buildInfo *build.Info, buildHash string) imageTagger {
+	it := newImageTagger(bc.Config, bc.smartBuildCtrl)
+
+	strategy := bc.getTagStrategy(manifest)
+	if strategy == "" {
+		return it
+	}
+
+	inputs := build.TagInputs{Time: bc.buildTime}
+	switch strategy {
+	case build.TagStrategyGitSHA:
+		inputs.Commit = bc.Config.GetGitCommit()
+	case build.TagStrategyContentHash:
+		inputs.BuildHash = buildHash
+		if inputs.BuildHash == "" {
+			inputs.BuildHash = bc.smartBuildCtrl.GetBuildHash(buildInfo, svcName)
+		}
+	case build.TagStrategyBranchTimestamp:
+		inputs.Branch = getCurrentBranch()
+	}
+
+	it.tag = build.GetTagForStrategy(strategy, inputs)
+	if it.tag == "" {
+		bc.ioCtrl.Logger().Infof("could not compute the '%s' tag for service '%s', using the default tag", strategy, svcName)
+	}
+	return it
+}
+
+// getCurrentBranch returns the branch being deployed, or the branch of the git repository of the working directory
+func getCurrentBranch() string {
+	if branch := os.Getenv(constants.OktetoGitBranchEnvVar); branch != "" {
+		return branch
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	branch, err := utils.GetBranch(wd)
+	if err != nil {
+		return ""
+	}
+	return branch
+}
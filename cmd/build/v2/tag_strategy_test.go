@@ -0,0 +1,73 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetImageTaggerWithTagStrategy(t *testing.T) {
+	t.Setenv(constants.OktetoGitBranchEnvVar, "feature/login")
+	buildInfo := &build.Info{Context: ".", Dockerfile: "Dockerfile"}
+
+	tests := []struct {
+		name             string
+		manifestStrategy string
+		contextStrategy  string
+		expected         string
+	}{
+		{
+			name:     "default tag",
+			expected: "okteto.dev/test-api:okteto",
+		},
+		{
+			name:            "git sha from the context",
+			contextStrategy: build.TagStrategyGitSHA,
+			expected:        "okteto.dev/test-api:a1b2c3",
+		},
+		{
+			name:             "manifest overrides the context",
+			manifestStrategy: build.TagStrategyBranchTimestamp,
+			contextStrategy:  build.TagStrategyGitSHA,
+			expected:         "okteto.dev/test-api:feature-login-20231005143000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := NewFakeBuilder(nil, fakeRegistry{}, fakeConfig{sha: "a1b2c3", isOkteto: true})
+			bc.tagStrategy = tt.contextStrategy
+			bc.buildTime = time.Date(2023, 10, 5, 14, 30, 0, 0, time.UTC)
+			manifest := &model.Manifest{Name: "test", TagStrategy: tt.manifestStrategy}
+
+			it := bc.getImageTagger(manifest, "api", buildInfo, "")
+			assert.Equal(t, tt.expected, it.getServiceDevImageReference(manifest.Name, "api", buildInfo))
+		})
+	}
+}
+
+func TestGetImageTaggerWithoutCommit(t *testing.T) {
+	bc := NewFakeBuilder(nil, fakeRegistry{}, fakeConfig{isOkteto: true})
+	bc.tagStrategy = build.TagStrategyGitSHA
+	buildInfo := &build.Info{Context: ".", Dockerfile: "Dockerfile"}
+
+	it := bc.getImageTagger(&model.Manifest{Name: "test"}, "api", buildInfo, "")
+	assert.Equal(t, []string{"okteto.dev/test-api:okteto"}, it.getImageReferencesForDeploy("test", "api"))
+}
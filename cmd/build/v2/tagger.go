@@ -42,6 +42,9 @@ type smartBuildController interface {
 type imageTagger struct {
 	cfg                  oktetoBuilderConfigInterface
 	smartBuildController smartBuildController
+
+	// tag is the tag of the inferred image references, set by the tag strategy. Defaults to "okteto"
+	tag string
 }
 
 func getTargetRegistries(isOkteto bool) []string {
@@ -69,7 +72,7 @@ When service image is set on manifest, this is the returned one.
 
 Inferred tag is constructed using the following:
 [name] is the combination of the targetRegistry, manifestName and serviceName
-[tag] it is the default okteto tag "okteto".
+[tag] it is the tag given by the tag strategy, or the default okteto tag "okteto".
 */
 func (it imageTagger) getServiceDevImageReference(manifestName, svcName string, b *build.Info) string {
 	// when b.Image is set or services does not have dockerfile then no infer reference and return what is set on the manifest
@@ -80,7 +83,22 @@ func (it imageTagger) getServiceDevImageReference(manifestName, svcName string,
 	// build the image reference based on context and buildInfo
 	targetRegistry := constants.DevRegistry
 	sanitizedName := format.ResourceK8sMetaString(manifestName)
-	return useReferenceTemplate(targetRegistry, sanitizedName, svcName, model.OktetoDefaultImageTag)
+	return useReferenceTemplate(targetRegistry, sanitizedName, svcName, it.getDevTag())
+}
+
+// getDevTag returns the tag of the inferred image references
+func (it imageTagger) getDevTag() string {
+	if it.tag != "" {
+		return it.tag
+	}
+	return model.OktetoDefaultImageTag
+}
+
+// getServiceDevImageReferenceForHash returns the image reference [name]:[tag] in the dev registry for the given service,
+// being [tag] the build hash of the service
+func (imageTagger) getServiceDevImageReferenceForHash(manifestName, svcName, buildHash string) string {
+	sanitizedName := format.ResourceK8sMetaString(manifestName)
+	return useReferenceTemplate(constants.DevRegistry, sanitizedName, svcName, buildHash)
 }
 
 func (it imageTagger) getGlobalTagFromDevIfNeccesary(tags, namespace, registryURL, buildHash string, ic registry.ImageCtrl) string {
@@ -125,10 +143,11 @@ func (it imageTagger) getImageReferencesForTag(manifestName, svcToBuildName, tag
 }
 
 // getImageReferencesForDeploy returns the list of images references for a service when deploying it. In case of deploy,
-// we only have to check if the image is present with the okteto tag. We don't check anything related to the hash
-func (imageTagger) getImageReferencesForDeploy(manifestName, svcToBuildName string) []string {
+// we only have to check if the image is present with the tag given by the tag strategy, or the okteto tag.
+// We don't check anything related to the hash
+func (it imageTagger) getImageReferencesForDeploy(manifestName, svcToBuildName string) []string {
 	sanitizedName := format.ResourceK8sMetaString(manifestName)
-	imageReferences := []string{useReferenceTemplate(constants.DevRegistry, sanitizedName, svcToBuildName, model.OktetoDefaultImageTag)}
+	imageReferences := []string{useReferenceTemplate(constants.DevRegistry, sanitizedName, svcToBuildName, it.getDevTag())}
 
 	return imageReferences
 }
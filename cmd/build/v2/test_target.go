@@ -0,0 +1,117 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/build"
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+)
+
+// testResultsStage is the stage appended to the Dockerfile to export the test results of a service
+const testResultsStage = "okteto-test-results"
+
+// getTestResultsPath returns the local folder where the test results of a service are exported
+func getTestResultsPath(svcName string) string {
+	return filepath.Join(".okteto", "test-results", svcName)
+}
+
+// runTestTarget builds the test stage of a service before its image, so the build fails if the tests fail.
+// If the service defines 'test_results', the content of that folder is exported to the local results folder once the stage succeeds
+func (bc *OktetoBuilder) runTestTarget(ctx context.Context, fs afero.Fs, manifestName, svcName string, info *build.Info, options *types.BuildOptions) error {
+	testInfo := info.Copy()
+	testInfo.Target = info.TestTarget
+	testInfo.Image = ""
+	testOptions := *options
+	testOptions.Target = ""
+	testOptions.Tag = ""
+	opts := buildCmd.OptsFromBuildInfo(manifestName, svcName, testInfo, &testOptions, bc.Registry, bc.oktetoContext)
+	// the test stage is never pushed and must not overwrite the cache of the image
+	opts.Tag = ""
+	opts.ExportCache = nil
+	opts.SBOM = ""
+	opts.Provenance = ""
+
+	if options.EnableStages {
+		bc.ioCtrl.SetStage(fmt.Sprintf("Testing service %s", svcName))
+		defer bc.ioCtrl.SetStage(fmt.Sprintf("Building service %s", svcName))
+	}
+	bc.ioCtrl.Out().Infof("Running test stage '%s' of service '%s'", info.TestTarget, svcName)
+
+	resultsPath := ""
+	if info.TestResults != "" {
+		if bc.oktetoContext.GetCurrentBuilder() == "" && !buildCmd.IsDepotEnabled() {
+			bc.ioCtrl.Out().Warning("The test results of service '%s' can't be exported by your local docker daemon. Use a BuildKit builder to export them", svcName)
+		} else {
+			dockerfile, err := writeTestResultsDockerfile(fs, opts.File, info.TestTarget, info.TestResults)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := fs.Remove(dockerfile); err != nil {
+					bc.ioCtrl.Logger().Infof("failed to remove '%s': %s", dockerfile, err)
+				}
+			}()
+
+			resultsPath = getTestResultsPath(svcName)
+			if err := fs.RemoveAll(resultsPath); err != nil {
+				return fmt.Errorf("failed to clean the test results of service '%s': %w", svcName, err)
+			}
+			opts.File = dockerfile
+			opts.Target = testResultsStage
+			opts.LocalOutputPath = resultsPath
+		}
+	}
+
+	if err := bc.Builder.BuildRunner.Run(ctx, opts, bc.ioCtrl); err != nil {
+		return fmt.Errorf("test stage '%s' of service '%s' failed: %w", info.TestTarget, svcName, err)
+	}
+
+	bc.ioCtrl.Out().Success("Test stage '%s' of service '%s' passed", info.TestTarget, svcName)
+	if resultsPath != "" {
+		bc.ioCtrl.Out().Infof("Test results of service '%s' exported to '%s'", svcName, resultsPath)
+	}
+	return nil
+}
+
+// writeTestResultsDockerfile writes a copy of the Dockerfile with an extra stage that only contains the test results
+// of the test stage, so BuildKit exports the results folder instead of the whole filesystem of the stage
+func writeTestResultsDockerfile(fs afero.Fs, dockerfile, target, results string) (string, error) {
+	content, err := afero.ReadFile(fs, dockerfile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read '%s': %w", dockerfile, err)
+	}
+
+	tmpFolder := filepath.Join(config.GetOktetoHome(), ".dockerfile")
+	if err := fs.MkdirAll(tmpFolder, 0700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", tmpFolder, err)
+	}
+	tmpFile, err := afero.TempFile(fs, tmpFolder, "test-")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+
+	stage := fmt.Sprintf("\nFROM scratch AS %s\nCOPY --from=%s %s /\n", testResultsStage, target, results)
+	if _, err := tmpFile.Write(append(content, []byte(stage)...)); err != nil {
+		return "", fmt.Errorf("failed to write dockerfile: %w", err)
+	}
+	return tmpFile.Name(), nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTestStageRunner struct {
+	fs          afero.Fs
+	err         error
+	opts        []types.BuildOptions
+	dockerfiles []string
+}
+
+func (*fakeTestStageRunner) GetBuilder() string {
+	return "test"
+}
+
+func (f *fakeTestStageRunner) Run(_ context.Context, opts *types.BuildOptions, _ *io.Controller) error {
+	f.opts = append(f.opts, *opts)
+	content, _ := afero.ReadFile(f.fs, opts.File)
+	f.dockerfiles = append(f.dockerfiles, string(content))
+	return f.err
+}
+
+func newTestStageBuilder(runner *fakeTestStageRunner, builder string) *OktetoBuilder {
+	registry := newFakeRegistry()
+	bc := NewFakeBuilder(runner, registry, fakeConfig{isOkteto: true})
+	bc.oktetoContext = &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+					IsOkteto:  true,
+					Registry:  "my-registry",
+					Builder:   builder,
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+	return bc
+}
+
+func TestRunTestTarget(t *testing.T) {
+	t.Setenv("OKTETO_HOME", t.TempDir())
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "Dockerfile"), []byte("FROM golang AS test\nRUN go test ./... > /reports/out.txt\nFROM alpine"), 0600))
+
+	runner := &fakeTestStageRunner{fs: fs}
+	bc := newTestStageBuilder(runner, "tcp://buildkit:443")
+	info := &build.Info{
+		Context:     dir,
+		Dockerfile:  "Dockerfile",
+		Image:       "okteto.dev/test-api:okteto",
+		Target:      "prod",
+		TestTarget:  "test",
+		TestResults: "/reports",
+		ExportCache: []string{"okteto.dev/test-api:cache"},
+	}
+
+	err := bc.runTestTarget(context.Background(), fs, "test", "api", info, &types.BuildOptions{Tag: "okteto.dev/test-api:dev"})
+	require.NoError(t, err)
+	require.Len(t, runner.opts, 1)
+	opts := runner.opts[0]
+	assert.Empty(t, opts.Tag)
+	assert.Empty(t, opts.ExportCache)
+	assert.Equal(t, testResultsStage, opts.Target)
+	assert.Equal(t, filepath.Join(".okteto", "test-results", "api"), opts.LocalOutputPath)
+	assert.Equal(t, "FROM golang AS test\nRUN go test ./... > /reports/out.txt\nFROM alpine\nFROM scratch AS okteto-test-results\nCOPY --from=test /reports /\n", runner.dockerfiles[0])
+
+	// the temporary dockerfile is removed and the build info of the image is not modified
+	_, err = fs.Stat(opts.File)
+	assert.Error(t, err)
+	assert.Equal(t, "prod", info.Target)
+	assert.Equal(t, "okteto.dev/test-api:okteto", info.Image)
+}
+
+func TestRunTestTargetWithoutResults(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	runner := &fakeTestStageRunner{fs: fs}
+	bc := newTestStageBuilder(runner, "")
+	info := &build.Info{
+		Context:    "api",
+		Dockerfile: "Dockerfile",
+		TestTarget: "test",
+	}
+
+	err := bc.runTestTarget(context.Background(), fs, "test", "api", info, &types.BuildOptions{})
+	require.NoError(t, err)
+	require.Len(t, runner.opts, 1)
+	assert.Equal(t, "test", runner.opts[0].Target)
+	assert.Empty(t, runner.opts[0].Tag)
+	assert.Empty(t, runner.opts[0].LocalOutputPath)
+}
+
+func TestRunTestTargetFailure(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	runner := &fakeTestStageRunner{fs: fs, err: assert.AnError}
+	bc := newTestStageBuilder(runner, "")
+	info := &build.Info{
+		Context:    "api",
+		Dockerfile: "Dockerfile",
+		TestTarget: "test",
+	}
+
+	err := bc.runTestTarget(context.Background(), fs, "test", "api", info, &types.BuildOptions{})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "test stage 'test' of service 'api' failed")
+}
+
+func TestBuildServiceWithFailingTestTarget(t *testing.T) {
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	runner := &fakeTestStageRunner{fs: afero.NewOsFs(), err: assert.AnError}
+	bc := newTestStageBuilder(runner, "")
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				TestTarget: "test",
+			},
+		},
+	}
+
+	_, err = bc.buildServiceImages(context.Background(), manifest, "api", &types.BuildOptions{})
+	assert.ErrorIs(t, err, assert.AnError)
+	// the image is not built if the test stage fails
+	require.Len(t, runner.opts, 1)
+	assert.Equal(t, "test", runner.opts[0].Target)
+}
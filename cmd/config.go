@@ -0,0 +1,164 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const configDocsURL = "https://okteto.com/docs/reference/okteto-cli/#config"
+
+// Config manages the user-level configuration of the okteto CLI
+func Config() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the default settings of the okteto CLI",
+		Long: fmt.Sprintf(`Manage the default settings of the okteto CLI.
+
+Settings are stored in %s and can be overridden for a specific context with '--context'.
+Environment variables take precedence over the settings of the context, which take precedence over the default settings`, config.GetSettingsPath()),
+		Args: utils.NoArgsAccepted(configDocsURL),
+	}
+	cmd.AddCommand(configSet())
+	cmd.AddCommand(configGet())
+	cmd.AddCommand(configList())
+	return cmd
+}
+
+func configSet() *cobra.Command {
+	var octx string
+	var unset bool
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a default setting of the okteto CLI",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if unset {
+				return utils.ExactArgsAccepted(1, configDocsURL)(cmd, args)
+			}
+			return utils.ExactArgsAccepted(2, configDocsURL)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := afero.NewOsFs()
+			settings, err := config.LoadSettings(fs, config.GetSettingsPath())
+			if err != nil {
+				return err
+			}
+			octx = resolveSettingsContext(octx)
+			if unset {
+				err = settings.Unset(octx, args[0])
+			} else {
+				err = settings.Set(octx, args[0], args[1])
+			}
+			if err != nil {
+				return err
+			}
+			if err := settings.Save(fs, config.GetSettingsPath()); err != nil {
+				return fmt.Errorf("failed to save your okteto configuration: %w", err)
+			}
+
+			scope := "default"
+			if octx != "" {
+				scope = fmt.Sprintf("context '%s'", octx)
+			}
+			if unset {
+				oktetoLog.Success("Setting '%s' removed from %s", args[0], scope)
+				return nil
+			}
+			oktetoLog.Success("Setting '%s' set to '%s' for %s", args[0], args[1], scope)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&octx, "context", "c", "", "set the value only for this okteto context")
+	cmd.Flags().BoolVarP(&unset, "unset", "", false, "remove the setting")
+	return cmd
+}
+
+func configGet() *cobra.Command {
+	var octx string
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Get the value of a setting of the okteto CLI",
+		Args:  utils.ExactArgsAccepted(1, configDocsURL),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, err := config.LoadSettings(afero.NewOsFs(), config.GetSettingsPath())
+			if err != nil {
+				return err
+			}
+			v, err := settings.Get(getSettingsContext(octx), args[0])
+			if err != nil {
+				return err
+			}
+			oktetoLog.Println(v.Value)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&octx, "context", "c", "", "get the value for this okteto context instead of the current one")
+	return cmd
+}
+
+func configList() *cobra.Command {
+	var octx string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the settings of the okteto CLI and where their values come from",
+		Args:  utils.NoArgsAccepted(configDocsURL),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, err := config.LoadSettings(afero.NewOsFs(), config.GetSettingsPath())
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+			fmt.Fprintf(w, "Key\tValue\tSource\n")
+			for _, v := range settings.List(getSettingsContext(octx)) {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", v.Key, v.Value, v.Source)
+			}
+			w.Flush()
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&octx, "context", "c", "", "list the values for this okteto context instead of the current one")
+	return cmd
+}
+
+// getSettingsContext returns the context used to resolve the settings, which defaults to the current one
+func getSettingsContext(octx string) string {
+	if octx == "" {
+		return okteto.GetContextStore().CurrentContext
+	}
+	return resolveSettingsContext(octx)
+}
+
+// resolveSettingsContext matches octx with the name of an existing context, adding the schema to okteto urls if needed
+func resolveSettingsContext(octx string) string {
+	if octx == "" {
+		return ""
+	}
+	contexts := okteto.GetContextStore().Contexts
+	if _, ok := contexts[octx]; ok {
+		return octx
+	}
+	if withSchema := okteto.AddSchema(octx); contexts[withSchema] != nil {
+		return withSchema
+	}
+	return octx
+}
@@ -0,0 +1,268 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultBenchmarkSamples = 3
+	benchmarkDialTimeout    = 3 * time.Second
+)
+
+// dialFunc opens a connection to an address, like net.DialTimeout
+type dialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
+// endpointLatency is the latency measured to one of the endpoints of a context
+type endpointLatency struct {
+	err      error
+	endpoint string
+	latency  time.Duration
+}
+
+// contextBenchmark is the latency from the user's location to the API, builder and registry of a context
+type contextBenchmark struct {
+	name     string
+	api      endpointLatency
+	builder  endpointLatency
+	registry endpointLatency
+}
+
+// contextBenchmarker measures the latency to the endpoints of the okteto contexts
+type contextBenchmarker struct {
+	dial    dialFunc
+	samples int
+}
+
+// Benchmark measures the latency to the okteto contexts and recommends the fastest one
+func Benchmark() *cobra.Command {
+	var samples int
+	var use bool
+	cmd := &cobra.Command{
+		Use:   "benchmark",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#context"),
+		Short: "Measure the latency to your okteto contexts and recommend the fastest one",
+		Long: `Measure the latency to your okteto contexts and recommend the fastest one
+
+The latency to the API, the builder and the registry of every okteto context is measured from your location.
+Use the '--use' flag to set the fastest context as your default context:
+
+    $ okteto context benchmark --use
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if samples < 1 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("invalid value '%d' for the '--samples' flag", samples),
+					Hint: "The number of samples must be greater than 0",
+				}
+			}
+			ctxStore := okteto.GetContextStore()
+			b := &contextBenchmarker{dial: net.DialTimeout, samples: samples}
+
+			oktetoLog.Spinner("Measuring the latency to your okteto contexts...")
+			oktetoLog.StartSpinner()
+			results := b.run(ctxStore)
+			oktetoLog.StopSpinner()
+
+			if len(results) == 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("there are no okteto contexts to benchmark"),
+					Hint: "Run 'okteto context use <url>' to add an okteto context",
+				}
+			}
+			printBenchmark(os.Stdout, results, ctxStore.CurrentContext)
+
+			fastest, ok := getFastestContext(results)
+			if !ok {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("none of your okteto contexts is reachable"),
+					Hint: "Check your network connection and try again",
+				}
+			}
+			if fastest == ctxStore.CurrentContext {
+				oktetoLog.Success("Your current context '%s' is the fastest", fastest)
+				return nil
+			}
+			if !use {
+				oktetoLog.Information("The fastest context is '%s'. Run 'okteto context use %s' to set it as your default context", fastest, fastest)
+				return nil
+			}
+			return NewContextCommand().Run(context.Background(), &Options{
+				Context:      fastest,
+				IsCtxCommand: true,
+				Save:         true,
+			})
+		},
+	}
+	cmd.Flags().IntVarP(&samples, "samples", "", defaultBenchmarkSamples, "number of connections opened to every endpoint, the fastest one is reported")
+	cmd.Flags().BoolVarP(&use, "use", "", false, "set the fastest context as your default context")
+	return cmd
+}
+
+// run measures the latency to the okteto contexts of the store at the same time
+func (b *contextBenchmarker) run(ctxStore *okteto.ContextStore) []contextBenchmark {
+	names := []string{}
+	for name, okCtx := range ctxStore.Contexts {
+		if okCtx.IsOkteto {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	results := make([]contextBenchmark, len(names))
+	wg := sync.WaitGroup{}
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			okCtx := ctxStore.Contexts[name]
+			results[i] = contextBenchmark{
+				name:     name,
+				api:      b.measure(name),
+				builder:  b.measure(okCtx.Builder),
+				registry: b.measure(okCtx.Registry),
+			}
+		}(i, name)
+	}
+	wg.Wait()
+	return results
+}
+
+// measure returns the lowest time to open a connection to an endpoint, which is the closest to the network latency.
+// Endpoints that aren't set aren't measured
+func (b *contextBenchmarker) measure(endpoint string) endpointLatency {
+	result := endpointLatency{endpoint: endpoint}
+	if endpoint == "" {
+		return result
+	}
+	address, err := getEndpointAddress(endpoint)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	reachable := false
+	for i := 0; i < b.samples; i++ {
+		start := time.Now()
+		conn, err := b.dial("tcp", address, benchmarkDialTimeout)
+		if err != nil {
+			oktetoLog.Infof("failed to connect to '%s': %s", address, err)
+			result.err = err
+			continue
+		}
+		elapsed := time.Since(start)
+		if err := conn.Close(); err != nil {
+			oktetoLog.Infof("failed to close the connection to '%s': %s", address, err)
+		}
+		if !reachable || elapsed < result.latency {
+			result.latency = elapsed
+		}
+		reachable = true
+	}
+	// an endpoint is reachable if any of the samples succeeded
+	if reachable {
+		result.err = nil
+	}
+	return result
+}
+
+// getEndpointAddress returns the host:port of the URL of an endpoint, like 'https://okteto.example.com' or 'tcp://buildkit.okteto.example.com:443'.
+// Endpoints without a scheme are https endpoints
+func getEndpointAddress(endpoint string) (string, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = fmt.Sprintf("https://%s", endpoint)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint '%s': %w", endpoint, err)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid endpoint '%s': the host is empty", endpoint)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// isReachable returns true if all the endpoints set in the context are reachable
+func (cb contextBenchmark) isReachable() bool {
+	return cb.api.err == nil && cb.builder.err == nil && cb.registry.err == nil
+}
+
+// total returns the sum of the latencies to the endpoints of the context
+func (cb contextBenchmark) total() time.Duration {
+	return cb.api.latency + cb.builder.latency + cb.registry.latency
+}
+
+// getFastestContext returns the reachable context with the lowest total latency
+func getFastestContext(results []contextBenchmark) (string, bool) {
+	fastest := -1
+	for i, result := range results {
+		if !result.isReachable() {
+			continue
+		}
+		if fastest == -1 || result.total() < results[fastest].total() {
+			fastest = i
+		}
+	}
+	if fastest == -1 {
+		return "", false
+	}
+	return results[fastest].name, true
+}
+
+func printBenchmark(out io.Writer, results []contextBenchmark, current string) {
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Name\tAPI\tBuilder\tRegistry\n")
+	for _, result := range results {
+		name := result.name
+		if name == current {
+			name += " *"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, formatLatency(result.api), formatLatency(result.builder), formatLatency(result.registry))
+	}
+	w.Flush()
+}
+
+func formatLatency(l endpointLatency) string {
+	switch {
+	case l.endpoint == "":
+		return "-"
+	case l.err != nil:
+		return "unreachable"
+	default:
+		return l.latency.Round(time.Millisecond).String()
+	}
+}
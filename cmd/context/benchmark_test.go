@@ -0,0 +1,128 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeDial(unreachable ...string) dialFunc {
+	return func(_, address string, _ time.Duration) (net.Conn, error) {
+		for _, u := range unreachable {
+			if u == address {
+				return nil, errors.New("connection refused")
+			}
+		}
+		client, server := net.Pipe()
+		if err := server.Close(); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+func Test_getEndpointAddress(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "https://okteto.example.com", expected: "okteto.example.com:443"},
+		{endpoint: "http://okteto.example.com", expected: "okteto.example.com:80"},
+		{endpoint: "tcp://buildkit.okteto.example.com:1234", expected: "buildkit.okteto.example.com:1234"},
+		{endpoint: "registry.okteto.example.com", expected: "registry.okteto.example.com:443"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			address, err := getEndpointAddress(tt.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, address)
+		})
+	}
+
+	_, err := getEndpointAddress("https://")
+	require.Error(t, err)
+}
+
+func Test_contextBenchmarkerRun(t *testing.T) {
+	ctxStore := &okteto.ContextStore{
+		CurrentContext: "https://a.okteto.dev",
+		Contexts: map[string]*okteto.Context{
+			"https://a.okteto.dev": {
+				IsOkteto: true,
+				Builder:  "tcp://buildkit.a.okteto.dev:443",
+				Registry: "registry.a.okteto.dev",
+			},
+			"https://b.okteto.dev": {
+				IsOkteto: true,
+				Registry: "registry.b.okteto.dev",
+			},
+			"kind-local": {},
+		},
+	}
+	b := &contextBenchmarker{dial: fakeDial("registry.b.okteto.dev:443"), samples: 2}
+	results := b.run(ctxStore)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "https://a.okteto.dev", results[0].name)
+	assert.True(t, results[0].isReachable())
+
+	assert.Equal(t, "https://b.okteto.dev", results[1].name)
+	assert.Equal(t, "", results[1].builder.endpoint)
+	assert.NoError(t, results[1].builder.err)
+	assert.Error(t, results[1].registry.err)
+	assert.False(t, results[1].isReachable())
+
+	fastest, ok := getFastestContext(results)
+	require.True(t, ok)
+	assert.Equal(t, "https://a.okteto.dev", fastest)
+
+	out := &bytes.Buffer{}
+	printBenchmark(out, results, ctxStore.CurrentContext)
+	assert.Contains(t, out.String(), "https://a.okteto.dev *")
+	assert.Contains(t, out.String(), "unreachable")
+}
+
+func Test_getFastestContext(t *testing.T) {
+	results := []contextBenchmark{
+		{
+			name:    "slow",
+			api:     endpointLatency{endpoint: "slow", latency: 80 * time.Millisecond},
+			builder: endpointLatency{endpoint: "slow", latency: 90 * time.Millisecond},
+		},
+		{
+			name:     "fast",
+			api:      endpointLatency{endpoint: "fast", latency: 10 * time.Millisecond},
+			builder:  endpointLatency{endpoint: "fast", latency: 20 * time.Millisecond},
+			registry: endpointLatency{endpoint: "fast", latency: 15 * time.Millisecond},
+		},
+		{
+			name: "down",
+			api:  endpointLatency{endpoint: "down", err: errors.New("timeout")},
+		},
+	}
+	fastest, ok := getFastestContext(results)
+	require.True(t, ok)
+	assert.Equal(t, "fast", fastest)
+
+	_, ok = getFastestContext(results[2:])
+	assert.False(t, ok)
+}
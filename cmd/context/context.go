@@ -48,6 +48,7 @@ This will prompt you to select one of your existing contexts or to create a new
 	cmd.AddCommand(Use())
 	cmd.AddCommand(List())
 	cmd.AddCommand(DeleteCMD())
+	cmd.AddCommand(Benchmark())
 
 	// deprecated
 	cmd.AddCommand(CreateCMD())
@@ -136,6 +136,43 @@ If you need to automate authentication or if you don't want to use browser-based
 func (c *Command) UseContext(ctx context.Context, ctxOptions *Options) error {
 	created := false
 
+	registryTemplates, err := parseRegistryTemplates(ctxOptions.RegistryTemplates)
+	if err != nil {
+		return err
+	}
+
+	registryMirrors, err := parseRegistryMirrors(ctxOptions.RegistryMirrors)
+	if err != nil {
+		return err
+	}
+
+	cpuPrice, err := parsePrice("cpu-price", ctxOptions.CPUPrice)
+	if err != nil {
+		return err
+	}
+	memoryPrice, err := parsePrice("memory-price", ctxOptions.MemoryPrice)
+	if err != nil {
+		return err
+	}
+
+	suppressWarnings, err := parseSuppressWarnings(ctxOptions.SuppressWarnings)
+	if err != nil {
+		return err
+	}
+
+	if err := validateTagStrategyFlag(ctxOptions.TagStrategy); err != nil {
+		return err
+	}
+
+	if err := validateBuildBackendFlag(ctxOptions.BuildBackend); err != nil {
+		return err
+	}
+
+	license, err := readLicenseFile(afero.NewOsFs(), ctxOptions.LicenseFile)
+	if err != nil {
+		return err
+	}
+
 	ctxStore := okteto.GetContextStore()
 	if okCtx, ok := ctxStore.Contexts[ctxOptions.Context]; ok && okCtx.IsOkteto {
 		ctxOptions.IsOkteto = true
@@ -192,6 +229,14 @@ func (c *Command) UseContext(ctx context.Context, ctxOptions *Options) error {
 		}
 	}
 
+	setRegistryTemplates(ctxStore.Contexts[ctxOptions.Context], registryTemplates)
+	setRegistryMirrors(ctxStore.Contexts[ctxOptions.Context], registryMirrors)
+	setPricing(ctxStore.Contexts[ctxOptions.Context], cpuPrice, memoryPrice)
+	setSuppressWarnings(ctxStore.Contexts[ctxOptions.Context], suppressWarnings)
+	setTagStrategy(ctxStore.Contexts[ctxOptions.Context], ctxOptions.TagStrategy)
+	setBuildBackend(ctxStore.Contexts[ctxOptions.Context], ctxOptions.BuildBackend)
+	setLicense(ctxStore.Contexts[ctxOptions.Context], license)
+
 	if ctxOptions.Save {
 		hasAccess, err := hasAccessToNamespace(ctx, c, ctxOptions)
 		if err != nil {
@@ -328,6 +373,7 @@ func (c *Command) initOktetoContext(ctx context.Context, ctxOptions *Options) er
 		return err
 	}
 
+	okteto.ApplyImpersonation(cfg)
 	okteto.GetContext().Cfg = cfg
 	okteto.GetContext().IsOkteto = true
 	okteto.GetContext().IsInsecure = okteto.IsInsecureSkipTLSVerifyPolicy()
@@ -389,6 +435,7 @@ func (*Command) initKubernetesContext(ctxOptions *Options) error {
 
 	kubeCtx.Namespace = okteto.GetContext().Namespace
 	cfg.CurrentContext = okteto.GetContext().Name
+	okteto.ApplyImpersonation(cfg)
 	okteto.GetContext().Cfg = cfg
 	okteto.GetContext().IsOkteto = false
 
@@ -27,6 +27,14 @@ type Options struct {
 	Context               string
 	Namespace             string
 	Builder               string
+	CPUPrice              string
+	MemoryPrice           string
+	RegistryTemplates     []string
+	RegistryMirrors       []string
+	SuppressWarnings      []string
+	TagStrategy           string
+	BuildBackend          string
+	LicenseFile           string
 	OnlyOkteto            bool
 	Show                  bool
 	Save                  bool
@@ -108,9 +116,9 @@ func (o *Options) InitFromEnvVars() {
 
 	if o.Show {
 		if len(usedEnvVars) == 1 {
-			oktetoLog.Warning("Initializing context with the value of %s environment variable", usedEnvVars[0])
+			oktetoLog.WarningWithID(oktetoLog.WarnContextFromEnvVars, "Initializing context with the value of %s environment variable", usedEnvVars[0])
 		} else if len(usedEnvVars) > 1 {
-			oktetoLog.Warning("Initializing context with the value of %s and %s environment variables", strings.Join(usedEnvVars[0:len(usedEnvVars)-1], ", "), usedEnvVars[len(usedEnvVars)-1])
+			oktetoLog.WarningWithID(oktetoLog.WarnContextFromEnvVars, "Initializing context with the value of %s and %s environment variables", strings.Join(usedEnvVars[0:len(usedEnvVars)-1], ", "), usedEnvVars[len(usedEnvVars)-1])
 		}
 	}
 }
@@ -87,6 +87,14 @@ Or a Kubernetes context:
 	cmd.Flags().StringVarP(&ctxOptions.Token, "token", "t", "", "API token for authentication")
 	cmd.Flags().StringVarP(&ctxOptions.Namespace, "namespace", "n", "", "namespace of your okteto context")
 	cmd.Flags().StringVarP(&ctxOptions.Builder, "builder", "b", "", "url of the builder service")
+	cmd.Flags().StringArrayVarP(&ctxOptions.RegistryTemplates, "registry-template", "", []string{}, "customize how 'okteto.dev' or 'okteto.global' are expanded, e.g. 'okteto.dev={{ .Registry }}/team/{{ .Namespace }}'. Use an empty template to restore the default expansion")
+	cmd.Flags().StringArrayVarP(&ctxOptions.RegistryMirrors, "registry-mirror", "", []string{}, "pull-through cache queried before a registry for image lookups and base image pulls, e.g. 'docker.io=mirror.example.com/dockerhub'. Repeat the flag to set several mirrors in order of preference. Use an empty mirror to remove the mirrors of a registry")
+	cmd.Flags().StringVarP(&ctxOptions.CPUPrice, "cpu-price", "", "", "monthly price of a CPU core, used by 'okteto deploy --cost-estimate'")
+	cmd.Flags().StringVarP(&ctxOptions.MemoryPrice, "memory-price", "", "", "monthly price of a GB of memory, used by 'okteto deploy --cost-estimate'")
+	cmd.Flags().StringArrayVarP(&ctxOptions.SuppressWarnings, "suppress-warning", "", []string{}, "hide the warning with the given ID, e.g. 'W010' (can be set more than once). Use 'none' to show all the warnings again")
+	cmd.Flags().StringVarP(&ctxOptions.TagStrategy, "tag-strategy", "", "", "tag scheme for the images built without an 'image' field, one of 'git-sha', 'content-hash' or 'branch-timestamp'. Use 'none' to restore the default 'okteto' tag")
+	cmd.Flags().StringVarP(&ctxOptions.BuildBackend, "build-backend", "", "", "default builder of 'okteto build', one of 'auto', 'buildkit', 'docker' or 'buildx[:<name>]'. 'auto' falls back to the local Docker daemon when the builder is unreachable. Use 'none' to restore the default")
+	cmd.Flags().StringVarP(&ctxOptions.LicenseFile, "license-file", "", "", "path to the license or offline activation file of your air-gapped okteto install")
 	cmd.Flags().BoolVarP(&ctxOptions.OnlyOkteto, "okteto", "", false, "only shows okteto context options")
 	if err := cmd.Flags().MarkHidden("okteto"); err != nil {
 		oktetoLog.Infof("failed to mark 'okteto' flag as hidden: %s", err)
@@ -150,6 +158,7 @@ func (c *Command) Run(ctx context.Context, ctxOptions *Options) error {
 	}
 
 	os.Setenv(model.OktetoNamespaceEnvVar, okteto.GetContext().Namespace)
+	oktetoLog.SuppressWarnings(okteto.GetContext().SuppressWarnings...)
 
 	if ctxOptions.Show {
 		oktetoLog.Information("Using %s @ %s as context", okteto.GetContext().Namespace, okteto.RemoveSchema(okteto.GetContext().Name))
@@ -240,7 +249,7 @@ func exportPlatformVariablesToEnv(variables []env.Var) {
 		value, exists := os.LookupEnv(v.Name)
 		if exists {
 			if value != v.Value {
-				oktetoLog.Warning("Okteto Variable '%s' is overridden by a local environment variable with the same name", v.Name)
+				oktetoLog.WarningWithID(oktetoLog.WarnVariableOverridden, "Okteto Variable '%s' is overridden by a local environment variable with the same name", v.Name)
 			}
 			oktetoLog.AddMaskedWord(value)
 			continue
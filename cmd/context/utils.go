@@ -18,9 +18,12 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/build"
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/discovery"
@@ -29,6 +32,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
 	"github.com/spf13/afero"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
@@ -247,3 +251,220 @@ func LoadContextFromPath(ctx context.Context, namespace, k8sContext, path string
 
 	return NewContextCommand().Run(ctx, &ctxOptions)
 }
+
+// parseRegistryTemplates parses and validates the registry templates in the format '<registry>=<template>'
+func parseRegistryTemplates(values []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, value := range values {
+		registryType, tpl, found := strings.Cut(value, "=")
+		if !found {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid registry template '%s'", value),
+				Hint: fmt.Sprintf("Use the format '%s=<template>' or '%s=<template>'", constants.DevRegistry, constants.GlobalRegistry),
+			}
+		}
+		registryType = strings.TrimSpace(registryType)
+		if tpl != "" {
+			if err := registry.ValidateRegistryTemplate(registryType, tpl); err != nil {
+				return nil, oktetoErrors.UserError{
+					E:    err,
+					Hint: "Templates can use the fields '{{ .Registry }}' and '{{ .Namespace }}'",
+				}
+			}
+		}
+		result[registryType] = tpl
+	}
+	return result, nil
+}
+
+// setRegistryTemplates stores the registry templates in the context. Empty templates restore the default expansion
+func setRegistryTemplates(okCtx *okteto.Context, templates map[string]string) {
+	if okCtx == nil || len(templates) == 0 {
+		return
+	}
+	if okCtx.RegistryTemplates == nil {
+		okCtx.RegistryTemplates = map[string]string{}
+	}
+	for registryType, tpl := range templates {
+		if tpl == "" {
+			delete(okCtx.RegistryTemplates, registryType)
+			continue
+		}
+		okCtx.RegistryTemplates[registryType] = tpl
+	}
+}
+
+// parsePrice parses the value of a price flag. It returns nil if the flag is not set
+func parsePrice(flag, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value '%s' for '--%s'", value, flag),
+			Hint: "Use a positive number, e.g. '--cpu-price 25.5'",
+		}
+	}
+	return &price, nil
+}
+
+// setPricing stores the prices used to estimate the cost of the dev environments in the context
+func setPricing(okCtx *okteto.Context, cpuPrice, memoryPrice *float64) {
+	if okCtx == nil || (cpuPrice == nil && memoryPrice == nil) {
+		return
+	}
+	if okCtx.Pricing == nil {
+		okCtx.Pricing = &okteto.Pricing{}
+	}
+	if cpuPrice != nil {
+		okCtx.Pricing.CPU = *cpuPrice
+	}
+	if memoryPrice != nil {
+		okCtx.Pricing.Memory = *memoryPrice
+	}
+}
+
+// parseSuppressWarnings validates the values of the '--suppress-warning' flags. It returns nil if the flag is not set,
+// and an empty list if it is set to 'none'
+func parseSuppressWarnings(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if len(values) == 1 && values[0] == "none" {
+		return []string{}, nil
+	}
+	if err := oktetoLog.ValidateWarningIDs(values); err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    err,
+			Hint: "The ID of a warning is shown at the beginning of its message, e.g. '[W010]'",
+		}
+	}
+	result := []string{}
+	for _, v := range values {
+		result = append(result, strings.ToUpper(strings.TrimSpace(v)))
+	}
+	return result, nil
+}
+
+// setSuppressWarnings stores the IDs of the warnings hidden for the context
+func setSuppressWarnings(okCtx *okteto.Context, ids []string) {
+	if okCtx == nil || ids == nil {
+		return
+	}
+	okCtx.SuppressWarnings = ids
+}
+
+// validateTagStrategyFlag validates the value of the '--tag-strategy' flag
+func validateTagStrategyFlag(value string) error {
+	if value == "" || value == "none" {
+		return nil
+	}
+	if err := build.ValidateTagStrategy(value); err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Use 'none' to restore the default 'okteto' tag",
+		}
+	}
+	return nil
+}
+
+// setTagStrategy stores the tag strategy of the context. 'none' restores the default tag
+func setTagStrategy(okCtx *okteto.Context, value string) {
+	if okCtx == nil || value == "" {
+		return
+	}
+	if value == "none" {
+		value = ""
+	}
+	okCtx.TagStrategy = value
+}
+
+// validateBuildBackendFlag validates the value of the '--build-backend' flag
+func validateBuildBackendFlag(value string) error {
+	if value == "none" {
+		return nil
+	}
+	return buildCmd.ValidateBuilder(value)
+}
+
+// setBuildBackend stores the default builder backend of the context. 'none' restores the default backend
+func setBuildBackend(okCtx *okteto.Context, value string) {
+	if okCtx == nil || value == "" {
+		return
+	}
+	if value == "none" {
+		value = ""
+	}
+	okCtx.BuildBackend = value
+}
+
+// readLicenseFile reads the file of the '--license-file' flag. It returns nil if the flag is not set
+func readLicenseFile(fs afero.Fs, path string) (*okteto.License, error) {
+	if path == "" {
+		return nil, nil
+	}
+	license, err := okteto.ReadLicenseFile(fs, path)
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    err,
+			Hint: "Request a new license or offline activation file to your okteto administrator",
+		}
+	}
+	return license, nil
+}
+
+// setLicense stores the license of the context. Offline licenses disable telemetry and release checks for the context
+func setLicense(okCtx *okteto.Context, license *okteto.License) {
+	if okCtx == nil || license == nil {
+		return
+	}
+	okCtx.License = license
+}
+
+// parseRegistryMirrors parses and validates the registry mirrors in the format '<registry>=<mirror>'.
+// An empty list of mirrors for a registry means its mirrors are removed
+func parseRegistryMirrors(values []string) (map[string][]string, error) {
+	hint := fmt.Sprintf("Use the format '<registry>=<mirror>', e.g. '%s=mirror.example.com/dockerhub'", registry.DockerHubRegistry)
+	result := map[string][]string{}
+	for _, value := range values {
+		registryName, mirror, found := strings.Cut(value, "=")
+		registryName = registry.NormalizeMirroredRegistry(registryName)
+		if !found || registryName == "" {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid registry mirror '%s'", value),
+				Hint: hint,
+			}
+		}
+		mirror = strings.TrimSpace(mirror)
+		if mirror == "" {
+			result[registryName] = []string{}
+			continue
+		}
+		if err := registry.ValidateRegistryMirror(mirror); err != nil {
+			return nil, oktetoErrors.UserError{
+				E:    err,
+				Hint: hint,
+			}
+		}
+		result[registryName] = append(result[registryName], mirror)
+	}
+	return result, nil
+}
+
+// setRegistryMirrors replaces the mirrors of each registry in the context. Empty lists remove the mirrors of the registry
+func setRegistryMirrors(okCtx *okteto.Context, mirrors map[string][]string) {
+	if okCtx == nil || len(mirrors) == 0 {
+		return
+	}
+	if okCtx.RegistryMirrors == nil {
+		okCtx.RegistryMirrors = map[string][]string{}
+	}
+	for registryName, registryMirrors := range mirrors {
+		if len(registryMirrors) == 0 {
+			delete(okCtx.RegistryMirrors, registryName)
+			continue
+		}
+		okCtx.RegistryMirrors[registryName] = registryMirrors
+	}
+}
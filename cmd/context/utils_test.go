@@ -20,11 +20,13 @@ import (
 
 	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/deps"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -277,3 +279,166 @@ context: manifest-context
 		})
 	}
 }
+
+func Test_parseRegistryTemplates(t *testing.T) {
+	result, err := parseRegistryTemplates([]string{"okteto.dev={{ .Registry }}/dev-{{ .Namespace }}", "okteto.global="})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"okteto.dev": "{{ .Registry }}/dev-{{ .Namespace }}", "okteto.global": ""}, result)
+
+	_, err = parseRegistryTemplates([]string{"okteto.dev"})
+	assert.Error(t, err)
+
+	_, err = parseRegistryTemplates([]string{"docker.io=my-registry.com"})
+	assert.Error(t, err)
+}
+
+func Test_setRegistryTemplates(t *testing.T) {
+	okCtx := &okteto.Context{}
+	setRegistryTemplates(okCtx, map[string]string{"okteto.dev": "my-registry.com/{{ .Namespace }}", "okteto.global": "ecr.aws.com/shared"})
+	assert.Equal(t, map[string]string{"okteto.dev": "my-registry.com/{{ .Namespace }}", "okteto.global": "ecr.aws.com/shared"}, okCtx.RegistryTemplates)
+
+	setRegistryTemplates(okCtx, map[string]string{"okteto.global": ""})
+	assert.Equal(t, map[string]string{"okteto.dev": "my-registry.com/{{ .Namespace }}"}, okCtx.RegistryTemplates)
+}
+
+func Test_parsePrice(t *testing.T) {
+	price, err := parsePrice("cpu-price", "")
+	assert.NoError(t, err)
+	assert.Nil(t, price)
+
+	price, err = parsePrice("cpu-price", "25.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.5, *price)
+
+	_, err = parsePrice("cpu-price", "cheap")
+	assert.Error(t, err)
+
+	_, err = parsePrice("memory-price", "-1")
+	assert.Error(t, err)
+}
+
+func Test_setPricing(t *testing.T) {
+	okCtx := &okteto.Context{}
+	setPricing(okCtx, nil, nil)
+	assert.Nil(t, okCtx.Pricing)
+
+	cpu, memory := 20.0, 3.5
+	setPricing(okCtx, &cpu, nil)
+	assert.Equal(t, &okteto.Pricing{CPU: 20}, okCtx.Pricing)
+
+	setPricing(okCtx, nil, &memory)
+	assert.Equal(t, &okteto.Pricing{CPU: 20, Memory: 3.5}, okCtx.Pricing)
+}
+
+func Test_parseSuppressWarnings(t *testing.T) {
+	ids, err := parseSuppressWarnings(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ids)
+
+	ids, err = parseSuppressWarnings([]string{"none"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, ids)
+
+	ids, err = parseSuppressWarnings([]string{"w010", " W001 "})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"W010", "W001"}, ids)
+
+	_, err = parseSuppressWarnings([]string{"W999"})
+	assert.Error(t, err)
+}
+
+func Test_setSuppressWarnings(t *testing.T) {
+	okCtx := &okteto.Context{SuppressWarnings: []string{"W010"}}
+	setSuppressWarnings(okCtx, nil)
+	assert.Equal(t, []string{"W010"}, okCtx.SuppressWarnings)
+
+	setSuppressWarnings(okCtx, []string{})
+	assert.Empty(t, okCtx.SuppressWarnings)
+}
+
+func Test_setTagStrategy(t *testing.T) {
+	assert.NoError(t, validateTagStrategyFlag(""))
+	assert.NoError(t, validateTagStrategyFlag("none"))
+	assert.NoError(t, validateTagStrategyFlag("git-sha"))
+	assert.Error(t, validateTagStrategyFlag("latest"))
+
+	okCtx := &okteto.Context{TagStrategy: "git-sha"}
+	setTagStrategy(okCtx, "")
+	assert.Equal(t, "git-sha", okCtx.TagStrategy)
+
+	setTagStrategy(okCtx, "content-hash")
+	assert.Equal(t, "content-hash", okCtx.TagStrategy)
+
+	setTagStrategy(okCtx, "none")
+	assert.Empty(t, okCtx.TagStrategy)
+}
+
+func Test_readLicenseFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "license.json", []byte(`{"id": "lic-1", "offline": true}`), 0600))
+
+	license, err := readLicenseFile(fs, "")
+	assert.NoError(t, err)
+	assert.Nil(t, license)
+
+	license, err = readLicenseFile(fs, "license.json")
+	assert.NoError(t, err)
+	assert.Equal(t, &okteto.License{ID: "lic-1", Offline: true}, license)
+
+	_, err = readLicenseFile(fs, "not-found.json")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	okCtx := &okteto.Context{}
+	setLicense(okCtx, nil)
+	assert.Nil(t, okCtx.License)
+	setLicense(okCtx, license)
+	assert.Equal(t, license, okCtx.License)
+}
+
+func Test_setBuildBackend(t *testing.T) {
+	assert.NoError(t, validateBuildBackendFlag(""))
+	assert.NoError(t, validateBuildBackendFlag("none"))
+	assert.NoError(t, validateBuildBackendFlag("buildx:remote"))
+	assert.Error(t, validateBuildBackendFlag("podman"))
+
+	okCtx := &okteto.Context{BuildBackend: "auto"}
+	setBuildBackend(okCtx, "")
+	assert.Equal(t, "auto", okCtx.BuildBackend)
+
+	setBuildBackend(okCtx, "docker")
+	assert.Equal(t, "docker", okCtx.BuildBackend)
+
+	setBuildBackend(okCtx, "none")
+	assert.Empty(t, okCtx.BuildBackend)
+}
+
+func Test_parseRegistryMirrors(t *testing.T) {
+	result, err := parseRegistryMirrors([]string{
+		"docker.io=mirror.example.com/dockerhub",
+		"index.docker.io=backup.example.com",
+		"ghcr.io=",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"docker.io": {"mirror.example.com/dockerhub", "backup.example.com"},
+		"ghcr.io":   {},
+	}, result)
+
+	_, err = parseRegistryMirrors([]string{"docker.io"})
+	assert.Error(t, err)
+
+	_, err = parseRegistryMirrors([]string{"=mirror.example.com"})
+	assert.Error(t, err)
+
+	_, err = parseRegistryMirrors([]string{"docker.io=https://mirror.example.com"})
+	assert.Error(t, err)
+}
+
+func Test_setRegistryMirrors(t *testing.T) {
+	okCtx := &okteto.Context{}
+	setRegistryMirrors(okCtx, map[string][]string{"docker.io": {"mirror.example.com/dockerhub"}, "ghcr.io": {"mirror.example.com/ghcr"}})
+	assert.Equal(t, map[string][]string{"docker.io": {"mirror.example.com/dockerhub"}, "ghcr.io": {"mirror.example.com/ghcr"}}, okCtx.RegistryMirrors)
+
+	setRegistryMirrors(okCtx, map[string][]string{"docker.io": {"backup.example.com"}, "ghcr.io": {}})
+	assert.Equal(t, map[string][]string{"docker.io": {"backup.example.com"}}, okCtx.RegistryMirrors)
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/deps"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const shortCommitLength = 7
+
+// OutdatedOptions represents the options of the outdated command
+type OutdatedOptions struct {
+	ManifestPath string
+	Update       bool
+}
+
+// outdatedChecker checks the dependencies of a manifest against their upstream repositories
+type outdatedChecker struct {
+	lister deps.RefLister
+	fs     afero.Fs
+	out    io.Writer
+}
+
+// Outdated checks if the dependencies of the okteto manifest have newer commits or releases
+func Outdated(ctx context.Context) *cobra.Command {
+	options := &OutdatedOptions{}
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "Check if the dependencies of your okteto manifest have newer commits or releases",
+		Long: `Check if the dependencies of your okteto manifest have newer commits or releases.
+
+Dependencies pinned to a branch are compared with the commit locked in the 'okteto.lock' file.
+Dependencies pinned to a semver tag are compared with the newest tag of their repository.
+Use '--update' to lock the dependencies to their latest revision. 'okteto deploy' deploys the locked commits.`,
+		Args: utils.NoArgsAccepted("https://www.okteto.com/docs/reference/okteto-cli/#outdated"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model.UseSections(model.DependenciesSection)
+			fs := afero.NewOsFs()
+			manifest, err := model.GetManifestV2(options.ManifestPath, fs)
+			if err != nil {
+				return err
+			}
+			if !manifest.HasDependencies() {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the okteto manifest doesn't define any dependency"),
+					Hint: "Add a 'dependencies' section to your okteto manifest",
+				}
+			}
+
+			oc := &outdatedChecker{
+				lister: deps.GitRefLister{},
+				fs:     fs,
+				out:    os.Stdout,
+			}
+			return oc.run(ctx, manifest.Dependencies, deps.GetLockPath(options.ManifestPath), options.Update)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().BoolVarP(&options.Update, "update", "u", false, "lock the dependencies to their latest revision")
+	return cmd
+}
+
+func (oc *outdatedChecker) run(ctx context.Context, dependencies deps.ManifestSection, lockPath string, update bool) error {
+	lock, err := deps.ReadLock(oc.fs, lockPath)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	statuses := []*deps.Status{}
+	for _, name := range names {
+		d := dependencies[name]
+		if err := d.ExpandVars(nil); err != nil {
+			return fmt.Errorf("could not expand variables in dependency '%s': %w", name, err)
+		}
+		locked, _ := lock.Get(name, d)
+		s, err := deps.CheckOutdated(ctx, oc.lister, name, d, locked)
+		if err != nil {
+			oktetoLog.Warning("Skipping dependency '%s': %s", name, err)
+			continue
+		}
+		statuses = append(statuses, s)
+	}
+
+	oc.print(statuses)
+
+	if !update {
+		return nil
+	}
+
+	for name := range lock.Dependencies {
+		if _, ok := dependencies[name]; !ok {
+			delete(lock.Dependencies, name)
+		}
+	}
+	for _, s := range statuses {
+		if !s.Lockable {
+			oktetoLog.Warning("Dependency '%s' is pinned to a commit: update its 'branch' in the okteto manifest", s.Name)
+			continue
+		}
+		lock.Dependencies[s.Name] = s.Lock()
+	}
+	if err := lock.Save(oc.fs, lockPath); err != nil {
+		return fmt.Errorf("failed to update '%s': %w", lockPath, err)
+	}
+	oktetoLog.Success("Dependencies locked in '%s'", lockPath)
+	return nil
+}
+
+func (oc *outdatedChecker) print(statuses []*deps.Status) {
+	tw := tabwriter.NewWriter(oc.out, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Name", "Ref", "Current", "Latest", "Status"}, "\t"))
+	for _, s := range statuses {
+		ref := s.Ref
+		if ref == "" {
+			ref = "(default branch)"
+		}
+		output := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", s.Name, ref, shortRevision(s.Current), shortRevision(s.Latest), getStatusText(s))
+		fmt.Fprintln(tw, output)
+	}
+	tw.Flush()
+}
+
+func getStatusText(s *deps.Status) string {
+	switch {
+	case s.Outdated:
+		return "outdated"
+	case s.Current == "":
+		return "not locked"
+	default:
+		return "up to date"
+	}
+}
+
+// shortRevision abbreviates commit SHAs, keeping branch and tag names as they are
+func shortRevision(revision string) string {
+	if revision == "" {
+		return "-"
+	}
+	if len(revision) == 40 && strings.Trim(revision, "0123456789abcdef") == "" {
+		return revision[:shortCommitLength]
+	}
+	return revision
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencies
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/okteto/okteto/pkg/deps"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mainCommit = "1111111111111111111111111111111111111111"
+	oldCommit  = "2222222222222222222222222222222222222222"
+	v120Commit = "4444444444444444444444444444444444444444"
+)
+
+type fakeRefLister struct{}
+
+func (fakeRefLister) ListRefs(context.Context, string) ([]*plumbing.Reference, error) {
+	return []*plumbing.Reference{
+		plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main"),
+		plumbing.NewHashReference("refs/heads/main", plumbing.NewHash(mainCommit)),
+		plumbing.NewHashReference("refs/tags/v1.0.0", plumbing.NewHash(oldCommit)),
+		plumbing.NewHashReference("refs/tags/v1.2.0", plumbing.NewHash(v120Commit)),
+	}, nil
+}
+
+func newTestDependencies() deps.ManifestSection {
+	return deps.ManifestSection{
+		"api": &deps.Dependency{
+			Repository: "https://github.com/okteto/api",
+			Branch:     "main",
+		},
+		"frontend": &deps.Dependency{
+			Repository: "https://github.com/okteto/frontend",
+			Branch:     "v1.0.0",
+		},
+	}
+}
+
+func TestOutdated(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	lock := &deps.Lock{
+		Dependencies: map[string]*deps.LockedDependency{
+			"api": {Repository: "https://github.com/okteto/api", Branch: "main", Commit: oldCommit},
+		},
+	}
+	require.NoError(t, lock.Save(fs, "okteto.lock"))
+
+	out := &bytes.Buffer{}
+	oc := &outdatedChecker{lister: fakeRefLister{}, fs: fs, out: out}
+	require.NoError(t, oc.run(context.Background(), newTestDependencies(), "okteto.lock", false))
+
+	expected := `Name      Ref     Current  Latest   Status
+api       main    2222222  1111111  outdated
+frontend  v1.0.0  v1.0.0   v1.2.0   outdated
+`
+	assert.Equal(t, expected, out.String())
+
+	// the lock file isn't modified without the update flag
+	loaded, err := deps.ReadLock(fs, "okteto.lock")
+	require.NoError(t, err)
+	assert.Equal(t, lock, loaded)
+}
+
+func TestOutdatedUpdate(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	lock := &deps.Lock{
+		Dependencies: map[string]*deps.LockedDependency{
+			"api":     {Repository: "https://github.com/okteto/api", Branch: "main", Commit: oldCommit},
+			"removed": {Repository: "https://github.com/okteto/removed", Commit: oldCommit},
+		},
+	}
+	require.NoError(t, lock.Save(fs, "okteto.lock"))
+
+	oc := &outdatedChecker{lister: fakeRefLister{}, fs: fs, out: &bytes.Buffer{}}
+	require.NoError(t, oc.run(context.Background(), newTestDependencies(), "okteto.lock", true))
+
+	loaded, err := deps.ReadLock(fs, "okteto.lock")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*deps.LockedDependency{
+		"api":      {Repository: "https://github.com/okteto/api", Branch: "main", Commit: mainCommit},
+		"frontend": {Repository: "https://github.com/okteto/frontend", Branch: "v1.0.0", Tag: "v1.2.0", Commit: v120Commit},
+	}, loaded.Dependencies)
+}
+
+func TestShortRevision(t *testing.T) {
+	assert.Equal(t, "-", shortRevision(""))
+	assert.Equal(t, "1111111", shortRevision(mainCommit))
+	assert.Equal(t, "v1.2.0", shortRevision("v1.2.0"))
+}
@@ -32,6 +32,8 @@ type ConfigMapHandler interface {
 	TranslateConfigMapAndDeploy(context.Context, *pipeline.CfgData) (*apiv1.ConfigMap, error)
 	UpdateConfigMap(context.Context, *apiv1.ConfigMap, *pipeline.CfgData, error) error
 	UpdateEnvsFromCommands(context.Context, string, string, []string) error
+	UpdateOutputs(context.Context, string, string, map[string]string) error
+	UpdateResources(context.Context, string, string, []string) error
 	GetConfigmapVariablesEncoded(ctx context.Context, name, namespace string) (string, error)
 	AddPhaseDuration(context.Context, string, string, string, time.Duration) error
 }
@@ -115,6 +117,24 @@ func (ch *defaultConfigMapHandler) UpdateEnvsFromCommands(ctx context.Context, n
 	return nil
 }
 
+// UpdateOutputs updates the config map with the outputs published by the deploy commands
+func (ch *defaultConfigMapHandler) UpdateOutputs(ctx context.Context, name, namespace string, outputs map[string]string) error {
+	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
+	if err != nil {
+		return err
+	}
+	return pipeline.UpdateOutputs(ctx, name, namespace, outputs, c)
+}
+
+// UpdateResources updates the config map with the resources applied by the deploy commands
+func (ch *defaultConfigMapHandler) UpdateResources(ctx context.Context, name, namespace string, resources []string) error {
+	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
+	if err != nil {
+		return err
+	}
+	return pipeline.UpdateResources(ctx, name, namespace, resources, c)
+}
+
 func (ch *defaultConfigMapHandler) AddPhaseDuration(ctx context.Context, name, namespace, phase string, duration time.Duration) error {
 	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
 	if err != nil {
@@ -152,6 +172,26 @@ func (*deployInsideDeployConfigMapHandler) UpdateEnvsFromCommands(_ context.Cont
 	return nil
 }
 
+// UpdateOutputs with the receiver deployInsideDeployConfigMapHandler writes the outputs directly
+// because the commands publishing them only run in this execution
+func (ch *deployInsideDeployConfigMapHandler) UpdateOutputs(ctx context.Context, name, namespace string, outputs map[string]string) error {
+	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
+	if err != nil {
+		return err
+	}
+	return pipeline.UpdateOutputs(ctx, name, namespace, outputs, c)
+}
+
+// UpdateResources with the receiver deployInsideDeployConfigMapHandler writes the resources directly
+// because the commands applying them only run in this execution
+func (ch *deployInsideDeployConfigMapHandler) UpdateResources(ctx context.Context, name, namespace string, resources []string) error {
+	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
+	if err != nil {
+		return err
+	}
+	return pipeline.UpdateResources(ctx, name, namespace, resources, c)
+}
+
 func (ch *deployInsideDeployConfigMapHandler) AddPhaseDuration(ctx context.Context, name, namespace, phase string, duration time.Duration) error {
 	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
 	if err != nil {
@@ -90,6 +90,15 @@ func setDeployOptionsValuesFromManifest(ctx context.Context, deployOptions *Opti
 		}
 	}
 
+	if name := utils.PerUserName(deployOptions.Name); name != deployOptions.Name {
+		oktetoLog.Infof("using per-user name '%s'", name)
+		deployOptions.Name = name
+		deployOptions.Manifest.Name = name
+		if deployOptions.Manifest.Deploy != nil && deployOptions.Manifest.Deploy.ComposeSection != nil && deployOptions.Manifest.Deploy.ComposeSection.Stack != nil {
+			deployOptions.Manifest.Deploy.ComposeSection.Stack.Name = name
+		}
+	}
+
 	if deployOptions.Manifest.Deploy != nil && deployOptions.Manifest.Deploy.ComposeSection != nil && deployOptions.Manifest.Deploy.ComposeSection.Stack != nil {
 
 		mergeServicesToDeployFromOptionsAndManifest(deployOptions)
@@ -131,7 +140,7 @@ func mergeServicesToDeployFromOptionsAndManifest(deployOptions *Options) {
 	}
 
 	if len(deployOptions.ServicesToDeploy) > 0 && len(manifestDeclaredServicesToDeploy) > 0 {
-		oktetoLog.Warning("overwriting manifest's `services to deploy` with command line arguments")
+		oktetoLog.WarningWithID(oktetoLog.WarnServicesToDeployOverridden, "overwriting manifest's `services to deploy` with command line arguments")
 	}
 	if len(deployOptions.ServicesToDeploy) == 0 && len(manifestDeclaredServicesToDeploy) > 0 {
 		deployOptions.ServicesToDeploy = manifestDeclaredServicesToDeploy
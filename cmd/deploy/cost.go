@@ -0,0 +1,95 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const bytesPerGB = 1024 * 1024 * 1024
+
+// resourceRequests is the total amount of resources requested by the workloads of a dev environment
+type resourceRequests struct {
+	cpu    float64
+	memory float64
+}
+
+// getResourceRequests returns the resources requested by the deployments and statefulsets deployed by name
+func getResourceRequests(ctx context.Context, name, namespace string, c kubernetes.Interface) (resourceRequests, error) {
+	result := resourceRequests{}
+	opts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name)),
+	}
+
+	deployments, err := c.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return result, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		result.add(d.Spec.Template.Spec, d.Spec.Replicas)
+	}
+
+	sfsList, err := c.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return result, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, sfs := range sfsList.Items {
+		result.add(sfs.Spec.Template.Spec, sfs.Spec.Replicas)
+	}
+	return result, nil
+}
+
+func (r *resourceRequests) add(spec apiv1.PodSpec, replicas *int32) {
+	n := int32(1)
+	if replicas != nil {
+		n = *replicas
+	}
+	for _, container := range spec.Containers {
+		if cpu, ok := container.Resources.Requests[apiv1.ResourceCPU]; ok {
+			r.cpu += cpu.AsApproximateFloat64() * float64(n)
+		}
+		if memory, ok := container.Resources.Requests[apiv1.ResourceMemory]; ok {
+			r.memory += memory.AsApproximateFloat64() / bytesPerGB * float64(n)
+		}
+	}
+}
+
+// getCostEstimate returns the message with the estimated monthly cost of the requested resources
+func getCostEstimate(requests resourceRequests, pricing *okteto.Pricing) string {
+	summary := fmt.Sprintf("Requested resources: %.2f CPU, %.2f GB of memory", requests.cpu, requests.memory)
+	if pricing == nil || (pricing.CPU == 0 && pricing.Memory == 0) {
+		return fmt.Sprintf("%s. Run 'okteto context use --cpu-price <price> --memory-price <price>' to estimate its monthly cost", summary)
+	}
+	cost := requests.cpu*pricing.CPU + requests.memory*pricing.Memory
+	return fmt.Sprintf("%s. Estimated monthly cost: %.2f", summary, cost)
+}
+
+// showCostEstimate displays the estimated monthly cost of the resources requested by the dev environment
+func showCostEstimate(ctx context.Context, name, namespace string, c kubernetes.Interface) {
+	requests, err := getResourceRequests(ctx, name, namespace, c)
+	if err != nil {
+		oktetoLog.Infof("could not estimate the cost of '%s': %s", name, err)
+		return
+	}
+	oktetoLog.Information(getCostEstimate(requests, okteto.GetContext().Pricing))
+}
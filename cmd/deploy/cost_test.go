@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPodSpec(cpu, memory string) apiv1.PodTemplateSpec {
+	return apiv1.PodTemplateSpec{
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{
+					Resources: apiv1.ResourceRequirements{
+						Requests: apiv1.ResourceList{
+							apiv1.ResourceCPU:    resource.MustParse(cpu),
+							apiv1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_getResourceRequests(t *testing.T) {
+	replicas := int32(2)
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns", Labels: map[string]string{model.DeployedByLabel: "movies"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Template: newPodSpec("500m", "1Gi")},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns", Labels: map[string]string{model.DeployedByLabel: "movies"}},
+			Spec:       appsv1.StatefulSetSpec{Template: newPodSpec("1", "512Mi")},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns", Labels: map[string]string{model.DeployedByLabel: "other"}},
+			Spec:       appsv1.DeploymentSpec{Template: newPodSpec("4", "8Gi")},
+		},
+	)
+
+	requests, err := getResourceRequests(context.Background(), "movies", "ns", c)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0, requests.cpu, 0.001)
+	assert.InDelta(t, 2.5, requests.memory, 0.001)
+}
+
+func Test_getCostEstimate(t *testing.T) {
+	requests := resourceRequests{cpu: 2, memory: 4}
+
+	assert.Equal(t, "Requested resources: 2.00 CPU, 4.00 GB of memory. Estimated monthly cost: 70.00", getCostEstimate(requests, &okteto.Pricing{CPU: 25, Memory: 5}))
+	assert.Contains(t, getCostEstimate(requests, nil), "okteto context use --cpu-price")
+	assert.Contains(t, getCostEstimate(requests, &okteto.Pricing{}), "okteto context use --cpu-price")
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/deps"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// dependencyConditionInterval is the interval to check the 'waitFor' conditions of a dependency
+	dependencyConditionInterval = 3 * time.Second
+
+	errConditionNotMet = errors.New("condition not met yet")
+)
+
+// endpointChecker returns nil if the endpoint is responding
+type endpointChecker func(ctx context.Context, endpoint string) error
+
+// dependencyWaiter waits for the 'waitFor' conditions of a dependency
+type dependencyWaiter struct {
+	k8sClient     kubernetes.Interface
+	checkEndpoint endpointChecker
+}
+
+func newDependencyWaiter(c kubernetes.Interface) *dependencyWaiter {
+	return &dependencyWaiter{
+		k8sClient:     c,
+		checkEndpoint: checkEndpointResponds,
+	}
+}
+
+// wait blocks until all the conditions of the dependency are met, in the order they are defined
+func (dw *dependencyWaiter) wait(ctx context.Context, name, namespace string, conditions []deps.WaitCondition, timeout time.Duration) error {
+	for _, c := range conditions {
+		if c.Endpoint != "" {
+			if err := c.ValidateEndpoint(); err != nil {
+				return err
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, c := range conditions {
+		if err := dw.waitCondition(ctx, name, namespace, c); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("%s of dependency '%s' was not ready after %s", c.String(), name, timeout),
+					Hint: "Increase the timeout of the dependency or check its logs in the Okteto UI",
+				}
+			}
+			return err
+		}
+		oktetoLog.Success("Dependency '%s': %s is ready", name, c.String())
+	}
+	return nil
+}
+
+func (dw *dependencyWaiter) waitCondition(ctx context.Context, name, namespace string, c deps.WaitCondition) error {
+	oktetoLog.Spinner(fmt.Sprintf("Waiting for %s of dependency '%s'...", c.String(), name))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	ticker := time.NewTicker(dependencyConditionInterval)
+	defer ticker.Stop()
+	for {
+		err := dw.check(ctx, name, namespace, c)
+		if !errors.Is(err, errConditionNotMet) {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (dw *dependencyWaiter) check(ctx context.Context, name, namespace string, c deps.WaitCondition) error {
+	switch {
+	case c.Variable != "":
+		return dw.checkVariable(ctx, name, namespace, c.Variable)
+	case c.Endpoint != "":
+		if err := dw.checkEndpoint(ctx, c.Endpoint); err != nil {
+			oktetoLog.Infof("endpoint '%s' is not ready: %s", c.Endpoint, err)
+			return errConditionNotMet
+		}
+		return nil
+	default:
+		return dw.checkJob(ctx, namespace, c.Job)
+	}
+}
+
+// checkVariable checks if the dependency exported the variable with OKTETO_ENV
+func (dw *dependencyWaiter) checkVariable(ctx context.Context, name, namespace, variable string) error {
+	status, envs, err := pipeline.GetDependencyEnvs(ctx, name, namespace, dw.k8sClient)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return errConditionNotMet
+		}
+		return err
+	}
+	if _, ok := envs[variable]; ok {
+		return nil
+	}
+	switch status {
+	case pipeline.ErrorStatus:
+		return fmt.Errorf("dependency '%s' failed before exporting the variable '%s'", name, variable)
+	case pipeline.DeployedStatus:
+		return fmt.Errorf("dependency '%s' was deployed without exporting the variable '%s' to $OKTETO_ENV", name, variable)
+	}
+	return errConditionNotMet
+}
+
+// checkJob checks if the job completed successfully
+func (dw *dependencyWaiter) checkJob(ctx context.Context, namespace, name string) error {
+	job, err := dw.k8sClient.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return errConditionNotMet
+		}
+		return err
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != apiv1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return nil
+		case batchv1.JobFailed:
+			return fmt.Errorf("job '%s' failed: %s", name, condition.Message)
+		}
+	}
+	return errConditionNotMet
+}
+
+// checkEndpointResponds returns nil if the endpoint responds without a server error
+func checkEndpointResponds(ctx context.Context, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/deps"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func dependencyConfigMap(name, status, envs string) *apiv1.ConfigMap {
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipeline.TranslatePipelineName(name),
+			Namespace: "test",
+		},
+		Data: map[string]string{"status": status},
+	}
+	if envs != "" {
+		cmap.Data[constants.OktetoDependencyEnvsKey] = base64.StdEncoding.EncodeToString([]byte(envs))
+	}
+	return cmap
+}
+
+func TestDependencyWaiterCheck(t *testing.T) {
+	ctx := context.Background()
+	var tests = []struct {
+		expectedErr error
+		condition   deps.WaitCondition
+		name        string
+		objects     []runtime.Object
+		expectFail  bool
+	}{
+		{
+			name:      "variable exported",
+			condition: deps.WaitCondition{Variable: "API_URL"},
+			objects:   []runtime.Object{dependencyConfigMap("api", pipeline.DeployedStatus, `{"API_URL":"https://api"}`)},
+		},
+		{
+			name:        "variable not exported yet",
+			condition:   deps.WaitCondition{Variable: "API_URL"},
+			objects:     []runtime.Object{dependencyConfigMap("api", pipeline.ProgressingStatus, "")},
+			expectedErr: errConditionNotMet,
+		},
+		{
+			name:        "pipeline not created yet",
+			condition:   deps.WaitCondition{Variable: "API_URL"},
+			expectedErr: errConditionNotMet,
+		},
+		{
+			name:       "variable never exported",
+			condition:  deps.WaitCondition{Variable: "API_URL"},
+			objects:    []runtime.Object{dependencyConfigMap("api", pipeline.DeployedStatus, `{"DB_URL":"postgres"}`)},
+			expectFail: true,
+		},
+		{
+			name:       "pipeline failed",
+			condition:  deps.WaitCondition{Variable: "API_URL"},
+			objects:    []runtime.Object{dependencyConfigMap("api", pipeline.ErrorStatus, "")},
+			expectFail: true,
+		},
+		{
+			name:      "job completed",
+			condition: deps.WaitCondition{Job: "migrations"},
+			objects: []runtime.Object{&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrations", Namespace: "test"},
+				Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobComplete, Status: apiv1.ConditionTrue},
+				}},
+			}},
+		},
+		{
+			name:      "job running",
+			condition: deps.WaitCondition{Job: "migrations"},
+			objects: []runtime.Object{&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrations", Namespace: "test"},
+				Status:     batchv1.JobStatus{Active: 1},
+			}},
+			expectedErr: errConditionNotMet,
+		},
+		{
+			name:      "job failed",
+			condition: deps.WaitCondition{Job: "migrations"},
+			objects: []runtime.Object{&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrations", Namespace: "test"},
+				Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: apiv1.ConditionTrue, Message: "BackoffLimitExceeded"},
+				}},
+			}},
+			expectFail: true,
+		},
+		{
+			name:        "job not created yet",
+			condition:   deps.WaitCondition{Job: "migrations"},
+			expectedErr: errConditionNotMet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dw := newDependencyWaiter(fake.NewSimpleClientset(tt.objects...))
+			err := dw.check(ctx, "api", "test", tt.condition)
+			switch {
+			case tt.expectedErr != nil:
+				assert.ErrorIs(t, err, tt.expectedErr)
+			case tt.expectFail:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, errConditionNotMet)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDependencyWaiterWaitEndpoint(t *testing.T) {
+	dependencyConditionInterval = time.Millisecond
+	defer func() {
+		dependencyConditionInterval = 3 * time.Second
+	}()
+
+	calls := 0
+	dw := &dependencyWaiter{
+		k8sClient: fake.NewSimpleClientset(),
+		checkEndpoint: func(_ context.Context, _ string) error {
+			calls++
+			if calls < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	}
+	err := dw.wait(context.Background(), "api", "test", []deps.WaitCondition{{Endpoint: "https://api.okteto.dev/healthz"}}, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDependencyWaiterWaitTimeout(t *testing.T) {
+	dependencyConditionInterval = time.Millisecond
+	defer func() {
+		dependencyConditionInterval = 3 * time.Second
+	}()
+
+	dw := newDependencyWaiter(fake.NewSimpleClientset())
+	err := dw.wait(context.Background(), "api", "test", []deps.WaitCondition{{Job: "migrations"}}, 10*time.Millisecond)
+	var userErr oktetoErrors.UserError
+	assert.ErrorAs(t, err, &userErr)
+}
+
+func TestDependencyWaiterWaitInvalidEndpoint(t *testing.T) {
+	dw := newDependencyWaiter(fake.NewSimpleClientset())
+	err := dw.wait(context.Background(), "api", "test", []deps.WaitCondition{{Endpoint: "api:8080"}}, time.Second)
+	assert.Error(t, err)
+}
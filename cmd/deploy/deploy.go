@@ -35,6 +35,7 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/deployable"
+	"github.com/okteto/okteto/pkg/deps"
 	"github.com/okteto/okteto/pkg/divert"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -46,11 +47,13 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
+	"github.com/okteto/okteto/pkg/remote"
 	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/okteto/okteto/pkg/validator"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -86,6 +89,37 @@ type Options struct {
 	RunInRemote      bool
 	Wait             bool
 	ShowCTA          bool
+	// GHASummary writes the result of the deploy to the GitHub Actions job summary
+	GHASummary bool
+	Atomic     bool
+	// CostEstimate shows the estimated monthly cost of the resources requested by the dev environment
+	CostEstimate bool
+	// RequireLocal fails the deploy if the commands would run remotely
+	RequireLocal bool
+	// RequireRemote fails the deploy if the commands can't run remotely
+	RequireRemote bool
+	// RawOutput shows the output of the deploy commands without collapsing or summarizing it
+	RawOutput bool
+	// Where prints where each phase of the deploy runs, in the given format, without deploying
+	Where string
+	// StrictVars fails the deploy if the manifest references undefined variables or if any variable is not used
+	StrictVars bool
+	// NoSeed skips the seeds defined in the manifest
+	NoSeed bool
+	// TTL is the time to live of the development environment. Zero keeps the current expiration
+	TTL time.Duration
+	// Diff shows the changes of the deploy commands to the resources before they are applied
+	Diff bool
+	// Confirm asks to accept the changes of the deploy commands to each resource before they are applied
+	Confirm bool
+	// LockTimeout is how long to wait for a concurrent deploy of the same development environment to finish. Zero fails right away
+	LockTimeout time.Duration
+	// IdempotencyKey skips the deploy if the last deploy of the development environment succeeded with the same key
+	IdempotencyKey string
+	// DryRun renders the manifests of the deploy commands without applying them to the cluster
+	DryRun bool
+	// RollbackOnFailure deploys again the last successful deploy if the deploy fails
+	RollbackOnFailure bool
 }
 
 type builderInterface interface {
@@ -121,6 +155,7 @@ type Command struct {
 	IoCtrl            *io.Controller
 	K8sLogger         *io.K8sLogger
 	InsightsTracker   buildDeployTrackerInterface
+	NsDestroyerGetter func() (nsDestroyer, error)
 
 	PipelineType model.Archetype
 	// onCleanUp is a list of functions to be executed when the execution is interrupted. This is a hack
@@ -128,6 +163,15 @@ type Command struct {
 	// This can probably be improved using context cancellation
 	onCleanUp []cleanUpFunc
 
+	// pipelineState is the pipeline being deployed, used to update its status if the execution is interrupted
+	pipelineState pipelineState
+
+	// endpointProber checks the endpoints of the development environment when --wait is set
+	endpointProber *endpointProber
+
+	// waitStartedAt is when the deploy started waiting for the deployed resources
+	waitStartedAt time.Time
+
 	IsRemote           bool
 	RunningInInstaller bool
 }
@@ -173,6 +217,14 @@ func Deploy(ctx context.Context, at AnalyticsTrackerInterface, insightsTracker b
 				return err
 			}
 
+			if err := remote.ValidateWhereFormat(options.Where); err != nil {
+				return err
+			}
+
+			if err := validateDryRun(options); err != nil {
+				return err
+			}
+
 			// This is needed because the deploy command needs the original kubeconfig configuration even in the execution within another
 			// deploy command. If not, we could be proxying a proxy and we would be applying the incorrect deployed-by label
 			os.Setenv(constants.OktetoSkipConfigCredentialsUpdate, "false")
@@ -192,7 +244,11 @@ func Deploy(ctx context.Context, at AnalyticsTrackerInterface, insightsTracker b
 				}
 			}
 
-			if okteto.IsOkteto() {
+			if options.Where != "" {
+				return showExecutionReport(options, afero.NewOsFs())
+			}
+
+			if okteto.IsOkteto() && !options.DryRun {
 				create, err := utils.ShouldCreateNamespace(ctx, okteto.GetContext().Namespace)
 				if err != nil {
 					return err
@@ -212,6 +268,14 @@ func Deploy(ctx context.Context, at AnalyticsTrackerInterface, insightsTracker b
 			options.ServicesToDeploy = args
 
 			k8sClientProvider := okteto.NewK8sClientProviderWithLogger(k8sLogger)
+			k8sClient, _, err := k8sClientProvider.Provide(okteto.GetContext().Cfg)
+			if err != nil {
+				return err
+			}
+			if err := setNamespaceVariables(ctx, okteto.GetContext().Namespace, k8sClient, os.LookupEnv, os.Setenv); err != nil {
+				oktetoLog.Infof("could not get the variables of namespace '%s': %s", okteto.GetContext().Namespace, err)
+			}
+
 			pc, err := pipelineCMD.NewCommand()
 			if err != nil {
 				return fmt.Errorf("could not create pipeline command: %w", err)
@@ -239,8 +303,9 @@ func Deploy(ctx context.Context, at AnalyticsTrackerInterface, insightsTracker b
 				IoCtrl:             ioCtrl,
 				K8sLogger:          k8sLogger,
 
-				onCleanUp:       []cleanUpFunc{},
-				InsightsTracker: insightsTracker,
+				onCleanUp:         []cleanUpFunc{},
+				InsightsTracker:   insightsTracker,
+				NsDestroyerGetter: newNsDestroyer,
 			}
 			startTime := time.Now()
 
@@ -248,14 +313,20 @@ func Deploy(ctx context.Context, at AnalyticsTrackerInterface, insightsTracker b
 			signal.Notify(stop, os.Interrupt)
 			exit := make(chan error, 1)
 
+			runCtx, cancelRun := context.WithCancel(ctx)
+			defer cancelRun()
+
 			go func() {
-				err := c.Run(ctx, options)
+				err := c.Run(runCtx, options)
 				namespace := okteto.GetContext().Namespace
 				if options.Manifest != nil {
 					namespace = options.Manifest.Namespace
 				}
 				c.InsightsTracker.TrackDeploy(ctx, options.Name, namespace, err == nil)
 				c.TrackDeploy(options.Manifest, options.RunInRemote, startTime, err)
+				if options.GHASummary {
+					c.writeGHASummary(ctx, options, time.Since(startTime), err)
+				}
 				exit <- err
 			}()
 
@@ -266,7 +337,8 @@ func Deploy(ctx context.Context, at AnalyticsTrackerInterface, insightsTracker b
 				oktetoLog.StartSpinner()
 				defer oktetoLog.StopSpinner()
 
-				c.cleanUp(ctx, oktetoErrors.ErrIntSig)
+				cancelRun()
+				c.handleInterrupt(ctx, options)
 				return oktetoErrors.ErrIntSig
 			case err := <-exit:
 				return err
@@ -282,9 +354,27 @@ func Deploy(ctx context.Context, at AnalyticsTrackerInterface, insightsTracker b
 	cmd.Flags().BoolVarP(&options.Build, "build", "", false, "force build of images when deploying the development environment")
 	cmd.Flags().BoolVarP(&options.Dependencies, "dependencies", "", false, "deploy the dependencies from manifest")
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute commands without bash")
-	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run deploy commands in remote")
-
-	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the development environment is deployed (defaults to false)")
+	cmd.Flags().BoolVarP(&options.NoSeed, "no-seed", "", false, "skip the seeds defined in the okteto manifest")
+	cmd.Flags().BoolVarP(&options.StrictVars, "strict-vars", "", false, "fail if the okteto manifest references undefined variables or if any variable set with '--var' is not used")
+	cmd.Flags().BoolVarP(&options.RawOutput, "raw-output", "", false, "show the output of the deploy commands as is, without collapsing progress lines or summarizing each command")
+	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run deploy commands in remote. Your local files, including uncommitted changes, are sent to the remote runner")
+	cmd.Flags().BoolVarP(&options.RequireLocal, "require-local", "", false, "fail if the deploy commands would run remotely")
+	cmd.Flags().BoolVarP(&options.RequireRemote, "require-remote", "", false, "run the deploy commands in remote and fail if remote execution is not available")
+	cmd.Flags().StringVarP(&options.Where, "where", "", "", "show where each phase of the deploy runs and why, without deploying. One of: ['text', 'json']")
+	cmd.Flags().Lookup("where").NoOptDefVal = remote.WhereFormatText
+
+	cmd.Flags().BoolVarP(&options.GHASummary, "gha-summary", "", false, "write a summary of the deploy to the GitHub Actions job summary and emit annotations for failures")
+	cmd.Flags().BoolVarP(&options.Atomic, "atomic", "", false, "roll back the resources deployed so far if the deploy is interrupted. Only applies to dev environments deployed for the first time")
+	cmd.Flags().BoolVarP(&options.CostEstimate, "cost-estimate", "", false, "show the estimated monthly cost of the resources requested by the development environment")
+	cmd.Flags().BoolVarP(&options.Diff, "diff", "", false, "show the changes of the deploy commands to each resource, computed with a server-side dry run, before they are applied. With --dry-run, the changes are only shown and compared with the resources of the last deploy")
+	cmd.Flags().BoolVarP(&options.Confirm, "confirm", "", false, "show the changes of the deploy commands to each resource and ask to apply them. Only available when the deploy commands run locally")
+	cmd.Flags().BoolVarP(&options.RollbackOnFailure, "rollback-on-failure", "", false, "deploy again the images and commands of the last successful deploy if the deploy fails. It can also be enabled with 'deploy.rollback: auto' in the okteto manifest")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "show the images that would be built and the manifests rendered by the kubectl and helm deploy commands, without applying any change to the cluster")
+
+	cmd.Flags().DurationVar(&options.LockTimeout, "lock-timeout", 0, "maximum time to wait for a concurrent deploy of the same development environment to finish, e.g. 10m. By default the deploy fails right away")
+	cmd.Flags().StringVar(&options.IdempotencyKey, "idempotency-key", os.Getenv(constants.OktetoIdempotencyKeyEnvVar), "skip the deploy if the last deploy of the development environment succeeded with the same key, e.g. the id of your CI pipeline (defaults to the value of OKTETO_IDEMPOTENCY_KEY)")
+	cmd.Flags().DurationVar(&options.TTL, "ttl", 0, "time to live of the development environment, e.g. 48h. Okteto warns about the development environments whose time to live has expired (defaults to the value of OKTETO_DEPLOY_TTL)")
+	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the development environment is deployed and the endpoints declared in 'deploy.healthchecks' respond with a 2xx status code (defaults to false)")
 	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", getDefaultTimeout(), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
 
 	return cmd
@@ -322,6 +412,12 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 	}
 	deployOptions.Manifest = manifest
 	oktetoLog.Debug("found okteto manifest")
+
+	if deployOptions.StrictVars {
+		if err := deployOptions.Manifest.CheckStrictVars(deployOptions.Variables); err != nil {
+			return err
+		}
+	}
 	dc.PipelineType = deployOptions.Manifest.Type
 
 	if deployOptions.Manifest.Deploy == nil && !deployOptions.Manifest.HasDependencies() {
@@ -332,6 +428,12 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 		return oktetoErrors.ErrDeployCantDeploySvcsIfNotCompose
 	}
 
+	if !okteto.GetContext().IsOkteto {
+		if err := validateManifestInVanilla(deployOptions.Manifest); err != nil {
+			return err
+		}
+	}
+
 	// We need to create a client that doesn't go through the proxy to create
 	// the configmap without the deployedByLabel
 	c, _, err := dc.K8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, dc.K8sLogger)
@@ -346,7 +448,7 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 
 	topLevelGitDir, err := repository.FindTopLevelGitDir(cwd)
 	if err != nil {
-		oktetoLog.Warning("Repository not detected: the env vars '%s' and '%s' might not be available.\n    For more information, check out: https://www.okteto.com/docs/core/okteto-variables/#default-environment-variables", constants.OktetoGitBranchEnvVar, constants.OktetoGitCommitEnvVar)
+		oktetoLog.WarningWithID(oktetoLog.WarnRepositoryNotDetected, "Repository not detected: the env vars '%s' and '%s' might not be available.\n    For more information, check out: https://www.okteto.com/docs/core/okteto-variables/#default-environment-variables", constants.OktetoGitBranchEnvVar, constants.OktetoGitCommitEnvVar)
 	}
 
 	if topLevelGitDir != "" {
@@ -373,6 +475,17 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 		}
 	}
 
+	// the remote deployer doesn't verify the helm releases again, they are verified by the main command execution
+	if len(deployOptions.Manifest.HelmReleases) > 0 && !dc.IsRemote {
+		if err := verifyHelmReleases(ctx, deployOptions.Manifest, c); err != nil {
+			return err
+		}
+	}
+
+	if deployOptions.DryRun {
+		return dc.dryRun(ctx, deployOptions)
+	}
+
 	// This is the manifest path to be stored in the config map. It should be relative to the repository root, so next operations
 	// triggered from the UI would take the correct manifest. So, it is calculated from the topLevelGitDir and the absolute path
 	// of the manifest file.
@@ -400,21 +513,71 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 		Namespace:  deployOptions.Manifest.Namespace,
 		Repository: os.Getenv(model.GithubRepositoryEnvVar),
 		Branch:     os.Getenv(constants.OktetoGitBranchEnvVar),
+		Commit:     os.Getenv(constants.OktetoGitCommitEnvVar),
 		Filename:   manifestPathForConfigMap,
 		Status:     pipeline.ProgressingStatus,
 		Manifest:   deployOptions.Manifest.Manifest,
 		Icon:       deployOptions.Manifest.Icon,
 		Variables:  deployOptions.Variables,
 	}
+	if utils.IsPerUserNamesEnabled() {
+		data.Owner = okteto.GetSanitizedUsername()
+	}
+	if topLevelGitDir != "" {
+		data.Repositories = getSiblingRepositories(deployOptions.Manifest, topLevelGitDir)
+	}
 
 	if !deployOptions.Manifest.IsV2 && deployOptions.Manifest.Type == model.StackType && deployOptions.Manifest.Deploy != nil {
 		data.Manifest = deployOptions.Manifest.Deploy.ComposeSection.Stack.Manifest
 	}
 
+	ttl, err := getDeployTTL(deployOptions)
+	if err != nil {
+		return err
+	}
+	if ttl > 0 {
+		data.ExpiresAt = time.Now().Add(ttl)
+	}
+
+	if !dc.IsRemote && !dc.RunningInInstaller {
+		pipeline.WarnExpired(ctx, deployOptions.Manifest.Namespace, deployOptions.Name, c)
+	}
+
+	firstDeploy := false
+	if deployOptions.Atomic {
+		_, errStatus := pipeline.GetStatus(ctx, deployOptions.Name, deployOptions.Manifest.Namespace, c)
+		firstDeploy = k8sErrors.IsNotFound(errStatus)
+	}
+
+	if !dc.IsRemote && !dc.RunningInInstaller {
+		lease, err := acquireDeployLock(ctx, deployOptions, data, c)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := lease.Release(ctx, deployOptions.IdempotencyKey, data.Status == pipeline.DeployedStatus); err != nil {
+				oktetoLog.Infof("failed to release the deploy lock of '%s': %s", deployOptions.Name, err)
+			}
+		}()
+		os.Setenv(constants.OktetoDeployLockHolderEnvVar, lease.Holder())
+
+		if deployOptions.IdempotencyKey != "" {
+			deployed, err := pipeline.IsDeployedWithIdempotencyKey(ctx, deployOptions.Name, deployOptions.Manifest.Namespace, deployOptions.IdempotencyKey, c)
+			if err != nil {
+				return err
+			}
+			if deployed {
+				oktetoLog.Success("'%s' was already deployed with the idempotency key '%s'", deployOptions.Name, deployOptions.IdempotencyKey)
+				return nil
+			}
+		}
+	}
+
 	cfg, err := dc.CfgMapHandler.TranslateConfigMapAndDeploy(ctx, data)
 	if err != nil {
 		return err
 	}
+	dc.pipelineState.set(cfg, data, firstDeploy)
 
 	os.Setenv(constants.OktetoNameEnvVar, deployOptions.Name)
 
@@ -442,6 +605,14 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 
 	oktetoLog.EnableMasking()
 	err = dc.deploy(ctx, deployOptions, cwd, c)
+	rollbackHint := ""
+	if err != nil && err != oktetoErrors.ErrIntSig && dc.shouldRollbackOnFailure(deployOptions) {
+		if rollbackErr := dc.rollbackToLastSuccessfulDeploy(ctx, deployOptions, c); rollbackErr != nil {
+			oktetoLog.Warning("'%s' was not rolled back: %s", deployOptions.Name, rollbackErr)
+		} else {
+			rollbackHint = fmt.Sprintf("'%s' was rolled back to its last successful deploy", deployOptions.Name)
+		}
+	}
 	oktetoLog.DisableMasking()
 	oktetoLog.SetStage("done")
 	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "EOF")
@@ -450,7 +621,7 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 		if err == oktetoErrors.ErrIntSig {
 			return nil
 		}
-		err = oktetoErrors.UserError{E: err}
+		err = oktetoErrors.UserError{E: err, Hint: rollbackHint}
 		data.Status = pipeline.ErrorStatus
 	} else {
 		// This has to be set only when the command succeeds for the case in which the deploy is executed within an
@@ -463,8 +634,18 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 			return err
 		}
 		if hasDeployed {
+			// the post-deploy hooks already waited for the resources to be running
+			if deployOptions.Wait && !dc.hasPostDeployHooks(deployOptions) {
+				if err := dc.waitForResources(ctx, deployOptions); err != nil {
+					return err
+				}
+			}
 			if deployOptions.Wait {
-				if err := dc.DeployWaiter.wait(ctx, deployOptions); err != nil {
+				if err := dc.waitForHealthyEndpoints(ctx, deployOptions); err != nil {
+					data.Status = pipeline.ErrorStatus
+					if errStatus := dc.CfgMapHandler.UpdateConfigMap(ctx, cfg, data, err); errStatus != nil {
+						return errStatus
+					}
 					return err
 				}
 			}
@@ -473,15 +654,23 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 				if err != nil {
 					oktetoLog.Infof("could not create endpoint getter: %s", err)
 				}
-				if err := eg.showEndpoints(ctx, &EndpointsOptions{Name: deployOptions.Name, Namespace: deployOptions.Manifest.Namespace}); err != nil {
+				if err := eg.showEndpoints(ctx, &EndpointsOptions{Name: deployOptions.Name, Namespace: deployOptions.Manifest.Namespace, External: deployOptions.Manifest.External}); err != nil {
 					oktetoLog.Infof("could not retrieve endpoints: %s", err)
 				}
 			}
+			if deployOptions.CostEstimate {
+				showCostEstimate(ctx, deployOptions.Name, deployOptions.Manifest.Namespace, c)
+			}
 			if deployOptions.ShowCTA {
 				oktetoLog.Success(succesfullyDeployedmsg, deployOptions.Name)
 			}
 			pipeline.AddDevAnnotations(ctx, deployOptions.Manifest, c)
 		}
+		if !dc.IsRemote {
+			if err := dc.saveDeployedState(ctx, deployOptions, c); err != nil {
+				oktetoLog.Infof("could not store the state of the deploy: %s", err)
+			}
+		}
 		data.Status = pipeline.DeployedStatus
 	}
 
@@ -542,9 +731,55 @@ func (dc *Command) deploy(ctx context.Context, deployOptions *Options, cwd strin
 		oktetoLog.SetStage("")
 	}
 
+	// seeds run once per deploy, from the main command execution, so they are skipped in the remote deployer
+	if len(deployOptions.Manifest.Seed) > 0 && !deployOptions.NoSeed && !dc.IsRemote {
+		runner := newSeedRunner(c)
+		if err := runner.run(ctx, seedRunOptions{
+			name:      deployOptions.Name,
+			namespace: deployOptions.Manifest.Namespace,
+			seeds:     deployOptions.Manifest.Seed,
+			variables: deployOptions.Variables,
+		}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// getDeployTTL returns the time to live of the development environment set by the '--ttl' flag,
+// the OKTETO_DEPLOY_TTL variable or the OKTETO_DEPLOY_TTL environment variable
+func getDeployTTL(options *Options) (time.Duration, error) {
+	if options.TTL != 0 {
+		if options.TTL < 0 {
+			return 0, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid time to live '%s': it must be greater than zero", options.TTL),
+				Hint: "Use a duration like '--ttl 48h'",
+			}
+		}
+		return options.TTL, nil
+	}
+
+	value := os.Getenv(constants.OktetoDeployTTLEnvVar)
+	for _, v := range options.Variables {
+		name, val, _ := strings.Cut(v, "=")
+		if name == constants.OktetoDeployTTLEnvVar {
+			value = val
+		}
+	}
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := pipeline.ParseTTL(value)
+	if err != nil {
+		return 0, oktetoErrors.UserError{
+			E:    err,
+			Hint: fmt.Sprintf("Set '%s' to a duration like '48h'", constants.OktetoDeployTTLEnvVar),
+		}
+	}
+	return ttl, nil
+}
+
 func getDefaultTimeout() time.Duration {
 	defaultTimeout := 5 * time.Minute
 	t := os.Getenv(model.OktetoTimeoutEnvVar)
@@ -563,30 +798,93 @@ func getDefaultTimeout() time.Duration {
 }
 
 func shouldRunInRemote(opts *Options) bool {
-	// already in remote so we need to deploy locally
-	if env.LoadBoolean(constants.OktetoDeployRemote) {
-		return false
+	if remote.IsRemoteRequested(newExecutionRequest(opts)) {
+		return true
 	}
 
-	// --remote flag enabled from command line
-	if opts.RunInRemote {
-		return true
+	// already in remote so we need to deploy locally
+	if !env.LoadBoolean(constants.OktetoDeployRemote) {
+		oktetoLog.Information("Okteto recommends that you enable remote execution for your deploy commands.\n    More information available here: https://www.okteto.com/docs/core/remote-execution")
 	}
+	return false
+}
 
-	// remote option set in the manifest via a remote deployer image or the remote option enabled
+// newExecutionRequest returns the information used to decide where the deploy commands run
+func newExecutionRequest(opts *Options) remote.ExecutionRequest {
+	req := remote.ExecutionRequest{
+		Command:       remote.DeployCommand,
+		RemoteFlag:    opts.RunInRemote,
+		RequireLocal:  opts.RequireLocal,
+		RequireRemote: opts.RequireRemote,
+	}
 	if opts.Manifest != nil && opts.Manifest.Deploy != nil {
-		if opts.Manifest.Deploy.Image != "" || opts.Manifest.Deploy.Remote {
-			return true
+		req.ManifestImage = opts.Manifest.Deploy.Image
+		req.ManifestRemote = opts.Manifest.Deploy.Remote
+	}
+	return req
+}
+
+// getExecutionReport returns where each phase of the deploy runs and why
+func getExecutionReport(opts *Options, octx *okteto.Context) (remote.ExecutionReport, error) {
+	report := remote.ExecutionReport{Command: remote.DeployCommand}
+	manifest := opts.Manifest
+
+	if manifest.HasDependencies() {
+		report.Phases = append(report.Phases, remote.Phase{
+			Name:   "dependencies",
+			Where:  remote.ExecutionRemote,
+			Reason: "dependencies are deployed as Okteto pipelines",
+		})
+	}
+
+	if len(manifest.Build) > 0 {
+		phase := remote.Phase{
+			Name:   "build",
+			Where:  remote.ExecutionLocal,
+			Reason: "images are built with the local docker daemon",
 		}
+		if octx.Builder != "" {
+			phase.Where = remote.ExecutionRemote
+			phase.Reason = fmt.Sprintf("images are built by the BuildKit instance at '%s'", octx.Builder)
+		}
+		report.Phases = append(report.Phases, phase)
 	}
 
-	if env.LoadBoolean(constants.OktetoForceRemote) {
-		return true
+	if manifest.Deploy == nil {
+		return report, nil
 	}
 
-	oktetoLog.Information("Okteto recommends that you enable remote execution for your deploy commands.\n    More information available here: https://www.okteto.com/docs/core/remote-execution")
-	return false
+	req := newExecutionRequest(opts)
+	req.IsOkteto = octx.IsOkteto
+	commands, err := remote.ResolveCommandsExecution(req)
+	if err != nil {
+		return remote.ExecutionReport{}, err
+	}
+	report.Phases = append(report.Phases, commands)
+
+	if manifest.Deploy.ComposeSection != nil {
+		report.Phases = append(report.Phases, remote.Phase{
+			Name:   "compose",
+			Where:  remote.ExecutionLocal,
+			Reason: "compose services are always deployed from the okteto CLI",
+		})
+	}
+	return report, nil
+}
+
+// showExecutionReport prints where each phase of the deploy runs without deploying
+func showExecutionReport(opts *Options, fs afero.Fs) error {
+	manifest, err := model.GetManifestV2(opts.ManifestPath, fs)
+	if err != nil {
+		return err
+	}
+	opts.Manifest = manifest
 
+	report, err := getExecutionReport(opts, okteto.GetContext())
+	if err != nil {
+		return err
+	}
+	return report.Print(opts.Where)
 }
 
 // GetDeployer returns a remote or a local deployer
@@ -600,10 +898,25 @@ func GetDeployer(ctx context.Context,
 	k8Logger *io.K8sLogger,
 	dependencyEnvVarsGetter dependencyEnvVarsGetter,
 ) (Deployer, error) {
-	if shouldRunInRemote(opts) {
-		oktetoLog.Info("Deploying remotely...")
+	req := newExecutionRequest(opts)
+	req.IsOkteto = okteto.GetContext().IsOkteto
+	phase, err := remote.ResolveCommandsExecution(req)
+	if err != nil {
+		return nil, err
+	}
+	if phase.Where == remote.ExecutionRemote {
+		if opts.Confirm {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("the '--confirm' flag can't be used when the deploy commands run remotely: %s", phase.Reason),
+				Hint: "Use '--diff' to show the changes without confirmation or run the deploy commands locally",
+			}
+		}
+		oktetoLog.Information("Running the deploy commands remotely: %s", phase.Reason)
 		return newRemoteDeployer(buildEnvVarsGetter, ioCtrl, dependencyEnvVarsGetter), nil
 	}
+	if shouldRunInRemote(opts) {
+		oktetoLog.WarningWithID(oktetoLog.WarnRemoteExecutionNotSupported, "remote execution is only supported in contexts with Okteto installed. Running your deploy commands locally")
+	}
 
 	oktetoLog.Info("Deploying locally...")
 	// In case the command has to run locally, we need the "local" runner
@@ -611,6 +924,7 @@ func GetDeployer(ctx context.Context,
 		ctx,
 		opts.Name,
 		opts.RunWithoutBash,
+		opts.RawOutput,
 		opts.ManifestPathFlag,
 		cmapHandler,
 		k8sProvider,
@@ -624,6 +938,7 @@ func GetDeployer(ctx context.Context,
 		}
 		return nil, eWrapped
 	}
+	runner.ResourcesWaiter = NewResourcesWaiter(k8sProvider, k8Logger, opts.Timeout)
 
 	return newLocalDeployer(runner), nil
 }
@@ -639,6 +954,11 @@ func (dc *Command) deployDependencies(ctx context.Context, deployOptions *Option
 		return errDepenNotAvailableInVanilla
 	}
 
+	lock, err := deps.ReadLock(dc.Fs, deps.GetLockPath(deployOptions.ManifestPath))
+	if err != nil {
+		return err
+	}
+
 	for depName, dep := range deployOptions.Manifest.Dependencies {
 		oktetoLog.Information("Deploying dependency  '%s'", depName)
 		oktetoLog.SetStage(fmt.Sprintf("Deploying dependency %s", depName))
@@ -661,20 +981,36 @@ func (dc *Command) deployDependencies(ctx context.Context, deployOptions *Option
 			return fmt.Errorf("could not expand variables in dependencies: %w", err)
 		}
 		pipOpts := &pipelineCMD.DeployOptions{
-			Name:         depName,
-			Repository:   dep.Repository,
-			Branch:       dep.Branch,
-			File:         dep.ManifestPath,
-			Variables:    model.SerializeEnvironmentVars(dep.Variables),
-			Wait:         dep.Wait,
+			Name:       depName,
+			Repository: dep.Repository,
+			Branch:     dep.Branch,
+			File:       dep.ManifestPath,
+			Variables:  model.SerializeEnvironmentVars(dep.Variables),
+			// variables are exported to $OKTETO_ENV when the dependency finishes its deployment
+			Wait:         dep.Wait || dep.HasVariableCondition(),
 			Timeout:      dep.GetTimeout(deployOptions.Timeout),
 			SkipIfExists: !deployOptions.Dependencies,
 			Namespace:    namespace,
+			Schedule:     dep.Schedule,
+		}
+		if locked, ok := lock.Get(depName, dep); ok {
+			oktetoLog.Infof("dependency '%s' is locked to commit '%s'", depName, locked.Commit)
+			pipOpts.Commit = locked.Commit
 		}
 
 		if err := dc.PipelineCMD.ExecuteDeployPipeline(ctx, pipOpts); err != nil {
 			return err
 		}
+
+		if len(dep.WaitFor) > 0 {
+			c, _, err := dc.K8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, dc.K8sLogger)
+			if err != nil {
+				return fmt.Errorf("could not get kubernetes client: %w", err)
+			}
+			if err := newDependencyWaiter(c).wait(ctx, depName, namespace, dep.WaitFor, pipOpts.Timeout); err != nil {
+				return err
+			}
+		}
 	}
 	oktetoLog.SetStage("")
 	return nil
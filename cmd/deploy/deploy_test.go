@@ -38,6 +38,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/remote"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -94,10 +95,16 @@ func (fr fakeRegistry) GetImageTagWithDigest(imageTag string) (string, error) {
 	return imageTag, nil
 }
 
+func (fr fakeRegistry) ResolveImageTagWithDigest(imageTag string) (string, error) {
+	return fr.GetImageTagWithDigest(imageTag)
+}
+
 func (fr fakeRegistry) Clone(from, to string) (string, error) {
 	return from, nil
 }
 
+func (fr fakeRegistry) GetAttestationDigests(_ string) ([]string, error) { return nil, nil }
+
 func (fr fakeRegistry) GetImageReference(image string) (registry.OktetoImageReference, error) {
 	ref, err := name.ParseReference(image)
 	if err != nil {
@@ -707,12 +714,75 @@ func TestDeployDependencies(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			dc := &Command{
 				PipelineCMD: fakePipelineDeployer{tc.config.pipelineErr},
+				Fs:          afero.NewMemMapFs(),
 			}
 			assert.ErrorIs(t, tc.expected, dc.deployDependencies(context.Background(), &Options{Manifest: fakeManifest}))
 		})
 	}
 }
 
+type recordingPipelineDeployer struct {
+	opts []*pipelineCMD.DeployOptions
+}
+
+func (rd *recordingPipelineDeployer) ExecuteDeployPipeline(_ context.Context, opts *pipelineCMD.DeployOptions) error {
+	rd.opts = append(rd.opts, opts)
+	return nil
+}
+
+func TestDeployDependenciesWithLock(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Name:     "test",
+				Token:    "test",
+				IsOkteto: true,
+			},
+		},
+		CurrentContext: "test",
+	}
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/app/okteto.lock", []byte(`dependencies:
+  api:
+    repository: https://github.com/okteto/api
+    branch: main
+    commit: 0123456789abcdef0123456789abcdef01234567
+  frontend:
+    repository: https://github.com/okteto/frontend
+    branch: main
+    commit: fedcba9876543210fedcba9876543210fedcba98
+`), 0600))
+
+	manifest := &model.Manifest{
+		Dependencies: deps.ManifestSection{
+			"api": &deps.Dependency{
+				Repository: "https://github.com/okteto/api",
+				Branch:     "main",
+			},
+			"frontend": &deps.Dependency{
+				Repository: "https://github.com/okteto/frontend",
+				Branch:     "develop",
+			},
+		},
+	}
+	deployer := &recordingPipelineDeployer{}
+	dc := &Command{
+		PipelineCMD: deployer,
+		Fs:          fs,
+	}
+	require.NoError(t, dc.deployDependencies(context.Background(), &Options{Manifest: manifest, ManifestPath: "/app/okteto.yml"}))
+
+	commits := map[string]string{}
+	for _, opts := range deployer.opts {
+		commits[opts.Name] = opts.Commit
+	}
+	assert.Equal(t, map[string]string{
+		"api": "0123456789abcdef0123456789abcdef01234567",
+		// the lock is ignored when the branch of the manifest changed
+		"frontend": "",
+	}, commits)
+}
+
 func TestDeployOnlyDependencies(t *testing.T) {
 	fakeOs := afero.NewMemMapFs()
 	fakeK8sClientProvider := test.NewFakeK8sProvider(&v1.Deployment{
@@ -754,7 +824,7 @@ func TestDeployOnlyDependencies(t *testing.T) {
 		},
 		{
 			name:        "error okteto not installed",
-			expecterErr: errDepenNotAvailableInVanilla,
+			expecterErr: errFeaturesNotSupportedInVanilla,
 			isOkteto:    false,
 		},
 	}
@@ -833,6 +903,66 @@ func TestTrackDeploy(t *testing.T) {
 	}
 }
 
+func TestGetExecutionReport(t *testing.T) {
+	t.Setenv(constants.OktetoDeployRemote, "")
+	t.Setenv(constants.OktetoForceRemote, "")
+	opts := &Options{
+		RunInRemote: true,
+		Manifest: &model.Manifest{
+			Build: build.ManifestBuild{
+				"api": &build.Info{},
+			},
+			Deploy: &model.DeployInfo{
+				ComposeSection: &model.ComposeSectionInfo{},
+			},
+		},
+	}
+
+	report, err := getExecutionReport(opts, &okteto.Context{IsOkteto: true, Builder: "tcp://buildkit:1234"})
+	require.NoError(t, err)
+	require.Equal(t, remote.ExecutionReport{
+		Command: "deploy",
+		Phases: []remote.Phase{
+			{Name: "build", Where: remote.ExecutionRemote, Reason: "images are built by the BuildKit instance at 'tcp://buildkit:1234'"},
+			{Name: "commands", Where: remote.ExecutionRemote, Reason: "the '--remote' flag is set"},
+			{Name: "compose", Where: remote.ExecutionLocal, Reason: "compose services are always deployed from the okteto CLI"},
+		},
+	}, report)
+
+	report, err = getExecutionReport(opts, &okteto.Context{})
+	require.NoError(t, err)
+	require.Equal(t, remote.ExecutionLocal, report.Phases[0].Where)
+	require.Equal(t, remote.ExecutionLocal, report.Phases[1].Where)
+
+	opts.RequireLocal = true
+	_, err = getExecutionReport(opts, &okteto.Context{IsOkteto: true})
+	require.Error(t, err)
+}
+
+func TestGetDeployerConfirmInRemote(t *testing.T) {
+	t.Setenv(constants.OktetoDeployRemote, "")
+	t.Setenv(constants.OktetoForceRemote, "")
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+	opts := &Options{
+		RunInRemote: true,
+		Confirm:     true,
+		Manifest:    &model.Manifest{Deploy: &model.DeployInfo{}},
+	}
+
+	_, err := GetDeployer(context.Background(), opts, nil, nil, nil, nil, nil, nil)
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	require.Contains(t, userErr.E.Error(), "'--confirm'")
+}
+
 func TestShouldRunInRemoteDeploy(t *testing.T) {
 	tempManifest := &model.Manifest{
 		Deploy: &model.DeployInfo{
@@ -1105,3 +1235,65 @@ func TestCalculateManifestPathToBeStored(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeployTTL(t *testing.T) {
+	tt := []struct {
+		name        string
+		options     *Options
+		envarValue  string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{
+			name:    "not set",
+			options: &Options{},
+		},
+		{
+			name:     "flag",
+			options:  &Options{TTL: 2 * time.Hour},
+			expected: 2 * time.Hour,
+		},
+		{
+			name:        "negative flag",
+			options:     &Options{TTL: -time.Hour},
+			expectedErr: true,
+		},
+		{
+			name:       "flag takes precedence",
+			options:    &Options{TTL: time.Hour, Variables: []string{"OKTETO_DEPLOY_TTL=3h"}},
+			envarValue: "4h",
+			expected:   time.Hour,
+		},
+		{
+			name:       "variable",
+			options:    &Options{Variables: []string{"A=B", "OKTETO_DEPLOY_TTL=3h"}},
+			envarValue: "4h",
+			expected:   3 * time.Hour,
+		},
+		{
+			name:       "env var",
+			options:    &Options{},
+			envarValue: "48h",
+			expected:   48 * time.Hour,
+		},
+		{
+			name:        "invalid env var",
+			options:     &Options{},
+			envarValue:  "2 days",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(constants.OktetoDeployTTLEnvVar, tc.envarValue)
+			ttl, err := getDeployTTL(tc.options)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ttl)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/deployable"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// validateDryRun checks that the flags set can be used in dry-run mode
+func validateDryRun(opts *Options) error {
+	if !opts.DryRun {
+		return nil
+	}
+	flags := map[string]bool{
+		"--confirm": opts.Confirm,
+		"--remote":  opts.RunInRemote,
+		"--wait":    opts.Wait,
+	}
+	for _, name := range []string{"--confirm", "--remote", "--wait"} {
+		if flags[name] {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the '%s' flag can't be used with '--dry-run'", name),
+				Hint: "The dry run renders the manifests locally without applying them to the cluster",
+			}
+		}
+	}
+	return nil
+}
+
+// dryRun shows the images that would be built, with their tags and build hashes, and runs the kubectl and helm deploy commands
+// in dry-run mode to render their manifests. The deploy commands go through a proxy that rejects any change to the cluster.
+// With --diff, the changes are compared with the resources of the namespace using a server-side dry run instead
+func (dc *Command) dryRun(ctx context.Context, opts *Options) error {
+	if opts.Manifest.HasDependencies() {
+		oktetoLog.Warning("The dependencies of '%s' are not rendered in dry-run mode", opts.Name)
+	}
+
+	if len(opts.Manifest.Build) > 0 {
+		oktetoLog.Information("Images of '%s':", opts.Name)
+		buildOptions := &types.BuildOptions{
+			Manifest:    opts.Manifest,
+			CommandArgs: setToSlice(opts.Manifest.GetBuildServices()),
+			DryRun:      true,
+		}
+		if err := dc.Builder.Build(ctx, buildOptions); err != nil {
+			return err
+		}
+	}
+
+	if opts.Manifest.Deploy == nil {
+		return nil
+	}
+
+	if len(opts.Manifest.Deploy.Commands) > 0 {
+		// the rendered manifests are shown as they are
+		runner, err := deployable.NewDeployRunnerForLocal(
+			ctx,
+			opts.Name,
+			opts.RunWithoutBash,
+			true,
+			opts.ManifestPathFlag,
+			dc.CfgMapHandler,
+			dc.K8sClientProvider,
+			model.GetAvailablePort,
+			dc.K8sLogger)
+		if err != nil {
+			return fmt.Errorf("could not initialize the dry run of the deploy commands: %w", err)
+		}
+		dc.onCleanUp = append(dc.onCleanUp, runner.CleanUp)
+
+		err = runner.RunDeploy(ctx, deployable.DeployParameters{
+			Name:         opts.Name,
+			Namespace:    opts.Manifest.Namespace,
+			Variables:    opts.Variables,
+			ManifestPath: opts.Manifest.ManifestPath,
+			Deployable: deployable.Entity{
+				Commands: opts.Manifest.Deploy.Commands,
+				Divert:   opts.Manifest.Deploy.Divert,
+				External: opts.Manifest.External,
+			},
+			Diff:   opts.Diff,
+			DryRun: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Manifest.Deploy.ComposeSection != nil {
+		oktetoLog.Warning("The compose services of '%s' are not rendered in dry-run mode", opts.Name)
+	}
+	if opts.Manifest.Deploy.Endpoints != nil {
+		oktetoLog.Warning("The endpoints of '%s' are not rendered in dry-run mode", opts.Name)
+	}
+	if opts.Manifest.Deploy.Hooks != nil {
+		oktetoLog.Warning("The pre and post-deploy hooks of '%s' are not executed in dry-run mode", opts.Name)
+	}
+	oktetoLog.Success("Dry run of '%s' completed: no changes were applied to the cluster", opts.Name)
+	return nil
+}
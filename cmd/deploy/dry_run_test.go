@@ -0,0 +1,69 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateDryRun(t *testing.T) {
+	tt := []struct {
+		opts        *Options
+		name        string
+		expectedErr bool
+	}{
+		{
+			name: "no dry run",
+			opts: &Options{Diff: true, RunInRemote: true},
+		},
+		{
+			name: "dry run",
+			opts: &Options{DryRun: true},
+		},
+		{
+			name: "dry run with diff",
+			opts: &Options{DryRun: true, Diff: true},
+		},
+		{
+			name:        "dry run with confirm",
+			opts:        &Options{DryRun: true, Confirm: true},
+			expectedErr: true,
+		},
+		{
+			name:        "dry run with remote",
+			opts:        &Options{DryRun: true, RunInRemote: true},
+			expectedErr: true,
+		},
+		{
+			name:        "dry run with wait",
+			opts:        &Options{DryRun: true, Wait: true},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDryRun(tc.opts)
+			if !tc.expectedErr {
+				require.NoError(t, err)
+				return
+			}
+			var userErr oktetoErrors.UserError
+			assert.ErrorAs(t, err, &userErr)
+		})
+	}
+}
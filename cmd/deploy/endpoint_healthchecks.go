@@ -0,0 +1,154 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const endpointProbeInterval = 5 * time.Second
+
+// endpointProber checks if an endpoint responds with a 2xx status code
+type endpointProber struct {
+	client   *http.Client
+	interval time.Duration
+}
+
+func newEndpointProber() *endpointProber {
+	return &endpointProber{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		interval: endpointProbeInterval,
+	}
+}
+
+// waitForHealthyEndpoints waits until the endpoints of the development environment that declare a healthcheck
+// in 'deploy.healthchecks' respond with a 2xx status code
+func (dc *Command) waitForHealthyEndpoints(ctx context.Context, opts *Options) error {
+	if opts.Manifest.Deploy == nil || len(opts.Manifest.Deploy.Healthchecks) == 0 {
+		return nil
+	}
+
+	eg, err := dc.EndpointGetter(dc.K8sLogger)
+	if err != nil {
+		return fmt.Errorf("could not create endpoint getter: %w", err)
+	}
+	eps, err := eg.getEndpoints(ctx, &EndpointsOptions{Name: opts.Name, Namespace: opts.Manifest.Namespace})
+	if err != nil {
+		return fmt.Errorf("could not retrieve endpoints: %w", err)
+	}
+
+	urls := []string{}
+	for _, ep := range eps {
+		probeURL, ok, err := getEndpointProbeURL(ep, opts.Manifest.Namespace, opts.Manifest.Deploy)
+		if err != nil {
+			return err
+		}
+		if ok {
+			urls = append(urls, probeURL)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	if dc.endpointProber == nil {
+		dc.endpointProber = newEndpointProber()
+	}
+
+	// the endpoints share the --timeout with the wait for the deployed resources
+	if opts.Timeout > 0 {
+		start := dc.waitStartedAt
+		if start.IsZero() {
+			start = time.Now()
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, start.Add(opts.Timeout))
+		defer cancel()
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Waiting for the endpoints of %s to be healthy...", opts.Name))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+	return dc.endpointProber.waitForEndpoints(ctx, urls, opts.Timeout)
+}
+
+// getEndpointProbeURL returns the url probed for an endpoint, using the healthcheck path declared in the manifest.
+// It returns false if the endpoint doesn't declare a healthcheck
+func getEndpointProbeURL(endpoint, namespace string, deploy *model.DeployInfo) (string, bool, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid endpoint '%s': %w", endpoint, err)
+	}
+	name := strings.Split(u.Hostname(), ".")[0]
+	path, ok := deploy.GetHealthcheckPath(name)
+	if !ok {
+		path, ok = deploy.GetHealthcheckPath(strings.TrimSuffix(name, fmt.Sprintf("-%s", namespace)))
+	}
+	if !ok {
+		return "", false, nil
+	}
+	return u.JoinPath(path).String(), true, nil
+}
+
+// waitForEndpoints probes the urls until all of them respond with a 2xx status code or ctx is done.
+// timeout is only used to report the error, zero means there is no timeout
+func (p *endpointProber) waitForEndpoints(ctx context.Context, urls []string, timeout time.Duration) error {
+	pending := urls
+	for {
+		var unhealthy []string
+		for _, u := range pending {
+			if err := p.probe(ctx, u); err != nil {
+				oktetoLog.Infof("endpoint '%s' is not healthy yet: %s", u, err)
+				unhealthy = append(unhealthy, u)
+			}
+		}
+		if len(unhealthy) == 0 {
+			return nil
+		}
+		pending = unhealthy
+
+		select {
+		case <-ctx.Done():
+			if timeout > 0 {
+				return fmt.Errorf("endpoints didn't pass their healthchecks after %s: %s", timeout.String(), strings.Join(pending, ", "))
+			}
+			return fmt.Errorf("endpoints didn't pass their healthchecks: %s", strings.Join(pending, ", "))
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+func (p *endpointProber) probe(ctx context.Context, u string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
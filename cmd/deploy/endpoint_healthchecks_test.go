@@ -0,0 +1,181 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getEndpointProbeURL(t *testing.T) {
+	deploy := &model.DeployInfo{
+		Healthchecks: []model.EndpointHealthcheck{
+			{Endpoint: "api", Path: "/healthz"},
+			{Endpoint: "frontend-cindy", Path: "/ready"},
+			{Endpoint: "worker"},
+		},
+	}
+	tests := []struct {
+		name     string
+		endpoint string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "endpoint name without namespace",
+			endpoint: "https://api-cindy.okteto.example.com",
+			expected: "https://api-cindy.okteto.example.com/healthz",
+			ok:       true,
+		},
+		{
+			name:     "endpoint name with namespace",
+			endpoint: "https://frontend-cindy.okteto.example.com",
+			expected: "https://frontend-cindy.okteto.example.com/ready",
+			ok:       true,
+		},
+		{
+			name:     "endpoint with path",
+			endpoint: "https://api-cindy.okteto.example.com/api",
+			expected: "https://api-cindy.okteto.example.com/api/healthz",
+			ok:       true,
+		},
+		{
+			name:     "default path",
+			endpoint: "https://worker-cindy.okteto.example.com",
+			expected: "https://worker-cindy.okteto.example.com/",
+			ok:       true,
+		},
+		{
+			name:     "no healthcheck",
+			endpoint: "https://admin-cindy.okteto.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := getEndpointProbeURL(tt.endpoint, "cindy", deploy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_waitForEndpoints(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" || requests.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	p := &endpointProber{client: server.Client(), interval: time.Millisecond}
+
+	err := p.waitForEndpoints(context.Background(), []string{server.URL + "/healthz"}, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func Test_waitForEndpointsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	p := &endpointProber{client: server.Client(), interval: time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := p.waitForEndpoints(ctx, []string{server.URL}, 50*time.Millisecond)
+	assert.ErrorContains(t, err, "didn't pass their healthchecks after 50ms")
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = p.waitForEndpoints(canceledCtx, []string{server.URL}, 0)
+	assert.EqualError(t, err, "endpoints didn't pass their healthchecks: "+server.URL)
+}
+
+func Test_waitForHealthyEndpointsUsesRemainingTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dc := &Command{
+		EndpointGetter: func(_ *io.K8sLogger) (EndpointGetter, error) {
+			return EndpointGetter{
+				endpointControl: &fakeEndpointControl{endpoints: []string{server.URL}},
+			}, nil
+		},
+		endpointProber: &endpointProber{client: server.Client(), interval: time.Millisecond},
+		// the wait for the deployed resources already used most of the timeout
+		waitStartedAt: time.Now().Add(-950 * time.Millisecond),
+	}
+	opts := &Options{
+		Name:    "test",
+		Timeout: time.Second,
+		Manifest: &model.Manifest{
+			Namespace: "test",
+			Deploy: &model.DeployInfo{
+				Healthchecks: []model.EndpointHealthcheck{{Endpoint: "127"}},
+			},
+		},
+	}
+
+	start := time.Now()
+	err := dc.waitForHealthyEndpoints(context.Background(), opts)
+	assert.ErrorContains(t, err, "didn't pass their healthchecks after 1s")
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func Test_waitForHealthyEndpointsWithoutHealthchecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dc := &Command{
+		EndpointGetter: func(_ *io.K8sLogger) (EndpointGetter, error) {
+			return EndpointGetter{
+				endpointControl: &fakeEndpointControl{endpoints: []string{server.URL}},
+			}, nil
+		},
+		endpointProber: &endpointProber{client: server.Client(), interval: time.Millisecond},
+	}
+	opts := &Options{
+		Name:    "test",
+		Timeout: time.Second,
+		Manifest: &model.Manifest{
+			Namespace: "test",
+			Deploy: &model.DeployInfo{
+				Healthchecks: []model.EndpointHealthcheck{{Endpoint: "api"}},
+			},
+		},
+	}
+	require.NoError(t, dc.waitForHealthyEndpoints(context.Background(), opts))
+
+	opts.Manifest.Deploy = nil
+	require.NoError(t, dc.waitForHealthyEndpoints(context.Background(), opts))
+}
@@ -22,9 +22,11 @@ import (
 	"strings"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/endpoints"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/filesystem"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/ingresses"
@@ -43,6 +45,8 @@ type EndpointsOptions struct {
 	Output       string
 	Namespace    string
 	K8sContext   string
+	External     externalresource.Section
+	PortForward  bool
 }
 
 type endpointGetterInterface interface {
@@ -130,6 +134,7 @@ func Endpoints(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				options.External = manifest.External
 				if manifest.Name != "" {
 					options.Name = manifest.Name
 				} else {
@@ -140,6 +145,7 @@ func Endpoints(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
 					inferer := devenvironment.NewNameInferer(c)
 					options.Name = inferer.InferName(ctx, cwd, okteto.GetContext().Namespace, options.ManifestPath)
 				}
+				options.Name = utils.PerUserName(options.Name)
 				if options.Namespace == "" {
 					options.Namespace = manifest.Namespace
 				}
@@ -151,6 +157,16 @@ func Endpoints(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
 			if err := validateOutput(options.Output); err != nil {
 				return err
 			}
+			if options.PortForward {
+				if options.Output != "" {
+					return fmt.Errorf("the '--port-forward' and '--output' flags can't be used at the same time")
+				}
+				c, forwarder, err := newServicePortForwarder(ctx, options.Namespace)
+				if err != nil {
+					return err
+				}
+				return portForwardEndpoints(ctx, options, c, forwarder)
+			}
 			return eg.showEndpoints(ctx, options)
 		},
 	}
@@ -160,6 +176,7 @@ func Endpoints(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
 	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment is deployed")
 
 	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json', 'md']")
+	cmd.Flags().BoolVarP(&options.PortForward, "port-forward", "", false, "forward the ports of the services of the development environment to localhost. Useful in contexts without Okteto installed or without an ingress controller")
 
 	return cmd
 }
@@ -186,6 +203,7 @@ func (eg *EndpointGetter) getEndpoints(ctx context.Context, opts *EndpointsOptio
 	if err != nil {
 		return nil, err
 	}
+	eps = appendExternalEndpoints(eps, opts.External)
 
 	if len(eps) > 0 {
 		sort.Slice(eps, func(i, j int) bool {
@@ -195,6 +213,31 @@ func (eg *EndpointGetter) getEndpoints(ctx context.Context, opts *EndpointsOptio
 	return eps, nil
 }
 
+// appendExternalEndpoints adds the urls declared by the external resources that are not already listed
+func appendExternalEndpoints(eps []string, external externalresource.Section) []string {
+	listed := map[string]bool{}
+	for _, ep := range eps {
+		listed[ep] = true
+	}
+
+	names := make([]string, 0, len(external))
+	for name := range external {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, endpoint := range external[name].Endpoints {
+			if endpoint.Url == "" || listed[endpoint.Url] {
+				continue
+			}
+			listed[endpoint.Url] = true
+			eps = append(eps, endpoint.Url)
+		}
+	}
+	return eps
+}
+
 type endpointGetterWithOktetoAPI struct {
 	endpointControl endpointGetterInterface
 }
@@ -267,7 +310,9 @@ func (dc *EndpointGetter) showEndpoints(ctx context.Context, opts *EndpointsOpti
 			}
 		}
 	default:
-		if len(eps) == 0 {
+		if len(eps) == 0 && !okteto.GetContext().IsOkteto {
+			oktetoLog.Information("There are no available endpoints for '%s'.\n    Run 'okteto endpoints --port-forward' to access its services from localhost", opts.Name)
+		} else if len(eps) == 0 {
 			oktetoLog.Information("There are no available endpoints for '%s'.\n    Follow this link to know more about how to create public endpoints for your application:\n    https://www.okteto.com/docs/core/endpoints/automatic-ssl", opts.Name)
 		} else {
 			oktetoLog.Information("Endpoints available:")
@@ -17,6 +17,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +25,7 @@ import (
 func TestGetEndpoints(t *testing.T) {
 	testCases := []struct {
 		endpointGetter *EndpointGetter
+		external       externalresource.Section
 		name           string
 		expected       []string
 		expectedErr    bool
@@ -43,6 +45,29 @@ func TestGetEndpoints(t *testing.T) {
 				"https://this.is.a.test.okteto",
 			},
 		},
+		{
+			name: "Get endpoints with external resources",
+			endpointGetter: &EndpointGetter{
+				endpointControl: &fakeEndpointControl{
+					endpoints: []string{
+						"https://this.is.a.test.okteto",
+					},
+				},
+			},
+			external: externalresource.Section{
+				"db": {
+					Endpoints: []*externalresource.ExternalEndpoint{
+						{Name: "api", Url: "https://api.example.com"},
+						{Name: "dynamic"},
+						{Name: "duplicated", Url: "https://this.is.a.test.okteto"},
+					},
+				},
+			},
+			expected: []string{
+				"https://api.example.com",
+				"https://this.is.a.test.okteto",
+			},
+		},
 		{
 			name: "Error when retrieving endpoints",
 			endpointGetter: &EndpointGetter{
@@ -54,7 +79,7 @@ func TestGetEndpoints(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			endpoints, err := tc.endpointGetter.getEndpoints(context.Background(), &EndpointsOptions{})
+			endpoints, err := tc.endpointGetter.getEndpoints(context.Background(), &EndpointsOptions{External: tc.external})
 			require.Equal(t, tc.expected, endpoints)
 			if tc.expectedErr {
 				require.Error(t, err)
@@ -0,0 +1,184 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+const (
+	// githubStepSummaryEnvVar is the file where GitHub Actions reads the job summary from
+	githubStepSummaryEnvVar = "GITHUB_STEP_SUMMARY"
+)
+
+// deploySummary is the result of a deploy execution shown in the GitHub Actions job summary
+type deploySummary struct {
+	err       error
+	name      string
+	namespace string
+	images    []builtImage
+	endpoints []string
+	warnings  []string
+	duration  time.Duration
+}
+
+// builtImage is an image built during the deploy execution
+type builtImage struct {
+	service string
+	image   string
+	digest  string
+}
+
+// writeGHASummary appends the deploy summary to the GitHub Actions job summary and emits the annotations for the failures
+func (dc *Command) writeGHASummary(ctx context.Context, opts *Options, duration time.Duration, deployErr error) {
+	summaryPath := os.Getenv(githubStepSummaryEnvVar)
+	if summaryPath == "" {
+		oktetoLog.Warning("'--gha-summary' is ignored: the env var '%s' is not set", githubStepSummaryEnvVar)
+		return
+	}
+
+	summary := &deploySummary{
+		name:      opts.Name,
+		namespace: opts.Namespace,
+		duration:  duration,
+		err:       deployErr,
+		warnings:  oktetoLog.GetWarnings(),
+	}
+	if opts.Manifest != nil {
+		summary.namespace = opts.Manifest.Namespace
+		services := []string{}
+		for svc := range opts.Manifest.Build {
+			services = append(services, svc)
+		}
+		summary.images = getBuiltImages(services, dc.Builder.GetBuildEnvVars())
+	}
+
+	if deployErr == nil && dc.EndpointGetter != nil {
+		eg, err := dc.EndpointGetter(dc.K8sLogger)
+		if err != nil {
+			oktetoLog.Infof("could not create endpoint getter: %s", err)
+		} else {
+			eps, err := eg.getEndpoints(ctx, &EndpointsOptions{Name: summary.name, Namespace: summary.namespace, Output: "md"})
+			if err != nil {
+				oktetoLog.Infof("could not retrieve endpoints: %s", err)
+			}
+			summary.endpoints = eps
+		}
+	}
+
+	if err := summary.write(dc.Fs, summaryPath); err != nil {
+		oktetoLog.Infof("could not write the GitHub Actions job summary: %s", err)
+	}
+	summary.writeAnnotations(os.Stdout)
+}
+
+// getBuiltImages returns the images built for the services from the okteto build env vars
+func getBuiltImages(services []string, buildEnvVars map[string]string) []builtImage {
+	sort.Strings(services)
+	result := []builtImage{}
+	for _, svc := range services {
+		sanitizedSvc := strings.ToUpper(strings.ReplaceAll(svc, "-", "_"))
+		image, ok := buildEnvVars[fmt.Sprintf("OKTETO_BUILD_%s_IMAGE", sanitizedSvc)]
+		if !ok {
+			continue
+		}
+		digest := buildEnvVars[fmt.Sprintf("OKTETO_BUILD_%s_TAG", sanitizedSvc)]
+		if !strings.HasPrefix(digest, "sha256:") {
+			digest = ""
+		}
+		result = append(result, builtImage{service: svc, image: image, digest: digest})
+	}
+	return result
+}
+
+// write appends the summary in markdown format to the file at path
+func (s *deploySummary) write(fs afero.Fs, path string) error {
+	f, err := fs.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(s.markdown())
+	return err
+}
+
+func (s *deploySummary) markdown() string {
+	var sb strings.Builder
+	status := "✅ Deployed"
+	if s.err != nil {
+		status = "❌ Failed"
+	}
+	fmt.Fprintf(&sb, "## Okteto deploy: %s\n\n", s.name)
+	sb.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&sb, "| Status | %s |\n", status)
+	fmt.Fprintf(&sb, "| Namespace | `%s` |\n", s.namespace)
+	fmt.Fprintf(&sb, "| Duration | %s |\n\n", s.duration.Round(time.Second))
+
+	if len(s.images) > 0 {
+		sb.WriteString("### Built images\n\n| Service | Image | Digest |\n|---|---|---|\n")
+		for _, img := range s.images {
+			digest := "-"
+			if img.digest != "" {
+				digest = fmt.Sprintf("`%s`", img.digest)
+			}
+			fmt.Fprintf(&sb, "| %s | `%s` | %s |\n", img.service, img.image, digest)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(s.endpoints) > 0 {
+		sb.WriteString("### Endpoints\n\n")
+		for _, ep := range s.endpoints {
+			fmt.Fprintf(&sb, "- %s\n", ep)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(s.warnings) > 0 {
+		sb.WriteString("### Warnings\n\n")
+		for _, w := range s.warnings {
+			fmt.Fprintf(&sb, "- %s\n", strings.ReplaceAll(w, "\n", " "))
+		}
+		sb.WriteString("\n")
+	}
+
+	if s.err != nil {
+		fmt.Fprintf(&sb, "### Error\n\n```\n%s\n```\n\n", s.err.Error())
+	}
+	return sb.String()
+}
+
+// writeAnnotations writes the workflow commands that create annotations for the error and the warnings
+func (s *deploySummary) writeAnnotations(w io.Writer) {
+	for _, warning := range s.warnings {
+		fmt.Fprintf(w, "::warning title=Okteto deploy::%s\n", escapeGHAData(warning))
+	}
+	if s.err != nil {
+		fmt.Fprintf(w, "::error title=Okteto deploy failed::%s\n", escapeGHAData(s.err.Error()))
+	}
+}
+
+// escapeGHAData escapes the message of a workflow command
+func escapeGHAData(msg string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(msg)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getBuiltImages(t *testing.T) {
+	buildEnvVars := map[string]string{
+		"OKTETO_BUILD_API_IMAGE":       "okteto.dev/test-api@sha256:1234",
+		"OKTETO_BUILD_API_TAG":         "sha256:1234",
+		"OKTETO_BUILD_MY_WORKER_IMAGE": "okteto.dev/test-worker:okteto",
+		"OKTETO_BUILD_MY_WORKER_TAG":   "okteto",
+	}
+	result := getBuiltImages([]string{"my-worker", "frontend", "api"}, buildEnvVars)
+	assert.Equal(t, []builtImage{
+		{service: "api", image: "okteto.dev/test-api@sha256:1234", digest: "sha256:1234"},
+		{service: "my-worker", image: "okteto.dev/test-worker:okteto"},
+	}, result)
+}
+
+func Test_deploySummaryWrite(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/summary.md", []byte("previous step\n"), 0600))
+
+	s := &deploySummary{
+		name:      "movies",
+		namespace: "test",
+		duration:  95 * time.Second,
+		images:    []builtImage{{service: "api", image: "okteto.dev/api@sha256:1234", digest: "sha256:1234"}},
+		endpoints: []string{"https://movies-test.okteto.dev"},
+		warnings:  []string{"something\nhappened"},
+	}
+	require.NoError(t, s.write(fs, "/summary.md"))
+
+	content, err := afero.ReadFile(fs, "/summary.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "previous step\n## Okteto deploy: movies")
+	assert.Contains(t, string(content), "| Status | ✅ Deployed |")
+	assert.Contains(t, string(content), "| Duration | 1m35s |")
+	assert.Contains(t, string(content), "| api | `okteto.dev/api@sha256:1234` | `sha256:1234` |")
+	assert.Contains(t, string(content), "- https://movies-test.okteto.dev")
+	assert.Contains(t, string(content), "- something happened")
+	assert.NotContains(t, string(content), "### Error")
+}
+
+func Test_deploySummaryWriteAnnotations(t *testing.T) {
+	s := &deploySummary{
+		err:      errors.New("exit status 1\n100% failed"),
+		warnings: []string{"deprecated field"},
+	}
+	out := &bytes.Buffer{}
+	s.writeAnnotations(out)
+	assert.Equal(t, "::warning title=Okteto deploy::deprecated field\n::error title=Okteto deploy failed::exit status 1%0A100%25 failed\n", out.String())
+	assert.Contains(t, s.markdown(), "| Status | ❌ Failed |")
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/helmrelease"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+// verifyHelmReleases checks that the helm releases required by the okteto manifest are deployed with a valid version.
+// It runs before the deploy commands to avoid partial dev environments when shared infrastructure is missing
+func verifyHelmReleases(ctx context.Context, manifest *model.Manifest, c kubernetes.Interface) error {
+	statuses, err := helmrelease.Check(ctx, manifest.HelmReleases, manifest.Namespace, c)
+	if err != nil {
+		return err
+	}
+	if err := helmrelease.Verify(statuses); err != nil {
+		return err
+	}
+	oktetoLog.Debugf("verified %d helm releases", len(statuses))
+	return nil
+}
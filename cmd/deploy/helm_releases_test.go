@@ -0,0 +1,39 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/helmrelease"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_verifyHelmReleases(t *testing.T) {
+	manifest := &model.Manifest{
+		Namespace:    "test",
+		HelmReleases: helmrelease.Section{{Name: "postgresql", Namespace: "infra"}},
+	}
+
+	err := verifyHelmReleases(context.Background(), manifest, fake.NewSimpleClientset())
+
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Contains(t, err.Error(), "'postgresql' is not deployed in namespace 'infra'")
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/deployable"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// hasPostDeployHooks returns if the deploy runner waits for the deployed resources to be running before the post-deploy hooks
+func (*Command) hasPostDeployHooks(opts *Options) bool {
+	return opts.Manifest.Deploy != nil && len(opts.Manifest.Deploy.Hooks.GetPost()) > 0
+}
+
+// resourcesWaiter waits for the resources deployed by the deploy commands to be running before the post-deploy hooks
+type resourcesWaiter struct {
+	waiter  Waiter
+	timeout time.Duration
+}
+
+// NewResourcesWaiter returns the waiter used by the deploy runner before the post-deploy hooks
+func NewResourcesWaiter(k8sClientProvider okteto.K8sClientProviderWithLogger, k8sLogger *io.K8sLogger, timeout time.Duration) deployable.ResourcesWaiter {
+	return &resourcesWaiter{
+		waiter:  NewDeployWaiter(k8sClientProvider, k8sLogger),
+		timeout: timeout,
+	}
+}
+
+// WaitForResources waits for the deployments and statefulsets of the development environment to be running
+func (rw *resourcesWaiter) WaitForResources(ctx context.Context, name, namespace string) error {
+	c, _, err := rw.waiter.K8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, rw.waiter.K8sLogger)
+	if err != nil {
+		return err
+	}
+	hasDeployed, err := pipeline.HasDeployedSomething(ctx, name, namespace, c)
+	if err != nil {
+		return err
+	}
+	if !hasDeployed {
+		return nil
+	}
+	opts := &Options{
+		Name:    name,
+		Timeout: rw.timeout,
+		Manifest: &model.Manifest{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	return rw.waiter.wait(ctx, opts)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hooksManifest() *model.Manifest {
+	return &model.Manifest{
+		Namespace: "test",
+		Deploy: &model.DeployInfo{
+			Hooks: &model.Hooks{
+				Pre: []model.DeployCommand{
+					{
+						Name:    "backup",
+						Command: "make backup",
+					},
+				},
+				Post: []model.DeployCommand{
+					{
+						Name:    "migrate",
+						Command: "make migrate",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResourcesWaiterWithoutDeployedResources(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+	waiter := NewResourcesWaiter(test.NewFakeK8sProvider(), nil, time.Second)
+
+	start := time.Now()
+	require.NoError(t, waiter.WaitForResources(context.Background(), "test", "test"))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestResourcesWaiterWithErrorGettingClient(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+	k8sProvider := test.NewFakeK8sProvider()
+	k8sProvider.ErrProvide = assert.AnError
+	waiter := NewResourcesWaiter(k8sProvider, nil, time.Second)
+
+	require.ErrorIs(t, waiter.WaitForResources(context.Background(), "test", "test"), assert.AnError)
+}
+
+func TestHasPostDeployHooks(t *testing.T) {
+	tests := []struct {
+		manifest *model.Manifest
+		name     string
+		expected bool
+	}{
+		{
+			name:     "post hooks",
+			manifest: hooksManifest(),
+			expected: true,
+		},
+		{
+			name:     "no hooks",
+			manifest: &model.Manifest{Deploy: &model.DeployInfo{}},
+		},
+		{
+			name:     "no deploy section",
+			manifest: &model.Manifest{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := &Command{}
+			assert.Equal(t, tt.expected, dc.hasPostDeployHooks(&Options{Manifest: tt.manifest}))
+		})
+	}
+}
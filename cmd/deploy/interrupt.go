@@ -0,0 +1,159 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// nsDestroyer removes the resources of a namespace matching a label selector
+type nsDestroyer interface {
+	DestroyWithLabel(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
+}
+
+// pipelineState is the pipeline of the running deploy, needed to update its status if the deploy is interrupted
+type pipelineState struct {
+	cfg         *apiv1.ConfigMap
+	data        *pipeline.CfgData
+	firstDeploy bool
+	mu          sync.Mutex
+}
+
+func (ps *pipelineState) set(cfg *apiv1.ConfigMap, data *pipeline.CfgData, firstDeploy bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.cfg = cfg
+	ps.data = data
+	ps.firstDeploy = firstDeploy
+}
+
+// get returns a copy of the pipeline data, as the deploy might still be modifying it
+func (ps *pipelineState) get() (*apiv1.ConfigMap, *pipeline.CfgData, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.data == nil {
+		return ps.cfg, nil, ps.firstDeploy
+	}
+	data := *ps.data
+	return ps.cfg, &data, ps.firstDeploy
+}
+
+// newNsDestroyer returns the destroyer used to roll back the resources of an interrupted deploy
+func newNsDestroyer() (nsDestroyer, error) {
+	dynClient, _, err := okteto.GetDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	discClient, _, err := okteto.GetDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	k8sClient, cfg, err := okteto.GetK8sClient()
+	if err != nil {
+		return nil, err
+	}
+	return namespaces.NewNamespace(dynClient, discClient, cfg, k8sClient), nil
+}
+
+// handleInterrupt stops the running deploy commands and leaves the pipeline in a consistent state.
+// If --atomic is set and the dev environment is deployed for the first time, the resources deployed so far are rolled back
+func (dc *Command) handleInterrupt(ctx context.Context, opts *Options) {
+	dc.cleanUp(ctx, oktetoErrors.ErrIntSig)
+
+	cfg, data, firstDeploy := dc.pipelineState.get()
+	if cfg == nil || data == nil {
+		oktetoLog.Infof("deploy interrupted before creating the pipeline, nothing to update")
+		return
+	}
+
+	rolledBack := false
+	if opts.Atomic {
+		if firstDeploy {
+			if err := dc.rollback(ctx, data.Name, data.Namespace); err != nil {
+				oktetoLog.Warning("Failed to roll back the interrupted deploy: %s", err)
+			} else {
+				rolledBack = true
+			}
+		} else {
+			oktetoLog.Warning("'--atomic' only rolls back dev environments deployed for the first time: the resources of '%s' were kept", data.Name)
+		}
+	}
+
+	if !rolledBack {
+		if err := dc.CfgMapHandler.UpdateConfigMap(ctx, cfg, data, oktetoErrors.ErrIntSig); err != nil && !errors.Is(err, oktetoErrors.ErrIntSig) {
+			oktetoLog.Infof("failed to update the status of '%s': %s", data.Name, err)
+		}
+	}
+
+	oktetoLog.StopSpinner()
+	oktetoLog.Information(getResumeInstructions(opts, data.Name, rolledBack))
+}
+
+// rollback destroys the resources deployed by the dev environment and its pipeline
+func (dc *Command) rollback(ctx context.Context, name, namespace string) error {
+	if dc.NsDestroyerGetter == nil {
+		return fmt.Errorf("rollback is not available")
+	}
+	destroyer, err := dc.NsDestroyerGetter()
+	if err != nil {
+		return err
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Rolling back '%s'...", name))
+	deleteOpts := namespaces.DeleteAllOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name)),
+	}
+	if err := destroyer.DestroyWithLabel(ctx, namespace, deleteOpts); err != nil {
+		return err
+	}
+
+	c, _, err := dc.K8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, dc.K8sLogger)
+	if err != nil {
+		return err
+	}
+	return configmaps.Destroy(ctx, pipeline.TranslatePipelineName(name), namespace, c)
+}
+
+// getResumeInstructions returns the commands to continue after an interrupted deploy
+func getResumeInstructions(opts *Options, name string, rolledBack bool) string {
+	flags := []string{}
+	if opts.ManifestPathFlag != "" {
+		flags = append(flags, fmt.Sprintf("-f %s", opts.ManifestPathFlag))
+	}
+	if opts.Namespace != "" {
+		flags = append(flags, fmt.Sprintf("-n %s", opts.Namespace))
+	}
+	flags = append(flags, fmt.Sprintf("--name %q", name))
+	deployCmd := strings.Join(append([]string{"okteto deploy"}, append(flags, opts.ServicesToDeploy...)...), " ")
+
+	if rolledBack {
+		return fmt.Sprintf("Deploy interrupted and rolled back. Run '%s' to deploy it again", deployCmd)
+	}
+	destroyCmd := strings.Join(append([]string{"okteto destroy"}, flags...), " ")
+	return fmt.Sprintf("Deploy interrupted. Run '%s' to resume it, or '%s' to remove the resources deployed so far", deployCmd, destroyCmd)
+}
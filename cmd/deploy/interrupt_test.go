@@ -0,0 +1,160 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+type fakeNsDestroyer struct {
+	selector string
+	called   bool
+}
+
+func (f *fakeNsDestroyer) DestroyWithLabel(_ context.Context, _ string, opts namespaces.DeleteAllOptions) error {
+	f.called = true
+	f.selector = opts.LabelSelector
+	return nil
+}
+
+func TestHandleInterrupt(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+				Cfg:       &api.Config{},
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	tests := []struct {
+		name               string
+		atomic             bool
+		firstDeploy        bool
+		expectedRollback   bool
+		expectedCfgDeleted bool
+	}{
+		{
+			name: "not atomic",
+		},
+		{
+			name:        "not atomic first deploy",
+			firstDeploy: true,
+		},
+		{
+			name:   "atomic redeploy",
+			atomic: true,
+		},
+		{
+			name:               "atomic first deploy",
+			atomic:             true,
+			firstDeploy:        true,
+			expectedRollback:   true,
+			expectedCfgDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			provider := test.NewFakeK8sProvider()
+			destroyer := &fakeNsDestroyer{}
+			dc := &Command{
+				K8sClientProvider: provider,
+				CfgMapHandler:     newDefaultConfigMapHandler(provider, nil),
+				NsDestroyerGetter: func() (nsDestroyer, error) {
+					return destroyer, nil
+				},
+			}
+			data := &pipeline.CfgData{
+				Name:      "my app",
+				Namespace: "test",
+				Status:    pipeline.ProgressingStatus,
+			}
+			cfg, err := dc.CfgMapHandler.TranslateConfigMapAndDeploy(ctx, data)
+			require.NoError(t, err)
+			dc.pipelineState.set(cfg, data, tt.firstDeploy)
+
+			dc.handleInterrupt(ctx, &Options{Atomic: tt.atomic})
+
+			assert.Equal(t, tt.expectedRollback, destroyer.called)
+			c, _, err := provider.Provide(nil)
+			require.NoError(t, err)
+			status, err := pipeline.GetStatus(ctx, "my app", "test", c)
+			if tt.expectedCfgDeleted {
+				assert.Equal(t, "dev.okteto.com/deployed-by=my-app", destroyer.selector)
+				assert.True(t, k8sErrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, pipeline.ErrorStatus, status)
+		})
+	}
+}
+
+func TestHandleInterruptWithoutPipeline(t *testing.T) {
+	destroyer := &fakeNsDestroyer{}
+	dc := &Command{
+		NsDestroyerGetter: func() (nsDestroyer, error) {
+			return destroyer, nil
+		},
+	}
+	dc.handleInterrupt(context.Background(), &Options{Atomic: true})
+	assert.False(t, destroyer.called)
+}
+
+func TestGetResumeInstructions(t *testing.T) {
+	tests := []struct {
+		opts       *Options
+		name       string
+		expected   string
+		rolledBack bool
+	}{
+		{
+			name:     "default",
+			opts:     &Options{},
+			expected: `Deploy interrupted. Run 'okteto deploy --name "app"' to resume it, or 'okteto destroy --name "app"' to remove the resources deployed so far`,
+		},
+		{
+			name: "with flags and services",
+			opts: &Options{
+				ManifestPathFlag: "okteto.yml",
+				Namespace:        "ns",
+				ServicesToDeploy: []string{"api", "db"},
+			},
+			expected: `Deploy interrupted. Run 'okteto deploy -f okteto.yml -n ns --name "app" api db' to resume it, or 'okteto destroy -f okteto.yml -n ns --name "app"' to remove the resources deployed so far`,
+		},
+		{
+			name:       "rolled back",
+			opts:       &Options{},
+			rolledBack: true,
+			expected:   `Deploy interrupted and rolled back. Run 'okteto deploy --name "app"' to deploy it again`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getResumeInstructions(tt.opts, "app", tt.rolledBack))
+		})
+	}
+}
@@ -57,8 +57,12 @@ func (ld *localDeployer) Deploy(ctx context.Context, deployOptions *Options) err
 		Deployable: deployable.Entity{
 			Commands: deployOptions.Manifest.Deploy.Commands,
 			Divert:   deployOptions.Manifest.Deploy.Divert,
+			Hooks:    deployOptions.Manifest.Deploy.Hooks,
 			External: deployOptions.Manifest.External,
+			Outputs:  deployOptions.Manifest.Outputs,
 		},
+		Diff:    deployOptions.Diff,
+		Confirm: deployOptions.Confirm,
 	}
 
 	err := ld.runner.RunDeploy(ctx, params)
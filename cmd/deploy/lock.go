@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/kubernetes"
+)
+
+// deployLockPollInterval is how often a deploy waiting for the lock tries to take it
+var deployLockPollInterval = 5 * time.Second
+
+// getDeployLockHolder returns the holder of the deploy lock. The deploys run by the commands of another deploy reuse its holder
+func getDeployLockHolder() string {
+	if holder := os.Getenv(constants.OktetoDeployLockHolderEnvVar); holder != "" {
+		return holder
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		oktetoLog.Infof("failed to get the hostname: %s", err)
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s/%d", hostname, os.Getpid())
+}
+
+// acquireDeployLock takes the lease on the configmap of the development environment so concurrent deploys don't interleave their commands.
+// If another deploy holds it, it waits for it up to the lock timeout
+func acquireDeployLock(ctx context.Context, opts *Options, data *pipeline.CfgData, c kubernetes.Interface) (*pipeline.DeployLease, error) {
+	holder := getDeployLockHolder()
+	deadline := time.Now().Add(opts.LockTimeout)
+	waiting := false
+	for {
+		lease, err := pipeline.TryAcquireDeployLease(ctx, data, holder, pipeline.DefaultDeployLockTTL, c)
+		if err == nil {
+			return lease, nil
+		}
+		lockedErr := pipeline.DeployLockedError{}
+		if !errors.As(err, &lockedErr) {
+			return nil, err
+		}
+		if !time.Now().Before(deadline) {
+			return nil, oktetoErrors.UserError{
+				E:    err,
+				Hint: "Wait for it to finish, or use '--lock-timeout' to wait for it, e.g. '--lock-timeout 10m'",
+			}
+		}
+		if !waiting {
+			oktetoLog.Information("%s. Waiting for it to finish...", err)
+			waiting = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(deployLockPollInterval):
+		}
+	}
+}
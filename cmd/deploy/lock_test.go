@@ -0,0 +1,61 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_getDeployLockHolder(t *testing.T) {
+	t.Setenv(constants.OktetoDeployLockHolderEnvVar, "")
+	assert.NotEmpty(t, getDeployLockHolder())
+
+	t.Setenv(constants.OktetoDeployLockHolderEnvVar, "parent")
+	assert.Equal(t, "parent", getDeployLockHolder())
+}
+
+func Test_acquireDeployLock(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	data := &pipeline.CfgData{Name: "test", Namespace: "ns", Status: pipeline.ProgressingStatus}
+	deployLockPollInterval = 10 * time.Millisecond
+	t.Setenv(constants.OktetoDeployLockHolderEnvVar, "")
+
+	other, err := pipeline.TryAcquireDeployLease(ctx, data, "other", time.Minute, c)
+	require.NoError(t, err)
+
+	// the deploy fails right away by default
+	_, err = acquireDeployLock(ctx, &Options{}, data, c)
+	require.ErrorAs(t, err, &oktetoErrors.UserError{})
+	require.ErrorAs(t, err, &pipeline.DeployLockedError{})
+
+	// the deploy waits for the other deploy to release the lock
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(t, other.Release(ctx, "", true))
+	}()
+	lease, err := acquireDeployLock(ctx, &Options{LockTimeout: 5 * time.Second}, data, c)
+	require.NoError(t, err)
+	assert.NotEqual(t, "other", lease.Holder())
+	require.NoError(t, lease.Release(ctx, "", true))
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	"k8s.io/client-go/kubernetes"
+)
+
+// setNamespaceVariables sets the default variables of the namespace that are not defined yet.
+// They have the lowest precedence: the variables of the flags, the environment, the Okteto Variables and the .env file override them
+func setNamespaceVariables(ctx context.Context, namespace string, c kubernetes.Interface, lookupEnv func(key string) (string, bool), setEnv func(key, value string) error) error {
+	vars, err := namespaces.GetVariables(ctx, namespace, c)
+	if err != nil {
+		return err
+	}
+	for _, v := range vars {
+		if _, ok := lookupEnv(v.Name); ok {
+			continue
+		}
+		if err := setEnv(v.Name, v.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetNamespaceVariables(t *testing.T) {
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: namespaces.VariablesConfigMapName, Namespace: "test"},
+		Data:       map[string]string{"REGION": "us-east-1", "LOG_LEVEL": "info"},
+	})
+	envs := map[string]string{"LOG_LEVEL": "debug"}
+	lookupEnv := func(key string) (string, bool) {
+		v, ok := envs[key]
+		return v, ok
+	}
+	setEnv := func(key, value string) error {
+		envs[key] = value
+		return nil
+	}
+
+	err := setNamespaceVariables(context.Background(), "test", c, lookupEnv, setEnv)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "REGION": "us-east-1"}, envs)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/forward"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	modelForward "github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxSystemPort is the highest privileged port. Services listening on privileged ports are forwarded to a random local port
+const maxSystemPort = 1024
+
+type servicePortForwarder interface {
+	Add(f modelForward.Forward) error
+	StartServices(namespace string)
+	Stop()
+}
+
+// getServiceForwards returns the port forwards to the TCP ports of the services deployed by the dev environment devName.
+// Local ports are the same as the service ports when they are available
+func getServiceForwards(ctx context.Context, c kubernetes.Interface, namespace, devName string, isPortAvailable func(int) bool, getAvailablePort func() (int, error)) ([]modelForward.Forward, error) {
+	svcList, err := c.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(devName)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	sort.Slice(svcList.Items, func(i, j int) bool {
+		return svcList.Items[i].Name < svcList.Items[j].Name
+	})
+
+	usedPorts := map[int]bool{}
+	result := []modelForward.Forward{}
+	for _, svc := range svcList.Items {
+		for _, p := range svc.Spec.Ports {
+			if p.Protocol != "" && p.Protocol != apiv1.ProtocolTCP {
+				continue
+			}
+			remote := int(p.Port)
+			local := remote
+			if local <= maxSystemPort || usedPorts[local] || !isPortAvailable(local) {
+				local, err = getAvailablePort()
+				if err != nil {
+					return nil, fmt.Errorf("failed to get a local port for service '%s': %w", svc.Name, err)
+				}
+			}
+			usedPorts[local] = true
+			result = append(result, modelForward.Forward{
+				Local:       local,
+				Remote:      remote,
+				Service:     true,
+				ServiceName: svc.Name,
+			})
+		}
+	}
+	return result, nil
+}
+
+// portForwardEndpoints forwards the services of the dev environment to localhost until the command is interrupted.
+// It gives access to the dev environment in clusters without public endpoints
+func portForwardEndpoints(ctx context.Context, opts *EndpointsOptions, c kubernetes.Interface, forwarder servicePortForwarder) error {
+	forwards, err := getServiceForwards(ctx, c, opts.Namespace, opts.Name,
+		func(port int) bool { return model.IsPortAvailable(model.Localhost, port) },
+		func() (int, error) { return model.GetAvailablePort(model.Localhost) },
+	)
+	if err != nil {
+		return err
+	}
+	if len(forwards) == 0 {
+		oktetoLog.Information("There are no services to forward for '%s'", opts.Name)
+		return nil
+	}
+
+	for _, f := range forwards {
+		if err := forwarder.Add(f); err != nil {
+			return err
+		}
+	}
+	forwarder.StartServices(opts.Namespace)
+	defer forwarder.Stop()
+
+	oktetoLog.Information("Endpoints available through port forwarding:")
+	for _, f := range forwards {
+		oktetoLog.Printf("  - http://%s:%d -> %s:%d\n", model.Localhost, f.Local, f.ServiceName, f.Remote)
+	}
+	oktetoLog.Information("Press Ctrl+C to stop forwarding")
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+	select {
+	case <-stop:
+		oktetoLog.Infof("CTRL+C received, stopping port forwarding")
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// newServicePortForwarder returns the port forwarder to the services of the current context
+func newServicePortForwarder(ctx context.Context, namespace string) (kubernetes.Interface, servicePortForwarder, error) {
+	c, restConfig, err := okteto.GetK8sClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, forward.NewPortForwardManager(ctx, model.Localhost, restConfig, c, namespace), nil
+}
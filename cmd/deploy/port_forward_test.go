@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	modelForward "github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeServicePortForwarder struct {
+	forwards  []modelForward.Forward
+	namespace string
+	stopped   bool
+}
+
+func (f *fakeServicePortForwarder) Add(fw modelForward.Forward) error {
+	f.forwards = append(f.forwards, fw)
+	return nil
+}
+
+func (f *fakeServicePortForwarder) StartServices(namespace string) {
+	f.namespace = namespace
+}
+
+func (f *fakeServicePortForwarder) Stop() {
+	f.stopped = true
+}
+
+func newFakeService(name, devName string, ports ...apiv1.ServicePort) *apiv1.Service {
+	return &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{model.DeployedByLabel: devName},
+		},
+		Spec: apiv1.ServiceSpec{Ports: ports},
+	}
+}
+
+func TestGetServiceForwards(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		newFakeService("web", "movies", apiv1.ServicePort{Port: 8080}, apiv1.ServicePort{Port: 53, Protocol: apiv1.ProtocolUDP}),
+		newFakeService("api", "movies", apiv1.ServicePort{Port: 8080, Protocol: apiv1.ProtocolTCP}, apiv1.ServicePort{Port: 80}),
+		newFakeService("other", "other", apiv1.ServicePort{Port: 9090}),
+	)
+
+	nextPort := 50000
+	getAvailablePort := func() (int, error) {
+		nextPort++
+		return nextPort, nil
+	}
+	forwards, err := getServiceForwards(context.Background(), c, "test", "movies", func(int) bool { return true }, getAvailablePort)
+	require.NoError(t, err)
+	assert.Equal(t, []modelForward.Forward{
+		{Local: 8080, Remote: 8080, Service: true, ServiceName: "api"},
+		{Local: 50001, Remote: 80, Service: true, ServiceName: "api"},
+		{Local: 50002, Remote: 8080, Service: true, ServiceName: "web"},
+	}, forwards)
+}
+
+func TestPortForwardEndpointsWithoutServices(t *testing.T) {
+	forwarder := &fakeServicePortForwarder{}
+	err := portForwardEndpoints(context.Background(), &EndpointsOptions{Name: "movies", Namespace: "test"}, fake.NewSimpleClientset(), forwarder)
+	require.NoError(t, err)
+	assert.Empty(t, forwarder.forwards)
+	assert.False(t, forwarder.stopped)
+}
+
+func TestPortForwardEndpoints(t *testing.T) {
+	c := fake.NewSimpleClientset(newFakeService("web", "movies", apiv1.ServicePort{Port: 8080}))
+	forwarder := &fakeServicePortForwarder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := portForwardEndpoints(ctx, &EndpointsOptions{Name: "movies", Namespace: "test"}, c, forwarder)
+	require.NoError(t, err)
+	assert.Len(t, forwarder.forwards, 1)
+	assert.Equal(t, "test", forwarder.namespace)
+	assert.True(t, forwarder.stopped)
+}
@@ -89,14 +89,25 @@ func (rd *remoteDeployer) Deploy(ctx context.Context, deployOptions *Options) er
 
 	dep := deployable.Entity{
 		Divert:   deployOptions.Manifest.Deploy.Divert,
+		Hooks:    deployOptions.Manifest.Deploy.Hooks,
 		Commands: deployOptions.Manifest.Deploy.Commands,
 		External: deployOptions.Manifest.External,
+		Outputs:  deployOptions.Manifest.Outputs,
 	}
 
 	commandsFlags, err := GetCommandFlags(deployOptions.Name, deployOptions.Variables)
 	if err != nil {
 		return err
 	}
+	if deployOptions.RawOutput {
+		commandsFlags = append(commandsFlags, "--raw-output")
+	}
+	if deployOptions.Diff {
+		commandsFlags = append(commandsFlags, "--diff")
+	}
+	if len(dep.Hooks.GetPost()) > 0 {
+		commandsFlags = append(commandsFlags, fmt.Sprintf("--timeout %s", deployOptions.Timeout))
+	}
 
 	cwd, err := remote.GetOriginalCWD(filesystem.NewOsWorkingDirectoryCtrl(), deployOptions.ManifestPathFlag)
 	if err != nil {
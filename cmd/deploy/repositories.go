@@ -0,0 +1,100 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	modelUtils "github.com/okteto/okteto/pkg/model/utils"
+	"github.com/okteto/okteto/pkg/repository"
+)
+
+// getSiblingRepositories returns the git metadata of the repositories checked out next to the repository of the manifest
+// that are referenced by the build contexts of the manifest
+func getSiblingRepositories(manifest *model.Manifest, rootDir string) []pipeline.Repository {
+	if manifest == nil || rootDir == "" {
+		return nil
+	}
+
+	roots := map[string]bool{}
+	for _, b := range manifest.Build {
+		if b == nil || b.Context == "" || build.IsRemoteContext(b.Context) {
+			continue
+		}
+		root, err := repository.FindTopLevelGitDir(b.Context)
+		if err != nil {
+			oktetoLog.Infof("could not find the repository of the build context '%s': %s", b.Context, err)
+			continue
+		}
+		if root == rootDir {
+			continue
+		}
+		roots[root] = true
+	}
+
+	result := []pipeline.Repository{}
+	for root := range roots {
+		path, err := filepath.Rel(rootDir, root)
+		if err != nil {
+			oktetoLog.Infof("could not get the relative path of the repository '%s': %s", root, err)
+			continue
+		}
+		result = append(result, getRepositoryMetadata(root, filepath.ToSlash(path)))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+func getRepositoryMetadata(root, path string) pipeline.Repository {
+	r := pipeline.Repository{Path: path}
+
+	branch, err := utils.GetBranch(root)
+	if err != nil {
+		oktetoLog.Infof("could not retrieve branch name of '%s': %s", path, err)
+	}
+	r.Branch = branch
+
+	url, err := modelUtils.GetRepositoryURL(root)
+	if err != nil {
+		oktetoLog.Infof("could not retrieve repo name of '%s': %s", path, err)
+	}
+	if url != "" {
+		if repoHTTPS := switchRepoSchemaToHTTPS(url); repoHTTPS != nil {
+			r.URL = repoHTTPS.String()
+		}
+	}
+
+	repo := repository.NewRepository(root)
+	sha, err := repo.GetSHA()
+	if err != nil {
+		oktetoLog.Infof("could not retrieve sha of '%s': %s", path, err)
+	}
+	isClean, err := repo.IsClean()
+	if err != nil {
+		oktetoLog.Infof("could not retrieve status of '%s': %s", path, err)
+	}
+	if sha != "" && !isClean {
+		sha = utils.GetRandomSHA()
+	}
+	r.Commit = sha
+	return r
+}
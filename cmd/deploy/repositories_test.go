@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initTestRepository(t *testing.T, dir, remote string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "server"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server", "Dockerfile"), []byte("FROM alpine"), 0600))
+
+	r, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	if remote != "" {
+		_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remote}})
+		require.NoError(t, err)
+	}
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("server/Dockerfile")
+	require.NoError(t, err)
+	_, err = w.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "okteto", Email: "test@okteto.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+}
+
+func Test_getSiblingRepositories(t *testing.T) {
+	dir := t.TempDir()
+	mainDir := filepath.Join(dir, "movies")
+	apiDir := filepath.Join(dir, "api")
+	initTestRepository(t, mainDir, "git@github.com:okteto/movies.git")
+	initTestRepository(t, apiDir, "git@github.com:okteto/api.git")
+
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"frontend": &build.Info{Context: filepath.Join(mainDir, "server")},
+			"api":      &build.Info{Context: filepath.Join(apiDir, "server")},
+			"worker":   &build.Info{Context: apiDir},
+			"remote":   &build.Info{Context: "https://github.com/okteto/worker.git"},
+			"missing":  &build.Info{Context: filepath.Join(dir, "missing")},
+		},
+	}
+
+	result := getSiblingRepositories(manifest, mainDir)
+	require.Len(t, result, 1)
+	assert.Equal(t, "../api", result[0].Path)
+	assert.Equal(t, "https://github.com/okteto/api.git", result[0].URL)
+	assert.Equal(t, "master", result[0].Branch)
+	assert.NotEmpty(t, result[0].Commit)
+}
+
+func Test_getSiblingRepositoriesWithoutSiblings(t *testing.T) {
+	dir := t.TempDir()
+	initTestRepository(t, dir, "")
+
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"frontend": &build.Info{Context: filepath.Join(dir, "server")},
+		},
+	}
+	assert.Empty(t, getSiblingRepositories(manifest, dir))
+	assert.Nil(t, getSiblingRepositories(manifest, ""))
+	assert.Nil(t, getSiblingRepositories(nil, dir))
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/kubernetes"
+)
+
+var errNoSuccessfulDeploy = errors.New("there is no successful deploy to roll back to")
+
+// shouldRollbackOnFailure returns if a failed deploy has to be rolled back to the last successful deploy.
+// The remote deployer doesn't roll back, the rollback is done by the main command execution
+func (dc *Command) shouldRollbackOnFailure(opts *Options) bool {
+	if dc.IsRemote {
+		return false
+	}
+	return opts.RollbackOnFailure || opts.Manifest.Deploy.IsRollbackAuto()
+}
+
+// saveDeployedState stores the images, commands and variables of a successful deploy,
+// so the next deploys can be rolled back to it if they fail
+func (dc *Command) saveDeployedState(ctx context.Context, opts *Options, c kubernetes.Interface) error {
+	resources, err := pipeline.GetResources(ctx, opts.Name, opts.Manifest.Namespace, c)
+	if err != nil {
+		return err
+	}
+	state := pipeline.DeployedState{
+		Images:    dc.Builder.GetBuildEnvVars(),
+		Commands:  opts.Manifest.Deploy.Commands,
+		Variables: opts.Variables,
+		Resources: resources,
+	}
+	return pipeline.UpdateLastSuccessfulDeploy(ctx, opts.Name, opts.Manifest.Namespace, state, c)
+}
+
+// rollbackToLastSuccessfulDeploy runs again the deploy commands of the last successful deploy with its images and variables.
+// Compose services and endpoints are not rolled back
+func (dc *Command) rollbackToLastSuccessfulDeploy(ctx context.Context, opts *Options, c kubernetes.Interface) error {
+	state, err := pipeline.GetLastSuccessfulDeploy(ctx, opts.Name, opts.Manifest.Namespace, c)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return errNoSuccessfulDeploy
+	}
+
+	oktetoLog.SetStage("Rollback")
+	oktetoLog.Information("Rolling back '%s' to its last successful deploy", opts.Name)
+	if opts.Manifest.Deploy.ComposeSection != nil || opts.Manifest.Deploy.Endpoints != nil {
+		oktetoLog.Warning("The compose services and endpoints of '%s' are not rolled back", opts.Name)
+	}
+
+	rollbackOpts := getRollbackOptions(opts, state)
+	deployer, err := dc.GetDeployer(ctx, rollbackOpts, func() map[string]string { return state.Images }, dc.CfgMapHandler, dc.K8sClientProvider, dc.IoCtrl, dc.K8sLogger, GetDependencyEnvVars)
+	if err != nil {
+		return err
+	}
+	dc.onCleanUp = append(dc.onCleanUp, deployer.CleanUp)
+
+	if err := deployer.Deploy(ctx, rollbackOpts); err != nil {
+		return err
+	}
+	oktetoLog.SetStage("")
+	return nil
+}
+
+// getRollbackOptions returns the options to deploy again the state of the last successful deploy.
+// The okteto build env vars of its images are added after its variables to override the ones of the images just built
+func getRollbackOptions(opts *Options, state *pipeline.DeployedState) *Options {
+	deployInfo := *opts.Manifest.Deploy
+	deployInfo.Commands = state.Commands
+	deployInfo.ComposeSection = nil
+	deployInfo.Endpoints = nil
+	deployInfo.Hooks = nil
+
+	manifest := *opts.Manifest
+	manifest.Deploy = &deployInfo
+
+	variables := make([]string, 0, len(state.Variables)+len(state.Images))
+	variables = append(variables, state.Variables...)
+	images := make([]string, 0, len(state.Images))
+	for k, v := range state.Images {
+		images = append(images, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(images)
+	variables = append(variables, images...)
+
+	rollbackOpts := *opts
+	rollbackOpts.Manifest = &manifest
+	rollbackOpts.Variables = variables
+	rollbackOpts.Diff = false
+	rollbackOpts.Confirm = false
+	return &rollbackOpts
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeRollbackDeployer struct {
+	err      error
+	deployed *Options
+}
+
+func (f *fakeRollbackDeployer) Deploy(_ context.Context, opts *Options) error {
+	f.deployed = opts
+	return f.err
+}
+
+func (*fakeRollbackDeployer) CleanUp(_ context.Context, _ error) {}
+
+func newRollbackCommand(deployer Deployer) *Command {
+	return &Command{
+		GetDeployer: func(context.Context, *Options, buildEnvVarsGetter, ConfigMapHandler, okteto.K8sClientProviderWithLogger, *io.Controller, *io.K8sLogger, dependencyEnvVarsGetter) (Deployer, error) {
+			return deployer, nil
+		},
+		Builder: &fakeV2Builder{},
+	}
+}
+
+func newPipelineClient(name, namespace string) *fake.Clientset {
+	return fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pipeline.TranslatePipelineName(name),
+			Namespace: namespace,
+		},
+		Data: map[string]string{},
+	})
+}
+
+func TestShouldRollbackOnFailure(t *testing.T) {
+	tests := []struct {
+		opts     *Options
+		name     string
+		isRemote bool
+		expected bool
+	}{
+		{
+			name:     "flag",
+			opts:     &Options{RollbackOnFailure: true, Manifest: &model.Manifest{Deploy: &model.DeployInfo{}}},
+			expected: true,
+		},
+		{
+			name:     "manifest",
+			opts:     &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{Rollback: model.RollbackAuto}}},
+			expected: true,
+		},
+		{
+			name: "never",
+			opts: &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{Rollback: model.RollbackNever}}},
+		},
+		{
+			name:     "remote deployer",
+			opts:     &Options{RollbackOnFailure: true, Manifest: &model.Manifest{Deploy: &model.DeployInfo{}}},
+			isRemote: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := &Command{IsRemote: tt.isRemote}
+			assert.Equal(t, tt.expected, dc.shouldRollbackOnFailure(tt.opts))
+		})
+	}
+}
+
+func TestRollbackWithoutSuccessfulDeploy(t *testing.T) {
+	deployer := &fakeRollbackDeployer{}
+	dc := newRollbackCommand(deployer)
+	opts := &Options{
+		Name:     "test",
+		Manifest: &model.Manifest{Namespace: "ns", Deploy: &model.DeployInfo{}},
+	}
+
+	err := dc.rollbackToLastSuccessfulDeploy(context.Background(), opts, newPipelineClient("test", "ns"))
+	require.ErrorIs(t, err, errNoSuccessfulDeploy)
+	require.Nil(t, deployer.deployed)
+}
+
+func TestRollbackToLastSuccessfulDeploy(t *testing.T) {
+	ctx := context.Background()
+	c := newPipelineClient("test", "ns")
+	deployer := &fakeRollbackDeployer{}
+	dc := newRollbackCommand(deployer)
+
+	previous := &Options{
+		Name:      "test",
+		Variables: []string{"A=previous"},
+		Manifest: &model.Manifest{
+			Namespace: "ns",
+			Deploy: &model.DeployInfo{
+				Commands: []model.DeployCommand{{Name: "previous", Command: "helm upgrade --install previous"}},
+			},
+		},
+	}
+	require.NoError(t, dc.saveDeployedState(ctx, previous, c))
+
+	current := &Options{
+		Name:      "test",
+		Variables: []string{"A=current"},
+		Diff:      true,
+		Manifest: &model.Manifest{
+			Namespace: "ns",
+			Deploy: &model.DeployInfo{
+				Commands: []model.DeployCommand{{Name: "current", Command: "helm upgrade --install current"}},
+				Hooks:    &model.Hooks{Post: []model.DeployCommand{{Name: "migrate", Command: "make migrate"}}},
+			},
+		},
+	}
+	require.NoError(t, dc.rollbackToLastSuccessfulDeploy(ctx, current, c))
+
+	require.NotNil(t, deployer.deployed)
+	assert.Equal(t, previous.Manifest.Deploy.Commands, deployer.deployed.Manifest.Deploy.Commands)
+	assert.Equal(t, []string{"A=previous"}, deployer.deployed.Variables)
+	assert.Nil(t, deployer.deployed.Manifest.Deploy.Hooks)
+	assert.False(t, deployer.deployed.Diff)
+	assert.Equal(t, "current", current.Manifest.Deploy.Commands[0].Name)
+}
+
+func TestGetRollbackOptions(t *testing.T) {
+	opts := &Options{
+		Name:      "test",
+		Variables: []string{"A=current"},
+		Confirm:   true,
+		Manifest: &model.Manifest{
+			Deploy: &model.DeployInfo{
+				Commands:       []model.DeployCommand{{Name: "current", Command: "current"}},
+				ComposeSection: &model.ComposeSectionInfo{},
+			},
+		},
+	}
+	state := &pipeline.DeployedState{
+		Images: map[string]string{
+			"OKTETO_BUILD_WEB_IMAGE": "okteto.dev/web:1",
+			"OKTETO_BUILD_API_IMAGE": "okteto.dev/api:1",
+		},
+		Commands:  []model.DeployCommand{{Name: "previous", Command: "previous"}},
+		Variables: []string{"A=previous"},
+	}
+
+	result := getRollbackOptions(opts, state)
+	assert.Equal(t, []string{"A=previous", "OKTETO_BUILD_API_IMAGE=okteto.dev/api:1", "OKTETO_BUILD_WEB_IMAGE=okteto.dev/web:1"}, result.Variables)
+	assert.Equal(t, state.Commands, result.Manifest.Deploy.Commands)
+	assert.Nil(t, result.Manifest.Deploy.ComposeSection)
+	assert.False(t, result.Confirm)
+	assert.NotNil(t, opts.Manifest.Deploy.ComposeSection)
+	assert.Equal(t, []string{"A=current"}, opts.Variables)
+}
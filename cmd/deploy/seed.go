@@ -0,0 +1,238 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/cmd/utils/executor"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/seed"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SeedOptions defines the options of the seed command
+type SeedOptions struct {
+	Name         string
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Variables    []string
+	Force        bool
+}
+
+type seedChecksumStore interface {
+	GetSeedChecksums(ctx context.Context, name, namespace string) (map[string]string, error)
+	UpdateSeedChecksums(ctx context.Context, name, namespace string, checksums map[string]string) error
+}
+
+// pipelineSeedChecksumStore stores the checksums of the seeds in the configmap of the dev environment
+type pipelineSeedChecksumStore struct {
+	c kubernetes.Interface
+}
+
+func (s pipelineSeedChecksumStore) GetSeedChecksums(ctx context.Context, name, namespace string) (map[string]string, error) {
+	checksums, err := pipeline.GetSeedChecksums(ctx, name, namespace, s.c)
+	if oktetoErrors.IsNotFound(err) {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("development environment '%s' not found", name),
+			Hint: "Run 'okteto deploy' to deploy your development environment before running its seeds",
+		}
+	}
+	return checksums, err
+}
+
+func (s pipelineSeedChecksumStore) UpdateSeedChecksums(ctx context.Context, name, namespace string, checksums map[string]string) error {
+	return pipeline.UpdateSeedChecksums(ctx, name, namespace, checksums, s.c)
+}
+
+// seedRunner executes the seeds of a dev environment
+type seedRunner struct {
+	executor executor.ManifestExecutor
+	store    seedChecksumStore
+	fs       afero.Fs
+}
+
+type seedRunOptions struct {
+	name      string
+	namespace string
+	seeds     seed.Section
+	only      []string
+	variables []string
+	force     bool
+}
+
+func newSeedRunner(c kubernetes.Interface) *seedRunner {
+	return &seedRunner{
+		// seeds always run with a shell to redirect the sql files to the database clients
+		executor: executor.NewExecutor(oktetoLog.GetOutputFormat(), false, "", false),
+		store:    pipelineSeedChecksumStore{c: c},
+		fs:       afero.NewOsFs(),
+	}
+}
+
+// run executes the seeds in order, skipping the ones that didn't change since their last execution.
+// The checksum of each seed is stored as soon as it succeeds, so a failed run resumes from the failed seed
+func (r *seedRunner) run(ctx context.Context, opts seedRunOptions) error {
+	seeds, err := opts.seeds.Filter(opts.only)
+	if err != nil {
+		return err
+	}
+
+	checksums, err := r.store.GetSeedChecksums(ctx, opts.name, opts.namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range seeds {
+		sum, err := seed.Checksum(r.fs, s)
+		if err != nil {
+			return err
+		}
+		if !opts.force && checksums[s.Name] == sum {
+			oktetoLog.Information("Seed '%s' didn't change since its last execution, skipping it", s.Name)
+			continue
+		}
+
+		oktetoLog.SetStage(fmt.Sprintf("Seed %s", s.Name))
+		oktetoLog.Information("Running seed '%s'", s.Name)
+		command := model.DeployCommand{
+			Name:    fmt.Sprintf("Seed '%s'", s.Name),
+			Command: s.ShellCommand(r.isDir(s)),
+		}
+		if err := r.executor.Execute(command, opts.variables); err != nil {
+			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error running seed '%s': %s", s.Name, err.Error())
+			return fmt.Errorf("error running seed '%s': %w", s.Name, err)
+		}
+
+		checksums[s.Name] = sum
+		if err := r.store.UpdateSeedChecksums(ctx, opts.name, opts.namespace, checksums); err != nil {
+			return fmt.Errorf("could not store the checksum of seed '%s': %w", s.Name, err)
+		}
+	}
+	oktetoLog.SetStage("")
+	return nil
+}
+
+func (r *seedRunner) isDir(s *seed.Seed) bool {
+	if s.Upload == nil {
+		return false
+	}
+	info, err := r.fs.Stat(s.Upload.Source)
+	return err == nil && info.IsDir()
+}
+
+// Seed runs the seeds defined in the okteto manifest
+func Seed(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
+	options := &SeedOptions{}
+	fs := afero.NewOsFs()
+	cmd := &cobra.Command{
+		Use:   "seed [seed...]",
+		Short: "Load the test data defined in the 'seed' section of your okteto manifest",
+		Long: `Load the test data defined in the 'seed' section of your okteto manifest.
+
+Seeds are executed in order. A seed is skipped if its definition and files didn't change since its last execution, unless the flag '--force' is set.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model.UseSections(model.SeedSection)
+			if options.ManifestPath != "" {
+				workdir := filesystem.GetWorkdirFromManifestPath(options.ManifestPath)
+				if err := os.Chdir(workdir); err != nil {
+					return err
+				}
+				options.ManifestPath = filesystem.GetManifestPathFromWorkdir(options.ManifestPath, workdir)
+				if _, err := fs.Stat(options.ManifestPath); err != nil {
+					return oktetoErrors.UserError{
+						E:    fmt.Errorf("the okteto manifest file '%s' does not exist", options.ManifestPath),
+						Hint: "Check the path to the okteto manifest file",
+					}
+				}
+			}
+
+			if err := contextCMD.LoadContextFromPath(ctx, options.Namespace, options.K8sContext, options.ManifestPath, contextCMD.Options{Show: true}); err != nil {
+				if err.Error() == fmt.Errorf(oktetoErrors.ErrNotLogged, okteto.GetContext().Name).Error() {
+					return err
+				}
+				if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{Namespace: options.Namespace, Show: true}); err != nil {
+					return err
+				}
+			}
+
+			manifest, err := model.GetManifestV2(options.ManifestPath, fs)
+			if err != nil {
+				return err
+			}
+			if len(manifest.Seed) == 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the okteto manifest doesn't define any seed"),
+					Hint: "Add a 'seed' section to your okteto manifest",
+				}
+			}
+
+			c, _, err := okteto.NewK8sClientProviderWithLogger(k8sLogger).Provide(okteto.GetContext().Cfg)
+			if err != nil {
+				return err
+			}
+
+			if options.Name == "" {
+				options.Name = manifest.Name
+				if options.Name == "" {
+					cwd, err := os.Getwd()
+					if err != nil {
+						return fmt.Errorf("failed to get the current working directory: %w", err)
+					}
+					inferer := devenvironment.NewNameInferer(c)
+					options.Name = inferer.InferName(ctx, cwd, okteto.GetContext().Namespace, options.ManifestPath)
+				}
+				options.Name = utils.PerUserName(options.Name)
+			}
+			if options.Namespace == "" {
+				options.Namespace = okteto.GetContext().Namespace
+			}
+
+			runner := newSeedRunner(c)
+			if err := runner.run(ctx, seedRunOptions{
+				name:      options.Name,
+				namespace: options.Namespace,
+				seeds:     manifest.Seed,
+				only:      args,
+				variables: options.Variables,
+				force:     options.Force,
+			}); err != nil {
+				return err
+			}
+			oktetoLog.Success("Seeds of '%s' successfully loaded", options.Name)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrites the namespace where the development environment is deployed")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment is deployed")
+	cmd.Flags().StringArrayVarP(&options.Variables, "var", "v", []string{}, "set a variable (can be set more than once)")
+	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "run the seeds even if they didn't change since their last execution")
+	return cmd
+}
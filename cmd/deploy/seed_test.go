@@ -0,0 +1,106 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/seed"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSeedExecutor struct {
+	err      map[string]error
+	executed []string
+}
+
+func (f *fakeSeedExecutor) Execute(command model.DeployCommand, _ []string) error {
+	f.executed = append(f.executed, command.Command)
+	return f.err[command.Command]
+}
+
+func (*fakeSeedExecutor) CleanUp(_ error) {}
+
+type fakeSeedChecksumStore struct {
+	checksums map[string]string
+}
+
+func (f *fakeSeedChecksumStore) GetSeedChecksums(_ context.Context, _, _ string) (map[string]string, error) {
+	result := map[string]string{}
+	for k, v := range f.checksums {
+		result[k] = v
+	}
+	return result, nil
+}
+
+func (f *fakeSeedChecksumStore) UpdateSeedChecksums(_ context.Context, _, _ string, checksums map[string]string) error {
+	f.checksums = checksums
+	return nil
+}
+
+func TestSeedRunner(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "users.sql", []byte("INSERT INTO users VALUES (1);"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "assets/logo.png", []byte("logo"), 0600))
+	seeds := seed.Section{
+		{Name: "db", SQL: &seed.SQL{File: "users.sql", Command: "psql"}},
+		{Name: "assets", Upload: &seed.Upload{Source: "assets", Bucket: "s3://bucket/assets"}},
+	}
+
+	store := &fakeSeedChecksumStore{}
+	exec := &fakeSeedExecutor{}
+	runner := &seedRunner{executor: exec, store: store, fs: fs}
+	opts := seedRunOptions{name: "test", namespace: "ns", seeds: seeds}
+
+	require.NoError(t, runner.run(context.Background(), opts))
+	assert.Equal(t, []string{"psql < 'users.sql'", "aws s3 sync 'assets' 's3://bucket/assets'"}, exec.executed)
+	assert.Len(t, store.checksums, 2)
+
+	// unchanged seeds are skipped
+	exec.executed = nil
+	require.NoError(t, runner.run(context.Background(), opts))
+	assert.Empty(t, exec.executed)
+
+	// changed seeds run again
+	require.NoError(t, afero.WriteFile(fs, "users.sql", []byte("INSERT INTO users VALUES (2);"), 0600))
+	require.NoError(t, runner.run(context.Background(), opts))
+	assert.Equal(t, []string{"psql < 'users.sql'"}, exec.executed)
+
+	// forced seeds run even if unchanged
+	exec.executed = nil
+	opts.force = true
+	opts.only = []string{"assets"}
+	require.NoError(t, runner.run(context.Background(), opts))
+	assert.Equal(t, []string{"aws s3 sync 'assets' 's3://bucket/assets'"}, exec.executed)
+}
+
+func TestSeedRunnerFailure(t *testing.T) {
+	seeds := seed.Section{
+		{Name: "db", Command: "make db"},
+		{Name: "fixtures", Command: "make fixtures"},
+	}
+	store := &fakeSeedChecksumStore{}
+	exec := &fakeSeedExecutor{err: map[string]error{"make db": errors.New("exit status 1")}}
+	runner := &seedRunner{executor: exec, store: store, fs: afero.NewMemMapFs()}
+
+	err := runner.run(context.Background(), seedRunOptions{name: "test", namespace: "ns", seeds: seeds})
+	assert.ErrorContains(t, err, "error running seed 'db'")
+	assert.Equal(t, []string{"make db"}, exec.executed)
+	assert.Empty(t, store.checksums)
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+var errFeaturesNotSupportedInVanilla = errors.New("your okteto manifest uses features that are only supported in contexts with Okteto installed")
+
+// getFeaturesNotSupportedInVanilla returns the sections of the manifest that can't be deployed on a context without Okteto installed
+func getFeaturesNotSupportedInVanilla(manifest *model.Manifest) []string {
+	features := []string{}
+	if manifest.HasDependencies() {
+		features = append(features, "dependencies")
+	}
+	if manifest.Deploy != nil && manifest.Deploy.Divert != nil {
+		features = append(features, "deploy.divert")
+	}
+	return features
+}
+
+// validateManifestInVanilla returns an error before building or deploying anything if the manifest
+// uses features that require a context with Okteto installed
+func validateManifestInVanilla(manifest *model.Manifest) error {
+	features := getFeaturesNotSupportedInVanilla(manifest)
+	if len(features) == 0 {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("%w: %s", errFeaturesNotSupportedInVanilla, strings.Join(features, ", ")),
+		Hint: "Remove them from your okteto manifest to deploy it on any Kubernetes cluster, or run 'okteto context use' to select a context with Okteto installed",
+	}
+}
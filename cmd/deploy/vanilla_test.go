@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/deps"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateManifestInVanilla(t *testing.T) {
+	var tests = []struct {
+		manifest *model.Manifest
+		name     string
+		expected []string
+	}{
+		{
+			name: "supported manifest",
+			manifest: &model.Manifest{
+				Deploy: &model.DeployInfo{
+					Commands: []model.DeployCommand{{Command: "kubectl apply -f k8s"}},
+				},
+			},
+			expected: []string{},
+		},
+		{
+			name: "dependencies and divert",
+			manifest: &model.Manifest{
+				Dependencies: deps.ManifestSection{
+					"api": &deps.Dependency{Repository: "https://github.com/okteto/api"},
+				},
+				Deploy: &model.DeployInfo{
+					Divert: &model.DivertDeploy{Namespace: "staging"},
+				},
+			},
+			expected: []string{"dependencies", "deploy.divert"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getFeaturesNotSupportedInVanilla(tt.manifest))
+			err := validateManifestInVanilla(tt.manifest)
+			if len(tt.expected) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errFeaturesNotSupportedInVanilla)
+			}
+		})
+	}
+}
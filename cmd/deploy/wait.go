@@ -66,6 +66,15 @@ func (dw *Waiter) wait(ctx context.Context, opts *Options) error {
 	return nil
 }
 
+// waitForResources waits for the deployed resources to be running and records when the first wait started,
+// so the endpoint healthchecks only get what is left of --timeout
+func (dc *Command) waitForResources(ctx context.Context, opts *Options) error {
+	if dc.waitStartedAt.IsZero() {
+		dc.waitStartedAt = time.Now()
+	}
+	return dc.DeployWaiter.wait(ctx, opts)
+}
+
 func (dw *Waiter) waitForResourcesToBeRunning(ctx context.Context, opts *Options) error {
 	ticker := time.NewTicker(5 * time.Second)
 	to := time.NewTicker(opts.Timeout)
@@ -45,6 +45,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
+	"github.com/okteto/okteto/pkg/remote"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -99,6 +100,12 @@ type Options struct {
 	RunWithoutBash      bool
 	DestroyAll          bool
 	RunInRemote         bool
+	// RequireLocal fails the destroy if the commands would run remotely
+	RequireLocal bool
+	// RequireRemote fails the destroy if the commands can't run remotely
+	RequireRemote bool
+	// Where prints where each phase of the destroy runs, in the given format, without destroying
+	Where string
 }
 
 type destroyInterface interface {
@@ -141,6 +148,10 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, insights buildTr
 		Long:  `Destroy everything created by the 'okteto deploy' command. You can also include a 'destroy' section in your okteto manifest with a list of custom commands to be executed on destroy`,
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#destroy"),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := remote.ValidateWhereFormat(options.Where); err != nil {
+				return err
+			}
+
 			if options.ManifestPath != "" {
 				// if path is absolute, its transformed to rel from root
 				initialCWD, err := os.Getwd()
@@ -170,6 +181,10 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, insights buildTr
 				}
 			}
 
+			if options.Where != "" {
+				return showExecutionReport(options, afero.NewOsFs())
+			}
+
 			cwd, err := os.Getwd()
 			if err != nil {
 				return fmt.Errorf("failed to get the current working directory: %w", err)
@@ -186,6 +201,7 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, insights buildTr
 					return fmt.Errorf("could not infer environment name")
 				}
 			}
+			options.Name = utils.PerUserName(options.Name)
 
 			dynClient, _, err := okteto.GetDynamicClient()
 			if err != nil {
@@ -212,7 +228,7 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, insights buildTr
 				}
 			}
 			c := &destroyCommand{
-				executor:          executor.NewExecutor(oktetoLog.GetOutputFormat(), options.RunWithoutBash, ""),
+				executor:          executor.NewExecutor(oktetoLog.GetOutputFormat(), options.RunWithoutBash, "", false),
 				ConfigMapHandler:  NewConfigmapHandler(k8sClient),
 				nsDestroyer:       namespaces.NewNamespace(dynClient, discClient, cfg, k8sClient),
 				secrets:           secrets.NewSecrets(k8sClient),
@@ -252,6 +268,10 @@ func Destroy(ctx context.Context, at analyticsTrackerInterface, insights buildTr
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute commands without bash")
 	cmd.Flags().BoolVarP(&options.DestroyAll, "all", "", false, "destroy everything in the namespace")
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run destroy commands in remote")
+	cmd.Flags().BoolVarP(&options.RequireLocal, "require-local", "", false, "fail if the destroy commands would run remotely")
+	cmd.Flags().BoolVarP(&options.RequireRemote, "require-remote", "", false, "run the destroy commands in remote and fail if remote execution is not available")
+	cmd.Flags().StringVarP(&options.Where, "where", "", "", "show where each phase of the destroy runs and why, without destroying. One of: ['text', 'json']")
+	cmd.Flags().Lookup("where").NoOptDefVal = remote.WhereFormatText
 
 	return cmd
 }
@@ -263,28 +283,67 @@ func getTempKubeConfigFile(name string) string {
 }
 
 func shouldRunInRemote(opts *Options) bool {
-	// already in remote so we need to deploy locally
-	if env.LoadBoolean(constants.OktetoDeployRemote) {
-		return false
+	return remote.IsRemoteRequested(newExecutionRequest(opts))
+}
+
+// newExecutionRequest returns the information used to decide where the destroy commands run
+func newExecutionRequest(opts *Options) remote.ExecutionRequest {
+	req := remote.ExecutionRequest{
+		Command:       remote.DestroyCommand,
+		RemoteFlag:    opts.RunInRemote,
+		RequireLocal:  opts.RequireLocal,
+		RequireRemote: opts.RequireRemote,
 	}
+	if opts.Manifest != nil && opts.Manifest.Destroy != nil {
+		req.ManifestImage = opts.Manifest.Destroy.Image
+		req.ManifestRemote = opts.Manifest.Destroy.Remote
+	}
+	return req
+}
+
+// getExecutionReport returns where each phase of the destroy runs and why
+func getExecutionReport(opts *Options, isOkteto bool) (remote.ExecutionReport, error) {
+	report := remote.ExecutionReport{Command: remote.DestroyCommand}
 
-	// --remote flag enabled from command line
-	if opts.RunInRemote {
-		return true
+	if opts.DestroyDependencies && opts.Manifest.HasDependencies() {
+		report.Phases = append(report.Phases, remote.Phase{
+			Name:   "dependencies",
+			Where:  remote.ExecutionRemote,
+			Reason: "dependencies are destroyed as Okteto pipelines",
+		})
 	}
 
-	//  remote option set in the manifest via a remote destroyer image or the remote option enabled
-	if opts.Manifest != nil && opts.Manifest.Destroy != nil {
-		if opts.Manifest.Destroy.Image != "" || opts.Manifest.Destroy.Remote {
-			return true
+	if opts.Manifest.Destroy != nil && len(opts.Manifest.Destroy.Commands) > 0 {
+		req := newExecutionRequest(opts)
+		req.IsOkteto = isOkteto
+		commands, err := remote.ResolveCommandsExecution(req)
+		if err != nil {
+			return remote.ExecutionReport{}, err
 		}
+		report.Phases = append(report.Phases, commands)
 	}
 
-	if env.LoadBoolean(constants.OktetoForceRemote) {
-		return true
+	report.Phases = append(report.Phases, remote.Phase{
+		Name:   "resources",
+		Where:  remote.ExecutionLocal,
+		Reason: "the resources of the development environment are always destroyed from the okteto CLI",
+	})
+	return report, nil
+}
+
+// showExecutionReport prints where each phase of the destroy runs without destroying
+func showExecutionReport(opts *Options, fs afero.Fs) error {
+	manifest, err := model.GetManifestV2(opts.ManifestPath, fs)
+	if err != nil {
+		return err
 	}
+	opts.Manifest = manifest
 
-	return false
+	report, err := getExecutionReport(opts, okteto.GetContext().IsOkteto)
+	if err != nil {
+		return err
+	}
+	return report.Print(opts.Where)
 }
 
 // runDestroy runs the main logic of the destroy command
@@ -345,6 +404,10 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 	}
 
 	opts.Manifest = manifest
+	if _, err := getExecutionReport(opts, okteto.GetContext().IsOkteto); err != nil {
+		return err
+	}
+
 	if opts.Manifest.Destroy != nil {
 		if opts.Name == "" {
 			if opts.Manifest.Name == "" {
@@ -409,7 +472,9 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 	}
 	os.Setenv(constants.OktetoNameEnvVar, opts.Name)
 
-	if opts.DestroyDependencies {
+	if opts.DestroyDependencies && !okteto.GetContext().IsOkteto {
+		oktetoLog.Information("Skipping the destruction of dependencies: they are only supported in contexts with Okteto installed")
+	} else if opts.DestroyDependencies {
 		if err := dc.destroyDependencies(ctx, opts); err != nil {
 			if err := dc.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
 				return err
@@ -418,7 +483,7 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 		}
 	}
 
-	if hasDivert(opts.Manifest) {
+	if hasDivert(opts.Manifest) && okteto.GetContext().IsOkteto {
 		oktetoLog.SetStage("Destroy Divert")
 		if err := dc.destroyDivert(ctx, opts.Manifest); err != nil {
 			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error destroying divert: %s", err.Error())
@@ -428,11 +493,24 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 	}
 
 	var commandErr error
+	if err := dc.runDestroyHooks("pre-destroy hook", opts.Manifest.Destroy.GetPreHooks(), opts); err != nil {
+		if errors.Is(err, oktetoErrors.ErrIntSig) || !opts.ForceDestroy {
+			if err := dc.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
+				return err
+			}
+			return err
+		}
+		commandErr = err
+	}
+
 	// As the destroy only execute the commands within the destroy section, if there are no commands,
 	// it should be executed
 	if opts.Manifest.Destroy != nil && len(opts.Manifest.Destroy.Commands) > 0 {
 		// call to specific Destroy logic
-		destroyer := dc.getDestroyer(opts)
+		destroyer, err := dc.getDestroyer(opts)
+		if err != nil {
+			return err
+		}
 		if err := destroyer.Destroy(ctx, opts); err != nil {
 			// If there was an interruption in the execution, or it was an error, but it wasn't a force Destroy
 			// we have to change the status to err
@@ -464,6 +542,19 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 		return err
 	}
 
+	if hooks := opts.Manifest.Destroy.GetPostHooks(); len(hooks) > 0 {
+		oktetoLog.StopSpinner()
+		if err := dc.runDestroyHooks("post-destroy hook", hooks, opts); err != nil {
+			if errors.Is(err, oktetoErrors.ErrIntSig) || !opts.ForceDestroy {
+				if err := dc.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
+					return err
+				}
+				return err
+			}
+			commandErr = err
+		}
+	}
+
 	oktetoLog.SetStage("Destroying configmap")
 
 	if err := dc.ConfigMapHandler.destroyConfigMap(ctx, cfg, namespace); err != nil {
@@ -473,6 +564,17 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 	return commandErr
 }
 
+// runDestroyHooks runs the pre or post hooks of the destroy section. They always run locally, even if the destroy commands run remotely
+func (dc *destroyCommand) runDestroyHooks(kind string, hooks []model.DeployCommand, opts *Options) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+	runner := &deployable.HooksRunner{
+		Executor: dc.executor,
+	}
+	return runner.RunHooks(kind, hooks, opts.Variables)
+}
+
 func (dc *destroyCommand) destroyDependencies(ctx context.Context, opts *Options) error {
 	for depName, depInfo := range opts.Manifest.Dependencies {
 		oktetoLog.SetStage(fmt.Sprintf("Destroying dependency '%s'", depName))
@@ -588,21 +690,27 @@ func (dc *destroyCommand) destroyHelmReleasesIfPresent(ctx context.Context, opts
 	return nil
 }
 
-func (dc *destroyCommand) getDestroyer(opts *Options) destroyInterface {
-	var destroyer destroyInterface
+func (dc *destroyCommand) getDestroyer(opts *Options) (destroyInterface, error) {
+	req := newExecutionRequest(opts)
+	req.IsOkteto = okteto.GetContext().IsOkteto
+	phase, err := remote.ResolveCommandsExecution(req)
+	if err != nil {
+		return nil, err
+	}
 
+	if phase.Where == remote.ExecutionRemote {
+		oktetoLog.Information("Running the destroy commands remotely: %s", phase.Reason)
+		return newRemoteDestroyer(opts.Manifest, dc.ioCtrl), nil
+	}
 	if shouldRunInRemote(opts) {
-		destroyer = newRemoteDestroyer(opts.Manifest, dc.ioCtrl)
-		oktetoLog.Info("Destroying remotely...")
-	} else {
-		runner := &deployable.DestroyRunner{
-			Executor: dc.executor,
-		}
-		destroyer = newLocalDestroyer(runner)
-		oktetoLog.Info("Destroying locally...")
+		oktetoLog.WarningWithID(oktetoLog.WarnRemoteExecutionNotSupported, "remote execution is only supported in contexts with Okteto installed. Running your destroy commands locally")
 	}
 
-	return destroyer
+	runner := &deployable.DestroyRunner{
+		Executor: dc.executor,
+	}
+	oktetoLog.Info("Destroying locally...")
+	return newLocalDestroyer(runner), nil
 }
 
 func hasDivert(manifest *model.Manifest) bool {
@@ -29,6 +29,7 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/namespaces"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/remote"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -221,6 +222,15 @@ func TestDestroyWithErrorGettingManifestButDestroySuccess(t *testing.T) {
 
 func TestDestroyWithErrorDestroyingDependencies(t *testing.T) {
 	ctx := context.Background()
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "namespace",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
 	k8sClientProvider := test.NewFakeK8sProvider()
 	fakeClient, _, err := k8sClientProvider.Provide(api.NewConfig())
 	if err != nil {
@@ -258,6 +268,15 @@ func TestDestroyWithErrorDestroyingDependencies(t *testing.T) {
 
 func TestDestroyWithErrorDestroyingDivert(t *testing.T) {
 	ctx := context.Background()
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "namespace",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
 	k8sClientProvider := test.NewFakeK8sProvider()
 	fakeClient, _, err := k8sClientProvider.Provide(api.NewConfig())
 	if err != nil {
@@ -371,6 +390,71 @@ func TestDestroyWithErrorOnCommandsForcingDestroy(t *testing.T) {
 	require.True(t, okerrors.IsNotFound(err))
 }
 
+func TestDestroyWithHooks(t *testing.T) {
+	ctx := context.Background()
+	k8sClientProvider := test.NewFakeK8sProvider()
+	fakeClient, _, err := k8sClientProvider.Provide(api.NewConfig())
+	if err != nil {
+		t.Fatal("could not create fake k8s client")
+	}
+	manifest := &model.Manifest{
+		Name: "test-app",
+		Destroy: &model.DestroyInfo{
+			Commands: []model.DeployCommand{
+				{
+					Name:    "uninstall",
+					Command: "helm uninstall test-app",
+				},
+			},
+			Hooks: &model.Hooks{
+				Pre: []model.DeployCommand{
+					{
+						Name:    "backup",
+						Command: "make backup",
+					},
+				},
+				Post: []model.DeployCommand{
+					{
+						Name:    "cleanup",
+						Command: "make cleanup",
+					},
+				},
+			},
+		},
+	}
+	destroyer := &fakeDestroyer{}
+	executor := &fakeExecutor{}
+	dc := &destroyCommand{
+		getManifest: func(_ string, _ afero.Fs) (*model.Manifest, error) {
+			return manifest, nil
+		},
+		ConfigMapHandler:  NewConfigmapHandler(fakeClient),
+		nsDestroyer:       destroyer,
+		secrets:           &fakeSecretHandler{},
+		k8sClientProvider: k8sClientProvider,
+		executor:          executor,
+		buildCtrl: buildCtrl{
+			builder: fakeBuilderV2{
+				getSvcs: fakeGetSvcs{},
+				build:   nil,
+			},
+		},
+	}
+
+	err = dc.destroy(ctx, &Options{
+		Name:      manifest.Name,
+		Namespace: "namespace",
+	})
+
+	require.NoError(t, err)
+	require.True(t, destroyer.destroyed)
+	require.Equal(t, []model.DeployCommand{
+		manifest.Destroy.Hooks.Pre[0],
+		manifest.Destroy.Commands[0],
+		manifest.Destroy.Hooks.Post[0],
+	}, executor.executed)
+}
+
 func TestDestroyWithErrorDestroyingK8sResources(t *testing.T) {
 	ctx := context.Background()
 	k8sClientProvider := test.NewFakeK8sProvider()
@@ -753,12 +837,14 @@ func TestGetDestroyer(t *testing.T) {
 		expectedType interface{}
 		opts         *Options
 		name         string
+		isOkteto     bool
 	}{
 		{
 			name: "local",
 			opts: &Options{
 				RunInRemote: false,
 			},
+			isOkteto:     true,
 			expectedType: &localDestroyCommand{},
 		},
 		{
@@ -767,19 +853,87 @@ func TestGetDestroyer(t *testing.T) {
 				RunInRemote: true,
 				Manifest:    &model.Manifest{},
 			},
+			isOkteto:     true,
 			expectedType: &remoteDestroyCommand{},
 		},
+		{
+			name: "remote in a context without okteto",
+			opts: &Options{
+				RunInRemote: true,
+				Manifest:    &model.Manifest{},
+			},
+			expectedType: &localDestroyCommand{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			okteto.CurrentStore = &okteto.ContextStore{
+				Contexts: map[string]*okteto.Context{
+					"test": {
+						IsOkteto: tt.isOkteto,
+					},
+				},
+				CurrentContext: "test",
+			}
 			dc := &destroyCommand{}
-			deployer := dc.getDestroyer(tt.opts)
+			deployer, err := dc.getDestroyer(tt.opts)
+			require.NoError(t, err)
 			require.IsType(t, tt.expectedType, deployer)
 		})
 	}
 }
 
+func TestGetDestroyerWithRequireFlags(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {},
+		},
+		CurrentContext: "test",
+	}
+	dc := &destroyCommand{}
+
+	_, err := dc.getDestroyer(&Options{RequireRemote: true, Manifest: &model.Manifest{}})
+	require.Error(t, err)
+
+	_, err = dc.getDestroyer(&Options{RequireLocal: true, Manifest: &model.Manifest{Destroy: &model.DestroyInfo{Remote: true}}})
+	require.NoError(t, err)
+}
+
+func TestGetExecutionReportDestroy(t *testing.T) {
+	t.Setenv(constants.OktetoDeployRemote, "")
+	t.Setenv(constants.OktetoForceRemote, "")
+	opts := &Options{
+		DestroyDependencies: true,
+		Manifest: &model.Manifest{
+			Dependencies: map[string]*deps.Dependency{
+				"dep": &deps.Dependency{},
+			},
+			Destroy: &model.DestroyInfo{
+				Image: "okteto/bin",
+				Commands: []model.DeployCommand{
+					{Name: "cleanup", Command: "make clean"},
+				},
+			},
+		},
+	}
+
+	report, err := getExecutionReport(opts, true)
+	require.NoError(t, err)
+	require.Equal(t, remote.ExecutionReport{
+		Command: "destroy",
+		Phases: []remote.Phase{
+			{Name: "dependencies", Where: remote.ExecutionRemote, Reason: "dependencies are destroyed as Okteto pipelines"},
+			{Name: "commands", Where: remote.ExecutionRemote, Reason: "'destroy.image' is set in the okteto manifest"},
+			{Name: "resources", Where: remote.ExecutionLocal, Reason: "the resources of the development environment are always destroyed from the okteto CLI"},
+		},
+	}, report)
+
+	opts.RequireLocal = true
+	_, err = getExecutionReport(opts, true)
+	require.Error(t, err)
+}
+
 func TestDestroyHelmReleasesIfPresentWithErrorGettingSecrets(t *testing.T) {
 	secrets := &fakeSecretHandler{
 		err: assert.AnError,
@@ -16,6 +16,14 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
+	goio "io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
@@ -24,9 +32,13 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/supervisor"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 // doctorOptions refers to all the options that can be passed to Doctor command
@@ -48,36 +60,89 @@ func Doctor(k8sLogger *io.K8sLogger) *cobra.Command {
 			oktetoLog.Info("starting doctor command")
 			ctx := context.Background()
 
-			if okteto.InDevContainer() {
-				return oktetoErrors.ErrNotInDevContainer
+			dev, c, _, err := getDoctorDev(ctx, doctorOpts, args, "Select which development container's logs to download:", k8sLogger)
+			if err != nil {
+				return err
+			}
+			filename, err := doctor.Run(ctx, dev, doctorOpts.DevPath, c)
+			if err == nil {
+				oktetoLog.Information("Your doctor file is available at %s", filename)
 			}
+			analytics.TrackDoctor(err == nil)
+			return err
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&doctorOpts.DevPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
+	cmd.PersistentFlags().StringVarP(&doctorOpts.Namespace, "namespace", "n", "", "namespace where the up command was executing")
+	cmd.PersistentFlags().StringVarP(&doctorOpts.K8sContext, "context", "c", "", "context where the up command was executing")
+	cmd.AddCommand(syncBenchmark(doctorOpts, k8sLogger))
+	cmd.AddCommand(processes())
+	return cmd
+}
+
+// syncBenchmarkOptions refers to all the options that can be passed to the sync benchmark command
+type syncBenchmarkOptions struct {
+	SmallFiles    int
+	LargeFileSize int
+	Timeout       time.Duration
+}
+
+func syncBenchmark(doctorOpts *doctorOptions, k8sLogger *io.K8sLogger) *cobra.Command {
+	opts := &syncBenchmarkOptions{}
+	cmd := &cobra.Command{
+		Use:   "sync-benchmark [service]",
+		Short: "Measure the file synchronization performance of a running development container",
+		Long: `Measure the file synchronization performance of a running development container.
 
-			manifest, err := contextCMD.LoadManifestWithContext(ctx, contextCMD.ManifestOptions{Filename: doctorOpts.DevPath, Namespace: doctorOpts.Namespace, K8sContext: doctorOpts.K8sContext}, afero.NewOsFs())
+It writes small and large files to your first sync folder, measures how long it takes to synchronize them to the development container and suggests how to tune your okteto manifest.
+The results are included in a zip file with the okteto logs. 'okteto up' must be running`,
+		Args: utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/okteto-cli/#doctor"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.SmallFiles <= 0 || opts.LargeFileSize <= 0 {
+				return fmt.Errorf("'--small-files' and '--large-file-size' must be greater than 0")
+			}
+			ctx := context.Background()
+
+			dev, c, config, err := getDoctorDev(ctx, doctorOpts, args, "Select which development container to benchmark:", k8sLogger)
+			if err != nil {
+				return err
+			}
+			run, err := doctor.NewDevContainerRunner(ctx, dev, c, config)
 			if err != nil {
 				return err
 			}
 
-			c, _, err := okteto.GetK8sClientWithLogger(k8sLogger)
+			oktetoLog.StartSpinner()
+			result, err := doctor.RunSyncBenchmark(ctx, dev, run, doctor.SyncBenchmarkOptions{
+				SmallFiles:    opts.SmallFiles,
+				LargeFileSize: opts.LargeFileSize,
+				Timeout:       opts.Timeout,
+				PollInterval:  200 * time.Millisecond,
+			})
+			oktetoLog.StopSpinner()
 			if err != nil {
 				return err
 			}
 
-			devName := ""
-			if len(args) == 1 {
-				devName = args[0]
+			oktetoLog.Information("Sync benchmark results:")
+			oktetoLog.Printf("  - %d small files synchronized in %s (%.1f files/s)\n", result.SmallFiles.Files, result.SmallFiles.Latency, result.SmallFiles.FilesPerSecond)
+			oktetoLog.Printf("  - %dMB file synchronized in %s (%.1f MB/s)\n", result.LargeFile.SizeMB, result.LargeFile.Latency, result.LargeFile.Throughput)
+			if len(result.Suggestions) == 0 {
+				oktetoLog.Success("Your file synchronization is well tuned")
+			} else {
+				oktetoLog.Information("Suggestions:")
+				for _, s := range result.Suggestions {
+					oktetoLog.Printf("  - %s\n", s)
+				}
 			}
-			dev, err := utils.GetDevFromManifest(manifest, devName)
+
+			benchmarkPath, err := doctor.WriteSyncBenchmarkFile(result)
 			if err != nil {
-				if !errors.Is(err, utils.ErrNoDevSelected) {
-					return err
-				}
-				selector := utils.NewOktetoSelector("Select which development container's logs to download:", "Development container")
-				dev, err = utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
-				if err != nil {
-					return err
-				}
+				return err
 			}
-			filename, err := doctor.Run(ctx, dev, doctorOpts.DevPath, c)
+			defer os.RemoveAll(filepath.Dir(benchmarkPath))
+
+			filename, err := doctor.Run(ctx, dev, doctorOpts.DevPath, c, benchmarkPath)
 			if err == nil {
 				oktetoLog.Information("Your doctor file is available at %s", filename)
 			}
@@ -85,8 +150,90 @@ func Doctor(k8sLogger *io.K8sLogger) *cobra.Command {
 			return err
 		},
 	}
-	cmd.Flags().StringVarP(&doctorOpts.DevPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
-	cmd.Flags().StringVarP(&doctorOpts.Namespace, "namespace", "n", "", "namespace where the up command was executing")
-	cmd.Flags().StringVarP(&doctorOpts.K8sContext, "context", "c", "", "context where the up command was executing")
+	cmd.Flags().IntVarP(&opts.SmallFiles, "small-files", "", 100, "number of small files to synchronize")
+	cmd.Flags().IntVarP(&opts.LargeFileSize, "large-file-size", "", 10, "size in MB of the large file to synchronize")
+	cmd.Flags().DurationVarP(&opts.Timeout, "timeout", "t", 2*time.Minute, "maximum time to wait for each set of files to be synchronized")
+	return cmd
+}
+
+func processes() *cobra.Command {
+	clean := false
+	cmd := &cobra.Command{
+		Use:   "processes",
+		Short: "List the helper processes started by okteto in your machine",
+		Long: `List the helper processes started by okteto in your machine.
+
+Processes whose 'okteto up' session is no longer running are reported as orphan, and automatically cleaned up the next time 'okteto up' runs.
+Use '--clean' to terminate them and remove their temporary directories now`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#doctor"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := supervisor.Default()
+			if clean {
+				cleaned, err := s.CleanOrphans()
+				if err != nil {
+					return err
+				}
+				for _, p := range cleaned {
+					oktetoLog.Success("Cleaned %s process %d from '%s'", p.Name, p.PID, p.Session)
+				}
+			}
+
+			list := s.List()
+			if len(list) == 0 {
+				oktetoLog.Information("There are no okteto helper processes running")
+				return nil
+			}
+			printProcesses(os.Stdout, list)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&clean, "clean", "", false, "terminate the orphan processes and remove their temporary directories")
 	return cmd
 }
+
+func printProcesses(out goio.Writer, list []supervisor.Process) {
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Name\tPID\tSession\tOwner PID\tPorts\tStatus\tStarted\n")
+	for _, p := range list {
+		ports := make([]string, 0, len(p.Ports))
+		for _, port := range p.Ports {
+			ports = append(ports, strconv.Itoa(port))
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%s\t%s\t%s\n", p.Name, p.PID, p.Session, p.OwnerPID, strings.Join(ports, ","), p.Status, p.StartedAt.Format(time.RFC3339))
+	}
+	w.Flush()
+}
+
+// getDoctorDev loads the okteto manifest and returns the development container selected by args
+func getDoctorDev(ctx context.Context, doctorOpts *doctorOptions, args []string, selectorTitle string, k8sLogger *io.K8sLogger) (*model.Dev, *kubernetes.Clientset, *rest.Config, error) {
+	if okteto.InDevContainer() {
+		return nil, nil, nil, oktetoErrors.ErrNotInDevContainer
+	}
+
+	manifest, err := contextCMD.LoadManifestWithContext(ctx, contextCMD.ManifestOptions{Filename: doctorOpts.DevPath, Namespace: doctorOpts.Namespace, K8sContext: doctorOpts.K8sContext}, afero.NewOsFs())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	c, config, err := okteto.GetK8sClientWithLogger(k8sLogger)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	devName := ""
+	if len(args) == 1 {
+		devName = args[0]
+	}
+	dev, err := utils.GetDevFromManifest(manifest, devName)
+	if err != nil {
+		if !errors.Is(err, utils.ErrNoDevSelected) {
+			return nil, nil, nil, err
+		}
+		selector := utils.NewOktetoSelector(selectorTitle, "Development container")
+		dev, err = utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return dev, c, config, nil
+}
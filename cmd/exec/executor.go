@@ -128,6 +128,7 @@ func (s *sshExecutor) execute(ctx context.Context, cmd []string) error {
 		defaultStdin,
 		defaultStdout,
 		defaultStderr,
+		ssh.Forwards{X11: s.dev.X11, Clipboard: s.dev.Clipboard},
 		cmd)
 }
 
@@ -0,0 +1,222 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/inspect"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const docsURL = "https://www.okteto.com/docs/reference/okteto-cli/#inspect"
+
+// Options represents the user input of the inspect command
+type Options struct {
+	output string
+	port   int
+	last   int
+	uiPort int
+	ui     bool
+}
+
+// Inspect reviews the HTTP traffic recorded on the forwards defined with 'inspect: true'
+func Inspect(ctx context.Context) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "inspect [id]",
+		Short: "Review the HTTP requests sent to the forwards of your development containers",
+		Long: `Review the HTTP requests sent to the forwards of your development containers.
+
+Requests are recorded on the forwards defined with 'inspect: true' while 'okteto up' is running.
+Run 'okteto inspect' to list the last requests, 'okteto inspect <id>' to see the headers and bodies of a request,
+or 'okteto inspect --ui' to review them on your browser.`,
+		Args: utils.MaximumNArgsAccepted(1, docsURL),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			fs := afero.NewOsFs()
+			folder := inspect.GetRecordsFolder()
+			if options.ui {
+				return serveUI(ctx, fs, folder, options.uiPort)
+			}
+			records, err := inspect.ReadRecords(fs, folder, options.port)
+			if err != nil {
+				return fmt.Errorf("failed to read the inspected requests: %w", err)
+			}
+			if len(args) == 1 {
+				return showRecord(records, args[0], options.output, os.Stdout)
+			}
+			return listRecords(records, options, os.Stdout)
+		},
+	}
+	cmd.Flags().IntVarP(&options.port, "port", "p", 0, "only show the requests of a local port")
+	cmd.Flags().IntVar(&options.last, "last", 20, "number of requests to show, 0 shows all of them")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	cmd.Flags().BoolVar(&options.ui, "ui", false, "serve a local web UI to review the requests")
+	cmd.Flags().IntVar(&options.uiPort, "ui-port", 0, "local port of the web UI (defaults to a random port)")
+	return cmd
+}
+
+func (o *Options) validate() error {
+	switch o.output {
+	case "", "json", "yaml":
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	}
+	if o.last < 0 {
+		return fmt.Errorf("the value of '--last' must be positive")
+	}
+	if o.ui && o.output != "" {
+		return fmt.Errorf("'--ui' and '--output' can't be used at the same time")
+	}
+	return nil
+}
+
+func listRecords(records []inspect.Record, options *Options, w io.Writer) error {
+	if options.last > 0 && len(records) > options.last {
+		records = records[len(records)-options.last:]
+	}
+
+	switch options.output {
+	case "json":
+		return writeJSON(records, w)
+	case "yaml":
+		return writeYAML(records, w)
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(w, "There are no inspected requests. Define 'inspect: true' in the forwards of your okteto manifest and run 'okteto up'")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"ID", "Time", "Method", "URL", "Status", "Duration"}, "\t"))
+	for _, r := range records {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.Time.Local().Format(time.TimeOnly), r.Method, r.URL, getStatus(r), r.Duration.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
+
+func showRecord(records []inspect.Record, id, output string, w io.Writer) error {
+	for _, r := range records {
+		if r.ID != id {
+			continue
+		}
+		switch output {
+		case "json":
+			return writeJSON(r, w)
+		case "yaml":
+			return writeYAML(r, w)
+		}
+
+		fmt.Fprintf(w, "%s %s -> %s (%s)\n", r.Method, r.URL, getStatus(r), r.Duration.Round(time.Millisecond))
+		if r.Error != "" {
+			fmt.Fprintf(w, "Error: %s\n", r.Error)
+		}
+		fmt.Fprintln(w, "\nRequest headers:")
+		writeHeaders(r.RequestHeaders, w)
+		if r.RequestBody != "" {
+			fmt.Fprintf(w, "\nRequest body:\n%s\n", r.RequestBody)
+		}
+		fmt.Fprintln(w, "\nResponse headers:")
+		writeHeaders(r.ResponseHeaders, w)
+		if r.ResponseBody != "" {
+			fmt.Fprintf(w, "\nResponse body:\n%s\n", r.ResponseBody)
+		}
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("request '%s' not found", id),
+		Hint: "Run 'okteto inspect' to list the inspected requests",
+	}
+}
+
+func getStatus(r inspect.Record) string {
+	if r.Status == 0 {
+		return "-"
+	}
+	return strconv.Itoa(r.Status)
+}
+
+func writeHeaders(headers http.Header, w io.Writer) {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %s: %s\n", k, strings.Join(headers[k], ", "))
+	}
+}
+
+func writeJSON(v interface{}, w io.Writer) error {
+	bytes, err := json.MarshalIndent(v, "", " ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, string(bytes))
+	return nil
+}
+
+func writeYAML(v interface{}, w io.Writer) error {
+	bytes, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(w, string(bytes))
+	return nil
+}
+
+// serveUI serves the web UI on localhost until the command is interrupted
+func serveUI(ctx context.Context, fs afero.Fs, folder string, port int) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to serve the inspect UI: %w", err)
+	}
+	server := &http.Server{
+		Handler:           inspect.NewUIHandler(fs, folder),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			oktetoLog.Infof("failed to close the inspect UI: %s", err)
+		}
+	}()
+
+	oktetoLog.Success("Inspect UI available at http://%s", listener.Addr().String())
+	oktetoLog.Information("Press Ctrl+C to stop it")
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/inspect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getRecords() []inspect.Record {
+	now := time.Now()
+	return []inspect.Record{
+		{
+			ID:     "8080-1",
+			Time:   now,
+			Method: http.MethodGet,
+			URL:    "/api/movies",
+			Status: http.StatusOK,
+		},
+		{
+			ID:             "8080-2",
+			Time:           now.Add(time.Second),
+			Method:         http.MethodPost,
+			URL:            "/api/rentals",
+			Status:         http.StatusBadRequest,
+			RequestHeaders: http.Header{"Content-Type": []string{"application/json"}},
+			RequestBody:    `{"movie":"1"}`,
+			ResponseBody:   "invalid movie",
+		},
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	assert.NoError(t, (&Options{output: "json"}).validate())
+	assert.Error(t, (&Options{output: "table"}).validate())
+	assert.Error(t, (&Options{last: -1}).validate())
+	assert.Error(t, (&Options{ui: true, output: "yaml"}).validate())
+}
+
+func TestListRecords(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, listRecords(getRecords(), &Options{last: 1}, &buf))
+	assert.Contains(t, buf.String(), "8080-2")
+	assert.NotContains(t, buf.String(), "8080-1")
+
+	buf.Reset()
+	require.NoError(t, listRecords(nil, &Options{}, &buf))
+	assert.Contains(t, buf.String(), "There are no inspected requests")
+}
+
+func TestShowRecord(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, showRecord(getRecords(), "8080-2", "", &buf))
+	assert.Contains(t, buf.String(), "POST /api/rentals -> 400")
+	assert.Contains(t, buf.String(), "Content-Type: application/json")
+	assert.Contains(t, buf.String(), `{"movie":"1"}`)
+	assert.Contains(t, buf.String(), "invalid movie")
+
+	err := showRecord(getRecords(), "3000-1", "", &buf)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
@@ -0,0 +1,369 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// fromSnapshotNameAnnotation tells Okteto to initialize a volume from the given volume snapshot
+	fromSnapshotNameAnnotation = "dev.okteto.com/from-snapshot-name"
+
+	// fromSnapshotNamespaceAnnotation is the namespace of the volume snapshot used to initialize a volume
+	fromSnapshotNamespaceAnnotation = "dev.okteto.com/from-snapshot-namespace"
+
+	helmReleaseSecretType = "helm.sh/release.v1"
+
+	kubeRootCAConfigMap = "kube-root-ca.crt"
+)
+
+var volumeSnapshotGVR = schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}
+
+// CloneOptions represents the options that namespace clone has
+type CloneOptions struct {
+	Source       string
+	Destination  string
+	Timeout      time.Duration
+	Volumes      bool
+	Wait         bool
+	SetCurrentNs bool
+}
+
+// Clone clones the okteto managed resources of a namespace into a new namespace
+func Clone(ctx context.Context) *cobra.Command {
+	options := &CloneOptions{}
+	cmd := &cobra.Command{
+		Use:   "clone <source> <destination>",
+		Short: "Clone the okteto managed resources of a namespace into a new namespace",
+		Long: `Clone the okteto managed resources of a namespace into a new namespace.
+
+Secrets and configmaps are copied, renaming references to the source namespace in their names.
+Pipelines are redeployed from the same repository, branch, manifest and variables.
+Volumes are cloned from volume snapshots when --volumes is set.`,
+		Args: utils.ExactArgsAccepted(2, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{}); err != nil {
+				return err
+			}
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+			options.Source = args[0]
+			options.Destination = withNamespacePrefix(args[1], config.GetSetting(okteto.GetContext().Name, config.NamespacePrefixSetting))
+
+			nsCmd, err := NewCommand()
+			if err != nil {
+				return err
+			}
+			err = nsCmd.Clone(ctx, options)
+			analytics.TrackCloneNamespace(err == nil, options.Volumes)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Volumes, "volumes", "", false, "clone the volumes of the source namespace using volume snapshots")
+	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the pipelines are redeployed in the new namespace")
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", 5*time.Minute, "the length of time to wait for each pipeline, zero means never")
+	cmd.Flags().BoolVarP(&options.SetCurrentNs, "use", "", false, "use the new namespace as the current namespace")
+	return cmd
+}
+
+// Clone creates the destination namespace and clones the okteto managed resources of the source namespace into it
+func (nc *Command) Clone(ctx context.Context, opts *CloneOptions) error {
+	if opts.Source == opts.Destination {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the source and destination namespaces are the same"),
+			Hint: "Choose a different name for the new namespace",
+		}
+	}
+
+	c, _, err := nc.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := c.CoreV1().Namespaces().Get(ctx, opts.Source, metav1.GetOptions{}); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("namespace '%s' not found", opts.Source),
+				Hint: "Run 'okteto namespace list' to see the namespaces you have access to",
+			}
+		}
+		return err
+	}
+
+	var dc dynamic.Interface
+	if opts.Volumes {
+		dc, _, err = okteto.GetDynamicClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := nc.Create(ctx, &CreateOptions{Namespace: opts.Destination, SetCurrentNs: opts.SetCurrentNs}); err != nil {
+		return err
+	}
+
+	cloner := &namespaceCloner{
+		c:        c,
+		dc:       dc,
+		pipeline: nc.okClient.Pipeline(),
+		opts:     opts,
+	}
+	if err := cloner.clone(ctx); err != nil {
+		return fmt.Errorf("failed to clone namespace '%s': %w", opts.Source, err)
+	}
+
+	oktetoLog.Success("Namespace '%s' cloned into '%s'", opts.Source, opts.Destination)
+	return nil
+}
+
+// namespaceCloner copies the resources from the source namespace to the destination namespace
+type namespaceCloner struct {
+	c        kubernetes.Interface
+	dc       dynamic.Interface
+	pipeline types.PipelineInterface
+	opts     *CloneOptions
+}
+
+func (nc *namespaceCloner) clone(ctx context.Context) error {
+	if err := nc.cloneConfigMaps(ctx); err != nil {
+		return err
+	}
+	if err := nc.cloneSecrets(ctx); err != nil {
+		return err
+	}
+	if nc.opts.Volumes {
+		if err := nc.cloneVolumes(ctx); err != nil {
+			return err
+		}
+	}
+	return nc.redeployPipelines(ctx)
+}
+
+// rename replaces the references to the source namespace in a resource name.
+// Only full segments of the name are replaced, so short namespace names don't rename unrelated resources
+func (nc *namespaceCloner) rename(name string) string {
+	re := regexp.MustCompile(fmt.Sprintf(`(^|[-.])%s($|[-.])`, regexp.QuoteMeta(nc.opts.Source)))
+	return re.ReplaceAllString(name, fmt.Sprintf("${1}%s${2}", nc.opts.Destination))
+}
+
+// cloneObjectMeta returns the metadata for the copy of a resource in the destination namespace
+func (nc *namespaceCloner) cloneObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        nc.rename(meta.Name),
+		Namespace:   nc.opts.Destination,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+// cloneConfigMaps copies the configmaps of the source namespace. Pipeline configmaps are skipped
+// because they are created again when the pipelines are redeployed
+func (nc *namespaceCloner) cloneConfigMaps(ctx context.Context) error {
+	cmaps, err := nc.c.CoreV1().ConfigMaps(nc.opts.Source).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, cmap := range cmaps.Items {
+		if cmap.Name == kubeRootCAConfigMap || cmap.Labels[model.GitDeployLabel] != "" || len(cmap.OwnerReferences) > 0 {
+			continue
+		}
+		clone := &apiv1.ConfigMap{
+			ObjectMeta: nc.cloneObjectMeta(cmap.ObjectMeta),
+			Data:       cmap.Data,
+			BinaryData: cmap.BinaryData,
+		}
+		if _, err := nc.c.CoreV1().ConfigMaps(nc.opts.Destination).Create(ctx, clone, metav1.CreateOptions{}); err != nil {
+			if k8sErrors.IsAlreadyExists(err) {
+				oktetoLog.Infof("configmap '%s' already exists in namespace '%s'", clone.Name, nc.opts.Destination)
+				continue
+			}
+			return fmt.Errorf("failed to copy configmap '%s': %w", cmap.Name, err)
+		}
+		oktetoLog.Success("Configmap '%s' copied as '%s'", cmap.Name, clone.Name)
+	}
+	return nil
+}
+
+// cloneSecrets copies the secrets of the source namespace. Service account tokens and helm releases are skipped
+// because they are generated again by Kubernetes and by the redeployed pipelines
+func (nc *namespaceCloner) cloneSecrets(ctx context.Context) error {
+	secrets, err := nc.c.CoreV1().Secrets(nc.opts.Source).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets.Items {
+		if secret.Type == apiv1.SecretTypeServiceAccountToken || secret.Type == helmReleaseSecretType || len(secret.OwnerReferences) > 0 {
+			continue
+		}
+		clone := &apiv1.Secret{
+			ObjectMeta: nc.cloneObjectMeta(secret.ObjectMeta),
+			Type:       secret.Type,
+			Data:       secret.Data,
+		}
+		if _, err := nc.c.CoreV1().Secrets(nc.opts.Destination).Create(ctx, clone, metav1.CreateOptions{}); err != nil {
+			if k8sErrors.IsAlreadyExists(err) {
+				oktetoLog.Infof("secret '%s' already exists in namespace '%s'", clone.Name, nc.opts.Destination)
+				continue
+			}
+			return fmt.Errorf("failed to copy secret '%s': %w", secret.Name, err)
+		}
+		oktetoLog.Success("Secret '%s' copied as '%s'", secret.Name, clone.Name)
+	}
+	return nil
+}
+
+// cloneVolumes takes a snapshot of every volume of the source namespace and creates a volume
+// in the destination namespace initialized from it
+func (nc *namespaceCloner) cloneVolumes(ctx context.Context) error {
+	pvcs, err := nc.c.CoreV1().PersistentVolumeClaims(nc.opts.Source).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, pvc := range pvcs.Items {
+		snapshotName := fmt.Sprintf("%s-clone-%d", pvc.Name, time.Now().Unix())
+		snapshot := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "snapshot.storage.k8s.io/v1",
+				"kind":       "VolumeSnapshot",
+				"metadata": map[string]interface{}{
+					"name":      snapshotName,
+					"namespace": nc.opts.Source,
+				},
+				"spec": map[string]interface{}{
+					"source": map[string]interface{}{
+						"persistentVolumeClaimName": pvc.Name,
+					},
+				},
+			},
+		}
+		if _, err := nc.dc.Resource(volumeSnapshotGVR).Namespace(nc.opts.Source).Create(ctx, snapshot, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to snapshot volume '%s': %w", pvc.Name, err)
+		}
+
+		meta := nc.cloneObjectMeta(pvc.ObjectMeta)
+		annotations := map[string]string{}
+		for k, v := range meta.Annotations {
+			// annotations set by Kubernetes when the volume is bound must not be copied
+			if strings.HasPrefix(k, "pv.kubernetes.io/") || strings.HasPrefix(k, "volume.") {
+				continue
+			}
+			annotations[k] = v
+		}
+		annotations[fromSnapshotNameAnnotation] = snapshotName
+		annotations[fromSnapshotNamespaceAnnotation] = nc.opts.Source
+		meta.Annotations = annotations
+
+		clone := &apiv1.PersistentVolumeClaim{
+			ObjectMeta: meta,
+			Spec: apiv1.PersistentVolumeClaimSpec{
+				AccessModes:      pvc.Spec.AccessModes,
+				Resources:        pvc.Spec.Resources,
+				StorageClassName: pvc.Spec.StorageClassName,
+				VolumeMode:       pvc.Spec.VolumeMode,
+			},
+		}
+		if _, err := nc.c.CoreV1().PersistentVolumeClaims(nc.opts.Destination).Create(ctx, clone, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to clone volume '%s': %w", pvc.Name, err)
+		}
+		oktetoLog.Success("Volume '%s' cloned as '%s'", pvc.Name, clone.Name)
+	}
+	return nil
+}
+
+// redeployPipelines deploys the pipelines of the source namespace in the destination namespace
+// with the same repository, branch, manifest, variables and labels
+func (nc *namespaceCloner) redeployPipelines(ctx context.Context) error {
+	cmaps, err := configmaps.List(ctx, nc.opts.Source, model.GitDeployLabel, nc.c)
+	if err != nil {
+		return err
+	}
+	for _, cmap := range cmaps {
+		opts, err := nc.getPipelineDeployOptions(cmap)
+		if err != nil {
+			oktetoLog.Warning("Skipping pipeline '%s': %s", cmap.Data["name"], err)
+			continue
+		}
+
+		resp, err := nc.pipeline.Deploy(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to redeploy pipeline '%s': %w", opts.Name, err)
+		}
+		if !nc.opts.Wait {
+			oktetoLog.Success("Pipeline '%s' scheduled for deployment", opts.Name)
+			continue
+		}
+
+		oktetoLog.Spinner(fmt.Sprintf("Waiting for pipeline '%s' to be deployed...", opts.Name))
+		oktetoLog.StartSpinner()
+		err = nc.pipeline.WaitForActionToFinish(ctx, opts.Name, opts.Namespace, resp.Action.Name, nc.opts.Timeout)
+		oktetoLog.StopSpinner()
+		if err != nil {
+			return fmt.Errorf("failed to redeploy pipeline '%s': %w", opts.Name, err)
+		}
+		oktetoLog.Success("Pipeline '%s' deployed", opts.Name)
+	}
+	return nil
+}
+
+// getPipelineDeployOptions returns the options to redeploy the pipeline stored in a configmap
+func (nc *namespaceCloner) getPipelineDeployOptions(cmap apiv1.ConfigMap) (types.PipelineDeployOptions, error) {
+	if cmap.Data["repository"] == "" {
+		return types.PipelineDeployOptions{}, fmt.Errorf("it was not deployed from a git repository")
+	}
+
+	opts := types.PipelineDeployOptions{
+		Name:       cmap.Data["name"],
+		Repository: cmap.Data["repository"],
+		Branch:     cmap.Data["branch"],
+		Commit:     cmap.Data["commit"],
+		Filename:   cmap.Data["filename"],
+		Namespace:  nc.opts.Destination,
+	}
+	for _, v := range types.DecodeStringToDeployVariable(cmap.Data["variables"]) {
+		opts.Variables = append(opts.Variables, types.Variable{Name: v.Name, Value: v.Value})
+	}
+	prefix := fmt.Sprintf("%s/", constants.EnvironmentLabelKeyPrefix)
+	for k, v := range cmap.Labels {
+		if strings.HasPrefix(k, prefix) && v == "true" {
+			opts.Labels = append(opts.Labels, strings.TrimPrefix(k, prefix))
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNamespaceClonerClone(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings-cindy", Namespace: "cindy", Labels: map[string]string{"app": "api"}},
+			Data:       map[string]string{"key": "value"},
+		},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: kubeRootCAConfigMap, Namespace: "cindy"},
+		},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "okteto-git-movies",
+				Namespace: "cindy",
+				Labels: map[string]string{
+					model.GitDeployLabel:                             "true",
+					constants.EnvironmentLabelKeyPrefix + "/backend": "true",
+				},
+			},
+			Data: map[string]string{
+				"name":       "movies",
+				"repository": "https://github.com/okteto/movies",
+				"branch":     "main",
+				"commit":     "123456",
+				"filename":   "okteto.yml",
+				"variables":  "W3sibmFtZSI6IktFWSIsInZhbHVlIjoidmFsdWUifV0=",
+			},
+		},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "okteto-git-local",
+				Namespace: "cindy",
+				Labels:    map[string]string{model.GitDeployLabel: "true"},
+			},
+			Data: map[string]string{"name": "local"},
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "cindy"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sh.helm.release.v1.movies.v1", Namespace: "cindy"},
+			Type:       helmReleaseSecretType,
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "data",
+				Namespace:   "cindy",
+				Annotations: map[string]string{"pv.kubernetes.io/bind-completed": "yes", "owner": "api"},
+			},
+			Spec: apiv1.PersistentVolumeClaimSpec{
+				AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+				VolumeName: "pv-123",
+			},
+		},
+	)
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{volumeSnapshotGVR: "VolumeSnapshotList"},
+	)
+	responses := &client.FakePipelineResponses{
+		DeployResponse: &types.GitDeployResponse{Action: &types.Action{Name: "action"}},
+	}
+	cloner := &namespaceCloner{
+		c:        c,
+		dc:       dc,
+		pipeline: client.NewFakePipelineClient(responses),
+		opts: &CloneOptions{
+			Source:      "cindy",
+			Destination: "cindy-debug",
+			Volumes:     true,
+			Wait:        true,
+		},
+	}
+
+	require.NoError(t, cloner.clone(ctx))
+
+	cmap, err := c.CoreV1().ConfigMaps("cindy-debug").Get(ctx, "settings-cindy-debug", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value"}, cmap.Data)
+	assert.Equal(t, map[string]string{"app": "api"}, cmap.Labels)
+	cmaps, err := c.CoreV1().ConfigMaps("cindy-debug").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, cmaps.Items, 1)
+
+	secrets, err := c.CoreV1().Secrets("cindy-debug").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, secrets.Items, 1)
+	assert.Equal(t, "db-credentials", secrets.Items[0].Name)
+
+	pvc, err := c.CoreV1().PersistentVolumeClaims("cindy-debug").Get(ctx, "data", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "cindy", pvc.Annotations[fromSnapshotNamespaceAnnotation])
+	assert.Equal(t, "api", pvc.Annotations["owner"])
+	assert.NotContains(t, pvc.Annotations, "pv.kubernetes.io/bind-completed")
+	assert.Empty(t, pvc.Spec.VolumeName)
+	snapshots, err := dc.Resource(volumeSnapshotGVR).Namespace("cindy").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, snapshots.Items, 1)
+	assert.Equal(t, snapshots.Items[0].GetName(), pvc.Annotations[fromSnapshotNameAnnotation])
+
+	assert.Equal(t, 1, responses.CallCount)
+	assert.Equal(t, types.PipelineDeployOptions{
+		Name:       "movies",
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Commit:     "123456",
+		Filename:   "okteto.yml",
+		Namespace:  "cindy-debug",
+		Variables:  []types.Variable{{Name: "KEY", Value: "value"}},
+		Labels:     []string{"backend"},
+	}, responses.DeployOpts)
+}
+
+func TestNamespaceClonerRename(t *testing.T) {
+	cloner := &namespaceCloner{opts: &CloneOptions{Source: "cindy", Destination: "cindy-debug"}}
+	assert.Equal(t, "cindy-debug", cloner.rename("cindy"))
+	assert.Equal(t, "settings-cindy-debug", cloner.rename("settings-cindy"))
+	assert.Equal(t, "cindy-debug.tls", cloner.rename("cindy.tls"))
+	assert.Equal(t, "cindyapi", cloner.rename("cindyapi"))
+
+	cloner = &namespaceCloner{opts: &CloneOptions{Source: "a", Destination: "b"}}
+	assert.Equal(t, "data", cloner.rename("data"))
+	assert.Equal(t, "api-b-tls", cloner.rename("api-a-tls"))
+}
+
+func TestNamespaceClonerPipelineError(t *testing.T) {
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "okteto-git-movies",
+			Namespace: "cindy",
+			Labels:    map[string]string{model.GitDeployLabel: "true"},
+		},
+		Data: map[string]string{"name": "movies", "repository": "https://github.com/okteto/movies"},
+	})
+	cloner := &namespaceCloner{
+		c: c,
+		pipeline: client.NewFakePipelineClient(&client.FakePipelineResponses{
+			DeployErr: assert.AnError,
+		}),
+		opts: &CloneOptions{Source: "cindy", Destination: "cindy-debug"},
+	}
+
+	err := cloner.clone(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestCloneValidations(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {Name: "test", Token: "test", IsOkteto: true},
+		},
+		CurrentContext: "test",
+	}
+	usr := &types.User{Token: "test"}
+	okClient := &client.FakeOktetoClient{
+		Namespace: client.NewFakeNamespaceClient([]types.Namespace{{ID: "test"}}, nil),
+		Users:     client.NewFakeUsersClient(usr),
+	}
+	nsCmd := NewFakeNamespaceCommand(okClient, fake.NewSimpleClientset(), usr)
+
+	var userErr oktetoErrors.UserError
+	err := nsCmd.Clone(context.Background(), &CloneOptions{Source: "cindy", Destination: "cindy"})
+	require.ErrorAs(t, err, &userErr)
+	assert.Equal(t, "the source and destination namespaces are the same", userErr.E.Error())
+
+	err = nsCmd.Clone(context.Background(), &CloneOptions{Source: "cindy", Destination: "cindy-debug"})
+	require.ErrorAs(t, err, &userErr)
+	assert.Equal(t, "namespace 'cindy' not found", userErr.E.Error())
+}
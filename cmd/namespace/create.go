@@ -21,6 +21,7 @@ import (
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -47,10 +48,10 @@ func Create(ctx context.Context) *cobra.Command {
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{}); err != nil {
 				return err
 			}
-			options.Namespace = args[0]
 			if !okteto.IsOkteto() {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
+			options.Namespace = withNamespacePrefix(args[0], config.GetSetting(okteto.GetContext().Name, config.NamespacePrefixSetting))
 			nsCmd, err := NewCommand()
 			if err != nil {
 				return err
@@ -67,6 +68,14 @@ func Create(ctx context.Context) *cobra.Command {
 	return cmd
 }
 
+// withNamespacePrefix adds the namespace prefix of the user configuration to name if it doesn't have it already
+func withNamespacePrefix(name, prefix string) string {
+	if prefix == "" || strings.HasPrefix(name, prefix) {
+		return name
+	}
+	return prefix + name
+}
+
 func (nc *Command) Create(ctx context.Context, opts *CreateOptions) error {
 	oktetoNS, err := nc.okClient.Namespaces().Create(ctx, opts.Namespace)
 	if err != nil {
@@ -80,3 +80,9 @@ func Test_createNamespace(t *testing.T) {
 		})
 	}
 }
+
+func Test_withNamespacePrefix(t *testing.T) {
+	assert.Equal(t, "api", withNamespacePrefix("api", ""))
+	assert.Equal(t, "cindy-api", withNamespacePrefix("api", "cindy-"))
+	assert.Equal(t, "cindy-api", withNamespacePrefix("cindy-api", "cindy-"))
+}
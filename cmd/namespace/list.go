@@ -23,16 +23,21 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 // List all namespace in current context
 func List(ctx context.Context) *cobra.Command {
-	return &cobra.Command{
+	page := &utils.ListPage{}
+	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List namespaces managed by Okteto in your current context",
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := page.Validate(); err != nil {
+				return err
+			}
 
 			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{}); err != nil {
 				return err
@@ -46,18 +51,24 @@ func List(ctx context.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			err = nsCmd.executeListNamespaces(ctx)
+			err = nsCmd.executeListNamespaces(ctx, *page, utils.NewListCache())
 			return err
 		},
 		Args: utils.NoArgsAccepted(""),
 	}
+	page.AddFlags(cmd.Flags())
+	return cmd
 }
 
-func (nc *Command) executeListNamespaces(ctx context.Context) error {
-	spaces, err := nc.okClient.Namespaces().List(ctx)
+func (nc *Command) executeListNamespaces(ctx context.Context, page utils.ListPage, cache *utils.ListCache) error {
+	key := utils.ListCacheKey("namespaces", okteto.GetContext().Name)
+	spaces, err := utils.FetchList(cache, key, page, func() ([]types.Namespace, error) {
+		return nc.okClient.Namespaces().List(ctx)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get namespaces: %w", err)
 	}
+	spaces = utils.Paginate(spaces, page)
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
 	fmt.Fprintf(w, "Namespace\tStatus\n")
 	for _, space := range spaces {
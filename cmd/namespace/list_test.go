@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
@@ -73,7 +74,7 @@ func Test_listNamespace(t *testing.T) {
 				okClient: fakeOktetoClient,
 				ctxCmd:   newFakeContextCommand(fakeOktetoClient, usr),
 			}
-			err := nsCmd.executeListNamespaces(ctx)
+			err := nsCmd.executeListNamespaces(ctx, utils.ListPage{Page: 1}, nil)
 			if tt.err != nil {
 				assert.Error(t, err)
 			} else {
@@ -69,8 +69,10 @@ func Namespace(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
 	cmd.AddCommand(Use(ctx))
 	cmd.AddCommand(List(ctx))
 	cmd.AddCommand(Create(ctx))
+	cmd.AddCommand(Clone(ctx))
 	cmd.AddCommand(Delete(ctx, k8sLogger))
 	cmd.AddCommand(Sleep(ctx))
 	cmd.AddCommand(Wake(ctx))
+	cmd.AddCommand(Vars(ctx, k8sLogger))
 	return cmd
 }
@@ -0,0 +1,147 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	oktetoIO "github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+// Vars manages the default variables of the deploys into a namespace
+func Vars(ctx context.Context, k8sLogger *oktetoIO.K8sLogger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vars",
+		Short: "Manage the default variables of the deploys into a namespace",
+		Long: `Manage the default variables of the deploys into a namespace.
+
+Every 'okteto deploy' into the namespace gets these variables with the lowest precedence:
+the variables of the '--var' flag, your environment, the Okteto Variables and the .env file override them.`,
+		Args: utils.NoArgsAccepted(""),
+	}
+	cmd.AddCommand(varsSet(ctx, k8sLogger))
+	cmd.AddCommand(varsList(ctx, k8sLogger))
+	return cmd
+}
+
+func varsSet(ctx context.Context, k8sLogger *oktetoIO.K8sLogger) *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:   "set NAME=VALUE...",
+		Short: "Set default variables of the deploys into a namespace",
+		Args:  utils.MinimumNArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nsCmd, err := newVarsCommand(ctx, namespace)
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = okteto.GetContext().Namespace
+			}
+			return nsCmd.ExecuteSetVariables(ctx, namespace, args, k8sLogger)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of the variables (default is the one of the okteto context)")
+	return cmd
+}
+
+func varsList(ctx context.Context, k8sLogger *oktetoIO.K8sLogger) *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the default variables of the deploys into a namespace",
+		Aliases: []string{"ls"},
+		Args:    utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nsCmd, err := newVarsCommand(ctx, namespace)
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				namespace = okteto.GetContext().Namespace
+			}
+			return nsCmd.ExecuteListVariables(ctx, namespace, os.Stdout, k8sLogger)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of the variables (default is the one of the okteto context)")
+	return cmd
+}
+
+func newVarsCommand(ctx context.Context, namespace string) (*Command, error) {
+	if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{Namespace: namespace}); err != nil {
+		return nil, err
+	}
+	if !okteto.IsOkteto() {
+		return nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+	return NewCommand()
+}
+
+// ExecuteSetVariables adds the variables to the default variables of the namespace
+func (nc *Command) ExecuteSetVariables(ctx context.Context, namespace string, variables []string, k8sLogger *oktetoIO.K8sLogger) error {
+	if err := validator.CheckReservedVariablesNameOption(variables); err != nil {
+		return err
+	}
+	vars, err := env.Parse(variables)
+	if err != nil {
+		return err
+	}
+
+	c, _, err := nc.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, k8sLogger)
+	if err != nil {
+		return err
+	}
+	if err := namespaces.SetVariables(ctx, namespace, vars, c); err != nil {
+		return fmt.Errorf("failed to set the variables of namespace '%s': %w", namespace, err)
+	}
+
+	oktetoLog.Success("Variables of namespace '%s' updated", namespace)
+	return nil
+}
+
+// ExecuteListVariables prints the default variables of the namespace
+func (nc *Command) ExecuteListVariables(ctx context.Context, namespace string, out io.Writer, k8sLogger *oktetoIO.K8sLogger) error {
+	c, _, err := nc.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, k8sLogger)
+	if err != nil {
+		return err
+	}
+	vars, err := namespaces.GetVariables(ctx, namespace, c)
+	if err != nil {
+		return fmt.Errorf("failed to get the variables of namespace '%s': %w", namespace, err)
+	}
+	if len(vars) == 0 {
+		oktetoLog.Information("Namespace '%s' has no variables", namespace)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Name\tValue\n")
+	for _, v := range vars {
+		fmt.Fprintf(w, "%s\t%s\n", v.Name, v.Value)
+	}
+	return w.Flush()
+}
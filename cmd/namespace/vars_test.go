@@ -0,0 +1,63 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/k8s/namespaces"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExecuteSetAndListVariables(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test-context": {
+				Name:      "test-context",
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test-context",
+	}
+	k8sClient := fake.NewSimpleClientset()
+	nsCmd := NewFakeNamespaceCommand(client.NewFakeOktetoClient(), k8sClient, &types.User{})
+
+	err := nsCmd.ExecuteSetVariables(ctx, "test", []string{"REGION=us-east-1", "LOG_LEVEL=info"}, nil)
+	require.NoError(t, err)
+
+	vars, err := namespaces.GetVariables(ctx, "test", k8sClient)
+	require.NoError(t, err)
+	assert.Equal(t, []env.Var{{Name: "LOG_LEVEL", Value: "info"}, {Name: "REGION", Value: "us-east-1"}}, vars)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, nsCmd.ExecuteListVariables(ctx, "test", out, nil))
+	assert.Equal(t, "Name       Value\nLOG_LEVEL  info\nREGION     us-east-1\n", out.String())
+}
+
+func TestExecuteSetVariablesWithInvalidFormat(t *testing.T) {
+	nsCmd := NewFakeNamespaceCommand(client.NewFakeOktetoClient(), fake.NewSimpleClientset(), &types.User{})
+
+	err := nsCmd.ExecuteSetVariables(context.Background(), "test", []string{"REGION"}, nil)
+	assert.Error(t, err)
+}
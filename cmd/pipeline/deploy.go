@@ -29,6 +29,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/deps"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
@@ -62,9 +63,13 @@ type deployFlags struct {
 	variables    []string
 	labels       []string
 	timeout      time.Duration
+	ttl          time.Duration
 	wait         bool
 	skipIfExists bool
 	reuseParams  bool
+	schedule     string
+	commit       string
+	tag          string
 }
 
 // DeployOptions represents options for deploy pipeline command
@@ -77,9 +82,13 @@ type DeployOptions struct {
 	Variables    []string
 	Labels       []string
 	Timeout      time.Duration
+	TTL          time.Duration
 	Wait         bool
 	SkipIfExists bool
 	ReuseParams  bool
+	Schedule     string
+	Commit       string
+	Tag          string
 }
 
 func deploy(ctx context.Context) *cobra.Command {
@@ -94,6 +103,17 @@ func deploy(ctx context.Context) *cobra.Command {
 				return err
 			}
 
+			if err := flags.validateRevision(); err != nil {
+				return err
+			}
+
+			if flags.ttl < 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("invalid time to live '%s': it must be greater than zero", flags.ttl),
+					Hint: "Use a duration like '--ttl 48h'",
+				}
+			}
+
 			ctxResource := &model.ContextResource{}
 			if err := ctxResource.UpdateNamespace(flags.namespace); err != nil {
 				return err
@@ -139,6 +159,10 @@ func deploy(ctx context.Context) *cobra.Command {
 	}
 	cmd.Flags().StringArrayVarP(&flags.labels, "label", "", []string{}, "set an environment label (can be set more than once)")
 	cmd.Flags().BoolVar(&flags.reuseParams, "reuse-params", false, "if pipeline exist, reuse same params to redeploy")
+	cmd.Flags().StringVarP(&flags.commit, "commit", "", "", "the commit SHA to deploy (defaults to the tip of the branch)")
+	cmd.Flags().StringVarP(&flags.tag, "tag", "", "", "the git tag to deploy (defaults to the tip of the branch)")
+	cmd.Flags().DurationVarP(&flags.ttl, "ttl", "", 0, "time after which the development environment expires, e.g. 48h. Expired environments are reported on later CLI runs")
+	cmd.Flags().StringVarP(&flags.schedule, "schedule", "", "", "cron expression to redeploy the pipeline periodically, e.g. '0 3 * * *'. Use 'okteto pipeline schedule' to manage the schedules")
 
 	return cmd
 }
@@ -149,11 +173,19 @@ func (pc *Command) ExecuteDeployPipeline(ctx context.Context, opts *DeployOption
 		return fmt.Errorf("could not set default values for options: %w", err)
 	}
 
+	if opts.Schedule != "" {
+		if err := pc.setSchedule(ctx, opts); err != nil {
+			return err
+		}
+	}
+
 	c, _, err := pc.k8sClientProvider.Provide(okteto.GetContext().Cfg)
 	if err != nil {
 		return fmt.Errorf("failed to load okteto context '%s': %w", okteto.GetContext().Name, err)
 	}
 
+	pipeline.WarnExpired(ctx, opts.Namespace, opts.Name, c)
+
 	exists := false
 	cfgName := pipeline.TranslatePipelineName(opts.Name)
 	cfg, err := configmaps.Get(ctx, cfgName, opts.Namespace, c)
@@ -221,6 +253,18 @@ func (pc *Command) ExecuteDeployPipeline(ctx context.Context, opts *DeployOption
 		}
 	}
 
+	if opts.TTL > 0 {
+		opts.Variables = append(opts.Variables, fmt.Sprintf("%s=%s", constants.OktetoDeployTTLEnvVar, opts.TTL))
+	}
+
+	resolver := pc.revisionResolver
+	if resolver == nil {
+		resolver = gitRevisionResolver{}
+	}
+	if err := opts.resolveRevision(ctx, resolver); err != nil {
+		return err
+	}
+
 	resp, err := pc.deployPipeline(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("failed to deploy pipeline '%s': %w", opts.Name, err)
@@ -252,6 +296,18 @@ func (pc *Command) ExecuteDeployPipeline(ctx context.Context, opts *DeployOption
 	return nil
 }
 
+// setSchedule registers the recurring redeploy of the pipeline
+func (pc *Command) setSchedule(ctx context.Context, opts *DeployOptions) error {
+	if err := deps.ValidateSchedule(opts.Schedule); err != nil {
+		return err
+	}
+	if err := pc.okClient.Pipeline().SetSchedule(ctx, opts.Name, opts.Namespace, opts.Schedule); err != nil {
+		return err
+	}
+	oktetoLog.Success("Repository '%s' scheduled to be redeployed with '%s'", opts.Name, opts.Schedule)
+	return nil
+}
+
 type envSetter func(name, value string) error
 
 // setEnvsFromDependency sets the environment variables found at configmap.Data[dependencyEnvs]
@@ -304,7 +360,7 @@ func (pc *Command) deployPipeline(ctx context.Context, opts *DeployOptions) (*ty
 			exit <- err
 			return
 		}
-		oktetoLog.Infof("deploy pipeline %s defined on file='%s' repository=%s branch=%s on namespace=%s", opts.Name, opts.File, opts.Repository, opts.Branch, opts.Namespace)
+		oktetoLog.Infof("deploy pipeline %s defined on file='%s' repository=%s branch=%s commit=%s on namespace=%s", opts.Name, opts.File, opts.Repository, opts.Branch, opts.Commit, opts.Namespace)
 
 		resp, err = pc.okClient.Pipeline().Deploy(ctx, pipelineOpts)
 		exit <- err
@@ -447,13 +503,28 @@ func (f deployFlags) toOptions() *DeployOptions {
 		Wait:         f.wait,
 		SkipIfExists: f.skipIfExists,
 		Timeout:      f.timeout,
+		TTL:          f.ttl,
 		File:         file,
 		Variables:    f.variables,
 		Labels:       f.labels,
 		ReuseParams:  f.reuseParams,
+		Schedule:     f.schedule,
+		Commit:       f.commit,
+		Tag:          f.tag,
 	}
 }
 
+// validateRevision checks that the flags to select the revision to deploy are not combined
+func (f deployFlags) validateRevision() error {
+	if f.commit != "" && f.tag != "" {
+		return fmt.Errorf("flags '--commit' and '--tag' can not be used at the same time")
+	}
+	if f.tag != "" && f.branch != "" {
+		return fmt.Errorf("flags '--tag' and '--branch' can not be used at the same time")
+	}
+	return nil
+}
+
 func (o *DeployOptions) setDefaults() error {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -487,7 +558,8 @@ func (o *DeployOptions) setDefaults() error {
 
 	currentRepo := repository.NewRepository(currentRepoURL)
 	optsRepo := repository.NewRepository(o.Repository)
-	if o.Branch == "" && currentRepo.IsEqual(optsRepo) {
+	// the branch is not inferred when a commit or tag is pinned: CI usually checks them out in detached HEAD
+	if o.Branch == "" && o.Commit == "" && o.Tag == "" && currentRepo.IsEqual(optsRepo) {
 
 		oktetoLog.Info("inferring git repository branch")
 		b, err := utils.GetBranch(cwd)
@@ -522,6 +594,7 @@ func (o *DeployOptions) toPipelineDeployClientOptions() (types.PipelineDeployOpt
 		Name:       o.Name,
 		Repository: o.Repository,
 		Branch:     o.Branch,
+		Commit:     o.Commit,
 		Filename:   o.File,
 		Variables:  varList,
 		Namespace:  o.Namespace,
@@ -590,6 +663,9 @@ func cfgToDeployOptions(cfg *v1.ConfigMap) *DeployOptions {
 	if v, ok := cfg.Data["branch"]; ok {
 		opts.Branch = v
 	}
+	if v, ok := cfg.Data["commit"]; ok {
+		opts.Commit = v
+	}
 	if v, ok := cfg.Data["variables"]; ok {
 		vars, err := parseVariablesListFromCfgVariablesString(v)
 		if err != nil {
@@ -605,13 +681,21 @@ func cfgToDeployOptions(cfg *v1.ConfigMap) *DeployOptions {
 	return opts
 }
 
-// applyOverrideToOptions overrides name, namespace, file, repository, branch, variables and labels from the current cfgmap options
+// applyOverrideToOptions overrides name, namespace, file, repository, branch, commit, variables and labels from the current cfgmap options.
+// The commit is only overridden when neither --commit nor --tag are set
 func applyOverrideToOptions(opts *DeployOptions, override *DeployOptions) {
 	opts.Name = override.Name
 	opts.Namespace = override.Namespace
 	opts.File = override.File
 	opts.Repository = override.Repository
 	opts.Branch = override.Branch
+	if opts.Commit == "" && opts.Tag == "" {
+		opts.Commit = override.Commit
+	}
+	if opts.Tag != "" {
+		// tags are deployed without a branch, as when the flags are not reused
+		opts.Branch = ""
+	}
 	opts.Variables = override.Variables
 	opts.Labels = override.Labels
 }
@@ -231,6 +231,85 @@ func TestDeployPipelineSuccesful(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDeployPipelineWithSchedule(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+			},
+		},
+	}
+	response := &client.FakePipelineResponses{
+		DeployResponse: &types.GitDeployResponse{
+			Action: &types.Action{
+				ID:   "test",
+				Name: "test",
+			},
+		},
+	}
+	pc := &Command{
+		okClient: &client.FakeOktetoClient{
+			PipelineClient: client.NewFakePipelineClient(response),
+		},
+		k8sClientProvider: test.NewFakeK8sProvider(),
+	}
+
+	opts := &DeployOptions{
+		Repository: "http://stest",
+		Name:       "test",
+		Schedule:   "0 3 * * *",
+	}
+	err := pc.ExecuteDeployPipeline(ctx, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.PipelineSchedule{{Name: "test", Schedule: "0 3 * * *"}}, response.Schedules)
+
+	opts.Schedule = "every night"
+	err = pc.ExecuteDeployPipeline(ctx, opts)
+	assert.Error(t, err)
+	assert.Equal(t, 1, response.CallCount)
+}
+
+func TestDeployPipelineWithTTL(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+			},
+		},
+	}
+	response := &client.FakePipelineResponses{
+		DeployResponse: &types.GitDeployResponse{
+			Action: &types.Action{
+				ID:   "test",
+				Name: "test",
+			},
+		},
+	}
+	pc := &Command{
+		okClient: &client.FakeOktetoClient{
+			PipelineClient: client.NewFakePipelineClient(response),
+		},
+		k8sClientProvider: test.NewFakeK8sProvider(),
+	}
+
+	opts := &DeployOptions{
+		Repository: "http://stest",
+		Name:       "test",
+		Variables:  []string{"A=B"},
+		TTL:        48 * time.Hour,
+	}
+	err := pc.ExecuteDeployPipeline(ctx, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Variable{
+		{Name: "A", Value: "B"},
+		{Name: "OKTETO_DEPLOY_TTL", Value: "48h0m0s"},
+	}, response.DeployOpts.Variables)
+}
+
 func TestDeployPipelineSuccesfulWithWait(t *testing.T) {
 	ctx := context.Background()
 	okteto.CurrentStore = &okteto.ContextStore{
@@ -859,6 +938,42 @@ func Test_applyOverrideToOptions(t *testing.T) {
 	}, opts)
 }
 
+func Test_applyOverrideToOptionsWithRevision(t *testing.T) {
+	override := &DeployOptions{
+		Name:       "test",
+		Repository: "repository",
+		Branch:     "branch",
+		Commit:     "1111111111111111111111111111111111111111",
+	}
+	var tests = []struct {
+		opts     *DeployOptions
+		expected *DeployOptions
+		name     string
+	}{
+		{
+			name:     "reused commit",
+			opts:     &DeployOptions{},
+			expected: &DeployOptions{Name: "test", Repository: "repository", Branch: "branch", Commit: "1111111111111111111111111111111111111111"},
+		},
+		{
+			name:     "explicit commit",
+			opts:     &DeployOptions{Commit: "2222222"},
+			expected: &DeployOptions{Name: "test", Repository: "repository", Branch: "branch", Commit: "2222222"},
+		},
+		{
+			name:     "explicit tag",
+			opts:     &DeployOptions{Tag: "v1.0.0"},
+			expected: &DeployOptions{Name: "test", Repository: "repository", Tag: "v1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyOverrideToOptions(tt.opts, override)
+			assert.Equal(t, tt.expected, tt.opts)
+		})
+	}
+}
+
 func Test_cfgToDeployOptions(t *testing.T) {
 	tests := []struct {
 		input    *v1.ConfigMap
@@ -43,7 +43,9 @@ type listFlags struct {
 	context   string
 	namespace string
 	output    string
+	user      string
 	labels    []string
+	page      utils.ListPage
 }
 
 type pipelineListItem struct {
@@ -51,6 +53,7 @@ type pipelineListItem struct {
 	Status     string   `json:"status" yaml:"status"`
 	Repository string   `json:"repository" yaml:"repository"`
 	Branch     string   `json:"branch" yaml:"branch"`
+	Owner      string   `json:"owner,omitempty" yaml:"owner,omitempty"`
 	Labels     []string `json:"labels" yaml:"labels"`
 }
 
@@ -62,6 +65,9 @@ func list(ctx context.Context) *cobra.Command {
 		Short: "List all okteto pipelines",
 		Args:  utils.NoArgsAccepted(""),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.page.Validate(); err != nil {
+				return err
+			}
 			return pipelineListCommandHandler(ctx, flags, contextCMD.NewContextCommand().Run)
 		},
 	}
@@ -70,6 +76,8 @@ func list(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringArrayVarP(&flags.labels, "label", "", []string{}, "tag and organize dev environments using labels (multiple --label flags accepted)")
 	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the pipelines are deployed (defaults to the current namespace)")
 	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	cmd.Flags().StringVarP(&flags.user, "user", "u", "", "only list the per-user dev environments deployed by this user")
+	flags.page.AddFlags(cmd.Flags())
 	return cmd
 }
 
@@ -121,7 +129,7 @@ func pipelineListCommandHandler(ctx context.Context, flags *listFlags, initOkCtx
 		return fmt.Errorf("failed to load okteto context '%s': %w", okCtx.Name, err)
 	}
 
-	return executeListPipelines(ctx, *flags, configmaps.List, getPipelineListOutput, c, os.Stdout)
+	return executeListPipelines(ctx, *flags, configmaps.List, getPipelineListOutput, c, utils.NewListCache(), os.Stdout)
 }
 
 type initOkCtxFn func(ctx context.Context, ctxOptions *contextCMD.Options) error
@@ -129,16 +137,20 @@ type getPipelineListOutputFn func(ctx context.Context, listPipelines listPipelin
 type listPipelinesFn func(ctx context.Context, namespace, labelSelector string, c kubernetes.Interface) ([]apiv1.ConfigMap, error)
 
 // executeListPipelines is responsible for output management and calling the function that lists pipelines
-func executeListPipelines(ctx context.Context, opts listFlags, listPipelines listPipelinesFn, getPipelineListOutput getPipelineListOutputFn, c kubernetes.Interface, w io.Writer) error {
-	labelSelector, err := getLabelSelector(opts.labels)
+func executeListPipelines(ctx context.Context, opts listFlags, listPipelines listPipelinesFn, getPipelineListOutput getPipelineListOutputFn, c kubernetes.Interface, cache *utils.ListCache, w io.Writer) error {
+	labelSelector, err := getLabelSelector(opts.labels, opts.user)
 	if err != nil {
 		return err
 	}
 
-	pipelineListOutput, err := getPipelineListOutput(ctx, listPipelines, opts.namespace, labelSelector, c)
+	key := utils.ListCacheKey("pipelines", okteto.GetContext().Name, opts.namespace, labelSelector)
+	pipelineListOutput, err := utils.FetchList(cache, key, opts.page, func() ([]pipelineListItem, error) {
+		return getPipelineListOutput(ctx, listPipelines, opts.namespace, labelSelector, c)
+	})
 	if err != nil {
 		return err
 	}
+	pipelineListOutput = utils.Paginate(pipelineListOutput, opts.page)
 	switch opts.output {
 	case "json":
 		bytes, err := json.MarshalIndent(pipelineListOutput, "", " ")
@@ -174,7 +186,7 @@ func executeListPipelines(ctx context.Context, opts listFlags, listPipelines lis
 	return nil
 }
 
-func getLabelSelector(labels []string) (string, error) {
+func getLabelSelector(labels []string, user string) (string, error) {
 	var labelSelector = []string{
 		model.GitDeployLabel,
 	}
@@ -188,6 +200,13 @@ func getLabelSelector(labels []string) (string, error) {
 		}
 		labelSelector = append(labelSelector, fmt.Sprintf("%s/%s", constants.EnvironmentLabelKeyPrefix, label))
 	}
+	if user != "" {
+		errs := validation.IsValidLabelValue(user)
+		if len(errs) > 0 {
+			return "", fmt.Errorf("invalid user '%s': %w", user, errors.New(errs[0]))
+		}
+		labelSelector = append(labelSelector, fmt.Sprintf("%s=%s", model.OwnerLabel, user))
+	}
 	return strings.Join(labelSelector, ","), nil
 }
 
@@ -219,6 +238,7 @@ func getPipelineListOutput(ctx context.Context, listPipelines listPipelinesFn, n
 			Status:     cm.Data["status"],
 			Repository: repository.NewRepository(cm.Data["repository"]).GetAnonymizedRepo(),
 			Branch:     cm.Data["branch"],
+			Owner:      cm.Labels[model.OwnerLabel],
 			Labels:     []string{},
 		}
 
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
@@ -434,6 +435,35 @@ dev2  dev2-status  https://dev2-repository  dev2-branch  fake-label-2
 			expectedError: nil,
 			expectedPrintedOutput: `Name  Status       Repository               Branch       Labels
 dev4  dev4-status  https://dev4-repository  dev4-branch  -
+`,
+		},
+		{
+			name: "success - second page",
+			input: input{
+				flags: listFlags{
+					namespace: "test-ns",
+					output:    "",
+					page:      utils.ListPage{Limit: 2, Page: 2},
+				},
+				listPipelines:         configmaps.List,
+				getPipelineListOutput: getPipelineListOutput,
+				c: fake.NewSimpleClientset(
+					&apiv1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-ns",
+							Labels: map[string]string{
+								constants.NamespaceStatusLabel: "Deployed",
+							},
+						},
+					},
+					mockPipeline("dev1", []string{}),
+					mockPipeline("dev2", []string{"fake-label-2"}),
+					mockPipeline("dev3", []string{"fake-label-3"}),
+				),
+			},
+			expectedError: nil,
+			expectedPrintedOutput: `Name  Status       Repository               Branch       Labels
+dev3  dev3-status  https://dev3-repository  dev3-branch  fake-label-3
 `,
 		},
 	}
@@ -443,7 +473,7 @@ dev4  dev4-status  https://dev4-repository  dev4-branch  -
 			// Redirect the log output to a buffer for our test
 			var buf bytes.Buffer
 
-			err := executeListPipelines(context.Background(), tt.input.flags, tt.input.listPipelines, tt.input.getPipelineListOutput, tt.input.c, &buf)
+			err := executeListPipelines(context.Background(), tt.input.flags, tt.input.listPipelines, tt.input.getPipelineListOutput, tt.input.c, nil, &buf)
 
 			if tt.expectedError == nil {
 				assert.NoError(t, err)
@@ -462,6 +492,7 @@ func TestGetPipelineListOutput(t *testing.T) {
 		listPipelines listPipelinesFn
 		flags         listFlags
 		namespace     string
+		user          string
 		labels        []string
 	}
 
@@ -661,6 +692,52 @@ func TestGetPipelineListOutput(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "success - filter by user",
+			input: input{
+				labels:        []string{},
+				user:          "cindy",
+				namespace:     "test-ns",
+				listPipelines: configmaps.List,
+				c: fake.NewSimpleClientset(
+					&apiv1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-ns",
+							Labels: map[string]string{
+								constants.NamespaceStatusLabel: "Deployed",
+							},
+						},
+					},
+					mockPipeline("dev1", []string{}),
+					&apiv1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "dev1-cindy",
+							Namespace: "test-ns",
+							Labels: map[string]string{
+								model.GitDeployLabel: "true",
+								model.OwnerLabel:     "cindy",
+							},
+						},
+						Data: map[string]string{
+							"name":       "dev1-cindy",
+							"status":     "deployed",
+							"repository": "https://dev1-repository",
+						},
+					},
+				),
+			},
+			output: output{
+				pipelines: []pipelineListItem{
+					{
+						Name:       "dev1-cindy",
+						Status:     "deployed",
+						Repository: "https://dev1-repository",
+						Owner:      "cindy",
+						Labels:     []string{},
+					},
+				},
+			},
+		},
 		{
 			name: "error - cannot get namespace",
 			input: input{
@@ -706,7 +783,7 @@ func TestGetPipelineListOutput(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 
-			labelSelector, err := getLabelSelector(tt.input.labels)
+			labelSelector, err := getLabelSelector(tt.input.labels, tt.input.user)
 			assert.NoError(t, err)
 
 			pipelines, err := getPipelineListOutput(ctx, tt.input.listPipelines, tt.input.namespace, labelSelector, tt.input.c)
@@ -721,3 +798,12 @@ func TestGetPipelineListOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLabelSelectorWithUser(t *testing.T) {
+	labelSelector, err := getLabelSelector([]string{"team"}, "cindy")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev.okteto.com/git-deploy,label.okteto.com/team,dev.okteto.com/owner=cindy", labelSelector)
+
+	_, err = getLabelSelector(nil, "cindy@okteto.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/kubernetes"
+)
+
+type outputsFlags struct {
+	context   string
+	namespace string
+	output    string
+}
+
+type getOutputsFn func(ctx context.Context, name, namespace string, c kubernetes.Interface) (map[string]string, error)
+
+func outputs(ctx context.Context) *cobra.Command {
+	flags := &outputsFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "outputs <name>",
+		Short: "Show the outputs published by the deploy commands of an okteto pipeline",
+		Args:  utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(flags.output); err != nil {
+				return err
+			}
+			return pipelineOutputsCommandHandler(ctx, args[0], flags, contextCMD.NewContextCommand().Run)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the pipeline is deployed (defaults to the current context)")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the pipeline is deployed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	return cmd
+}
+
+func validateOutputFormat(output string) error {
+	switch output {
+	case "", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	}
+}
+
+// pipelineOutputsCommandHandler prepares the right okteto context depending on the provided flags and then shows the pipeline outputs
+func pipelineOutputsCommandHandler(ctx context.Context, name string, flags *outputsFlags, initOkCtx initOkCtxFn) error {
+	ctxResource := &model.ContextResource{}
+	if flags.context != "" {
+		if err := ctxResource.UpdateContext(flags.context); err != nil {
+			return err
+		}
+	}
+
+	if flags.namespace != "" {
+		if err := ctxResource.UpdateNamespace(flags.namespace); err != nil {
+			return err
+		}
+	}
+
+	ctxOptions := &contextCMD.Options{
+		Context:   ctxResource.Context,
+		Namespace: ctxResource.Namespace,
+		Show:      flags.output == "",
+	}
+	if err := initOkCtx(ctx, ctxOptions); err != nil {
+		return err
+	}
+
+	okCtx := okteto.GetContext()
+	if flags.namespace == "" {
+		flags.namespace = okCtx.Namespace
+	}
+
+	pc, err := NewCommand()
+	if err != nil {
+		return err
+	}
+	c, _, err := pc.k8sClientProvider.Provide(okCtx.Cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load okteto context '%s': %w", okCtx.Name, err)
+	}
+
+	return executePipelineOutputs(ctx, name, *flags, pipeline.GetOutputs, c, os.Stdout)
+}
+
+// executePipelineOutputs retrieves the outputs of a pipeline and writes them in the requested format
+func executePipelineOutputs(ctx context.Context, name string, opts outputsFlags, getOutputs getOutputsFn, c kubernetes.Interface, w io.Writer) error {
+	outputs, err := getOutputs(ctx, name, opts.namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("pipeline '%s' not found in namespace '%s'", name, opts.namespace),
+				Hint: "Run 'okteto pipeline list' to see the pipelines deployed in the namespace",
+			}
+		}
+		return err
+	}
+
+	switch opts.output {
+	case "json":
+		bytes, err := json.MarshalIndent(outputs, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(outputs)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	default:
+		names := make([]string, 0, len(outputs))
+		for k := range outputs {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, "Name\tValue")
+		for _, k := range names {
+			fmt.Fprintf(tw, "%s\t%s\n", k, outputs[k])
+		}
+		tw.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExecutePipelineOutputs(t *testing.T) {
+	getOutputs := func(_ context.Context, _, _ string, _ kubernetes.Interface) (map[string]string, error) {
+		return map[string]string{
+			"DB_HOST": "postgres",
+			"API_URL": "https://api.okteto.dev",
+		}, nil
+	}
+	tests := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:     "json",
+			output:   "json",
+			expected: "{\n \"API_URL\": \"https://api.okteto.dev\",\n \"DB_HOST\": \"postgres\"\n}\n",
+		},
+		{
+			name:     "yaml",
+			output:   "yaml",
+			expected: "API_URL: https://api.okteto.dev\nDB_HOST: postgres\n",
+		},
+		{
+			name:     "table",
+			expected: "Name     Value\nAPI_URL  https://api.okteto.dev\nDB_HOST  postgres\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := executePipelineOutputs(context.Background(), "test", outputsFlags{namespace: "ns", output: tt.output}, getOutputs, fake.NewSimpleClientset(), &out)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestExecutePipelineOutputsNotFound(t *testing.T) {
+	getOutputs := func(_ context.Context, name, _ string, _ kubernetes.Interface) (map[string]string, error) {
+		return nil, k8sErrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+	}
+
+	var out bytes.Buffer
+	err := executePipelineOutputs(context.Background(), "test", outputsFlags{namespace: "ns"}, getOutputs, fake.NewSimpleClientset(), &out)
+
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Equal(t, "pipeline 'test' not found in namespace 'ns'", userErr.E.Error())
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	assert.NoError(t, validateOutputFormat(""))
+	assert.NoError(t, validateOutputFormat("json"))
+	assert.NoError(t, validateOutputFormat("yaml"))
+	assert.Error(t, validateOutputFormat("xml"))
+}
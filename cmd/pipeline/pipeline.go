@@ -33,6 +33,7 @@ type Interface interface {
 type Command struct {
 	okClient          types.OktetoInterface
 	k8sClientProvider okteto.K8sClientProvider
+	revisionResolver  revisionResolver
 }
 
 // NewCommand creates a namespace command to
@@ -48,6 +49,7 @@ func NewCommand() (*Command, error) {
 	return &Command{
 		okClient:          okClient,
 		k8sClientProvider: okteto.NewK8sClientProvider(),
+		revisionResolver:  gitRevisionResolver{},
 	}, nil
 }
 
@@ -61,5 +63,7 @@ func Pipeline(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(deploy(ctx))
 	cmd.AddCommand(destroy(ctx))
 	cmd.AddCommand(list(ctx))
+	cmd.AddCommand(outputs(ctx))
+	cmd.AddCommand(schedule(ctx))
 	return cmd
 }
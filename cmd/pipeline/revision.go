@@ -0,0 +1,143 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	modelUtils "github.com/okteto/okteto/pkg/model/utils"
+	"github.com/okteto/okteto/pkg/repository"
+)
+
+const fullCommitSHALength = 40
+
+// revisionResolver validates and resolves the git revisions to deploy
+type revisionResolver interface {
+	// ListRefs returns the references of a remote repository
+	ListRefs(ctx context.Context, repository string) ([]*plumbing.Reference, error)
+	// ResolveLocalCommit returns the full SHA of commit if it exists in the local clone of repository
+	ResolveLocalCommit(repository, commit string) (string, bool)
+}
+
+// gitRevisionResolver resolves revisions using the remote repository and the git repository of the current directory
+type gitRevisionResolver struct{}
+
+// ListRefs returns the references of a remote repository
+func (gitRevisionResolver) ListRefs(ctx context.Context, repositoryURL string) ([]*plumbing.Reference, error) {
+	return repository.ListRemoteRefs(ctx, repositoryURL)
+}
+
+// ResolveLocalCommit returns the full SHA of commit if it exists in the git repository of the current directory
+func (gitRevisionResolver) ResolveLocalCommit(repositoryURL, commit string) (string, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	currentRepoURL, err := modelUtils.GetRepositoryURL(cwd)
+	if err != nil {
+		return "", false
+	}
+	if !repository.NewRepository(currentRepoURL).IsEqual(repository.NewRepository(repositoryURL)) {
+		return "", false
+	}
+	repo, err := repository.FindTopLevelGitRepoFromPath(cwd)
+	if err != nil {
+		return "", false
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(commit))
+	if err != nil {
+		oktetoLog.Infof("commit '%s' not found in the local repository: %s", commit, err)
+		return "", false
+	}
+	return hash.String(), true
+}
+
+// resolveRevision validates that the repository and the commit or tag to deploy exist, and resolves them to a full commit SHA
+func (o *DeployOptions) resolveRevision(ctx context.Context, resolver revisionResolver) error {
+	if o.Commit == "" && o.Tag == "" {
+		return nil
+	}
+
+	refs, err := resolver.ListRefs(ctx, o.Repository)
+	if err != nil {
+		if errors.Is(err, transport.ErrRepositoryNotFound) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("repository '%s' not found", o.Repository),
+				Hint: "Check the value of the '--repository' flag",
+			}
+		}
+		oktetoLog.Infof("failed to list the references of repository '%s': %s", o.Repository, err)
+		refs = nil
+	}
+
+	if o.Tag != "" {
+		if err != nil {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("failed to resolve tag '%s' of repository '%s': %w", o.Tag, o.Repository, err),
+				Hint: "Use the '--commit' flag with the full commit SHA of the tag instead",
+			}
+		}
+		sha, ok := findTagCommit(refs, o.Tag)
+		if !ok {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("tag '%s' not found in repository '%s'", o.Tag, o.Repository),
+				Hint: "Push the tag to the repository or check the value of the '--tag' flag",
+			}
+		}
+		o.Commit = sha
+		return nil
+	}
+
+	commit := strings.ToLower(o.Commit)
+	if !repository.IsCommitSHA(commit) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is not a valid commit SHA", o.Commit),
+			Hint: "The '--commit' flag must be a commit SHA of at least 7 hexadecimal characters",
+		}
+	}
+
+	if sha, ok := resolver.ResolveLocalCommit(o.Repository, commit); ok {
+		o.Commit = sha
+		return nil
+	}
+	for _, ref := range refs {
+		if strings.HasPrefix(ref.Hash().String(), commit) {
+			o.Commit = ref.Hash().String()
+			return nil
+		}
+	}
+	if len(commit) == fullCommitSHALength {
+		// commits that are not the tip of a reference can't be validated without cloning the repository
+		oktetoLog.Infof("commit '%s' couldn't be validated locally", commit)
+		o.Commit = commit
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("commit '%s' not found in repository '%s'", o.Commit, o.Repository),
+		Hint: "Use the full commit SHA or run the command from a clone of the repository that contains the commit",
+	}
+}
+
+// findTagCommit returns the commit SHA a tag points to. Annotated tags are resolved to the commit they reference
+func findTagCommit(refs []*plumbing.Reference, tag string) (string, bool) {
+	return repository.FindRefCommit(refs, repository.TagRefPrefix+strings.TrimPrefix(tag, repository.TagRefPrefix))
+}
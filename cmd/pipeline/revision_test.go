@@ -0,0 +1,150 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mainSHA      = "1111111111111111111111111111111111111111"
+	tagSHA       = "2222222222222222222222222222222222222222"
+	tagCommitSHA = "3333333333333333333333333333333333333333"
+	localSHA     = "4444444444444444444444444444444444444444"
+)
+
+type fakeRevisionResolver struct {
+	err         error
+	localCommit string
+	refs        []*plumbing.Reference
+}
+
+func (f fakeRevisionResolver) ListRefs(context.Context, string) ([]*plumbing.Reference, error) {
+	return f.refs, f.err
+}
+
+func (f fakeRevisionResolver) ResolveLocalCommit(_, commit string) (string, bool) {
+	if f.localCommit != "" && len(commit) <= len(f.localCommit) && f.localCommit[:len(commit)] == commit {
+		return f.localCommit, true
+	}
+	return "", false
+}
+
+func TestResolveRevision(t *testing.T) {
+	refs := []*plumbing.Reference{
+		plumbing.NewHashReference("refs/heads/main", plumbing.NewHash(mainSHA)),
+		plumbing.NewHashReference("refs/tags/v1.0.0", plumbing.NewHash(tagSHA)),
+		plumbing.NewHashReference("refs/tags/v1.0.0^{}", plumbing.NewHash(tagCommitSHA)),
+		plumbing.NewHashReference("refs/tags/lightweight", plumbing.NewHash(mainSHA)),
+	}
+	tests := []struct {
+		resolver       fakeRevisionResolver
+		name           string
+		commit         string
+		tag            string
+		expectedCommit string
+		expectedErr    bool
+	}{
+		{
+			name:     "no commit nor tag",
+			resolver: fakeRevisionResolver{err: assert.AnError},
+		},
+		{
+			name:           "annotated tag",
+			resolver:       fakeRevisionResolver{refs: refs},
+			tag:            "v1.0.0",
+			expectedCommit: tagCommitSHA,
+		},
+		{
+			name:           "lightweight tag",
+			resolver:       fakeRevisionResolver{refs: refs},
+			tag:            "lightweight",
+			expectedCommit: mainSHA,
+		},
+		{
+			name:        "tag not found",
+			resolver:    fakeRevisionResolver{refs: refs},
+			tag:         "v2.0.0",
+			expectedErr: true,
+		},
+		{
+			name:        "tag with references not available",
+			resolver:    fakeRevisionResolver{err: transport.ErrAuthenticationRequired},
+			tag:         "v1.0.0",
+			expectedErr: true,
+		},
+		{
+			name:        "repository not found",
+			resolver:    fakeRevisionResolver{err: transport.ErrRepositoryNotFound},
+			commit:      mainSHA,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid commit",
+			resolver:    fakeRevisionResolver{refs: refs},
+			commit:      "main",
+			expectedErr: true,
+		},
+		{
+			name:           "short commit in local repository",
+			resolver:       fakeRevisionResolver{refs: refs, localCommit: localSHA},
+			commit:         "4444444",
+			expectedCommit: localSHA,
+		},
+		{
+			name:           "short commit of a remote reference",
+			resolver:       fakeRevisionResolver{refs: refs},
+			commit:         "1111111",
+			expectedCommit: mainSHA,
+		},
+		{
+			name:        "short commit not found",
+			resolver:    fakeRevisionResolver{refs: refs},
+			commit:      "5555555",
+			expectedErr: true,
+		},
+		{
+			name:           "full commit not validated",
+			resolver:       fakeRevisionResolver{err: transport.ErrAuthenticationRequired},
+			commit:         "5555555555555555555555555555555555555555",
+			expectedCommit: "5555555555555555555555555555555555555555",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &DeployOptions{Repository: "https://github.com/okteto/movies", Commit: tt.commit, Tag: tt.tag}
+			err := opts.resolveRevision(context.Background(), tt.resolver)
+			if tt.expectedErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCommit, opts.Commit)
+		})
+	}
+}
+
+func TestValidateRevisionFlags(t *testing.T) {
+	assert.NoError(t, deployFlags{commit: mainSHA, branch: "main"}.validateRevision())
+	assert.NoError(t, deployFlags{tag: "v1.0.0"}.validateRevision())
+	assert.Error(t, deployFlags{commit: mainSHA, tag: "v1.0.0"}.validateRevision())
+	assert.Error(t, deployFlags{tag: "v1.0.0", branch: "main"}.validateRevision())
+}
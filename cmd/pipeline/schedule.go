@@ -0,0 +1,179 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// scheduleFlags represents the user input for the pipeline schedule commands
+type scheduleFlags struct {
+	namespace string
+	output    string
+}
+
+func schedule(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage the scheduled redeploys of okteto pipelines",
+		Long: `Manage the scheduled redeploys of okteto pipelines.
+
+Schedules are registered with the 'schedule' field of the dependencies of your okteto manifest, or with 'okteto pipeline deploy --schedule'`,
+		Args: utils.NoArgsAccepted(""),
+	}
+	cmd.AddCommand(scheduleList(ctx))
+	cmd.AddCommand(scheduleRemove(ctx))
+	return cmd
+}
+
+func scheduleList(ctx context.Context) *cobra.Command {
+	flags := &scheduleFlags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the scheduled redeploys of okteto pipelines",
+		Args:  utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(flags.output); err != nil {
+				return err
+			}
+			pc, err := initScheduleCommand(ctx, flags)
+			if err != nil {
+				return err
+			}
+			return pc.executeListSchedules(ctx, flags, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the pipelines are deployed (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	return cmd
+}
+
+func scheduleRemove(ctx context.Context) *cobra.Command {
+	flags := &scheduleFlags{}
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove the scheduled redeploy of an okteto pipeline",
+		Args:  utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pc, err := initScheduleCommand(ctx, flags)
+			if err != nil {
+				return err
+			}
+			return pc.executeRemoveSchedule(ctx, args[0], flags.namespace)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the pipeline is deployed (defaults to the current namespace)")
+	return cmd
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	}
+}
+
+// initScheduleCommand loads the okteto context and returns the command to manage the schedules
+func initScheduleCommand(ctx context.Context, flags *scheduleFlags) (*Command, error) {
+	ctxResource := &model.ContextResource{}
+	if err := ctxResource.UpdateNamespace(flags.namespace); err != nil {
+		return nil, err
+	}
+
+	ctxOptions := &contextCMD.Options{
+		Namespace: ctxResource.Namespace,
+		Show:      flags.output == "",
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return nil, err
+	}
+
+	if !okteto.IsOkteto() {
+		return nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+
+	if flags.namespace == "" {
+		flags.namespace = okteto.GetContext().Namespace
+	}
+	return NewCommand()
+}
+
+func (pc *Command) executeListSchedules(ctx context.Context, flags *scheduleFlags, w io.Writer) error {
+	schedules, err := pc.okClient.Pipeline().ListSchedules(ctx, flags.namespace)
+	if err != nil {
+		return err
+	}
+
+	switch flags.output {
+	case "json":
+		bytes, err := json.MarshalIndent(schedules, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(schedules)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	default:
+		if len(schedules) == 0 {
+			fmt.Fprintf(w, "There are no scheduled pipelines in namespace '%s'\n", flags.namespace)
+			return nil
+		}
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join([]string{"Name", "Schedule", "Repository", "Branch"}, "\t"))
+		for _, s := range schedules {
+			branch := s.Branch
+			if branch == "" {
+				branch = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.Name, s.Schedule, s.Repository, branch)
+		}
+		tw.Flush()
+	}
+	return nil
+}
+
+func (pc *Command) executeRemoveSchedule(ctx context.Context, name, namespace string) error {
+	if err := pc.okClient.Pipeline().RemoveSchedule(ctx, name, namespace); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("pipeline '%s' is not scheduled in namespace '%s'", name, namespace),
+				Hint: "Run 'okteto pipeline schedule list' to see the scheduled pipelines",
+			}
+		}
+		return err
+	}
+	oktetoLog.Success("Schedule of pipeline '%s' removed", name)
+	return nil
+}
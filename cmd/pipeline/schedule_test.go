@@ -0,0 +1,82 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteListSchedules(t *testing.T) {
+	schedules := []types.PipelineSchedule{
+		{
+			Name:       "movies",
+			Schedule:   "0 3 * * *",
+			Repository: "https://github.com/okteto/movies",
+			Branch:     "main",
+		},
+	}
+	var tests = []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:     "table",
+			expected: "Name    Schedule   Repository                        Branch\nmovies  0 3 * * *  https://github.com/okteto/movies  main\n",
+		},
+		{
+			name:     "yaml",
+			output:   "yaml",
+			expected: "- name: movies\n  schedule: 0 3 * * *\n  repository: https://github.com/okteto/movies\n  branch: main\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &Command{
+				okClient: &client.FakeOktetoClient{
+					PipelineClient: client.NewFakePipelineClient(&client.FakePipelineResponses{Schedules: schedules}),
+				},
+			}
+			var buf bytes.Buffer
+			err := pc.executeListSchedules(context.Background(), &scheduleFlags{namespace: "test", output: tt.output}, &buf)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestExecuteRemoveSchedule(t *testing.T) {
+	responses := &client.FakePipelineResponses{
+		Schedules: []types.PipelineSchedule{{Name: "movies", Schedule: "@daily"}},
+	}
+	pc := &Command{
+		okClient: &client.FakeOktetoClient{
+			PipelineClient: client.NewFakePipelineClient(responses),
+		},
+	}
+
+	err := pc.executeRemoveSchedule(context.Background(), "movies", "test")
+	assert.NoError(t, err)
+	assert.Empty(t, responses.Schedules)
+
+	err = pc.executeRemoveSchedule(context.Background(), "movies", "test")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/plugin"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type installFlags struct {
+	index        string
+	manifestPath string
+}
+
+// pluginInstaller installs plugins from a plugin index
+type pluginInstaller interface {
+	Install(entry *plugin.IndexEntry, index string) error
+}
+
+func install() *cobra.Command {
+	flags := &installFlags{}
+	cmd := &cobra.Command{
+		Use:   "install <name>",
+		Short: "Install a plugin from the plugin index",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestIndex := ""
+			if flags.index == "" {
+				manifestIndex = getManifestIndex(flags.manifestPath, afero.NewOsFs())
+			}
+			index, err := getIndex(flags.index, manifestIndex)
+			if err != nil {
+				return err
+			}
+			return executeInstall(args[0], index, plugin.LoadIndex, plugin.NewManager())
+		},
+	}
+	cmd.Flags().StringVarP(&flags.index, "index", "", "", "path or url of the plugin index (defaults to 'plugins.index' in the okteto manifest or to the 'plugin-index' setting)")
+	cmd.Flags().StringVarP(&flags.manifestPath, "file", "f", "", "path to the okteto manifest that declares the plugin index")
+	return cmd
+}
+
+func executeInstall(name, index string, loadIndex func(string) (*plugin.Index, error), installer pluginInstaller) error {
+	i, err := loadIndex(index)
+	if err != nil {
+		return err
+	}
+	entry, err := i.Get(name)
+	if err != nil {
+		return err
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Installing plugin '%s'...", name))
+	oktetoLog.StartSpinner()
+	err = installer.Install(entry, index)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return err
+	}
+	oktetoLog.Success("Plugin '%s' %s installed. Run it with 'okteto %s'", entry.Name, entry.Version, entry.Name)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// pluginLister lists the plugins available
+type pluginLister interface {
+	List() []plugin.Plugin
+}
+
+func list() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List the plugins available",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeList(plugin.NewManager(), os.Stdout)
+		},
+	}
+}
+
+func executeList(lister pluginLister, w io.Writer) error {
+	plugins := lister.List()
+	if len(plugins) == 0 {
+		oktetoLog.Information("There are no plugins installed. Run 'okteto plugin install <name>' to install one")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Name\tVersion\tPath")
+	for _, p := range plugins {
+		version := p.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Name, version, p.Path)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/plugin"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// pluginFinder finds the executable that implements a subcommand
+type pluginFinder interface {
+	Find(name string) (*plugin.Plugin, error)
+}
+
+// Plugin okteto plugin management commands
+func Plugin() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage the plugins of the okteto CLI",
+		Long: `Manage the plugins of the okteto CLI.
+
+A plugin is an executable named 'okteto-<name>' that is run as 'okteto <name>'.
+Plugins are discovered in $OKTETO_HOME/plugins and in the folders of your PATH, and they
+receive the current okteto context in the OKTETO_CONTEXT, OKTETO_NAMESPACE, OKTETO_URL and OKTETO_TOKEN environment variables.
+
+Plugins are installed from the plugin index declared in the 'plugins.index' field of the okteto manifest
+or in the 'plugin-index' setting.`,
+	}
+	cmd.AddCommand(install())
+	cmd.AddCommand(list())
+	cmd.AddCommand(update())
+	return cmd
+}
+
+// Dispatch runs the plugin that implements the subcommand in args when it isn't a built-in command.
+// It returns false if args don't refer to a plugin
+func Dispatch(root *cobra.Command, args []string) (bool, error) {
+	return dispatch(root, args, plugin.NewManager(), runPlugin)
+}
+
+func dispatch(root *cobra.Command, args []string, finder pluginFinder, run func(p *plugin.Plugin, args []string) error) (bool, error) {
+	if len(args) == 0 || !plugin.IsValidName(args[0]) {
+		return false, nil
+	}
+	if _, _, err := root.Find(args); err == nil {
+		return false, nil
+	}
+	p, err := finder.Find(args[0])
+	if err != nil {
+		return false, nil
+	}
+	oktetoLog.Infof("running plugin '%s' from %s", p.Name, p.Path)
+	return true, run(p, args[1:])
+}
+
+func runPlugin(p *plugin.Plugin, args []string) error {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = plugin.Env(os.Environ())
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to run plugin '%s': %w", p.Name, err)
+	}
+	return err
+}
+
+// getIndex returns the plugin index set by the flag, declared in the okteto manifest or set in the user configuration
+func getIndex(flag, manifestIndex string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	if manifestIndex != "" {
+		return manifestIndex, nil
+	}
+	if index := config.GetSetting(okteto.GetContextStore().CurrentContext, config.PluginIndexSetting); index != "" {
+		return index, nil
+	}
+	return "", oktetoErrors.UserError{
+		E:    fmt.Errorf("the plugin index is not configured"),
+		Hint: fmt.Sprintf("Declare 'plugins.index' in your okteto manifest, run 'okteto config set %s <path or url>' or use the '--index' flag", config.PluginIndexSetting),
+	}
+}
+
+// getManifestIndex returns the plugin registry declared in the okteto manifest, if any
+func getManifestIndex(manifestPath string, fs afero.Fs) string {
+	model.UseSections(model.PluginsSection)
+	manifest, err := model.GetManifestV2(manifestPath, fs)
+	if err != nil {
+		oktetoLog.Infof("could not read the plugin index from the okteto manifest: %s", err)
+		return ""
+	}
+	return manifest.GetPluginIndex()
+}
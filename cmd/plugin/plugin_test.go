@@ -0,0 +1,220 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/plugin"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePluginManager struct {
+	plugins   map[string]*plugin.Plugin
+	receipts  []plugin.Receipt
+	installed []string
+	err       error
+}
+
+func (f *fakePluginManager) Find(name string) (*plugin.Plugin, error) {
+	if p, ok := f.plugins[name]; ok {
+		return p, nil
+	}
+	return nil, plugin.ErrPluginNotFound
+}
+
+func (f *fakePluginManager) List() []plugin.Plugin {
+	result := []plugin.Plugin{}
+	for _, name := range []string{"db", "lint"} {
+		if p, ok := f.plugins[name]; ok {
+			result = append(result, *p)
+		}
+	}
+	return result
+}
+
+func (f *fakePluginManager) GetReceipts() ([]plugin.Receipt, error) {
+	return f.receipts, nil
+}
+
+func (f *fakePluginManager) Install(entry *plugin.IndexEntry, index string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.installed = append(f.installed, entry.Name+"@"+entry.Version+"@"+index)
+	return nil
+}
+
+func newTestIndex(string) (*plugin.Index, error) {
+	return &plugin.Index{
+		Plugins: []plugin.IndexEntry{
+			{Name: "db", Version: "1.1.0"},
+			{Name: "lint", Version: "2.0.0"},
+		},
+	}, nil
+}
+
+func Test_dispatch(t *testing.T) {
+	root := &cobra.Command{Use: "okteto"}
+	root.AddCommand(&cobra.Command{Use: "up", Run: func(*cobra.Command, []string) {}})
+	finder := &fakePluginManager{
+		plugins: map[string]*plugin.Plugin{
+			"db": {Name: "db", Path: "/plugins/okteto-db"},
+			"up": {Name: "up", Path: "/plugins/okteto-up"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		expectedArgs []string
+		expected     bool
+	}{
+		{
+			name: "no args",
+		},
+		{
+			name: "flag",
+			args: []string{"--help"},
+		},
+		{
+			name: "built-in command",
+			args: []string{"up", "api"},
+		},
+		{
+			name: "unknown command",
+			args: []string{"lint"},
+		},
+		{
+			name:         "plugin",
+			args:         []string{"db", "migrate", "--dry-run"},
+			expected:     true,
+			expectedArgs: []string{"migrate", "--dry-run"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []string
+			handled, err := dispatch(root, tt.args, finder, func(p *plugin.Plugin, args []string) error {
+				ran = args
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, handled)
+			assert.Equal(t, tt.expectedArgs, ran)
+		})
+	}
+}
+
+func Test_executeList(t *testing.T) {
+	var b bytes.Buffer
+	m := &fakePluginManager{
+		plugins: map[string]*plugin.Plugin{
+			"db":   {Name: "db", Path: "/home/.okteto/plugins/okteto-db", Version: "1.0.0"},
+			"lint": {Name: "lint", Path: "/usr/local/bin/okteto-lint"},
+		},
+	}
+	require.NoError(t, executeList(m, &b))
+	assert.Equal(t, `Name  Version  Path
+db    1.0.0    /home/.okteto/plugins/okteto-db
+lint  -        /usr/local/bin/okteto-lint
+`, b.String())
+}
+
+func Test_executeInstall(t *testing.T) {
+	m := &fakePluginManager{}
+	require.NoError(t, executeInstall("db", "index.yaml", newTestIndex, m))
+	assert.Equal(t, []string{"db@1.1.0@index.yaml"}, m.installed)
+
+	assert.Error(t, executeInstall("cache", "index.yaml", newTestIndex, m))
+
+	m.err = assert.AnError
+	assert.ErrorIs(t, executeInstall("db", "index.yaml", newTestIndex, m), assert.AnError)
+}
+
+func Test_executeUpdate(t *testing.T) {
+	receipts := []plugin.Receipt{
+		{Name: "db", Version: "1.0.0", Index: "team.yaml"},
+		{Name: "lint", Version: "2.0.0", Index: "team.yaml"},
+		{Name: "legacy", Version: "0.1.0", Index: "team.yaml"},
+	}
+
+	tests := []struct {
+		name        string
+		plugin      string
+		index       string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "all",
+			expected: []string{"db@1.1.0@team.yaml"},
+		},
+		{
+			name:     "from another index",
+			index:    "org.yaml",
+			expected: []string{"db@1.1.0@org.yaml"},
+		},
+		{
+			name:   "up to date",
+			plugin: "lint",
+		},
+		{
+			name:        "not installed",
+			plugin:      "cache",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &fakePluginManager{receipts: receipts}
+			err := executeUpdate(tt.plugin, tt.index, newTestIndex, m)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m.installed)
+		})
+	}
+}
+
+func Test_getIndex(t *testing.T) {
+	index, err := getIndex("flag.yaml", "manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "flag.yaml", index)
+
+	index, err = getIndex("", "manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "manifest.yaml", index)
+}
+
+func Test_getManifestIndex(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`plugins:
+  index: https://plugins.example.com/index.yaml
+deploy:
+  - echo hello
+`), 0600))
+
+	assert.Equal(t, "https://plugins.example.com/index.yaml", getManifestIndex(manifestPath, afero.NewOsFs()))
+	assert.Equal(t, "", getManifestIndex(filepath.Join(dir, "missing.yml"), afero.NewOsFs()))
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+type updateFlags struct {
+	index string
+}
+
+// pluginUpdater updates the plugins installed from a plugin index
+type pluginUpdater interface {
+	pluginInstaller
+	GetReceipts() ([]plugin.Receipt, error)
+}
+
+func update() *cobra.Command {
+	flags := &updateFlags{}
+	cmd := &cobra.Command{
+		Use:   "update [name]",
+		Short: "Update the plugins installed from the plugin index",
+		Long: `Update the plugins installed from the plugin index.
+
+Every plugin is updated from the index it was installed from, unless the '--index' flag is set.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return executeUpdate(name, flags.index, plugin.LoadIndex, plugin.NewManager())
+		},
+	}
+	cmd.Flags().StringVarP(&flags.index, "index", "", "", "path or url of the plugin index (defaults to the index each plugin was installed from)")
+	return cmd
+}
+
+func executeUpdate(name, indexFlag string, loadIndex func(string) (*plugin.Index, error), updater pluginUpdater) error {
+	receipts, err := updater.GetReceipts()
+	if err != nil {
+		return fmt.Errorf("failed to read the installed plugins: %w", err)
+	}
+
+	found := false
+	indexes := map[string]*plugin.Index{}
+	for _, r := range receipts {
+		if name != "" && r.Name != name {
+			continue
+		}
+		found = true
+
+		index := indexFlag
+		if index == "" {
+			index = r.Index
+		}
+		if index == "" {
+			index, err = getIndex("", "")
+			if err != nil {
+				return err
+			}
+		}
+		if _, ok := indexes[index]; !ok {
+			indexes[index], err = loadIndex(index)
+			if err != nil {
+				return err
+			}
+		}
+		entry, err := indexes[index].Get(r.Name)
+		if err != nil {
+			oktetoLog.Warning("Skipping plugin '%s': %s", r.Name, err)
+			continue
+		}
+		if !r.IsOutdated(entry) {
+			oktetoLog.Information("Plugin '%s' is up to date (%s)", r.Name, r.Version)
+			continue
+		}
+		if err := updater.Install(entry, index); err != nil {
+			return err
+		}
+		oktetoLog.Success("Plugin '%s' updated from %s to %s", r.Name, r.Version, entry.Version)
+	}
+
+	if name != "" && !found {
+		return fmt.Errorf("plugin '%s' wasn't installed with 'okteto plugin install'", name)
+	}
+	if name == "" && !found {
+		oktetoLog.Information("There are no plugins installed from a plugin index")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"context"
+	"fmt"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+type convertPreviewCommand struct {
+	okClient types.OktetoInterface
+}
+
+// ConvertOptions are the options of the convert command
+type ConvertOptions struct {
+	name  string
+	scope string
+}
+
+// Convert changes the scope of a preview environment
+func Convert(ctx context.Context) *cobra.Command {
+	opts := &ConvertOptions{}
+	cmd := &cobra.Command{
+		Use:   "convert <name>",
+		Short: "Change the scope of a preview environment",
+		Long: `Change the scope of a preview environment without destroying it.
+
+Converting a personal preview environment to global shares it with all the members of your Okteto instance, and converting a global preview environment to personal restricts its access to you`,
+		Args: utils.ExactArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = getExpandedName(args[0])
+			if err := validatePreviewType(opts.scope); err != nil {
+				return err
+			}
+
+			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{}); err != nil {
+				return err
+			}
+
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+
+			okClient, err := okteto.NewOktetoClient()
+			if err != nil {
+				return err
+			}
+			c := convertPreviewCommand{okClient: okClient}
+			return c.run(ctx, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.scope, "scope", "s", "", "the new scope of the preview environment. Accepted values are ['personal', 'global']")
+	if err := cmd.MarkFlagRequired("scope"); err != nil {
+		oktetoLog.Infof("failed to mark 'scope' flag as required: %s", err)
+	}
+	return cmd
+}
+
+func (c convertPreviewCommand) run(ctx context.Context, opts *ConvertOptions) error {
+	preview, err := findPreview(ctx, c.okClient, opts.name)
+	if err != nil {
+		return err
+	}
+	if preview.Scope == opts.scope {
+		oktetoLog.Information("Preview environment '%s' is already %s", opts.name, opts.scope)
+		return nil
+	}
+
+	if err := c.okClient.Previews().UpdateScope(ctx, opts.name, opts.scope); err != nil {
+		if uErr, ok := err.(oktetoErrors.UserError); ok {
+			return uErr
+		}
+		return fmt.Errorf("failed to change the scope of preview environment '%s': %w", opts.name, err)
+	}
+	oktetoLog.Success("Preview environment '%s' converted to %s", opts.name, opts.scope)
+	return nil
+}
+
+// findPreview returns the preview environment called name
+func findPreview(ctx context.Context, okClient types.OktetoInterface, name string) (*types.Preview, error) {
+	previews, err := okClient.Previews().List(ctx, []string{})
+	if err != nil {
+		if uErr, ok := err.(oktetoErrors.UserError); ok {
+			return nil, uErr
+		}
+		return nil, fmt.Errorf("failed to get preview environments: %w", err)
+	}
+	for i := range previews {
+		if previews[i].ID == name {
+			return &previews[i], nil
+		}
+	}
+	return nil, oktetoErrors.UserError{
+		E:    fmt.Errorf("preview environment '%s' not found", name),
+		Hint: "Run 'okteto preview list' to see your preview environments",
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertPreview(t *testing.T) {
+	previews := []types.Preview{
+		{ID: "personal-preview", Scope: "personal"},
+		{ID: "global-preview", Scope: "global"},
+	}
+	tests := []struct {
+		expectedErr   error
+		name          string
+		opts          *ConvertOptions
+		errUpdate     error
+		expectedScope string
+	}{
+		{
+			name:          "personal to global",
+			opts:          &ConvertOptions{name: "personal-preview", scope: "global"},
+			expectedScope: "global",
+		},
+		{
+			name: "already in scope",
+			opts: &ConvertOptions{name: "global-preview", scope: "global"},
+		},
+		{
+			name:        "not found",
+			opts:        &ConvertOptions{name: "unknown", scope: "global"},
+			expectedErr: oktetoErrors.UserError{},
+		},
+		{
+			name:        "update error",
+			opts:        &ConvertOptions{name: "global-preview", scope: "personal"},
+			errUpdate:   assert.AnError,
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &client.FakePreviewResponse{
+				PreviewList:    previews,
+				ErrUpdateScope: tt.errUpdate,
+			}
+			c := convertPreviewCommand{
+				okClient: &client.FakeOktetoClient{
+					Preview: client.NewFakePreviewClient(response),
+				},
+			}
+			err := c.run(context.Background(), tt.opts)
+			if tt.expectedErr != nil {
+				assert.ErrorAs(t, err, &tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedScope, response.UpdatedScope)
+		})
+	}
+}
@@ -49,6 +49,7 @@ type DeployOptions struct {
 	variables          []string
 	labels             []string
 	timeout            time.Duration
+	ttl                time.Duration
 	wait               bool
 }
 
@@ -94,6 +95,7 @@ func Deploy(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "wait until the preview environment deployment finishes (defaults to false)")
 	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "relative path within the repository to the okteto manifest (default to okteto.yaml or .okteto/okteto.yaml)")
 	cmd.Flags().StringArrayVarP(&opts.labels, "label", "", []string{}, "set a preview environment label (can be set more than once)")
+	cmd.Flags().DurationVarP(&opts.ttl, "ttl", "", 0, "time after which the preview environment expires, e.g. 48h. Expired environments are reported on later CLI runs")
 
 	cmd.Flags().StringVarP(&opts.deprecatedFilename, "filename", "", "", "relative path within the repository to the manifest file (default to okteto-pipeline.yaml or .okteto/okteto-pipeline.yaml)")
 	if err := cmd.Flags().MarkHidden("filename"); err != nil {
@@ -20,7 +20,9 @@ import (
 
 	"github.com/docker/docker/pkg/namesgenerator"
 	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	modelUtils "github.com/okteto/okteto/pkg/model/utils"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -36,6 +38,16 @@ func optionsSetup(cwd string, opts *DeployOptions, args []string) error {
 		return err
 	}
 
+	if opts.ttl < 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid time to live '%s': it must be greater than zero", opts.ttl),
+			Hint: "Use a duration like '--ttl 48h'",
+		}
+	}
+	if opts.ttl > 0 {
+		opts.variables = append(opts.variables, fmt.Sprintf("%s=%s", constants.OktetoDeployTTLEnvVar, opts.ttl))
+	}
+
 	if len(args) == 0 {
 		opts.name = getRandomName(opts.scope)
 	} else {
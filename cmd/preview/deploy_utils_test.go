@@ -15,6 +15,7 @@ package preview
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -141,3 +142,26 @@ func Test_getPreviewURL(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func Test_optionsSetupWithTTL(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.Context{
+			"test": {},
+		},
+	}
+	opts := &DeployOptions{
+		scope:      "global",
+		repository: "test-repository",
+		branch:     "test-branch",
+		variables:  []string{"A=B"},
+		ttl:        48 * time.Hour,
+	}
+	err := optionsSetup(t.TempDir(), opts, []string{"preview-name"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A=B", "OKTETO_DEPLOY_TTL=48h0m0s"}, opts.variables)
+
+	opts.ttl = -time.Hour
+	err = optionsSetup(t.TempDir(), opts, []string{"preview-name"})
+	assert.ErrorContains(t, err, "must be greater than zero")
+}
@@ -49,8 +49,9 @@ func newDestroyPreviewCommand(okClient types.OktetoInterface, k8sClient kubernet
 }
 
 type DestroyOptions struct {
-	name string
-	wait bool
+	name  string
+	scope string
+	wait  bool
 }
 
 // Destroy destroy a preview
@@ -62,6 +63,11 @@ func Destroy(ctx context.Context) *cobra.Command {
 		Args:  utils.ExactArgsAccepted(1, ""),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.name = getExpandedName(args[0])
+			if opts.scope != "" {
+				if err := validatePreviewType(opts.scope); err != nil {
+					return err
+				}
+			}
 
 			ctxResource := &model.ContextResource{}
 			if err := ctxResource.UpdateNamespace(opts.name); err != nil {
@@ -93,10 +99,24 @@ func Destroy(ctx context.Context) *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", true, "wait until the preview environment gets destroyed (defaults to true)")
+	cmd.Flags().StringVarP(&opts.scope, "scope", "s", "", "only destroy the preview environment if it has the given scope. Accepted values are ['personal', 'global']")
 	return cmd
 }
 
 func (c destroyPreviewCommand) executeDestroyPreview(ctx context.Context, opts *DestroyOptions) error {
+	if opts.scope != "" {
+		preview, err := findPreview(ctx, c.okClient, opts.name)
+		if err != nil {
+			return err
+		}
+		if preview.Scope != opts.scope {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("preview environment '%s' is %s, not %s", opts.name, preview.Scope, opts.scope),
+				Hint: "Remove the '--scope' flag to destroy it anyway",
+			}
+		}
+	}
+
 	oktetoLog.Spinner(fmt.Sprintf("Destroying %q preview environment", opts.name))
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
@@ -19,6 +19,8 @@ import (
 
 	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
@@ -150,3 +152,27 @@ func TestExecuteDestroyPreviewWithErrorStreaming(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, previewResponse.DestroySuccessCount)
 }
+
+func TestExecuteDestroyPreviewWithDifferentScope(t *testing.T) {
+	ctx := context.Background()
+	opts := &DestroyOptions{
+		name:  "test-preview",
+		scope: "personal",
+	}
+	previewResponse := client.FakePreviewResponse{
+		PreviewList: []types.Preview{{ID: "test-preview", Scope: "global"}},
+	}
+	command := destroyPreviewCommand{
+		okClient: &client.FakeOktetoClient{
+			Preview: client.NewFakePreviewClient(
+				&previewResponse,
+			),
+		},
+		k8sClient: fake.NewSimpleClientset(),
+	}
+
+	err := command.executeDestroyPreview(ctx, opts)
+
+	require.ErrorAs(t, err, &oktetoErrors.UserError{})
+	require.Equal(t, 0, previewResponse.DestroySuccessCount)
+}
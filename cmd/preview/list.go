@@ -22,6 +22,7 @@ import (
 	"text/tabwriter"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
@@ -36,7 +37,9 @@ var (
 // listFlags are the flags available for list commands
 type listFlags struct {
 	output string
+	scope  string
 	labels []string
+	page   utils.ListPage
 }
 
 type previewOutput struct {
@@ -50,11 +53,13 @@ type previewOutput struct {
 type listPreviewCommand struct {
 	okClient types.OktetoInterface
 	flags    *listFlags
+	cache    *utils.ListCache
 }
 
 func newListPreviewCommand(okClient types.OktetoInterface, flags *listFlags) *listPreviewCommand {
 	return &listPreviewCommand{
-		okClient, flags,
+		okClient: okClient,
+		flags:    flags,
 	}
 }
 
@@ -65,6 +70,10 @@ func List(ctx context.Context) *cobra.Command {
 		Use:   "list",
 		Short: "List all preview environments",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.page.Validate(); err != nil {
+				return err
+			}
+
 			ctxOptions := &contextCMD.Options{}
 
 			if flags.output == "" {
@@ -84,11 +93,14 @@ func List(ctx context.Context) *cobra.Command {
 				return err
 			}
 			listCmd := newListPreviewCommand(okClient, flags)
+			listCmd.cache = utils.NewListCache()
 			return listCmd.run(ctx)
 		},
 	}
 	cmd.Flags().StringArrayVarP(&flags.labels, "label", "", []string{}, "tag and organize preview environments using labels (multiple --label flags accepted)")
 	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	cmd.Flags().StringVarP(&flags.scope, "scope", "s", "", "only list the preview environments of the given scope. Accepted values are ['personal', 'global']")
+	flags.page.AddFlags(cmd.Flags())
 
 	return cmd
 }
@@ -98,8 +110,16 @@ func (cmd *listPreviewCommand) run(ctx context.Context) error {
 	if err := validatePreviewListOutput(cmd.flags.output); err != nil {
 		return err
 	}
+	if cmd.flags.scope != "" {
+		if err := validatePreviewType(cmd.flags.scope); err != nil {
+			return err
+		}
+	}
 
-	previewList, err := cmd.okClient.Previews().List(ctx, cmd.flags.labels)
+	key := utils.ListCacheKey(append([]string{"previews", okteto.GetContext().Name}, cmd.flags.labels...)...)
+	previewList, err := utils.FetchList(cmd.cache, key, cmd.flags.page, func() ([]types.Preview, error) {
+		return cmd.okClient.Previews().List(ctx, cmd.flags.labels)
+	})
 	if err != nil {
 		if uErr, ok := err.(oktetoErrors.UserError); ok {
 			return uErr
@@ -107,7 +127,8 @@ func (cmd *listPreviewCommand) run(ctx context.Context) error {
 		return fmt.Errorf("failed to get preview environments: %w", err)
 	}
 
-	previewOutput := getPreviewOutput(previewList)
+	previews := utils.Paginate(filterPreviewsByScope(previewList, cmd.flags.scope), cmd.flags.page)
+	previewOutput := getPreviewOutput(previews)
 	return displayListPreviews(previewOutput, cmd.flags.output)
 }
 
@@ -172,6 +193,20 @@ func getPreviewOutput(previews []types.Preview) []previewOutput {
 	return previewSlice
 }
 
+// filterPreviewsByScope returns the previews of the given scope, or all of them if scope is empty
+func filterPreviewsByScope(previews []types.Preview, scope string) []types.Preview {
+	if scope == "" {
+		return previews
+	}
+	result := []types.Preview{}
+	for _, p := range previews {
+		if p.Scope == scope {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
 // validatePreviewListOutput returns error if output flag is not valid
 func validatePreviewListOutput(output string) error {
 	switch output {
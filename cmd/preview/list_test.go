@@ -425,3 +425,13 @@ func Test_run(t *testing.T) {
 	}
 
 }
+
+func Test_filterPreviewsByScope(t *testing.T) {
+	previews := []types.Preview{
+		{ID: "a", Scope: "personal"},
+		{ID: "b", Scope: "global"},
+	}
+	assert.Equal(t, previews, filterPreviewsByScope(previews, ""))
+	assert.Equal(t, []types.Preview{{ID: "b", Scope: "global"}}, filterPreviewsByScope(previews, "global"))
+	assert.Empty(t, filterPreviewsByScope(previews[:1], "global"))
+}
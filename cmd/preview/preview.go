@@ -45,6 +45,7 @@ func Preview(ctx context.Context) *cobra.Command {
 
 	cmd.AddCommand(Deploy(ctx))
 	cmd.AddCommand(Destroy(ctx))
+	cmd.AddCommand(Convert(ctx))
 	cmd.AddCommand(List(ctx))
 	cmd.AddCommand(Endpoints(ctx))
 	cmd.AddCommand(Sleep(ctx))
@@ -0,0 +1,72 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/prompt"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// Prompt prints the current context, namespace and active development sessions for shell prompts
+func Prompt() *cobra.Command {
+	var format string
+	var output string
+	cmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print the current okteto context and namespace for your shell prompt",
+		Long: `Print the current okteto context, namespace and active development sessions for your shell prompt.
+
+It only reads local files and caches the result, so it's fast enough to run on every prompt. For example:
+
+  PS1='$(okteto prompt) \$ '
+
+The output can be customized with a Go template using the fields .Context, .Namespace and .DevSessions`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "" && output != "json" {
+				return fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
+			}
+
+			info, err := prompt.NewResolver(afero.NewOsFs(), config.GetOktetoHome()).Get()
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				b, err := json.Marshal(info)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+				return nil
+			}
+
+			result, err := prompt.Render(info, format)
+			if err != nil {
+				return err
+			}
+			fmt.Print(result)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&format, "format", "f", prompt.DefaultFormat, "Go template to render the prompt")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// pipelineManifestField is the field of the pipeline configmaps with the base64 encoded okteto manifest
+const pipelineManifestField = "yaml"
+
+// imageReferenceRegex matches the image references in the data of the pipeline configmaps
+var imageReferenceRegex = regexp.MustCompile(`([a-zA-Z0-9][\w.-]*(?::\d+)?)/([a-z0-9][\w./-]*(?::[\w][\w.-]*)?(?:@sha256:[a-f0-9]{64})?)`)
+
+type gcFlags struct {
+	context   string
+	namespace string
+	olderThan time.Duration
+	dryRun    bool
+}
+
+func gc(ctx context.Context) *cobra.Command {
+	flags := &gcFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete the images of the okteto registry that are not referenced by the namespace",
+		Long: `Delete the images of the okteto registry that are not referenced by the namespace.
+
+Images pushed to the namespace are garbage collected when they are not referenced by any workload
+or pipeline configmap of the namespace and they were built before the '--older-than' window.
+
+Use '--dry-run' to list the images that would be deleted without deleting them.`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return gcCommandHandler(ctx, flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the images were pushed (defaults to the current context)")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the images were pushed (defaults to the current namespace)")
+	cmd.Flags().DurationVarP(&flags.olderThan, "older-than", "", defaultKeepRecent, "only delete the images built before this period")
+	cmd.Flags().BoolVarP(&flags.dryRun, "dry-run", "", false, "list the images that would be deleted without deleting them")
+	return cmd
+}
+
+func gcCommandHandler(ctx context.Context, flags *gcFlags) error {
+	namespace, c, err := loadOktetoContext(ctx, flags.context, flags.namespace)
+	if err != nil {
+		return err
+	}
+	flags.namespace = namespace
+
+	return executeGC(ctx, *flags, registry.NewOktetoRegistry(okteto.Config{}), c, okteto.GetContext().Registry, time.Now(), os.Stdout)
+}
+
+func executeGC(ctx context.Context, opts gcFlags, reg devImagesRegistry, c kubernetes.Interface, registryURL string, now time.Time, w io.Writer) error {
+	images, err := reg.ListDevImages(opts.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list the images of namespace '%s': %w", opts.namespace, err)
+	}
+
+	references, err := getReferencedImages(ctx, opts.namespace, c)
+	if err != nil {
+		return fmt.Errorf("failed to list the images used by namespace '%s': %w", opts.namespace, err)
+	}
+	if err := addPipelineReferences(ctx, opts.namespace, registryURL, c, references); err != nil {
+		return fmt.Errorf("failed to list the images referenced by the pipelines of namespace '%s': %w", opts.namespace, err)
+	}
+
+	stale := getStaleImages(images, references, now.Add(-opts.olderThan))
+	if len(stale) == 0 {
+		oktetoLog.Success("No unreferenced images found in namespace '%s'", opts.namespace)
+		return nil
+	}
+
+	if err := printStaleImages(w, stale); err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		oktetoLog.Information("Found %d unreferenced images. Run 'okteto registry gc' without '--dry-run' to delete them", len(stale))
+		return nil
+	}
+
+	deleted, err := deleteStaleImages(reg, stale)
+	if deleted > 0 {
+		oktetoLog.Success("Deleted %d unreferenced images from namespace '%s'", deleted, opts.namespace)
+	}
+	return err
+}
+
+// addPipelineReferences adds the images of the registry referenced by the pipeline configmaps of the namespace,
+// including their okteto manifests. Images of the okteto dev registry are expanded to the registry of the namespace
+func addPipelineReferences(ctx context.Context, namespace, registryURL string, c kubernetes.Interface, refs imageReferences) error {
+	cmaps, err := c.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true", model.GitDeployLabel),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cmap := range cmaps.Items {
+		for key, value := range cmap.Data {
+			if key == pipelineManifestField {
+				decoded, err := base64.StdEncoding.DecodeString(value)
+				if err != nil {
+					oktetoLog.Infof("could not decode the manifest of pipeline '%s': %s", cmap.Name, err)
+					continue
+				}
+				value = string(decoded)
+			}
+			for _, image := range getRegistryImages(value, namespace, registryURL) {
+				refs.add(image)
+			}
+		}
+	}
+	return nil
+}
+
+// getRegistryImages returns the images of the registry referenced in a text
+func getRegistryImages(text, namespace, registryURL string) []string {
+	result := []string{}
+	for _, match := range imageReferenceRegex.FindAllStringSubmatch(text, -1) {
+		host, repo := match[1], match[2]
+		switch host {
+		case registryURL:
+		case constants.DevRegistry:
+			repo = fmt.Sprintf("%s/%s", namespace, repo)
+		default:
+			continue
+		}
+
+		image := fmt.Sprintf("%s/%s", registryURL, repo)
+		if !strings.Contains(repo, "@") && !strings.Contains(repo[strings.LastIndex(repo, "/")+1:], ":") {
+			image = fmt.Sprintf("%s:latest", image)
+		}
+		result = append(result, image)
+	}
+	return result
+}
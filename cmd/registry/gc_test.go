@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetRegistryImages(t *testing.T) {
+	text := `build:
+  api:
+    image: okteto.dev/api:5678
+deploy:
+  - helm upgrade --install api chart --set image=registry.okteto.dev/cindy/worker@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+  - kubectl apply -f https://github.com/okteto/movies/k8s.yml
+  - docker run registry.okteto.dev/cindy/frontend`
+
+	images := getRegistryImages(text, "cindy", "registry.okteto.dev")
+	assert.Equal(t, []string{
+		"registry.okteto.dev/cindy/api:5678",
+		"registry.okteto.dev/cindy/worker@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		"registry.okteto.dev/cindy/frontend:latest",
+	}, images)
+}
+
+func TestExecuteGC(t *testing.T) {
+	manifest := "build:\n  api:\n    image: okteto.dev/api:5678\n"
+	pipeline := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "okteto-git-movies",
+			Namespace: "cindy",
+			Labels:    map[string]string{model.GitDeployLabel: "true"},
+		},
+		Data: map[string]string{
+			"yaml":      base64.StdEncoding.EncodeToString([]byte(manifest)),
+			"variables": "FRONTEND_IMAGE=registry.okteto.dev/cindy/frontend:okteto",
+		},
+	}
+
+	var tests = []struct {
+		name            string
+		expectedDeleted []string
+		dryRun          bool
+	}{
+		{
+			name:   "dry run",
+			dryRun: true,
+		},
+		{
+			name:            "delete",
+			expectedDeleted: []string{"registry.okteto.dev/cindy/worker:abcd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := getFakeWorkloads()
+			_, err := c.CoreV1().ConfigMaps("cindy").Create(context.Background(), pipeline, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			reg := &fakeDevImagesRegistry{images: devImages}
+			opts := gcFlags{namespace: "cindy", olderThan: defaultKeepRecent, dryRun: tt.dryRun}
+			out := &bytes.Buffer{}
+
+			err = executeGC(context.Background(), opts, reg, c, "registry.okteto.dev", pruneNow, out)
+			require.NoError(t, err)
+			// the image referenced by the manifest of the pipeline is kept
+			assert.NotContains(t, out.String(), "registry.okteto.dev/cindy/api:5678")
+			assert.Contains(t, out.String(), "registry.okteto.dev/cindy/worker:abcd")
+			assert.Equal(t, tt.expectedDeleted, reg.deleted)
+		})
+	}
+}
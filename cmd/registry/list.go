@@ -0,0 +1,100 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+type listFlags struct {
+	context   string
+	namespace string
+}
+
+type devImagesLister interface {
+	ListDevImages(namespace string) ([]registry.DevImage, error)
+}
+
+func list(ctx context.Context) *cobra.Command {
+	flags := &listFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "list [namespace]",
+		Short:   "List the images stored in the okteto registry for a namespace",
+		Aliases: []string{"ls"},
+		Long: `List the images stored in the okteto registry for a namespace.
+
+Every tag of the repositories pushed to the namespace is listed with its digest, its compressed size,
+the time the image was built and whether it's referenced by a workload of the namespace.`,
+		Args: utils.MaximumNArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				flags.namespace = args[0]
+			}
+			return listCommandHandler(ctx, flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the images were pushed (defaults to the current context)")
+	return cmd
+}
+
+func listCommandHandler(ctx context.Context, flags *listFlags) error {
+	namespace, c, err := loadOktetoContext(ctx, flags.context, flags.namespace)
+	if err != nil {
+		return err
+	}
+
+	return executeList(ctx, namespace, registry.NewOktetoRegistry(okteto.Config{}), c, os.Stdout)
+}
+
+func executeList(ctx context.Context, namespace string, reg devImagesLister, c kubernetes.Interface, w io.Writer) error {
+	images, err := reg.ListDevImages(namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list the images of namespace '%s': %w", namespace, err)
+	}
+	if len(images) == 0 {
+		oktetoLog.Information("There are no images in the okteto registry for namespace '%s'", namespace)
+		return nil
+	}
+
+	references, err := getReferencedImages(ctx, namespace, c)
+	if err != nil {
+		return fmt.Errorf("failed to list the images used by namespace '%s': %w", namespace, err)
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Image\tDigest\tSize\tBuilt\tIn use")
+	for _, image := range images {
+		inUse := "no"
+		if references.images[image.Image] || references.digests[image.Digest] {
+			inUse = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", image.Image, image.Digest, units.HumanSize(float64(image.Size)), image.Created.Format(time.RFC3339), inUse)
+	}
+	return tw.Flush()
+}
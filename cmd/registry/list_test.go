@@ -0,0 +1,68 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeDevImagesLister struct {
+	err    error
+	images []registry.DevImage
+}
+
+func (f fakeDevImagesLister) ListDevImages(_ string) ([]registry.DevImage, error) {
+	return f.images, f.err
+}
+
+func TestExecuteList(t *testing.T) {
+	images := []registry.DevImage{
+		{Image: "registry.okteto.dev/cindy/api:1234", Digest: "sha256:api", Created: oldBuild, Size: 25 * 1000 * 1000},
+		{Image: "registry.okteto.dev/cindy/api:5678", Digest: "sha256:old-api", Created: oldBuild, Size: 1000},
+		{Image: "registry.okteto.dev/cindy/frontend:okteto", Digest: "sha256:frontend", Created: oldBuild},
+	}
+	out := &bytes.Buffer{}
+
+	err := executeList(context.Background(), "cindy", fakeDevImagesLister{images: images}, getFakeWorkloads(), out)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, []string{"Image", "Digest", "Size", "Built", "In", "use"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"registry.okteto.dev/cindy/api:1234", "sha256:api", "25MB", "2023-02-13T00:00:00Z", "yes"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"registry.okteto.dev/cindy/api:5678", "sha256:old-api", "1kB", "2023-02-13T00:00:00Z", "no"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"registry.okteto.dev/cindy/frontend:okteto", "sha256:frontend", "0B", "2023-02-13T00:00:00Z", "yes"}, strings.Fields(lines[3]))
+}
+
+func TestExecuteListNoImages(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := executeList(context.Background(), "cindy", fakeDevImagesLister{}, fake.NewSimpleClientset(), out)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+}
+
+func TestExecuteListError(t *testing.T) {
+	errCatalog := errors.New("catalog not allowed")
+	err := executeList(context.Background(), "cindy", fakeDevImagesLister{err: errCatalog}, fake.NewSimpleClientset(), &bytes.Buffer{})
+	assert.ErrorIs(t, err, errCatalog)
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const defaultKeepRecent = 7 * 24 * time.Hour
+
+type pruneFlags struct {
+	context    string
+	namespace  string
+	keepRecent time.Duration
+	delete     bool
+}
+
+type devImagesRegistry interface {
+	ListDevImages(namespace string) ([]registry.DevImage, error)
+	DeleteDevImage(image registry.DevImage) error
+}
+
+func prune(ctx context.Context) *cobra.Command {
+	flags := &pruneFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete the images of the okteto registry that are no longer used",
+		Long: `Delete the images of the okteto registry that are no longer used.
+
+Images pushed to the namespace are stale when they are not referenced by any workload of the namespace
+and they were built before the '--keep-recent' window, so the images of recent pipelines are kept.
+
+By default, the stale images are only listed. Use '--delete' to delete them.`,
+		Args: utils.NoArgsAccepted(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pruneCommandHandler(ctx, flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the images were pushed (defaults to the current context)")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the images were pushed (defaults to the current namespace)")
+	cmd.Flags().DurationVarP(&flags.keepRecent, "keep-recent", "", defaultKeepRecent, "keep the images built within this period")
+	cmd.Flags().BoolVarP(&flags.delete, "delete", "", false, "delete the stale images instead of only listing them")
+	return cmd
+}
+
+// pruneCommandHandler prepares the okteto context depending on the provided flags and then prunes the registry
+func pruneCommandHandler(ctx context.Context, flags *pruneFlags) error {
+	namespace, c, err := loadOktetoContext(ctx, flags.context, flags.namespace)
+	if err != nil {
+		return err
+	}
+	flags.namespace = namespace
+
+	return executePrune(ctx, *flags, registry.NewOktetoRegistry(okteto.Config{}), c, time.Now(), os.Stdout)
+}
+
+// executePrune lists the stale images of the namespace and deletes them if requested
+func executePrune(ctx context.Context, opts pruneFlags, reg devImagesRegistry, c kubernetes.Interface, now time.Time, w io.Writer) error {
+	images, err := reg.ListDevImages(opts.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list the images of namespace '%s': %w", opts.namespace, err)
+	}
+
+	references, err := getReferencedImages(ctx, opts.namespace, c)
+	if err != nil {
+		return fmt.Errorf("failed to list the images used by namespace '%s': %w", opts.namespace, err)
+	}
+
+	stale := getStaleImages(images, references, now.Add(-opts.keepRecent))
+	if len(stale) == 0 {
+		oktetoLog.Success("No stale images found in namespace '%s'", opts.namespace)
+		return nil
+	}
+
+	if err := printStaleImages(w, stale); err != nil {
+		return err
+	}
+
+	if !opts.delete {
+		oktetoLog.Information("Found %d stale images. Run 'okteto registry prune --delete' to delete them", len(stale))
+		return nil
+	}
+
+	deleted, err := deleteStaleImages(reg, stale)
+	if deleted > 0 {
+		oktetoLog.Success("Deleted %d stale images from namespace '%s'", deleted, opts.namespace)
+	}
+	return err
+}
+
+func printStaleImages(w io.Writer, stale []registry.DevImage) error {
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Image\tDigest\tBuilt")
+	for _, image := range stale {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", image.Image, image.Digest, image.Created.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+// deleteStaleImages deletes the stale images and returns how many of them were deleted.
+// A failed deletion doesn't stop the deletion of the rest of the images
+func deleteStaleImages(reg devImagesRegistry, stale []registry.DevImage) (int, error) {
+	deleted := map[string]bool{}
+	failed := map[string]bool{}
+	count := 0
+	for _, image := range stale {
+		if failed[image.Digest] {
+			continue
+		}
+		// tags pointing to the same digest are deleted together
+		if !deleted[image.Digest] {
+			if err := reg.DeleteDevImage(image); err != nil {
+				oktetoLog.Warning("failed to delete image '%s': %s", image.Image, err)
+				failed[image.Digest] = true
+				continue
+			}
+			deleted[image.Digest] = true
+		}
+		count++
+	}
+	if count < len(stale) {
+		return count, fmt.Errorf("failed to delete %d of the %d stale images", len(stale)-count, len(stale))
+	}
+	return count, nil
+}
+
+// imageReferences are the images used by the workloads of a namespace
+type imageReferences struct {
+	images  map[string]bool
+	digests map[string]bool
+}
+
+func (r imageReferences) add(image string) {
+	r.images[image] = true
+	if i := strings.LastIndex(image, "@"); i != -1 {
+		r.digests[image[i+1:]] = true
+	}
+}
+
+// getReferencedImages returns the images used by the pods and the workload templates of the namespace,
+// so images of sleeping or scaled down workloads are not pruned
+func getReferencedImages(ctx context.Context, namespace string, c kubernetes.Interface) (imageReferences, error) {
+	refs := imageReferences{images: map[string]bool{}, digests: map[string]bool{}}
+	addPodSpec := func(spec apiv1.PodSpec) {
+		for _, container := range append(spec.InitContainers, spec.Containers...) {
+			refs.add(container.Image)
+		}
+	}
+
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return refs, err
+	}
+	for _, pod := range pods.Items {
+		addPodSpec(pod.Spec)
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			refs.add(status.ImageID)
+		}
+	}
+
+	deployments, err := c.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return refs, err
+	}
+	for _, d := range deployments.Items {
+		addPodSpec(d.Spec.Template.Spec)
+	}
+
+	statefulsets, err := c.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return refs, err
+	}
+	for _, sfs := range statefulsets.Items {
+		addPodSpec(sfs.Spec.Template.Spec)
+	}
+
+	cronjobs, err := c.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return refs, err
+	}
+	for _, cj := range cronjobs.Items {
+		addPodSpec(cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+	return refs, nil
+}
+
+// getStaleImages returns the images not referenced by any workload and built before keepSince
+func getStaleImages(images []registry.DevImage, refs imageReferences, keepSince time.Time) []registry.DevImage {
+	stale := []registry.DevImage{}
+	for _, image := range images {
+		// tags sharing a digest with a referenced image can't be deleted without deleting the referenced image
+		if refs.images[image.Image] || refs.digests[image.Digest] {
+			continue
+		}
+		if image.Created.After(keepSince) {
+			continue
+		}
+		stale = append(stale, image)
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].Image < stale[j].Image
+	})
+	return stale
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeDevImagesRegistry struct {
+	deleteErr map[string]error
+	images    []registry.DevImage
+	deleted   []string
+}
+
+func (f *fakeDevImagesRegistry) ListDevImages(_ string) ([]registry.DevImage, error) {
+	return f.images, nil
+}
+
+func (f *fakeDevImagesRegistry) DeleteDevImage(image registry.DevImage) error {
+	if err := f.deleteErr[image.Image]; err != nil {
+		return err
+	}
+	f.deleted = append(f.deleted, image.Image)
+	return nil
+}
+
+var (
+	pruneNow = time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)
+	oldBuild = pruneNow.Add(-30 * 24 * time.Hour)
+
+	devImages = []registry.DevImage{
+		{Image: "registry.okteto.dev/cindy/api:okteto", Digest: "sha256:api", Created: oldBuild},
+		{Image: "registry.okteto.dev/cindy/api:1234", Digest: "sha256:api", Created: oldBuild},
+		{Image: "registry.okteto.dev/cindy/api:5678", Digest: "sha256:old-api", Created: oldBuild},
+		{Image: "registry.okteto.dev/cindy/frontend:okteto", Digest: "sha256:frontend", Created: oldBuild},
+		{Image: "registry.okteto.dev/cindy/worker:okteto", Digest: "sha256:worker", Created: oldBuild},
+		{Image: "registry.okteto.dev/cindy/worker:abcd", Digest: "sha256:old-worker", Created: oldBuild},
+		{Image: "registry.okteto.dev/cindy/db:okteto", Digest: "sha256:db", Created: pruneNow.Add(-time.Hour)},
+	}
+)
+
+func getFakeWorkloads() *fake.Clientset {
+	return fake.NewSimpleClientset(
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "cindy"},
+			Spec: apiv1.PodSpec{
+				Containers: []apiv1.Container{{Name: "api", Image: "registry.okteto.dev/cindy/api:1234"}},
+			},
+			Status: apiv1.PodStatus{
+				ContainerStatuses: []apiv1.ContainerStatus{{ImageID: "docker-pullable://registry.okteto.dev/cindy/api@sha256:api"}},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "cindy"},
+			Spec: appsv1.StatefulSetSpec{
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "frontend", Image: "registry.okteto.dev/cindy/frontend@sha256:frontend"}},
+				}},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "cindy"},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{
+					InitContainers: []apiv1.Container{{Name: "init", Image: "registry.okteto.dev/cindy/worker:okteto"}},
+				}},
+			},
+		},
+	)
+}
+
+func TestGetStaleImages(t *testing.T) {
+	refs, err := getReferencedImages(context.Background(), "cindy", getFakeWorkloads())
+	require.NoError(t, err)
+
+	stale := getStaleImages(devImages, refs, pruneNow.Add(-defaultKeepRecent))
+	images := []string{}
+	for _, image := range stale {
+		images = append(images, image.Image)
+	}
+	assert.Equal(t, []string{
+		"registry.okteto.dev/cindy/api:5678",
+		"registry.okteto.dev/cindy/worker:abcd",
+	}, images)
+}
+
+func TestExecutePrune(t *testing.T) {
+	var tests = []struct {
+		name            string
+		expectedDeleted []string
+		delete          bool
+	}{
+		{
+			name: "dry run",
+		},
+		{
+			name:   "delete",
+			delete: true,
+			expectedDeleted: []string{
+				"registry.okteto.dev/cindy/api:5678",
+				"registry.okteto.dev/cindy/worker:abcd",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &fakeDevImagesRegistry{images: devImages}
+			opts := pruneFlags{namespace: "cindy", keepRecent: defaultKeepRecent, delete: tt.delete}
+			out := &bytes.Buffer{}
+
+			err := executePrune(context.Background(), opts, reg, getFakeWorkloads(), pruneNow, out)
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "registry.okteto.dev/cindy/api:5678")
+			assert.NotContains(t, out.String(), "registry.okteto.dev/cindy/db:okteto")
+			assert.Equal(t, tt.expectedDeleted, reg.deleted)
+		})
+	}
+}
+
+func TestExecutePruneNoStaleImages(t *testing.T) {
+	reg := &fakeDevImagesRegistry{}
+	out := &bytes.Buffer{}
+	err := executePrune(context.Background(), pruneFlags{namespace: "cindy", delete: true}, reg, fake.NewSimpleClientset(), pruneNow, out)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+	assert.Empty(t, reg.deleted)
+}
+
+func TestDeleteStaleImages(t *testing.T) {
+	stale := []registry.DevImage{
+		{Image: "registry.okteto.dev/cindy/api:1234", Digest: "sha256:api"},
+		{Image: "registry.okteto.dev/cindy/api:5678", Digest: "sha256:api"},
+		{Image: "registry.okteto.dev/cindy/worker:abcd", Digest: "sha256:worker"},
+		{Image: "registry.okteto.dev/cindy/db:okteto", Digest: "sha256:db"},
+	}
+	reg := &fakeDevImagesRegistry{
+		deleteErr: map[string]error{"registry.okteto.dev/cindy/worker:abcd": assert.AnError},
+	}
+
+	deleted, err := deleteStaleImages(reg, stale)
+	require.Error(t, err)
+	// both tags of the api digest are deleted by a single request, the failed worker image is not counted
+	assert.Equal(t, 3, deleted)
+	assert.Equal(t, []string{"registry.okteto.dev/cindy/api:1234", "registry.okteto.dev/cindy/db:okteto"}, reg.deleted)
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Registry okteto registry management commands
+func Registry(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Okteto registry management commands",
+	}
+	cmd.AddCommand(list(ctx))
+	cmd.AddCommand(prune(ctx))
+	cmd.AddCommand(gc(ctx))
+	return cmd
+}
+
+// loadOktetoContext loads the okteto context where the images were pushed and returns
+// the namespace of the images, that defaults to the namespace of the context
+func loadOktetoContext(ctx context.Context, contextName, namespace string) (string, kubernetes.Interface, error) {
+	ctxResource := &model.ContextResource{}
+	if contextName != "" {
+		if err := ctxResource.UpdateContext(contextName); err != nil {
+			return "", nil, err
+		}
+	}
+	if namespace != "" {
+		if err := ctxResource.UpdateNamespace(namespace); err != nil {
+			return "", nil, err
+		}
+	}
+
+	ctxOptions := &contextCMD.Options{
+		Context:   ctxResource.Context,
+		Namespace: ctxResource.Namespace,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return "", nil, err
+	}
+
+	okCtx := okteto.GetContext()
+	if !okCtx.IsOkteto {
+		return "", nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+	if namespace == "" {
+		namespace = okCtx.Namespace
+	}
+
+	c, _, err := okteto.NewK8sClientProvider().Provide(okCtx.Cfg)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load okteto context '%s': %w", okCtx.Name, err)
+	}
+	return namespace, c, nil
+}
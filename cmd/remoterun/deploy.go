@@ -16,6 +16,7 @@ package remoterun
 import (
 	"context"
 	"fmt"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	deployCMD "github.com/okteto/okteto/cmd/deploy"
@@ -36,6 +37,9 @@ type deployRunner interface {
 type DeployOptions struct {
 	Name      string
 	Variables []string
+	Timeout   time.Duration
+	RawOutput bool
+	Diff      bool
 }
 
 // DeployCommand struct with the dependencies needed to run the deploy operation
@@ -58,6 +62,14 @@ commands:
 - name: Echo deploy variable
   command: echo "This is a deploy variable ${DEPLOY_VARIABLE}"
 ...
+hooks:
+  pre:
+  - name: Backup the database
+    command: make backup
+  post:
+  - name: Run the database migrations
+    command: make migrate
+...
 external:
   fake:
     icon: dashboard
@@ -108,6 +120,7 @@ It is important that this command does the minimum and must not do calculations
 			runner, err := deployable.NewDeployRunnerForRemote(
 				options.Name,
 				false,
+				options.RawOutput,
 				cmapHandler,
 				k8sClientProvider,
 				model.GetAvailablePort,
@@ -116,6 +129,7 @@ It is important that this command does the minimum and must not do calculations
 			if err != nil {
 				return fmt.Errorf("could not initialize the command properly: %w", err)
 			}
+			runner.ResourcesWaiter = deployCMD.NewResourcesWaiter(k8sClientProvider, k8sLogger, options.Timeout)
 
 			params := deployable.DeployParameters{
 				Name:      options.Name,
@@ -124,6 +138,7 @@ It is important that this command does the minimum and must not do calculations
 				ManifestPath: ".",
 				Deployable:   dep,
 				Variables:    options.Variables,
+				Diff:         options.Diff,
 			}
 
 			c := &DeployCommand{
@@ -136,6 +151,9 @@ It is important that this command does the minimum and must not do calculations
 
 	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
 	cmd.Flags().StringArrayVarP(&options.Variables, "var", "v", []string{}, "set a variable (can be set more than once)")
+	cmd.Flags().BoolVar(&options.RawOutput, "raw-output", false, "show the output of the deploy commands as is")
+	cmd.Flags().BoolVar(&options.Diff, "diff", false, "show the changes of the deploy commands to each resource before they are applied")
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", 5*time.Minute, "the length of time to wait for the deployed resources to be running before the post-deploy hooks")
 	return cmd
 }
 
@@ -84,7 +84,7 @@ commands:
 			}
 
 			runner := &deployable.TestRunner{
-				Executor: executor.NewExecutor(oktetoLog.GetOutputFormat(), false, ""),
+				Executor: executor.NewExecutor(oktetoLog.GetOutputFormat(), false, "", false),
 				Fs:       afero.NewOsFs(),
 			}
 
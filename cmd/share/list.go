@@ -0,0 +1,94 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func list(ctx context.Context) *cobra.Command {
+	flags := &Flags{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the share links of your development environment",
+		Args:  utils.NoArgsAccepted(docsURL),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(flags.output); err != nil {
+				return err
+			}
+			sc, err := initShareCommand(ctx, flags)
+			if err != nil {
+				return err
+			}
+			return sc.executeList(ctx, flags, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace of the share links (defaults to the current namespace)")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	return cmd
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json', 'yaml']")
+	}
+}
+
+func (sc *Command) executeList(ctx context.Context, flags *Flags, w io.Writer) error {
+	links, err := sc.okClient.Share().List(ctx, flags.namespace)
+	if err != nil {
+		return err
+	}
+
+	switch flags.output {
+	case "json":
+		bytes, err := json.MarshalIndent(links, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(links)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	default:
+		if len(links) == 0 {
+			fmt.Fprintf(w, "There are no share links in namespace '%s'\n", flags.namespace)
+			return nil
+		}
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join([]string{"ID", "Endpoint", "URL", "Expires"}, "\t"))
+		for _, l := range links {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", l.ID, l.Endpoint, l.URL, l.ExpiresAt.Local().Format(time.RFC1123))
+		}
+		tw.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+func revoke(ctx context.Context) *cobra.Command {
+	flags := &Flags{}
+	cmd := &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "Revoke a share link before it expires",
+		Args:  utils.ExactArgsAccepted(1, docsURL),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sc, err := initShareCommand(ctx, flags)
+			if err != nil {
+				return err
+			}
+			return sc.executeRevoke(ctx, args[0], flags.namespace)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace of the share link (defaults to the current namespace)")
+	return cmd
+}
+
+func (sc *Command) executeRevoke(ctx context.Context, id, namespace string) error {
+	if err := sc.okClient.Share().Revoke(ctx, namespace, id); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("share link '%s' not found in namespace '%s'", id, namespace),
+				Hint: "Run 'okteto share list' to see the share links of your development environment",
+			}
+		}
+		return err
+	}
+	oktetoLog.Success("Share link '%s' revoked", id)
+	return nil
+}
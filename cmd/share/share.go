@@ -0,0 +1,167 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultExpiration = 24 * time.Hour
+	minExpiration     = 5 * time.Minute
+	maxExpiration     = 7 * 24 * time.Hour
+
+	docsURL = "https://www.okteto.com/docs/reference/okteto-cli/#share"
+)
+
+// Command has the dependencies to run the share commands
+type Command struct {
+	okClient types.OktetoInterface
+}
+
+// Flags represents the user input for the share commands
+type Flags struct {
+	namespace string
+	output    string
+	expires   time.Duration
+}
+
+// NewCommand creates a share command
+func NewCommand() (*Command, error) {
+	c, err := okteto.NewOktetoClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Command{
+		okClient: c,
+	}, nil
+}
+
+// Share shares an endpoint of a namespace with external reviewers
+func Share(ctx context.Context) *cobra.Command {
+	flags := &Flags{}
+	cmd := &cobra.Command{
+		Use:   "share <endpoint>",
+		Short: "Share an endpoint of your development environment with external reviewers",
+		Long: `Share an endpoint of your development environment with external reviewers.
+
+It creates a time-limited URL that gives access to the endpoint to anyone who has it, without an Okteto account.
+Run 'okteto endpoints' to see the endpoints of your development environment, and 'okteto share revoke' to revoke access before the link expires.`,
+		Args: utils.ExactArgsAccepted(1, docsURL),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			endpoint, err := normalizeEndpoint(args[0])
+			if err != nil {
+				return err
+			}
+			if err := validateExpiration(flags.expires); err != nil {
+				return err
+			}
+			sc, err := initShareCommand(ctx, flags)
+			if err != nil {
+				return err
+			}
+			return sc.executeCreate(ctx, endpoint, flags, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace of the endpoint (defaults to the current namespace)")
+	cmd.Flags().DurationVar(&flags.expires, "expires", defaultExpiration, "time until the link expires, between 5m and 168h")
+
+	cmd.AddCommand(list(ctx))
+	cmd.AddCommand(revoke(ctx))
+	return cmd
+}
+
+// initShareCommand loads the okteto context and returns the command to manage the share links
+func initShareCommand(ctx context.Context, flags *Flags) (*Command, error) {
+	ctxResource := &model.ContextResource{}
+	if err := ctxResource.UpdateNamespace(flags.namespace); err != nil {
+		return nil, err
+	}
+
+	ctxOptions := &contextCMD.Options{
+		Namespace: ctxResource.Namespace,
+		Show:      flags.output == "",
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return nil, err
+	}
+
+	if !okteto.IsOkteto() {
+		return nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+
+	if flags.namespace == "" {
+		flags.namespace = okteto.GetContext().Namespace
+	}
+	return NewCommand()
+}
+
+// normalizeEndpoint returns the endpoint as an https URL. The scheme can be omitted
+func normalizeEndpoint(endpoint string) (string, error) {
+	value := strings.TrimSpace(endpoint)
+	if !strings.Contains(value, "://") {
+		value = fmt.Sprintf("https://%s", value)
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' is not a valid endpoint", endpoint),
+			Hint: "Run 'okteto endpoints' to see the endpoints of your development environment",
+		}
+	}
+	u.Scheme = "https"
+	return u.String(), nil
+}
+
+func validateExpiration(expires time.Duration) error {
+	if expires < minExpiration || expires > maxExpiration {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid expiration '%s'", expires),
+			Hint: fmt.Sprintf("Share links must expire between %s and %s", minExpiration, maxExpiration),
+		}
+	}
+	return nil
+}
+
+func (sc *Command) executeCreate(ctx context.Context, endpoint string, flags *Flags, w io.Writer) error {
+	link, err := sc.okClient.Share().Create(ctx, flags.namespace, endpoint, flags.expires)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("endpoint '%s' not found in namespace '%s'", endpoint, flags.namespace),
+				Hint: "Run 'okteto endpoints' to see the endpoints of your development environment",
+			}
+		}
+		return err
+	}
+
+	fmt.Fprintf(w, "Endpoint '%s' shared until %s:\n", link.Endpoint, link.ExpiresAt.Local().Format(time.RFC1123))
+	fmt.Fprintf(w, "  %s\n", link.URL)
+	fmt.Fprintf(w, "Run 'okteto share revoke %s' to revoke the access\n", link.ID)
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test/client"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "https url",
+			input:    "https://api-test.okteto.example.com/docs",
+			expected: "https://api-test.okteto.example.com/docs",
+		},
+		{
+			name:     "without scheme",
+			input:    "api-test.okteto.example.com",
+			expected: "https://api-test.okteto.example.com",
+		},
+		{
+			name:     "http url",
+			input:    "http://api-test.okteto.example.com",
+			expected: "https://api-test.okteto.example.com",
+		},
+		{
+			name:      "unsupported scheme",
+			input:     "tcp://api-test.okteto.example.com",
+			expectErr: true,
+		},
+		{
+			name:      "empty",
+			input:     " ",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := normalizeEndpoint(tt.input)
+			if tt.expectErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestValidateExpiration(t *testing.T) {
+	assert.NoError(t, validateExpiration(defaultExpiration))
+	assert.NoError(t, validateExpiration(maxExpiration))
+	assert.Error(t, validateExpiration(time.Minute))
+	assert.Error(t, validateExpiration(30*24*time.Hour))
+}
+
+func TestExecuteCreate(t *testing.T) {
+	shareClient := client.NewFakeShareClient()
+	sc := &Command{
+		okClient: &client.FakeOktetoClient{
+			ShareClient: shareClient,
+		},
+	}
+
+	var buf bytes.Buffer
+	err := sc.executeCreate(context.Background(), "https://api-test.okteto.example.com", &Flags{namespace: "test", expires: time.Hour}, &buf)
+	require.NoError(t, err)
+	require.Len(t, shareClient.Links, 1)
+	assert.Contains(t, buf.String(), "https://share.okteto.example.com/link-1")
+	assert.Contains(t, buf.String(), "okteto share revoke link-1")
+
+	shareClient.Err = oktetoErrors.ErrNotFound
+	err = sc.executeCreate(context.Background(), "https://web-test.okteto.example.com", &Flags{namespace: "test", expires: time.Hour}, &buf)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func TestExecuteList(t *testing.T) {
+	links := []types.ShareLink{
+		{
+			ID:        "abc",
+			Endpoint:  "https://api-test.okteto.example.com",
+			URL:       "https://share.okteto.example.com/abc",
+			ExpiresAt: time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	var tests = []struct {
+		name     string
+		links    []types.ShareLink
+		output   string
+		expected string
+	}{
+		{
+			name:     "empty",
+			expected: "There are no share links in namespace 'test'\n",
+		},
+		{
+			name:     "yaml",
+			links:    links,
+			output:   "yaml",
+			expected: "- expiresAt: 2026-10-16T10:00:00Z\n  id: abc\n  endpoint: https://api-test.okteto.example.com\n  url: https://share.okteto.example.com/abc\n  createdBy: \"\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &Command{
+				okClient: &client.FakeOktetoClient{
+					ShareClient: client.NewFakeShareClient(tt.links...),
+				},
+			}
+			var buf bytes.Buffer
+			err := sc.executeList(context.Background(), &Flags{namespace: "test", output: tt.output}, &buf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestExecuteRevoke(t *testing.T) {
+	shareClient := client.NewFakeShareClient(types.ShareLink{ID: "abc"})
+	sc := &Command{
+		okClient: &client.FakeOktetoClient{
+			ShareClient: shareClient,
+		},
+	}
+
+	require.NoError(t, sc.executeRevoke(context.Background(), "abc", "test"))
+	assert.Empty(t, shareClient.Links)
+
+	err := sc.executeRevoke(context.Background(), "abc", "test")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// composeOptions are the flags shared by the compose management commands
+type composeOptions struct {
+	name      string
+	namespace string
+	stackPath []string
+}
+
+func (o *composeOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&o.stackPath, "file", "f", []string{}, "path to the compose manifest files. If more than one is passed the latest will overwrite the fields from the previous")
+	cmd.Flags().StringVarP(&o.name, "name", "", "", "overwrites the compose name")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "overwrites the compose namespace where the compose is deployed")
+}
+
+func (o *composeOptions) load(ctx context.Context) (*model.Stack, kubernetes.Interface, error) {
+	s, err := contextCMD.LoadStackWithContext(ctx, o.name, o.namespace, o.stackPath, afero.NewOsFs())
+	if err != nil {
+		return nil, nil, err
+	}
+	c, _, err := okteto.GetK8sClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load your local Kubeconfig: %w", err)
+	}
+	return s, c, nil
+}
+
+// Ps lists the status of the compose services
+func Ps(ctx context.Context) *cobra.Command {
+	opts := &composeOptions{}
+	cmd := &cobra.Command{
+		Use:   "ps [service...]",
+		Short: "List the status of the compose services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, c, err := opts.load(ctx)
+			if err != nil {
+				return err
+			}
+			statuses, err := stack.GetServicesStatus(ctx, s, args, c)
+			if err != nil {
+				return err
+			}
+			return printServicesStatus(os.Stdout, statuses)
+		},
+	}
+	opts.addFlags(cmd)
+	return cmd
+}
+
+func printServicesStatus(out io.Writer, statuses []stack.ServiceStatus) error {
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Service\tKind\tStatus\tPods\n")
+	for _, st := range statuses {
+		pods := "-"
+		if st.Status != stack.ServiceStatusNotDeployed {
+			pods = fmt.Sprintf("%d/%d", st.Ready, st.Replicas)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", st.Name, st.Kind, st.Status, pods)
+	}
+	return w.Flush()
+}
+
+// Logs fetches the logs of the compose services
+func Logs(ctx context.Context) *cobra.Command {
+	opts := &composeOptions{}
+	logsOpts := stack.LogsOptions{}
+	cmd := &cobra.Command{
+		Use:   "logs [service...]",
+		Short: "Fetch the logs of the compose services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			s, c, err := opts.load(ctx)
+			if err != nil {
+				return err
+			}
+
+			go func() {
+				sigint := make(chan os.Signal, 1)
+				signal.Notify(sigint, syscall.SIGTERM, syscall.SIGINT)
+				<-sigint
+				cancel()
+			}()
+
+			if err := stack.StreamServicesLogs(ctx, s, args, logsOpts, os.Stdout, c); err != nil && ctx.Err() == nil {
+				return err
+			}
+			return nil
+		},
+	}
+	opts.addFlags(cmd)
+	cmd.Flags().BoolVarP(&logsOpts.Follow, "follow", "", false, "follow the log output")
+	cmd.Flags().Int64Var(&logsOpts.Tail, "tail", -1, "the number of lines from the end of the logs to show (defaults to all)")
+	cmd.Flags().BoolVarP(&logsOpts.Timestamps, "timestamps", "t", false, "print timestamps")
+	return cmd
+}
+
+// Restart restarts the compose services
+func Restart(ctx context.Context) *cobra.Command {
+	opts := &composeOptions{}
+	cmd := &cobra.Command{
+		Use:   "restart [service...]",
+		Short: "Restart the compose services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, c, err := opts.load(ctx)
+			if err != nil {
+				return err
+			}
+			return stack.RestartServices(ctx, s, args, c)
+		},
+	}
+	opts.addFlags(cmd)
+	return cmd
+}
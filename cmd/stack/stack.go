@@ -29,13 +29,17 @@ type buildTrackerInterface interface {
 // Stack stack management commands
 func Stack(ctx context.Context, at, insights buildTrackerInterface, ioCtrl *io.Controller) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:    "stack",
-		Short:  "Stack management commands",
-		Args:   utils.NoArgsAccepted("https://www.okteto.com/docs/reference/okteto-cli/#deploy"),
-		Hidden: true,
+		Use:     "stack",
+		Aliases: []string{"compose"},
+		Short:   "Stack management commands",
+		Args:    utils.NoArgsAccepted("https://www.okteto.com/docs/reference/okteto-cli/#deploy"),
+		Hidden:  true,
 	}
 	cmd.AddCommand(deploy(ctx, at, insights, ioCtrl))
 	cmd.AddCommand(Destroy(ctx))
 	cmd.AddCommand(Endpoints(ctx))
+	cmd.AddCommand(Ps(ctx))
+	cmd.AddCommand(Logs(ctx))
+	cmd.AddCommand(Restart(ctx))
 	return cmd
 }
@@ -15,9 +15,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
@@ -26,7 +31,9 @@ import (
 	"github.com/okteto/okteto/pkg/cmd/status"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/ingresses"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/afero"
@@ -44,6 +51,8 @@ func Status() *cobra.Command {
 	var k8sContext string
 	var showInfo bool
 	var watch bool
+	var all bool
+	var output string
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Status of the synchronization process",
@@ -56,6 +65,13 @@ func Status() *cobra.Command {
 
 			ctx := context.Background()
 
+			if all {
+				return runEnvironmentStatus(ctx, devPath, namespace, k8sContext, output)
+			}
+			if output != "" {
+				return fmt.Errorf("the '--output' flag can only be used with the '--all' flag")
+			}
+
 			manifestOpts := contextCMD.ManifestOptions{Filename: devPath, Namespace: namespace, K8sContext: k8sContext}
 			manifest, err := contextCMD.LoadManifestWithContext(ctx, manifestOpts, afero.NewOsFs())
 			if err != nil {
@@ -110,9 +126,125 @@ func Status() *cobra.Command {
 	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "context where the up command is executing")
 	cmd.Flags().BoolVarP(&showInfo, "info", "i", false, "show syncthing links for troubleshooting the synchronization service")
 	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch for changes")
+	cmd.Flags().BoolVarP(&all, "all", "", false, "show the health summary of the whole environment instead of the synchronization status")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format of the '--all' summary. One of: ['json']")
 	return cmd
 }
 
+// runEnvironmentStatus shows the rollout status, images, endpoints, pipelines and development containers of the namespace
+func runEnvironmentStatus(ctx context.Context, devPath, namespace, k8sContext, output string) error {
+	if output != "" && output != "json" {
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
+	}
+
+	ctxResource, err := model.GetContextResource(devPath)
+	if err != nil {
+		return err
+	}
+	if err := ctxResource.UpdateNamespace(namespace); err != nil {
+		return err
+	}
+	if err := ctxResource.UpdateContext(k8sContext); err != nil {
+		return err
+	}
+	ctxOptions := &contextCMD.Options{
+		Context:   ctxResource.Context,
+		Namespace: ctxResource.Namespace,
+		Show:      output == "",
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return err
+	}
+
+	// the manifest is optional, it's only used to compare the running images with the expected ones
+	manifest, err := model.GetManifestV2(devPath, afero.NewOsFs())
+	if err != nil {
+		oktetoLog.Infof("images are not compared with the okteto manifest: %s", err)
+		manifest = nil
+	}
+
+	c, _, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+	ic, err := ingresses.GetClient(c)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		oktetoLog.Spinner("Retrieving environment status...")
+		oktetoLog.StartSpinner()
+	}
+	envStatus, err := status.NewEnvironmentStatusGetter(c, ic).Get(ctx, okteto.GetContext().Namespace, manifest)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(envStatus, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, string(bytes))
+		return nil
+	}
+	printEnvironmentStatus(os.Stdout, envStatus)
+	return nil
+}
+
+func printEnvironmentStatus(w io.Writer, envStatus *status.EnvironmentStatus) {
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Service\tKind\tRollout\tReady\tImage\tDigest\tManifest\tDev mode")
+	for _, svc := range envStatus.Services {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d/%d\t%s\t%s\t%s\t%t\n", svc.Name, svc.Kind, svc.Rollout, svc.Ready, svc.Desired, svc.Image, orDash(svc.Digest), orDash(svc.ImageStatus), svc.InDevMode)
+	}
+
+	fmt.Fprintln(tw, "\nEndpoint\tHealth")
+	for _, ep := range envStatus.Endpoints {
+		health := "healthy"
+		switch {
+		case ep.Error != "":
+			health = fmt.Sprintf("unreachable: %s", ep.Error)
+		case !ep.Healthy:
+			health = fmt.Sprintf("unhealthy (%d)", ep.StatusCode)
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", ep.URL, health)
+	}
+
+	fmt.Fprintln(tw, "\nPipeline\tStatus")
+	for _, p := range envStatus.Pipelines {
+		fmt.Fprintf(tw, "%s\t%s\n", p.Name, p.Status)
+	}
+
+	if len(envStatus.HelmReleases) > 0 {
+		fmt.Fprintln(tw, "\nHelm release\tNamespace\tChart\tVersion\tRequired\tState")
+		for _, r := range envStatus.HelmReleases {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Name, r.Namespace, orDash(r.Chart), orDash(r.Version), orDash(r.RequiredVersion), r.State)
+		}
+	}
+
+	devSessions := make([]string, 0, len(envStatus.DevSessions))
+	for _, d := range envStatus.DevSessions {
+		state := "not ready"
+		if d.Ready {
+			state = "ready"
+		}
+		devSessions = append(devSessions, fmt.Sprintf("%s (%s)", d.Name, state))
+	}
+	fmt.Fprintf(tw, "\nActive dev sessions:\t%s\n", orDash(strings.Join(devSessions, ", ")))
+	tw.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 func runWithWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	textSpinner := "Synchronizing your files..."
 	oktetoLog.Spinner(textSpinner)
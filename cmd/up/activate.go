@@ -15,6 +15,7 @@ package up
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -35,6 +36,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
 func (up *upContext) activate() error {
@@ -50,6 +52,7 @@ func (up *upContext) activate() error {
 	up.Cancel = cancel
 	up.ShutdownCompleted = make(chan bool, 1)
 	up.Sy = nil
+	up.syncEngine = nil
 	up.Forwarder = nil
 	defer func() {
 		if !up.interruptReceived {
@@ -94,8 +97,24 @@ func (up *upContext) activate() error {
 		return err
 	}
 
+	if !up.isRetry && len(up.Dev.EnvRequired) > 0 {
+		validator, err := newRequiredEnvsValidator(k8sClient)
+		if err != nil {
+			return err
+		}
+		if err := validator.validate(ctx, up.Dev, app.PodSpec()); err != nil {
+			return err
+		}
+	}
+
+	engine, err := up.newSyncEngine()
+	if err != nil {
+		return err
+	}
+	up.syncEngine = engine
+
 	buildDevImage := false
-	if _, err := up.Registry.GetImageTagWithDigest(up.Dev.Image.Name); err == oktetoErrors.ErrNotFound {
+	if _, err := up.Registry.GetImageTagWithDigest(up.Dev.Image.Name); errors.Is(err, oktetoErrors.ErrNotFound) {
 		oktetoLog.Infof("image '%s' not found, building it: %s", up.Dev.Image.Name, err.Error())
 		path := up.Dev.Image.GetDockerfilePath(up.Fs)
 		if _, err := os.Stat(path); err != nil {
@@ -165,6 +184,7 @@ func (up *upContext) activate() error {
 		}
 		return fmt.Errorf("couldn't connect to your development container: %w", err)
 	}
+	up.updateHosts()
 	go up.cleanCommand(ctx)
 
 	if err := up.sync(ctx); err != nil {
@@ -177,6 +197,9 @@ func (up *upContext) activate() error {
 	// success means all context is ready to run the activation
 	up.success = true
 
+	go newDependencyHealthWatcher(up, k8sClient, os.Stdout).run(ctx)
+	go up.watchPause(ctx)
+
 	go func() {
 		output := <-up.cleaned
 		oktetoLog.Debugf("clean command output: %s", output)
@@ -210,6 +233,15 @@ func (up *upContext) activate() error {
 		durationActivateUp := time.Since(up.StartTime)
 		up.analyticsMeta.ActivateDuration(durationActivateUp)
 
+		if err := waitUntilReady(ctx, up.Dev, up.checkReadyProbe); err != nil {
+			oktetoLog.Infof("stopped waiting for the ready probe: %s", err)
+			return
+		}
+
+		if up.Dev.IsZeroDowntimeActivation() {
+			go up.shiftTrafficToDevContainer(ctx)
+		}
+
 		startRunCommand := time.Now()
 		up.CommandResult <- up.RunCommand(ctx, up.Dev.Command.Values)
 		up.analyticsMeta.ExecDuration(time.Since(startRunCommand))
@@ -237,7 +269,7 @@ func (up *upContext) shouldRetry(ctx context.Context, err error) bool {
 	case oktetoErrors.ErrLostSyncthing:
 		return true
 	case oktetoErrors.ErrCommandFailed:
-		return !up.Sy.Ping(ctx, false)
+		return !up.syncEngine.isConnected(ctx)
 	case oktetoErrors.ErrApplyToApp:
 		return true
 	}
@@ -278,6 +310,10 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		}
 	}
 
+	if err := apps.ValidateEnvFrom(ctx, up.Dev, k8sClient); err != nil {
+		return err
+	}
+
 	resetOnDevContainerStart := up.resetSyncthing || !up.Dev.PersistentVolumeEnabled()
 	trMap, err := apps.GetTranslations(ctx, up.Dev, app, resetOnDevContainerStart, k8sClient)
 	if err != nil {
@@ -289,6 +325,11 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 		return err
 	}
 
+	if up.Dev.Prefetch {
+		up.prefetchImages(ctx, trMap, k8sClient)
+		oktetoLog.Spinner(msg)
+	}
+
 	initSyncErr := <-up.hardTerminate
 	if initSyncErr != nil {
 		return initSyncErr
@@ -329,6 +370,23 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	return nil
 }
 
+// prefetchImages pre-pulls the development container images on the target node.
+// Prefetching is best effort: errors are shown as warnings and dev mode activation continues
+func (up *upContext) prefetchImages(ctx context.Context, trMap map[string]*apps.Translation, c kubernetes.Interface) {
+	oktetoLog.Spinner("Prefetching development container images...")
+	start := time.Now()
+	for _, tr := range trMap {
+		if tr.MainDev != tr.Dev {
+			continue
+		}
+		if err := apps.PrefetchImages(ctx, tr, c); err != nil {
+			oktetoLog.Warning("could not prefetch the development container images: %s", err)
+			return
+		}
+	}
+	oktetoLog.Infof("development container images prefetched in %s", time.Since(start))
+}
+
 func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context, app apps.App) error {
 	msg := "Preparing development environment..."
 	if !up.Dev.IsHybridModeEnabled() {
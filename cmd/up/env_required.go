@@ -0,0 +1,117 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// requiredEnvsValidator checks that the variables listed in 'envRequired' are defined for the development container
+type requiredEnvsValidator struct {
+	client                      kubernetes.Interface
+	devContainerEnvGetter       devContainerEnvGetterInterface
+	platformVariablesEnvsGetter platformVariablesEnvsGetterInterface
+	imageEnvsGetter             imageEnvsGetterInterface
+}
+
+func newRequiredEnvsValidator(client kubernetes.Interface) (*requiredEnvsValidator, error) {
+	var variablesGetter platformVariablesGetterInterface
+	if okteto.IsOkteto() {
+		oc, err := okteto.NewOktetoClient()
+		if err != nil {
+			return nil, err
+		}
+		variablesGetter = oc.User()
+	}
+
+	return &requiredEnvsValidator{
+		client:                client,
+		devContainerEnvGetter: &devContainerEnvGetter{},
+		platformVariablesEnvsGetter: &platformVariablesEnvsGetter{
+			variablesGetter: variablesGetter,
+		},
+		imageEnvsGetter: &imageEnvsGetter{
+			imageGetter: registry.NewOktetoRegistry(okteto.Config{}),
+		},
+	}, nil
+}
+
+// validate returns an error listing all the required variables that are not defined in the manifest,
+// the Okteto Variables, the image or the configmaps and secrets referenced by the original container
+func (v *requiredEnvsValidator) validate(ctx context.Context, dev *model.Dev, spec *apiv1.PodSpec) error {
+	if len(dev.EnvRequired) == 0 {
+		return nil
+	}
+
+	defined := map[string]bool{}
+	for _, e := range dev.Environment {
+		defined[e.Name] = true
+	}
+
+	platformEnvs, err := v.platformVariablesEnvsGetter.getEnvsFromPlatformVariables(ctx)
+	if err != nil {
+		return err
+	}
+	addEnvNames(defined, platformEnvs)
+
+	containerEnvs, err := v.devContainerEnvGetter.getEnvsFromDevContainer(ctx, spec, dev.Container, dev.Namespace, v.client)
+	if err != nil {
+		return err
+	}
+	addEnvNames(defined, containerEnvs)
+
+	image := apps.GetDevContainer(spec, dev.Container).Image
+	if dev.Image != nil && dev.Image.Name != "" {
+		image = dev.Image.Name
+	}
+	imageEnvs, err := v.imageEnvsGetter.getEnvsFromImage(image)
+	if err != nil {
+		oktetoLog.Infof("could not retrieve environment variables from the image '%s': %s", image, err)
+	}
+	addEnvNames(defined, imageEnvs)
+
+	missing := []string{}
+	for _, name := range dev.EnvRequired {
+		if !defined[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("the development container '%s' requires environment variables that are not defined:\n    - %s", dev.Name, strings.Join(missing, "\n    - ")),
+		Hint: `Define them in the 'environment' section of your okteto manifest, as Okteto Variables,
+    or in the configmaps and secrets referenced by your deployment`,
+	}
+}
+
+func addEnvNames(defined map[string]bool, envs []string) {
+	for _, e := range envs {
+		name, _, _ := strings.Cut(e, "=")
+		defined[name] = true
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestRequiredEnvsValidator(t *testing.T) {
+	spec := &apiv1.PodSpec{Containers: []apiv1.Container{{Name: "api", Image: "okteto/api"}}}
+	var tests = []struct {
+		platformGetter  *fakeGetter
+		containerGetter *fakeGetter
+		imageGetter     *fakeGetter
+		name            string
+		required        []string
+		expectedErr     string
+	}{
+		{
+			name:            "no required envs",
+			platformGetter:  &fakeGetter{err: errors.New("must not be called")},
+			containerGetter: &fakeGetter{},
+			imageGetter:     &fakeGetter{},
+		},
+		{
+			name:            "all defined",
+			required:        []string{"MANIFEST", "PLATFORM", "CONTAINER", "IMAGE"},
+			platformGetter:  &fakeGetter{envs: []string{"PLATFORM=value"}},
+			containerGetter: &fakeGetter{envs: []string{"CONTAINER=value"}},
+			imageGetter:     &fakeGetter{envs: []string{"IMAGE=value"}},
+		},
+		{
+			name:            "missing envs are reported together",
+			required:        []string{"MANIFEST", "DB_URL", "API_KEY"},
+			platformGetter:  &fakeGetter{},
+			containerGetter: &fakeGetter{},
+			imageGetter:     &fakeGetter{err: errors.New("image not found")},
+			expectedErr:     "the development container 'api' requires environment variables that are not defined:\n    - DB_URL\n    - API_KEY",
+		},
+		{
+			name:            "referenced secret not found",
+			required:        []string{"DB_URL"},
+			platformGetter:  &fakeGetter{},
+			containerGetter: &fakeGetter{err: errors.New("secret 'db' not found")},
+			imageGetter:     &fakeGetter{},
+			expectedErr:     "secret 'db' not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{
+				Name:        "api",
+				EnvRequired: tt.required,
+				Environment: env.Environment{{Name: "MANIFEST", Value: "value"}},
+			}
+			v := &requiredEnvsValidator{
+				platformVariablesEnvsGetter: tt.platformGetter,
+				devContainerEnvGetter:       tt.containerGetter,
+				imageEnvsGetter:             tt.imageGetter,
+			}
+			err := v.validate(context.Background(), dev, spec)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -107,12 +108,14 @@ func expandExecutableInCurrentDirectory(args0, dir string) (string, error) {
 }
 
 type syncExecutor struct {
+	stdin      io.Reader
 	iface      string
 	remotePort int
+	forwards   ssh.Forwards
 }
 
 func (se *syncExecutor) RunCommand(ctx context.Context, cmd []string) error {
-	return ssh.Exec(ctx, se.iface, se.remotePort, true, os.Stdin, os.Stdout, os.Stderr, cmd)
+	return ssh.Exec(ctx, se.iface, se.remotePort, true, se.stdin, os.Stdout, os.Stderr, se.forwards, cmd)
 }
 
 func NewHybridExecutor(ctx context.Context, hybridCtx *HybridExecCtx) (*hybridExecutor, error) {
@@ -134,8 +137,10 @@ func NewHybridExecutor(ctx context.Context, hybridCtx *HybridExecCtx) (*hybridEx
 
 func newSyncExecutor(up *upContext) *syncExecutor {
 	return &syncExecutor{
+		stdin:      up.getStdin(),
 		iface:      up.Dev.Interface,
 		remotePort: up.Dev.RemotePort,
+		forwards:   ssh.Forwards{X11: up.Dev.X11, Clipboard: up.Dev.Clipboard},
 	}
 }
 
@@ -395,14 +400,18 @@ func (up *upContext) cleanCommand(ctx context.Context) {
 	in := strings.NewReader("\n")
 	var out bytes.Buffer
 
-	cmd := "cat /var/okteto/bin/version.txt; cat /proc/sys/fs/inotify/max_user_watches; /var/okteto/bin/clean >/dev/null 2>&1"
-
 	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
 	if err != nil {
 		oktetoLog.Infof("failed to clean session: %s", err)
 		return
 	}
 
+	cmd := "cat /var/okteto/bin/version.txt; cat /proc/sys/fs/inotify/max_user_watches; /var/okteto/bin/clean >/dev/null 2>&1"
+	if up.Dev.PersistentSession {
+		// the clean binary kills the processes of previous sessions, including the persistent session of the dev command
+		cmd = "cat /var/okteto/bin/version.txt; cat /proc/sys/fs/inotify/max_user_watches"
+	}
+
 	err = k8sExec.Exec(
 		ctx,
 		k8sClient,
@@ -435,7 +444,7 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 		return err
 	}
 
-	if up.Dev.RemoteModeEnabled() {
+	if up.isSSHAvailable() {
 		if up.Dev.IsHybridModeEnabled() {
 			hybridCtx := &HybridExecCtx{
 				Dev:       up.Dev,
@@ -458,12 +467,18 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 
 			return executor.RunCommand(cmd)
 		} else {
+			if up.Dev.PersistentSession {
+				cmd = getPersistentSessionCommand(cmd)
+			}
 			executor := newSyncExecutor(up)
 			return executor.RunCommand(ctx, cmd)
 		}
 
 	}
 
+	if up.Dev.PersistentSession {
+		cmd = getPersistentSessionCommand(cmd)
+	}
 	return k8sExec.Exec(
 		ctx,
 		k8sClient,
@@ -472,7 +487,7 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 		up.Pod.Name,
 		up.Dev.Container,
 		true,
-		os.Stdin,
+		up.getStdin(),
 		os.Stdout,
 		os.Stderr,
 		cmd,
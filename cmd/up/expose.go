@@ -0,0 +1,223 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+const (
+	// exposeTokenParam is the query parameter used to share the access token of an exposed forward
+	exposeTokenParam = "okteto_token"
+
+	// exposeTokenCookie keeps the access token in the browser of the teammate once it was validated
+	exposeTokenCookie = "okteto_token"
+
+	exposeTokenBytes = 16
+)
+
+var errNoLANAddress = errors.New("no local network address found")
+
+// lanExposer shares the forwards defined with 'expose: lan' on the local network.
+// Every request must carry the access token of the session, and the listeners are closed
+// when 'okteto up' finishes, which includes 'okteto down' deactivating the development container
+type lanExposer struct {
+	token   string
+	host    string
+	servers []*http.Server
+	urls    []string
+	lock    sync.Mutex
+}
+
+// newLANExposer returns a lanExposer listening on the local network address of the machine
+func newLANExposer() (*lanExposer, error) {
+	host, err := getLANAddress()
+	if err != nil {
+		return nil, err
+	}
+	token, err := generateExposeToken()
+	if err != nil {
+		return nil, err
+	}
+	return &lanExposer{host: host, token: token}, nil
+}
+
+// expose shares the forward on the local network, proxying the requests to the local forward listening on iface
+func (e *lanExposer) expose(ctx context.Context, f forward.Forward, iface string) error {
+	target := &url.URL{Scheme: "http", Host: net.JoinHostPort(iface, strconv.Itoa(f.Local))}
+	address := net.JoinHostPort(e.host, strconv.Itoa(f.Local))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to share port %d on the local network: %w", f.Local, err)
+	}
+
+	server := &http.Server{
+		Handler:           exposeTokenHandler(e.token, httputil.NewSingleHostReverseProxy(target)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	e.lock.Lock()
+	e.servers = append(e.servers, server)
+	e.urls = append(e.urls, fmt.Sprintf("http://%s/?%s=%s", address, exposeTokenParam, e.token))
+	e.lock.Unlock()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			oktetoLog.Infof("lan forward %s -> failed to serve: %s", address, err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			oktetoLog.Infof("lan forward %s -> failed to close: %s", address, err)
+		}
+	}()
+	oktetoLog.Infof("lan forward %s -> %s", address, target.Host)
+	return nil
+}
+
+// getURLs returns the urls to share with teammates, including the access token
+func (e *lanExposer) getURLs() []string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]string{}, e.urls...)
+}
+
+// stop closes all the listeners on the local network
+func (e *lanExposer) stop() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for _, server := range e.servers {
+		if err := server.Close(); err != nil {
+			oktetoLog.Infof("failed to stop lan forward: %s", err)
+		}
+	}
+	e.servers = nil
+	e.urls = nil
+}
+
+// exposeTokenHandler only lets through the requests carrying the access token, either as a bearer token,
+// a query parameter or a cookie. When the token is sent as a query parameter, it is stored in a cookie
+// so browsers can keep navigating the service
+func exposeTokenHandler(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isValidExposeToken(token, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")) {
+			r.Header.Del("Authorization")
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if cookie, err := r.Cookie(exposeTokenCookie); err == nil && isValidExposeToken(token, cookie.Value) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		if isValidExposeToken(token, query.Get(exposeTokenParam)) {
+			http.SetCookie(w, &http.Cookie{
+				Name:     exposeTokenCookie,
+				Value:    token,
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+			query.Del(exposeTokenParam)
+			redirect := *r.URL
+			redirect.RawQuery = query.Encode()
+			http.Redirect(w, r, redirect.String(), http.StatusFound)
+			return
+		}
+
+		http.Error(w, "missing or invalid okteto access token", http.StatusUnauthorized)
+	})
+}
+
+func isValidExposeToken(expected, value string) bool {
+	return value != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(value)) == 1
+}
+
+func generateExposeToken() (string, error) {
+	b := make([]byte, exposeTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// getLANAddress returns the first private IPv4 address of the machine
+func getLANAddress() (string, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", err
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil || ip.IsLoopback() || !ip.IsPrivate() {
+			continue
+		}
+		return ip.String(), nil
+	}
+	return "", errNoLANAddress
+}
+
+// exposeForwards shares the forwards defined with 'expose: lan' on the local network.
+// Failing to share a forward doesn't stop 'okteto up', it only prints a warning
+func (up *upContext) exposeForwards(ctx context.Context) {
+	var toExpose []forward.Forward
+	for _, f := range up.Dev.Forward {
+		if f.IsExposedOnLAN() {
+			toExpose = append(toExpose, f)
+		}
+	}
+	if len(toExpose) == 0 {
+		return
+	}
+
+	if up.lanExposer == nil {
+		exposer, err := newLANExposer()
+		if err != nil {
+			oktetoLog.Warning("Forwards are not shared on the local network: %s", err)
+			return
+		}
+		up.lanExposer = exposer
+	}
+	// forwards are recreated when the development container reconnects
+	up.lanExposer.stop()
+
+	for _, f := range toExpose {
+		if err := up.lanExposer.expose(ctx, f, up.Dev.Interface); err != nil {
+			oktetoLog.Warning("%s", err)
+		}
+	}
+}
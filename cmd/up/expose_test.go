@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExposeTokenHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := exposeTokenHandler("secret", next)
+
+	tests := []struct {
+		request          func() *http.Request
+		name             string
+		expectedLocation string
+		expectedStatus   int
+		expectedCookie   bool
+	}{
+		{
+			name: "no token",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/api", nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid bearer token",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/api", nil)
+				r.Header.Set("Authorization", "Bearer wrong")
+				return r
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "valid bearer token",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/api", nil)
+				r.Header.Set("Authorization", "Bearer secret")
+				return r
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "valid cookie",
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/api", nil)
+				r.AddCookie(&http.Cookie{Name: exposeTokenCookie, Value: "secret"})
+				return r
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "valid query parameter",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/api?page=2&okteto_token=secret", nil)
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "/api?page=2",
+			expectedCookie:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.request())
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			assert.Equal(t, tt.expectedCookie, w.Header().Get("Set-Cookie") != "")
+		})
+	}
+}
+
+func TestGenerateExposeToken(t *testing.T) {
+	first, err := generateExposeToken()
+	require.NoError(t, err)
+	second, err := generateExposeToken()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 2*exposeTokenBytes)
+	assert.NotEqual(t, first, second)
+}
+
+func TestExposeForwardsWithoutLANForwards(t *testing.T) {
+	up := &upContext{
+		Dev: &model.Dev{
+			Forward: []forward.Forward{{Local: 8080, Remote: 8080}},
+		},
+	}
+	up.exposeForwards(context.Background())
+	assert.Nil(t, up.lanExposer)
+}
+
+func TestLANExposerStop(t *testing.T) {
+	e := &lanExposer{
+		servers: []*http.Server{{}},
+		urls:    []string{"http://192.168.1.10:8080/?okteto_token=secret"},
+	}
+	e.stop()
+	assert.Empty(t, e.getURLs())
+	assert.Empty(t, e.servers)
+}
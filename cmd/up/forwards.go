@@ -37,10 +37,20 @@ func (up *upContext) forwards(ctx context.Context) error {
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 
+	up.sshFallback = false
 	if up.Dev.RemoteModeEnabled() {
-		return up.sshForwards(ctx)
+		err := up.sshForwards(ctx)
+		// hybrid mode can't work without the reverse tunnel of the SSH server
+		if !errors.Is(err, oktetoErrors.ErrSSHConnectError) || up.Dev.IsHybridModeEnabled() {
+			return err
+		}
+		return up.fallbackToK8sForwards(ctx)
 	}
 
+	return up.k8sForwards(ctx)
+}
+
+func (up *upContext) k8sForwards(ctx context.Context) error {
 	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
 	if err != nil {
 		return err
@@ -58,6 +68,10 @@ func (up *upContext) forwards(ctx context.Context) error {
 			up.Dev.Forward[idx] = forwardWithServiceName
 			f = forwardWithServiceName
 		}
+		f, err = up.getInspectedForward(f)
+		if err != nil {
+			return err
+		}
 		if err := up.Forwarder.Add(f); err != nil {
 			return err
 		}
@@ -76,6 +90,9 @@ func (up *upContext) forwards(ctx context.Context) error {
 		return err
 	}
 
+	up.inspectForwards(ctx)
+	up.exposeForwards(ctx)
+
 	if isNeededGlobalForwarder(up.Manifest.GlobalForward) {
 		up.GlobalForwarderStatus = make(chan error, 1)
 		go up.setGlobalForwardsIfRequiredLoop(ctx)
@@ -119,6 +136,9 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 		return err
 	}
 
+	up.inspectForwards(ctx)
+	up.exposeForwards(ctx)
+
 	if isNeededGlobalForwarder(up.Manifest.GlobalForward) {
 		up.GlobalForwarderStatus = make(chan error, 1)
 		go up.setGlobalForwardsIfRequiredLoop(ctx)
@@ -152,7 +172,13 @@ func addToForwarder(up *upContext) error {
 					f = forwardWithServiceName
 					alreadyAdded[f.Local] = true
 				}
-				if err := up.Forwarder.Add(f); err != nil {
+				inspectedForward, err := up.getInspectedForward(f)
+				if err != nil {
+					oktetoLog.Infof("could not create forward port: %s", err)
+					forwardErr = err
+					continue
+				}
+				if err := up.Forwarder.Add(inspectedForward); err != nil {
 					oktetoLog.Infof("could not create forward port: %s", err)
 					forwardErr = err
 					continue
@@ -0,0 +1,230 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/deps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	dependencyHealthCheckInterval = 30 * time.Second
+
+	// dependencyHealthDetailsKey typed after ssh.EscapeChar at the beginning of a line shows the health details
+	dependencyHealthDetailsKey = 'h'
+
+	crashLoopBackOffReason = "CrashLoopBackOff"
+)
+
+// healthIssue represents a degraded resource the development container depends on
+type healthIssue struct {
+	resource string
+	reason   string
+}
+
+func (hi healthIssue) String() string {
+	return fmt.Sprintf("%s: %s", hi.resource, hi.reason)
+}
+
+// dependencyHealthWatcher monitors the health of the dependency pipelines and services of the namespace
+// while the development container is active
+type dependencyHealthWatcher struct {
+	k8sClient    kubernetes.Interface
+	out          io.Writer
+	dependencies deps.ManifestSection
+	namespace    string
+	devName      string
+	issues       []healthIssue
+	interval     time.Duration
+	showKeyHint  bool
+	mu           sync.Mutex
+}
+
+func newDependencyHealthWatcher(up *upContext, k8sClient kubernetes.Interface, out io.Writer) *dependencyHealthWatcher {
+	return &dependencyHealthWatcher{
+		k8sClient:    k8sClient,
+		out:          out,
+		dependencies: up.Manifest.Dependencies,
+		namespace:    up.Dev.Namespace,
+		devName:      up.Dev.Name,
+		interval:     dependencyHealthCheckInterval,
+		showKeyHint:  !up.Dev.IsHybridModeEnabled(),
+	}
+}
+
+// run checks the health periodically until the context is cancelled
+func (hw *dependencyHealthWatcher) run(ctx context.Context) {
+	ssh.RegisterEscapeHandler(dependencyHealthDetailsKey, hw.printDetails)
+	defer ssh.UnregisterEscapeHandler(dependencyHealthDetailsKey)
+
+	t := time.NewTicker(hw.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			issues, err := hw.check(ctx)
+			if err != nil {
+				oktetoLog.Infof("failed to check the health of the dependencies: %s", err)
+				continue
+			}
+			if msg := hw.update(issues); msg != "" {
+				hw.writeLine(msg)
+			}
+		}
+	}
+}
+
+// check returns the issues found in the namespace, the dependency pipelines and the services
+func (hw *dependencyHealthWatcher) check(ctx context.Context) ([]healthIssue, error) {
+	issues := []healthIssue{}
+
+	ns, err := hw.k8sClient.CoreV1().Namespaces().Get(ctx, hw.namespace, metav1.GetOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, err
+	}
+	if ns != nil && ns.Labels[constants.NamespaceStatusLabel] == constants.NamespaceStatusSleeping {
+		issues = append(issues, healthIssue{resource: fmt.Sprintf("namespace '%s'", hw.namespace), reason: "sleeping"})
+	}
+
+	for name, dep := range hw.dependencies {
+		depNamespace := dep.Namespace
+		if depNamespace == "" {
+			depNamespace = hw.namespace
+		}
+		resource := fmt.Sprintf("dependency '%s'", name)
+		status, err := pipeline.GetStatus(ctx, name, depNamespace, hw.k8sClient)
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				issues = append(issues, healthIssue{resource: resource, reason: "not deployed"})
+				continue
+			}
+			return nil, err
+		}
+		switch status {
+		case pipeline.ErrorStatus:
+			issues = append(issues, healthIssue{resource: resource, reason: "deployment failed"})
+		case pipeline.DestroyingStatus:
+			issues = append(issues, healthIssue{resource: resource, reason: "being destroyed"})
+		}
+	}
+
+	podList, err := hw.k8sClient.CoreV1().Pods(hw.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if pod.Labels[model.InteractiveDevLabel] == hw.devName {
+			continue
+		}
+		if container := getCrashLoopingContainer(pod); container != "" {
+			issues = append(issues, healthIssue{
+				resource: fmt.Sprintf("pod '%s'", pod.Name),
+				reason:   fmt.Sprintf("container '%s' is crash looping", container),
+			})
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].String() < issues[j].String()
+	})
+	return issues, nil
+}
+
+// update stores the issues found and returns the notification to show if the health changed
+func (hw *dependencyHealthWatcher) update(issues []healthIssue) string {
+	hw.mu.Lock()
+	defer hw.mu.Unlock()
+
+	previous := map[healthIssue]bool{}
+	for _, issue := range hw.issues {
+		previous[issue] = true
+	}
+	hadIssues := len(hw.issues) > 0
+	hw.issues = issues
+
+	newIssues := []healthIssue{}
+	for _, issue := range issues {
+		if !previous[issue] {
+			newIssues = append(newIssues, issue)
+		}
+	}
+
+	switch {
+	case len(newIssues) > 0:
+		msg := fmt.Sprintf("%s %s", oktetoLog.YellowString("Dependency health degraded:"), newIssues[0])
+		if len(newIssues) > 1 {
+			msg = fmt.Sprintf("%s (and %d more)", msg, len(newIssues)-1)
+		}
+		if hw.showKeyHint {
+			msg = fmt.Sprintf("%s. Type '%c%c' to view details", msg, ssh.EscapeChar, dependencyHealthDetailsKey)
+		}
+		return msg
+	case hadIssues && len(issues) == 0:
+		return oktetoLog.GreenString("Dependencies are healthy again")
+	}
+	return ""
+}
+
+// printDetails shows all the issues found in the last check
+func (hw *dependencyHealthWatcher) printDetails() {
+	hw.mu.Lock()
+	issues := hw.issues
+	hw.mu.Unlock()
+
+	if len(issues) == 0 {
+		hw.writeLine("All dependencies are healthy")
+		return
+	}
+	lines := []string{oktetoLog.YellowString("Dependency health issues:")}
+	for _, issue := range issues {
+		lines = append(lines, fmt.Sprintf("  - %s", issue))
+	}
+	hw.writeLine(strings.Join(lines, "\r\n"))
+}
+
+// writeLine writes a message in its own line, the terminal might be in raw mode
+func (hw *dependencyHealthWatcher) writeLine(msg string) {
+	if _, err := fmt.Fprintf(hw.out, "\r\n%s\r\n", msg); err != nil {
+		oktetoLog.Infof("failed to write dependency health notification: %s", err)
+	}
+}
+
+func getCrashLoopingContainer(pod apiv1.Pod) string {
+	statuses := append([]apiv1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+			return status.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/deps"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func crashLoopingPod(name string, labels map[string]string) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: labels},
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{
+					Name:  "app",
+					State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: crashLoopBackOffReason}},
+				},
+			},
+		},
+	}
+}
+
+func Test_dependencyHealthWatcherCheck(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&apiv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "test",
+				Labels: map[string]string{constants.NamespaceStatusLabel: constants.NamespaceStatusSleeping},
+			},
+		},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: pipeline.TranslatePipelineName("api"), Namespace: "test"},
+			Data:       map[string]string{"status": pipeline.ErrorStatus},
+		},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: pipeline.TranslatePipelineName("db"), Namespace: "other"},
+			Data:       map[string]string{"status": pipeline.DeployedStatus},
+		},
+		crashLoopingPod("worker", nil),
+		crashLoopingPod("dev-pod", map[string]string{model.InteractiveDevLabel: "dev"}),
+	)
+	hw := &dependencyHealthWatcher{
+		k8sClient: c,
+		namespace: "test",
+		devName:   "dev",
+		dependencies: deps.ManifestSection{
+			"api":      &deps.Dependency{},
+			"db":       &deps.Dependency{Namespace: "other"},
+			"frontend": &deps.Dependency{},
+		},
+	}
+
+	issues, err := hw.check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []healthIssue{
+		{resource: "dependency 'api'", reason: "deployment failed"},
+		{resource: "dependency 'frontend'", reason: "not deployed"},
+		{resource: "namespace 'test'", reason: "sleeping"},
+		{resource: "pod 'worker'", reason: "container 'app' is crash looping"},
+	}, issues)
+}
+
+func Test_dependencyHealthWatcherUpdate(t *testing.T) {
+	out := &bytes.Buffer{}
+	hw := &dependencyHealthWatcher{out: out, showKeyHint: true}
+	crashLoop := healthIssue{resource: "pod 'worker'", reason: "container 'app' is crash looping"}
+	sleeping := healthIssue{resource: "namespace 'test'", reason: "sleeping"}
+
+	assert.Empty(t, hw.update([]healthIssue{}))
+
+	msg := hw.update([]healthIssue{crashLoop})
+	assert.Contains(t, msg, "pod 'worker': container 'app' is crash looping")
+	assert.Contains(t, msg, "Type '~h' to view details")
+
+	assert.Empty(t, hw.update([]healthIssue{crashLoop}))
+
+	msg = hw.update([]healthIssue{sleeping, crashLoop})
+	assert.Contains(t, msg, "namespace 'test': sleeping")
+	assert.NotContains(t, msg, "and 1 more")
+
+	hw.printDetails()
+	assert.Contains(t, out.String(), "  - namespace 'test': sleeping\r\n  - pod 'worker'")
+
+	assert.Contains(t, hw.update([]healthIssue{}), "Dependencies are healthy again")
+	assert.Empty(t, hw.update([]healthIssue{}))
+}
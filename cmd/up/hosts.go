@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"sort"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/hosts"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// updateHosts resolves the hostnames of the forwarded services to the local forwards
+func (up *upContext) updateHosts() {
+	if up.hostsManager == nil {
+		return
+	}
+	entries := getHostEntries(up.Dev, up.Manifest)
+	if err := up.hostsManager.Set(hosts.Section(up.Dev.Namespace, up.Dev.Name), entries); err != nil {
+		oktetoLog.Warning("Couldn't add the hostnames of your forwards to the hosts file: %s", err)
+		oktetoLog.Hint("    Give your user write permissions to '%s' or unset the '%s' setting", hosts.GetPath(), config.ManageHostsSetting)
+		return
+	}
+	for _, e := range entries {
+		for _, h := range e.Hostnames {
+			oktetoLog.Infof("'%s' resolves to %s", h, e.IP)
+		}
+	}
+}
+
+// removeHosts removes the hostnames added by updateHosts
+func (up *upContext) removeHosts() {
+	if up.hostsManager == nil || up.Dev == nil {
+		return
+	}
+	if err := up.hostsManager.Remove(hosts.Section(up.Dev.Namespace, up.Dev.Name)); err != nil {
+		oktetoLog.Infof("failed to remove the hostnames from the hosts file: %s", err)
+	}
+}
+
+// getHostEntries returns the hostnames of the services forwarded by the development container:
+// '<service>.<namespace>.local.okteto' for the service forwards and '<dev>.<namespace>.local.okteto' for the rest
+func getHostEntries(dev *model.Dev, manifest *model.Manifest) []hosts.Entry {
+	hostnames := map[string]bool{}
+	for _, f := range dev.Forward {
+		if f.Service {
+			if f.ServiceName != "" {
+				hostnames[hosts.Hostname(f.ServiceName, dev.Namespace)] = true
+			}
+			continue
+		}
+		hostnames[hosts.Hostname(dev.Name, dev.Namespace)] = true
+	}
+	if manifest != nil {
+		for _, gf := range manifest.GlobalForward {
+			if gf.ServiceName != "" {
+				hostnames[hosts.Hostname(gf.ServiceName, dev.Namespace)] = true
+			}
+		}
+	}
+	if len(hostnames) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(hostnames))
+	for h := range hostnames {
+		result = append(result, h)
+	}
+	sort.Strings(result)
+
+	ip := dev.Interface
+	if ip == "" || ip == model.Localhost || ip == model.PrivilegedLocalhost {
+		ip = hosts.LoopbackIP
+	}
+	return []hosts.Entry{{IP: ip, Hostnames: result}}
+}
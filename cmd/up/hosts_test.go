@@ -0,0 +1,135 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/hosts"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHostsManager struct {
+	entries map[string][]hosts.Entry
+	err     error
+}
+
+func (f *fakeHostsManager) Set(section string, entries []hosts.Entry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.entries[section] = entries
+	return nil
+}
+
+func (f *fakeHostsManager) Remove(section string) error {
+	delete(f.entries, section)
+	return f.err
+}
+
+func TestGetHostEntries(t *testing.T) {
+	tests := []struct {
+		name     string
+		dev      *model.Dev
+		manifest *model.Manifest
+		expected []hosts.Entry
+	}{
+		{
+			name:     "no forwards",
+			dev:      &model.Dev{Name: "api", Namespace: "cindy"},
+			expected: nil,
+		},
+		{
+			name: "service, dev container and global forwards",
+			dev: &model.Dev{
+				Name:      "api",
+				Namespace: "cindy",
+				Interface: model.Localhost,
+				Forward: []forward.Forward{
+					{Local: 8080, Remote: 8080},
+					{Local: 8081, Remote: 8081},
+					{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+					{Local: 6379, Remote: 6379, Service: true},
+				},
+			},
+			manifest: &model.Manifest{
+				GlobalForward: []forward.GlobalForward{
+					{Local: 9000, Remote: 9000, ServiceName: "minio"},
+				},
+			},
+			expected: []hosts.Entry{
+				{
+					IP:        hosts.LoopbackIP,
+					Hostnames: []string{"api.cindy.local.okteto", "db.cindy.local.okteto", "minio.cindy.local.okteto"},
+				},
+			},
+		},
+		{
+			name: "custom interface",
+			dev: &model.Dev{
+				Name:      "api",
+				Namespace: "cindy",
+				Interface: "192.168.1.10",
+				Forward:   []forward.Forward{{Local: 8080, Remote: 8080}},
+			},
+			expected: []hosts.Entry{{IP: "192.168.1.10", Hostnames: []string{"api.cindy.local.okteto"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getHostEntries(tt.dev, tt.manifest))
+		})
+	}
+}
+
+func TestUpdateAndRemoveHosts(t *testing.T) {
+	m := &fakeHostsManager{entries: map[string][]hosts.Entry{}}
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:      "api",
+			Namespace: "cindy",
+			Forward:   []forward.Forward{{Local: 8080, Remote: 8080}},
+		},
+		Manifest:     &model.Manifest{},
+		hostsManager: m,
+	}
+
+	up.updateHosts()
+	assert.Equal(t, []hosts.Entry{{IP: hosts.LoopbackIP, Hostnames: []string{"api.cindy.local.okteto"}}}, m.entries["cindy/api"])
+
+	up.removeHosts()
+	assert.Empty(t, m.entries)
+
+	// disabled
+	up.hostsManager = nil
+	up.updateHosts()
+	up.removeHosts()
+}
+
+func TestUpdateHostsError(t *testing.T) {
+	m := &fakeHostsManager{entries: map[string][]hosts.Entry{}, err: assert.AnError}
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:      "api",
+			Namespace: "cindy",
+			Forward:   []forward.Forward{{Local: 8080, Remote: 8080}},
+		},
+		hostsManager: m,
+	}
+
+	up.updateHosts()
+	assert.Empty(t, m.entries)
+}
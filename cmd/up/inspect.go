@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/inspect"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
+)
+
+// getInspectedForward returns the forward to add to the forwarder for a forward defined with 'inspect: true'.
+// The forward listens on an internal port, and the inspector listens on the local port to record the traffic
+func (up *upContext) getInspectedForward(f forward.Forward) (forward.Forward, error) {
+	if !f.Inspect {
+		return f, nil
+	}
+	if up.inspector == nil {
+		up.inspector = inspect.NewInspector(afero.NewOsFs())
+	}
+	port, err := up.inspector.GetInternalPort(f.Local, func() (int, error) {
+		return model.GetAvailablePort(up.Dev.Interface)
+	})
+	if err != nil {
+		return f, fmt.Errorf("failed to inspect port %d: %w", f.Local, err)
+	}
+	f.Local = port
+	return f, nil
+}
+
+// inspectForwards starts recording the traffic of the forwards defined with 'inspect: true'.
+// Failing to inspect a forward doesn't stop 'okteto up', it only prints a warning
+func (up *upContext) inspectForwards(ctx context.Context) {
+	if up.inspector == nil {
+		return
+	}
+	// forwards are recreated when the development container reconnects
+	up.inspector.Stop()
+
+	for _, f := range up.Dev.Forward {
+		if !f.Inspect {
+			continue
+		}
+		if err := up.inspector.Start(ctx, f.Local, up.Dev.Interface); err != nil {
+			oktetoLog.Warning("%s", err)
+		}
+	}
+}
+
+// getInspectedPorts returns the local ports of the forwards defined with 'inspect: true'
+func (up *upContext) getInspectedPorts() []int {
+	result := []int{}
+	for _, f := range up.Dev.Forward {
+		if f.Inspect {
+			result = append(result, f.Local)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
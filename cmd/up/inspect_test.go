@@ -0,0 +1,52 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInspectedForward(t *testing.T) {
+	up := &upContext{
+		Dev: &model.Dev{
+			Interface: model.Localhost,
+			Forward: []forward.Forward{
+				{Local: 8080, Remote: 8080, Inspect: true},
+				{Local: 9090, Remote: 9090},
+			},
+		},
+	}
+
+	f, err := up.getInspectedForward(up.Dev.Forward[1])
+	require.NoError(t, err)
+	assert.Equal(t, up.Dev.Forward[1], f)
+	assert.Nil(t, up.inspector)
+
+	f, err = up.getInspectedForward(up.Dev.Forward[0])
+	require.NoError(t, err)
+	assert.NotEqual(t, 8080, f.Local)
+	assert.Equal(t, 8080, f.Remote)
+
+	// the internal port is kept when the forwards are recreated
+	again, err := up.getInspectedForward(up.Dev.Forward[0])
+	require.NoError(t, err)
+	assert.Equal(t, f.Local, again.Local)
+
+	assert.Equal(t, []int{8080}, up.getInspectedPorts())
+}
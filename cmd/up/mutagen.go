@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/mutagen"
+)
+
+// mutagenEngine synchronizes the files using mutagen sessions over the SSH server of the development container
+type mutagenEngine struct {
+	up *upContext
+	m  *mutagen.Mutagen
+}
+
+func newMutagenEngine(up *upContext) (*mutagenEngine, error) {
+	if !up.Dev.RemoteModeEnabled() {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the '%s' sync engine requires the SSH server of the development container", model.SyncEngineMutagen),
+			Hint: fmt.Sprintf("Unset the env var '%s' or remove the 'sync.engine' field from your okteto manifest", model.OktetoExecuteSSHEnvVar),
+		}
+	}
+	m, err := mutagen.New(up.Dev, up.Fs)
+	if err != nil {
+		return nil, err
+	}
+	return &mutagenEngine{up: up, m: m}, nil
+}
+
+func (*mutagenEngine) name() string {
+	return model.SyncEngineMutagen
+}
+
+func (me *mutagenEngine) start(ctx context.Context) error {
+	oktetoLog.Spinner("Starting the file synchronization service...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	if err := config.UpdateStateFile(me.up.Dev.Name, me.up.Dev.Namespace, config.StartingSync); err != nil {
+		return err
+	}
+	return me.m.Start(ctx)
+}
+
+func (*mutagenEngine) isLocalRunningOutOfSpace(_ context.Context) bool {
+	return false
+}
+
+func (me *mutagenEngine) waitForCompletion(ctx context.Context, reporter chan float64) error {
+	return me.m.WaitForCompletion(ctx, reporter)
+}
+
+func (*mutagenEngine) getInSynchronizationFile(_ context.Context) string {
+	return ""
+}
+
+func (me *mutagenEngine) watch(ctx context.Context, disconnect chan error) error {
+	go me.m.Monitor(ctx, disconnect)
+	return nil
+}
+
+func (me *mutagenEngine) isConnected(ctx context.Context) bool {
+	return me.m.IsConnected(ctx)
+}
+
+func (me *mutagenEngine) pause(ctx context.Context) error {
+	return me.m.Pause(ctx)
+}
+
+func (me *mutagenEngine) resume(ctx context.Context) error {
+	return me.m.Resume(ctx)
+}
+
+func (me *mutagenEngine) stop() error {
+	return me.m.Terminate(context.Background())
+}
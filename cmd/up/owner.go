@@ -0,0 +1,150 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// onConflictFail fails when the development container is owned by another user
+	onConflictFail = "fail"
+
+	// onConflictSteal takes over the development container owned by another user
+	onConflictSteal = "steal"
+
+	// onConflictSandbox starts a copy of the development container for the current user
+	onConflictSandbox = "sandbox"
+)
+
+var onConflictOptions = []string{onConflictFail, onConflictSteal, onConflictSandbox}
+
+// devModeOwnerResolver decides what to do when the development container is already active for another user
+type devModeOwnerResolver struct {
+	k8sClient   kubernetes.Interface
+	selector    utils.OktetoSelectorInterface
+	askYesNo    func(q string, d utils.YesNoDefault) (bool, error)
+	interactive bool
+}
+
+func newDevModeOwnerResolver(k8sClient kubernetes.Interface, interactive bool) *devModeOwnerResolver {
+	return &devModeOwnerResolver{
+		k8sClient:   k8sClient,
+		selector:    utils.NewOktetoSelector("What do you want to do?", "Option"),
+		askYesNo:    utils.AskYesNo,
+		interactive: interactive,
+	}
+}
+
+// resolve checks the owner of the development container and applies the conflict policy when it belongs to another user.
+// An empty policy asks the user in interactive terminals and fails otherwise
+func (r *devModeOwnerResolver) resolve(ctx context.Context, dev *model.Dev, onConflict string) error {
+	if dev.Username == "" {
+		return nil
+	}
+
+	app, err := apps.Get(ctx, dev, dev.Namespace, r.k8sClient)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("failed to check the owner of the development container: %s", err)
+		}
+		return nil
+	}
+	if !apps.IsDevModeOn(app) {
+		return nil
+	}
+	owner := app.ObjectMeta().Annotations[model.DevModeOwnerAnnotation]
+	if owner == "" || owner == dev.Username {
+		return nil
+	}
+
+	if onConflict == "" {
+		onConflict = onConflictFail
+		if r.interactive {
+			oktetoLog.Warning("'%s' is already in development mode by '%s'", dev.Name, owner)
+			onConflict, err = r.selector.AskForOptionsOkteto(utils.ListToSelectorItem(onConflictOptions), -1)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	switch onConflict {
+	case onConflictSteal:
+		if r.interactive {
+			answer, err := r.askYesNo(fmt.Sprintf("The development session of '%s' will be disconnected. Do you want to continue?", owner), utils.YesNoDefault_No)
+			if err != nil {
+				return err
+			}
+			if !answer {
+				return newDevModeConflictError(dev.Name, owner)
+			}
+		}
+		oktetoLog.Warning("Taking over the development container '%s' from '%s'", dev.Name, owner)
+		return nil
+	case onConflictSandbox:
+		toSandbox(dev, app)
+		oktetoLog.Information("Starting your own development container '%s'", dev.Name)
+		return nil
+	default:
+		return newDevModeConflictError(dev.Name, owner)
+	}
+}
+
+func newDevModeConflictError(devName, owner string) error {
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("'%s' is already in development mode by '%s'", devName, owner),
+		Hint: `Run 'okteto up --on-conflict=steal' to take over the development container
+    Or run 'okteto up --on-conflict=sandbox' to start your own copy of it`,
+	}
+}
+
+// toSandbox updates dev to create a standalone development container for the current user,
+// based on the image of the application owned by another user
+func toSandbox(dev *model.Dev, app apps.App) {
+	dev.Name = fmt.Sprintf("%s-%s", dev.Name, format.ResourceK8sMetaString(dev.Username))
+	dev.Autocreate = true
+	dev.Selector = nil
+	if dev.Image == nil || dev.Image.Name != "" {
+		return
+	}
+	if container := apps.GetDevContainer(app.PodSpec(), dev.Container); container != nil {
+		dev.Image.Name = container.Image
+	}
+}
+
+func validateOnConflict(onConflict string) error {
+	if onConflict == "" {
+		return nil
+	}
+	for _, o := range onConflictOptions {
+		if o == onConflict {
+			return nil
+		}
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("invalid value '%s' for flag '--on-conflict'", onConflict),
+		Hint: fmt.Sprintf("Accepted values are: %s", strings.Join(onConflictOptions, ", ")),
+	}
+}
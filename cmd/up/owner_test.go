@@ -0,0 +1,211 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeConflictSelector struct {
+	err      error
+	selected string
+}
+
+func (s *fakeConflictSelector) AskForOptionsOkteto([]utils.SelectorItem, int) (string, error) {
+	return s.selected, s.err
+}
+
+func newOwnedDeployment(owner string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "ns",
+			Labels: map[string]string{
+				constants.DevLabel: "true",
+				"app":              "api",
+			},
+			Annotations: map[string]string{
+				model.DevModeOwnerAnnotation: owner,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{
+						{
+							Name:  "api",
+							Image: "api:1.0",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newOwnerTestDev() *model.Dev {
+	return &model.Dev{
+		Name:      "api",
+		Namespace: "ns",
+		Username:  "cindy",
+		Image:     &build.Info{},
+		Selector:  model.Selector{"app": "api"},
+	}
+}
+
+func TestDevModeOwnerResolver(t *testing.T) {
+	tests := []struct {
+		expectedErr     error
+		selector        *fakeConflictSelector
+		deployment      *appsv1.Deployment
+		name            string
+		onConflict      string
+		expectedDevName string
+		confirm         bool
+		interactive     bool
+	}{
+		{
+			name:            "not in dev mode",
+			deployment:      &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns", Labels: map[string]string{"app": "api"}}},
+			expectedDevName: "api",
+		},
+		{
+			name:            "same owner",
+			deployment:      newOwnedDeployment("cindy"),
+			expectedDevName: "api",
+		},
+		{
+			name:            "no owner annotation",
+			deployment:      newOwnedDeployment(""),
+			expectedDevName: "api",
+		},
+		{
+			name:            "another owner and no tty",
+			deployment:      newOwnedDeployment("bob"),
+			expectedDevName: "api",
+			expectedErr:     oktetoErrors.UserError{},
+		},
+		{
+			name:            "another owner and fail",
+			deployment:      newOwnedDeployment("bob"),
+			onConflict:      onConflictFail,
+			interactive:     true,
+			expectedDevName: "api",
+			expectedErr:     oktetoErrors.UserError{},
+		},
+		{
+			name:            "another owner and steal without tty",
+			deployment:      newOwnedDeployment("bob"),
+			onConflict:      onConflictSteal,
+			expectedDevName: "api",
+		},
+		{
+			name:            "another owner and steal confirmed",
+			deployment:      newOwnedDeployment("bob"),
+			onConflict:      onConflictSteal,
+			interactive:     true,
+			confirm:         true,
+			expectedDevName: "api",
+		},
+		{
+			name:            "another owner and steal not confirmed",
+			deployment:      newOwnedDeployment("bob"),
+			onConflict:      onConflictSteal,
+			interactive:     true,
+			expectedDevName: "api",
+			expectedErr:     oktetoErrors.UserError{},
+		},
+		{
+			name:            "another owner and sandbox selected",
+			deployment:      newOwnedDeployment("bob"),
+			interactive:     true,
+			selector:        &fakeConflictSelector{selected: onConflictSandbox},
+			expectedDevName: "api-cindy",
+		},
+		{
+			name:            "another owner and selector error",
+			deployment:      newOwnedDeployment("bob"),
+			interactive:     true,
+			selector:        &fakeConflictSelector{err: assert.AnError},
+			expectedDevName: "api",
+			expectedErr:     assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &devModeOwnerResolver{
+				k8sClient: fake.NewSimpleClientset(tt.deployment),
+				selector:  tt.selector,
+				askYesNo: func(string, utils.YesNoDefault) (bool, error) {
+					return tt.confirm, nil
+				},
+				interactive: tt.interactive,
+			}
+			dev := newOwnerTestDev()
+			err := r.resolve(context.Background(), dev, tt.onConflict)
+			switch tt.expectedErr.(type) {
+			case nil:
+				require.NoError(t, err)
+			case oktetoErrors.UserError:
+				require.ErrorAs(t, err, &oktetoErrors.UserError{})
+			default:
+				require.ErrorIs(t, err, tt.expectedErr)
+			}
+			assert.Equal(t, tt.expectedDevName, dev.Name)
+		})
+	}
+}
+
+func TestDevModeOwnerResolverNotFound(t *testing.T) {
+	r := &devModeOwnerResolver{
+		k8sClient: fake.NewSimpleClientset(),
+	}
+	dev := newOwnerTestDev()
+	require.NoError(t, r.resolve(context.Background(), dev, onConflictFail))
+	assert.Equal(t, "api", dev.Name)
+}
+
+func TestToSandbox(t *testing.T) {
+	dev := newOwnerTestDev()
+	dev.Container = "api"
+	deployment := newOwnedDeployment("bob")
+
+	toSandbox(dev, apps.NewDeploymentApp(deployment))
+
+	assert.Equal(t, "api-cindy", dev.Name)
+	assert.True(t, dev.Autocreate)
+	assert.Empty(t, dev.Selector)
+	assert.Equal(t, "api:1.0", dev.Image.Name)
+}
+
+func TestValidateOnConflict(t *testing.T) {
+	assert.NoError(t, validateOnConflict(""))
+	assert.NoError(t, validateOnConflict(onConflictSandbox))
+	assert.ErrorAs(t, validateOnConflict("ignore"), &oktetoErrors.UserError{})
+}
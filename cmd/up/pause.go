@@ -0,0 +1,191 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+const (
+	// oktetoPausedFilename is created by 'okteto sync pause' and the pause key binding to pause the session
+	oktetoPausedFilename = "okteto.paused"
+
+	// escapeKey starts a key binding when typed at the beginning of a line, like the ssh escape character
+	escapeKey = '~'
+	pauseKey  = 'p'
+	resumeKey = 'r'
+)
+
+// pauseWatchInterval is the interval to check if the session has been paused or resumed
+var pauseWatchInterval = time.Second
+
+func getPausedFilePath(namespace, name string) string {
+	return filepath.Join(config.GetAppHome(namespace, name), oktetoPausedFilename)
+}
+
+// setSessionPaused creates or removes the file that pauses the file synchronization and forwards of a session
+func setSessionPaused(fs afero.Fs, path string, paused bool) error {
+	if paused {
+		return afero.WriteFile(fs, path, []byte{}, 0600)
+	}
+	if err := fs.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func isSessionPaused(fs afero.Fs, path string) bool {
+	_, err := fs.Stat(path)
+	return err == nil
+}
+
+// watchPause pauses and resumes the file synchronization and the forwards when the paused file is created or removed
+func (up *upContext) watchPause(ctx context.Context) {
+	path := getPausedFilePath(up.Dev.Namespace, up.Dev.Name)
+	ticker := time.NewTicker(pauseWatchInterval)
+	defer ticker.Stop()
+
+	paused := false
+	for {
+		if isSessionPaused(up.Fs, path) != paused {
+			paused = !paused
+			if paused {
+				up.pauseSession(ctx)
+			} else {
+				up.resumeSession(ctx)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (up *upContext) pauseSession(ctx context.Context) {
+	if err := up.syncEngine.pause(ctx); err != nil {
+		oktetoLog.Warning("%s", err)
+		return
+	}
+	if up.Forwarder != nil {
+		// the forwards of the synchronization service are kept to monitor the connection
+		var syncPorts []int
+		if up.Sy != nil {
+			syncPorts = []int{up.Sy.RemotePort, up.Sy.RemoteGUIPort}
+		}
+		up.Forwarder.Pause(syncPorts...)
+	}
+	oktetoLog.Information("File synchronization and port forwards paused. Run 'okteto sync resume' or type '%c%c' to resume them", escapeKey, resumeKey)
+}
+
+func (up *upContext) resumeSession(ctx context.Context) {
+	if err := up.syncEngine.resume(ctx); err != nil {
+		oktetoLog.Warning("%s", err)
+		return
+	}
+	if up.Forwarder != nil {
+		up.Forwarder.Resume()
+	}
+	oktetoLog.Information("File synchronization and port forwards resumed")
+}
+
+// getStdin returns the input of the development container command. In interactive sessions,
+// the key bindings to pause and resume the session are handled before forwarding the input
+func (up *upContext) getStdin() io.Reader {
+	if !up.isTerm {
+		return os.Stdin
+	}
+	path := getPausedFilePath(up.Dev.Namespace, up.Dev.Name)
+	return newPauseKeysReader(os.Stdin, func(key byte) {
+		if err := setSessionPaused(up.Fs, path, key == pauseKey); err != nil {
+			oktetoLog.Infof("failed to update paused file: %s", err)
+		}
+	})
+}
+
+// pauseKeysReader detects the key bindings '~p' and '~r' at the beginning of a line and removes them from the input.
+// Typing '~~' sends a single '~'
+type pauseKeysReader struct {
+	r         io.Reader
+	err       error
+	onKey     func(key byte)
+	pending   []byte
+	lineStart bool
+	escaped   bool
+}
+
+func newPauseKeysReader(r io.Reader, onKey func(key byte)) *pauseKeysReader {
+	return &pauseKeysReader{r: r, onKey: onKey, lineStart: true}
+}
+
+func (pr *pauseKeysReader) Read(p []byte) (int, error) {
+	for len(pr.pending) == 0 {
+		if pr.err != nil {
+			return 0, pr.err
+		}
+		buf := make([]byte, len(p))
+		n, err := pr.r.Read(buf)
+		pr.err = err
+		pr.pending = pr.filter(buf[:n])
+	}
+	n := copy(p, pr.pending)
+	pr.pending = pr.pending[n:]
+	return n, nil
+}
+
+func (pr *pauseKeysReader) filter(in []byte) []byte {
+	out := make([]byte, 0, len(in))
+	for _, b := range in {
+		if pr.escaped {
+			pr.escaped = false
+			switch b {
+			case pauseKey, resumeKey:
+				pr.onKey(b)
+				continue
+			case escapeKey:
+				out = append(out, escapeKey)
+			default:
+				out = append(out, escapeKey, b)
+			}
+			pr.lineStart = b == '\r' || b == '\n'
+			continue
+		}
+		if pr.lineStart && b == escapeKey {
+			pr.escaped = true
+			continue
+		}
+		out = append(out, b)
+		pr.lineStart = b == '\r' || b == '\n'
+	}
+	return out
+}
+
+// removePausedFile resumes the sessions paused by a previous 'okteto up' execution
+func removePausedFile(fs afero.Fs, dev *model.Dev) {
+	if err := setSessionPaused(fs, getPausedFilePath(dev.Namespace, dev.Name), false); err != nil {
+		oktetoLog.Infof("failed to remove paused file: %s", err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSyncEngine struct {
+	syncEngine
+	paused atomic.Bool
+}
+
+func (f *fakeSyncEngine) pause(_ context.Context) error {
+	f.paused.Store(true)
+	return nil
+}
+
+func (f *fakeSyncEngine) resume(_ context.Context) error {
+	f.paused.Store(false)
+	return nil
+}
+
+type fakePausedForwarder struct {
+	forwarder
+	paused atomic.Bool
+}
+
+func (f *fakePausedForwarder) Pause(_ ...int) {
+	f.paused.Store(true)
+}
+
+func (f *fakePausedForwarder) Resume() {
+	f.paused.Store(false)
+}
+
+func TestPauseKeysReader(t *testing.T) {
+	var tests = []struct {
+		name         string
+		input        string
+		expected     string
+		expectedKeys string
+	}{
+		{name: "no escape", input: "ls -la\n", expected: "ls -la\n"},
+		{name: "pause", input: "~p", expectedKeys: "p"},
+		{name: "pause and resume", input: "~pls\r~r", expected: "ls\r", expectedKeys: "pr"},
+		{name: "escaped tilde", input: "~~p", expected: "~p"},
+		{name: "other key", input: "~/bin\n", expected: "~/bin\n"},
+		{name: "tilde in the middle of a line", input: "cd ~p", expected: "cd ~p"},
+		{name: "after newline", input: "echo\n~p", expected: "echo\n", expectedKeys: "p"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := ""
+			r := newPauseKeysReader(bytes.NewBufferString(tt.input), func(key byte) {
+				keys += string(key)
+			})
+			result, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+			assert.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestPauseKeysReaderSmallBuffer(t *testing.T) {
+	r := newPauseKeysReader(bytes.NewBufferString("~xy"), func(byte) {})
+	p := make([]byte, 1)
+	result := ""
+	for {
+		n, err := r.Read(p)
+		result += string(p[:n])
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "~xy", result)
+}
+
+func TestWatchPause(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	pauseWatchInterval = 10 * time.Millisecond
+	defer func() {
+		pauseWatchInterval = time.Second
+	}()
+
+	engine := &fakeSyncEngine{}
+	fwd := &fakePausedForwarder{}
+	up := &upContext{
+		Dev:        &model.Dev{Name: "api", Namespace: "cindy", Forward: []forward.Forward{{Local: 8080, Remote: 8080}}},
+		Fs:         afero.NewOsFs(),
+		syncEngine: engine,
+		Forwarder:  fwd,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		up.watchPause(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	path := getPausedFilePath("cindy", "api")
+	require.NoError(t, setSessionPaused(up.Fs, path, true))
+	assert.Eventually(t, func() bool { return engine.paused.Load() && fwd.paused.Load() }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, setSessionPaused(up.Fs, path, false))
+	assert.Eventually(t, func() bool { return !engine.paused.Load() && !fwd.paused.Load() }, time.Second, 10*time.Millisecond)
+}
+
+func TestGetActiveSession(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	home := "/okteto/cindy"
+
+	_, err := getActiveSession(fs, home, "")
+	assert.Error(t, err)
+
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(home, "api", oktetoPIDFilename), []byte("1234"), 0600))
+	name, err := getActiveSession(fs, home, "")
+	require.NoError(t, err)
+	assert.Equal(t, "api", name)
+
+	_, err = getActiveSession(fs, home, "frontend")
+	assert.Error(t, err)
+
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(home, "frontend", oktetoPIDFilename), []byte("5678"), 0600))
+	_, err = getActiveSession(fs, home, "")
+	assert.Error(t, err)
+
+	name, err = getActiveSession(fs, home, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", name)
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+var (
+	// proxyEnvVars are the proxy variables injected in the development container, NO_PROXY is handled separately
+	proxyEnvVars = []string{"HTTP_PROXY", "HTTPS_PROXY"}
+
+	// inClusterNoProxy are the addresses that must never go through the local proxy from inside the cluster
+	inClusterNoProxy = []string{"localhost", "127.0.0.1", ".svc", ".cluster.local"}
+)
+
+// injectProxySettings adds the proxy configuration of the local machine to the development container.
+// Proxies listening on the local machine are reached through reverse tunnels, and the in-cluster addresses are added to NO_PROXY.
+// Variables already defined in the manifest are not overridden.
+func injectProxySettings(dev *model.Dev, getEnv func(string) string) {
+	defined := map[string]bool{}
+	for _, e := range dev.Environment {
+		defined[strings.ToUpper(e.Name)] = true
+	}
+
+	injected := false
+	reverses := map[int]bool{}
+	for _, r := range dev.Reverse {
+		reverses[r.Remote] = true
+	}
+	for _, name := range proxyEnvVars {
+		value := lookupProxyEnv(name, getEnv)
+		if value == "" || defined[name] {
+			continue
+		}
+		port, isLocal, err := getLocalProxyPort(value)
+		if err != nil {
+			oktetoLog.Warning("Ignoring the proxy configured in '%s': %s", name, err)
+			continue
+		}
+		if isLocal {
+			if !dev.RemoteModeEnabled() {
+				oktetoLog.Warning("Ignoring the proxy configured in '%s': local proxies require the SSH server of the development container", name)
+				continue
+			}
+			if !reverses[port] {
+				dev.Reverse = append(dev.Reverse, model.Reverse{Remote: port, Local: port})
+				reverses[port] = true
+			}
+		}
+		dev.Environment = append(dev.Environment, env.Var{Name: name, Value: value}, env.Var{Name: strings.ToLower(name), Value: value})
+		injected = true
+	}
+
+	if !injected || defined["NO_PROXY"] {
+		return
+	}
+	noProxy := getNoProxy(lookupProxyEnv("NO_PROXY", getEnv), dev.Namespace)
+	dev.Environment = append(dev.Environment, env.Var{Name: "NO_PROXY", Value: noProxy}, env.Var{Name: "no_proxy", Value: noProxy})
+	oktetoLog.Infof("injected proxy settings in development container '%s'", dev.Name)
+}
+
+func lookupProxyEnv(name string, getEnv func(string) string) string {
+	if v := getEnv(name); v != "" {
+		return v
+	}
+	return getEnv(strings.ToLower(name))
+}
+
+// getLocalProxyPort returns the port of the proxy and if it listens on the loopback interface of the local machine
+func getLocalProxyPort(proxy string) (int, bool, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = fmt.Sprintf("http://%s", proxy)
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid proxy url: %w", err)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return 0, false, fmt.Errorf("invalid proxy url '%s'", proxy)
+	}
+	if host != "localhost" {
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return 0, false, nil
+		}
+	}
+
+	portValue := u.Port()
+	if portValue == "" {
+		portValue = "80"
+		if u.Scheme == "https" {
+			portValue = "443"
+		}
+	}
+	port, err := strconv.Atoi(portValue)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid proxy port '%s'", portValue)
+	}
+	return port, true, nil
+}
+
+// getNoProxy merges the local NO_PROXY value with the in-cluster addresses
+func getNoProxy(local, namespace string) string {
+	result := []string{}
+	seen := map[string]bool{}
+	add := func(value string) {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			return
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	for _, v := range strings.Split(local, ",") {
+		add(v)
+	}
+	for _, v := range inClusterNoProxy {
+		add(v)
+	}
+	if namespace != "" {
+		add(fmt.Sprintf(".%s", namespace))
+	}
+	return strings.Join(result, ",")
+}
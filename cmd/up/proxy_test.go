@@ -0,0 +1,117 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_injectProxySettings(t *testing.T) {
+	var tests = []struct {
+		localEnvs           map[string]string
+		environment         env.Environment
+		name                string
+		expectedEnvironment env.Environment
+		expectedReverse     []model.Reverse
+	}{
+		{
+			name:      "no proxy configured",
+			localEnvs: map[string]string{},
+		},
+		{
+			name: "remote proxy",
+			localEnvs: map[string]string{
+				"HTTPS_PROXY": "http://proxy.corp.com:3128",
+				"no_proxy":    "corp.com",
+			},
+			expectedEnvironment: env.Environment{
+				{Name: "HTTPS_PROXY", Value: "http://proxy.corp.com:3128"},
+				{Name: "https_proxy", Value: "http://proxy.corp.com:3128"},
+				{Name: "NO_PROXY", Value: "corp.com,localhost,127.0.0.1,.svc,.cluster.local,.test"},
+				{Name: "no_proxy", Value: "corp.com,localhost,127.0.0.1,.svc,.cluster.local,.test"},
+			},
+		},
+		{
+			name: "local proxy uses a reverse tunnel",
+			localEnvs: map[string]string{
+				"http_proxy":  "127.0.0.1:8888",
+				"HTTPS_PROXY": "http://localhost:8888",
+			},
+			expectedEnvironment: env.Environment{
+				{Name: "HTTP_PROXY", Value: "127.0.0.1:8888"},
+				{Name: "http_proxy", Value: "127.0.0.1:8888"},
+				{Name: "HTTPS_PROXY", Value: "http://localhost:8888"},
+				{Name: "https_proxy", Value: "http://localhost:8888"},
+				{Name: "NO_PROXY", Value: "localhost,127.0.0.1,.svc,.cluster.local,.test"},
+				{Name: "no_proxy", Value: "localhost,127.0.0.1,.svc,.cluster.local,.test"},
+			},
+			expectedReverse: []model.Reverse{{Remote: 8888, Local: 8888}},
+		},
+		{
+			name: "manifest variables are not overridden",
+			localEnvs: map[string]string{
+				"HTTP_PROXY": "http://proxy.corp.com:3128",
+				"NO_PROXY":   "corp.com",
+			},
+			environment: env.Environment{
+				{Name: "no_proxy", Value: "custom"},
+			},
+			expectedEnvironment: env.Environment{
+				{Name: "no_proxy", Value: "custom"},
+				{Name: "HTTP_PROXY", Value: "http://proxy.corp.com:3128"},
+				{Name: "http_proxy", Value: "http://proxy.corp.com:3128"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{Name: "api", Namespace: "test", RemotePort: 2222, Environment: tt.environment}
+			injectProxySettings(dev, func(name string) string { return tt.localEnvs[name] })
+			assert.Equal(t, tt.expectedEnvironment, dev.Environment)
+			assert.Equal(t, tt.expectedReverse, dev.Reverse)
+		})
+	}
+}
+
+func Test_getLocalProxyPort(t *testing.T) {
+	var tests = []struct {
+		proxy         string
+		expectedPort  int
+		expectedLocal bool
+		expectErr     bool
+	}{
+		{proxy: "http://localhost:3128", expectedPort: 3128, expectedLocal: true},
+		{proxy: "127.0.0.1:8080", expectedPort: 8080, expectedLocal: true},
+		{proxy: "https://[::1]", expectedPort: 443, expectedLocal: true},
+		{proxy: "http://10.0.0.1:3128"},
+		{proxy: "http://proxy.corp.com"},
+		{proxy: "http://:3128", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.proxy, func(t *testing.T) {
+			port, local, err := getLocalProxyPort(tt.proxy)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPort, port)
+			assert.Equal(t, tt.expectedLocal, local)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	k8sExec "github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+const (
+	// defaultReadyTimeout is the time 'okteto up' waits on the ready probe when 'timeout' is not set
+	defaultReadyTimeout = 5 * time.Minute
+
+	// defaultReadyInterval is the time between two checks of the ready probe when 'interval' is not set
+	defaultReadyInterval = 2 * time.Second
+)
+
+// readyCheck runs a check of the ready probe in the development container
+type readyCheck func(ctx context.Context, command string) error
+
+// getReadyProbeCommand returns the shell command that checks the ready probe of the development container.
+// Port probes look for a listening socket in /proc/net to avoid depending on the tools installed in the image
+func getReadyProbeCommand(probe *model.ReadyProbe) string {
+	if probe.Command != "" {
+		return probe.Command
+	}
+	return fmt.Sprintf("cat /proc/net/tcp /proc/net/tcp6 2>/dev/null | grep -qiE '^ *[0-9]+: [0-9A-F]+:%04X [0-9A-F]+:[0-9A-F]+ 0A '", probe.Port)
+}
+
+// waitUntilReady runs the ready probe until it succeeds or its timeout expires.
+// A probe that never succeeds is reported as a warning: the dev command runs anyway
+func waitUntilReady(ctx context.Context, dev *model.Dev, check readyCheck) error {
+	if dev.Ready == nil {
+		return nil
+	}
+
+	timeout := dev.Ready.Timeout
+	if timeout == 0 {
+		timeout = defaultReadyTimeout
+	}
+	interval := dev.Ready.Interval
+	if interval == 0 {
+		interval = defaultReadyInterval
+	}
+	command := getReadyProbeCommand(dev.Ready)
+
+	oktetoLog.Spinner(fmt.Sprintf("Waiting for '%s' to be ready...", dev.Name))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	to := time.NewTimer(timeout)
+	defer to.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := check(ctx, command)
+		if err == nil {
+			oktetoLog.Infof("ready probe of '%s' succeeded", dev.Name)
+			return nil
+		}
+		oktetoLog.Infof("ready probe of '%s' failed: %s", dev.Name, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-to.C:
+			oktetoLog.Warning("'%s' wasn't ready after %s: running the dev command anyway", dev.Name, timeout)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReadyProbe executes a check of the ready probe in the development container
+func (up *upContext) checkReadyProbe(ctx context.Context, command string) error {
+	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		return err
+	}
+
+	out := &strings.Builder{}
+	err = k8sExec.Exec(ctx, k8sClient, restConfig, up.Dev.Namespace, up.Pod.Name, up.Dev.Container, false, strings.NewReader(""), io.Discard, out, []string{"sh", "-c", command})
+	if err != nil && out.Len() > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(out.String()))
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitUntilReady(t *testing.T) {
+	errNotReady := errors.New("not ready")
+
+	t.Run("without ready probe", func(t *testing.T) {
+		called := false
+		err := waitUntilReady(context.Background(), &model.Dev{Name: "api"}, func(context.Context, string) error {
+			called = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("ready after some checks", func(t *testing.T) {
+		dev := &model.Dev{
+			Name:  "api",
+			Ready: &model.ReadyProbe{Command: "test -f /tmp/ready", Interval: time.Millisecond, Timeout: time.Minute},
+		}
+		var commands []string
+		err := waitUntilReady(context.Background(), dev, func(_ context.Context, command string) error {
+			commands = append(commands, command)
+			if len(commands) < 3 {
+				return errNotReady
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"test -f /tmp/ready", "test -f /tmp/ready", "test -f /tmp/ready"}, commands)
+	})
+
+	t.Run("timeout runs the dev command anyway", func(t *testing.T) {
+		dev := &model.Dev{
+			Name:  "api",
+			Ready: &model.ReadyProbe{Port: 8080, Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+		}
+		err := waitUntilReady(context.Background(), dev, func(context.Context, string) error {
+			return errNotReady
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		dev := &model.Dev{
+			Name:  "api",
+			Ready: &model.ReadyProbe{Port: 8080, Interval: time.Millisecond, Timeout: time.Minute},
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		err := waitUntilReady(ctx, dev, func(context.Context, string) error {
+			cancel()
+			return errNotReady
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestGetReadyProbeCommand(t *testing.T) {
+	assert.Equal(t, "curl -sf localhost:8080/healthz", getReadyProbeCommand(&model.ReadyProbe{Command: "curl -sf localhost:8080/healthz"}))
+
+	command := getReadyProbeCommand(&model.ReadyProbe{Port: 8080})
+	assert.Contains(t, command, ":1F90 ")
+}
+
+func TestGetReadyProbeCommandMatchesListeningSockets(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	netTCP := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 1234 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:0BB8 0100007F:1F91 01 00000000:00000000 00:00000000 00000000  1000        0 1235 1 0000000000000000 20 4 30 10 -1
+`
+	tests := []struct {
+		name     string
+		port     int
+		expected bool
+	}{
+		{name: "listening port", port: 8080, expected: true},
+		{name: "port of an established connection", port: 3000, expected: false},
+		{name: "remote port", port: 8081, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := getReadyProbeCommand(&model.ReadyProbe{Port: tt.port})
+			command = strings.Replace(command, "cat /proc/net/tcp /proc/net/tcp6 2>/dev/null", `echo "$NET_TCP"`, 1)
+			cmd := exec.Command("sh", "-c", command)
+			cmd.Env = []string{"NET_TCP=" + netTCP}
+			err := cmd.Run()
+			assert.Equal(t, tt.expected, err == nil)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+
+	"github.com/alessio/shellescape"
+)
+
+// persistentSessionName is the name of the tmux or screen session that runs the dev command
+const persistentSessionName = "okteto"
+
+// getPersistentSessionCommand returns the command that runs the dev command in a tmux or screen session of the development container.
+// The session is created the first time and reattached on the next ones, so the dev command keeps running between reconnections.
+// If neither tmux nor screen are installed in the development container, the dev command runs as usual
+func getPersistentSessionCommand(cmd []string) []string {
+	command := shellescape.QuoteCommand(cmd)
+	script := fmt.Sprintf(
+		`if command -v tmux >/dev/null 2>&1; then exec tmux new-session -A -s %[1]s %[2]s; fi; `+
+			`if command -v screen >/dev/null 2>&1; then exec screen -D -RR -S %[1]s sh -c %[2]s; fi; `+
+			`echo "tmux or screen are not installed in the development container: 'persistentSession' is ignored" >&2; `+
+			`exec %[3]s`,
+		persistentSessionName,
+		shellescape.Quote(command),
+		command,
+	)
+	return []string{"sh", "-c", script}
+}
@@ -0,0 +1,32 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getPersistentSessionCommand(t *testing.T) {
+	cmd := getPersistentSessionCommand([]string{"yarn", "start", "--name", "my app"})
+
+	require.Len(t, cmd, 3)
+	assert.Equal(t, "sh", cmd[0])
+	assert.Equal(t, "-c", cmd[1])
+	assert.Contains(t, cmd[2], `exec tmux new-session -A -s okteto 'yarn start --name '"'"'my app'"'"''`)
+	assert.Contains(t, cmd[2], `exec screen -D -RR -S okteto sh -c 'yarn start --name '"'"'my app'"'"''`)
+	assert.Contains(t, cmd[2], `exec yarn start --name 'my app'`)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// fallbackToK8sForwards is used when the SSH server of the development container can't be reached.
+// Instead of aborting, the session continues with kubernetes port forwards and exec, and the capabilities that need SSH are disabled
+func (up *upContext) fallbackToK8sForwards(ctx context.Context) error {
+	if up.Forwarder != nil {
+		up.Forwarder.Stop()
+		up.Forwarder = nil
+	}
+	up.sshFallback = true
+
+	if up.syncEngine != nil && up.syncEngine.name() == model.SyncEngineMutagen {
+		up.syncEngine = &syncthingEngine{up: up}
+	}
+
+	oktetoLog.StopSpinner()
+	oktetoLog.Warning("Couldn't connect to the SSH server of your development container. Falling back to kubernetes exec and port forwards")
+	if unavailable := getSSHOnlyCapabilities(up.Dev); len(unavailable) > 0 {
+		oktetoLog.Warning("The following capabilities are not available in this session:\n    - %s", strings.Join(unavailable, "\n    - "))
+	}
+	oktetoLog.StartSpinner()
+
+	return up.k8sForwards(ctx)
+}
+
+// getSSHOnlyCapabilities returns the capabilities of the development container that require its SSH server
+func getSSHOnlyCapabilities(dev *model.Dev) []string {
+	result := []string{
+		fmt.Sprintf("SSH access and remote IDEs ('ssh %s.okteto')", dev.Name),
+	}
+	for _, r := range dev.Reverse {
+		result = append(result, fmt.Sprintf("reverse forward %d <- %d", r.Local, r.Remote))
+	}
+	if dev.Sync.IsMutagenEngine() {
+		result = append(result, fmt.Sprintf("the '%s' sync engine, files are synchronized with syncthing", model.SyncEngineMutagen))
+	}
+	if dev.X11 {
+		result = append(result, "X11 forwarding")
+	}
+	if dev.Clipboard {
+		result = append(result, "clipboard forwarding")
+	}
+	return result
+}
+
+// isSSHAvailable returns true if the session uses the SSH server of the development container
+func (up *upContext) isSSHAvailable() bool {
+	return up.Dev.RemoteModeEnabled() && !up.sshFallback
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStoppedForwarder struct {
+	forwarder
+	stopped bool
+}
+
+func (f *fakeStoppedForwarder) Stop() {
+	f.stopped = true
+}
+
+type fakeNamedSyncEngine struct {
+	syncEngine
+	engineName string
+}
+
+func (f *fakeNamedSyncEngine) name() string {
+	return f.engineName
+}
+
+func TestGetSSHOnlyCapabilities(t *testing.T) {
+	dev := &model.Dev{
+		Name: "api",
+		Reverse: []model.Reverse{
+			{Local: 8080, Remote: 9000},
+		},
+		Sync:      model.Sync{Engine: model.SyncEngineMutagen},
+		X11:       true,
+		Clipboard: true,
+	}
+	expected := []string{
+		"SSH access and remote IDEs ('ssh api.okteto')",
+		"reverse forward 8080 <- 9000",
+		"the 'mutagen' sync engine, files are synchronized with syncthing",
+		"X11 forwarding",
+		"clipboard forwarding",
+	}
+	assert.Equal(t, expected, getSSHOnlyCapabilities(dev))
+	assert.Equal(t, []string{"SSH access and remote IDEs ('ssh api.okteto')"}, getSSHOnlyCapabilities(&model.Dev{Name: "api"}))
+}
+
+func TestFallbackToK8sForwards(t *testing.T) {
+	f := &fakeStoppedForwarder{}
+	up := &upContext{
+		Dev:               &model.Dev{Name: "api", RemotePort: 2222},
+		Forwarder:         f,
+		syncEngine:        &fakeNamedSyncEngine{engineName: model.SyncEngineMutagen},
+		K8sClientProvider: &test.FakeK8sProvider{ErrProvide: assert.AnError},
+	}
+	assert.True(t, up.isSSHAvailable())
+
+	err := up.fallbackToK8sForwards(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.True(t, f.stopped)
+	assert.True(t, up.sshFallback)
+	assert.False(t, up.isSSHAvailable())
+	assert.Equal(t, model.SyncEngineSyncthing, up.syncEngine.name())
+}
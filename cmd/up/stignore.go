@@ -202,6 +202,6 @@ func checkIfStignoreHasGitFolder(stignorePath string) error {
 		return nil
 	}
 
-	oktetoLog.Warning("The synchronization service performance could be degraded if the '.git' folder is synchronized. Please add '.git' to the '.stignore' file.")
+	oktetoLog.WarningWithID(oktetoLog.WarnGitFolderSynchronized, "The synchronization service performance could be degraded if the '.git' folder is synchronized. Please add '.git' to the '.stignore' file.")
 	return nil
 }
@@ -0,0 +1,136 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+type syncFlags struct {
+	context   string
+	namespace string
+}
+
+// Sync controls the file synchronization and the forwards of a running 'okteto up' session
+func Sync(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Pause or resume the file synchronization of your development containers",
+	}
+	cmd.AddCommand(syncPauseResume(ctx, true))
+	cmd.AddCommand(syncPauseResume(ctx, false))
+	return cmd
+}
+
+func syncPauseResume(ctx context.Context, pause bool) *cobra.Command {
+	flags := &syncFlags{}
+	cmd := &cobra.Command{
+		Use:   "resume [service]",
+		Short: "Resume the file synchronization and the port forwards of an 'okteto up' session",
+		Args:  utils.MaximumNArgsAccepted(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctxOptions := &contextCMD.Options{
+				Context:   flags.context,
+				Namespace: flags.namespace,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			devName := ""
+			if len(args) == 1 {
+				devName = args[0]
+			}
+			namespace := okteto.GetContext().Namespace
+			fs := afero.NewOsFs()
+			devName, err := getActiveSession(fs, config.GetNamespaceHome(namespace), devName)
+			if err != nil {
+				return err
+			}
+
+			if err := setSessionPaused(fs, getPausedFilePath(namespace, devName), pause); err != nil {
+				return fmt.Errorf("failed to update the 'okteto up' session of '%s': %w", devName, err)
+			}
+			if pause {
+				oktetoLog.Success("File synchronization and port forwards of '%s' will be paused", devName)
+			} else {
+				oktetoLog.Success("File synchronization and port forwards of '%s' will be resumed", devName)
+			}
+			return nil
+		},
+	}
+	if pause {
+		cmd.Use = "pause [service]"
+		cmd.Short = "Pause the file synchronization and the port forwards of an 'okteto up' session"
+		cmd.Long = `Pause the file synchronization and the port forwards of an 'okteto up' session.
+
+The development container keeps running. New connections to the port forwards are rejected until the session is resumed.
+You can also type '~p' and '~r' at the beginning of a line of your 'okteto up' terminal to pause and resume the session`
+	}
+	cmd.Flags().StringVarP(&flags.context, "context", "c", "", "context where the development container is running")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace where the development container is running")
+	return cmd
+}
+
+// getActiveSession returns the development container with a running 'okteto up' session.
+// If devName is empty, there must be a single session running in the namespace
+func getActiveSession(fs afero.Fs, namespaceHome, devName string) (string, error) {
+	if devName != "" {
+		if _, err := fs.Stat(filepath.Join(namespaceHome, devName, oktetoPIDFilename)); err != nil {
+			return "", oktetoErrors.UserError{
+				E:    fmt.Errorf("there is no 'okteto up' session running for '%s'", devName),
+				Hint: "Run 'okteto up' first",
+			}
+		}
+		return devName, nil
+	}
+
+	pidFiles, err := afero.Glob(fs, filepath.Join(namespaceHome, "*", oktetoPIDFilename))
+	if err != nil {
+		return "", err
+	}
+	sessions := []string{}
+	for _, pidFile := range pidFiles {
+		sessions = append(sessions, filepath.Base(filepath.Dir(pidFile)))
+	}
+	sort.Strings(sessions)
+
+	switch len(sessions) {
+	case 0:
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("there are no 'okteto up' sessions running"),
+			Hint: "Run 'okteto up' first",
+		}
+	case 1:
+		return sessions[0], nil
+	default:
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("there are several 'okteto up' sessions running: %s", strings.Join(sessions, ", ")),
+			Hint: "Specify the name of the development container",
+		}
+	}
+}
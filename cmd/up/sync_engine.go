@@ -0,0 +1,50 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+)
+
+// syncEngine synchronizes the local folders with the development container
+type syncEngine interface {
+	// name returns the name of the engine for logging purposes
+	name() string
+	// start starts the engine and waits until it is connected to the development container
+	start(ctx context.Context) error
+	// isLocalRunningOutOfSpace returns true if the local disk is almost full
+	isLocalRunningOutOfSpace(ctx context.Context) bool
+	// waitForCompletion waits for the initial synchronization, reporting its progress
+	waitForCompletion(ctx context.Context, reporter chan float64) error
+	// getInSynchronizationFile returns the file being synchronized, if any
+	getInSynchronizationFile(ctx context.Context) string
+	// watch keeps the folders in sync and sends an error to disconnect if the engine fails
+	watch(ctx context.Context, disconnect chan error) error
+	// isConnected returns true if the engine is connected to the development container
+	isConnected(ctx context.Context) bool
+	// pause stops synchronizing the files until resume is called
+	pause(ctx context.Context) error
+	// resume synchronizes the files again after a pause
+	resume(ctx context.Context) error
+	// stop stops the synchronization
+	stop() error
+}
+
+// newSyncEngine returns the sync engine configured in the development container
+func (up *upContext) newSyncEngine() (syncEngine, error) {
+	if up.Dev.Sync.IsMutagenEngine() {
+		return newMutagenEngine(up)
+	}
+	return &syncthingEngine{up: up}, nil
+}
@@ -22,6 +22,9 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ports"
+	"github.com/okteto/okteto/pkg/supervisor"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/afero"
 )
@@ -52,7 +55,7 @@ func (up *upContext) initializeSyncthing() error {
 }
 
 func (up *upContext) sync(ctx context.Context) error {
-	if err := up.startSyncthing(ctx); err != nil {
+	if err := up.syncEngine.start(ctx); err != nil {
 		return err
 	}
 
@@ -85,19 +88,20 @@ func (up *upContext) sync(ctx context.Context) error {
     More information is available here: https://okteto.com/docs/reference/file-synchronization/`, elapsedString)
 	}
 
-	up.Sy.Type = "sendreceive"
-	up.Sy.IgnoreDelete = false
-	if err := up.Sy.UpdateConfig(); err != nil {
-		return err
-	}
+	return up.syncEngine.watch(ctx, up.Disconnect)
+}
 
-	go up.Sy.Monitor(ctx, up.Disconnect)
-	go up.Sy.MonitorStatus(ctx, up.Disconnect)
-	oktetoLog.Infof("restarting syncthing to update sync mode to sendreceive")
-	return up.Sy.Restart(ctx)
+// syncthingEngine synchronizes the files using the local and remote syncthing instances
+type syncthingEngine struct {
+	up *upContext
 }
 
-func (up *upContext) startSyncthing(ctx context.Context) error {
+func (*syncthingEngine) name() string {
+	return model.SyncEngineSyncthing
+}
+
+func (se *syncthingEngine) start(ctx context.Context) error {
+	up := se.up
 	if !up.Dev.IsHybridModeEnabled() {
 		oktetoLog.Spinner("Starting the file synchronization service...")
 		oktetoLog.StartSpinner()
@@ -111,6 +115,7 @@ func (up *upContext) startSyncthing(ctx context.Context) error {
 	if err := up.Sy.Run(); err != nil {
 		return err
 	}
+	se.register()
 
 	if err := up.Sy.WaitForPing(ctx, true); err != nil {
 		return err
@@ -140,7 +145,74 @@ func (up *upContext) startSyncthing(ctx context.Context) error {
 	return up.Sy.WaitForConnected(ctx)
 }
 
-// checkForSystemErrors is called when syncthing is started to check for system errors (ie. available disk space is lower than 1%) and print a warning
+func (se *syncthingEngine) isLocalRunningOutOfSpace(ctx context.Context) bool {
+	return se.up.Sy.IsLocalRunningOutOfSpace(ctx)
+}
+
+func (se *syncthingEngine) waitForCompletion(ctx context.Context, reporter chan float64) error {
+	return se.up.Sy.WaitForCompletion(ctx, reporter)
+}
+
+func (se *syncthingEngine) getInSynchronizationFile(ctx context.Context) string {
+	return se.up.Sy.GetInSynchronizationFile(ctx)
+}
+
+// watch updates the sync mode to sendreceive and monitors the syncthing instances
+func (se *syncthingEngine) watch(ctx context.Context, disconnect chan error) error {
+	sy := se.up.Sy
+	sy.Type = "sendreceive"
+	sy.IgnoreDelete = false
+	if err := sy.UpdateConfig(); err != nil {
+		return err
+	}
+
+	go sy.Monitor(ctx, disconnect)
+	go sy.MonitorStatus(ctx, disconnect)
+	oktetoLog.Infof("restarting syncthing to update sync mode to sendreceive")
+	return sy.Restart(ctx)
+}
+
+func (se *syncthingEngine) isConnected(ctx context.Context) bool {
+	return se.up.Sy.Ping(ctx, false)
+}
+
+func (se *syncthingEngine) pause(ctx context.Context) error {
+	return se.up.Sy.Pause(ctx)
+}
+
+func (se *syncthingEngine) resume(ctx context.Context) error {
+	return se.up.Sy.Resume(ctx)
+}
+
+func (se *syncthingEngine) stop() error {
+	if se.up.Sy == nil {
+		return nil
+	}
+	supervisor.Unregister(se.up.Sy.PID())
+	return se.up.Sy.SoftTerminate()
+}
+
+// register tracks the local syncthing process so it can be cleaned up if okteto crashes
+func (se *syncthingEngine) register() {
+	up := se.up
+	if up.Sy.PID() == 0 {
+		return
+	}
+	p := supervisor.Process{
+		Name:    "syncthing",
+		Session: ports.Owner(up.Dev.Namespace, up.Dev.Name),
+		PID:     up.Sy.PID(),
+		Ports:   []int{up.Sy.LocalGUIPort, up.Sy.LocalPort},
+	}
+	if up.Dev.IsHybridModeEnabled() {
+		for _, folder := range up.Dev.Sync.Folders {
+			p.TempDirs = append(p.TempDirs, folder.LocalPath)
+		}
+	}
+	supervisor.Register(p)
+}
+
+// checkForSystemErrors is called when the sync engine is started to check for system errors (ie. available disk space is lower than 1%) and print a warning
 func (up *upContext) checkForSystemErrors(ctx context.Context) {
 	if up.Dev.IsHybridModeEnabled() {
 		return
@@ -150,7 +222,7 @@ func (up *upContext) checkForSystemErrors(ctx context.Context) {
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 
-	if up.Sy.IsLocalRunningOutOfSpace(ctx) {
+	if up.syncEngine.isLocalRunningOutOfSpace(ctx) {
 		oktetoLog.Warning("Your local disk is almost full. Please free up some space to avoid synchronization issues.")
 	}
 }
@@ -173,7 +245,7 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 			case <-quit:
 				return
 			case <-time.NewTicker(1 * time.Second).C:
-				inSynchronizationFile := up.Sy.GetInSynchronizationFile(ctx)
+				inSynchronizationFile := up.syncEngine.getInSynchronizationFile(ctx)
 				if inSynchronizationFile != "" && oktetoLog.GetOutputFormat() != oktetoLog.PlainFormat {
 					oktetoLog.StopSpinner()
 					progressBar.UpdateItemInSync(inSynchronizationFile)
@@ -198,7 +270,7 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 		quit <- true
 	}()
 
-	if err := up.Sy.WaitForCompletion(ctx, reporter); err != nil {
+	if err := up.syncEngine.waitForCompletion(ctx, reporter); err != nil {
 		up.analyticsMeta.ErrSync()
 		switch err {
 		case oktetoErrors.ErrLostSyncthing:
@@ -20,6 +20,8 @@ import (
 
 	"github.com/moby/term"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/hosts"
+	"github.com/okteto/okteto/pkg/inspect"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
@@ -76,6 +78,7 @@ type upContext struct {
 	CommandResult         chan error
 	Exit                  chan error
 	Sy                    *syncthing.Syncthing
+	syncEngine            syncEngine
 	cleaned               chan string
 	hardTerminate         chan error
 	Translations          map[string]*apps.Translation
@@ -88,12 +91,18 @@ type upContext struct {
 	Pod                   *apiv1.Pod
 	Cancel                context.CancelFunc
 	pidController         pidController
+	lanExposer            *lanExposer
+	inspector             *inspect.Inspector
 	inFd                  uintptr
 	isRetry               bool
 	success               bool
 	resetSyncthing        bool
 	isTerm                bool
 	interruptReceived     bool
+	// sshFallback is true when the SSH server of the development container failed and the session uses kubernetes exec instead
+	sshFallback bool
+	// hostsManager resolves the hostnames of the forwarded services locally, nil if disabled
+	hostsManager hosts.Manager
 }
 
 // Forwarder is an interface for the port-forwarding features
@@ -102,6 +111,8 @@ type forwarder interface {
 	AddReverse(model.Reverse) error
 	Start(string, string) error
 	StartGlobalForwarding() error
+	Pause(except ...int)
+	Resume()
 	Stop()
 	TransformLabelsToServiceName(forward.Forward) (forward.Forward, error)
 }
@@ -45,15 +45,18 @@ import (
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/hosts"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
+	"github.com/okteto/okteto/pkg/ports"
 	"github.com/okteto/okteto/pkg/process"
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/supervisor"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
@@ -90,6 +93,16 @@ type Options struct {
 	Deploy           bool
 	ForcePull        bool
 	Reset            bool
+	// Remember persists the development container selected so it is used by default on next executions
+	Remember bool
+	// InjectProxy adds the proxy settings of the local machine to the development container
+	InjectProxy bool
+	// StrictVars fails if the okteto manifest references undefined variables
+	StrictVars bool
+	// OnConflict is the action taken when the development container is active for another user: fail, steal or sandbox
+	OnConflict string
+	// forgetDevSelection is true when the user explicitly disabled the remembered development container
+	forgetDevSelection bool
 }
 
 // Up starts a development container
@@ -107,6 +120,7 @@ func Up(at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCt
 			if err := upOptions.AddArgs(cmd, args); err != nil {
 				return err
 			}
+			upOptions.forgetDevSelection = cmd.Flags().Changed("remember") && !upOptions.Remember
 
 			u := utils.UpgradeAvailable()
 			if len(u) > 0 {
@@ -202,8 +216,15 @@ func Up(at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCt
 				inferer := devenvironment.NewNameInferer(c)
 				oktetoManifest.Name = inferer.InferName(ctx, wd, okteto.GetContext().Namespace, upOptions.ManifestPathFlag)
 			}
+			oktetoManifest.Name = utils.PerUserName(oktetoManifest.Name)
 			os.Setenv(constants.OktetoNameEnvVar, oktetoManifest.Name)
 
+			if upOptions.StrictVars {
+				if err := oktetoManifest.CheckStrictVars(nil); err != nil {
+					return err
+				}
+			}
+
 			if len(oktetoManifest.Dev) == 0 {
 				if oktetoManifest.Type == model.StackType {
 					return fmt.Errorf("your docker compose file is not currently supported: Okteto requires a 'host volume' to be defined. See %s", composeVolumesUrl)
@@ -274,6 +295,7 @@ func Up(at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCt
 				tokenUpdater:      newTokenUpdaterController(),
 				builder:           buildv2.NewBuilderFromScratch(ioCtrl, onBuildFinish),
 			}
+			up.hostsManager = hosts.NewManager(up.Fs, okteto.GetContext().Name)
 			up.inFd, up.isTerm = term.GetFdInfo(os.Stdin)
 			if up.isTerm {
 				var err error
@@ -308,16 +330,11 @@ func Up(at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCt
 				oktetoLog.Information("'%s' was already deployed. To redeploy run 'okteto deploy' or 'okteto up --deploy'", up.Manifest.Name)
 			}
 
-			dev, err := utils.GetDevFromManifest(oktetoManifest, upOptions.DevName)
+			selector := utils.NewOktetoSelector("Select which development container to activate:", "Development container")
+			selector.Searchable = true
+			dev, err := getDevFromManifest(up.Fs, oktetoManifest, upOptions, selector)
 			if err != nil {
-				if !errors.Is(err, utils.ErrNoDevSelected) {
-					return err
-				}
-				selector := utils.NewOktetoSelector("Select which development container to activate:", "Development container")
-				dev, err = utils.SelectDevFromManifest(oktetoManifest, selector, oktetoManifest.Dev.GetDevs())
-				if err != nil {
-					return err
-				}
+				return err
 			}
 			if len(upOptions.commandToExecute) > 0 {
 				dev.Command.Values = upOptions.commandToExecute
@@ -359,6 +376,10 @@ func Up(at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCt
 				return err
 			}
 
+			if err := newDevModeOwnerResolver(k8sClient, up.isTerm).resolve(ctx, dev, upOptions.OnConflict); err != nil {
+				return err
+			}
+
 			if syncthing.ShouldUpgrade() {
 				oktetoLog.Println("Installing dependencies...")
 				if err := downloadSyncthing(); err != nil {
@@ -432,6 +453,10 @@ func Up(at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCt
 	}
 	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "reset the file synchronization database")
 	cmd.Flags().StringArrayVarP(&upOptions.commandToExecute, "command", "", []string{}, "external commands to be supplied to 'okteto up'")
+	cmd.Flags().BoolVarP(&upOptions.Remember, "remember", "", false, "remember the selected development container and use it by default on next executions")
+	cmd.Flags().BoolVarP(&upOptions.StrictVars, "strict-vars", "", false, "fail if the okteto manifest references undefined variables")
+	cmd.Flags().StringVarP(&upOptions.OnConflict, "on-conflict", "", "", "action when the development container is active for another user: fail, steal or sandbox (asks by default in interactive terminals)")
+	cmd.Flags().BoolVarP(&upOptions.InjectProxy, "inject-proxy", "", false, "inject the HTTP_PROXY, HTTPS_PROXY and NO_PROXY settings of your machine in the development container")
 	return cmd
 }
 
@@ -453,7 +478,7 @@ func (o *Options) AddArgs(cmd *cobra.Command, args []string) error {
 		o.DevName = args[0]
 	}
 
-	return nil
+	return validateOnConflict(o.OnConflict)
 }
 
 func LoadManifestWithInit(ctx context.Context, k8sContext, namespace, devPath string, at analyticsTrackerInterface, ioCtrl *io.Controller, insights buildDeployTrackerInterface, k8sLogger *io.K8sLogger) (*model.Manifest, error) {
@@ -501,6 +526,46 @@ func LoadManifestWithInit(ctx context.Context, k8sContext, namespace, devPath st
 	return manifest, nil
 }
 
+// getDevFromManifest returns the development container to activate. If the manifest has several and none was specified,
+// the remembered one is used or the user is asked to select one, with the last one selected as default
+func getDevFromManifest(fs afero.Fs, manifest *model.Manifest, upOptions *Options, selector utils.OktetoSelectorInterface) (*model.Dev, error) {
+	namespace := manifest.Namespace
+	if namespace == "" {
+		namespace = okteto.GetContext().Namespace
+	}
+	lastSelection := utils.GetDevSelection(fs, namespace, manifest.Name)
+
+	devName := upOptions.DevName
+	if devName == "" && lastSelection.Remember && !upOptions.forgetDevSelection {
+		if _, ok := manifest.Dev[lastSelection.Dev]; ok {
+			oktetoLog.Information("Using the remembered development container '%s'. Run 'okteto up --remember=false' to select a different one", lastSelection.Dev)
+			devName = lastSelection.Dev
+		}
+	}
+
+	dev, err := utils.GetDevFromManifest(manifest, devName)
+	if err != nil {
+		if !errors.Is(err, utils.ErrNoDevSelected) {
+			return nil, err
+		}
+		dev, err = utils.SelectDevFromManifestWithDefault(manifest, selector, manifest.Dev.GetDevs(), lastSelection.Dev)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(manifest.Dev) > 1 {
+		selection := utils.DevSelection{
+			Dev:      dev.Name,
+			Remember: upOptions.Remember || (lastSelection.Remember && lastSelection.Dev == dev.Name && !upOptions.forgetDevSelection),
+		}
+		if err := utils.SetDevSelection(fs, namespace, manifest.Name, selection); err != nil {
+			oktetoLog.Infof("failed to store the selected development container: %s", err)
+		}
+	}
+	return dev, nil
+}
+
 func loadManifestOverrides(dev *model.Dev, upOptions *Options) error {
 	if upOptions.Remote > 0 {
 		dev.RemotePort = upOptions.Remote
@@ -511,6 +576,14 @@ func loadManifestOverrides(dev *model.Dev, upOptions *Options) error {
 			return err
 		}
 
+		if dev.RemotePort == 0 {
+			p, err := ports.Reserve(ports.Owner(dev.Namespace, dev.Name), "ssh", dev.Interface)
+			if err != nil {
+				oktetoLog.Infof("failed to reserve port for SSH connection: %s", err)
+			} else {
+				dev.RemotePort = p
+			}
+		}
 		dev.LoadRemote(ssh.GetPublicKey())
 	}
 
@@ -518,6 +591,10 @@ func loadManifestOverrides(dev *model.Dev, upOptions *Options) error {
 		dev.LoadForcePull()
 	}
 
+	if upOptions.InjectProxy {
+		injectProxySettings(dev, os.Getenv)
+	}
+
 	if len(upOptions.Envs) > 0 {
 		overridedEnvVars, err := getOverridedEnvVarsFromCmd(dev.Environment, upOptions.Envs)
 		if err != nil {
@@ -651,6 +728,9 @@ func (up *upContext) getManifest(path string, fs afero.Fs) (*model.Manifest, err
 }
 
 func (up *upContext) start() error {
+	// helper processes left behind by crashed sessions keep ports and temporary directories busy
+	supervisor.CleanOrphans()
+
 	up.pidController = newPIDController(up.Dev.Namespace, up.Dev.Name)
 
 	if err := up.pidController.create(); err != nil {
@@ -666,6 +746,7 @@ func (up *upContext) start() error {
 	}
 
 	defer up.pidController.delete()
+	removePausedFile(up.Fs, up.Dev)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -958,10 +1039,10 @@ func (up *upContext) shutdown() {
 		oktetoLog.Info("sent cancellation signal")
 	}
 
-	if up.Sy != nil {
-		oktetoLog.Infof("stopping syncthing")
-		if err := up.Sy.SoftTerminate(); err != nil {
-			oktetoLog.Infof("failed to stop syncthing during shutdown: %s", err.Error())
+	if up.syncEngine != nil {
+		oktetoLog.Infof("stopping %s", up.syncEngine.name())
+		if err := up.syncEngine.stop(); err != nil {
+			oktetoLog.Infof("failed to stop %s during shutdown: %s", up.syncEngine.name(), err.Error())
 		}
 	}
 
@@ -969,12 +1050,21 @@ func (up *upContext) shutdown() {
 	if up.Forwarder != nil {
 		up.Forwarder.Stop()
 	}
+	if up.lanExposer != nil {
+		up.lanExposer.stop()
+	}
+	if up.inspector != nil {
+		up.inspector.Stop()
+	}
+	up.removeHosts()
 
 	if up.Dev.IsHybridModeEnabled() {
 		oktetoLog.Infof("stopping local process...")
 		up.shutdownHybridMode()
 	}
 
+	ports.Release(ports.Owner(up.Dev.Namespace, up.Dev.Name))
+
 	oktetoLog.Info("completed shutdown sequence")
 	up.ShutdownCompleted <- true
 
@@ -1072,7 +1162,25 @@ func printDisplayContext(up *upContext) {
 		}
 	}
 
-	if len(up.Dev.Reverse) > 0 {
+	if up.lanExposer != nil {
+		urls := up.lanExposer.getURLs()
+		if len(urls) > 0 {
+			oktetoLog.Println(fmt.Sprintf("    %s    %s", oktetoLog.BlueString("Shared:"), urls[0]))
+			for i := 1; i < len(urls); i++ {
+				oktetoLog.Println(fmt.Sprintf("               %s", urls[i]))
+			}
+		}
+	}
+
+	if ports := up.getInspectedPorts(); len(ports) > 0 {
+		inspected := make([]string, 0, len(ports))
+		for _, p := range ports {
+			inspected = append(inspected, strconv.Itoa(p))
+		}
+		oktetoLog.Println(fmt.Sprintf("    %s   %s (run 'okteto inspect' to review the requests)", oktetoLog.BlueString("Inspect:"), strings.Join(inspected, ", ")))
+	}
+
+	if len(up.Dev.Reverse) > 0 && !up.sshFallback {
 		oktetoLog.Println(fmt.Sprintf("    %s   %d <- %d", oktetoLog.BlueString("Reverse:"), up.Dev.Reverse[0].Local, up.Dev.Reverse[0].Remote))
 		for i := 1; i < len(up.Dev.Reverse); i++ {
 			oktetoLog.Println(fmt.Sprintf("               %d <- %d", up.Dev.Reverse[i].Local, up.Dev.Reverse[i].Remote))
@@ -19,8 +19,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -29,6 +31,7 @@ import (
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	authenticationv1 "k8s.io/api/authentication/v1"
@@ -748,3 +751,73 @@ func Test_buildServicesAndSetBuildEnvs(t *testing.T) {
 		})
 	}
 }
+
+type fakeDevSelector struct {
+	dev             string
+	initialPosition int
+	called          bool
+}
+
+func (s *fakeDevSelector) AskForOptionsOkteto(_ []utils.SelectorItem, initialPosition int) (string, error) {
+	s.called = true
+	s.initialPosition = initialPosition
+	return s.dev, nil
+}
+
+func Test_getDevFromManifest(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/okteto", 0700))
+	t.Setenv(constants.OktetoFolderEnvVar, "/okteto")
+
+	newDev := func(name string) *model.Dev {
+		return &model.Dev{
+			Name:            name,
+			ImagePullPolicy: v1.PullAlways,
+			Image:           &build.Info{},
+			Sync: model.Sync{
+				Folders: []model.SyncFolder{{LocalPath: "/", RemotePath: "/app"}},
+			},
+			SSHServerPort: 2222,
+		}
+	}
+	newManifest := func() *model.Manifest {
+		return &model.Manifest{
+			Name:      "app",
+			Namespace: "ns",
+			Dev: model.ManifestDevs{
+				"api":      newDev("api"),
+				"frontend": newDev("frontend"),
+			},
+		}
+	}
+
+	// first execution asks without default
+	selector := &fakeDevSelector{dev: "frontend"}
+	dev, err := getDevFromManifest(fs, newManifest(), &Options{}, selector)
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", dev.Name)
+	assert.True(t, selector.called)
+	assert.Equal(t, -1, selector.initialPosition)
+
+	// last selection is preselected
+	selector = &fakeDevSelector{dev: "api"}
+	dev, err = getDevFromManifest(fs, newManifest(), &Options{Remember: true}, selector)
+	require.NoError(t, err)
+	assert.Equal(t, "api", dev.Name)
+	assert.Equal(t, 1, selector.initialPosition)
+
+	// remembered selection skips the selector
+	selector = &fakeDevSelector{dev: "frontend"}
+	dev, err = getDevFromManifest(fs, newManifest(), &Options{}, selector)
+	require.NoError(t, err)
+	assert.Equal(t, "api", dev.Name)
+	assert.False(t, selector.called)
+
+	// --remember=false forgets the remembered selection
+	selector = &fakeDevSelector{dev: "frontend"}
+	dev, err = getDevFromManifest(fs, newManifest(), &Options{forgetDevSelection: true}, selector)
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", dev.Name)
+	assert.True(t, selector.called)
+	assert.Equal(t, utils.DevSelection{Dev: "frontend"}, utils.GetDevSelection(fs, "ns", "app"))
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// devContainerReadyInterval is the interval to check if the development container is receiving traffic
+	devContainerReadyInterval = time.Second
+
+	errDevContainerNotReady = errors.New("development container is not ready to receive traffic")
+)
+
+// shiftTrafficToDevContainer marks the development container as ready, waits until it receives traffic
+// and then scales down the original workload. It is only used by the zero-downtime activation,
+// once the ready probe succeeded and while the dev command starts.
+// If the development container keeps its own readiness probe, it decides when the container receives traffic
+func (up *upContext) shiftTrafficToDevContainer(ctx context.Context) {
+	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		oktetoLog.Warning("could not shift traffic to your development container: %s", err)
+		return
+	}
+
+	tr := getMainTranslation(up.Translations)
+	if tr == nil {
+		oktetoLog.Infof("main translation not found, skipping zero-downtime activation")
+		return
+	}
+
+	container := getMainDevContainer(tr)
+	if usesReadyFile(up.Pod, container) {
+		markReady := []string{"touch", model.ZeroDowntimeReadyFile}
+		if err := exec.Exec(ctx, k8sClient, restConfig, up.Pod.Namespace, up.Pod.Name, container, false, strings.NewReader(""), io.Discard, io.Discard, markReady); err != nil {
+			oktetoLog.Warning("could not mark your development container as ready: %s", err)
+			return
+		}
+	}
+
+	if err := waitUntilPodIsReady(ctx, up.Pod.Name, up.Pod.Namespace, up.Dev.Timeout.Resources, k8sClient); err != nil {
+		oktetoLog.Warning("could not shift traffic to your development container: %s", err)
+		return
+	}
+
+	if err := tr.ScaleDownOriginal(ctx, k8sClient); err != nil {
+		oktetoLog.Warning("could not scale down the original %s '%s': %s", tr.App.Kind(), tr.App.ObjectMeta().Name, err)
+		return
+	}
+	oktetoLog.Infof("traffic shifted to the development container, original %s '%s' scaled down", tr.App.Kind(), tr.App.ObjectMeta().Name)
+}
+
+func getMainTranslation(trMap map[string]*apps.Translation) *apps.Translation {
+	for _, tr := range trMap {
+		if tr.MainDev == tr.Dev {
+			return tr
+		}
+	}
+	return nil
+}
+
+func getMainDevContainer(tr *apps.Translation) string {
+	for _, rule := range tr.Rules {
+		if rule.IsMainDevContainer() {
+			return rule.Container
+		}
+	}
+	return tr.Dev.Container
+}
+
+// usesReadyFile returns true if the readiness of the container depends on the ready file created by okteto
+func usesReadyFile(pod *apiv1.Pod, container string) bool {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == container {
+			return apps.IsZeroDowntimeReadinessProbe(pod.Spec.Containers[i].ReadinessProbe)
+		}
+	}
+	return false
+}
+
+// waitUntilPodIsReady waits until the pod is ready to receive traffic
+func waitUntilPodIsReady(ctx context.Context, name, namespace string, timeout time.Duration, c kubernetes.Interface) error {
+	ticker := time.NewTicker(devContainerReadyInterval)
+	defer ticker.Stop()
+	to := time.After(timeout)
+
+	for {
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if isPodReady(pod) {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-to:
+			return fmt.Errorf("%w after %s", errDevContainerNotReady, timeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func isPodReady(pod *apiv1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == apiv1.PodReady {
+			return condition.Status == apiv1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWaitUntilPodIsReady(t *testing.T) {
+	devContainerReadyInterval = 10 * time.Millisecond
+	t.Cleanup(func() { devContainerReadyInterval = time.Second })
+
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test"},
+		Status: apiv1.PodStatus{
+			Conditions: []apiv1.PodCondition{{Type: apiv1.PodReady, Status: apiv1.ConditionFalse}},
+		},
+	}
+
+	t.Run("not ready", func(t *testing.T) {
+		c := fake.NewSimpleClientset(pod.DeepCopy())
+		err := waitUntilPodIsReady(context.Background(), "web", "test", 50*time.Millisecond, c)
+		assert.ErrorIs(t, err, errDevContainerNotReady)
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		ready := pod.DeepCopy()
+		ready.Status.Conditions[0].Status = apiv1.ConditionTrue
+		c := fake.NewSimpleClientset(ready)
+		require.NoError(t, waitUntilPodIsReady(context.Background(), "web", "test", time.Second, c))
+	})
+
+	t.Run("pod not found", func(t *testing.T) {
+		c := fake.NewSimpleClientset()
+		assert.Error(t, waitUntilPodIsReady(context.Background(), "web", "test", time.Second, c))
+	})
+}
+
+func TestGetMainTranslation(t *testing.T) {
+	main := &model.Dev{Name: "web", Container: "web"}
+	service := &model.Dev{Name: "worker"}
+	trMap := map[string]*apps.Translation{
+		"worker": {MainDev: main, Dev: service},
+		"web": {
+			MainDev: main,
+			Dev:     main,
+			Rules:   []*model.TranslationRule{{Container: "sidecar"}, {Container: "app", OktetoBinImageTag: model.OktetoBinImageTag}},
+		},
+	}
+
+	tr := getMainTranslation(trMap)
+	require.NotNil(t, tr)
+	assert.Equal(t, main, tr.Dev)
+	assert.Equal(t, "app", getMainDevContainer(tr))
+	assert.Nil(t, getMainTranslation(map[string]*apps.Translation{"worker": trMap["worker"]}))
+}
+
+func TestUsesReadyFile(t *testing.T) {
+	pod := &apiv1.Pod{
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{Name: "sidecar"},
+				{Name: "app"},
+			},
+		},
+	}
+	assert.False(t, usesReadyFile(pod, "app"))
+
+	apps.TranslateZeroDowntimeReadinessProbe(&pod.Spec.Containers[1])
+	assert.True(t, usesReadyFile(pod, "app"))
+	assert.False(t, usesReadyFile(pod, "sidecar"))
+
+	pod.Spec.Containers[1].ReadinessProbe = &apiv1.Probe{
+		ProbeHandler: apiv1.ProbeHandler{HTTPGet: &apiv1.HTTPGetAction{Path: "/healthz"}},
+	}
+	assert.False(t, usesReadyFile(pod, "app"))
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/cobra"
 )
 
@@ -36,6 +37,11 @@ func UpdateDeprecated() *cobra.Command {
 				return fmt.Errorf("could not retrieve version")
 			}
 
+			if okteto.IsOffline() {
+				oktetoLog.Information("Checking for new releases is disabled in offline installs")
+				return nil
+			}
+
 			if isUpdateAvailable(currentVersion) {
 				displayUpdateSteps()
 			} else {
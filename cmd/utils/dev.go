@@ -177,6 +177,11 @@ func GetDevFromManifest(manifest *model.Manifest, devName string) (*model.Dev, e
 
 // SelectDevFromManifest prompts the selector to choose a development container and returns the dev selected or error
 func SelectDevFromManifest(manifest *model.Manifest, selector OktetoSelectorInterface, devs []string) (*model.Dev, error) {
+	return SelectDevFromManifestWithDefault(manifest, selector, devs, "")
+}
+
+// SelectDevFromManifestWithDefault prompts the selector to choose a development container with defaultDev preselected and returns the dev selected or error
+func SelectDevFromManifestWithDefault(manifest *model.Manifest, selector OktetoSelectorInterface, devs []string, defaultDev string) (*model.Dev, error) {
 	sort.Slice(devs, func(i, j int) bool {
 		l1, l2 := len(devs[i]), len(devs[j])
 		if l1 != l2 {
@@ -184,15 +189,19 @@ func SelectDevFromManifest(manifest *model.Manifest, selector OktetoSelectorInte
 		}
 		return devs[i] < devs[j]
 	})
+	initialPosition := -1
 	var items []SelectorItem
-	for _, dev := range devs {
+	for i, dev := range devs {
+		if dev == defaultDev {
+			initialPosition = i
+		}
 		items = append(items, SelectorItem{
 			Name:   dev,
 			Label:  dev,
 			Enable: true,
 		})
 	}
-	devKey, err := selector.AskForOptionsOkteto(items, -1)
+	devKey, err := selector.AskForOptionsOkteto(items, initialPosition)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,73 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+const devSelectionFolder = ".dev-selections"
+
+// DevSelection stores the development container selected for a manifest in a namespace
+type DevSelection struct {
+	// Dev is the name of the last development container selected
+	Dev string `json:"dev"`
+	// Remember is true when the selection must be reused without asking again
+	Remember bool `json:"remember,omitempty"`
+}
+
+func getDevSelectionPath(fs afero.Fs, namespace, manifestName string) string {
+	return filepath.Join(config.GetOktetoHomeWithFilesystem(fs), devSelectionFolder, namespace, manifestName)
+}
+
+// GetDevSelection returns the development container last selected for a manifest in a namespace
+func GetDevSelection(fs afero.Fs, namespace, manifestName string) DevSelection {
+	result := DevSelection{}
+	if namespace == "" || manifestName == "" {
+		return result
+	}
+	filePath := getDevSelectionPath(fs, namespace, manifestName)
+	bytes, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		oktetoLog.Infof("failed to read dev selection file '%s': %s", filePath, err)
+		return result
+	}
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		oktetoLog.Infof("failed to parse dev selection file '%s': %s", filePath, err)
+		return DevSelection{}
+	}
+	return result
+}
+
+// SetDevSelection stores the development container selected for a manifest in a namespace
+func SetDevSelection(fs afero.Fs, namespace, manifestName string, selection DevSelection) error {
+	if namespace == "" || manifestName == "" {
+		return fmt.Errorf("can't store dev selection, namespace and manifest name are required")
+	}
+	filePath := getDevSelectionPath(fs, namespace, manifestName)
+	if err := fs.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(selection)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, filePath, bytes, 0600)
+}
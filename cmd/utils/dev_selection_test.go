@@ -0,0 +1,37 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DevSelection(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/okteto", 0700))
+	t.Setenv(constants.OktetoFolderEnvVar, "/okteto")
+
+	assert.Equal(t, DevSelection{}, GetDevSelection(fs, "ns", "app"))
+
+	require.NoError(t, SetDevSelection(fs, "ns", "app", DevSelection{Dev: "api", Remember: true}))
+	assert.Equal(t, DevSelection{Dev: "api", Remember: true}, GetDevSelection(fs, "ns", "app"))
+	assert.Equal(t, DevSelection{}, GetDevSelection(fs, "other-ns", "app"))
+
+	assert.Error(t, SetDevSelection(fs, "", "app", DevSelection{Dev: "api"}))
+}
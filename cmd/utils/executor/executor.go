@@ -15,6 +15,7 @@ package executor
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 
@@ -32,20 +33,23 @@ type ManifestExecutor interface {
 
 // Executor implements ManifestExecutor with a executor displayer
 type Executor struct {
-	displayer      executorDisplayer
-	outputMode     string
-	shell, dir     string
-	runWithoutBash bool
+	displayer       executorDisplayer
+	normalizer      *outputNormalizer
+	outputMode      string
+	shell, dir      string
+	runWithoutBash  bool
+	normalizeOutput bool
 }
 
 type executorDisplayer interface {
 	display(command string)
-	startCommand(cmd *exec.Cmd) error
+	startCommand(cmd *exec.Cmd, normalizer *outputNormalizer) error
 	cleanUp(err error)
 }
 
-// NewExecutor returns a new executor
-func NewExecutor(output string, runWithoutBash bool, dir string) *Executor {
+// NewExecutor returns a new executor. If normalizeOutput is true, the output of the commands is post-processed
+// to collapse repeated progress lines, highlight warnings and errors and summarize each command
+func NewExecutor(output string, runWithoutBash bool, dir string, normalizeOutput bool) *Executor {
 	var displayer executorDisplayer
 
 	switch output {
@@ -65,11 +69,12 @@ func NewExecutor(output string, runWithoutBash bool, dir string) *Executor {
 	}
 
 	return &Executor{
-		outputMode:     output,
-		displayer:      displayer,
-		runWithoutBash: runWithoutBash,
-		shell:          shell,
-		dir:            dir,
+		outputMode:      output,
+		displayer:       displayer,
+		runWithoutBash:  runWithoutBash,
+		shell:           shell,
+		dir:             dir,
+		normalizeOutput: normalizeOutput,
 	}
 }
 
@@ -86,7 +91,12 @@ func (e *Executor) Execute(cmdInfo model.DeployCommand, env []string) error {
 		cmd.Dir = e.dir
 	}
 
-	if err := e.displayer.startCommand(cmd); err != nil {
+	e.normalizer = nil
+	if e.normalizeOutput {
+		e.normalizer = newOutputNormalizer()
+	}
+
+	if err := e.displayer.startCommand(cmd, e.normalizer); err != nil {
 		if execErr, ok := err.(*exec.Error); ok {
 			if execErr != nil && execErr.Name == e.shell {
 				return fmt.Errorf("%w: \"%s\" is a required dependency for executing the command", err, e.shell)
@@ -100,6 +110,15 @@ func (e *Executor) Execute(cmdInfo model.DeployCommand, env []string) error {
 	err := cmd.Wait()
 
 	e.CleanUp(err)
+
+	if e.normalizer != nil {
+		if e.normalizer.hasNoise() {
+			oktetoLog.Information("Command '%s' output: %s", cmdInfo.Name, e.normalizer.summary())
+		} else {
+			oktetoLog.Infof("command '%s' output: %s", cmdInfo.Name, e.normalizer.summary())
+		}
+		err = e.normalizer.wrapError(err)
+	}
 	return err
 }
 
@@ -108,8 +127,31 @@ func (e *Executor) CleanUp(err error) {
 	if e.displayer != nil {
 		e.displayer.cleanUp(err)
 	}
+	if e.normalizer != nil {
+		e.normalizer.stop()
+	}
 }
 
 func startCommand(cmd *exec.Cmd) error {
 	return cmd.Start()
 }
+
+// getCommandOutput returns the readers for the stdout and stderr of a command.
+// If a normalizer is provided, the readers return the normalized output
+func getCommandOutput(cmd *exec.Cmd, normalizer *outputNormalizer) (io.Reader, io.Reader, error) {
+	stdoutReader, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stderrReader, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if normalizer == nil {
+		return stdoutReader, stderrReader, nil
+	}
+	stdout, stderr := normalizer.normalize(stdoutReader, stderrReader)
+	return stdout, stderr, nil
+}
@@ -28,13 +28,8 @@ func newJSONExecutor() *jsonExecutor {
 	return &jsonExecutor{}
 }
 
-func (e *jsonExecutor) startCommand(cmd *exec.Cmd) error {
-	stdoutReader, err := cmd.StdoutPipe()
-	if err != nil {
-		return err
-	}
-
-	stderrReader, err := cmd.StderrPipe()
+func (e *jsonExecutor) startCommand(cmd *exec.Cmd, normalizer *outputNormalizer) error {
+	stdoutReader, stderrReader, err := getCommandOutput(cmd, normalizer)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,228 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// maxFailureLines is the number of error lines kept to explain why a command failed
+	maxFailureLines = 3
+
+	maxLineSize = 1024 * 1024
+)
+
+var (
+	errorLineRegex    = regexp.MustCompile(`(?i)^\s*(error|fatal)\b|^E\d{4}\s|\b(error|failed):`)
+	warningLineRegex  = regexp.MustCompile(`(?i)^\s*(warn|warning)\b|^W\d{4}\s|\bwarning:`)
+	progressLineRegex = regexp.MustCompile(`(?i)\d+%|\d+ of \d+|\d+/\d+|waiting|progress|pending`)
+	digitsRegex       = regexp.MustCompile(`\d+`)
+)
+
+// outputNormalizer post-processes the output of a command to reduce the noise of tools like kubectl or helm:
+// repeated progress lines are collapsed, warnings and errors are sent to the highlighted stream (stderr)
+// and every other line to the regular stream (stdout), and the error lines are kept to explain failures
+type outputNormalizer struct {
+	stdoutReader *io.PipeReader
+	stdoutWriter *io.PipeWriter
+	stderrReader *io.PipeReader
+	stderrWriter *io.PipeWriter
+
+	failures  []string
+	lines     int
+	collapsed int
+	warnings  int
+	errors    int
+	sync.Mutex
+}
+
+// lineCollapser keeps the state needed to collapse consecutive progress lines of a stream
+type lineCollapser struct {
+	shape       string
+	last        string
+	repetitions int
+}
+
+func newOutputNormalizer() *outputNormalizer {
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	return &outputNormalizer{
+		stdoutReader: stdoutReader,
+		stdoutWriter: stdoutWriter,
+		stderrReader: stderrReader,
+		stderrWriter: stderrWriter,
+	}
+}
+
+// normalize starts processing the command output and returns the normalized stdout and stderr readers
+func (n *outputNormalizer) normalize(stdout, stderr io.Reader) (io.Reader, io.Reader) {
+	wg := sync.WaitGroup{}
+	for _, r := range []io.Reader{stdout, stderr} {
+		if r == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(r io.Reader) {
+			defer wg.Done()
+			n.process(r)
+		}(r)
+	}
+	go func() {
+		wg.Wait()
+		n.stdoutWriter.Close()
+		n.stderrWriter.Close()
+	}()
+	return n.stdoutReader, n.stderrReader
+}
+
+// stop discards the rest of the output, so the command is never blocked when nobody is displaying it
+func (n *outputNormalizer) stop() {
+	n.stdoutReader.Close()
+	n.stderrReader.Close()
+}
+
+func (n *outputNormalizer) process(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	collapser := &lineCollapser{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		// progress bars rewrite the same line using carriage returns, only the last update matters
+		if idx := strings.LastIndex(line, "\r"); idx >= 0 {
+			line = line[idx+1:]
+		}
+		line = strings.TrimRight(line, " \t")
+
+		n.Lock()
+		n.lines++
+		n.Unlock()
+
+		collapse, repeated := collapser.add(line)
+		if collapse {
+			n.Lock()
+			n.collapsed++
+			n.Unlock()
+			continue
+		}
+		if repeated != "" {
+			n.write(repeated)
+		}
+		n.write(line)
+	}
+	if repeated := collapser.flush(); repeated != "" {
+		n.write(repeated)
+	}
+	if err := scanner.Err(); err != nil {
+		oktetoLog.Infof("error reading command output: %s", err)
+	}
+}
+
+// add returns true if the line is a repetition of the previous progress line and must be collapsed.
+// Otherwise, it returns the last collapsed line, if any, so the final state of the progress is not lost
+func (c *lineCollapser) add(line string) (bool, string) {
+	shape := digitsRegex.ReplaceAllString(line, "#")
+	if c.shape != "" && shape == c.shape && (line == c.last || progressLineRegex.MatchString(line)) {
+		c.last = line
+		c.repetitions++
+		return true, ""
+	}
+	repeated := c.flush()
+	c.shape = shape
+	c.last = line
+	return false, repeated
+}
+
+// flush returns the last collapsed line with the number of repetitions, if any
+func (c *lineCollapser) flush() string {
+	if c.repetitions == 0 {
+		return ""
+	}
+	repeated := fmt.Sprintf("%s (repeated %d times)", c.last, c.repetitions)
+	c.repetitions = 0
+	return repeated
+}
+
+func (n *outputNormalizer) write(line string) {
+	w := n.stdoutWriter
+	switch {
+	case errorLineRegex.MatchString(line):
+		w = n.stderrWriter
+		n.Lock()
+		n.errors++
+		n.failures = append(n.failures, strings.TrimSpace(line))
+		if len(n.failures) > maxFailureLines {
+			n.failures = n.failures[1:]
+		}
+		n.Unlock()
+	case warningLineRegex.MatchString(line):
+		w = n.stderrWriter
+		n.Lock()
+		n.warnings++
+		n.Unlock()
+	}
+	// errors are ignored: if the output is no longer displayed, it is discarded
+	_, _ = fmt.Fprintln(w, line)
+}
+
+// summary returns a compact description of the command output
+func (n *outputNormalizer) summary() string {
+	n.Lock()
+	defer n.Unlock()
+	return fmt.Sprintf("%d lines (%d collapsed), %d warnings, %d errors", n.lines, n.collapsed, n.warnings, n.errors)
+}
+
+// hasNoise returns if the output had lines worth mentioning in the summary
+func (n *outputNormalizer) hasNoise() bool {
+	n.Lock()
+	defer n.Unlock()
+	return n.collapsed > 0 || n.warnings > 0 || n.errors > 0
+}
+
+// wrapError adds the error lines extracted from the output to the error of a failed command
+func (n *outputNormalizer) wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	n.Lock()
+	defer n.Unlock()
+	if len(n.failures) == 0 {
+		return err
+	}
+	return commandFailedError{
+		err:      err,
+		failures: append([]string{}, n.failures...),
+	}
+}
+
+// commandFailedError is the error of a command including the error lines extracted from its output
+type commandFailedError struct {
+	err      error
+	failures []string
+}
+
+func (e commandFailedError) Error() string {
+	return fmt.Sprintf("%s: %s", e.err.Error(), strings.Join(e.failures, "; "))
+}
+
+func (e commandFailedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readNormalized(t *testing.T, n *outputNormalizer, stdout, stderr string) (string, string) {
+	t.Helper()
+	outReader, errReader := n.normalize(strings.NewReader(stdout), strings.NewReader(stderr))
+
+	var out, errOut []byte
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var err error
+		out, err = io.ReadAll(outReader)
+		require.NoError(t, err)
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		errOut, err = io.ReadAll(errReader)
+		require.NoError(t, err)
+	}()
+	wg.Wait()
+	return string(out), string(errOut)
+}
+
+func TestNormalizerCollapsesProgressLines(t *testing.T) {
+	n := newOutputNormalizer()
+	stdout := strings.Join([]string{
+		"deployment.apps/api created",
+		`Waiting for deployment "api" rollout to finish: 0 of 3 updated replicas are available...`,
+		`Waiting for deployment "api" rollout to finish: 1 of 3 updated replicas are available...`,
+		`Waiting for deployment "api" rollout to finish: 2 of 3 updated replicas are available...`,
+		`deployment "api" successfully rolled out`,
+		"Downloading 10%\rDownloading 50%\rDownloading 100%",
+	}, "\n")
+
+	out, errOut := readNormalized(t, n, stdout, "")
+
+	expected := strings.Join([]string{
+		"deployment.apps/api created",
+		`Waiting for deployment "api" rollout to finish: 0 of 3 updated replicas are available...`,
+		`Waiting for deployment "api" rollout to finish: 2 of 3 updated replicas are available... (repeated 2 times)`,
+		`deployment "api" successfully rolled out`,
+		"Downloading 100%",
+	}, "\n") + "\n"
+	assert.Equal(t, expected, out)
+	assert.Empty(t, errOut)
+	assert.Equal(t, "6 lines (2 collapsed), 0 warnings, 0 errors", n.summary())
+	assert.True(t, n.hasNoise())
+}
+
+func TestNormalizerDoesNotCollapseDifferentResources(t *testing.T) {
+	n := newOutputNormalizer()
+	stdout := "service/api-1 created\nservice/api-2 created\n"
+
+	out, _ := readNormalized(t, n, stdout, "")
+
+	assert.Equal(t, stdout, out)
+	assert.False(t, n.hasNoise())
+}
+
+func TestNormalizerHighlightsWarningsAndErrors(t *testing.T) {
+	n := newOutputNormalizer()
+	stderr := strings.Join([]string{
+		"Release \"api\" does not exist. Installing it now.",
+		"W0102 10:00:00.000000 1 warnings.go:70] policy/v1beta1 PodSecurityPolicy is deprecated",
+		"Error: INSTALLATION FAILED: timed out waiting for the condition",
+	}, "\n")
+
+	out, errOut := readNormalized(t, n, "", stderr)
+
+	assert.Equal(t, "Release \"api\" does not exist. Installing it now.\n", out)
+	assert.Equal(t, "W0102 10:00:00.000000 1 warnings.go:70] policy/v1beta1 PodSecurityPolicy is deprecated\nError: INSTALLATION FAILED: timed out waiting for the condition\n", errOut)
+	assert.Equal(t, "3 lines (0 collapsed), 1 warnings, 1 errors", n.summary())
+}
+
+func TestNormalizerWrapError(t *testing.T) {
+	n := newOutputNormalizer()
+	stderr := "error: line 1\nerror: line 2\nerror: line 3\nerror: line 4\n"
+	readNormalized(t, n, "", stderr)
+
+	assert.NoError(t, n.wrapError(nil))
+
+	exitErr := errors.New("exit status 1")
+	err := n.wrapError(exitErr)
+	assert.Equal(t, "exit status 1: error: line 2; error: line 3; error: line 4", err.Error())
+	assert.True(t, errors.Is(err, exitErr))
+
+	assert.Equal(t, assert.AnError, newOutputNormalizer().wrapError(assert.AnError))
+}
+
+func TestNormalizerStopDiscardsOutput(t *testing.T) {
+	n := newOutputNormalizer()
+	r, w := io.Pipe()
+	outReader, _ := n.normalize(r, nil)
+	n.stop()
+
+	_, err := w.Write([]byte("line not displayed\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = outReader.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+}
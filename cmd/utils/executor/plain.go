@@ -30,13 +30,8 @@ func newPlainExecutor() *plainExecutor {
 	}
 }
 
-func (e *plainExecutor) startCommand(cmd *exec.Cmd) error {
-	stdoutReader, err := cmd.StdoutPipe()
-	if err != nil {
-		return err
-	}
-
-	stderrReader, err := cmd.StderrPipe()
+func (e *plainExecutor) startCommand(cmd *exec.Cmd, normalizer *outputNormalizer) error {
+	stdoutReader, stderrReader, err := getCommandOutput(cmd, normalizer)
 	if err != nil {
 		return err
 	}
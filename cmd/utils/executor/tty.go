@@ -38,13 +38,8 @@ func (e *ttyExecutor) cleanUp(err error) {
 	}
 }
 
-func (e *ttyExecutor) startCommand(cmd *exec.Cmd) error {
-	stdoutReader, err := cmd.StdoutPipe()
-	if err != nil {
-		return err
-	}
-
-	stderrReader, err := cmd.StderrPipe()
+func (e *ttyExecutor) startCommand(cmd *exec.Cmd, normalizer *outputNormalizer) error {
+	stdoutReader, stderrReader, err := getCommandOutput(cmd, normalizer)
 	if err != nil {
 		return err
 	}
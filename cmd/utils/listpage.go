@@ -0,0 +1,189 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// listCacheTTL is the time the results of a list command are reused to show its next pages
+	listCacheTTL = 2 * time.Minute
+
+	listCacheFolder = "list-cache"
+)
+
+// ListPage is the page of a list command selected with the --limit and --page flags
+type ListPage struct {
+	Limit int
+	Page  int
+}
+
+// AddFlags adds the --limit and --page flags to a list command
+func (p *ListPage) AddFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&p.Limit, "limit", 0, "maximum number of items to show. All items are shown if not set")
+	flags.IntVar(&p.Page, "page", 1, "page of the list to show, with as many items per page as the value of --limit. The list is cached briefly when the first page is requested, so the next pages are shown without querying it again")
+}
+
+// Validate checks the values of the --limit and --page flags
+func (p ListPage) Validate() error {
+	if p.Limit < 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value '%d' for the '--limit' flag", p.Limit),
+			Hint: "The limit must be a positive number",
+		}
+	}
+	if p.Page < 1 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value '%d' for the '--page' flag", p.Page),
+			Hint: "Pages start at 1",
+		}
+	}
+	if p.Page > 1 && p.Limit == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the '--page' flag requires the '--limit' flag"),
+			Hint: "Use '--limit' to set the number of items of each page",
+		}
+	}
+	return nil
+}
+
+// Paginate returns the items of the page
+func Paginate[T any](items []T, p ListPage) []T {
+	if p.Limit <= 0 {
+		return items
+	}
+	start := (p.Page - 1) * p.Limit
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + p.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// ListCache stores on disk the results of the list commands for a short time. A nil cache doesn't store anything
+type ListCache struct {
+	fs  afero.Fs
+	now func() time.Time
+	dir string
+	ttl time.Duration
+}
+
+type listCacheEntry struct {
+	CreatedAt time.Time       `json:"createdAt"`
+	Items     json.RawMessage `json:"items"`
+}
+
+// NewListCache returns the cache of the list commands
+func NewListCache() *ListCache {
+	return NewListCacheWithFilesystem(afero.NewOsFs())
+}
+
+// NewListCacheWithFilesystem returns the cache of the list commands stored in fs
+func NewListCacheWithFilesystem(fs afero.Fs) *ListCache {
+	return &ListCache{
+		fs:  fs,
+		now: time.Now,
+		dir: filepath.Join(config.GetOktetoHomeWithFilesystem(fs), listCacheFolder),
+		ttl: listCacheTTL,
+	}
+}
+
+// ListCacheKey returns the key of a list in the cache. parts must identify the context and the filters of the list
+func ListCacheKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// FetchList returns the list to paginate. Pages after the first one reuse the list cached by a previous request
+// of the same list, the first page always calls list and refreshes the cache
+func FetchList[T any](lc *ListCache, key string, p ListPage, list func() ([]T, error)) ([]T, error) {
+	var items []T
+	if p.Page > 1 && lc.get(key, &items) {
+		return items, nil
+	}
+	items, err := list()
+	if err != nil {
+		return nil, err
+	}
+	if p.Limit > 0 {
+		lc.set(key, items)
+	}
+	return items, nil
+}
+
+func (lc *ListCache) path(key string) string {
+	return filepath.Join(lc.dir, fmt.Sprintf("%s.json", key))
+}
+
+func (lc *ListCache) get(key string, items interface{}) bool {
+	if lc == nil {
+		return false
+	}
+	content, err := afero.ReadFile(lc.fs, lc.path(key))
+	if err != nil {
+		return false
+	}
+	var entry listCacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		oktetoLog.Infof("ignoring invalid list cache: %s", err)
+		return false
+	}
+	if lc.now().Sub(entry.CreatedAt) > lc.ttl {
+		return false
+	}
+	if err := json.Unmarshal(entry.Items, items); err != nil {
+		oktetoLog.Infof("ignoring invalid list cache: %s", err)
+		return false
+	}
+	return true
+}
+
+func (lc *ListCache) set(key string, items interface{}) {
+	if lc == nil {
+		return
+	}
+	encodedItems, err := json.Marshal(items)
+	if err != nil {
+		oktetoLog.Infof("failed to encode the list cache: %s", err)
+		return
+	}
+	content, err := json.Marshal(listCacheEntry{CreatedAt: lc.now(), Items: encodedItems})
+	if err != nil {
+		oktetoLog.Infof("failed to encode the list cache: %s", err)
+		return
+	}
+	if err := lc.fs.MkdirAll(lc.dir, 0700); err != nil {
+		oktetoLog.Infof("failed to create the list cache folder: %s", err)
+		return
+	}
+	if err := afero.WriteFile(lc.fs, lc.path(key), content, 0600); err != nil {
+		oktetoLog.Infof("failed to write the list cache: %s", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPageValidate(t *testing.T) {
+	assert.NoError(t, ListPage{Page: 1}.Validate())
+	assert.NoError(t, ListPage{Limit: 10, Page: 3}.Validate())
+	assert.Error(t, ListPage{Limit: -1, Page: 1}.Validate())
+	assert.Error(t, ListPage{Limit: 10, Page: 0}.Validate())
+	assert.Error(t, ListPage{Page: 2}.Validate())
+}
+
+func TestPaginate(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	assert.Equal(t, items, Paginate(items, ListPage{Page: 1}))
+	assert.Equal(t, []string{"a", "b"}, Paginate(items, ListPage{Limit: 2, Page: 1}))
+	assert.Equal(t, []string{"c", "d"}, Paginate(items, ListPage{Limit: 2, Page: 2}))
+	assert.Equal(t, []string{"e"}, Paginate(items, ListPage{Limit: 2, Page: 3}))
+	assert.Equal(t, []string{}, Paginate(items, ListPage{Limit: 2, Page: 4}))
+}
+
+func TestFetchList(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("/home/okteto", 0700))
+	t.Setenv(constants.OktetoHomeEnvVar, "/home/okteto")
+	now := time.Now()
+	cache := NewListCacheWithFilesystem(fs)
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	list := func(items ...string) func() ([]string, error) {
+		return func() ([]string, error) {
+			calls++
+			return items, nil
+		}
+	}
+	key := ListCacheKey("namespaces", "context")
+
+	// the first page always lists and refreshes the cache
+	items, err := FetchList(cache, key, ListPage{Limit: 2, Page: 1}, list("a", "b", "c"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, items)
+	assert.Equal(t, 1, calls)
+
+	// next pages reuse the cached list
+	items, err = FetchList(cache, key, ListPage{Limit: 2, Page: 2}, list("x"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, items)
+	assert.Equal(t, 1, calls)
+
+	// other lists are not shared
+	items, err = FetchList(cache, ListCacheKey("namespaces", "other"), ListPage{Limit: 2, Page: 2}, list("y"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"y"}, items)
+	assert.Equal(t, 2, calls)
+
+	// the cache expires
+	cache.now = func() time.Time { return now.Add(listCacheTTL + time.Second) }
+	items, err = FetchList(cache, key, ListPage{Limit: 2, Page: 2}, list("z"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"z"}, items)
+	assert.Equal(t, 3, calls)
+
+	_, err = FetchList(cache, key, ListPage{Page: 1}, func() ([]string, error) {
+		return nil, assert.AnError
+	})
+	assert.True(t, errors.Is(err, assert.AnError))
+}
+
+func TestFetchListWithoutCache(t *testing.T) {
+	items, err := FetchList(nil, "key", ListPage{Limit: 1, Page: 2}, func() ([]string, error) {
+		return []string{"a", "b"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, items)
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// IsPerUserNamesEnabled returns if the dev environment names of the current context are suffixed with the username
+func IsPerUserNamesEnabled() bool {
+	enabled, err := strconv.ParseBool(config.GetSetting(okteto.GetContext().Name, config.PerUserNamesSetting))
+	if err != nil {
+		return false
+	}
+	return enabled
+}
+
+// PerUserName returns the name of a dev environment suffixed with the username when per-user names are enabled.
+// It allows several users to deploy the same repository in a shared namespace without overwriting
+// the pipeline configmaps, divert resources and endpoints of each other
+func PerUserName(name string) string {
+	if !IsPerUserNamesEnabled() {
+		return name
+	}
+	return withUserSuffix(name, okteto.GetSanitizedUsername())
+}
+
+func withUserSuffix(name, username string) string {
+	if name == "" || username == "" {
+		return name
+	}
+	suffix := fmt.Sprintf("-%s", username)
+	if strings.HasSuffix(name, suffix) {
+		return name
+	}
+	return fmt.Sprintf("%s%s", name, suffix)
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithUserSuffix(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    string
+		username string
+		expected string
+	}{
+		{name: "suffixed", input: "movies", username: "cindy", expected: "movies-cindy"},
+		{name: "already suffixed", input: "movies-cindy", username: "cindy", expected: "movies-cindy"},
+		{name: "no username", input: "movies", expected: "movies"},
+		{name: "no name", username: "cindy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, withUserSuffix(tt.input, tt.username))
+		})
+	}
+}
+
+func TestPerUserName(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	currentStore := okteto.CurrentStore
+	defer func() {
+		okteto.CurrentStore = currentStore
+	}()
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Name:     "test",
+				Username: "Cindy",
+			},
+		},
+	}
+
+	t.Setenv("OKTETO_PER_USER_NAMES", "false")
+	assert.Equal(t, "movies", PerUserName("movies"))
+
+	t.Setenv("OKTETO_PER_USER_NAMES", "true")
+	assert.Equal(t, "movies-cindy", PerUserName("movies"))
+}
@@ -22,6 +22,7 @@ import (
 	"runtime"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/chzyer/readline"
 	"github.com/juju/ansiterm"
@@ -53,6 +54,8 @@ type OktetoSelector struct {
 	Label           string
 	Items           []SelectorItem
 	Size            int
+	// Searchable enables filtering the options by typing a fuzzy search term
+	Searchable bool
 }
 
 // oktetoTemplates stores the templates to render the text
@@ -64,6 +67,7 @@ type oktetoTemplates struct {
 	selected  *template.Template
 	details   *template.Template
 	help      *template.Template
+	search    *template.Template
 	extraInfo *template.Template
 }
 
@@ -167,10 +171,28 @@ func (s *OktetoSelector) run(initialPosition int) (string, error) {
 	sb := screenbuf.New(rl)
 	l.SetCursor(startPosition)
 
+	var searchTerm []rune
+	if s.Searchable {
+		l.Searcher = func(input string, index int) bool {
+			return fuzzyMatch(input, s.Items[index].Name)
+		}
+	}
+
 	c.SetListener(func(line []rune, pos int, key rune) ([]rune, int, bool) {
+		_, activeIdx := l.Items()
 		switch {
 		case key == promptui.KeyEnter:
 			return nil, 0, true
+		case s.Searchable && (key == readline.CharBackspace || key == promptui.KeyCtrlH):
+			if len(searchTerm) > 0 {
+				searchTerm = searchTerm[:len(searchTerm)-1]
+				l.Search(string(searchTerm))
+			}
+		case s.Searchable && key != ' ' && unicode.IsPrint(key):
+			searchTerm = append(searchTerm, key)
+			l.Search(string(searchTerm))
+		case activeIdx == list.NotFound:
+			// there are no items matching the search term, there is nothing to move
 		case key == s.Keys.Next.Code:
 			nextItemIndex := l.Index() + 1
 			if s.Size > nextItemIndex && !s.Items[nextItemIndex].Enable {
@@ -202,6 +224,12 @@ func (s *OktetoSelector) run(initialPosition int) (string, error) {
 
 		s.renderLabel(sb)
 
+		if s.Searchable && len(searchTerm) > 0 {
+			if _, err := sb.Write(render(s.OktetoTemplates.search, string(searchTerm))); err != nil {
+				oktetoLog.Infof("error writing search term: %s", err)
+			}
+		}
+
 		help := s.renderHelp()
 		if _, err := sb.Write(help); err != nil {
 			oktetoLog.Infof("error writing help: %s", err)
@@ -375,7 +403,7 @@ func (s *OktetoSelector) prepareTemplates() error {
 	if s.Templates.Help == "" {
 		s.Templates.Help = fmt.Sprintf(`{{ "Use the arrow keys to navigate:" | faint }} {{ .NextKey | faint }} ` +
 			`{{ .PrevKey | faint }} {{ .PageDownKey | faint }} {{ .PageUpKey | faint }} ` +
-			`{{ if .Search }} {{ "and" | faint }} {{ .SearchKey | faint }} {{ "toggles search" | faint }}{{ end }}`)
+			`{{ if .Search }} {{ "or type to search" | faint }}{{ end }}`)
 	}
 
 	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(s.Templates.Help)
@@ -385,6 +413,13 @@ func (s *OktetoSelector) prepareTemplates() error {
 
 	tpls.help = tpl
 
+	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(`{{ "Search:" | faint }} {{ . }}`)
+	if err != nil {
+		return err
+	}
+
+	tpls.search = tpl
+
 	extraInfo := changeColorForWindows(`{{ " i " | black | bgBlue }} {{ "Use 'okteto context <URL>' to add a new cluster context" | oktetoblue }}`)
 
 	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(extraInfo)
@@ -467,6 +502,7 @@ func (s *OktetoSelector) renderHelp() []byte {
 		PageDownKey: s.Keys.PageDown.Display,
 		PageUpKey:   s.Keys.PageUp.Display,
 		SearchKey:   s.Keys.Search.Display,
+		Search:      s.Searchable,
 	}
 
 	return render(s.OktetoTemplates.help, keys)
@@ -522,6 +558,22 @@ func getInactiveTemplate() string {
 	return result
 }
 
+// fuzzyMatch returns true if all the characters of term appear in candidate in the same order, ignoring case
+func fuzzyMatch(term, candidate string) bool {
+	term = strings.ToLower(strings.TrimSpace(term))
+	candidate = strings.ToLower(candidate)
+	remaining := []rune(term)
+	for _, c := range candidate {
+		if len(remaining) == 0 {
+			break
+		}
+		if c == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
+
 func changeColorForWindows(template string) string {
 	if runtime.GOOS == "windows" {
 		template = strings.ReplaceAll(template, "oktetoblue", "blue")
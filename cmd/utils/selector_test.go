@@ -0,0 +1,65 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		term      string
+		candidate string
+		expected  bool
+	}{
+		{
+			name:      "empty term",
+			term:      "",
+			candidate: "api",
+			expected:  true,
+		},
+		{
+			name:      "prefix",
+			term:      "fro",
+			candidate: "frontend",
+			expected:  true,
+		},
+		{
+			name:      "subsequence ignoring case",
+			term:      "FTD",
+			candidate: "frontend",
+			expected:  true,
+		},
+		{
+			name:      "wrong order",
+			term:      "dnf",
+			candidate: "frontend",
+			expected:  false,
+		},
+		{
+			name:      "longer than candidate",
+			term:      "apis",
+			candidate: "api",
+			expected:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fuzzyMatch(tt.term, tt.candidate))
+		})
+	}
+}
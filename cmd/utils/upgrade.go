@@ -16,15 +16,26 @@ package utils
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"runtime"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-github/github"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
 )
 
+// releaseCheckTimeout is the maximum time spent checking for new releases, so unreachable release urls don't block the commands
+const releaseCheckTimeout = 3 * time.Second
+
+// UpgradeAvailable returns the latest okteto version if it's a minor or major upgrade. It never checks for new releases in offline installs
 func UpgradeAvailable() string {
+	if okteto.IsOffline() {
+		return ""
+	}
+
 	current, err := semver.NewVersion(config.VersionString)
 	if err != nil {
 		return ""
@@ -54,8 +65,9 @@ func UpgradeAvailable() string {
 
 // GetLatestVersionFromGithub returns the latest okteto version from GitHub
 func GetLatestVersionFromGithub() (string, error) {
-	client := github.NewClient(nil)
-	ctx := context.Background()
+	client := github.NewClient(&http.Client{Timeout: releaseCheckTimeout})
+	ctx, cancel := context.WithTimeout(context.Background(), releaseCheckTimeout)
+	defer cancel()
 	releases, _, err := client.Repositories.ListReleases(ctx, "okteto", "okteto", &github.ListOptions{PerPage: 10})
 	if err != nil {
 		return "", fmt.Errorf("fail to get releases from github: %w", err)
@@ -0,0 +1,187 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vars
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/compose-spec/godotenv"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const maskedValue = "********"
+
+type syncFlags struct {
+	Flags
+	from   string
+	dryRun bool
+}
+
+// varsDiff are the changes needed to make the Okteto variables match a .env file
+type varsDiff struct {
+	added   []env.Var
+	changed []env.Var
+	removed []string
+}
+
+func sync(ctx context.Context) *cobra.Command {
+	flags := &syncFlags{}
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync your Okteto variables with the content of a .env file",
+		Long: `Sync your Okteto variables with the content of a .env file.
+
+The variables of the .env file are compared with the Okteto variables of the scope: the new and changed variables are set,
+and the variables not defined in the .env file are removed. Values are never displayed.
+Running it again with the same .env file doesn't change anything, so it can run as part of your CI pipelines.`,
+		Example: `okteto vars sync --from .env --scope namespace`,
+		Args:    utils.NoArgsAccepted(docsURL),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateScope(flags.scope); err != nil {
+				return err
+			}
+			vars, err := readEnvFile(afero.NewOsFs(), flags.from)
+			if err != nil {
+				return err
+			}
+			vc, err := initVarsCommand(ctx, &flags.Flags)
+			if err != nil {
+				return err
+			}
+			return vc.executeSync(ctx, vars, flags, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&flags.from, "from", ".env", "path to the .env file with the variables")
+	cmd.Flags().StringVar(&flags.scope, "scope", types.VariableScopeNamespace, fmt.Sprintf("scope of the variables. One of: ['%s', '%s']", types.VariableScopeNamespace, types.VariableScopePersonal))
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "namespace of the variables when the scope is 'namespace' (defaults to the current namespace)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "show the changes without applying them")
+	return cmd
+}
+
+// readEnvFile returns the variables of a .env file sorted by name
+func readEnvFile(fs afero.Fs, path string) ([]env.Var, error) {
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("the file '%s' does not exist", path),
+				Hint: "Use the flag '--from' to set the path to your .env file",
+			}
+		}
+		return nil, err
+	}
+	values, err := godotenv.Unmarshal(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid .env file '%s': %w", path, err)
+	}
+
+	result := make([]env.Var, 0, len(values))
+	for name, value := range values {
+		result = append(result, env.Var{Name: name, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// getVarsDiff returns the changes needed to make the current variables match the desired ones
+func getVarsDiff(desired, current []env.Var) varsDiff {
+	currentValues := map[string]string{}
+	for _, v := range current {
+		currentValues[v.Name] = v.Value
+	}
+	desiredNames := map[string]bool{}
+
+	diff := varsDiff{}
+	for _, v := range desired {
+		desiredNames[v.Name] = true
+		value, ok := currentValues[v.Name]
+		switch {
+		case !ok:
+			diff.added = append(diff.added, v)
+		case value != v.Value:
+			diff.changed = append(diff.changed, v)
+		}
+	}
+	for _, v := range current {
+		if !desiredNames[v.Name] {
+			diff.removed = append(diff.removed, v.Name)
+		}
+	}
+	sort.Strings(diff.removed)
+	return diff
+}
+
+func (d varsDiff) isEmpty() bool {
+	return len(d.added) == 0 && len(d.changed) == 0 && len(d.removed) == 0
+}
+
+// print shows the changes with the values masked
+func (d varsDiff) print(w io.Writer) {
+	for _, v := range d.added {
+		fmt.Fprintf(w, "  + %s=%s\n", v.Name, maskedValue)
+	}
+	for _, v := range d.changed {
+		fmt.Fprintf(w, "  ~ %s=%s\n", v.Name, maskedValue)
+	}
+	for _, name := range d.removed {
+		fmt.Fprintf(w, "  - %s\n", name)
+	}
+	fmt.Fprintf(w, "%d to add, %d to change, %d to remove\n", len(d.added), len(d.changed), len(d.removed))
+}
+
+func (vc *Command) executeSync(ctx context.Context, vars []env.Var, flags *syncFlags, w io.Writer) error {
+	current, err := vc.okClient.Variables().List(ctx, flags.scope, flags.namespace)
+	if err != nil {
+		return err
+	}
+
+	diff := getVarsDiff(vars, current)
+	if diff.isEmpty() {
+		oktetoLog.Success("The %s variables are already in sync with '%s'", flags.scope, flags.from)
+		return nil
+	}
+	diff.print(w)
+	if flags.dryRun {
+		oktetoLog.Information("Dry run: no variables were changed")
+		return nil
+	}
+
+	toSet := make([]env.Var, 0, len(diff.added)+len(diff.changed))
+	toSet = append(toSet, diff.added...)
+	toSet = append(toSet, diff.changed...)
+	if len(toSet) > 0 {
+		if err := vc.okClient.Variables().Set(ctx, flags.scope, flags.namespace, toSet); err != nil {
+			return err
+		}
+	}
+	if len(diff.removed) > 0 {
+		if err := vc.okClient.Variables().Delete(ctx, flags.scope, flags.namespace, diff.removed); err != nil {
+			return err
+		}
+	}
+	oktetoLog.Success("The %s variables are in sync with '%s'", flags.scope, flags.from)
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vars
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test/client"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateScope(t *testing.T) {
+	assert.NoError(t, validateScope(types.VariableScopeNamespace))
+	assert.NoError(t, validateScope(types.VariableScopePersonal))
+	assert.ErrorAs(t, validateScope("cluster"), &oktetoErrors.UserError{})
+}
+
+func TestReadEnvFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, ".env", []byte("# database\nDB_USER=admin\nDB_PASSWORD=\"s3cr3t\"\nAPI_KEY=abc\n"), 0600))
+
+	vars, err := readEnvFile(fs, ".env")
+	require.NoError(t, err)
+	assert.Equal(t, []env.Var{
+		{Name: "API_KEY", Value: "abc"},
+		{Name: "DB_PASSWORD", Value: "s3cr3t"},
+		{Name: "DB_USER", Value: "admin"},
+	}, vars)
+
+	_, err = readEnvFile(fs, "missing.env")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func TestGetVarsDiff(t *testing.T) {
+	desired := []env.Var{
+		{Name: "A", Value: "1"},
+		{Name: "B", Value: "new"},
+		{Name: "C", Value: "3"},
+	}
+	current := []env.Var{
+		{Name: "B", Value: "old"},
+		{Name: "C", Value: "3"},
+		{Name: "E", Value: "5"},
+		{Name: "D", Value: "4"},
+	}
+
+	diff := getVarsDiff(desired, current)
+	assert.Equal(t, []env.Var{{Name: "A", Value: "1"}}, diff.added)
+	assert.Equal(t, []env.Var{{Name: "B", Value: "new"}}, diff.changed)
+	assert.Equal(t, []string{"D", "E"}, diff.removed)
+	assert.False(t, diff.isEmpty())
+
+	assert.True(t, getVarsDiff(current, current).isEmpty())
+}
+
+func TestExecuteSync(t *testing.T) {
+	variablesClient := client.NewFakeVariablesClient(
+		env.Var{Name: "B", Value: "old"},
+		env.Var{Name: "D", Value: "4"},
+	)
+	vc := &Command{
+		okClient: &client.FakeOktetoClient{
+			VariablesClient: variablesClient,
+		},
+	}
+	desired := []env.Var{
+		{Name: "A", Value: "1"},
+		{Name: "B", Value: "new"},
+	}
+	flags := &syncFlags{
+		Flags: Flags{namespace: "test", scope: types.VariableScopeNamespace},
+		from:  ".env",
+	}
+
+	var buf bytes.Buffer
+	flags.dryRun = true
+	require.NoError(t, vc.executeSync(context.Background(), desired, flags, &buf))
+	assert.Equal(t, "  + A=********\n  ~ B=********\n  - D\n1 to add, 1 to change, 1 to remove\n", buf.String())
+	assert.Equal(t, 0, variablesClient.Calls)
+
+	buf.Reset()
+	flags.dryRun = false
+	require.NoError(t, vc.executeSync(context.Background(), desired, flags, &buf))
+	assert.NotContains(t, buf.String(), "new")
+	assert.ElementsMatch(t, desired, variablesClient.Variables)
+	assert.Equal(t, 2, variablesClient.Calls)
+
+	buf.Reset()
+	require.NoError(t, vc.executeSync(context.Background(), desired, flags, &buf))
+	assert.Empty(t, buf.String())
+	assert.Equal(t, 2, variablesClient.Calls)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vars
+
+import (
+	"context"
+	"fmt"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+const docsURL = "https://www.okteto.com/docs/reference/okteto-cli/#vars"
+
+// Command has the dependencies to run the vars commands
+type Command struct {
+	okClient types.OktetoInterface
+}
+
+// Flags represents the user input for the vars commands
+type Flags struct {
+	namespace string
+	scope     string
+}
+
+// NewCommand creates a vars command
+func NewCommand() (*Command, error) {
+	c, err := okteto.NewOktetoClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Command{
+		okClient: c,
+	}, nil
+}
+
+// Vars manages the Okteto variables
+func Vars(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vars",
+		Short: "Manage your Okteto variables",
+		Long: `Manage your Okteto variables.
+
+Okteto variables are available as environment variables to your deploy, destroy and test commands.`,
+	}
+	cmd.AddCommand(sync(ctx))
+	return cmd
+}
+
+func validateScope(scope string) error {
+	switch scope {
+	case types.VariableScopeNamespace, types.VariableScopePersonal:
+		return nil
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid scope '%s'", scope),
+			Hint: fmt.Sprintf("The scope must be one of: ['%s', '%s']", types.VariableScopeNamespace, types.VariableScopePersonal),
+		}
+	}
+}
+
+// initVarsCommand loads the okteto context and returns the command to manage the variables
+func initVarsCommand(ctx context.Context, flags *Flags) (*Command, error) {
+	ctxResource := &model.ContextResource{}
+	if err := ctxResource.UpdateNamespace(flags.namespace); err != nil {
+		return nil, err
+	}
+
+	ctxOptions := &contextCMD.Options{
+		Namespace: ctxResource.Namespace,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return nil, err
+	}
+
+	if !okteto.IsOkteto() {
+		return nil, oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+
+	if flags.scope == types.VariableScopePersonal {
+		flags.namespace = ""
+	} else if flags.namespace == "" {
+		flags.namespace = okteto.GetContext().Namespace
+	}
+	return NewCommand()
+}
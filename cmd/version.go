@@ -20,6 +20,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/cobra"
 )
 
@@ -47,6 +48,11 @@ func Update() *cobra.Command {
 				return fmt.Errorf("could not retrieve version")
 			}
 
+			if okteto.IsOffline() {
+				oktetoLog.Information("Checking for new releases is disabled in offline installs")
+				return nil
+			}
+
 			if isUpdateAvailable(currentVersion) {
 				displayUpdateSteps()
 			} else {
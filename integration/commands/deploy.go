@@ -14,42 +14,19 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"os"
 	"os/exec"
-	"path/filepath"
 
-	"github.com/okteto/okteto/pkg/constants"
-	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/clitest"
 )
 
 // DeployOptions defines the options that can be added to a deploy command
-type DeployOptions struct {
-	Workdir          string
-	ManifestPath     string
-	LogLevel         string
-	LogOutput        string
-	Namespace        string
-	OktetoHome       string
-	Token            string
-	Name             string
-	Variables        []string
-	ServicesToDeploy []string
-	Build            bool
-	IsRemote         bool
-}
+type DeployOptions = clitest.DeployOptions
 
 // DestroyOptions defines the options that can be added to a deploy command
-type DestroyOptions struct {
-	Workdir      string
-	ManifestPath string
-	Namespace    string
-	OktetoHome   string
-	Token        string
-	Name         string
-	IsRemote     bool
-}
+type DestroyOptions = clitest.DestroyOptions
 
 // GetOktetoDeployCmdOutput runs an okteto deploy command
 func GetOktetoDeployCmdOutput(oktetoPath string, deployOptions *DeployOptions) ([]byte, error) {
@@ -65,32 +42,29 @@ func GetOktetoDeployCmdOutput(oktetoPath string, deployOptions *DeployOptions) (
 
 // RunOktetoDeploy runs an okteto deploy command
 func RunOktetoDeploy(oktetoPath string, deployOptions *DeployOptions) error {
-	output, err := GetOktetoDeployCmdOutput(oktetoPath, deployOptions)
+	_, err := clitest.NewRunner(oktetoPath).Deploy(context.Background(), deployOptions)
 	if err != nil {
-		return fmt.Errorf("okteto deploy failed: %s - %w", string(output), err)
+		return err
 	}
-	return err
+	log.Printf("okteto deploy success")
+	return nil
 }
 
 // RunOktetoDeployAndGetOutput runs an okteto deploy command and returns the output
 func RunOktetoDeployAndGetOutput(oktetoPath string, deployOptions *DeployOptions) (string, error) {
-	cmd := getDeployCmd(oktetoPath, deployOptions)
-	log.Printf("Running '%s'", cmd.String())
-	o, err := cmd.CombinedOutput()
+	o, err := clitest.NewRunner(oktetoPath).Deploy(context.Background(), deployOptions)
 	if err != nil {
-		return string(o), fmt.Errorf("okteto deploy failed: %s - %w", string(o), err)
+		return o, err
 	}
 	log.Printf("okteto deploy success")
-	return string(o), nil
+	return o, nil
 }
 
 // RunOktetoDestroy runs an okteto destroy command
 func RunOktetoDestroy(oktetoPath string, destroyOptions *DestroyOptions) error {
-	log.Printf("okteto destroy %s", oktetoPath)
-	cmd := getDestroyCmd(oktetoPath, destroyOptions)
-	o, err := cmd.CombinedOutput()
+	_, err := clitest.NewRunner(oktetoPath).Destroy(context.Background(), destroyOptions)
 	if err != nil {
-		return fmt.Errorf("okteto deploy failed: %s - %w", string(o), err)
+		return err
 	}
 	log.Printf("okteto destroy success")
 	return nil
@@ -98,105 +72,25 @@ func RunOktetoDestroy(oktetoPath string, destroyOptions *DestroyOptions) error {
 
 // RunOktetoDestroyAndGetOutput runs an okteto destroy command and returns the output
 func RunOktetoDestroyAndGetOutput(oktetoPath string, destroyOptions *DestroyOptions) (string, error) {
-	cmd := getDestroyCmd(oktetoPath, destroyOptions)
-	log.Printf("Running '%s'", cmd.String())
-	o, err := cmd.CombinedOutput()
+	o, err := clitest.NewRunner(oktetoPath).Destroy(context.Background(), destroyOptions)
 	if err != nil {
-		return string(o), fmt.Errorf("okteto deploy failed: %s - %w", string(o), err)
+		return o, err
 	}
-	log.Printf("okteto deploy success")
-	return string(o), nil
+	log.Printf("okteto destroy success")
+	return o, nil
 }
 
 // RunOktetoDestroyRemote runs an okteto destroy command in remote
 func RunOktetoDestroyRemote(oktetoPath string, destroyOptions *DestroyOptions) error {
-	log.Printf("okteto destroy %s", oktetoPath)
-	cmd := getDestroyCmd(oktetoPath, destroyOptions)
-	cmd.Args = append(cmd.Args, "--remote")
-
-	o, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("okteto deploy --remote failed: %s - %w", string(o), err)
+	opts := *destroyOptions
+	opts.IsRemote = true
+	if _, err := clitest.NewRunner(oktetoPath).Destroy(context.Background(), &opts); err != nil {
+		return fmt.Errorf("okteto destroy --remote failed: %w", err)
 	}
 	log.Printf("okteto destroy success")
 	return nil
 }
 
-func getDestroyCmd(oktetoPath string, destroyOptions *DestroyOptions) *exec.Cmd {
-	cmd := exec.Command(oktetoPath, "destroy")
-	if destroyOptions.Workdir != "" {
-		cmd.Dir = destroyOptions.Workdir
-	}
-	if destroyOptions.Name != "" {
-		cmd.Args = append(cmd.Args, "--name", destroyOptions.Name)
-	}
-	if destroyOptions.ManifestPath != "" {
-		cmd.Args = append(cmd.Args, "-f", destroyOptions.ManifestPath)
-	}
-	if destroyOptions.Namespace != "" {
-		cmd.Args = append(cmd.Args, "--namespace", destroyOptions.Namespace)
-	}
-	if destroyOptions.IsRemote {
-		cmd.Args = append(cmd.Args, "--remote")
-	}
-	cmd.Env = os.Environ()
-	if v := os.Getenv(model.OktetoURLEnvVar); v != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoURLEnvVar, v))
-	}
-
-	if destroyOptions.OktetoHome != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", constants.OktetoHomeEnvVar, destroyOptions.OktetoHome))
-	}
-	if destroyOptions.Token != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, destroyOptions.Token))
-	}
-	return cmd
-}
-
 func getDeployCmd(oktetoPath string, deployOptions *DeployOptions) *exec.Cmd {
-	cmd := exec.Command(oktetoPath, "deploy")
-	if deployOptions.Workdir != "" {
-		cmd.Dir = deployOptions.Workdir
-	}
-	if len(deployOptions.ServicesToDeploy) > 0 {
-		cmd.Args = append(cmd.Args, deployOptions.ServicesToDeploy...)
-	}
-	if deployOptions.ManifestPath != "" {
-		cmd.Args = append(cmd.Args, "-f", deployOptions.ManifestPath)
-	}
-	if deployOptions.Build {
-		cmd.Args = append(cmd.Args, "--build")
-	}
-	if deployOptions.LogLevel != "" {
-		cmd.Args = append(cmd.Args, "--log-level", deployOptions.LogLevel)
-	}
-	if deployOptions.Namespace != "" {
-		cmd.Args = append(cmd.Args, "--namespace", deployOptions.Namespace)
-	}
-	if deployOptions.LogOutput != "" {
-		cmd.Args = append(cmd.Args, "--log-output", deployOptions.LogOutput)
-	}
-	if deployOptions.Name != "" {
-		cmd.Args = append(cmd.Args, "--name", deployOptions.Name)
-	}
-	for _, v := range deployOptions.Variables {
-		cmd.Args = append(cmd.Args, "--var", v)
-	}
-	if deployOptions.IsRemote {
-		cmd.Args = append(cmd.Args, "--remote")
-	}
-	cmd.Env = os.Environ()
-	if v := os.Getenv(model.OktetoURLEnvVar); v != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoURLEnvVar, v))
-	}
-
-	if deployOptions.OktetoHome != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", constants.OktetoHomeEnvVar, deployOptions.OktetoHome))
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", constants.KubeConfigEnvVar, filepath.Join(deployOptions.OktetoHome, ".kube", "config")))
-	}
-	if deployOptions.Token != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, deployOptions.Token))
-	}
-
-	return cmd
+	return clitest.NewRunner(oktetoPath).Cmd(context.Background(), deployOptions.Command())
 }
@@ -14,49 +14,30 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"strings"
 
-	"github.com/okteto/okteto/pkg/constants"
-	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/clitest"
 	"github.com/okteto/okteto/pkg/okteto"
 )
 
 // NamespaceOptions defines the options that can be added to a build command
-type NamespaceOptions struct {
-	Namespace  string
-	OktetoHome string
-	Token      string
-}
+type NamespaceOptions = clitest.NamespaceOptions
 
 // RunOktetoCreateNamespace runs okteto namespace create
 func RunOktetoCreateNamespace(oktetoPath string, namespaceOpts *NamespaceOptions) error {
 	okteto.CurrentStore = nil
 	log.Printf("creating namespace %s", namespaceOpts.Namespace)
-	args := []string{"namespace", "create", namespaceOpts.Namespace, "-l", "debug"}
-	cmd := exec.Command(oktetoPath, args...)
-	cmd.Env = os.Environ()
-	if v := os.Getenv(model.OktetoURLEnvVar); v != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoURLEnvVar, v))
-	}
-
-	if namespaceOpts.Token != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, namespaceOpts.Token))
-	}
-	if namespaceOpts.OktetoHome != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", constants.OktetoHomeEnvVar, namespaceOpts.OktetoHome))
-	}
-
-	log.Printf("Running: %s\n", cmd.String())
-	o, err := cmd.CombinedOutput()
+	o, err := clitest.NewRunner(oktetoPath).CreateNamespace(context.Background(), namespaceOpts)
 	if err != nil {
-		return fmt.Errorf("%s %s: %s", oktetoPath, strings.Join(args, " "), string(o))
+		return err
 	}
 
-	log.Printf("create namespace output: \n%s\n", string(o))
+	log.Printf("create namespace output: \n%s\n", o)
 
 	return nil
 }
@@ -65,37 +46,23 @@ func RunOktetoCreateNamespace(oktetoPath string, namespaceOpts *NamespaceOptions
 func RunOktetoNamespace(oktetoPath string, namespaceOpts *NamespaceOptions) error {
 	okteto.CurrentStore = nil
 	log.Printf("changing to namespace %s", namespaceOpts.Namespace)
-	args := []string{"namespace", namespaceOpts.Namespace, "-l", "debug"}
-	cmd := exec.Command(oktetoPath, args...)
-
-	cmd.Env = os.Environ()
-	if v := os.Getenv(model.OktetoURLEnvVar); v != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoURLEnvVar, v))
-	}
-
-	if namespaceOpts.OktetoHome != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", constants.OktetoHomeEnvVar, namespaceOpts.OktetoHome))
-	}
-	if namespaceOpts.Token != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, namespaceOpts.Token))
-	}
-	o, err := cmd.CombinedOutput()
+	o, err := clitest.NewRunner(oktetoPath).UseNamespace(context.Background(), namespaceOpts)
 	if err != nil {
-		return fmt.Errorf("%s %s: %s", oktetoPath, strings.Join(args, " "), string(o))
+		return err
 	}
 
-	log.Printf("namespace output: \n%s\n", string(o))
+	log.Printf("namespace output: \n%s\n", o)
 
 	n := okteto.GetContext().Namespace
 	if namespaceOpts.Namespace != n {
 		return fmt.Errorf("current namespace is %s, expected %s", n, namespaceOpts.Namespace)
 	}
-	args = []string{"kubeconfig"}
-	cmd = exec.Command(oktetoPath, args...)
+	args := []string{"kubeconfig"}
+	cmd := exec.Command(oktetoPath, args...)
 	cmd.Env = os.Environ()
-	o, err = cmd.CombinedOutput()
+	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("%s %s: %s", oktetoPath, strings.Join(args, " "), string(o))
+		return fmt.Errorf("%s %s: %s", oktetoPath, strings.Join(args, " "), string(out))
 	}
 
 	return nil
@@ -104,22 +71,8 @@ func RunOktetoNamespace(oktetoPath string, namespaceOpts *NamespaceOptions) erro
 // RunOktetoDeleteNamespace runs okteto namespace delete
 func RunOktetoDeleteNamespace(oktetoPath string, namespaceOpts *NamespaceOptions) error {
 	log.Printf("okteto delete namespace %s", namespaceOpts.Namespace)
-	deleteCMD := exec.Command(oktetoPath, "namespace", "delete", namespaceOpts.Namespace)
-
-	deleteCMD.Env = os.Environ()
-	if v := os.Getenv(model.OktetoURLEnvVar); v != "" {
-		deleteCMD.Env = append(deleteCMD.Env, fmt.Sprintf("%s=%s", model.OktetoURLEnvVar, v))
-	}
-	if namespaceOpts.Token != "" {
-		deleteCMD.Env = append(deleteCMD.Env, fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, namespaceOpts.Token))
-	}
-
-	if namespaceOpts.OktetoHome != "" {
-		deleteCMD.Env = append(deleteCMD.Env, fmt.Sprintf("%s=%s", constants.OktetoHomeEnvVar, namespaceOpts.OktetoHome))
-	}
-	o, err := deleteCMD.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("okteto delete namespace failed: %s - %w", string(o), err)
+	if _, err := clitest.NewRunner(oktetoPath).DeleteNamespace(context.Background(), namespaceOpts); err != nil {
+		return err
 	}
 	return nil
 }
@@ -14,18 +14,18 @@
 package integration
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/okteto/okteto/pkg/clitest"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	"github.com/okteto/okteto/pkg/model"
@@ -36,18 +36,13 @@ import (
 // set OKTETO_PATH to the bin you want to test otherwise it'll
 // use the one you have in your path
 func GetOktetoPath() (string, error) {
-	oktetoPath, ok := os.LookupEnv(model.OktetoPathEnvVar)
-	if !ok {
-		oktetoPath = "/usr/local/bin/okteto"
+	oktetoPath, err := clitest.FindOktetoPath()
+	if err != nil {
+		return "", err
 	}
 
 	log.Printf("using %s", oktetoPath)
 
-	var err error
-	oktetoPath, err = filepath.Abs(oktetoPath)
-	if err != nil {
-		return "", err
-	}
 	output, err := RunOktetoVersion(oktetoPath)
 	if err != nil {
 		return "", fmt.Errorf("okteto version failed: %s - %w", output, err)
@@ -69,26 +64,16 @@ func GetToken() string {
 
 // RunOktetoVersion runs okteto version given an oktetoPath
 func RunOktetoVersion(oktetoPath string) (string, error) {
-	cmd := exec.Command(oktetoPath, "version")
-	cmd.Env = os.Environ()
-
-	o, err := cmd.CombinedOutput()
+	o, err := clitest.NewRunner(oktetoPath).Version(context.Background())
 	if err != nil {
-		return "", fmt.Errorf("okteto version failed: %s - %w", string(o), err)
+		return "", err
 	}
-	return string(o), nil
+	return o, nil
 }
 
 // GetTestNamespace returns the name for a namespace
 func GetTestNamespace(prefix, user string) string {
-	os := runtime.GOOS
-	if os == "windows" {
-		os = "win"
-	} else {
-		os = os[:3]
-	}
-	namespace := fmt.Sprintf("%s-%s-%d-%s", prefix, os, time.Now().Unix(), user)
-	return strings.ToLower(namespace)
+	return clitest.GetTestNamespace(prefix, user)
 }
 
 // GetCurrentNamespace returns the current namespace of the kubeconfig path
@@ -40,6 +40,8 @@ type FakeOktetoClient struct {
 	PipelineClient  types.PipelineInterface
 	StreamClient    types.StreamInterface
 	KubetokenClient types.KubetokenInterface
+	ShareClient     types.ShareInterface
+	VariablesClient types.VariablesInterface
 }
 
 func NewFakeOktetoClient() *FakeOktetoClient {
@@ -75,3 +77,13 @@ func (c *FakeOktetoClient) Stream() types.StreamInterface {
 func (c *FakeOktetoClient) Kubetoken() types.KubetokenInterface {
 	return c.KubetokenClient
 }
+
+// Share retrieves the share links client
+func (c *FakeOktetoClient) Share() types.ShareInterface {
+	return c.ShareClient
+}
+
+// Variables retrieves the Okteto variables client
+func (c *FakeOktetoClient) Variables() types.VariablesInterface {
+	return c.VariablesClient
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"time"
 
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/types"
 )
 
@@ -31,12 +32,14 @@ type FakePipelineResponses struct {
 	ResourceErr error
 	WaitErr     error
 	DestroyErr  error
+	ScheduleErr error
 
 	DeployResponse  *types.GitDeployResponse
 	DestroyResponse *types.GitDeployResponse
 	ResourcesMap    map[string]string
 	DeployOpts      types.PipelineDeployOptions
 	CallCount       int
+	Schedules       []types.PipelineSchedule
 }
 
 // NewFakePipelineClient creates a pipeline client to use in tests
@@ -77,3 +80,37 @@ func (*FakePipelineClient) GetByName(_ context.Context, _, _ string) (*types.Git
 func (fc *FakePipelineClient) WaitForActionProgressing(_ context.Context, _, _, _ string, _ time.Duration) error {
 	return fc.responses.WaitErr
 }
+
+// SetSchedule registers the schedule of a fake pipeline
+func (fc *FakePipelineClient) SetSchedule(_ context.Context, name, _, schedule string) error {
+	if fc.responses.ScheduleErr != nil {
+		return fc.responses.ScheduleErr
+	}
+	for i := range fc.responses.Schedules {
+		if fc.responses.Schedules[i].Name == name {
+			fc.responses.Schedules[i].Schedule = schedule
+			return nil
+		}
+	}
+	fc.responses.Schedules = append(fc.responses.Schedules, types.PipelineSchedule{Name: name, Schedule: schedule})
+	return nil
+}
+
+// ListSchedules lists the schedules of the fake pipelines
+func (fc *FakePipelineClient) ListSchedules(_ context.Context, _ string) ([]types.PipelineSchedule, error) {
+	return fc.responses.Schedules, fc.responses.ScheduleErr
+}
+
+// RemoveSchedule removes the schedule of a fake pipeline
+func (fc *FakePipelineClient) RemoveSchedule(_ context.Context, name, _ string) error {
+	if fc.responses.ScheduleErr != nil {
+		return fc.responses.ScheduleErr
+	}
+	for i := range fc.responses.Schedules {
+		if fc.responses.Schedules[i].Name == name {
+			fc.responses.Schedules = append(fc.responses.Schedules[:i], fc.responses.Schedules[i+1:]...)
+			return nil
+		}
+	}
+	return oktetoErrors.ErrNotFound
+}
@@ -32,11 +32,13 @@ type FakePreviewResponse struct {
 	ErrSleepPreview   error
 	ErrWakePreview    error
 	ErrGetPreview     error
+	ErrUpdateScope    error
 
 	Preview             *types.PreviewResponse
 	ResourceStatus      map[string]string
 	PreviewList         []types.Preview
 	DestroySuccessCount int
+	UpdatedScope        string
 }
 
 // NewFakePreviewClient returns a new fake preview client
@@ -69,6 +71,15 @@ func (c *FakePreviewsClient) Destroy(_ context.Context, _ string) error {
 	return nil
 }
 
+// UpdateScope changes the scope of a fake preview
+func (c *FakePreviewsClient) UpdateScope(_ context.Context, _, scope string) error {
+	if c.response.ErrUpdateScope != nil {
+		return c.response.ErrUpdateScope
+	}
+	c.response.UpdatedScope = scope
+	return nil
+}
+
 func (*FakePreviewsClient) ListEndpoints(_ context.Context, _ string) ([]types.Endpoint, error) {
 	return nil, nil
 }
@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// FakeShareClient mocks the share links interface
+type FakeShareClient struct {
+	Err   error
+	Links []types.ShareLink
+}
+
+// NewFakeShareClient creates a share links client to use in tests
+func NewFakeShareClient(links ...types.ShareLink) *FakeShareClient {
+	return &FakeShareClient{
+		Links: links,
+	}
+}
+
+// Create creates a fake share link
+func (c *FakeShareClient) Create(_ context.Context, _, endpoint string, expiresIn time.Duration) (*types.ShareLink, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	id := fmt.Sprintf("link-%d", len(c.Links)+1)
+	link := types.ShareLink{
+		ID:        id,
+		Endpoint:  endpoint,
+		URL:       fmt.Sprintf("https://share.okteto.example.com/%s", id),
+		ExpiresAt: time.Now().Add(expiresIn),
+	}
+	c.Links = append(c.Links, link)
+	return &link, nil
+}
+
+// List lists the fake share links
+func (c *FakeShareClient) List(_ context.Context, _ string) ([]types.ShareLink, error) {
+	return c.Links, c.Err
+}
+
+// Revoke removes a fake share link
+func (c *FakeShareClient) Revoke(_ context.Context, _, id string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	for i := range c.Links {
+		if c.Links[i].ID == id {
+			c.Links = append(c.Links[:i], c.Links[i+1:]...)
+			return nil
+		}
+	}
+	return oktetoErrors.ErrNotFound
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/env"
+)
+
+// FakeVariablesClient mocks the Okteto variables interface
+type FakeVariablesClient struct {
+	Err       error
+	Variables []env.Var
+	// Calls counts the calls to Set and Delete
+	Calls int
+}
+
+// NewFakeVariablesClient creates an Okteto variables client to use in tests
+func NewFakeVariablesClient(vars ...env.Var) *FakeVariablesClient {
+	return &FakeVariablesClient{
+		Variables: vars,
+	}
+}
+
+// List lists the fake variables
+func (c *FakeVariablesClient) List(_ context.Context, _, _ string) ([]env.Var, error) {
+	return c.Variables, c.Err
+}
+
+// Set creates or updates fake variables
+func (c *FakeVariablesClient) Set(_ context.Context, _, _ string, vars []env.Var) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	c.Calls++
+	for _, v := range vars {
+		updated := false
+		for i := range c.Variables {
+			if c.Variables[i].Name == v.Name {
+				c.Variables[i].Value = v.Value
+				updated = true
+			}
+		}
+		if !updated {
+			c.Variables = append(c.Variables, v)
+		}
+	}
+	return nil
+}
+
+// Delete removes fake variables
+func (c *FakeVariablesClient) Delete(_ context.Context, _, _ string, names []string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	c.Calls++
+	toDelete := map[string]bool{}
+	for _, name := range names {
+		toDelete[name] = true
+	}
+	result := make([]env.Var, 0, len(c.Variables))
+	for _, v := range c.Variables {
+		if !toDelete[v.Name] {
+			result = append(result, v)
+		}
+	}
+	c.Variables = result
+	return nil
+}
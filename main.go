@@ -17,9 +17,11 @@ import (
 	"context"
 	cryptoRand "crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	osExec "os/exec"
 	"strings"
 	"time"
 	"unicode"
@@ -27,19 +29,25 @@ import (
 	"github.com/okteto/okteto/cmd"
 	"github.com/okteto/okteto/cmd/build"
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/dependencies"
 	"github.com/okteto/okteto/cmd/deploy"
 	"github.com/okteto/okteto/cmd/destroy"
 	"github.com/okteto/okteto/cmd/exec"
+	"github.com/okteto/okteto/cmd/inspect"
 	"github.com/okteto/okteto/cmd/kubetoken"
 	"github.com/okteto/okteto/cmd/logs"
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/pipeline"
+	pluginCMD "github.com/okteto/okteto/cmd/plugin"
 	"github.com/okteto/okteto/cmd/preview"
+	registryCMD "github.com/okteto/okteto/cmd/registry"
 	"github.com/okteto/okteto/cmd/registrytoken"
 	"github.com/okteto/okteto/cmd/remoterun"
+	"github.com/okteto/okteto/cmd/share"
 	"github.com/okteto/okteto/cmd/stack"
 	"github.com/okteto/okteto/cmd/test"
 	"github.com/okteto/okteto/cmd/up"
+	"github.com/okteto/okteto/cmd/vars"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -104,6 +112,9 @@ func main() {
 	var logLevel string
 	var outputMode string
 	var serverNameOverride string
+	var validateAll bool
+	var impersonateUser string
+	var impersonateGroups []string
 
 	if err := analytics.Init(); err != nil {
 		oktetoLog.Infof("error initializing okteto analytics: %s", err)
@@ -120,6 +131,7 @@ func main() {
 		SilenceErrors: true,
 		PersistentPreRun: func(ccmd *cobra.Command, args []string) {
 			ccmd.SilenceUsage = true
+			applyUserSettings(ccmd, &logLevel, &outputMode)
 			if !registrytoken.IsRegistryCredentialHelperCommand(os.Args) {
 				oktetoLog.SetLevel(logLevel)          // TODO: Remove when we fully move to ioController
 				oktetoLog.SetOutputFormat(outputMode) // TODO: Remove when we fully move to ioController
@@ -128,6 +140,8 @@ func main() {
 				ioController.SetOutputFormat(outputMode)
 			}
 			okteto.SetServerNameOverride(serverNameOverride)
+			model.SetValidateAllSections(validateAll)
+			okteto.SetImpersonation(impersonateUser, impersonateGroups)
 			ioController.Logger().Infof("started %s", strings.Join(os.Args, " "))
 
 			if k8sLogger.IsEnabled() {
@@ -145,6 +159,10 @@ func main() {
 	root.PersistentFlags().StringVar(&outputMode, "log-output", oktetoLog.TTYFormat, "output format for logs (tty, plain, json)")
 
 	root.PersistentFlags().StringVarP(&serverNameOverride, "server-name", "", "", "The address and port of the Okteto Ingress server")
+	root.PersistentFlags().StringVar(&impersonateUser, "as", "", "user to impersonate in the requests to the Kubernetes API")
+	root.PersistentFlags().StringArrayVar(&impersonateGroups, "as-group", nil, "group to impersonate in the requests to the Kubernetes API (can be set more than once)")
+	root.PersistentFlags().BoolVar(&validateAll, "validate-all", false, "validate all the sections of the okteto manifest, not only the ones used by the command")
+
 	err := root.PersistentFlags().MarkHidden("server-name")
 	if err != nil {
 		ioController.Logger().Infof("error hiding server-name flag: %s", err)
@@ -158,6 +176,7 @@ func main() {
 	fs := afero.NewOsFs()
 
 	root.AddCommand(cmd.Analytics())
+	root.AddCommand(cmd.Config())
 	root.AddCommand(cmd.Version())
 	root.AddCommand(cmd.Login())
 
@@ -172,8 +191,10 @@ func main() {
 	root.AddCommand(namespace.Namespace(ctx, k8sLogger))
 	root.AddCommand(cmd.Init(at, insights, ioController))
 	root.AddCommand(up.Up(at, insights, ioController, k8sLogger))
+	root.AddCommand(up.Sync(ctx))
 	root.AddCommand(cmd.Down(at, k8sLogger))
 	root.AddCommand(cmd.Status())
+	root.AddCommand(cmd.Prompt())
 	root.AddCommand(cmd.Doctor(k8sLogger))
 	root.AddCommand(exec.NewExec(fs, ioController, k8sClientProvider).Cmd(ctx))
 	root.AddCommand(preview.Preview(ctx))
@@ -182,6 +203,7 @@ func main() {
 	root.AddCommand(deploy.Deploy(ctx, at, insights, ioController, k8sLogger))
 	root.AddCommand(destroy.Destroy(ctx, at, insights, ioController, k8sLogger))
 	root.AddCommand(deploy.Endpoints(ctx, k8sLogger))
+	root.AddCommand(deploy.Seed(ctx, k8sLogger))
 	root.AddCommand(logs.Logs(ctx, k8sLogger))
 	root.AddCommand(generateFigSpec.NewCmdGenFigSpec())
 	root.AddCommand(remoterun.RemoteRun(ctx, k8sLogger))
@@ -193,11 +215,25 @@ func main() {
 	root.AddCommand(cmd.Delete(ctx))
 	root.AddCommand(stack.Stack(ctx, at, insights, ioController))
 	root.AddCommand(cmd.Push(ctx, at))
+	root.AddCommand(registryCMD.Registry(ctx))
 	root.AddCommand(pipeline.Pipeline(ctx))
+	root.AddCommand(dependencies.Dependencies(ctx))
+	root.AddCommand(share.Share(ctx))
+	root.AddCommand(vars.Vars(ctx))
+	root.AddCommand(inspect.Inspect(ctx))
+	root.AddCommand(pluginCMD.Plugin())
 
-	err = root.Execute()
+	handled, err := pluginCMD.Dispatch(root, os.Args[1:])
+	if !handled {
+		err = root.Execute()
+	}
 
 	if err != nil {
+		var exitErr *osExec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+
 		message := err.Error()
 		if len(message) > 0 {
 			tmp := []rune(message)
@@ -214,6 +250,33 @@ func main() {
 	}
 }
 
+// applyUserSettings sets the values of $OKTETO_HOME/config.yaml for the flags and environment variables not set by the user
+func applyUserSettings(ccmd *cobra.Command, logLevel, outputMode *string) {
+	settings, err := config.LoadSettings(afero.NewOsFs(), config.GetSettingsPath())
+	if err != nil {
+		oktetoLog.Warning("Ignoring your okteto configuration: %s", err)
+		return
+	}
+	octx := okteto.GetContextStore().CurrentContext
+
+	if !ccmd.Flags().Changed("log-level") {
+		if v, err := settings.Get(octx, config.LogLevelSetting); err == nil {
+			*logLevel = v.Value
+		}
+	}
+	if !ccmd.Flags().Changed("log-output") {
+		if v, err := settings.Get(octx, config.LogOutputSetting); err == nil {
+			*outputMode = v.Value
+		}
+	}
+	if v, err := settings.Get(octx, config.TimeoutSetting); err == nil && v.Source != config.SourceEnv && v.Value != "" {
+		// commands read the timeout from OKTETO_TIMEOUT, which is also propagated to the commands run by okteto
+		if err := os.Setenv(model.OktetoTimeoutEnvVar, v.Value); err != nil {
+			oktetoLog.Infof("failed to set %s: %s", model.OktetoTimeoutEnvVar, err)
+		}
+	}
+}
+
 func getCurrentCmdWithUsedFlags(cmd *cobra.Command) (string, string) {
 	var flags []string
 	cmd.Flags().Visit(func(f *pflag.Flag) {
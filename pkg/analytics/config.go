@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/denisbrodbeck/machineid"
 	"github.com/okteto/okteto/pkg/config"
@@ -58,6 +59,11 @@ func Init() error {
 		a.Enabled = false
 	}
 
+	// air-gapped installs never send telemetry, not even on first boot
+	if okteto.IsOffline() {
+		a.Enabled = false
+	}
+
 	return a.save()
 }
 
@@ -149,6 +155,22 @@ func Enable() error {
 	return a.save()
 }
 
+// isEnabled returns if analytics are enabled. Offline installs never send telemetry, and otherwise the telemetry setting
+// of the user configuration takes precedence over the analytics file
+func isEnabled() bool {
+	if okteto.IsOffline() {
+		return false
+	}
+	if v := config.GetSetting(okteto.GetContextStore().CurrentContext, config.TelemetrySetting); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err == nil {
+			return enabled
+		}
+		oktetoLog.Infof("ignoring invalid telemetry setting '%s'", v)
+	}
+	return get().Enabled
+}
+
 func getTrackID() string {
 	if okteto.GetContext().UserID != "" {
 		return okteto.GetContext().UserID
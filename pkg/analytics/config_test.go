@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/okteto"
 )
 
 func Test_Get(t *testing.T) {
@@ -85,3 +86,27 @@ func Test_Get(t *testing.T) {
 	}
 
 }
+
+func Test_isEnabledOffline(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	previousStore := okteto.CurrentStore
+	previousAnalytics := currentAnalytics
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts:       map[string]*okteto.Context{"test": {Name: "test"}},
+		CurrentContext: "test",
+	}
+	defer func() {
+		okteto.CurrentStore = previousStore
+		currentAnalytics = previousAnalytics
+	}()
+	currentAnalytics = &Analytics{Enabled: true}
+
+	if !isEnabled() {
+		t.Fatalf("analytics should be enabled")
+	}
+
+	t.Setenv(constants.OktetoOfflineEnvVar, "true")
+	if isEnabled() {
+		t.Fatalf("analytics should be disabled in offline installs")
+	}
+}
@@ -53,6 +53,7 @@ const (
 	namespaceEvent           = "Namespace"
 	namespaceCreateEvent     = "CreateNamespace"
 	namespaceDeleteEvent     = "DeleteNamespace"
+	namespaceCloneEvent      = "CloneNamespace"
 	previewDeployEvent       = "DeployPreview"
 	previewDestroyEvent      = "DestroyPreview"
 	execEvent                = "Exec"
@@ -114,6 +115,11 @@ func TrackDeleteNamespace(success bool) {
 	track(namespaceDeleteEvent, success, nil)
 }
 
+// TrackCloneNamespace sends a tracking event to mixpanel when the user clones a namespace
+func TrackCloneNamespace(success bool, volumes bool) {
+	track(namespaceCloneEvent, success, map[string]interface{}{"volumes": volumes})
+}
+
 // TrackPreviewDeploy sends a tracking event to mixpanel when the creates a preview environment
 func TrackPreviewDeploy(success bool, scope string) {
 	props := map[string]interface{}{
@@ -274,7 +280,7 @@ func TrackContextDelete(ctxs int, success bool) {
 }
 
 func track(event string, success bool, props map[string]interface{}) {
-	if !get().Enabled {
+	if !isEnabled() {
 		oktetoLog.Info("failed to send analytics: analytics has been disabled")
 		return
 	}
@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockercredentials
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker-credential-helpers/client"
+	"github.com/docker/docker-credential-helpers/credentials"
+)
+
+// IdentityTokenUsername is the username returned by the credential helpers when the secret is an identity token
+const IdentityTokenUsername = "<token>"
+
+// cloudRegistry is a registry of a cloud provider that issues short-lived tokens through docker credential helpers
+type cloudRegistry struct {
+	host *regexp.Regexp
+	// helpers are the suffixes of the docker-credential-<suffix> helpers of the registry, in order of preference
+	helpers []string
+}
+
+var cloudRegistries = []cloudRegistry{
+	{
+		// Amazon ECR
+		host:    regexp.MustCompile(`^[0-9]{12}\.dkr\.ecr(-fips)?\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`),
+		helpers: []string{"ecr-login"},
+	},
+	{
+		// Google Container Registry and Artifact Registry
+		host:    regexp.MustCompile(`^([a-z0-9-]+\.)?gcr\.io$|^[a-z0-9-]+-docker\.pkg\.dev$`),
+		helpers: []string{"gcloud", "gcr"},
+	},
+	{
+		// Azure Container Registry
+		host:    regexp.MustCompile(`^[a-z0-9]+\.azurecr\.io$`),
+		helpers: []string{"acr-env"},
+	},
+}
+
+var (
+	lookPath = exec.LookPath
+
+	getFromHelper = func(helper, host string) (*credentials.Credentials, error) {
+		return client.Get(client.NewShellProgramFunc(helper), host)
+	}
+)
+
+// GetCloudHelper returns the docker credential helper installed for the registry of a cloud provider.
+// It returns an empty string for other registries or when none of their helpers is installed
+func GetCloudHelper(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://"), "/"))
+	for _, r := range cloudRegistries {
+		if !r.host.MatchString(host) {
+			continue
+		}
+		for _, suffix := range r.helpers {
+			helper := fmt.Sprintf("docker-credential-%s", suffix)
+			if _, err := lookPath(helper); err == nil {
+				return helper
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// GetCloudCredentials returns the credentials of the registry of a cloud provider from its docker credential helper.
+// The username is IdentityTokenUsername when the secret is an identity token
+func GetCloudCredentials(host string) (string, string, error) {
+	helper := GetCloudHelper(host)
+	if helper == "" {
+		return "", "", credentials.NewErrCredentialsNotFound()
+	}
+	creds, err := getFromHelper(helper, host)
+	if err != nil {
+		return "", "", fmt.Errorf("error getting credentials of '%s' from %s: %w", host, helper, err)
+	}
+	return creds.Username, creds.Secret, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockercredentials
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/docker/docker-credential-helpers/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeLookPath(installed ...string) func(string) (string, error) {
+	return func(file string) (string, error) {
+		for _, helper := range installed {
+			if helper == file {
+				return fmt.Sprintf("/usr/local/bin/%s", file), nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+}
+
+func TestGetCloudHelper(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		expected  string
+		installed []string
+	}{
+		{
+			name:      "ecr",
+			host:      "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+			installed: []string{"docker-credential-ecr-login"},
+			expected:  "docker-credential-ecr-login",
+		},
+		{
+			name:      "gcr with gcloud",
+			host:      "eu.gcr.io",
+			installed: []string{"docker-credential-gcloud", "docker-credential-gcr"},
+			expected:  "docker-credential-gcloud",
+		},
+		{
+			name:      "artifact registry with the standalone helper",
+			host:      "https://us-central1-docker.pkg.dev",
+			installed: []string{"docker-credential-gcr"},
+			expected:  "docker-credential-gcr",
+		},
+		{
+			name:      "acr",
+			host:      "myregistry.azurecr.io",
+			installed: []string{"docker-credential-acr-env"},
+			expected:  "docker-credential-acr-env",
+		},
+		{
+			name:     "helper not installed",
+			host:     "myregistry.azurecr.io",
+			expected: "",
+		},
+		{
+			name:      "not a cloud registry",
+			host:      "registry-1.docker.io",
+			installed: []string{"docker-credential-ecr-login", "docker-credential-gcloud", "docker-credential-acr-env"},
+			expected:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := lookPath
+			defer func() { lookPath = original }()
+			lookPath = fakeLookPath(tt.installed...)
+
+			assert.Equal(t, tt.expected, GetCloudHelper(tt.host))
+		})
+	}
+}
+
+func TestGetCloudCredentials(t *testing.T) {
+	originalLookPath, originalGet := lookPath, getFromHelper
+	defer func() {
+		lookPath, getFromHelper = originalLookPath, originalGet
+	}()
+	lookPath = fakeLookPath("docker-credential-ecr-login")
+	getFromHelper = func(helper, host string) (*credentials.Credentials, error) {
+		if helper != "docker-credential-ecr-login" {
+			return nil, assert.AnError
+		}
+		return &credentials.Credentials{ServerURL: host, Username: "AWS", Secret: "token"}, nil
+	}
+
+	username, secret, err := GetCloudCredentials("123456789012.dkr.ecr.eu-west-1.amazonaws.com")
+	require.NoError(t, err)
+	assert.Equal(t, "AWS", username)
+	assert.Equal(t, "token", secret)
+
+	_, _, err = GetCloudCredentials("eu.gcr.io")
+	assert.True(t, credentials.IsErrCredentialsNotFound(err))
+}
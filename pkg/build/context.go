@@ -0,0 +1,52 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"net/url"
+	"strings"
+)
+
+// IsRemoteContext returns true if the build context is fetched by the builder instead of being sent from
+// the local filesystem: a git repository or the url of a tarball
+func IsRemoteContext(buildContext string) bool {
+	if IsGitContext(buildContext) {
+		return true
+	}
+	uri, err := url.ParseRequestURI(buildContext)
+	return err == nil && uri.Scheme != "" && uri.Host != ""
+}
+
+// IsGitContext returns true if the build context is a git repository. The url can include a ref and a
+// subdirectory of the repository after a '#', like 'https://github.com/org/repo.git#main:service'
+func IsGitContext(buildContext string) bool {
+	for _, prefix := range []string{"git@", "git://", "github.com/"} {
+		if strings.HasPrefix(buildContext, prefix) {
+			return true
+		}
+	}
+
+	uri, err := url.Parse(buildContext)
+	if err != nil || uri.Host == "" {
+		return false
+	}
+	switch uri.Scheme {
+	case "ssh":
+		return true
+	case "http", "https":
+		return strings.HasSuffix(uri.Path, ".git")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRemoteContext(t *testing.T) {
+	tests := []struct {
+		context  string
+		isRemote bool
+		isGit    bool
+	}{
+		{context: ".", isRemote: false, isGit: false},
+		{context: "api", isRemote: false, isGit: false},
+		{context: "/home/okteto/api", isRemote: false, isGit: false},
+		{context: "https://github.com/okteto/movies.git", isRemote: true, isGit: true},
+		{context: "https://github.com/okteto/movies.git#main:api", isRemote: true, isGit: true},
+		{context: "git@github.com:okteto/movies.git#main", isRemote: true, isGit: true},
+		{context: "git://github.com/okteto/movies.git", isRemote: true, isGit: true},
+		{context: "ssh://git@github.com/okteto/movies.git", isRemote: true, isGit: true},
+		{context: "github.com/okteto/movies", isRemote: true, isGit: true},
+		{context: "https://example.com/context.tar.gz", isRemote: true, isGit: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.context, func(t *testing.T) {
+			assert.Equal(t, tt.isRemote, IsRemoteContext(tt.context))
+			assert.Equal(t, tt.isGit, IsGitContext(tt.context))
+		})
+	}
+}
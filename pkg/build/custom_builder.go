@@ -0,0 +1,104 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+)
+
+// CustomBuilderType is the tool that builds the image of a project without a Dockerfile
+type CustomBuilderType string
+
+const (
+	// BuildpacksBuilder builds the image with Cloud Native Buildpacks
+	BuildpacksBuilder CustomBuilderType = "buildpacks"
+
+	// KoBuilder builds the image of a Go application with ko
+	KoBuilder CustomBuilderType = "ko"
+
+	// NixpacksBuilder generates the Dockerfile of the image with nixpacks
+	NixpacksBuilder CustomBuilderType = "nixpacks"
+
+	// DefaultBuildpacksBuilderImage is the builder image of Cloud Native Buildpacks used when 'image' is not set
+	DefaultBuildpacksBuilderImage = "paketobuildpacks/builder-jammy-base"
+)
+
+// CustomBuilder defines how to build the image of a project without a Dockerfile
+type CustomBuilder struct {
+	Type CustomBuilderType `yaml:"type,omitempty"`
+	// Image is the builder image of Cloud Native Buildpacks
+	Image string `yaml:"image,omitempty"`
+	// Path is the path of the main package built by ko, relative to the build context
+	Path string `yaml:"path,omitempty"`
+}
+
+type customBuilderRaw CustomBuilder
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (b *CustomBuilder) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var rawType string
+	if err := unmarshal(&rawType); err == nil {
+		b.Type = CustomBuilderType(rawType)
+		return nil
+	}
+
+	var raw customBuilderRaw
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*b = CustomBuilder(raw)
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (b *CustomBuilder) MarshalYAML() (interface{}, error) {
+	if b.Image == "" && b.Path == "" {
+		return string(b.Type), nil
+	}
+	return customBuilderRaw(*b), nil
+}
+
+// validate checks the custom builder of a service
+func (b *CustomBuilder) validate() error {
+	switch b.Type {
+	case BuildpacksBuilder, KoBuilder, NixpacksBuilder:
+	case "":
+		return fmt.Errorf("'builder.type' is required")
+	default:
+		return fmt.Errorf("'builder.type' must be one of '%s', '%s' or '%s'", BuildpacksBuilder, KoBuilder, NixpacksBuilder)
+	}
+	if b.Image != "" && b.Type != BuildpacksBuilder {
+		return fmt.Errorf("'builder.image' is only supported by the '%s' builder", BuildpacksBuilder)
+	}
+	if b.Path != "" && b.Type != KoBuilder {
+		return fmt.Errorf("'builder.path' is only supported by the '%s' builder", KoBuilder)
+	}
+	return nil
+}
+
+// GetImage returns the builder image of Cloud Native Buildpacks
+func (b *CustomBuilder) GetImage() string {
+	if b.Image == "" {
+		return DefaultBuildpacksBuilderImage
+	}
+	return b.Image
+}
+
+// GetPath returns the path of the main package built by ko
+func (b *CustomBuilder) GetPath() string {
+	if b.Path == "" {
+		return "."
+	}
+	return b.Path
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestCustomBuilderUnmarshal(t *testing.T) {
+	tests := []struct {
+		expected CustomBuilder
+		name     string
+		input    string
+	}{
+		{
+			name:     "shorthand",
+			input:    "builder: nixpacks",
+			expected: CustomBuilder{Type: NixpacksBuilder},
+		},
+		{
+			name:     "buildpacks with builder image",
+			input:    "builder:\n  type: buildpacks\n  image: heroku/builder:24",
+			expected: CustomBuilder{Type: BuildpacksBuilder, Image: "heroku/builder:24"},
+		},
+		{
+			name:     "ko with path",
+			input:    "builder:\n  type: ko\n  path: ./cmd/api",
+			expected: CustomBuilder{Type: KoBuilder, Path: "./cmd/api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info Info
+			require.NoError(t, yaml.Unmarshal([]byte(tt.input), &info))
+			require.NotNil(t, info.CustomBuilder)
+			assert.Equal(t, tt.expected, *info.CustomBuilder)
+			// services with a custom builder don't default the Dockerfile
+			assert.Empty(t, info.Dockerfile)
+
+			out, err := yaml.Marshal(&info)
+			require.NoError(t, err)
+			var result Info
+			require.NoError(t, yaml.Unmarshal(out, &result))
+			assert.Equal(t, tt.expected, *result.CustomBuilder)
+		})
+	}
+}
+
+func TestCustomBuilderValidate(t *testing.T) {
+	tests := []struct {
+		builder   CustomBuilder
+		name      string
+		expectErr bool
+	}{
+		{name: "buildpacks", builder: CustomBuilder{Type: BuildpacksBuilder, Image: "heroku/builder:24"}},
+		{name: "ko", builder: CustomBuilder{Type: KoBuilder, Path: "./cmd/api"}},
+		{name: "nixpacks", builder: CustomBuilder{Type: NixpacksBuilder}},
+		{name: "missing type", builder: CustomBuilder{}, expectErr: true},
+		{name: "unknown type", builder: CustomBuilder{Type: "kaniko"}, expectErr: true},
+		{name: "image with ko", builder: CustomBuilder{Type: KoBuilder, Image: "heroku/builder:24"}, expectErr: true},
+		{name: "path with buildpacks", builder: CustomBuilder{Type: BuildpacksBuilder, Path: "./cmd"}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.builder.validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCustomBuilderDefaults(t *testing.T) {
+	b := CustomBuilder{Type: KoBuilder}
+	assert.Equal(t, DefaultBuildpacksBuilderImage, b.GetImage())
+	assert.Equal(t, ".", b.GetPath())
+}
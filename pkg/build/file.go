@@ -61,3 +61,25 @@ func CreateDockerfileWithVolumeMounts(context, image string, volumes []VolumeMou
 	build.VolumesToInclude = volumes
 	return build, nil
 }
+
+// CreateDockerfileFromContent writes the content of a Dockerfile that is not in the filesystem, like
+// 'dockerfile_inline' or a Dockerfile read from the standard input, and returns its path
+func CreateDockerfileFromContent(content string, fs afero.Fs) (string, error) {
+	dockerfileTmpFolder := filepath.Join(config.GetOktetoHomeWithFilesystem(fs), ".dockerfile")
+	filePerm := os.FileMode(0700)
+	if err := fs.MkdirAll(dockerfileTmpFolder, filePerm); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dockerfileTmpFolder, err)
+	}
+
+	tmpFile, err := afero.TempFile(fs, dockerfileTmpFolder, "inline-")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		return "", fmt.Errorf("failed to write dockerfile: %w", err)
+	}
+
+	return filepath.Abs(tmpFile.Name())
+}
@@ -60,3 +60,22 @@ func TestCreateDockerfileWithVolumeMounts(t *testing.T) {
 	expected := "FROM nginx:latest\nCOPY /local/path /remote/path\nCOPY ./nginx/nginx.conf /etc/nginx/nginx.conf\n"
 	require.Equal(t, expected, string(dockerfileContent))
 }
+
+func TestCreateDockerfileFromContent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	oktetoHome, err := filepath.Abs("./tmp/tests")
+	require.NoError(t, err)
+	err = fs.MkdirAll(oktetoHome, 0700)
+	require.NoError(t, err)
+	t.Setenv(constants.OktetoFolderEnvVar, oktetoHome)
+
+	path, err := CreateDockerfileFromContent("FROM alpine\nRUN echo $VALUE\n", fs)
+	require.NoError(t, err)
+	require.True(t, filepath.IsAbs(path))
+	require.Equal(t, filepath.Join(oktetoHome, ".dockerfile"), filepath.Dir(path))
+
+	dockerfileContent, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	require.Equal(t, "FROM alpine\nRUN echo $VALUE\n", string(dockerfileContent))
+}
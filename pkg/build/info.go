@@ -28,10 +28,12 @@ import (
 
 // Info represents the build info to generate an image
 type Info struct {
-	Secrets          Secrets           `yaml:"secrets,omitempty"`
-	Name             string            `yaml:"name,omitempty"`
-	Context          string            `yaml:"context,omitempty"`
-	Dockerfile       string            `yaml:"dockerfile,omitempty"`
+	Secrets    Secrets `yaml:"secrets,omitempty"`
+	Name       string  `yaml:"name,omitempty"`
+	Context    string  `yaml:"context,omitempty"`
+	Dockerfile string  `yaml:"dockerfile,omitempty"`
+	// DockerfileInline is the content of the Dockerfile, used instead of a Dockerfile in the filesystem
+	DockerfileInline string            `yaml:"dockerfile_inline,omitempty"`
 	Target           string            `yaml:"target,omitempty"`
 	Image            string            `yaml:"image,omitempty"`
 	CacheFrom        cache.From        `yaml:"cache_from,omitempty"`
@@ -39,6 +41,22 @@ type Info struct {
 	VolumesToInclude []VolumeMounts    `yaml:"-"`
 	ExportCache      cache.ExportCache `yaml:"export_cache,omitempty"`
 	DependsOn        DependsOn         `yaml:"depends_on,omitempty"`
+	SSH              []string          `yaml:"ssh,omitempty"`
+	Reproducible     bool              `yaml:"reproducible,omitempty"`
+	// TestTarget is a stage of the Dockerfile built before the image to run its tests
+	TestTarget string `yaml:"test_target,omitempty"`
+	// TestResults is the folder of the test stage exported to the local results folder
+	TestResults string `yaml:"test_results,omitempty"`
+	// Platforms are the platforms of the image. More than one builds a multi-arch image
+	Platforms []string `yaml:"platforms,omitempty"`
+	// Sign signs the image with cosign once it is pushed
+	Sign bool `yaml:"sign,omitempty"`
+	// SignKey is the cosign key used to sign the image. Empty means keyless signing using OIDC
+	SignKey string `yaml:"sign_key,omitempty"`
+	// Ignore are the rules that exclude files from the build context in addition to its .dockerignore file
+	Ignore []string `yaml:"ignore,omitempty"`
+	// CustomBuilder builds the image without a Dockerfile
+	CustomBuilder *CustomBuilder `yaml:"builder,omitempty"`
 }
 
 // Secrets represents the secrets to be injected to the build of the image
@@ -46,10 +64,12 @@ type Secrets map[string]string
 
 // infoRaw represents the build info for serialization
 type infoRaw struct {
-	Secrets          Secrets           `yaml:"secrets,omitempty"`
-	Name             string            `yaml:"name,omitempty"`
-	Context          string            `yaml:"context,omitempty"`
-	Dockerfile       string            `yaml:"dockerfile,omitempty"`
+	Secrets    Secrets `yaml:"secrets,omitempty"`
+	Name       string  `yaml:"name,omitempty"`
+	Context    string  `yaml:"context,omitempty"`
+	Dockerfile string  `yaml:"dockerfile,omitempty"`
+	// DockerfileInline is the content of the Dockerfile, used instead of a Dockerfile in the filesystem
+	DockerfileInline string            `yaml:"dockerfile_inline,omitempty"`
 	Target           string            `yaml:"target,omitempty"`
 	Image            string            `yaml:"image,omitempty"`
 	CacheFrom        cache.From        `yaml:"cache_from,omitempty"`
@@ -57,6 +77,22 @@ type infoRaw struct {
 	VolumesToInclude []VolumeMounts    `yaml:"-"`
 	ExportCache      cache.ExportCache `yaml:"export_cache,omitempty"`
 	DependsOn        DependsOn         `yaml:"depends_on,omitempty"`
+	SSH              []string          `yaml:"ssh,omitempty"`
+	Reproducible     bool              `yaml:"reproducible,omitempty"`
+	// TestTarget is a stage of the Dockerfile built before the image to run its tests
+	TestTarget string `yaml:"test_target,omitempty"`
+	// TestResults is the folder of the test stage exported to the local results folder
+	TestResults string `yaml:"test_results,omitempty"`
+	// Platforms are the platforms of the image. More than one builds a multi-arch image
+	Platforms []string `yaml:"platforms,omitempty"`
+	// Sign signs the image with cosign once it is pushed
+	Sign bool `yaml:"sign,omitempty"`
+	// SignKey is the cosign key used to sign the image. Empty means keyless signing using OIDC
+	SignKey string `yaml:"sign_key,omitempty"`
+	// Ignore are the rules that exclude files from the build context in addition to its .dockerignore file
+	Ignore []string `yaml:"ignore,omitempty"`
+	// CustomBuilder builds the image without a Dockerfile
+	CustomBuilder *CustomBuilder `yaml:"builder,omitempty"`
 }
 
 func (i *Info) addExpandedPreviousImageArgs(previousImageArgs map[string]string) error {
@@ -138,6 +174,7 @@ func (i *Info) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err != nil {
 		return err
 	}
+	i.DockerfileInline = rawBuildInfo.DockerfileInline
 	i.Target = rawBuildInfo.Target
 	i.Args = rawBuildInfo.Args
 	i.Image = rawBuildInfo.Image
@@ -145,6 +182,18 @@ func (i *Info) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	i.ExportCache = rawBuildInfo.ExportCache
 	i.DependsOn = rawBuildInfo.DependsOn
 	i.Secrets = rawBuildInfo.Secrets
+	i.SSH = rawBuildInfo.SSH
+	i.Reproducible = rawBuildInfo.Reproducible
+	i.TestTarget = rawBuildInfo.TestTarget
+	i.TestResults = rawBuildInfo.TestResults
+	i.Platforms = rawBuildInfo.Platforms
+	i.Sign = rawBuildInfo.Sign
+	i.Ignore = rawBuildInfo.Ignore
+	i.CustomBuilder = rawBuildInfo.CustomBuilder
+	i.SignKey, err = env.ExpandEnvIfNotEmpty(rawBuildInfo.SignKey)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -156,24 +205,51 @@ func (i *Info) MarshalYAML() (interface{}, error) {
 	if i.Dockerfile != "" && i.Dockerfile != "./Dockerfile" {
 		return infoRaw(*i), nil
 	}
+	if i.DockerfileInline != "" {
+		return infoRaw(*i), nil
+	}
 	if i.Target != "" {
 		return infoRaw(*i), nil
 	}
 	if i.Args != nil && len(i.Args) != 0 {
 		return infoRaw(*i), nil
 	}
+	if i.Reproducible {
+		return infoRaw(*i), nil
+	}
+	if i.TestTarget != "" {
+		return infoRaw(*i), nil
+	}
+	if len(i.Platforms) != 0 {
+		return infoRaw(*i), nil
+	}
+	if i.Sign {
+		return infoRaw(*i), nil
+	}
+	if len(i.Ignore) != 0 {
+		return infoRaw(*i), nil
+	}
+	if i.CustomBuilder != nil {
+		return infoRaw(*i), nil
+	}
 	return i.Name, nil
 }
 
 // Copy clones the buildInfo without the pointers
 func (i *Info) Copy() *Info {
 	result := &Info{
-		Name:        i.Name,
-		Context:     i.Context,
-		Dockerfile:  i.Dockerfile,
-		Target:      i.Target,
-		Image:       i.Image,
-		ExportCache: i.ExportCache,
+		Name:             i.Name,
+		Context:          i.Context,
+		Dockerfile:       i.Dockerfile,
+		DockerfileInline: i.DockerfileInline,
+		Target:           i.Target,
+		Image:            i.Image,
+		ExportCache:      i.ExportCache,
+		Reproducible:     i.Reproducible,
+		TestTarget:       i.TestTarget,
+		TestResults:      i.TestResults,
+		Sign:             i.Sign,
+		SignKey:          i.SignKey,
 	}
 
 	// copy to new pointers
@@ -185,6 +261,14 @@ func (i *Info) Copy() *Info {
 	args = append(args, i.Args...)
 	result.Args = args
 
+	if i.Platforms != nil {
+		result.Platforms = append([]string{}, i.Platforms...)
+	}
+
+	if i.Ignore != nil {
+		result.Ignore = append([]string{}, i.Ignore...)
+	}
+
 	secrets := Secrets{}
 	for k, v := range i.Secrets {
 		secrets[k] = v
@@ -199,6 +283,15 @@ func (i *Info) Copy() *Info {
 	dependsOn = append(dependsOn, i.DependsOn...)
 	result.DependsOn = dependsOn
 
+	if i.SSH != nil {
+		result.SSH = append([]string{}, i.SSH...)
+	}
+
+	if i.CustomBuilder != nil {
+		customBuilder := *i.CustomBuilder
+		result.CustomBuilder = &customBuilder
+	}
+
 	return result
 }
 
@@ -207,7 +300,7 @@ func (i *Info) SetBuildDefaults() {
 		i.Context = "."
 	}
 
-	if _, err := url.ParseRequestURI(i.Context); err != nil && i.Dockerfile == "" {
+	if _, err := url.ParseRequestURI(i.Context); err != nil && i.Dockerfile == "" && i.DockerfileInline == "" && i.CustomBuilder == nil {
 		i.Dockerfile = "Dockerfile"
 	}
 
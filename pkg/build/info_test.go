@@ -263,7 +263,12 @@ func Test_BuildInfoCopy(t *testing.T) {
 				RemotePath: "remote",
 			},
 		},
-		DependsOn: DependsOn{"other"},
+		DependsOn:    DependsOn{"other"},
+		Reproducible: true,
+		TestTarget:   "test",
+		TestResults:  "/reports",
+		Platforms:    []string{"linux/amd64", "linux/arm64"},
+		Ignore:       []string{"services/worker"},
 	}
 
 	copyB := b.Copy()
@@ -283,6 +288,12 @@ func TestSetBuildDefaults(t *testing.T) {
 	}
 	info.SetBuildDefaults()
 	require.Equal(t, info, expected)
+
+	info = &Info{
+		DockerfileInline: "FROM alpine",
+	}
+	info.SetBuildDefaults()
+	require.Equal(t, &Info{Context: ".", DockerfileInline: "FROM alpine"}, info)
 }
 
 func TestUnmarshalInfo(t *testing.T) {
@@ -373,6 +384,31 @@ secrets:
 				},
 			},
 		},
+		{
+			name: "unmarshal dockerfile inline",
+			input: `
+context: testContext
+dockerfile_inline: |
+  FROM alpine
+  ARG VALUE
+  RUN echo $VALUE`,
+			expected: &Info{
+				Context:          "testContext",
+				DockerfileInline: "FROM alpine\nARG VALUE\nRUN echo $VALUE",
+			},
+		},
+		{
+			name: "unmarshal ignore",
+			input: `
+context: .
+ignore:
+  - services/worker
+  - "**/node_modules"`,
+			expected: &Info{
+				Context: ".",
+				Ignore:  []string{"services/worker", "**/node_modules"},
+			},
+		},
 		{
 			name:        "error unmarshal string nor struct",
 			input:       "- an string value as list",
@@ -421,6 +457,13 @@ func TestMarshalInfo(t *testing.T) {
 				Dockerfile: "an string value",
 			},
 		},
+		{
+			name:     "unmarshal info with dockerfile inline",
+			expected: "dockerfile_inline: FROM alpine\n",
+			input: &Info{
+				DockerfileInline: "FROM alpine",
+			},
+		},
 		{
 			name:     "unmarshal info with target",
 			expected: "target: an string value\n",
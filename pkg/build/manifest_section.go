@@ -15,6 +15,7 @@ package build
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -30,6 +31,34 @@ func (b *ManifestBuild) Validate() error {
 		if v == nil {
 			return fmt.Errorf("manifest validation failed: service '%s' build section not defined correctly", k)
 		}
+		if v.Dockerfile != "" && v.DockerfileInline != "" {
+			return fmt.Errorf("manifest validation failed: service '%s' defines both 'dockerfile' and 'dockerfile_inline'", k)
+		}
+		if v.CustomBuilder != nil {
+			if err := v.CustomBuilder.validate(); err != nil {
+				return fmt.Errorf("manifest validation failed: service '%s': %w", k, err)
+			}
+			if v.Dockerfile != "" || v.DockerfileInline != "" {
+				return fmt.Errorf("manifest validation failed: service '%s' defines both 'builder' and a Dockerfile", k)
+			}
+			if v.CustomBuilder.Type != NixpacksBuilder && (v.Target != "" || v.TestTarget != "") {
+				return fmt.Errorf("manifest validation failed: service '%s' defines 'target' or 'test_target', which are not supported by the '%s' builder", k, v.CustomBuilder.Type)
+			}
+		}
+		if v.TestResults != "" && v.TestTarget == "" {
+			return fmt.Errorf("manifest validation failed: service '%s' defines 'test_results' without 'test_target'", k)
+		}
+		if v.SignKey != "" && !v.Sign {
+			return fmt.Errorf("manifest validation failed: service '%s' defines 'sign_key' without 'sign'", k)
+		}
+		if v.TestResults != "" && !path.IsAbs(v.TestResults) {
+			return fmt.Errorf("manifest validation failed: 'test_results' of service '%s' must be an absolute path of the '%s' stage", k, v.TestTarget)
+		}
+		for _, platform := range v.Platforms {
+			if err := ValidatePlatform(platform); err != nil {
+				return fmt.Errorf("manifest validation failed: service '%s': %w", k, err)
+			}
+		}
 	}
 
 	cycle := utils.GetDependentCyclic(b.toGraph())
@@ -48,7 +77,7 @@ func (b *ManifestBuild) GetSvcsToBuildFromList(toBuild []string) []string {
 	svcsToBuildWithDependencies := getDependentNodes(b.toGraph(), toBuild)
 	if len(initialSvcsToBuild) != len(svcsToBuildWithDependencies) {
 		dependantBuildImages := getListDiff(initialSvcsToBuild, svcsToBuildWithDependencies)
-		oktetoLog.Warning("The following build images need to be built because of dependencies: [%s]", strings.Join(dependantBuildImages, ", "))
+		oktetoLog.WarningWithID(oktetoLog.WarnDependantBuildImages, "The following build images need to be built because of dependencies: [%s]", strings.Join(dependantBuildImages, ", "))
 	}
 	return svcsToBuildWithDependencies
 }
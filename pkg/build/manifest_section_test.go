@@ -59,6 +59,130 @@ func TestValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "custom builder",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					CustomBuilder: &CustomBuilder{Type: KoBuilder, Path: "./cmd/api"},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "unknown custom builder",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					CustomBuilder: &CustomBuilder{Type: "kaniko"},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "custom builder with dockerfile",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					Dockerfile:    "Dockerfile",
+					CustomBuilder: &CustomBuilder{Type: BuildpacksBuilder},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "custom builder with target",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					Target:        "prod",
+					CustomBuilder: &CustomBuilder{Type: BuildpacksBuilder},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "nixpacks with test target",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					TestTarget:    "test",
+					CustomBuilder: &CustomBuilder{Type: NixpacksBuilder},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "test results without test target",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					TestResults: "/reports",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "relative test results",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					TestTarget:  "test",
+					TestResults: "reports",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "test target with results",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					TestTarget:  "test",
+					TestResults: "/reports",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "dockerfile and dockerfile inline",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					Dockerfile:       "Dockerfile",
+					DockerfileInline: "FROM alpine",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "sign key without sign",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					SignKey: "cosign.key",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "sign with key",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					Sign:    true,
+					SignKey: "cosign.key",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "invalid platform",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					Platforms: []string{"linux/amd64", "arm64"},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "multi-arch platforms",
+			input: &ManifestBuild{
+				"testSvc": &Info{
+					Platforms: []string{"linux/amd64", "linux/arm64/v8"},
+				},
+			},
+			expectErr: false,
+		},
 		{
 			name: "successful validation",
 			input: &ManifestBuild{
@@ -0,0 +1,46 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParsePlatforms splits a comma separated list of platforms like 'linux/amd64,linux/arm64'
+func ParsePlatforms(value string) []string {
+	result := []string{}
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// ValidatePlatform checks that a platform follows the 'os/arch[/variant]' format
+func ValidatePlatform(platform string) error {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("invalid platform '%s': the format must be 'os/arch[/variant]', like 'linux/amd64'", platform)
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return fmt.Errorf("invalid platform '%s': the format must be 'os/arch[/variant]', like 'linux/amd64'", platform)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlatforms(t *testing.T) {
+	assert.Equal(t, []string{}, ParsePlatforms(""))
+	assert.Equal(t, []string{"linux/amd64"}, ParsePlatforms("linux/amd64"))
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, ParsePlatforms("linux/amd64, linux/arm64,"))
+}
+
+func TestValidatePlatform(t *testing.T) {
+	tests := []struct {
+		platform  string
+		expectErr bool
+	}{
+		{platform: "linux/amd64"},
+		{platform: "linux/arm64/v8"},
+		{platform: "arm64", expectErr: true},
+		{platform: "linux/", expectErr: true},
+		{platform: "linux/arm/v7/extra", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			err := ValidatePlatform(tt.platform)
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
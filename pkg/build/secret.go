@@ -0,0 +1,107 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// Secret is a BuildKit secret exposed to the build, defined like 'id=mysecret,src=/local/secret'
+type Secret struct {
+	// ID is the id used to mount the secret in the Dockerfile
+	ID string
+	// Src is the local file with the content of the secret
+	Src string
+	// Env is the environment variable with the content of the secret
+	Env string
+}
+
+// ParseSecret parses a secret with the format of the '--secret' flag of 'docker build':
+// 'id=mysecret,src=/local/secret' or 'id=mysecret,env=MY_SECRET'.
+// Without 'src' nor 'env', BuildKit reads the secret from the environment variable or the file named as the id
+func ParseSecret(value string) (Secret, error) {
+	fields, err := csv.NewReader(strings.NewReader(value)).Read()
+	if err != nil {
+		return Secret{}, fmt.Errorf("invalid secret '%s': %w", value, err)
+	}
+
+	result := Secret{}
+	typ := ""
+	for _, field := range fields {
+		key, val, found := strings.Cut(field, "=")
+		if !found {
+			return Secret{}, fmt.Errorf("invalid secret '%s': '%s' must be a key=value pair", value, field)
+		}
+		switch strings.ToLower(key) {
+		case "type":
+			if val != "file" && val != "env" {
+				return Secret{}, fmt.Errorf("invalid secret '%s': unsupported type '%s'", value, val)
+			}
+			typ = val
+		case "id":
+			result.ID = val
+		case "src", "source":
+			result.Src = val
+		case "env":
+			result.Env = val
+		default:
+			return Secret{}, fmt.Errorf("invalid secret '%s': unexpected key '%s'", value, key)
+		}
+	}
+	if typ == "env" && result.Env == "" {
+		result.Env = result.Src
+		result.Src = ""
+	}
+
+	if result.ID == "" {
+		return Secret{}, fmt.Errorf("invalid secret '%s': 'id' is required", value)
+	}
+	if result.Src != "" && result.Env != "" {
+		return Secret{}, fmt.Errorf("invalid secret '%s': 'src' and 'env' can't be defined at the same time", value)
+	}
+	return result, nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// Secrets are defined as a map of ids and local files, or as a list with the format 'id=mysecret,src=/local/secret'
+func (s *Secrets) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		result := Secrets{}
+		for _, value := range list {
+			secret, err := ParseSecret(value)
+			if err != nil {
+				return err
+			}
+			if secret.Src == "" {
+				return fmt.Errorf("invalid secret '%s': the secrets of the okteto manifest must define a local file with 'src'", value)
+			}
+			if _, ok := result[secret.ID]; ok {
+				return fmt.Errorf("invalid secret '%s': secret '%s' is defined more than once", value, secret.ID)
+			}
+			result[secret.ID] = secret.Src
+		}
+		*s = result
+		return nil
+	}
+
+	var raw map[string]string
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*s = raw
+	return nil
+}
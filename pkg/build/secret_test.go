@@ -0,0 +1,149 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseSecret(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  Secret
+		expectErr bool
+	}{
+		{
+			name:     "file secret",
+			input:    "id=npm,src=/home/okteto/.npmrc",
+			expected: Secret{ID: "npm", Src: "/home/okteto/.npmrc"},
+		},
+		{
+			name:     "file secret with source",
+			input:    "source=/home/okteto/.npmrc,id=npm",
+			expected: Secret{ID: "npm", Src: "/home/okteto/.npmrc"},
+		},
+		{
+			name:     "env secret",
+			input:    "id=token,env=GITHUB_TOKEN",
+			expected: Secret{ID: "token", Env: "GITHUB_TOKEN"},
+		},
+		{
+			name:     "env secret with type",
+			input:    "type=env,id=token,src=GITHUB_TOKEN",
+			expected: Secret{ID: "token", Env: "GITHUB_TOKEN"},
+		},
+		{
+			name:     "only id",
+			input:    "id=GITHUB_TOKEN",
+			expected: Secret{ID: "GITHUB_TOKEN"},
+		},
+		{
+			name:      "missing id",
+			input:     "src=/home/okteto/.npmrc",
+			expectErr: true,
+		},
+		{
+			name:      "not a key value pair",
+			input:     "npm",
+			expectErr: true,
+		},
+		{
+			name:      "unknown key",
+			input:     "id=npm,path=/home/okteto/.npmrc",
+			expectErr: true,
+		},
+		{
+			name:      "unknown type",
+			input:     "type=ssh,id=npm",
+			expectErr: true,
+		},
+		{
+			name:      "src and env",
+			input:     "id=npm,src=/home/okteto/.npmrc,env=NPMRC",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSecret(tt.input)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestUnmarshalSecrets(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  Secrets
+		expectErr bool
+	}{
+		{
+			name: "map",
+			input: `npm: /home/okteto/.npmrc
+pip: /home/okteto/.pip.conf`,
+			expected: Secrets{
+				"npm": "/home/okteto/.npmrc",
+				"pip": "/home/okteto/.pip.conf",
+			},
+		},
+		{
+			name: "list",
+			input: `- id=npm,src=/home/okteto/.npmrc
+- id=pip,src=/home/okteto/.pip.conf`,
+			expected: Secrets{
+				"npm": "/home/okteto/.npmrc",
+				"pip": "/home/okteto/.pip.conf",
+			},
+		},
+		{
+			name:      "list with env secret",
+			input:     `- id=token,env=GITHUB_TOKEN`,
+			expectErr: true,
+		},
+		{
+			name: "list with duplicated ids",
+			input: `- id=npm,src=/home/okteto/.npmrc
+- id=npm,src=/home/okteto/.pip.conf`,
+			expectErr: true,
+		},
+		{
+			name:      "list with invalid secret",
+			input:     `- /home/okteto/.npmrc`,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Secrets
+			err := yaml.UnmarshalStrict([]byte(tt.input), &result)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// TagStrategyGitSHA tags the images with the commit of the repository
+	TagStrategyGitSHA = "git-sha"
+
+	// TagStrategyContentHash tags the images with the hash of their build context
+	TagStrategyContentHash = "content-hash"
+
+	// TagStrategyBranchTimestamp tags the images with the branch of the repository and the build time
+	TagStrategyBranchTimestamp = "branch-timestamp"
+
+	tagTimestampFormat = "20060102150405"
+
+	// maxTagLength is the maximum length of a docker tag
+	maxTagLength = 128
+)
+
+var invalidTagCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// TagStrategyValues contains the values accepted by 'tagStrategy'
+var TagStrategyValues = []string{TagStrategyGitSHA, TagStrategyContentHash, TagStrategyBranchTimestamp}
+
+// ValidateTagStrategy returns an error if the tag strategy is not supported. An empty strategy uses the default tag
+func ValidateTagStrategy(strategy string) error {
+	if strategy == "" {
+		return nil
+	}
+	for _, v := range TagStrategyValues {
+		if strategy == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid tag strategy '%s': supported values are '%s'", strategy, strings.Join(TagStrategyValues, "', '"))
+}
+
+// TagInputs are the values used to compute the tag of an image
+type TagInputs struct {
+	Time      time.Time
+	Commit    string
+	BuildHash string
+	Branch    string
+}
+
+// GetTagForStrategy returns the tag for the given strategy, or an empty string if the strategy
+// is not set or the values it needs are not available
+func GetTagForStrategy(strategy string, inputs TagInputs) string {
+	switch strategy {
+	case TagStrategyGitSHA:
+		return sanitizeTag(inputs.Commit)
+	case TagStrategyContentHash:
+		return sanitizeTag(inputs.BuildHash)
+	case TagStrategyBranchTimestamp:
+		branch := sanitizeTag(inputs.Branch)
+		if branch == "" || inputs.Time.IsZero() {
+			return ""
+		}
+		timestamp := inputs.Time.UTC().Format(tagTimestampFormat)
+		if maxBranchLength := maxTagLength - len(timestamp) - 1; len(branch) > maxBranchLength {
+			branch = branch[:maxBranchLength]
+		}
+		return fmt.Sprintf("%s-%s", branch, timestamp)
+	default:
+		return ""
+	}
+}
+
+// sanitizeTag replaces the characters not allowed in a docker tag and trims it to its maximum length
+func sanitizeTag(tag string) string {
+	tag = invalidTagCharsRegex.ReplaceAllString(tag, "-")
+	tag = strings.TrimLeft(tag, ".-")
+	if len(tag) > maxTagLength {
+		tag = tag[:maxTagLength]
+	}
+	return tag
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTagStrategy(t *testing.T) {
+	assert.NoError(t, ValidateTagStrategy(""))
+	assert.NoError(t, ValidateTagStrategy(TagStrategyGitSHA))
+	assert.NoError(t, ValidateTagStrategy(TagStrategyContentHash))
+	assert.NoError(t, ValidateTagStrategy(TagStrategyBranchTimestamp))
+	assert.Error(t, ValidateTagStrategy("latest"))
+}
+
+func TestGetTagForStrategy(t *testing.T) {
+	inputs := TagInputs{
+		Commit:    "a1b2c3",
+		BuildHash: "d4e5f6",
+		Branch:    "feature/login",
+		Time:      time.Date(2023, 10, 5, 14, 30, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		inputs   TagInputs
+		expected string
+	}{
+		{name: "default", strategy: "", inputs: inputs, expected: ""},
+		{name: "git sha", strategy: TagStrategyGitSHA, inputs: inputs, expected: "a1b2c3"},
+		{name: "content hash", strategy: TagStrategyContentHash, inputs: inputs, expected: "d4e5f6"},
+		{name: "branch timestamp", strategy: TagStrategyBranchTimestamp, inputs: inputs, expected: "feature-login-20231005143000"},
+		{name: "branch timestamp without branch", strategy: TagStrategyBranchTimestamp, inputs: TagInputs{Time: inputs.Time}, expected: ""},
+		{name: "git sha without commit", strategy: TagStrategyGitSHA, inputs: TagInputs{}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetTagForStrategy(tt.strategy, tt.inputs))
+		})
+	}
+}
+
+func TestGetTagForStrategyLongBranch(t *testing.T) {
+	tag := GetTagForStrategy(TagStrategyBranchTimestamp, TagInputs{
+		Branch: strings.Repeat("a", 200),
+		Time:   time.Now(),
+	})
+	assert.Len(t, tag, maxTagLength)
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clitest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/constants"
+)
+
+// DeployOptions defines the options that can be added to a deploy command
+type DeployOptions struct {
+	Workdir          string
+	ManifestPath     string
+	LogLevel         string
+	LogOutput        string
+	Namespace        string
+	OktetoHome       string
+	Token            string
+	Name             string
+	Variables        []string
+	ServicesToDeploy []string
+	Build            bool
+	IsRemote         bool
+}
+
+// DestroyOptions defines the options that can be added to a destroy command
+type DestroyOptions struct {
+	Workdir      string
+	ManifestPath string
+	Namespace    string
+	OktetoHome   string
+	Token        string
+	Name         string
+	IsRemote     bool
+}
+
+// Command returns the okteto deploy command for the options
+func (o *DeployOptions) Command() Command {
+	args := []string{"deploy"}
+	args = append(args, o.ServicesToDeploy...)
+	if o.ManifestPath != "" {
+		args = append(args, "-f", o.ManifestPath)
+	}
+	if o.Build {
+		args = append(args, "--build")
+	}
+	if o.LogLevel != "" {
+		args = append(args, "--log-level", o.LogLevel)
+	}
+	if o.Namespace != "" {
+		args = append(args, "--namespace", o.Namespace)
+	}
+	if o.LogOutput != "" {
+		args = append(args, "--log-output", o.LogOutput)
+	}
+	if o.Name != "" {
+		args = append(args, "--name", o.Name)
+	}
+	for _, v := range o.Variables {
+		args = append(args, "--var", v)
+	}
+	if o.IsRemote {
+		args = append(args, "--remote")
+	}
+
+	env := getEnv(o.OktetoHome, o.Token)
+	if o.OktetoHome != "" {
+		env = append(env, fmt.Sprintf("%s=%s", constants.KubeConfigEnvVar, filepath.Join(o.OktetoHome, ".kube", "config")))
+	}
+	return Command{Dir: o.Workdir, Args: args, Env: env}
+}
+
+// Command returns the okteto destroy command for the options
+func (o *DestroyOptions) Command() Command {
+	args := []string{"destroy"}
+	if o.Name != "" {
+		args = append(args, "--name", o.Name)
+	}
+	if o.ManifestPath != "" {
+		args = append(args, "-f", o.ManifestPath)
+	}
+	if o.Namespace != "" {
+		args = append(args, "--namespace", o.Namespace)
+	}
+	if o.IsRemote {
+		args = append(args, "--remote")
+	}
+	return Command{Dir: o.Workdir, Args: args, Env: getEnv(o.OktetoHome, o.Token)}
+}
+
+// Deploy runs okteto deploy and returns its output
+func (r *Runner) Deploy(ctx context.Context, opts *DeployOptions) (string, error) {
+	return r.Run(ctx, opts.Command())
+}
+
+// Destroy runs okteto destroy and returns its output
+func (r *Runner) Destroy(ctx context.Context, opts *DestroyOptions) (string, error) {
+	return r.Run(ctx, opts.Command())
+}
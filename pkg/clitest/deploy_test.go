@@ -0,0 +1,55 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clitest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployOptionsCommand(t *testing.T) {
+	opts := &DeployOptions{
+		Workdir:          "/tmp/app",
+		ManifestPath:     "okteto.yml",
+		Namespace:        "test",
+		OktetoHome:       "/tmp/home",
+		Token:            "token",
+		Name:             "app",
+		Variables:        []string{"A=1"},
+		ServicesToDeploy: []string{"api"},
+		Build:            true,
+		IsRemote:         true,
+	}
+
+	require.Equal(t, Command{
+		Dir:  "/tmp/app",
+		Args: []string{"deploy", "api", "-f", "okteto.yml", "--build", "--namespace", "test", "--name", "app", "--var", "A=1", "--remote"},
+		Env:  []string{"OKTETO_HOME=/tmp/home", "OKTETO_TOKEN=token", "KUBECONFIG=" + filepath.Join("/tmp/home", ".kube", "config")},
+	}, opts.Command())
+}
+
+func TestDestroyOptionsCommand(t *testing.T) {
+	opts := &DestroyOptions{
+		Name:      "app",
+		Namespace: "test",
+		Token:     "token",
+	}
+
+	require.Equal(t, Command{
+		Args: []string{"destroy", "--name", "app", "--namespace", "test"},
+		Env:  []string{"OKTETO_TOKEN=token"},
+	}, opts.Command())
+}
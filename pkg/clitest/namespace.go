@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clitest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// NamespaceOptions defines the options that can be added to a namespace command
+type NamespaceOptions struct {
+	Namespace  string
+	OktetoHome string
+	Token      string
+}
+
+// CreateNamespace runs okteto namespace create
+func (r *Runner) CreateNamespace(ctx context.Context, opts *NamespaceOptions) (string, error) {
+	return r.Run(ctx, Command{
+		Args: []string{"namespace", "create", opts.Namespace, "-l", "debug"},
+		Env:  getEnv(opts.OktetoHome, opts.Token),
+	})
+}
+
+// UseNamespace runs okteto namespace to set the namespace of the current context
+func (r *Runner) UseNamespace(ctx context.Context, opts *NamespaceOptions) (string, error) {
+	return r.Run(ctx, Command{
+		Args: []string{"namespace", opts.Namespace, "-l", "debug"},
+		Env:  getEnv(opts.OktetoHome, opts.Token),
+	})
+}
+
+// DeleteNamespace runs okteto namespace delete
+func (r *Runner) DeleteNamespace(ctx context.Context, opts *NamespaceOptions) (string, error) {
+	return r.Run(ctx, Command{
+		Args: []string{"namespace", "delete", opts.Namespace},
+		Env:  getEnv(opts.OktetoHome, opts.Token),
+	})
+}
+
+// TestNamespace creates a namespace for a test and deletes it when the test and all its subtests complete
+func (r *Runner) TestNamespace(t testing.TB, opts *NamespaceOptions) {
+	t.Helper()
+	if _, err := r.CreateNamespace(context.Background(), opts); err != nil {
+		t.Fatalf("error creating namespace '%s': %s", opts.Namespace, err)
+	}
+	t.Cleanup(func() {
+		if _, err := r.DeleteNamespace(context.Background(), opts); err != nil {
+			t.Logf("error deleting namespace '%s': %s", opts.Namespace, err)
+		}
+	})
+}
+
+// GetTestNamespace returns a unique name for a test namespace
+func GetTestNamespace(prefix, user string) string {
+	os := runtime.GOOS
+	if os == "windows" {
+		os = "win"
+	} else {
+		os = os[:3]
+	}
+	namespace := fmt.Sprintf("%s-%s-%d-%s", prefix, os, time.Now().Unix(), user)
+	return strings.ToLower(namespace)
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clitest drives the okteto CLI from Go tests.
+// It is used by the okteto integration tests and can be used by platform teams to test their own
+// development environments against their clusters: commands are retried, their output is captured
+// and namespaces are created and deleted as part of the test lifecycle.
+package clitest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	defaultOktetoPath    = "/usr/local/bin/okteto"
+	defaultRetryInterval = 5 * time.Second
+)
+
+// Command is an invocation of the okteto CLI
+type Command struct {
+	// Dir is the working directory of the command. If empty, the current directory is used
+	Dir string
+	// Args are the arguments passed to the okteto CLI
+	Args []string
+	// Env are the environment variables added to the current environment
+	Env []string
+}
+
+// Runner runs commands of the okteto CLI
+type Runner struct {
+	// Logger logs the commands executed and their retries. If nil, the standard logger is used
+	Logger *log.Logger
+	// OktetoPath is the path of the okteto binary
+	OktetoPath string
+	// Retries is the number of times a failed command is retried
+	Retries int
+	// RetryInterval is the time to wait between retries
+	RetryInterval time.Duration
+}
+
+// NewRunner returns a runner for the okteto binary at oktetoPath that doesn't retry failed commands
+func NewRunner(oktetoPath string) *Runner {
+	return &Runner{
+		OktetoPath:    oktetoPath,
+		RetryInterval: defaultRetryInterval,
+	}
+}
+
+// FindOktetoPath returns the absolute path of the okteto binary defined by OKTETO_PATH,
+// or /usr/local/bin/okteto if it is not set
+func FindOktetoPath() (string, error) {
+	oktetoPath, ok := os.LookupEnv(model.OktetoPathEnvVar)
+	if !ok {
+		oktetoPath = defaultOktetoPath
+	}
+	return filepath.Abs(oktetoPath)
+}
+
+func (r *Runner) logf(format string, args ...interface{}) {
+	if r.Logger == nil {
+		log.Printf(format, args...)
+		return
+	}
+	r.Logger.Printf(format, args...)
+}
+
+// Cmd returns the exec.Cmd for a command of the okteto CLI
+func (r *Runner) Cmd(ctx context.Context, c Command) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, r.OktetoPath, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = append(os.Environ(), c.Env...)
+	return cmd
+}
+
+// Run runs a command of the okteto CLI, retrying it if it fails, and returns its combined output
+func (r *Runner) Run(ctx context.Context, c Command) (string, error) {
+	var output []byte
+	var err error
+	for attempt := 0; attempt <= r.Retries; attempt++ {
+		if attempt > 0 {
+			r.logf("'okteto %s' failed, retrying (%d/%d)", strings.Join(c.Args, " "), attempt, r.Retries)
+			select {
+			case <-time.After(r.RetryInterval):
+			case <-ctx.Done():
+				return string(output), ctx.Err()
+			}
+		}
+
+		cmd := r.Cmd(ctx, c)
+		r.logf("Running '%s'", cmd.String())
+		output, err = cmd.CombinedOutput()
+		if err == nil {
+			return string(output), nil
+		}
+	}
+	return string(output), fmt.Errorf("okteto %s failed: %s - %w", c.Args[0], string(output), err)
+}
+
+// Version runs okteto version
+func (r *Runner) Version(ctx context.Context) (string, error) {
+	return r.Run(ctx, Command{Args: []string{"version"}})
+}
+
+// getEnv returns the environment variables to run a command with a custom okteto home and token
+func getEnv(oktetoHome, token string) []string {
+	env := []string{}
+	if oktetoHome != "" {
+		env = append(env, fmt.Sprintf("%s=%s", constants.OktetoHomeEnvVar, oktetoHome))
+	}
+	if token != "" {
+		env = append(env, fmt.Sprintf("%s=%s", model.OktetoTokenEnvVar, token))
+	}
+	return env
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clitest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeOkteto writes a script that prints its arguments and fails the first 'failures' times it is called
+func newFakeOkteto(t *testing.T, failures int) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake okteto binary is a shell script")
+	}
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "calls")
+	script := `#!/bin/sh
+calls=$(cat ` + counter + ` 2>/dev/null || echo 0)
+calls=$((calls+1))
+echo $calls > ` + counter + `
+echo "args: $@"
+echo "home: $OKTETO_HOME"
+if [ $calls -le ` + strconv.Itoa(failures) + ` ]; then
+  echo "failure $calls"
+  exit 1
+fi
+`
+	path := filepath.Join(dir, "okteto")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0700))
+	return path
+}
+
+func TestRunnerRun(t *testing.T) {
+	r := NewRunner(newFakeOkteto(t, 0))
+	output, err := r.Run(context.Background(), Command{Args: []string{"version"}, Env: []string{"OKTETO_HOME=/tmp/home"}})
+	require.NoError(t, err)
+	require.Contains(t, output, "args: version")
+	require.Contains(t, output, "home: /tmp/home")
+}
+
+func TestRunnerRunWithRetries(t *testing.T) {
+	r := NewRunner(newFakeOkteto(t, 2))
+	r.RetryInterval = 0
+
+	output, err := r.Run(context.Background(), Command{Args: []string{"version"}})
+	require.Error(t, err)
+	require.Contains(t, output, "failure 1")
+
+	r = NewRunner(newFakeOkteto(t, 2))
+	r.RetryInterval = 0
+	r.Retries = 2
+	output, err = r.Run(context.Background(), Command{Args: []string{"version"}})
+	require.NoError(t, err)
+	require.NotContains(t, output, "failure")
+}
+
+func TestFindOktetoPath(t *testing.T) {
+	t.Setenv("OKTETO_PATH", "bin/okteto")
+	path, err := FindOktetoPath()
+	require.NoError(t, err)
+	require.True(t, filepath.IsAbs(path))
+	require.Equal(t, "okteto", filepath.Base(path))
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/docker/cli/cli/config"
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/cli/config/types"
+	"github.com/docker/docker-credential-helpers/credentials"
 	"github.com/moby/buildkit/session/auth"
+	"github.com/okteto/okteto/pkg/auth/dockercredentials"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/okteto"
@@ -95,6 +97,10 @@ type authProvider struct {
 
 	newOktetoClient newOktetoClientFunc
 
+	// cloudCredentials gets the credentials of the cloud registries from their docker credential helpers.
+	// It is nil to use the helpers installed locally
+	cloudCredentials func(host string) (string, string, error)
+
 	// The need for this mutex is not well understood.
 	// Without it, the docker cli on OS X hangs when
 	// reading credentials from docker-credential-osxkeychain.
@@ -164,6 +170,9 @@ func (ap *authProvider) Credentials(ctx context.Context, req *auth.CredentialsRe
 
 		return nil, err
 	}
+	if ac.IdentityToken == "" && ac.Username == "" && ac.Password == "" {
+		ac = ap.getCloudAuthConfig(req.Host)
+	}
 	if ac.IdentityToken != "" {
 		return &auth.CredentialsResponse{
 			Secret: ac.IdentityToken,
@@ -191,6 +200,27 @@ func (ap *authProvider) getOktetoCredentials(host string, c *okteto.Client) *aut
 	return res
 }
 
+// getCloudAuthConfig returns the credentials of a cloud registry from its docker credential helper.
+// It is used for the registries without credentials in the config file, so the builds can pull from private
+// cloud registries that only issue short-lived tokens
+func (ap *authProvider) getCloudAuthConfig(host string) types.AuthConfig {
+	getCredentials := ap.cloudCredentials
+	if getCredentials == nil {
+		getCredentials = dockercredentials.GetCloudCredentials
+	}
+	username, secret, err := getCredentials(host)
+	if err != nil {
+		if !credentials.IsErrCredentialsNotFound(err) {
+			oktetoLog.Infof("failed to get the credentials of %s from its credential helper: %s", host, err)
+		}
+		return types.AuthConfig{}
+	}
+	if username == dockercredentials.IdentityTokenUsername {
+		return types.AuthConfig{IdentityToken: secret}
+	}
+	return types.AuthConfig{Username: username, Password: secret}
+}
+
 func isErrCredentialsHelperNotAccessible(err error) bool {
 
 	if !strings.HasPrefix(err.Error(), "error getting credentials") {
@@ -356,3 +356,69 @@ func TestCredentials(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialsFromCloudHelper(t *testing.T) {
+	oktetoRegistry = "okteto.registry.com"
+	ecrHost := "123456789012.dkr.ecr.us-east-1.amazonaws.com"
+
+	tt := []struct {
+		cloudCredentials func(host string) (string, string, error)
+		localCredentials *types.AuthConfig
+		expected         *auth.CredentialsResponse
+		name             string
+	}{
+		{
+			name: "short-lived token from the credential helper",
+			cloudCredentials: func(string) (string, string, error) {
+				return "AWS", "ecr-token", nil
+			},
+			expected: &auth.CredentialsResponse{Username: "AWS", Secret: "ecr-token"},
+		},
+		{
+			name: "identity token from the credential helper",
+			cloudCredentials: func(string) (string, string, error) {
+				return "<token>", "identity-token", nil
+			},
+			expected: &auth.CredentialsResponse{Secret: "identity-token"},
+		},
+		{
+			name: "config file credentials take precedence",
+			cloudCredentials: func(string) (string, string, error) {
+				return "AWS", "ecr-token", nil
+			},
+			localCredentials: &types.AuthConfig{Username: "local", Password: "local"},
+			expected:         &auth.CredentialsResponse{Username: "local", Secret: "local"},
+		},
+		{
+			name: "credential helper fails",
+			cloudCredentials: func(string) (string, string, error) {
+				return "", "", assert.AnError
+			},
+			expected: &auth.CredentialsResponse{Username: "okteto", Secret: "okteto"},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ap := &authProvider{
+				config: &configfile.ConfigFile{
+					AuthConfigs: map[string]types.AuthConfig{},
+				},
+				externalAuth: func(string, bool, *okteto.Client) (string, string, error) {
+					return "okteto", "okteto", nil
+				},
+				newOktetoClient: func(cfg *okteto.ClientCfg, opts ...okteto.Option) (*okteto.Client, error) {
+					return &okteto.Client{}, nil
+				},
+				authContext:      &fakeContext{},
+				cloudCredentials: tc.cloudCredentials,
+			}
+			if tc.localCredentials != nil {
+				ap.config.AuthConfigs[ecrHost] = *tc.localCredentials
+			}
+
+			creds, err := ap.Credentials(context.Background(), &auth.CredentialsRequest{Host: ecrHost})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, creds)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// BuilderAuto builds with the BuildKit instance of the context and falls back to the local Docker daemon when it is unreachable
+	BuilderAuto = "auto"
+
+	// BuilderBuildkit builds with the BuildKit instance of the context
+	BuilderBuildkit = "buildkit"
+
+	// BuilderDocker builds with the local Docker daemon
+	BuilderDocker = "docker"
+
+	// BuilderBuildx builds with a builder of 'docker buildx'. A builder name can be set with 'buildx:<name>'
+	BuilderBuildx = "buildx"
+
+	buildkitDialTimeout = 3 * time.Second
+)
+
+// dialFunc opens a network connection, used to check if the BuildKit instance is reachable
+type dialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
+// ValidateBuilder validates the builder backend used to build the images
+func ValidateBuilder(value string) error {
+	backend, name := parseBuilder(value)
+	switch {
+	case backend == BuilderBuildx:
+		return nil
+	case name == "" && (backend == "" || backend == BuilderAuto || backend == BuilderBuildkit || backend == BuilderDocker):
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("invalid builder '%s'", value),
+		Hint: fmt.Sprintf("Use one of '%s', '%s', '%s' or '%s[:<name>]'", BuilderAuto, BuilderBuildkit, BuilderDocker, BuilderBuildx),
+	}
+}
+
+// parseBuilder returns the backend of a builder and the name of the buildx builder, if any
+func parseBuilder(value string) (string, string) {
+	backend, name, _ := strings.Cut(value, ":")
+	return backend, name
+}
+
+// resolveBuilder returns the backend that builds the image and the name of the buildx builder.
+// The builder of the build options takes precedence over the default builder of the context.
+// Without any of them, images are built with the BuildKit instance of the context or, if there is none, with the local Docker daemon
+func (ob *OktetoBuilder) resolveBuilder(buildOptions *types.BuildOptions, dial dialFunc) (string, string) {
+	builder := buildOptions.Builder
+	if builder == "" {
+		builder = ob.OktetoContext.GetBuildBackend()
+	}
+	backend, buildxName := parseBuilder(builder)
+	buildkitURL := ob.OktetoContext.GetCurrentBuilder()
+
+	switch backend {
+	case BuilderDocker, BuilderBuildx:
+		return backend, buildxName
+	case BuilderAuto:
+		if buildkitURL == "" {
+			return BuilderDocker, ""
+		}
+		if !isBuildkitReachable(buildkitURL, dial) {
+			oktetoLog.Warning("The builder '%s' is not reachable, falling back to your local Docker daemon", buildkitURL)
+			return BuilderDocker, ""
+		}
+		return BuilderBuildkit, ""
+	case BuilderBuildkit:
+		return BuilderBuildkit, ""
+	default:
+		if buildkitURL == "" {
+			return BuilderDocker, ""
+		}
+		return BuilderBuildkit, ""
+	}
+}
+
+// isBuildkitReachable checks if a TCP connection can be opened with the BuildKit instance.
+// Builders that are not reached over the network are considered reachable
+func isBuildkitReachable(buildkitURL string, dial dialFunc) bool {
+	u, err := url.Parse(buildkitURL)
+	if err != nil || u.Host == "" {
+		return true
+	}
+	switch u.Scheme {
+	case "tcp", "https", "http":
+	default:
+		return true
+	}
+	address := u.Host
+	if u.Port() == "" {
+		port := "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+		address = net.JoinHostPort(u.Hostname(), port)
+	}
+	conn, err := dial("tcp", address, buildkitDialTimeout)
+	if err != nil {
+		oktetoLog.Infof("builder '%s' is not reachable: %s", buildkitURL, err)
+		return false
+	}
+	conn.Close()
+	return true
+}
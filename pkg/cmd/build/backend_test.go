@@ -0,0 +1,141 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBuilder(t *testing.T) {
+	for _, value := range []string{"", "auto", "buildkit", "docker", "buildx", "buildx:my-builder"} {
+		assert.NoError(t, ValidateBuilder(value), value)
+	}
+	for _, value := range []string{"podman", "docker:remote", "buildkit:tcp://buildkit:1234"} {
+		assert.Error(t, ValidateBuilder(value), value)
+	}
+}
+
+func newFakeDial(reachable bool) dialFunc {
+	return func(_, _ string, _ time.Duration) (net.Conn, error) {
+		if !reachable {
+			return nil, errors.New("connection refused")
+		}
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	}
+}
+
+func TestResolveBuilder(t *testing.T) {
+	tests := []struct {
+		name            string
+		option          string
+		contextDefault  string
+		buildkitURL     string
+		expectedBackend string
+		expectedBuildx  string
+		reachable       bool
+	}{
+		{
+			name:            "default with buildkit",
+			buildkitURL:     "tcp://buildkit.okteto.dev:443",
+			expectedBackend: BuilderBuildkit,
+		},
+		{
+			name:            "default without buildkit",
+			expectedBackend: BuilderDocker,
+		},
+		{
+			name:            "auto with reachable buildkit",
+			option:          BuilderAuto,
+			buildkitURL:     "tcp://buildkit.okteto.dev:443",
+			reachable:       true,
+			expectedBackend: BuilderBuildkit,
+		},
+		{
+			name:            "auto with unreachable buildkit",
+			option:          BuilderAuto,
+			buildkitURL:     "tcp://buildkit.okteto.dev:443",
+			expectedBackend: BuilderDocker,
+		},
+		{
+			name:            "auto from the context",
+			contextDefault:  BuilderAuto,
+			buildkitURL:     "tcp://buildkit.okteto.dev:443",
+			expectedBackend: BuilderDocker,
+		},
+		{
+			name:            "option takes precedence over the context",
+			option:          "buildx:remote",
+			contextDefault:  BuilderAuto,
+			buildkitURL:     "tcp://buildkit.okteto.dev:443",
+			expectedBackend: BuilderBuildx,
+			expectedBuildx:  "remote",
+		},
+		{
+			name:            "docker",
+			option:          BuilderDocker,
+			buildkitURL:     "tcp://buildkit.okteto.dev:443",
+			reachable:       true,
+			expectedBackend: BuilderDocker,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ob := &OktetoBuilder{
+				OktetoContext: &okteto.ContextStateless{
+					Store: &okteto.ContextStore{
+						Contexts: map[string]*okteto.Context{
+							"test": {
+								Builder:      tt.buildkitURL,
+								BuildBackend: tt.contextDefault,
+							},
+						},
+						CurrentContext: "test",
+					},
+				},
+			}
+			backend, buildxName := ob.resolveBuilder(&types.BuildOptions{Builder: tt.option}, newFakeDial(tt.reachable))
+			assert.Equal(t, tt.expectedBackend, backend)
+			assert.Equal(t, tt.expectedBuildx, buildxName)
+		})
+	}
+}
+
+func TestIsBuildkitReachable(t *testing.T) {
+	var address string
+	dial := func(_, addr string, _ time.Duration) (net.Conn, error) {
+		address = addr
+		return newFakeDial(true)("", "", 0)
+	}
+
+	assert.True(t, isBuildkitReachable("tcp://buildkit.okteto.dev:1234", dial))
+	assert.Equal(t, "buildkit.okteto.dev:1234", address)
+
+	assert.True(t, isBuildkitReachable("https://buildkit.okteto.dev", dial))
+	assert.Equal(t, "buildkit.okteto.dev:443", address)
+
+	address = ""
+	assert.True(t, isBuildkitReachable("unix:///run/buildkit/buildkitd.sock", dial))
+	assert.Empty(t, address)
+
+	assert.False(t, isBuildkitReachable("tcp://buildkit.okteto.dev:1234", newFakeDial(false)))
+}
@@ -18,10 +18,13 @@ import (
 	"context"
 	"encoding/csv"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	registrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/client"
 	"github.com/okteto/okteto/pkg/build"
@@ -51,6 +54,11 @@ var (
 		E:    fmt.Errorf("cannot connect to Docker Daemon"),
 		Hint: "Please start the Docker Daemon or configure a builder endpoint with 'okteto context --builder BUILDKIT_URL",
 	}
+
+	errCacheWarmNotSupportedByDockerDaemon = oktetoErrors.UserError{
+		E:    fmt.Errorf("cache warm builds are not supported by your local docker daemon"),
+		Hint: "Run 'okteto context' to select an Okteto context with a BuildKit builder",
+	}
 )
 
 // OktetoBuilderInterface runs the build of an image
@@ -88,19 +96,56 @@ func (ob *OktetoBuilder) GetBuilder() string {
 func (ob *OktetoBuilder) Run(ctx context.Context, buildOptions *types.BuildOptions, ioCtrl *io.Controller) error {
 	isRemoteExecution := buildOptions.OutputMode == DeployOutputModeOnBuild || buildOptions.OutputMode == DestroyOutputModeOnBuild || buildOptions.OutputMode == TestOutputModeOnBuild
 	buildOptions.OutputMode = setOutputMode(buildOptions.OutputMode)
+	if buildOptions.Reproducible {
+		setReproducibleBuildArgs(buildOptions)
+	}
+	platforms := build.ParsePlatforms(buildOptions.Platform)
+	for _, platform := range platforms {
+		if err := build.ValidatePlatform(platform); err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "Use a comma separated list of platforms to build a multi-arch image, like 'linux/amd64,linux/arm64'",
+			}
+		}
+	}
+	buildOptions.Platform = strings.Join(platforms, ",")
+	for _, secret := range buildOptions.Secrets {
+		if _, err := build.ParseSecret(secret); err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "Secrets must have the format 'id=mysecret,src=/local/secret' or 'id=mysecret,env=MY_SECRET'",
+			}
+		}
+	}
+	if err := validateSBOMFormat(buildOptions.SBOM); err != nil {
+		return err
+	}
+	provenance, err := ParseProvenanceMode(buildOptions.Provenance)
+	if err != nil {
+		return err
+	}
+	buildOptions.Provenance = provenance
 	depotToken := os.Getenv(DepotTokenEnvVar)
 	depotProject := os.Getenv(DepotProjectEnvVar)
 
+	if err := ValidateBuilder(buildOptions.Builder); err != nil {
+		return err
+	}
+	backend, buildxName := ob.resolveBuilder(buildOptions, net.DialTimeout)
+
 	if !isRemoteExecution {
-		builder := ob.GetBuilder()
 		buildMsg := fmt.Sprintf("Building '%s'", buildOptions.File)
-		depotEnabled := IsDepotEnabled()
-		if depotEnabled {
+		switch {
+		case IsDepotEnabled():
 			ioCtrl.Out().Infof("%s on depot's machine...", buildMsg)
-		} else if builder == "" {
+		case backend == BuilderDocker:
 			ioCtrl.Out().Infof("%s using your local docker daemon", buildMsg)
-		} else {
-			ioCtrl.Out().Infof("%s in %s...", buildMsg, builder)
+		case backend == BuilderBuildx && buildxName != "":
+			ioCtrl.Out().Infof("%s with buildx builder '%s'...", buildMsg, buildxName)
+		case backend == BuilderBuildx:
+			ioCtrl.Out().Infof("%s with docker buildx...", buildMsg)
+		default:
+			ioCtrl.Out().Infof("%s in %s...", buildMsg, ob.GetBuilder())
 		}
 	}
 
@@ -110,12 +155,61 @@ func (ob *OktetoBuilder) Run(ctx context.Context, buildOptions *types.BuildOptio
 	// use the internal cluster ip as if they were running their scripts on the k8s cluster
 	case IsDepotEnabled() && !isRemoteExecution:
 		depotManager := newDepotBuilder(depotProject, depotToken, ob.OktetoContext, ioCtrl)
-		return depotManager.Run(ctx, buildOptions, solveBuild)
-	case ob.OktetoContext.GetCurrentBuilder() == "":
+		if err := depotManager.Run(ctx, buildOptions, solveBuild); err != nil {
+			return err
+		}
+		showSBOM(buildOptions, ioCtrl)
+		showProvenance(buildOptions, ioCtrl)
+		showLocalExport(buildOptions, ioCtrl)
+		return nil
+	case backend == BuilderDocker:
+		if len(platforms) > 1 {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("multi-platform builds are not supported by your local docker daemon"),
+				Hint: "Run 'okteto context' to select an Okteto context with a BuildKit builder",
+			}
+		}
+		if buildOptions.SBOM != "" {
+			return errSBOMNotSupportedByDockerDaemon
+		}
+		if buildOptions.Provenance != "" {
+			return errProvenanceNotSupportedByDockerDaemon
+		}
+		if buildOptions.CacheWarm {
+			return errCacheWarmNotSupportedByDockerDaemon
+		}
+		if buildOptions.LocalExport != nil {
+			return errExportNotSupportedByDockerDaemon
+		}
 		return ob.buildWithDocker(ctx, buildOptions)
+	case backend == BuilderBuildx:
+		run, err := newBuildxRunner()
+		if err != nil {
+			return err
+		}
+		return ob.buildWithBuildx(ctx, buildOptions, buildxName, run)
+	case ob.OktetoContext.GetCurrentBuilder() == "":
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("your context doesn't have a BuildKit builder"),
+			Hint: fmt.Sprintf("Configure one with 'okteto context use --builder BUILDKIT_URL' or build with '--builder %s'", BuilderDocker),
+		}
 	default:
-		return ob.buildWithOkteto(ctx, buildOptions, ioCtrl, solveBuild)
+		if err := ob.buildWithOkteto(ctx, buildOptions, ioCtrl, solveBuild); err != nil {
+			return err
+		}
+		showSBOM(buildOptions, ioCtrl)
+		showProvenance(buildOptions, ioCtrl)
+		showLocalExport(buildOptions, ioCtrl)
+		return nil
+	}
+}
+
+// showLocalExport shows where the image exported instead of pushed has been written
+func showLocalExport(buildOptions *types.BuildOptions, ioCtrl *io.Controller) {
+	if buildOptions.LocalExport == nil || buildOptions.CacheWarm {
+		return
 	}
+	ioCtrl.Out().Infof("Image exported in %s format to '%s'", buildOptions.LocalExport.Type, buildOptions.LocalExport.Dest)
 }
 
 func setOutputMode(outputMode string) string {
@@ -144,6 +238,9 @@ func GetRegistryConfigFromOktetoConfig(okCtx OktetoContextInterface) *okteto.Con
 		UserId:                      okCtx.GetCurrentUser(),
 		Token:                       okCtx.GetCurrentToken(),
 		GlobalNamespace:             okCtx.GetGlobalNamespace(),
+		RegistryTemplates:           okCtx.GetRegistryTemplates(),
+		RegistryMirrors:             okCtx.GetRegistryMirrors(),
+		RegistryRetry:               okCtx.GetRegistryRetry(),
 		InsecureSkipTLSVerifyPolicy: okCtx.IsInsecure(),
 	}
 }
@@ -152,8 +249,8 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 	oktetoLog.Infof("building your image on %s", ob.OktetoContext.GetCurrentBuilder())
 
 	var err error
-	if buildOptions.File != "" {
-		buildOptions.File, err = GetDockerfile(buildOptions.File, ob.OktetoContext)
+	if hasLocalDockerfile(buildOptions) {
+		buildOptions.File, err = GetDockerfile(buildOptions, ob.OktetoContext)
 		if err != nil {
 			return err
 		}
@@ -177,6 +274,10 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 		return err
 	}
 
+	if buildOptions.OutputMode == oktetoLog.TTYFormat || buildOptions.OutputMode == oktetoLog.PlainFormat {
+		showBuildQueue(ctx, buildkitClient.ControlClient(), ioCtrl)
+	}
+
 	err = run(ctx, buildkitClient, opt, buildOptions.OutputMode, ioCtrl)
 	if err != nil {
 		if shouldRetryBuild(err, buildOptions.Tag, ob.OktetoContext) {
@@ -210,6 +311,27 @@ func (ob *OktetoBuilder) buildWithDocker(ctx context.Context, buildOptions *type
 	if err != nil {
 		return err
 	}
+
+	// the local docker daemon is also a fallback of the builder of okteto contexts
+	var oktetoAuth *registrytypes.AuthConfig
+	if ob.OktetoContext.IsOktetoCluster() {
+		if err := expandOktetoRegistryReferences(buildOptions, ob.OktetoContext); err != nil {
+			return err
+		}
+		oktetoAuth = &registrytypes.AuthConfig{
+			ServerAddress: ob.OktetoContext.GetCurrentRegister(),
+			Username:      ob.OktetoContext.GetCurrentUser(),
+			Password:      ob.OktetoContext.GetCurrentToken(),
+		}
+	}
+	// the ignore rules of the build are written next to the translated Dockerfile
+	if hasLocalDockerfile(buildOptions) && (ob.OktetoContext.IsOktetoCluster() || len(buildOptions.IgnoreRules) > 0) {
+		buildOptions.File, err = GetDockerfile(buildOptions, ob.OktetoContext)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(buildOptions.File)
+	}
 	if versions.GreaterThanOrEqualTo(cli.ClientVersion(), "1.39") {
 		err = buildWithDockerDaemonBuildkit(ctx, buildOptions, cli)
 		if err != nil {
@@ -221,8 +343,10 @@ func (ob *OktetoBuilder) buildWithDocker(ctx context.Context, buildOptions *type
 			return translateDockerErr(err)
 		}
 	}
-	if buildOptions.Tag != "" {
-		return pushImage(ctx, buildOptions.Tag, cli)
+	for _, tag := range getTags(buildOptions.Tag) {
+		if err := pushImage(ctx, tag, cli, oktetoAuth); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -302,7 +426,7 @@ func OptsFromBuildInfo(manifestName, svcName string, b *build.Info, o *types.Bui
 	}
 
 	file := b.Dockerfile
-	if b.Context != "" && b.Dockerfile != "" {
+	if b.Context != "" && b.Dockerfile != "" && !build.IsRemoteContext(b.Context) {
 		file = extractFromContextAndDockerfile(b.Context, b.Dockerfile, svcName)
 	}
 
@@ -360,16 +484,27 @@ func OptsFromBuildInfo(manifestName, svcName string, b *build.Info, o *types.Bui
 		}
 	}
 
+	platform := o.Platform
+	if platform == "" {
+		platform = strings.Join(b.Platforms, ",")
+	}
+
 	opts := &types.BuildOptions{
-		CacheFrom:   b.CacheFrom,
-		Target:      b.Target,
-		Path:        b.Context,
-		Tag:         b.Image,
-		File:        file,
-		BuildArgs:   build.SerializeArgs(args),
-		NoCache:     o.NoCache,
-		ExportCache: b.ExportCache,
-		Platform:    o.Platform,
+		CacheFrom:    b.CacheFrom,
+		Target:       b.Target,
+		Path:         b.Context,
+		Tag:          b.Image,
+		File:         file,
+		BuildArgs:    build.SerializeArgs(args),
+		NoCache:      o.NoCache,
+		ExportCache:  b.ExportCache,
+		Platform:     platform,
+		Reproducible: b.Reproducible || o.Reproducible,
+		SBOM:         o.SBOM,
+		Provenance:   o.Provenance,
+		Builder:      o.Builder,
+		CacheWarm:    o.CacheWarm,
+		LocalExport:  o.LocalExport,
 	}
 
 	// if secrets are present at the cmd flag, copy them to opts.Secrets
@@ -377,9 +512,22 @@ func OptsFromBuildInfo(manifestName, svcName string, b *build.Info, o *types.Bui
 		opts.Secrets = o.Secrets
 	}
 	// add to the build the secrets from the manifest build
-	for id, src := range b.Secrets {
-		opts.Secrets = append(opts.Secrets, fmt.Sprintf("id=%s,src=%s", id, src))
+	secretIDs := make([]string, 0, len(b.Secrets))
+	for id := range b.Secrets {
+		secretIDs = append(secretIDs, id)
+	}
+	sort.Strings(secretIDs)
+	for _, id := range secretIDs {
+		opts.Secrets = append(opts.Secrets, fmt.Sprintf("id=%s,src=%s", id, b.Secrets[id]))
+	}
+	opts.SshSessions = getSSHSessions(b.SSH)
+
+	ignoreRules, err := GetIgnoreRules(b.Context, svcName, b.Ignore)
+	if err != nil {
+		oktetoLog.Warning("The rules of '%s' to exclude files from the build context of service '%s' are not applied: %s", model.IgnoreFilename, svcName, err)
+		ignoreRules = b.Ignore
 	}
+	opts.IgnoreRules = ignoreRules
 
 	outputMode := oktetoLog.GetOutputFormat()
 	if o != nil && o.OutputMode != "" {
@@ -390,6 +538,54 @@ func OptsFromBuildInfo(manifestName, svcName string, b *build.Info, o *types.Bui
 	return opts
 }
 
+// getSSHSessions translates the ssh entries of a build section with the format 'default|<id>[=<socket>|<key>[,<key>]]' into ssh sessions.
+// Entries without a path mount the local ssh agent. Paths can reference environment variables
+func getSSHSessions(ssh []string) []types.BuildSshSession {
+	var result []types.BuildSshSession
+	for _, entry := range ssh {
+		id, targets, _ := strings.Cut(entry, "=")
+		if targets == "" {
+			sock := os.Getenv("SSH_AUTH_SOCK")
+			if sock == "" {
+				oktetoLog.Warning("Skipping ssh '%s' of the build: SSH_AUTH_SOCK is not set", id)
+				continue
+			}
+			result = append(result, types.BuildSshSession{Id: id, Target: sock})
+			continue
+		}
+
+		for _, path := range strings.Split(targets, ",") {
+			target, err := expandSSHPath(path)
+			if err != nil {
+				oktetoLog.Infof("failed to expand ssh path '%s': %s", path, err)
+				continue
+			}
+			if _, err := os.Stat(target); err != nil {
+				oktetoLog.Warning("Skipping ssh '%s' of the build: '%s' not found", id, target)
+				continue
+			}
+			result = append(result, types.BuildSshSession{Id: id, Target: target})
+		}
+	}
+	return result
+}
+
+// expandSSHPath expands the environment variables and the home directory of an ssh socket or key path
+func expandSSHPath(path string) (string, error) {
+	path, err := env.ExpandEnv(strings.TrimSpace(path))
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	return path, nil
+}
+
 // OptsFromBuildInfoForRemoteDeploy returns the options for the remote deploy
 func OptsFromBuildInfoForRemoteDeploy(b *build.Info, o *types.BuildOptions) *types.BuildOptions {
 	opts := &types.BuildOptions{
@@ -443,6 +639,14 @@ func replaceSecretsSourceEnvWithTempFile(fs afero.Fs, secretTempFolder string, b
 			return fmt.Errorf("error reading the csv secret, %w", err)
 		}
 
+		// the content of env secrets is read by buildkit from the environment, there is no file to expand
+		isEnvSecret := false
+		for _, field := range fields {
+			if field == "type=env" {
+				isEnvSecret = true
+			}
+		}
+
 		newFields := make([]string, len(fields))
 		for indx, field := range fields {
 			key, value, found := strings.Cut(field, "=")
@@ -450,7 +654,7 @@ func replaceSecretsSourceEnvWithTempFile(fs afero.Fs, secretTempFolder string, b
 				return fmt.Errorf("secret format error")
 			}
 
-			if key == "src" || key == "source" {
+			if (key == "src" || key == "source") && !isEnvSecret {
 				tempFileName, err := createTempFileWithExpandedEnvsAtSource(fs, value, secretTempFolder)
 				if err != nil {
 					return fmt.Errorf("error creating the temp file with expanded values: %w", err)
@@ -49,6 +49,7 @@ import (
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/moby/buildkit/util/progress/progresswriter"
 	"github.com/moby/term"
+	oktetoBuild "github.com/okteto/okteto/pkg/build"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/pkg/errors"
@@ -83,7 +84,12 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 		dockerfileDir string
 	)
 
+	dockerfile := filepath.Base(buildOptions.File)
 	switch {
+	case oktetoBuild.IsRemoteContext(buildOptions.Path):
+		// the daemon fetches the context, the Dockerfile is a path inside of it
+		remote = buildOptions.Path
+		dockerfile = buildOptions.File
 	case isLocalDir(buildOptions.Path):
 		contextDir = buildOptions.Path
 		dockerfileDir = filepath.Dir(buildOptions.File)
@@ -119,15 +125,13 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 		dockerBuildOptions := dockerTypes.ImageBuildOptions{
 			BuildID:       buildID,
 			Version:       dockerTypes.BuilderBuildKit,
-			Dockerfile:    filepath.Base(buildOptions.File),
+			Dockerfile:    dockerfile,
 			RemoteContext: remote,
 			SessionID:     s.ID(),
 			BuildArgs:     make(map[string]*string),
 			Platform:      buildOptions.Platform,
 		}
-		if buildOptions.Tag != "" {
-			dockerBuildOptions.Tags = append(dockerBuildOptions.Tags, buildOptions.Tag)
-		}
+		dockerBuildOptions.Tags = append(dockerBuildOptions.Tags, getTags(buildOptions.Tag)...)
 
 		dockerBuildOptions.Target = buildOptions.Target
 
@@ -394,9 +398,7 @@ func getDockerOptions(buildOptions *types.BuildOptions) (dockerTypes.ImageBuildO
 		Target:         buildOptions.Target,
 		NoCache:        buildOptions.NoCache,
 	}
-	if buildOptions.Tag != "" {
-		opts.Tags = append(opts.Tags, buildOptions.Tag)
-	}
+	opts.Tags = append(opts.Tags, getTags(buildOptions.Tag)...)
 
 	maxArgFormatParts := 2
 	for _, buildArg := range buildOptions.BuildArgs {
@@ -409,7 +411,8 @@ func getDockerOptions(buildOptions *types.BuildOptions) (dockerTypes.ImageBuildO
 	return opts, nil
 }
 
-func pushImage(ctx context.Context, tag string, client *client.Client) error {
+// pushImage pushes a tag with the docker credentials of its registry. oktetoAuth, if set, are the credentials of the Okteto Registry
+func pushImage(ctx context.Context, tag string, client *client.Client, oktetoAuth *registry.AuthConfig) error {
 	dockerCli, err := command.NewDockerCli()
 	if err != nil {
 		return fmt.Errorf("docker not found")
@@ -425,8 +428,8 @@ func pushImage(ctx context.Context, tag string, client *client.Client) error {
 	}
 
 	authConfig := ResolveAuthConfig(ctx, dockerCli, client, repoInfo)
-	if err != nil {
-		return err
+	if oktetoAuth != nil && reference.Domain(ref) == oktetoAuth.ServerAddress {
+		authConfig = *oktetoAuth
 	}
 
 	encodedAuth, err := registry.EncodeAuthConfig(authConfig)
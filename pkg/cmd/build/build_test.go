@@ -174,6 +174,48 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 				BuildArgs:  []string{namespaceEnvVar.String()},
 			},
 		},
+		{
+			name:        "platforms-from-manifest",
+			serviceName: "service",
+			buildInfo: &build.Info{
+				Platforms: []string{"linux/amd64", "linux/arm64"},
+			},
+			initialOpts: &types.BuildOptions{},
+			mr: mockRegistry{
+				isOktetoRegistry: true,
+				registry:         "okteto.dev",
+				repo:             "movies-service",
+			},
+			isOkteto: true,
+			expected: &types.BuildOptions{
+				OutputMode: oktetoLog.TTYFormat,
+				Tag:        "okteto.dev/movies-service:okteto",
+				BuildArgs:  []string{namespaceEnvVar.String()},
+				Platform:   "linux/amd64,linux/arm64",
+			},
+		},
+		{
+			name:        "platform-flag-overrides-manifest",
+			serviceName: "service",
+			buildInfo: &build.Info{
+				Platforms: []string{"linux/amd64", "linux/arm64"},
+			},
+			initialOpts: &types.BuildOptions{
+				Platform: "linux/arm64",
+			},
+			mr: mockRegistry{
+				isOktetoRegistry: true,
+				registry:         "okteto.dev",
+				repo:             "movies-service",
+			},
+			isOkteto: true,
+			expected: &types.BuildOptions{
+				OutputMode: oktetoLog.TTYFormat,
+				Tag:        "okteto.dev/movies-service:okteto",
+				BuildArgs:  []string{namespaceEnvVar.String()},
+				Platform:   "linux/arm64",
+			},
+		},
 		{
 			name:        "not-okteto-empty-buildInfo",
 			serviceName: "service",
@@ -278,6 +320,7 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 				},
 				Secrets: map[string]string{
 					"mysecret": "source",
+					"npm":      "/home/okteto/.npmrc",
 				},
 				ExportCache: []string{"export-image"},
 			},
@@ -300,7 +343,7 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 					"cache-image",
 				},
 				BuildArgs: []string{namespaceEnvVar.String(), "arg1=value1"},
-				Secrets:   []string{"id=mysecret,src=source"},
+				Secrets:   []string{"id=mysecret,src=source", "id=npm,src=/home/okteto/.npmrc"},
 				ExportCache: []string{
 					"export-image",
 				},
@@ -653,6 +696,15 @@ func Test_replaceSecretsSourceEnvWithTempFile(t *testing.T) {
 			expectedErr:             false,
 			expectedReplacedSecrets: true,
 		},
+		{
+			name:             "env secret",
+			fs:               fakeFs,
+			secretTempFolder: t.TempDir(),
+			buildOptions: &types.BuildOptions{
+				Secrets: []string{"type=env,id=mysecret,src=MY_SECRET"},
+			},
+			expectedErr: false,
+		},
 		{
 			name:             "invalid secret, local file does not exist",
 			fs:               fakeFs,
@@ -820,3 +872,30 @@ func Test_setOutputMode(t *testing.T) {
 		})
 	}
 }
+
+func Test_getSSHSessions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+	t.Setenv("KEYS_DIR", home)
+
+	for _, key := range []string{"github.pem", "gitlab.pem", "bitbucket.pem"} {
+		require.NoError(t, os.WriteFile(filepath.Join(home, key), []byte("key"), 0600))
+	}
+
+	sessions := getSSHSessions([]string{
+		"default",
+		"github=~/github.pem",
+		"gitlab=${KEYS_DIR}/gitlab.pem, ~/bitbucket.pem",
+		"missing=~/missing.pem",
+	})
+	assert.Equal(t, []types.BuildSshSession{
+		{Id: "default", Target: "/tmp/agent.sock"},
+		{Id: "github", Target: filepath.Join(home, "github.pem")},
+		{Id: "gitlab", Target: filepath.Join(home, "gitlab.pem")},
+		{Id: "gitlab", Target: filepath.Join(home, "bitbucket.pem")},
+	}, sessions)
+
+	t.Setenv("SSH_AUTH_SOCK", "")
+	assert.Empty(t, getSSHSessions([]string{"default"}))
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/moby/buildkit/session/sshforward/sshprovider"
 	"github.com/moby/buildkit/util/progress/progressui"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
@@ -49,13 +50,13 @@ const (
 
 type buildWriter struct{}
 
-// getSolveOpt returns the buildkit solve options
-func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface, secretTempFolder string, fs afero.Fs) (*client.SolveOpt, error) {
-
+// expandOktetoRegistryReferences validates the tags of the build and expands the okteto registry references
+// of the tags and the cache images
+func expandOktetoRegistryReferences(buildOptions *types.BuildOptions, okctx OktetoContextInterface) error {
 	if buildOptions.Tag != "" {
 		err := validateImages(okctx, buildOptions.Tag)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -73,6 +74,15 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 		}
 	}
 
+	return nil
+}
+
+// getSolveOpt returns the buildkit solve options
+func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface, secretTempFolder string, fs afero.Fs) (*client.SolveOpt, error) {
+	if err := expandOktetoRegistryReferences(buildOptions, okctx); err != nil {
+		return nil, err
+	}
+
 	// inject secrets to buildkit from temp folder
 	if err := replaceSecretsSourceEnvWithTempFile(afero.NewOsFs(), secretTempFolder, buildOptions); err != nil {
 		return nil, fmt.Errorf("%w: secret should have the format 'id=mysecret,src=/local/secret'", err)
@@ -81,8 +91,7 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 	var localDirs map[string]string
 	var frontendAttrs map[string]string
 
-	if uri, err := url.ParseRequestURI(buildOptions.Path); err != nil || (uri != nil && (uri.Scheme == "" || uri.Host == "")) {
-
+	if !build.IsRemoteContext(buildOptions.Path) {
 		if buildOptions.File == "" {
 			buildOptions.File = filepath.Join(buildOptions.Path, "Dockerfile")
 		}
@@ -97,9 +106,13 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 			"filename": filepath.Base(buildOptions.File),
 		}
 	} else {
+		// the builder fetches the context, the Dockerfile is a path inside of it
 		frontendAttrs = map[string]string{
 			"context": buildOptions.Path,
 		}
+		if buildOptions.File != "" {
+			frontendAttrs["filename"] = buildOptions.File
+		}
 	}
 
 	if buildOptions.Platform != "" {
@@ -111,6 +124,15 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 	if buildOptions.NoCache {
 		frontendAttrs["no-cache"] = ""
 	}
+	if buildOptions.SBOM != "" {
+		frontendAttrs["attest:sbom"] = ""
+	}
+	if buildOptions.Provenance != "" {
+		frontendAttrs["attest:provenance"] = fmt.Sprintf("mode=%s", buildOptions.Provenance)
+		if buildOptions.VCSRevision != "" {
+			frontendAttrs["vcs:revision"] = buildOptions.VCSRevision
+		}
+	}
 
 	frontend := defaultFrontend
 
@@ -148,15 +170,10 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 		attachable = append(attachable, authprovider.NewDockerAuthProvider(dockerCfg))
 	}
 
-	for _, sess := range buildOptions.SshSessions {
-		oktetoLog.Debugf("mounting ssh agent to build from %s with key %s", sess.Target, sess.Id)
-		ssh, err := sshprovider.NewSSHAgentProvider([]sshprovider.AgentConfig{{
-			ID:    sess.Id,
-			Paths: []string{sess.Target},
-		}})
-
+	if len(buildOptions.SshSessions) > 0 {
+		ssh, err := sshprovider.NewSSHAgentProvider(getSSHAgentConfigs(buildOptions.SshSessions))
 		if err != nil {
-			return nil, fmt.Errorf("Failed to mount ssh agent for %s: %w", sess.Id, err)
+			return nil, fmt.Errorf("failed to mount ssh agent: %w", err)
 		}
 		attachable = append(attachable, ssh)
 	}
@@ -177,7 +194,12 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 		CacheExports:  []client.CacheOptionsEntry{},
 	}
 
-	if buildOptions.Tag != "" {
+	// cache warm builds only export the cache of the image
+	switch {
+	case buildOptions.CacheWarm:
+	case buildOptions.LocalExport != nil:
+		opt.Exports = []client.ExportEntry{getLocalExportEntry(buildOptions.LocalExport, buildOptions.Tag)}
+	case buildOptions.Tag != "":
 		opt.Exports = []client.ExportEntry{
 			{
 				Type: "image",
@@ -188,6 +210,11 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 			},
 		}
 	}
+	if buildOptions.Reproducible && len(opt.Exports) > 0 {
+		for k, v := range getReproducibleExportAttrs() {
+			opt.Exports[0].Attrs[k] = v
+		}
+	}
 
 	if buildOptions.LocalOutputPath != "" {
 		opt.Exports = append(opt.Exports, client.ExportEntry{
@@ -208,7 +235,7 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 
 	for _, exportCacheTo := range buildOptions.ExportCache {
 		exportType := "inline"
-		if exportCacheTo != buildOptions.Tag {
+		if exportCacheTo != buildOptions.Tag || buildOptions.CacheWarm {
 			exportType = "registry"
 		}
 		opt.CacheExports = append(
@@ -231,6 +258,26 @@ func getSolveOpt(buildOptions *types.BuildOptions, okctx OktetoContextInterface,
 	return opt, nil
 }
 
+// getSSHAgentConfigs groups the ssh sessions by id. Buildkit only accepts a single ssh provider per session,
+// so every id has to be served by the same provider, using all the sockets or keys defined for it
+func getSSHAgentConfigs(sessions []types.BuildSshSession) []sshprovider.AgentConfig {
+	result := []sshprovider.AgentConfig{}
+	indexByID := map[string]int{}
+	for _, sess := range sessions {
+		oktetoLog.Debugf("mounting ssh agent to build from %s with key %s", sess.Target, sess.Id)
+		if idx, ok := indexByID[sess.Id]; ok {
+			result[idx].Paths = append(result[idx].Paths, sess.Target)
+			continue
+		}
+		indexByID[sess.Id] = len(result)
+		result = append(result, sshprovider.AgentConfig{
+			ID:    sess.Id,
+			Paths: []string{sess.Target},
+		})
+	}
+	return result
+}
+
 func getBuildkitClient(ctx context.Context, okctx OktetoContextInterface) (*client.Client, error) {
 	builder := okctx.GetCurrentBuilder()
 	okctx.UseContextByBuilder()
@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getSSHAgentConfigs(t *testing.T) {
+	sessions := []types.BuildSshSession{
+		{Id: "remote", Target: "/tmp/remote.sock"},
+		{Id: "git", Target: "/keys/github.pem"},
+		{Id: "default", Target: "/tmp/agent.sock"},
+		{Id: "git", Target: "/keys/gitlab.pem"},
+	}
+
+	expected := []sshprovider.AgentConfig{
+		{ID: "remote", Paths: []string{"/tmp/remote.sock"}},
+		{ID: "git", Paths: []string{"/keys/github.pem", "/keys/gitlab.pem"}},
+		{ID: "default", Paths: []string{"/tmp/agent.sock"}},
+	}
+	assert.Equal(t, expected, getSSHAgentConfigs(sessions))
+}
+
+func Test_getSolveOptCacheWarm(t *testing.T) {
+	okCtx := &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "Dockerfile"), []byte("FROM alpine"), 0600))
+
+	opt, err := getSolveOpt(&types.BuildOptions{
+		Path:        dir,
+		Tag:         "okteto/test:1.0",
+		ExportCache: []string{"okteto/test:1.0"},
+		CacheWarm:   true,
+	}, okCtx, "", fs)
+	require.NoError(t, err)
+	assert.Empty(t, opt.Exports)
+	require.Len(t, opt.CacheExports, 1)
+	assert.Equal(t, "registry", opt.CacheExports[0].Type)
+	assert.Equal(t, "okteto/test:1.0", opt.CacheExports[0].Attrs["ref"])
+}
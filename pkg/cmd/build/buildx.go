@@ -0,0 +1,148 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// buildxRunner runs a docker buildx command streaming its output
+type buildxRunner func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+
+// newBuildxRunner returns a runner of the docker CLI, failing if the docker binary is not available
+func newBuildxRunner() (buildxRunner, error) {
+	binPath, err := exec.LookPath("docker")
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the 'docker' binary is not available in your PATH"),
+			Hint: "Install the Docker CLI with the buildx plugin or select another builder with '--builder'",
+		}
+	}
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		oktetoLog.Infof("running docker %s", strings.Join(args, " "))
+		cmd := exec.CommandContext(ctx, binPath, args...)
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		return cmd.Run()
+	}, nil
+}
+
+// buildWithBuildx builds the image with a builder of 'docker buildx' and pushes its tags
+func (ob *OktetoBuilder) buildWithBuildx(ctx context.Context, buildOptions *types.BuildOptions, builderName string, run buildxRunner) error {
+	if err := expandOktetoRegistryReferences(buildOptions, ob.OktetoContext); err != nil {
+		return err
+	}
+	if err := run(ctx, getBuildxArgs(buildOptions, builderName), os.Stdout, os.Stderr); err != nil {
+		oktetoLog.Infof("docker buildx failed: %s", err)
+		hint := "Check the output of 'docker buildx build' for more information"
+		if ob.OktetoContext.IsOktetoCluster() {
+			hint = "Run 'okteto registrytoken install' so 'docker buildx' can push to the Okteto Registry"
+		}
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("error building image with docker buildx: %w", err),
+			Hint: hint,
+		}
+	}
+	return nil
+}
+
+// getBuildxArgs translates the build options into the arguments of 'docker buildx build'
+func getBuildxArgs(buildOptions *types.BuildOptions, builderName string) []string {
+	args := []string{"buildx", "build"}
+	if builderName != "" {
+		args = append(args, "--builder", builderName)
+	}
+
+	progress := "auto"
+	if buildOptions.OutputMode == oktetoLog.PlainFormat || buildOptions.OutputMode == oktetoLog.TTYFormat {
+		progress = buildOptions.OutputMode
+	}
+	args = append(args, "--progress", progress)
+
+	if buildOptions.File != "" {
+		args = append(args, "--file", buildOptions.File)
+	}
+	tags := getTags(buildOptions.Tag)
+	if buildOptions.CacheWarm {
+		tags = nil
+	}
+	for _, tag := range tags {
+		args = append(args, "--tag", tag)
+	}
+	switch {
+	case buildOptions.LocalExport != nil && !buildOptions.CacheWarm:
+		args = append(args, "--output", fmt.Sprintf("type=%s,dest=%s", buildOptions.LocalExport.Type, buildOptions.LocalExport.Dest))
+	case len(tags) != 0:
+		args = append(args, "--push")
+	}
+	if buildOptions.Target != "" {
+		args = append(args, "--target", buildOptions.Target)
+	}
+	if buildOptions.Platform != "" {
+		args = append(args, "--platform", buildOptions.Platform)
+	}
+	if buildOptions.NoCache {
+		args = append(args, "--no-cache")
+	}
+	for _, arg := range buildOptions.BuildArgs {
+		args = append(args, "--build-arg", arg)
+	}
+	for _, secret := range buildOptions.Secrets {
+		args = append(args, "--secret", secret)
+	}
+	for _, s := range buildOptions.SshSessions {
+		args = append(args, "--ssh", fmt.Sprintf("%s=%s", s.Id, s.Target))
+	}
+	for _, h := range buildOptions.ExtraHosts {
+		args = append(args, "--add-host", fmt.Sprintf("%s:%s", h.Hostname, h.IP))
+	}
+	for _, cacheFrom := range buildOptions.CacheFrom {
+		args = append(args, "--cache-from", fmt.Sprintf("type=registry,ref=%s", cacheFrom))
+	}
+	for _, exportCache := range buildOptions.ExportCache {
+		args = append(args, "--cache-to", fmt.Sprintf("type=registry,ref=%s,mode=max", exportCache))
+	}
+	if buildOptions.SBOM != "" {
+		args = append(args, "--sbom=true")
+	}
+	if buildOptions.Provenance != "" {
+		args = append(args, fmt.Sprintf("--provenance=mode=%s", buildOptions.Provenance))
+	}
+
+	path := buildOptions.Path
+	if path == "" {
+		path = "."
+	}
+	return append(args, path)
+}
+
+// getTags returns the tags of a comma separated list of images
+func getTags(images string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(images, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
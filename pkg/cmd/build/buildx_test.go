@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBuildxArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     *types.BuildOptions
+		builderName string
+		expected    []string
+	}{
+		{
+			name:     "without tag",
+			options:  &types.BuildOptions{},
+			expected: []string{"buildx", "build", "--progress", "auto", "."},
+		},
+		{
+			name: "cache warm",
+			options: &types.BuildOptions{
+				Tag:         "registry.okteto.dev/ns/api:1.0",
+				ExportCache: []string{"registry.okteto.dev/ns/api:cache"},
+				CacheWarm:   true,
+			},
+			expected: []string{
+				"buildx", "build", "--progress", "auto",
+				"--cache-to", "type=registry,ref=registry.okteto.dev/ns/api:cache,mode=max",
+				".",
+			},
+		},
+		{
+			name: "all options",
+			options: &types.BuildOptions{
+				Path:        "api",
+				File:        "api/Dockerfile",
+				Tag:         "registry.okteto.dev/ns/api:1.0,registry.okteto.dev/ns/api:okteto",
+				Target:      "prod",
+				Platform:    "linux/amd64,linux/arm64",
+				OutputMode:  "plain",
+				NoCache:     true,
+				BuildArgs:   []string{"VERSION=1.0"},
+				Secrets:     []string{"id=npm,src=.npmrc"},
+				SshSessions: []types.BuildSshSession{{Id: "default", Target: "/tmp/agent.sock"}},
+				ExtraHosts:  []types.HostMap{{Hostname: "db", IP: "10.0.0.1"}},
+				CacheFrom:   []string{"registry.okteto.dev/ns/api:cache"},
+				ExportCache: []string{"registry.okteto.dev/ns/api:cache"},
+				SBOM:        "spdx",
+			},
+			builderName: "remote",
+			expected: []string{
+				"buildx", "build", "--builder", "remote",
+				"--progress", "plain",
+				"--file", "api/Dockerfile",
+				"--tag", "registry.okteto.dev/ns/api:1.0",
+				"--tag", "registry.okteto.dev/ns/api:okteto",
+				"--push",
+				"--target", "prod",
+				"--platform", "linux/amd64,linux/arm64",
+				"--no-cache",
+				"--build-arg", "VERSION=1.0",
+				"--secret", "id=npm,src=.npmrc",
+				"--ssh", "default=/tmp/agent.sock",
+				"--add-host", "db:10.0.0.1",
+				"--cache-from", "type=registry,ref=registry.okteto.dev/ns/api:cache",
+				"--cache-to", "type=registry,ref=registry.okteto.dev/ns/api:cache,mode=max",
+				"--sbom=true",
+				"api",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getBuildxArgs(tt.options, tt.builderName))
+		})
+	}
+}
+
+func TestBuildWithBuildx(t *testing.T) {
+	ob := &OktetoBuilder{
+		OktetoContext: &okteto.ContextStateless{
+			Store: &okteto.ContextStore{
+				Contexts: map[string]*okteto.Context{
+					"test": {
+						Namespace: "test",
+						Registry:  "registry.okteto.dev",
+						IsOkteto:  true,
+					},
+				},
+				CurrentContext: "test",
+			},
+		},
+	}
+
+	var args []string
+	run := func(_ context.Context, a []string, _, _ io.Writer) error {
+		args = a
+		return nil
+	}
+	require.NoError(t, ob.buildWithBuildx(context.Background(), &types.BuildOptions{Tag: "okteto.dev/api:okteto"}, "", run))
+	assert.Contains(t, args, "registry.okteto.dev/test/api:okteto")
+
+	failing := func(context.Context, []string, io.Writer, io.Writer) error {
+		return errors.New("exit status 1")
+	}
+	err := ob.buildWithBuildx(context.Background(), &types.BuildOptions{Tag: "okteto.dev/api:okteto"}, "", failing)
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Contains(t, userErr.Hint, "okteto registrytoken install")
+}
+
+func TestGetTags(t *testing.T) {
+	assert.Equal(t, []string{}, getTags(""))
+	assert.Equal(t, []string{"a:1", "a:2"}, getTags("a:1, a:2,"))
+}
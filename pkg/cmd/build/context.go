@@ -14,6 +14,7 @@
 package build
 
 import (
+	"github.com/okteto/okteto/pkg/okteto"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -33,4 +34,8 @@ type OktetoContextInterface interface {
 	UseContextByBuilder()
 	GetTokenByContextName(name string) (string, error)
 	GetRegistryURL() string
+	GetRegistryTemplates() map[string]string
+	GetRegistryMirrors() map[string][]string
+	GetRegistryRetry() *okteto.RegistryRetry
+	GetBuildBackend() string
 }
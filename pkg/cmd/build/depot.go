@@ -135,8 +135,8 @@ func (db *depotBuilder) Run(ctx context.Context, buildOptions *types.BuildOption
 		return err
 	}
 
-	if buildOptions.File != "" {
-		buildOptions.File, err = GetDockerfile(buildOptions.File, db.okCtx)
+	if hasLocalDockerfile(buildOptions) {
+		buildOptions.File, err = GetDockerfile(buildOptions, db.okCtx)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/buildkit/client"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// ExportTypeOCI writes the image as a tarball with an OCI image layout
+	ExportTypeOCI = "oci"
+
+	// ExportTypeDocker writes the image as a tarball that can be loaded with 'docker load'
+	ExportTypeDocker = "docker"
+)
+
+var errExportNotSupportedByDockerDaemon = oktetoErrors.UserError{
+	E:    fmt.Errorf("exporting images to a local file is not supported by your local docker daemon"),
+	Hint: "Run 'okteto context' to select an Okteto context with a BuildKit builder",
+}
+
+// IsLocalExport returns true if the value of the '--output' flag defines where to export the image instead of an output format
+func IsLocalExport(value string) bool {
+	return strings.Contains(value, "=")
+}
+
+// ParseLocalExport parses the exporters accepted by 'docker buildx build --output': 'type=oci,dest=image.tar' or 'type=docker,dest=image.tar'.
+// The destination is made absolute because the manifest builds run from the folder of the manifest
+func ParseLocalExport(value string) (*types.LocalExport, error) {
+	hint := fmt.Sprintf("Use the format 'type=%s,dest=image.tar' or 'type=%s,dest=image.tar'", ExportTypeOCI, ExportTypeDocker)
+	result := &types.LocalExport{}
+	for _, field := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid output '%s': '%s' is not a key=value pair", value, field),
+				Hint: hint,
+			}
+		}
+		switch key {
+		case "type":
+			result.Type = val
+		case "dest":
+			result.Dest = val
+		default:
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid output '%s': unknown key '%s'", value, key),
+				Hint: hint,
+			}
+		}
+	}
+
+	if result.Type != ExportTypeOCI && result.Type != ExportTypeDocker {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid output '%s': type '%s' is not supported", value, result.Type),
+			Hint: hint,
+		}
+	}
+	if result.Dest == "" {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid output '%s': 'dest' is required", value),
+			Hint: hint,
+		}
+	}
+	dest, err := filepath.Abs(result.Dest)
+	if err != nil {
+		return nil, err
+	}
+	result.Dest = dest
+	return result, nil
+}
+
+// getLocalExportEntry returns the exporter that downloads the image from the builder to the local tarball instead of pushing it
+func getLocalExportEntry(export *types.LocalExport, tag string) client.ExportEntry {
+	attrs := map[string]string{}
+	if tag != "" {
+		attrs["name"] = tag
+	}
+	return client.ExportEntry{
+		Type:  export.Type,
+		Attrs: attrs,
+		Output: func(map[string]string) (io.WriteCloser, error) {
+			return os.Create(export.Dest)
+		},
+	}
+}
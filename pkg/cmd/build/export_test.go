@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLocalExport(t *testing.T) {
+	assert.True(t, IsLocalExport("type=oci,dest=image.tar"))
+	assert.False(t, IsLocalExport("json"))
+	assert.False(t, IsLocalExport(""))
+}
+
+func TestParseLocalExport(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	export, err := ParseLocalExport("type=oci,dest=image.tar")
+	require.NoError(t, err)
+	assert.Equal(t, &types.LocalExport{Type: ExportTypeOCI, Dest: filepath.Join(wd, "image.tar")}, export)
+
+	export, err = ParseLocalExport("dest=/tmp/image.tar, type=docker")
+	require.NoError(t, err)
+	assert.Equal(t, &types.LocalExport{Type: ExportTypeDocker, Dest: "/tmp/image.tar"}, export)
+
+	tests := []string{
+		"type=local,dest=out",
+		"type=oci",
+		"type=oci,dest=image.tar,push=true",
+		"type=oci,image.tar",
+	}
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseLocalExport(value)
+			require.ErrorAs(t, err, &oktetoErrors.UserError{})
+		})
+	}
+}
+
+func Test_getSolveOptLocalExport(t *testing.T) {
+	okCtx := &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "Dockerfile"), []byte("FROM alpine"), 0600))
+	dest := filepath.Join(dir, "image.tar")
+
+	opt, err := getSolveOpt(&types.BuildOptions{Path: dir, Tag: "okteto/test:1.0", LocalExport: &types.LocalExport{Type: ExportTypeOCI, Dest: dest}}, okCtx, "", fs)
+	require.NoError(t, err)
+	require.Len(t, opt.Exports, 1)
+	assert.Equal(t, ExportTypeOCI, opt.Exports[0].Type)
+	assert.Equal(t, map[string]string{"name": "okteto/test:1.0"}, opt.Exports[0].Attrs)
+
+	w, err := opt.Exports[0].Output(nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.FileExists(t, dest)
+}
+
+func Test_getBuildxArgsLocalExport(t *testing.T) {
+	args := getBuildxArgs(&types.BuildOptions{Path: ".", Tag: "okteto/test:1.0", LocalExport: &types.LocalExport{Type: ExportTypeDocker, Dest: "/tmp/image.tar"}}, "")
+	assert.Contains(t, args, "type=docker,dest=/tmp/image.tar")
+	assert.NotContains(t, args, "--push")
+}
+
+func Test_RunLocalExportWithDockerDaemon(t *testing.T) {
+	okCtx := &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+	ob := NewOktetoBuilder(okCtx, afero.NewMemMapFs())
+	err := ob.Run(context.Background(), &types.BuildOptions{Tag: "okteto/test:1.0", LocalExport: &types.LocalExport{Type: ExportTypeOCI, Dest: "image.tar"}}, io.NewIOController())
+	require.ErrorIs(t, err, errExportNotSupportedByDockerDaemon)
+}
@@ -20,10 +20,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/pkg/errors"
 )
 
@@ -31,9 +33,15 @@ const (
 	defaultDockerIgnore = ".dockerignore"
 )
 
-// GetDockerfile returns the dockerfile with the cache and registry translations
-func GetDockerfile(dockerFile string, okCtx OktetoContextInterface) (string, error) {
-	file, err := getTranslatedDockerFile(dockerFile, okCtx)
+// hasLocalDockerfile returns true if the Dockerfile of the build is in the local filesystem and can be translated.
+// The Dockerfile of a remote context is a path inside of the context fetched by the builder
+func hasLocalDockerfile(buildOptions *types.BuildOptions) bool {
+	return buildOptions.File != "" && !build.IsRemoteContext(buildOptions.Path)
+}
+
+// GetDockerfile returns the dockerfile of the build with the cache and registry translations
+func GetDockerfile(buildOptions *types.BuildOptions, okCtx OktetoContextInterface) (string, error) {
+	file, err := getTranslatedDockerFile(buildOptions, okCtx)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create temporary build folder")
 	}
@@ -41,7 +49,8 @@ func GetDockerfile(dockerFile string, okCtx OktetoContextInterface) (string, err
 	return file, nil
 }
 
-func getTranslatedDockerFile(filename string, okCtx OktetoContextInterface) (string, error) {
+func getTranslatedDockerFile(buildOptions *types.BuildOptions, okCtx OktetoContextInterface) (string, error) {
+	filename := buildOptions.File
 	file, err := os.Open(filename)
 	if err != nil {
 		return "", err
@@ -67,9 +76,12 @@ func getTranslatedDockerFile(filename string, okCtx OktetoContextInterface) (str
 	datawriter := bufio.NewWriter(tmpFile)
 	defer datawriter.Flush()
 
+	mirrorImage := getBaseImageMirrorResolver(okCtx)
+	stages := map[string]bool{}
 	for scanner.Scan() {
 		line := scanner.Text()
 		translatedLine := translateOktetoRegistryImage(line, okCtx)
+		translatedLine = translateBaseImageMirror(translatedLine, stages, mirrorImage)
 		_, err = datawriter.WriteString(translatedLine + "\n")
 		if err != nil {
 			return "", fmt.Errorf("failed to write dockerfile: %w", err)
@@ -79,7 +91,7 @@ func getTranslatedDockerFile(filename string, okCtx OktetoContextInterface) (str
 		return "", err
 	}
 
-	if err := copyDockerIgnore(filename, tmpFile.Name()); err != nil {
+	if err := writeDockerIgnore(filename, tmpFile.Name(), buildOptions.Path, buildOptions.IgnoreRules); err != nil {
 		return "", err
 	}
 
@@ -108,7 +120,47 @@ func translateOktetoRegistryImage(input string, okCtx OktetoContextInterface) st
 
 }
 
-func copyDockerIgnore(originalPath, translatedPath string) error {
+// getBaseImageMirrorResolver returns the function that resolves the base images from the registry mirrors
+// of the context. It returns nil when the context has no registry mirrors
+func getBaseImageMirrorResolver(okCtx OktetoContextInterface) func(image string) string {
+	if len(okCtx.GetRegistryMirrors()) == 0 {
+		return nil
+	}
+	cfg := GetRegistryConfigFromOktetoConfig(okCtx)
+	return func(image string) string {
+		return registry.GetMirroredImage(cfg, image)
+	}
+}
+
+// translateBaseImageMirror replaces the base image of a FROM instruction with its reference in a registry mirror.
+// Stages and images defined by build args are not translated. stages collects the names of the stages of the Dockerfile
+func translateBaseImageMirror(line string, stages map[string]bool, mirrorImage func(image string) string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || !strings.EqualFold(fields[0], "FROM") {
+		return line
+	}
+	idx := 1
+	for idx < len(fields) && strings.HasPrefix(fields[idx], "--") {
+		idx++
+	}
+	if idx >= len(fields) {
+		return line
+	}
+	image := fields[idx]
+	isStage := stages[strings.ToLower(image)]
+	if idx+2 < len(fields) && strings.EqualFold(fields[idx+1], "AS") {
+		stages[strings.ToLower(fields[idx+2])] = true
+	}
+
+	if mirrorImage == nil || isStage || image == "scratch" || strings.Contains(image, "$") {
+		return line
+	}
+	return strings.Replace(line, image, mirrorImage(image), 1)
+}
+
+// writeDockerIgnore writes the .dockerignore of the translated Dockerfile. The builder reads it instead of the
+// .dockerignore of the context, so the ignore rules of the build are appended to the rules of the original one
+func writeDockerIgnore(originalPath, translatedPath, contextPath string, ignoreRules []string) error {
 	originalPath, err := filepath.Abs(originalPath)
 	if err != nil {
 		oktetoLog.Infof("could not load original dockerfile path")
@@ -121,25 +173,42 @@ func copyDockerIgnore(originalPath, translatedPath string) error {
 	translatedDir := filepath.Dir(translatedPath)
 	translatedName := filepath.Base(translatedPath)
 	newPath := filepath.Join(translatedDir, fmt.Sprintf("%s%s", translatedName, defaultDockerIgnore))
-	if fs, err := os.Stat(originalDockerIgnore); err == nil && !fs.IsDir() {
-		err := copyFile(originalDockerIgnore, newPath)
+	if !isFile(originalDockerIgnore) {
+		originalDockerIgnore = filepath.Join(originalDir, defaultDockerIgnore)
+	}
+	if !isFile(originalDockerIgnore) {
+		oktetoLog.Infof("could not detect any .dockerignore on %s", originalDir)
+		if len(ignoreRules) == 0 {
+			return nil
+		}
+		originalDockerIgnore = filepath.Join(contextPath, defaultDockerIgnore)
+	}
+
+	if len(ignoreRules) == 0 {
+		return copyFile(originalDockerIgnore, newPath)
+	}
+
+	var content []byte
+	if isFile(originalDockerIgnore) {
+		content, err = os.ReadFile(originalDockerIgnore)
 		if err != nil {
+			oktetoLog.Infof("could not read %s: %s", originalDockerIgnore, err)
 			return err
 		}
-	} else {
-		originalDockerIgnore := filepath.Join(originalDir, defaultDockerIgnore)
-		if fs, err := os.Stat(originalDockerIgnore); err == nil && !fs.IsDir() {
-			err := copyFile(originalDockerIgnore, newPath)
-			if err != nil {
-				return err
-			}
-		} else {
-			oktetoLog.Infof("could not detect any .dockerignore on %s", originalDir)
-		}
+	}
+	content = append(content, fmt.Sprintf("\n%s\n", strings.Join(ignoreRules, "\n"))...)
+	if err := os.WriteFile(newPath, content, 0600); err != nil {
+		oktetoLog.Infof("error creating %s: %s", newPath, err)
+		return err
 	}
 	return nil
 }
 
+func isFile(path string) bool {
+	fs, err := os.Stat(path)
+	return err == nil && !fs.IsDir()
+}
+
 func copyFile(orig, dest string) error {
 	input, err := os.ReadFile(orig)
 	if err != nil {
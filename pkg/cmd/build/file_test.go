@@ -14,9 +14,13 @@
 package build
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_translateOktetoRegistryImage(t *testing.T) {
@@ -80,3 +84,110 @@ func Test_translateOktetoRegistryImage(t *testing.T) {
 		})
 	}
 }
+
+func Test_writeDockerIgnore(t *testing.T) {
+	tests := []struct {
+		files       map[string]string
+		name        string
+		expected    string
+		ignoreRules []string
+		exists      bool
+	}{
+		{
+			name: "no dockerignore",
+		},
+		{
+			name:     "dockerignore of the Dockerfile folder",
+			files:    map[string]string{"app/.dockerignore": "node_modules\n"},
+			expected: "node_modules\n",
+			exists:   true,
+		},
+		{
+			name: "dockerignore of the Dockerfile",
+			files: map[string]string{
+				"app/.dockerignore":           "node_modules\n",
+				"app/Dockerfile.dockerignore": "dist\n",
+			},
+			expected: "dist\n",
+			exists:   true,
+		},
+		{
+			name:        "ignore rules appended to the dockerignore",
+			files:       map[string]string{"app/.dockerignore": "node_modules\n"},
+			ignoreRules: []string{"services/api", "services/worker"},
+			expected:    "node_modules\n\nservices/api\nservices/worker\n",
+			exists:      true,
+		},
+		{
+			name:        "ignore rules appended to the dockerignore of the context",
+			files:       map[string]string{".dockerignore": ".git\n"},
+			ignoreRules: []string{"services/api"},
+			expected:    ".git\n\nservices/api\n",
+			exists:      true,
+		},
+		{
+			name:        "ignore rules without dockerignore",
+			ignoreRules: []string{"services/api"},
+			expected:    "\nservices/api\n",
+			exists:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "app"), 0700))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "app", "Dockerfile"), []byte("FROM alpine"), 0600))
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+			}
+			translated := filepath.Join(t.TempDir(), "buildkit-123")
+
+			err := writeDockerIgnore(filepath.Join(dir, "app", "Dockerfile"), translated, dir, tt.ignoreRules)
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(translated + ".dockerignore")
+			if !tt.exists {
+				assert.ErrorIs(t, err, os.ErrNotExist)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func Test_translateBaseImageMirror(t *testing.T) {
+	mirrorImage := func(image string) string {
+		if image == "node:18" || image == "golang:1.21" {
+			return "mirror.example.com/library/" + image
+		}
+		return image
+	}
+	lines := []string{
+		"FROM golang:1.21 AS builder",
+		"RUN go build",
+		"FROM --platform=$BUILDPLATFORM node:18 as assets",
+		"FROM builder AS test",
+		"FROM ${BASE_IMAGE}",
+		"FROM scratch",
+		"FROM alpine:3.18",
+		"COPY --from=assets /app /app",
+	}
+	expected := []string{
+		"FROM mirror.example.com/library/golang:1.21 AS builder",
+		"RUN go build",
+		"FROM --platform=$BUILDPLATFORM mirror.example.com/library/node:18 as assets",
+		"FROM builder AS test",
+		"FROM ${BASE_IMAGE}",
+		"FROM scratch",
+		"FROM alpine:3.18",
+		"COPY --from=assets /app /app",
+	}
+	stages := map[string]bool{}
+	for i, line := range lines {
+		assert.Equal(t, expected[i], translateBaseImageMirror(line, stages, mirrorImage))
+	}
+	assert.Equal(t, map[string]bool{"builder": true, "assets": true, "test": true}, stages)
+
+	assert.Equal(t, "FROM node:18", translateBaseImageMirror("FROM node:18", map[string]bool{}, nil))
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/ignore"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// buildIgnoreSection is the section of the .oktetoignore file with the rules of the build contexts
+const buildIgnoreSection = "build"
+
+// GetIgnoreRules returns the rules that exclude files from the build context of a service: the 'build' and
+// 'build.<service>' sections of the .oktetoignore file of the context followed by the 'ignore' list of the service
+func GetIgnoreRules(contextPath, svcName string, ignoreList []string) ([]string, error) {
+	if build.IsRemoteContext(contextPath) {
+		return nil, nil
+	}
+	ig, err := ignore.NewFromFile(filepath.Join(contextPath, model.IgnoreFilename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore file: %w", err)
+	}
+	rules, err := ig.Rules(buildIgnoreSection, fmt.Sprintf("%s.%s", buildIgnoreSection, svcName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ignore rules for %s: %w", svcName, err)
+	}
+	return append(rules, ignoreList...), nil
+}
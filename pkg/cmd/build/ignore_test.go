@@ -0,0 +1,54 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIgnoreRules(t *testing.T) {
+	dir := t.TempDir()
+	content := `.git
+[deploy]
+chart
+[build]
+docs
+[build.api]
+services/worker
+[build.worker]
+services/api
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".oktetoignore"), []byte(content), 0600))
+
+	rules, err := GetIgnoreRules(dir, "api", []string{"tmp"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "services/worker", "tmp"}, rules)
+
+	rules, err = GetIgnoreRules(dir, "frontend", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs"}, rules)
+
+	rules, err = GetIgnoreRules(t.TempDir(), "api", []string{"tmp"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tmp"}, rules)
+
+	rules, err = GetIgnoreRules("https://github.com/okteto/movies.git", "api", []string{"tmp"})
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+}
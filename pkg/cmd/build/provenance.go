@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// ProvenanceModeMin records the sources and the build parameters of the image
+	ProvenanceModeMin = "min"
+
+	// ProvenanceModeMax also records the build steps and the materials used to build the image
+	ProvenanceModeMax = "max"
+)
+
+var errProvenanceNotSupportedByDockerDaemon = oktetoErrors.UserError{
+	E:    fmt.Errorf("provenance attestations are not supported by your local docker daemon"),
+	Hint: "Run 'okteto context' to select an Okteto context with a BuildKit builder",
+}
+
+// ParseProvenanceMode returns the mode of the provenance attestation from the values accepted by 'docker buildx build --provenance':
+// 'mode=max', 'max', 'true' or 'false'. It returns an empty mode if provenance is disabled
+func ParseProvenanceMode(value string) (string, error) {
+	value = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "mode=")
+	switch value {
+	case "":
+		return "", nil
+	case ProvenanceModeMin, ProvenanceModeMax:
+		return value, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid provenance mode '%s'", value),
+			Hint: fmt.Sprintf("Supported values: mode=%s, mode=%s", ProvenanceModeMin, ProvenanceModeMax),
+		}
+	}
+	if !enabled {
+		return "", nil
+	}
+	return ProvenanceModeMin, nil
+}
+
+// showProvenance shows where the provenance attached to the pushed image can be found
+func showProvenance(buildOptions *types.BuildOptions, ioCtrl *io.Controller) {
+	if buildOptions.Provenance == "" || buildOptions.Tag == "" || buildOptions.LocalExport != nil {
+		return
+	}
+	tag := strings.Split(buildOptions.Tag, ",")[0]
+	ioCtrl.Out().Infof("Provenance attached to '%s' as a SLSA attestation", tag)
+	ioCtrl.Out().Infof("Inspect it running: docker buildx imagetools inspect %s --format '{{ json .Provenance }}'", tag)
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	ioCtrl "github.com/okteto/okteto/pkg/log/io"
+	"google.golang.org/grpc"
+)
+
+const (
+	// maxQueueHistorySamples is the number of completed builds used to estimate the duration of a build
+	maxQueueHistorySamples = 20
+
+	// queueInfoTimeout is the max time spent retrieving the builder queue before starting a build
+	queueInfoTimeout = 3 * time.Second
+)
+
+// historyClient lists the builds of the builder
+type historyClient interface {
+	ListenBuildHistory(ctx context.Context, in *controlapi.BuildHistoryRequest, opts ...grpc.CallOption) (controlapi.Control_ListenBuildHistoryClient, error)
+}
+
+// RunningBuild is a build currently running in the builder
+type RunningBuild struct {
+	StartedAt      time.Time `json:"startedAt" yaml:"startedAt"`
+	Ref            string    `json:"ref" yaml:"ref"`
+	Image          string    `json:"image,omitempty" yaml:"image,omitempty"`
+	TotalSteps     int32     `json:"totalSteps" yaml:"totalSteps"`
+	CompletedSteps int32     `json:"completedSteps" yaml:"completedSteps"`
+}
+
+// BuildQueue is the backlog of the builder for the current user
+type BuildQueue struct {
+	Running []RunningBuild `json:"running" yaml:"running"`
+	// AverageDuration is the average duration of the last completed builds
+	AverageDuration time.Duration `json:"averageDuration" yaml:"averageDuration"`
+}
+
+// EstimatedWait returns the estimated time until the running builds are completed,
+// based on the average duration of the last builds
+func (q *BuildQueue) EstimatedWait(now time.Time) time.Duration {
+	var wait time.Duration
+	for _, b := range q.Running {
+		remaining := q.AverageDuration - now.Sub(b.StartedAt)
+		if remaining > wait {
+			wait = remaining
+		}
+	}
+	return wait
+}
+
+// GetBuildQueue returns the builds running in the builder of the okteto context
+func GetBuildQueue(ctx context.Context, okctx OktetoContextInterface) (*BuildQueue, error) {
+	if okctx.GetCurrentBuilder() == "" {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("there is no builder configured for the context '%s'", okctx.GetCurrentName()),
+			Hint: "Configure a builder endpoint with 'okteto context --builder BUILDKIT_URL'",
+		}
+	}
+	c, err := getBuildkitClient(ctx, okctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	return getBuildQueue(ctx, c.ControlClient())
+}
+
+func getBuildQueue(ctx context.Context, hc historyClient) (*BuildQueue, error) {
+	stream, err := hc.ListenBuildHistory(ctx, &controlapi.BuildHistoryRequest{EarlyExit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the builder queue: %w", err)
+	}
+
+	queue := &BuildQueue{
+		Running: []RunningBuild{},
+	}
+	var completed []*controlapi.BuildHistoryRecord
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to get the builder queue: %w", err)
+		}
+		if event.Type == controlapi.BuildHistoryEventType_DELETED || event.Record == nil || event.Record.CreatedAt == nil {
+			continue
+		}
+		r := event.Record
+		if r.CompletedAt == nil {
+			queue.Running = append(queue.Running, RunningBuild{
+				Ref:            r.Ref,
+				Image:          getRecordImage(r),
+				StartedAt:      *r.CreatedAt,
+				TotalSteps:     r.NumTotalSteps,
+				CompletedSteps: r.NumCompletedSteps,
+			})
+			continue
+		}
+		if r.Error == nil {
+			completed = append(completed, r)
+		}
+	}
+
+	sort.Slice(queue.Running, func(i, j int) bool {
+		return queue.Running[i].StartedAt.Before(queue.Running[j].StartedAt)
+	})
+	queue.AverageDuration = getAverageDuration(completed)
+	return queue, nil
+}
+
+// getAverageDuration returns the average duration of the most recent completed builds
+func getAverageDuration(completed []*controlapi.BuildHistoryRecord) time.Duration {
+	if len(completed) == 0 {
+		return 0
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].CompletedAt.After(*completed[j].CompletedAt)
+	})
+	if len(completed) > maxQueueHistorySamples {
+		completed = completed[:maxQueueHistorySamples]
+	}
+	var total time.Duration
+	for _, r := range completed {
+		total += r.CompletedAt.Sub(*r.CreatedAt)
+	}
+	return total / time.Duration(len(completed))
+}
+
+// getRecordImage returns the image exported by a build, if any
+func getRecordImage(r *controlapi.BuildHistoryRecord) string {
+	for _, e := range r.Exporters {
+		if name, ok := e.Attrs["name"]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// showBuildQueue shows the builds running in the builder before starting a build.
+// The builder is shared, so they might belong to other users
+func showBuildQueue(ctx context.Context, hc historyClient, ioCtrl *ioCtrl.Controller) {
+	ctx, cancel := context.WithTimeout(ctx, queueInfoTimeout)
+	defer cancel()
+
+	queue, err := getBuildQueue(ctx, hc)
+	if err != nil {
+		ioCtrl.Logger().Infof("could not get the builder queue: %s", err)
+		return
+	}
+	if len(queue.Running) == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("%d builds running in the builder", len(queue.Running))
+	if wait := queue.EstimatedWait(time.Now()); wait > 0 {
+		msg = fmt.Sprintf("%s, estimated time until they complete: %s", msg, wait.Round(time.Second))
+	}
+	ioCtrl.Out().Infof("%s", msg)
+}
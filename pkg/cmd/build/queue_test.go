@@ -0,0 +1,101 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeHistoryStream struct {
+	controlapi.Control_ListenBuildHistoryClient
+	events []*controlapi.BuildHistoryEvent
+}
+
+func (f *fakeHistoryStream) Recv() (*controlapi.BuildHistoryEvent, error) {
+	if len(f.events) == 0 {
+		return nil, io.EOF
+	}
+	e := f.events[0]
+	f.events = f.events[1:]
+	return e, nil
+}
+
+type fakeHistoryClient struct {
+	err    error
+	events []*controlapi.BuildHistoryEvent
+}
+
+func (f *fakeHistoryClient) ListenBuildHistory(_ context.Context, in *controlapi.BuildHistoryRequest, _ ...grpc.CallOption) (controlapi.Control_ListenBuildHistoryClient, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &fakeHistoryStream{events: f.events}, nil
+}
+
+func newHistoryEvent(ref string, created time.Time, duration time.Duration) *controlapi.BuildHistoryEvent {
+	r := &controlapi.BuildHistoryRecord{
+		Ref:       ref,
+		CreatedAt: &created,
+		Exporters: []*controlapi.Exporter{
+			{Attrs: map[string]string{"name": "okteto.dev/" + ref}},
+		},
+	}
+	if duration > 0 {
+		completed := created.Add(duration)
+		r.CompletedAt = &completed
+	}
+	return &controlapi.BuildHistoryEvent{Record: r}
+}
+
+func TestGetBuildQueue(t *testing.T) {
+	now := time.Now()
+	hc := &fakeHistoryClient{
+		events: []*controlapi.BuildHistoryEvent{
+			newHistoryEvent("completed-1", now.Add(-time.Hour), 2*time.Minute),
+			newHistoryEvent("running-2", now.Add(-30*time.Second), 0),
+			newHistoryEvent("completed-2", now.Add(-30*time.Minute), 4*time.Minute),
+			newHistoryEvent("running-1", now.Add(-time.Minute), 0),
+			{Type: controlapi.BuildHistoryEventType_DELETED, Record: &controlapi.BuildHistoryRecord{Ref: "deleted"}},
+		},
+	}
+
+	queue, err := getBuildQueue(context.Background(), hc)
+	require.NoError(t, err)
+	require.Len(t, queue.Running, 2)
+	assert.Equal(t, "running-1", queue.Running[0].Ref)
+	assert.Equal(t, "okteto.dev/running-1", queue.Running[0].Image)
+	assert.Equal(t, "running-2", queue.Running[1].Ref)
+	assert.Equal(t, 3*time.Minute, queue.AverageDuration)
+	assert.Equal(t, 150*time.Second, queue.EstimatedWait(now))
+}
+
+func TestGetBuildQueueError(t *testing.T) {
+	_, err := getBuildQueue(context.Background(), &fakeHistoryClient{err: assert.AnError})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestEstimatedWaitWithoutHistory(t *testing.T) {
+	queue := &BuildQueue{
+		Running: []RunningBuild{{Ref: "running", StartedAt: time.Now()}},
+	}
+	assert.Equal(t, time.Duration(0), queue.EstimatedWait(time.Now()))
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/repository"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// sourceDateEpochArg is the build arg used by buildkit to set the timestamps of the image
+	sourceDateEpochArg = "SOURCE_DATE_EPOCH"
+)
+
+// setReproducibleBuildArgs sets SOURCE_DATE_EPOCH, unless it is already a build arg, and sorts the build args
+// so the image metadata doesn't depend on the order they were defined
+func setReproducibleBuildArgs(buildOptions *types.BuildOptions) {
+	found := false
+	for _, arg := range buildOptions.BuildArgs {
+		if strings.HasPrefix(arg, fmt.Sprintf("%s=", sourceDateEpochArg)) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		epoch := getSourceDateEpoch(buildOptions.Path)
+		oktetoLog.Infof("reproducible build: setting %s=%s", sourceDateEpochArg, epoch)
+		buildOptions.BuildArgs = append(buildOptions.BuildArgs, fmt.Sprintf("%s=%s", sourceDateEpochArg, epoch))
+	}
+	sort.Strings(buildOptions.BuildArgs)
+}
+
+// getSourceDateEpoch returns the SOURCE_DATE_EPOCH env var if set, the commit date of the last commit
+// of the repository containing the build context or the unix epoch
+func getSourceDateEpoch(contextPath string) string {
+	if value := os.Getenv(sourceDateEpochArg); value != "" {
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return value
+		}
+		oktetoLog.Warning("'%s' is not a valid value for %s, it must be a unix timestamp", value, sourceDateEpochArg)
+	}
+
+	if contextPath == "" {
+		contextPath = "."
+	}
+	repo, err := repository.FindTopLevelGitRepoFromPath(contextPath)
+	if err != nil {
+		oktetoLog.Infof("could not find the repository of the build context: %s", err)
+		return "0"
+	}
+	head, err := repo.Head()
+	if err != nil {
+		oktetoLog.Infof("could not get the repository head: %s", err)
+		return "0"
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		oktetoLog.Infof("could not get the last commit: %s", err)
+		return "0"
+	}
+	return strconv.FormatInt(commit.Committer.When.Unix(), 10)
+}
+
+// getReproducibleExportAttrs returns the image exporter attributes that make the layers deterministic
+func getReproducibleExportAttrs() map[string]string {
+	return map[string]string{
+		"rewrite-timestamp": "true",
+		"compression":       "gzip",
+		"force-compression": "true",
+	}
+}
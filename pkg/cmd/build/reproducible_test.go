@@ -0,0 +1,110 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_setReproducibleBuildArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "epoch from env var",
+			env:      "1700000000",
+			args:     []string{"B=2", "A=1"},
+			expected: []string{"A=1", "B=2", "SOURCE_DATE_EPOCH=1700000000"},
+		},
+		{
+			name:     "epoch from build args",
+			env:      "1700000000",
+			args:     []string{"SOURCE_DATE_EPOCH=1", "A=1"},
+			expected: []string{"A=1", "SOURCE_DATE_EPOCH=1"},
+		},
+		{
+			name:     "invalid env var and no repository",
+			env:      "yesterday",
+			args:     []string{},
+			expected: []string{"SOURCE_DATE_EPOCH=0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(sourceDateEpochArg, tt.env)
+			opts := &types.BuildOptions{
+				Path:      t.TempDir(),
+				BuildArgs: tt.args,
+			}
+			setReproducibleBuildArgs(opts)
+			assert.Equal(t, tt.expected, opts.BuildArgs)
+		})
+	}
+}
+
+func Test_getSolveOptReproducible(t *testing.T) {
+	okCtx := &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "Dockerfile"), []byte("FROM alpine"), 0600))
+
+	opt, err := getSolveOpt(&types.BuildOptions{Path: dir, Tag: "okteto/test:1.0", Reproducible: true}, okCtx, "", fs)
+	require.NoError(t, err)
+	require.Len(t, opt.Exports, 1)
+	assert.Equal(t, "true", opt.Exports[0].Attrs["rewrite-timestamp"])
+	assert.Equal(t, "true", opt.Exports[0].Attrs["force-compression"])
+
+	opt, err = getSolveOpt(&types.BuildOptions{Path: dir, Tag: "okteto/test:1.0"}, okCtx, "", fs)
+	require.NoError(t, err)
+	assert.NotContains(t, opt.Exports[0].Attrs, "rewrite-timestamp")
+}
+
+func Test_getSolveOptWithGitContext(t *testing.T) {
+	okCtx := &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+
+	gitContext := "https://github.com/okteto/movies.git#main:api"
+	opt, err := getSolveOpt(&types.BuildOptions{Path: gitContext, File: "Dockerfile.prod", Tag: "okteto/test:1.0"}, okCtx, "", afero.NewMemMapFs())
+	require.NoError(t, err)
+	assert.Empty(t, opt.LocalDirs)
+	assert.Equal(t, gitContext, opt.FrontendAttrs["context"])
+	assert.Equal(t, "Dockerfile.prod", opt.FrontendAttrs["filename"])
+}
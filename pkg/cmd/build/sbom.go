@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// SBOMFormatSPDX generates the SBOM as an SPDX document
+	SBOMFormatSPDX = "spdx"
+
+	// SBOMFormatCycloneDX is the CycloneDX format, not supported by BuildKit attestations
+	SBOMFormatCycloneDX = "cyclonedx"
+)
+
+var errSBOMNotSupportedByDockerDaemon = oktetoErrors.UserError{
+	E:    fmt.Errorf("SBOM generation is not supported by your local docker daemon"),
+	Hint: "Run 'okteto context' to select an Okteto context with a BuildKit builder",
+}
+
+// validateSBOMFormat checks that the SBOM format is supported by the builder
+func validateSBOMFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "", SBOMFormatSPDX:
+		return nil
+	case SBOMFormatCycloneDX:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("SBOM format '%s' is not supported: BuildKit attaches the SBOM of the image as an SPDX document", format),
+			Hint: fmt.Sprintf("Use '--sbom=%s' and convert the SPDX document with your SBOM tooling", SBOMFormatSPDX),
+		}
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid SBOM format '%s'", format),
+			Hint: fmt.Sprintf("Supported formats: %s", SBOMFormatSPDX),
+		}
+	}
+}
+
+// showSBOM shows where the SBOM attached to the pushed image can be found
+func showSBOM(buildOptions *types.BuildOptions, ioCtrl *io.Controller) {
+	if buildOptions.SBOM == "" || buildOptions.Tag == "" || buildOptions.LocalExport != nil {
+		return
+	}
+	tag := strings.Split(buildOptions.Tag, ",")[0]
+	ioCtrl.Out().Infof("SBOM attached to '%s' as an SPDX attestation", tag)
+	ioCtrl.Out().Infof("Inspect it running: docker buildx imagetools inspect %s --format '{{ json .SBOM }}'", tag)
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateSBOMFormat(t *testing.T) {
+	require.NoError(t, validateSBOMFormat(""))
+	require.NoError(t, validateSBOMFormat("spdx"))
+	require.NoError(t, validateSBOMFormat("SPDX"))
+
+	err := validateSBOMFormat("cyclonedx")
+	require.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	err = validateSBOMFormat("xml")
+	require.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func Test_getSolveOptSBOM(t *testing.T) {
+	okCtx := &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "Dockerfile"), []byte("FROM alpine"), 0600))
+
+	opt, err := getSolveOpt(&types.BuildOptions{Path: dir, Tag: "okteto/test:1.0", SBOM: SBOMFormatSPDX}, okCtx, "", fs)
+	require.NoError(t, err)
+	assert.Contains(t, opt.FrontendAttrs, "attest:sbom")
+
+	opt, err = getSolveOpt(&types.BuildOptions{Path: dir, Tag: "okteto/test:1.0"}, okCtx, "", fs)
+	require.NoError(t, err)
+	assert.NotContains(t, opt.FrontendAttrs, "attest:sbom")
+}
+
+func Test_RunSBOMWithDockerDaemon(t *testing.T) {
+	okCtx := &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {
+					Namespace: "test",
+				},
+			},
+			CurrentContext: "test",
+		},
+	}
+	ob := NewOktetoBuilder(okCtx, afero.NewMemMapFs())
+	err := ob.Run(context.Background(), &types.BuildOptions{Tag: "okteto/test:1.0", SBOM: SBOMFormatSPDX}, io.NewIOController())
+	require.ErrorIs(t, err, errSBOMNotSupportedByDockerDaemon)
+}
@@ -0,0 +1,337 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	yaml "gopkg.in/yaml.v2"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	benchmarkFolder = ".okteto-sync-benchmark"
+	smallFileSize   = 1024
+	bytesPerMB      = 1024 * 1024
+
+	// slowSmallFilesRate is the number of small files per second below which the sync is considered slow
+	slowSmallFilesRate = 20
+	// slowThroughput is the MB/s below which large files are considered slow to synchronize
+	slowThroughput = 5
+	// fastThroughput is the MB/s above which compression doesn't pay off
+	fastThroughput = 50
+	// maxSyncFolders is the number of sync folders above which merging them is recommended
+	maxSyncFolders = 4
+	// maxSyncedFiles is the number of synchronized files above which the sync folders are considered too big
+	maxSyncedFiles = 20000
+)
+
+// heavyFolders are folders that usually contain dependencies or build artifacts and shouldn't be synchronized
+var heavyFolders = []string{"node_modules", "vendor", "target", "dist", "build", "__pycache__", ".venv", ".gradle"}
+
+// RemoteCommandRunner runs a shell command in the development container and returns its output
+type RemoteCommandRunner func(ctx context.Context, command string) (string, error)
+
+// SyncBenchmarkOptions defines the options of the sync benchmark
+type SyncBenchmarkOptions struct {
+	SmallFiles    int
+	LargeFileSize int
+	Timeout       time.Duration
+	PollInterval  time.Duration
+}
+
+// SyncBenchmarkResult is the result of the sync benchmark. It is included in the doctor bundle
+type SyncBenchmarkResult struct {
+	SmallFiles  SmallFilesResult `yaml:"smallFiles"`
+	LargeFile   LargeFileResult  `yaml:"largeFile"`
+	Suggestions []string         `yaml:"suggestions,omitempty"`
+}
+
+// SmallFilesResult is the result of synchronizing many small files
+type SmallFilesResult struct {
+	Files          int           `yaml:"files"`
+	Latency        time.Duration `yaml:"latency"`
+	FilesPerSecond float64       `yaml:"filesPerSecond"`
+}
+
+// LargeFileResult is the result of synchronizing a single large file
+type LargeFileResult struct {
+	SizeMB     int           `yaml:"sizeMB"`
+	Latency    time.Duration `yaml:"latency"`
+	Throughput float64       `yaml:"throughputMBps"`
+}
+
+// localSyncInfo is the information about the local sync folders used to suggest tuning
+type localSyncInfo struct {
+	files         int
+	heavyFolders  []string
+	maxWatches    int
+	watchesLoaded bool
+}
+
+// RunSyncBenchmark measures how long it takes to synchronize small and large files to the development container.
+// It requires 'okteto up' to be running
+func RunSyncBenchmark(ctx context.Context, dev *model.Dev, run RemoteCommandRunner, opts SyncBenchmarkOptions) (*SyncBenchmarkResult, error) {
+	if len(dev.Sync.Folders) == 0 {
+		return nil, fmt.Errorf("'%s' doesn't have sync folders", dev.Name)
+	}
+	folder := dev.Sync.Folders[0]
+	localDir := filepath.Join(folder.LocalPath, benchmarkFolder)
+	remoteDir := path.Join(folder.RemotePath, benchmarkFolder)
+
+	if err := os.RemoveAll(localDir); err != nil {
+		return nil, fmt.Errorf("failed to clean up '%s': %w", localDir, err)
+	}
+	defer func() {
+		if err := os.RemoveAll(localDir); err != nil {
+			oktetoLog.Infof("failed to remove '%s': %s", localDir, err)
+		}
+	}()
+
+	smallDir := filepath.Join(localDir, "small")
+	if err := os.MkdirAll(smallDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create '%s': %w", smallDir, err)
+	}
+
+	result := &SyncBenchmarkResult{}
+
+	oktetoLog.Spinner(fmt.Sprintf("Synchronizing %d small files...", opts.SmallFiles))
+	start := time.Now()
+	for i := 0; i < opts.SmallFiles; i++ {
+		if err := writeRandomFile(filepath.Join(smallDir, fmt.Sprintf("file-%d", i)), smallFileSize); err != nil {
+			return nil, err
+		}
+	}
+	countCmd := fmt.Sprintf("find '%s' -type f 2>/dev/null | wc -l", path.Join(remoteDir, "small"))
+	if err := waitForRemoteValue(ctx, run, countCmd, strconv.Itoa(opts.SmallFiles), opts); err != nil {
+		return nil, err
+	}
+	result.SmallFiles = SmallFilesResult{
+		Files:          opts.SmallFiles,
+		Latency:        time.Since(start).Round(time.Millisecond),
+		FilesPerSecond: float64(opts.SmallFiles) / time.Since(start).Seconds(),
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Synchronizing a %dMB file...", opts.LargeFileSize))
+	start = time.Now()
+	largeSize := opts.LargeFileSize * bytesPerMB
+	if err := writeRandomFile(filepath.Join(localDir, "large"), largeSize); err != nil {
+		return nil, err
+	}
+	sizeCmd := fmt.Sprintf("wc -c < '%s' 2>/dev/null", path.Join(remoteDir, "large"))
+	if err := waitForRemoteValue(ctx, run, sizeCmd, strconv.Itoa(largeSize), opts); err != nil {
+		return nil, err
+	}
+	result.LargeFile = LargeFileResult{
+		SizeMB:     opts.LargeFileSize,
+		Latency:    time.Since(start).Round(time.Millisecond),
+		Throughput: float64(opts.LargeFileSize) / time.Since(start).Seconds(),
+	}
+
+	result.Suggestions = getTuningSuggestions(dev, result, getLocalSyncInfo(dev))
+	return result, nil
+}
+
+// writeRandomFile writes size random bytes to path. Random content prevents compression from skewing the results
+func writeRandomFile(path string, size int) error {
+	content := make([]byte, size)
+	if _, err := rand.Read(content); err != nil {
+		return fmt.Errorf("failed to generate the content of '%s': %w", path, err)
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", path, err)
+	}
+	return nil
+}
+
+// waitForRemoteValue runs command in the development container until its output is expected
+func waitForRemoteValue(ctx context.Context, run RemoteCommandRunner, command, expected string, opts SyncBenchmarkOptions) error {
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+	timeout := time.NewTimer(opts.Timeout)
+	defer timeout.Stop()
+
+	for {
+		output, err := run(ctx, command)
+		if err != nil {
+			oktetoLog.Infof("failed to check the benchmark files in the development container: %s", err)
+		} else if strings.TrimSpace(output) == expected {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the benchmark files were not synchronized after %s", opts.Timeout),
+				Hint: fmt.Sprintf("Check that 'okteto up' is running and that '%s' is not ignored by your .stignore file", benchmarkFolder),
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// getLocalSyncInfo collects the information about the local sync folders used to suggest tuning
+func getLocalSyncInfo(dev *model.Dev) localSyncInfo {
+	info := localSyncInfo{}
+	for _, folder := range dev.Sync.Folders {
+		ignored := getIgnoredPatterns(filepath.Join(folder.LocalPath, ".stignore"))
+		err := filepath.WalkDir(folder.LocalPath, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if d.Name() == benchmarkFolder {
+					return filepath.SkipDir
+				}
+				for _, heavy := range heavyFolders {
+					if d.Name() == heavy && !ignored[heavy] {
+						info.heavyFolders = append(info.heavyFolders, p)
+						return filepath.SkipDir
+					}
+				}
+				return nil
+			}
+			info.files++
+			return nil
+		})
+		if err != nil {
+			oktetoLog.Infof("failed to walk '%s': %s", folder.LocalPath, err)
+		}
+	}
+
+	if runtime.GOOS == "linux" {
+		if b, err := os.ReadFile("/proc/sys/fs/inotify/max_user_watches"); err == nil {
+			if n, err := strconv.Atoi(strings.TrimSpace(string(b))); err == nil {
+				info.maxWatches = n
+				info.watchesLoaded = true
+			}
+		}
+	}
+	return info
+}
+
+// getIgnoredPatterns returns the folder names ignored by a .stignore file
+func getIgnoredPatterns(stignorePath string) map[string]bool {
+	result := map[string]bool{}
+	f, err := os.Open(stignorePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			oktetoLog.Infof("failed to read '%s': %s", stignorePath, err)
+		}
+		return result
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimPrefix(line, "(?d)")
+		line = strings.Trim(line, "/")
+		line = strings.TrimPrefix(line, "**/")
+		if line != "" {
+			result[line] = true
+		}
+	}
+	return result
+}
+
+// getTuningSuggestions returns the suggestions to improve the sync performance based on the benchmark results
+func getTuningSuggestions(dev *model.Dev, result *SyncBenchmarkResult, info localSyncInfo) []string {
+	suggestions := []string{}
+
+	if result.LargeFile.Throughput < slowThroughput && !dev.Sync.Compression {
+		suggestions = append(suggestions, "Large files are synchronized slowly. Set 'sync.compression: true' in your okteto manifest to reduce the amount of data sent over the network")
+	}
+	if result.LargeFile.Throughput > fastThroughput && dev.Sync.Compression {
+		suggestions = append(suggestions, "Your connection to the cluster is fast. Set 'sync.compression: false' in your okteto manifest to save CPU when synchronizing files")
+	}
+
+	for _, folder := range info.heavyFolders {
+		suggestions = append(suggestions, fmt.Sprintf("'%s' is synchronized. Add '%s' to your .stignore file if it contains dependencies or build artifacts", folder, filepath.Base(folder)))
+	}
+
+	if len(dev.Sync.Folders) > maxSyncFolders {
+		suggestions = append(suggestions, fmt.Sprintf("You synchronize %d folders. Each folder is watched and scanned separately: consider synchronizing a common parent folder and ignoring what you don't need", len(dev.Sync.Folders)))
+	}
+	if info.files > maxSyncedFiles {
+		suggestions = append(suggestions, fmt.Sprintf("You synchronize %d files. Ignore the files you don't need in the development container to speed up the initial scan", info.files))
+	}
+
+	if info.watchesLoaded && info.files > info.maxWatches {
+		suggestions = append(suggestions, fmt.Sprintf("You synchronize more files (%d) than the file watcher can track (fs.inotify.max_user_watches=%d). Increase it with 'sudo sysctl fs.inotify.max_user_watches=%d' so changes are detected immediately", info.files, info.maxWatches, info.files*2))
+	}
+	if result.SmallFiles.FilesPerSecond < slowSmallFilesRate {
+		suggestions = append(suggestions, "Small files are synchronized slowly. Each file is synchronized separately: avoid synchronizing generated or temporary files")
+	}
+	return suggestions
+}
+
+// NewDevContainerRunner returns a RemoteCommandRunner that executes the commands in the running development container of dev
+func NewDevContainerRunner(ctx context.Context, dev *model.Dev, c *kubernetes.Clientset, config *rest.Config) (RemoteCommandRunner, error) {
+	app, err := apps.Get(ctx, dev, dev.Namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	devApp := app.DevClone()
+	if err := devApp.Refresh(ctx, c); err != nil {
+		return nil, err
+	}
+	pod, err := devApp.GetRunningPod(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if pod == nil {
+		return nil, oktetoErrors.ErrNotInDevMode
+	}
+	return func(ctx context.Context, command string) (string, error) {
+		return pods.RunCommand(ctx, pod, dev.Container, []string{"sh", "-c", command}, config, c)
+	}, nil
+}
+
+// WriteSyncBenchmarkFile writes the benchmark results to a file to be included in the doctor bundle
+func WriteSyncBenchmarkFile(result *SyncBenchmarkResult) (string, error) {
+	tempdir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return "", fmt.Errorf("error creating temp dir: %w", err)
+	}
+	marshalled, err := yaml.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	benchmarkPath := filepath.Join(tempdir, "sync-benchmark.yaml")
+	if err := os.WriteFile(benchmarkPath, marshalled, 0600); err != nil {
+		return "", err
+	}
+	return benchmarkPath, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func newBenchmarkDev(t *testing.T) *model.Dev {
+	return &model.Dev{
+		Name: "dev",
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{
+				{LocalPath: t.TempDir(), RemotePath: "/app"},
+			},
+		},
+	}
+}
+
+func TestRunSyncBenchmark(t *testing.T) {
+	dev := newBenchmarkDev(t)
+	opts := SyncBenchmarkOptions{SmallFiles: 3, LargeFileSize: 1, Timeout: time.Second, PollInterval: 10 * time.Millisecond}
+
+	calls := 0
+	run := func(_ context.Context, command string) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("not ready")
+		}
+		if strings.Contains(command, "wc -l") {
+			assert.Contains(t, command, "/app/.okteto-sync-benchmark/small")
+			return "3\n", nil
+		}
+		assert.Contains(t, command, "/app/.okteto-sync-benchmark/large")
+		return strconv.Itoa(bytesPerMB), nil
+	}
+
+	result, err := RunSyncBenchmark(context.Background(), dev, run, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.SmallFiles.Files)
+	assert.Equal(t, 1, result.LargeFile.SizeMB)
+	assert.Greater(t, result.LargeFile.Throughput, 0.0)
+	assert.NoDirExists(t, filepath.Join(dev.Sync.Folders[0].LocalPath, benchmarkFolder))
+}
+
+func TestRunSyncBenchmarkTimeout(t *testing.T) {
+	dev := newBenchmarkDev(t)
+	opts := SyncBenchmarkOptions{SmallFiles: 1, LargeFileSize: 1, Timeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}
+	run := func(_ context.Context, _ string) (string, error) {
+		return "0", nil
+	}
+
+	_, err := RunSyncBenchmark(context.Background(), dev, run, opts)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.NoDirExists(t, filepath.Join(dev.Sync.Folders[0].LocalPath, benchmarkFolder))
+}
+
+func TestRunSyncBenchmarkWithoutSyncFolders(t *testing.T) {
+	_, err := RunSyncBenchmark(context.Background(), &model.Dev{Name: "dev"}, nil, SyncBenchmarkOptions{})
+	assert.Error(t, err)
+}
+
+func Test_getIgnoredPatterns(t *testing.T) {
+	stignore := filepath.Join(t.TempDir(), ".stignore")
+	require.NoError(t, os.WriteFile(stignore, []byte("node_modules\n(?d)/vendor/\n**/dist\n\n"), 0600))
+
+	assert.Equal(t, map[string]bool{"node_modules": true, "vendor": true, "dist": true}, getIgnoredPatterns(stignore))
+	assert.Empty(t, getIgnoredPatterns(filepath.Join(t.TempDir(), ".stignore")))
+}
+
+func Test_getLocalSyncInfo(t *testing.T) {
+	dev := newBenchmarkDev(t)
+	local := dev.Sync.Folders[0].LocalPath
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "node_modules", "lib"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(local, "vendor"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "node_modules", "lib", "index.js"), []byte(""), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, "main.go"), []byte(""), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(local, ".stignore"), []byte("vendor\n"), 0600))
+
+	info := getLocalSyncInfo(dev)
+	assert.Equal(t, 2, info.files)
+	assert.Equal(t, []string{filepath.Join(local, "node_modules")}, info.heavyFolders)
+}
+
+func Test_getTuningSuggestions(t *testing.T) {
+	fast := &SyncBenchmarkResult{
+		SmallFiles: SmallFilesResult{FilesPerSecond: 100},
+		LargeFile:  LargeFileResult{Throughput: 20},
+	}
+	tests := []struct {
+		dev      *model.Dev
+		result   *SyncBenchmarkResult
+		name     string
+		expected []string
+		info     localSyncInfo
+	}{
+		{
+			name:     "well tuned",
+			dev:      &model.Dev{},
+			result:   fast,
+			expected: []string{},
+		},
+		{
+			name: "slow link without compression",
+			dev:  &model.Dev{},
+			result: &SyncBenchmarkResult{
+				SmallFiles: SmallFilesResult{FilesPerSecond: 100},
+				LargeFile:  LargeFileResult{Throughput: 1},
+			},
+			expected: []string{"sync.compression: true"},
+		},
+		{
+			name: "fast link with compression",
+			dev:  &model.Dev{Sync: model.Sync{Compression: true}},
+			result: &SyncBenchmarkResult{
+				SmallFiles: SmallFilesResult{FilesPerSecond: 100},
+				LargeFile:  LargeFileResult{Throughput: 100},
+			},
+			expected: []string{"sync.compression: false"},
+		},
+		{
+			name:     "heavy folders",
+			dev:      &model.Dev{},
+			result:   fast,
+			info:     localSyncInfo{heavyFolders: []string{filepath.Join("app", "node_modules")}},
+			expected: []string{"Add 'node_modules' to your .stignore"},
+		},
+		{
+			name:     "too many sync folders",
+			dev:      &model.Dev{Sync: model.Sync{Folders: make([]model.SyncFolder, 5)}},
+			result:   fast,
+			expected: []string{"You synchronize 5 folders"},
+		},
+		{
+			name:     "too many files and inotify limit",
+			dev:      &model.Dev{},
+			result:   fast,
+			info:     localSyncInfo{files: 30000, maxWatches: 8192, watchesLoaded: true},
+			expected: []string{"You synchronize 30000 files", "fs.inotify.max_user_watches=8192"},
+		},
+		{
+			name: "slow small files",
+			dev:  &model.Dev{},
+			result: &SyncBenchmarkResult{
+				SmallFiles: SmallFilesResult{FilesPerSecond: 5},
+				LargeFile:  LargeFileResult{Throughput: 20},
+			},
+			expected: []string{"Small files are synchronized slowly"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := getTuningSuggestions(tt.dev, tt.result, tt.info)
+			require.Len(t, suggestions, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.Contains(t, suggestions[i], expected)
+			}
+		})
+	}
+}
+
+func TestWriteSyncBenchmarkFile(t *testing.T) {
+	result := &SyncBenchmarkResult{
+		SmallFiles:  SmallFilesResult{Files: 100, Latency: time.Second, FilesPerSecond: 100},
+		LargeFile:   LargeFileResult{SizeMB: 10, Latency: 2 * time.Second, Throughput: 5},
+		Suggestions: []string{"suggestion"},
+	}
+	benchmarkPath, err := WriteSyncBenchmarkFile(result)
+	require.NoError(t, err)
+	defer os.RemoveAll(filepath.Dir(benchmarkPath))
+
+	b, err := os.ReadFile(benchmarkPath)
+	require.NoError(t, err)
+	read := &SyncBenchmarkResult{}
+	require.NoError(t, yaml.Unmarshal(b, read))
+	assert.Equal(t, result, read)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/mutagen"
 	"github.com/okteto/okteto/pkg/syncthing"
 	yaml "gopkg.in/yaml.v2"
 	apiv1 "k8s.io/api/core/v1"
@@ -47,8 +48,8 @@ type PodInfo struct {
 	Conditions []apiv1.PodCondition `yaml:"conditions,omitempty"`
 }
 
-// Run runs the "okteto status" sequence
-func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clientset) (string, error) {
+// Run runs the "okteto doctor" sequence. extraFiles are added to the generated bundle
+func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clientset, extraFiles ...string) (string, error) {
 	z := archiver.Zip{
 		CompressionLevel:       flate.DefaultCompression,
 		MkdirAll:               true,
@@ -58,7 +59,7 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clie
 		ImplicitTopLevelFolder: true,
 	}
 
-	summaryFilename, err := generateSummaryFile()
+	summaryFilename, err := generateSummaryFile(dev)
 	if err != nil {
 		return "", err
 	}
@@ -91,14 +92,17 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clie
 	archiveName := fmt.Sprintf("okteto-doctor-%s.zip", now.Format("20060102150405"))
 	files := []string{summaryFilename}
 	files = append(files, stignoreFilenames...)
+	files = append(files, extraFiles...)
 
 	appLogsPath := filepath.Join(config.GetAppHome(dev.Namespace, dev.Name), "okteto.log")
 	if filesystem.FileExists(appLogsPath) {
 		files = append(files, appLogsPath)
 	}
 
-	if filesystem.FileExists(syncthing.GetLogFile(dev.Namespace, dev.Name)) {
-		files = append(files, syncthing.GetLogFile(dev.Namespace, dev.Name))
+	for _, logFile := range getSyncEngineLogFiles(dev) {
+		if filesystem.FileExists(logFile) {
+			files = append(files, logFile)
+		}
 	}
 	if podPath != "" {
 		files = append(files, podPath)
@@ -123,7 +127,7 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, c *kubernetes.Clie
 	return archiveName, nil
 }
 
-func generateSummaryFile() (string, error) {
+func generateSummaryFile(dev *model.Dev) (string, error) {
 	tempdir, err := os.MkdirTemp("", "")
 	if err != nil {
 		return "", fmt.Errorf("error creating temp dir: %w", err)
@@ -139,12 +143,30 @@ func generateSummaryFile() (string, error) {
 		}
 	}()
 	fmt.Fprintf(fileSummary, "version=%s\nos=%s\narch=%s\n", config.VersionString, runtime.GOOS, runtime.GOARCH)
+	fmt.Fprint(fileSummary, getSyncEngineSummary(dev))
 	if err := fileSummary.Sync(); err != nil {
 		return "", err
 	}
 	return summaryPath, nil
 }
 
+// getSyncEngineSummary returns the information about the sync engine included in the summary file
+func getSyncEngineSummary(dev *model.Dev) string {
+	if dev.Sync.IsMutagenEngine() {
+		return fmt.Sprintf("syncEngine=%s\nmutagenInstalled=%t\n", model.SyncEngineMutagen, mutagen.IsInstalled())
+	}
+	return fmt.Sprintf("syncEngine=%s\nsyncthingInstalled=%t\n", model.SyncEngineSyncthing, syncthing.IsInstalled())
+}
+
+// getSyncEngineLogFiles returns the local log files of the sync engines used by the development container
+func getSyncEngineLogFiles(dev *model.Dev) []string {
+	result := []string{syncthing.GetLogFile(dev.Namespace, dev.Name)}
+	if dev.Sync.IsMutagenEngine() {
+		result = append(result, mutagen.GetLogFile(dev.Namespace, dev.Name))
+	}
+	return result
+}
+
 func generateStignoreFiles(dev *model.Dev) []string {
 	result := []string{}
 	for i := range dev.Sync.Folders {
@@ -15,6 +15,8 @@ package doctor
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/build"
@@ -87,3 +89,19 @@ func Test_generateManifestFile(t *testing.T) {
 	}
 
 }
+
+func Test_getSyncEngineLogFiles(t *testing.T) {
+	dev := &model.Dev{Name: "dev", Namespace: "ns"}
+	if got := getSyncEngineLogFiles(dev); len(got) != 1 || filepath.Base(got[0]) != "syncthing.log" {
+		t.Errorf("unexpected log files for syncthing engine: %v", got)
+	}
+
+	dev.Sync.Engine = model.SyncEngineMutagen
+	got := getSyncEngineLogFiles(dev)
+	if len(got) != 2 || filepath.Base(got[1]) != "mutagen.log" {
+		t.Errorf("unexpected log files for mutagen engine: %v", got)
+	}
+	if !strings.HasPrefix(getSyncEngineSummary(dev), "syncEngine=mutagen\n") {
+		t.Errorf("unexpected summary for mutagen engine: %s", getSyncEngineSummary(dev))
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/syncthing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// cleanupSummary is the list of resources removed by 'okteto down --all --volumes'
+type cleanupSummary struct {
+	volumes     []string
+	syncFolders []string
+	sshEntries  []string
+}
+
+func (cs *cleanupSummary) isEmpty() bool {
+	return len(cs.volumes) == 0 && len(cs.syncFolders) == 0 && len(cs.sshEntries) == 0
+}
+
+// String returns the summary of the resources removed
+func (cs *cleanupSummary) String() string {
+	if cs.isEmpty() {
+		return "Nothing to clean up"
+	}
+	var sb strings.Builder
+	sb.WriteString("Removed resources:")
+	write := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n    %s:", title)
+		for _, item := range items {
+			fmt.Fprintf(&sb, "\n      - %s", item)
+		}
+	}
+	write("Persistent volumes", cs.volumes)
+	write("Local synchronization databases", cs.syncFolders)
+	write("SSH config entries", cs.sshEntries)
+	return sb.String()
+}
+
+// cleanup removes the persistent volumes, the local synchronization databases and the SSH config entries
+// of all the development containers defined in the manifest, even if they are not active
+func (d *Operation) cleanup(ctx context.Context, devs model.ManifestDevs, c kubernetes.Interface) (*cleanupSummary, error) {
+	summary := &cleanupSummary{}
+	names := make([]string, 0, len(devs))
+	for name := range devs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dev := devs[name]
+		removed, err := removeVolumeIfExists(ctx, dev, c)
+		if err != nil {
+			return summary, err
+		}
+		if removed {
+			summary.volumes = append(summary.volumes, dev.GetVolumeName())
+		}
+
+		if d.removeSyncFolder(dev) {
+			summary.syncFolders = append(summary.syncFolders, getAppHomePath(dev))
+		}
+
+		if removeSSHEntry(dev) {
+			summary.sshEntries = append(summary.sshEntries, fmt.Sprintf("%s.okteto", dev.Name))
+		}
+	}
+	return summary, nil
+}
+
+func removeVolumeIfExists(ctx context.Context, dev *model.Dev, c kubernetes.Interface) (bool, error) {
+	if dev.HasExistingPersistentVolumeClaim() {
+		return false, nil
+	}
+	if _, err := c.CoreV1().PersistentVolumeClaims(dev.Namespace).Get(ctx, dev.GetVolumeName(), metav1.GetOptions{}); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := removeVolume(ctx, dev, c); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (d *Operation) removeSyncFolder(dev *model.Dev) bool {
+	if os.Getenv(model.OktetoSkipCleanupEnvVar) != "" {
+		return false
+	}
+	// config.GetAppHome creates the folder, check if it exists before
+	if _, err := d.Fs.Stat(getAppHomePath(dev)); err != nil {
+		return false
+	}
+	if err := syncthing.RemoveFolder(dev, d.Fs); err != nil {
+		oktetoLog.Infof("failed to delete existing syncthing folder: %s", err)
+		return false
+	}
+	return true
+}
+
+func removeSSHEntry(dev *model.Dev) bool {
+	if _, err := ssh.GetPort(dev.Name); err != nil {
+		return false
+	}
+	if err := ssh.RemoveEntry(dev.Name); err != nil {
+		oktetoLog.Infof("failed to remove ssh entry: %s", err)
+		return false
+	}
+	return true
+}
+
+func getAppHomePath(dev *model.Dev) string {
+	return filepath.Join(config.GetOktetoHome(), dev.Namespace, dev.Name)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCleanup(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(constants.OktetoHomeEnvVar, home)
+	t.Setenv(constants.OktetoFolderEnvVar, filepath.Join(home, ".okteto"))
+
+	api := &model.Dev{Name: "api", Namespace: "test", Timeout: model.Timeout{Default: 5 * time.Second}}
+	web := &model.Dev{Name: "web", Namespace: "test"}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".okteto", "test", "api"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".okteto", "test", "api", "config.xml"), []byte("<configuration/>"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ssh"), 0700))
+	require.NoError(t, ssh.AddEntry("api", "localhost", 22000))
+
+	c := fake.NewSimpleClientset(&apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: api.GetVolumeName(), Namespace: "test"},
+	})
+
+	d := &Operation{Fs: afero.NewOsFs()}
+	summary, err := d.cleanup(context.Background(), model.ManifestDevs{"api": api, "web": web}, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{api.GetVolumeName()}, summary.volumes)
+	assert.Equal(t, []string{filepath.Join(home, ".okteto", "test", "api")}, summary.syncFolders)
+	assert.Equal(t, []string{"api.okteto"}, summary.sshEntries)
+
+	_, err = c.CoreV1().PersistentVolumeClaims("test").Get(context.Background(), api.GetVolumeName(), metav1.GetOptions{})
+	assert.Error(t, err)
+	assert.NoDirExists(t, filepath.Join(home, ".okteto", "test", "api"))
+	_, err = ssh.GetPort("api")
+	assert.Error(t, err)
+}
+
+func TestCleanupSummaryString(t *testing.T) {
+	assert.Equal(t, "Nothing to clean up", (&cleanupSummary{}).String())
+
+	summary := &cleanupSummary{
+		volumes:    []string{"okteto-api"},
+		sshEntries: []string{"api.okteto"},
+	}
+	expected := `Removed resources:
+    Persistent volumes:
+      - okteto-api
+    SSH config entries:
+      - api.okteto`
+	assert.Equal(t, expected, summary.String())
+}
@@ -57,7 +57,7 @@ func (d *Operation) Down(ctx context.Context, dev *model.Dev, rm bool) error {
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 
-	k8sClient, _, err := d.K8sClientProvider.Provide(okteto.GetContext().Cfg)
+	k8sClient, restConfig, err := d.K8sClientProvider.Provide(okteto.GetContext().Cfg)
 	if err != nil {
 		return err
 	}
@@ -79,6 +79,16 @@ func (d *Operation) Down(ctx context.Context, dev *model.Dev, rm bool) error {
 			app = apps.NewDeploymentApp(deployments.Sandbox(dev))
 		}
 
+		if dev.Down != nil && len(dev.Down.Commands) > 0 && apps.IsDevModeOn(app) {
+			run, err := newK8sHookRunner(ctx, dev, app, k8sClient, restConfig)
+			if err != nil {
+				oktetoLog.Infof("skipping down hooks of '%s': %s", dev.Name, err)
+			} else {
+				runDownHooks(ctx, dev, run)
+				oktetoLog.Spinner(fmt.Sprintf("Deactivating '%s' development container...", dev.Name))
+			}
+		}
+
 		trMap, err := apps.GetTranslations(ctx, dev, app, false, k8sClient)
 		if err != nil {
 			exit <- err
@@ -103,7 +113,9 @@ func (d *Operation) Down(ctx context.Context, dev *model.Dev, rm bool) error {
 			exit <- err
 			return
 		}
-		oktetoLog.Success(fmt.Sprintf("Persistent volume '%s' removed", dev.Name))
+		if !dev.HasExistingPersistentVolumeClaim() {
+			oktetoLog.Success(fmt.Sprintf("Persistent volume '%s' removed", dev.Name))
+		}
 
 		if os.Getenv(model.OktetoSkipCleanupEnvVar) == "" {
 			if err := syncthing.RemoveFolder(dev, d.Fs); err != nil {
@@ -130,6 +142,10 @@ func (d *Operation) Down(ctx context.Context, dev *model.Dev, rm bool) error {
 }
 
 func removeVolume(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	if dev.HasExistingPersistentVolumeClaim() {
+		oktetoLog.Information("Persistent volume claim '%s' is not managed by okteto and was not removed", dev.GetVolumeName())
+		return nil
+	}
 	return volumes.Destroy(ctx, dev.GetVolumeName(), dev.Namespace, c, dev.Timeout.Default)
 }
 
@@ -155,7 +171,8 @@ func (d *Operation) AllDown(ctx context.Context, manifest *model.Manifest, rm bo
 
 		if apps.IsDevModeOn(app) {
 			oktetoLog.StopSpinner()
-			if err := d.Down(ctx, dev, rm); err != nil {
+			// volumes are removed by the cleanup for all the development containers
+			if err := d.Down(ctx, dev, false); err != nil {
 				d.AnalyticsTracker.TrackDown(false)
 				return fmt.Errorf("%w\n    Find additional logs at: %s/okteto.log", err, config.GetAppHome(dev.Namespace, dev.Name))
 			}
@@ -164,5 +181,20 @@ func (d *Operation) AllDown(ctx context.Context, manifest *model.Manifest, rm bo
 
 	d.AnalyticsTracker.TrackDown(true)
 
+	if !rm {
+		return nil
+	}
+
+	oktetoLog.Spinner("Removing volumes and local data of your development containers...")
+	oktetoLog.StartSpinner()
+	summary, err := d.cleanup(ctx, manifest.Dev, k8sClient)
+	oktetoLog.StopSpinner()
+	if err != nil {
+		d.AnalyticsTracker.TrackDownVolumes(false)
+		return err
+	}
+	d.AnalyticsTracker.TrackDownVolumes(true)
+	oktetoLog.Information(summary.String())
+
 	return nil
 }
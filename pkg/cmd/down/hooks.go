@@ -0,0 +1,96 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// defaultDownHooksGracePeriod is the time given to the down hooks when 'gracePeriod' is not set
+	defaultDownHooksGracePeriod = 30 * time.Second
+)
+
+// hookRunner runs a down hook command in the development container
+type hookRunner func(ctx context.Context, command string) error
+
+// runDownHooks runs the down hooks of the development container in order within the grace period.
+// Failed hooks are reported as warnings: they never block the deactivation of the development container
+func runDownHooks(ctx context.Context, dev *model.Dev, run hookRunner) {
+	if dev.Down == nil || len(dev.Down.Commands) == 0 {
+		return
+	}
+
+	gracePeriod := dev.Down.GracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = defaultDownHooksGracePeriod
+	}
+	ctx, cancel := context.WithTimeout(ctx, gracePeriod)
+	defer cancel()
+
+	oktetoLog.Spinner(fmt.Sprintf("Running down hooks of '%s' development container...", dev.Name))
+	for _, command := range dev.Down.Commands {
+		oktetoLog.Infof("running down hook '%s'", command)
+		err := run(ctx, command)
+		// the exec of a command is interrupted without error when the context is done
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			oktetoLog.Warning("Down hooks of '%s' didn't finish within the grace period of %s", dev.Name, gracePeriod)
+			return
+		}
+		if err != nil {
+			oktetoLog.Warning("Down hook '%s' of '%s' failed: %s", command, dev.Name, err)
+		}
+	}
+}
+
+// newK8sHookRunner returns a hookRunner that executes the down hooks in the running pod of the development container
+func newK8sHookRunner(ctx context.Context, dev *model.Dev, app apps.App, c kubernetes.Interface, config *rest.Config) (hookRunner, error) {
+	devApp := app
+	if !dev.Autocreate {
+		devApp = app.DevClone()
+	}
+	if err := devApp.Refresh(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to refresh app: %w", err)
+	}
+	pod, err := devApp.GetRunningPod(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	container := dev.Container
+	if container == "" {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	return func(ctx context.Context, command string) error {
+		out := &strings.Builder{}
+		err := exec.Exec(ctx, c, config, pod.Namespace, pod.Name, container, false, strings.NewReader(""), io.Discard, out, []string{"sh", "-c", command})
+		if err != nil && out.Len() > 0 {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(out.String()))
+		}
+		return err
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package down
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDownHooks(t *testing.T) {
+	tests := []struct {
+		down     *model.DownHooks
+		failures map[string]error
+		name     string
+		expected []string
+	}{
+		{
+			name:     "no down hooks",
+			down:     nil,
+			expected: nil,
+		},
+		{
+			name:     "commands run in order",
+			down:     &model.DownHooks{Commands: []string{"redis-cli save", "pkill -TERM watcher"}},
+			expected: []string{"redis-cli save", "pkill -TERM watcher"},
+		},
+		{
+			name:     "failed command does not stop the rest",
+			down:     &model.DownHooks{Commands: []string{"redis-cli save", "pkill -TERM watcher"}},
+			failures: map[string]error{"redis-cli save": errors.New("connection refused")},
+			expected: []string{"redis-cli save", "pkill -TERM watcher"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var executed []string
+			dev := &model.Dev{Name: "api", Down: tt.down}
+			runDownHooks(context.Background(), dev, func(_ context.Context, command string) error {
+				executed = append(executed, command)
+				return tt.failures[command]
+			})
+			assert.Equal(t, tt.expected, executed)
+		})
+	}
+}
+
+func TestRunDownHooksGracePeriod(t *testing.T) {
+	var executed []string
+	dev := &model.Dev{
+		Name: "api",
+		Down: &model.DownHooks{
+			Commands:    []string{"sleep 60", "redis-cli save"},
+			GracePeriod: 10 * time.Millisecond,
+		},
+	}
+	runDownHooks(context.Background(), dev, func(ctx context.Context, command string) error {
+		executed = append(executed, command)
+		<-ctx.Done()
+		return nil
+	})
+	assert.Equal(t, []string{"sleep 60"}, executed)
+}
+
+func TestRunDownHooksDefaultGracePeriod(t *testing.T) {
+	dev := &model.Dev{Name: "api", Down: &model.DownHooks{Commands: []string{"redis-cli save"}}}
+	runDownHooks(context.Background(), dev, func(ctx context.Context, _ string) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(defaultDownHooksGracePeriod), deadline, time.Second)
+		return nil
+	})
+}
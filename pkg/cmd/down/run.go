@@ -16,12 +16,15 @@ package down
 import (
 	"context"
 
+	"github.com/okteto/okteto/pkg/hosts"
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/k8s/secrets"
 	"github.com/okteto/okteto/pkg/k8s/services"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/mutagen"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ports"
 	"github.com/okteto/okteto/pkg/ssh"
 	"github.com/okteto/okteto/pkg/syncthing"
 )
@@ -76,10 +79,19 @@ func (d *Operation) Run(app apps.App, dev *model.Dev, trMap map[string]*apps.Tra
 	}
 
 	d.stopSyncthing(dev)
+	if dev.Sync.IsMutagenEngine() {
+		d.stopMutagen(ctx, dev)
+	}
 
 	if err := ssh.RemoveEntry(dev.Name); err != nil {
 		oktetoLog.Infof("failed to remove ssh entry: %s", err)
 	}
+	if m := hosts.NewManager(d.Fs, okteto.GetContext().Name); m != nil {
+		if err := m.Remove(hosts.Section(dev.Namespace, dev.Name)); err != nil {
+			oktetoLog.Infof("failed to remove the hostnames from the hosts file: %s", err)
+		}
+	}
+	ports.Release(ports.Owner(dev.Namespace, dev.Name))
 
 	if !wait {
 		return nil
@@ -101,3 +113,15 @@ func (d *Operation) stopSyncthing(dev *model.Dev) {
 		oktetoLog.Infof("failed to hard terminate existing syncthing")
 	}
 }
+
+func (d *Operation) stopMutagen(ctx context.Context, dev *model.Dev) {
+	m, err := mutagen.New(dev, d.Fs)
+	if err != nil {
+		oktetoLog.Infof("failed to create mutagen instance: %s", err)
+		return
+	}
+
+	if err := m.Terminate(ctx); err != nil {
+		oktetoLog.Infof("failed to terminate existing mutagen sessions: %s", err)
+	}
+}
@@ -15,8 +15,11 @@ package pipeline
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
@@ -36,6 +39,36 @@ func IsDeployed(ctx context.Context, name, namespace string, c kubernetes.Interf
 	return cmap.Data[statusField] != ErrorStatus
 }
 
+// GetStatus returns the status of a pipeline
+func GetStatus(ctx context.Context, name, namespace string, c kubernetes.Interface) (string, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return "", err
+	}
+	return cmap.Data[statusField], nil
+}
+
+// GetDependencyEnvs returns the status of a pipeline and the variables it exported with OKTETO_ENV
+func GetDependencyEnvs(ctx context.Context, name, namespace string, c kubernetes.Interface) (string, map[string]string, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return "", nil, err
+	}
+	envs := map[string]string{}
+	encoded, ok := cmap.Data[constants.OktetoDependencyEnvsKey]
+	if !ok {
+		return cmap.Data[statusField], envs, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not decode the variables of '%s': %w", name, err)
+	}
+	if err := json.Unmarshal(decoded, &envs); err != nil {
+		return "", nil, fmt.Errorf("could not decode the variables of '%s': %w", name, err)
+	}
+	return cmap.Data[statusField], envs, nil
+}
+
 // ListDeployments list all the deployments created by the pipeline
 func ListDeployments(ctx context.Context, name, ns string, c kubernetes.Interface) ([]v1.Deployment, error) {
 	labels := fmt.Sprintf("%s=%s", model.DeployedByLabel, format.ResourceK8sMetaString(name))
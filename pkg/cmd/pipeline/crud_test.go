@@ -15,9 +15,12 @@ package pipeline
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -81,3 +84,26 @@ func Test_IsDeployedPipeline(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetDependencyEnvs(t *testing.T) {
+	ctx := context.Background()
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("api"),
+			Namespace: "test",
+		},
+		Data: map[string]string{
+			statusField:                       DeployedStatus,
+			constants.OktetoDependencyEnvsKey: base64.StdEncoding.EncodeToString([]byte(`{"API_URL":"https://api.okteto.dev"}`)),
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(cmap)
+
+	status, envs, err := GetDependencyEnvs(ctx, "api", "test", fakeClient)
+	require.NoError(t, err)
+	assert.Equal(t, DeployedStatus, status)
+	assert.Equal(t, map[string]string{"API_URL": "https://api.okteto.dev"}, envs)
+
+	_, _, err = GetDependencyEnvs(ctx, "frontend", "test", fakeClient)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ExpiredEnvironment represents a development environment whose time to live has expired
+type ExpiredEnvironment struct {
+	ExpiresAt time.Time
+	Name      string
+}
+
+// ListExpired returns the development environments of the namespace whose time to live expired before now
+func ListExpired(ctx context.Context, namespace string, now time.Time, c kubernetes.Interface) ([]ExpiredEnvironment, error) {
+	cmaps, err := configmaps.List(ctx, namespace, fmt.Sprintf("%s=true", model.GitDeployLabel), c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ExpiredEnvironment{}
+	for _, cmap := range cmaps {
+		value, ok := cmap.Annotations[constants.ExpiresAtAnnotation]
+		if !ok || cmap.Data[statusField] == DestroyingStatus {
+			continue
+		}
+		expiresAt, err := time.Parse(constants.TimeFormat, value)
+		if err != nil {
+			oktetoLog.Infof("invalid expiration '%s' of configmap '%s': %s", value, cmap.Name, err)
+			continue
+		}
+		if expiresAt.Before(now) {
+			result = append(result, ExpiredEnvironment{
+				Name:      cmap.Data[nameField],
+				ExpiresAt: expiresAt,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// WarnExpired shows a warning for each development environment of the namespace whose time to live has expired.
+// The development environment being deployed is skipped
+func WarnExpired(ctx context.Context, namespace, deploying string, c kubernetes.Interface) {
+	expired, err := ListExpired(ctx, namespace, time.Now().UTC(), c)
+	if err != nil {
+		oktetoLog.Infof("could not check the expired development environments: %s", err)
+		return
+	}
+	for _, env := range expired {
+		if env.Name == deploying {
+			continue
+		}
+		oktetoLog.Warning("The development environment '%s' expired on %s UTC.\n    Run 'okteto destroy --name %s -n %s' to destroy it", env.Name, env.ExpiresAt.Format(time.DateTime), env.Name, namespace)
+	}
+}
+
+// ParseTTL parses the time to live of a development environment, like '48h'
+func ParseTTL(value string) (time.Duration, error) {
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time to live '%s': %w", value, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid time to live '%s': it must be greater than zero", value)
+	}
+	return ttl, nil
+}
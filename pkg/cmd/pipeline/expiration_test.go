@@ -0,0 +1,93 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_ListExpired(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	deploy := func(name string, expiresAt time.Time, status string) {
+		_, err := TranslateConfigMapAndDeploy(ctx, &CfgData{
+			Name:      name,
+			Namespace: "test",
+			Status:    status,
+			ExpiresAt: expiresAt,
+		}, fakeClient)
+		require.NoError(t, err)
+	}
+	deploy("zeta", now.Add(-time.Hour), DeployedStatus)
+	deploy("alpha", now.Add(-48*time.Hour), ErrorStatus)
+	deploy("beta", now.Add(time.Hour), DeployedStatus)
+	deploy("gamma", time.Time{}, DeployedStatus)
+	deploy("delta", now.Add(-time.Hour), DestroyingStatus)
+
+	expired, err := ListExpired(ctx, "test", now, fakeClient)
+	require.NoError(t, err)
+	assert.Equal(t, []ExpiredEnvironment{
+		{Name: "alpha", ExpiresAt: now.Add(-48 * time.Hour)},
+		{Name: "zeta", ExpiresAt: now.Add(-time.Hour)},
+	}, expired)
+}
+
+func Test_TranslateConfigMapKeepsExpiration(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	expiresAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	cfg, err := TranslateConfigMapAndDeploy(ctx, &CfgData{Name: "test", Namespace: "test", Status: ProgressingStatus, ExpiresAt: expiresAt}, fakeClient)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-10T12:00:00", cfg.Annotations[constants.ExpiresAtAnnotation])
+
+	cfg, err = TranslateConfigMapAndDeploy(ctx, &CfgData{Name: "test", Namespace: "test", Status: DeployedStatus}, fakeClient)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-10T12:00:00", cfg.Annotations[constants.ExpiresAtAnnotation])
+}
+
+func Test_ParseTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{name: "hours", value: "48h", expected: 48 * time.Hour},
+		{name: "minutes", value: "90m", expected: 90 * time.Minute},
+		{name: "invalid", value: "2d", expectedErr: true},
+		{name: "zero", value: "0s", expectedErr: true},
+		{name: "negative", value: "-1h", expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := ParseTTL(tt.value)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ttl)
+		})
+	}
+}
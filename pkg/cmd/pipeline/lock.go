@@ -0,0 +1,247 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultDeployLockTTL is how long the lease of a deploy lasts without being renewed. The lease of a deploy that crashes expires after it
+const DefaultDeployLockTTL = time.Minute
+
+var errDeployLeaseLost = errors.New("the lease is held by another deploy")
+
+// DeployLockedError is returned when the configmap of a development environment is leased by another deploy
+type DeployLockedError struct {
+	ExpiresAt time.Time
+	Name      string
+	Holder    string
+}
+
+func (e DeployLockedError) Error() string {
+	if e.Holder == "" {
+		return fmt.Sprintf("'%s' is being deployed by another operation", e.Name)
+	}
+	return fmt.Sprintf("'%s' is being deployed by another operation ('%s')", e.Name, e.Holder)
+}
+
+// DeployLease is the lease held by a deploy on the configmap of a development environment,
+// so concurrent deploys of the same development environment don't interleave their commands
+type DeployLease struct {
+	c         kubernetes.Interface
+	stop      chan struct{}
+	name      string
+	namespace string
+	holder    string
+	wg        sync.WaitGroup
+	ttl       time.Duration
+	// reentrant is true when the lease was already held by the same holder, like the deploys run by the commands of another deploy.
+	// The lease is released by the deploy that took it
+	reentrant bool
+}
+
+// TryAcquireDeployLease takes the lease on the configmap of a development environment, creating the configmap if it doesn't exist.
+// It returns a DeployLockedError if the lease is held by another holder and it hasn't expired
+func TryAcquireDeployLease(ctx context.Context, data *CfgData, holder string, ttl time.Duration, c kubernetes.Interface) (*DeployLease, error) {
+	now := time.Now()
+	lease := &DeployLease{
+		c:         c,
+		name:      TranslatePipelineName(data.Name),
+		namespace: data.Namespace,
+		holder:    holder,
+		ttl:       ttl,
+		stop:      make(chan struct{}),
+	}
+
+	cmap, err := configmaps.Get(ctx, lease.name, data.Namespace, c)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return nil, err
+		}
+		cmap = translateConfigMapSandBox(data)
+		lease.name = cmap.Name
+		setDeployLock(cmap, holder, now.Add(ttl))
+		if err := configmaps.Create(ctx, cmap, cmap.Namespace, c); err != nil {
+			if k8sErrors.IsAlreadyExists(err) {
+				return nil, DeployLockedError{Name: data.Name}
+			}
+			return nil, err
+		}
+		lease.start()
+		return lease, nil
+	}
+
+	if current, expiresAt := getDeployLock(cmap); current != "" && now.Before(expiresAt) {
+		if current != holder {
+			return nil, DeployLockedError{Name: data.Name, Holder: current, ExpiresAt: expiresAt}
+		}
+		lease.reentrant = true
+		return lease, nil
+	}
+
+	setDeployLock(cmap, holder, now.Add(ttl))
+	if err := configmaps.Deploy(ctx, cmap, cmap.Namespace, c); err != nil {
+		if k8sErrors.IsConflict(err) {
+			return nil, DeployLockedError{Name: data.Name}
+		}
+		return nil, err
+	}
+	lease.start()
+	return lease, nil
+}
+
+// IsDeployedWithIdempotencyKey returns true if the last deploy of a development environment succeeded with the given idempotency key
+func IsDeployedWithIdempotencyKey(ctx context.Context, name, namespace, key string, c kubernetes.Interface) (bool, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return cmap.Annotations[constants.IdempotencyKeyAnnotation] == key && cmap.Data[statusField] == DeployedStatus, nil
+}
+
+// Holder returns the holder of the lease
+func (l *DeployLease) Holder() string {
+	return l.holder
+}
+
+// start renews the lease until it's released.
+// A lease taken by another deploy, or not renewed before it expires, is reported as a warning
+func (l *DeployLease) start() {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+		renewedAt := time.Now()
+		expired := false
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-ticker.C:
+				err := l.renew(context.Background())
+				if err == nil {
+					renewedAt = time.Now()
+					expired = false
+					continue
+				}
+				if errors.Is(err, errDeployLeaseLost) {
+					oktetoLog.Warning("The deploy lock of '%s' was lost: %s. Another deploy might be running at the same time", l.name, err)
+					return
+				}
+				oktetoLog.Infof("failed to renew the deploy lock of '%s': %s", l.name, err)
+				if !expired && time.Since(renewedAt) >= l.ttl {
+					oktetoLog.Warning("The deploy lock of '%s' expired: another deploy might be running at the same time", l.name)
+					expired = true
+				}
+			}
+		}
+	}()
+}
+
+// renew extends the lease. The lock annotations are patched so it doesn't conflict with other updates of the configmap
+func (l *DeployLease) renew(ctx context.Context) error {
+	cmap, err := configmaps.Get(ctx, l.name, l.namespace, l.c)
+	if err != nil {
+		return err
+	}
+	if current, _ := getDeployLock(cmap); current != l.holder {
+		return fmt.Errorf("%w ('%s')", errDeployLeaseLost, current)
+	}
+	return l.patchAnnotations(ctx, map[string]interface{}{
+		constants.DeployLockHolderAnnotation:    l.holder,
+		constants.DeployLockExpiresAtAnnotation: time.Now().Add(l.ttl).UTC().Format(constants.TimeFormat),
+	})
+}
+
+// patchAnnotations applies a merge patch of the given annotations to the configmap. Nil values remove the annotation
+func (l *DeployLease) patchAnnotations(ctx context.Context, annotations map[string]interface{}) error {
+	payload := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = l.c.CoreV1().ConfigMaps(l.namespace).Patch(ctx, l.name, types.MergePatchType, b, metav1.PatchOptions{})
+	return err
+}
+
+// Release stops renewing the lease and releases it. The idempotency key is recorded if the deploy succeeded
+func (l *DeployLease) Release(ctx context.Context, idempotencyKey string, succeeded bool) error {
+	if l.reentrant {
+		return nil
+	}
+	close(l.stop)
+	l.wg.Wait()
+
+	cmap, err := configmaps.Get(ctx, l.name, l.namespace, l.c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			// the development environment has been destroyed by its deploy commands
+			return nil
+		}
+		return err
+	}
+	if current, _ := getDeployLock(cmap); current != l.holder {
+		return nil
+	}
+	annotations := map[string]interface{}{
+		constants.DeployLockHolderAnnotation:    nil,
+		constants.DeployLockExpiresAtAnnotation: nil,
+	}
+	if succeeded && idempotencyKey != "" {
+		annotations[constants.IdempotencyKeyAnnotation] = idempotencyKey
+	}
+	return l.patchAnnotations(ctx, annotations)
+}
+
+func setDeployLock(cmap *apiv1.ConfigMap, holder string, expiresAt time.Time) {
+	if cmap.Annotations == nil {
+		cmap.Annotations = map[string]string{}
+	}
+	cmap.Annotations[constants.DeployLockHolderAnnotation] = holder
+	cmap.Annotations[constants.DeployLockExpiresAtAnnotation] = expiresAt.UTC().Format(constants.TimeFormat)
+}
+
+// getDeployLock returns the holder of the lease on the configmap and when it expires.
+// A lease without a valid expiration is expired
+func getDeployLock(cmap *apiv1.ConfigMap) (string, time.Time) {
+	holder := cmap.Annotations[constants.DeployLockHolderAnnotation]
+	expiresAt, err := time.Parse(constants.TimeFormat, cmap.Annotations[constants.DeployLockExpiresAtAnnotation])
+	if err != nil {
+		return holder, time.Time{}
+	}
+	return holder, expiresAt
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTryAcquireDeployLease(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	data := &CfgData{Name: "test", Namespace: "ns", Status: ProgressingStatus}
+
+	lease, err := TryAcquireDeployLease(ctx, data, "ci-1", time.Minute, c)
+	require.NoError(t, err)
+	cmap, err := c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "ci-1", cmap.Annotations[constants.DeployLockHolderAnnotation])
+	assert.Equal(t, ProgressingStatus, cmap.Data[statusField])
+
+	_, err = TryAcquireDeployLease(ctx, data, "ci-2", time.Minute, c)
+	lockedErr := DeployLockedError{}
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, "ci-1", lockedErr.Holder)
+
+	// the deploys run by the commands of the deploy reuse its lease without releasing it
+	nested, err := TryAcquireDeployLease(ctx, data, "ci-1", time.Minute, c)
+	require.NoError(t, err)
+	require.NoError(t, nested.Release(ctx, "", true))
+	cmap, err = c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "ci-1", cmap.Annotations[constants.DeployLockHolderAnnotation])
+
+	require.NoError(t, lease.Release(ctx, "pipeline-1", true))
+	cmap, err = c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, cmap.Annotations, constants.DeployLockHolderAnnotation)
+	assert.NotContains(t, cmap.Annotations, constants.DeployLockExpiresAtAnnotation)
+	assert.Equal(t, "pipeline-1", cmap.Annotations[constants.IdempotencyKeyAnnotation])
+
+	lease, err = TryAcquireDeployLease(ctx, data, "ci-2", time.Minute, c)
+	require.NoError(t, err)
+	require.NoError(t, lease.Release(ctx, "pipeline-2", false))
+	cmap, err = c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "pipeline-1", cmap.Annotations[constants.IdempotencyKeyAnnotation])
+}
+
+func TestTryAcquireDeployLeaseExpired(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("test"),
+			Namespace: "ns",
+			Annotations: map[string]string{
+				constants.DeployLockHolderAnnotation:    "crashed",
+				constants.DeployLockExpiresAtAnnotation: time.Now().Add(-time.Minute).UTC().Format(constants.TimeFormat),
+			},
+		},
+		Data: map[string]string{statusField: ProgressingStatus},
+	})
+
+	lease, err := TryAcquireDeployLease(ctx, &CfgData{Name: "test", Namespace: "ns"}, "ci-1", time.Minute, c)
+	require.NoError(t, err)
+	cmap, err := c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "ci-1", cmap.Annotations[constants.DeployLockHolderAnnotation])
+	require.NoError(t, lease.Release(ctx, "", false))
+}
+
+func TestIsDeployedWithIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        TranslatePipelineName("deployed"),
+			Namespace:   "ns",
+			Annotations: map[string]string{constants.IdempotencyKeyAnnotation: "pipeline-1"},
+		},
+		Data: map[string]string{statusField: DeployedStatus},
+	}, &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        TranslatePipelineName("failed"),
+			Namespace:   "ns",
+			Annotations: map[string]string{constants.IdempotencyKeyAnnotation: "pipeline-1"},
+		},
+		Data: map[string]string{statusField: ErrorStatus},
+	})
+
+	tests := []struct {
+		name     string
+		key      string
+		expected bool
+	}{
+		{name: "deployed", key: "pipeline-1", expected: true},
+		{name: "deployed", key: "pipeline-2", expected: false},
+		{name: "failed", key: "pipeline-1", expected: false},
+		{name: "not-found", key: "pipeline-1", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.key, func(t *testing.T) {
+			deployed, err := IsDeployedWithIdempotencyKey(ctx, tt.name, "ns", tt.key, c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, deployed)
+		})
+	}
+}
+
+func TestDeployLeaseRenew(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	data := &CfgData{Name: "test", Namespace: "ns", Status: ProgressingStatus}
+
+	lease, err := TryAcquireDeployLease(ctx, data, "ci-1", time.Minute, c)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, lease.Release(ctx, "", false))
+	}()
+	cmap, err := c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	setDeployLock(cmap, "ci-1", time.Now())
+	_, err = c.CoreV1().ConfigMaps("ns").Update(ctx, cmap, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, lease.renew(ctx))
+	cmap, err = c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	holder, expiresAt := getDeployLock(cmap)
+	assert.Equal(t, "ci-1", holder)
+	assert.True(t, expiresAt.After(time.Now().Add(30*time.Second)))
+	assert.Equal(t, ProgressingStatus, cmap.Data[statusField])
+
+	setDeployLock(cmap, "ci-2", time.Now().Add(time.Minute))
+	_, err = c.CoreV1().ConfigMaps("ns").Update(ctx, cmap, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, lease.renew(ctx), errDeployLeaseLost)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -39,6 +40,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 )
 
 const (
@@ -47,6 +49,7 @@ const (
 	outputField     = "output"
 	repoField       = "repository"
 	branchField     = "branch"
+	commitField     = "commit"
 	filenameField   = "filename"
 	yamlField       = "yaml"
 	iconField       = "icon"
@@ -54,6 +57,16 @@ const (
 	actionNameField = "actionName"
 	variablesField  = "variables"
 	PhasesField     = "phases"
+	OutputsField    = "outputs"
+	SeedsField      = "seeds"
+	// AttestationsField stores the provenance attestations of the images built for the pipeline
+	AttestationsField = "attestations"
+	// ResourcesField stores the resources applied by the deploy commands of the last deploy
+	ResourcesField = "resources"
+	// LastSuccessfulDeployField is the key of the secret that stores the state of the last successful deploy, used to roll back failed deploys
+	LastSuccessfulDeployField = "lastSuccessfulDeploy"
+
+	repositoriesField = "repositories"
 
 	actionDefaultName = "cli"
 
@@ -90,10 +103,47 @@ type CfgData struct {
 	Output     string
 	Repository string
 	Branch     string
+	Commit     string
 	Filename   string
 	Manifest   []byte
 	Icon       string
+	Owner      string
 	Variables  []string
+	// Repositories are the sibling repositories referenced by the build contexts of the manifest
+	Repositories []Repository
+	// ExpiresAt is when the time to live of the development environment expires. Zero keeps the current expiration
+	ExpiresAt time.Time
+}
+
+// Repository represents the git metadata of a sibling repository referenced by the manifest
+type Repository struct {
+	// Path is the path of the repository relative to the repository of the manifest
+	Path   string `json:"path"`
+	URL    string `json:"url,omitempty"`
+	Branch string `json:"branch,omitempty"`
+	Commit string `json:"commit,omitempty"`
+}
+
+// Attestation is the provenance attestation of an image built for a pipeline
+type Attestation struct {
+	// Image is the image with the digest it was pushed with
+	Image string `json:"image"`
+	// Commit is the git commit the image was built from
+	Commit string `json:"commit,omitempty"`
+	// Digests are the digests of the attestation manifests attached to the image
+	Digests []string `json:"digests"`
+}
+
+// DeployedState is the state of a successful deploy of a pipeline
+type DeployedState struct {
+	// Images are the okteto build env vars of the images deployed, e.g. OKTETO_BUILD_API_IMAGE
+	Images map[string]string `json:"images,omitempty"`
+	// Commands are the deploy commands executed
+	Commands []model.DeployCommand `json:"commands"`
+	// Variables are the variables the deploy commands were executed with
+	Variables []string `json:"variables,omitempty"`
+	// Resources are the resources applied by the deploy commands
+	Resources []string `json:"resources,omitempty"`
 }
 
 type phaseJSON struct {
@@ -147,14 +197,18 @@ func TranslateConfigMapAndDeploy(ctx context.Context, data *CfgData, c kubernete
 
 // UpdateConfigMap updates the configmaps fields
 func UpdateConfigMap(ctx context.Context, cmap *apiv1.ConfigMap, data *CfgData, c kubernetes.Interface) error {
-	cmap, err := configmaps.Get(ctx, cmap.Name, cmap.Namespace, c)
-	if err != nil {
-		return err
-	}
-	if err := updateCmap(cmap, data); err != nil {
-		return err
-	}
-	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+	name, namespace := cmap.Name, cmap.Namespace
+	// the lease of the deploy is renewed concurrently
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cmap, err := configmaps.Get(ctx, name, namespace, c)
+		if err != nil {
+			return err
+		}
+		if err := updateCmap(cmap, data); err != nil {
+			return err
+		}
+		return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+	})
 }
 
 // UpdateEnvs updates the configmap adding the envs as data fields
@@ -189,6 +243,240 @@ func UpdateEnvs(ctx context.Context, name, namespace string, envs []string, c ku
 	return nil
 }
 
+// UpdateOutputs stores the outputs published by the deploy commands in the configmap.
+// Outputs from previous deployments are replaced, and the field is removed if there are no outputs
+func UpdateOutputs(ctx context.Context, name, namespace string, outputs map[string]string, c kubernetes.Interface) error {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+
+	if len(outputs) == 0 {
+		if _, ok := cmap.Data[OutputsField]; !ok {
+			return nil
+		}
+		delete(cmap.Data, OutputsField)
+		return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+	}
+
+	encodedOutputs, err := json.Marshal(outputs)
+	if err != nil {
+		return err
+	}
+	if cmap.Data == nil {
+		cmap.Data = map[string]string{}
+	}
+	cmap.Data[OutputsField] = string(encodedOutputs)
+	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
+// GetOutputs returns the outputs published by the last deployment of a pipeline
+func GetOutputs(ctx context.Context, name, namespace string, c kubernetes.Interface) (map[string]string, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]string{}
+	val, ok := cmap.Data[OutputsField]
+	if !ok || val == "" {
+		return outputs, nil
+	}
+	if err := json.Unmarshal([]byte(val), &outputs); err != nil {
+		return nil, fmt.Errorf("invalid outputs for '%s': %w", name, err)
+	}
+	return outputs, nil
+}
+
+// UpdateResources stores the resources applied by the deploy commands in the configmap.
+// Resources from previous deployments are replaced, and the field is removed if there are no resources
+func UpdateResources(ctx context.Context, name, namespace string, resources []string, c kubernetes.Interface) error {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+
+	if len(resources) == 0 {
+		if _, ok := cmap.Data[ResourcesField]; !ok {
+			return nil
+		}
+		delete(cmap.Data, ResourcesField)
+		return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+	}
+
+	sorted := make([]string, len(resources))
+	copy(sorted, resources)
+	sort.Strings(sorted)
+	encodedResources, err := json.Marshal(sorted)
+	if err != nil {
+		return err
+	}
+	if cmap.Data == nil {
+		cmap.Data = map[string]string{}
+	}
+	cmap.Data[ResourcesField] = string(encodedResources)
+	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
+// GetResources returns the resources applied by the last deployment of a pipeline
+func GetResources(ctx context.Context, name, namespace string, c kubernetes.Interface) ([]string, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []string{}
+	val, ok := cmap.Data[ResourcesField]
+	if !ok || val == "" {
+		return resources, nil
+	}
+	if err := json.Unmarshal([]byte(val), &resources); err != nil {
+		return nil, fmt.Errorf("invalid resources for '%s': %w", name, err)
+	}
+	return resources, nil
+}
+
+// UpdateLastSuccessfulDeploy stores the state of the last successful deploy of a pipeline.
+// It is stored in a secret owned by the pipeline configmap, as it contains the variables of the deploy
+func UpdateLastSuccessfulDeploy(ctx context.Context, name, namespace string, state DeployedState, c kubernetes.Interface) error {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, translateLastSuccessfulDeployName(name), metav1.GetOptions{})
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		secret = &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      translateLastSuccessfulDeployName(name),
+				Namespace: namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Name:       cmap.Name,
+						UID:        cmap.UID,
+					},
+				},
+			},
+			Type: apiv1.SecretTypeOpaque,
+			Data: map[string][]byte{LastSuccessfulDeployField: encoded},
+		}
+		_, err = c.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[LastSuccessfulDeployField] = encoded
+	_, err = c.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+// GetLastSuccessfulDeploy returns the state of the last successful deploy of a pipeline, or nil if it was never successfully deployed
+func GetLastSuccessfulDeploy(ctx context.Context, name, namespace string, c kubernetes.Interface) (*DeployedState, error) {
+	if _, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c); err != nil {
+		return nil, err
+	}
+
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, translateLastSuccessfulDeployName(name), metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	val, ok := secret.Data[LastSuccessfulDeployField]
+	if !ok || len(val) == 0 {
+		return nil, nil
+	}
+	state := &DeployedState{}
+	if err := json.Unmarshal(val, state); err != nil {
+		return nil, fmt.Errorf("invalid last successful deploy for '%s': %w", name, err)
+	}
+	return state, nil
+}
+
+// translateLastSuccessfulDeployName returns the name of the secret that stores the last successful deploy of a pipeline
+func translateLastSuccessfulDeployName(name string) string {
+	return fmt.Sprintf("%s-last-deploy", TranslatePipelineName(name))
+}
+
+// GetSeedChecksums returns the checksums of the seeds executed in a pipeline
+func GetSeedChecksums(ctx context.Context, name, namespace string, c kubernetes.Interface) (map[string]string, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := map[string]string{}
+	val, ok := cmap.Data[SeedsField]
+	if !ok || val == "" {
+		return checksums, nil
+	}
+	if err := json.Unmarshal([]byte(val), &checksums); err != nil {
+		return nil, fmt.Errorf("invalid seed checksums for '%s': %w", name, err)
+	}
+	return checksums, nil
+}
+
+// UpdateSeedChecksums stores the checksums of the seeds executed in a pipeline
+func UpdateSeedChecksums(ctx context.Context, name, namespace string, checksums map[string]string, c kubernetes.Interface) error {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(checksums)
+	if err != nil {
+		return err
+	}
+	if cmap.Data == nil {
+		cmap.Data = map[string]string{}
+	}
+	cmap.Data[SeedsField] = string(encoded)
+	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
+// UpdateAttestations stores the provenance attestations of the images built for a pipeline.
+// The attestations of the services not included are kept
+func UpdateAttestations(ctx context.Context, name, namespace string, attestations map[string]Attestation, c kubernetes.Interface) error {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+
+	current := map[string]Attestation{}
+	if val, ok := cmap.Data[AttestationsField]; ok && val != "" {
+		if err := json.Unmarshal([]byte(val), &current); err != nil {
+			return fmt.Errorf("invalid attestations for '%s': %w", name, err)
+		}
+	}
+	for svc, attestation := range attestations {
+		current[svc] = attestation
+	}
+
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	if cmap.Data == nil {
+		cmap.Data = map[string]string{}
+	}
+	cmap.Data[AttestationsField] = string(encoded)
+	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
 // AddPhaseDuration adds a new phase to the configmap with the duration in seconds
 func AddPhaseDuration(ctx context.Context, name, namespace, phase string, duration time.Duration, c kubernetes.Interface) error {
 	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
@@ -261,9 +549,10 @@ func translateOutput(output *bytes.Buffer) []byte {
 
 // translateConfigMapSandBox creates a configmap adding data from a config data
 func translateConfigMapSandBox(data *CfgData) *apiv1.ConfigMap {
-	// if repository is empty, force empty branch
+	// if repository is empty, force empty branch and commit
 	if data.Repository == "" {
 		data.Branch = ""
+		data.Commit = ""
 	}
 	cmap := &apiv1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -292,10 +581,26 @@ func translateConfigMapSandBox(data *CfgData) *apiv1.ConfigMap {
 		cmap.Data[variablesField] = translateVariables(data.Variables)
 	}
 
+	if data.Owner != "" {
+		cmap.Labels[model.OwnerLabel] = data.Owner
+	}
+
+	if !data.ExpiresAt.IsZero() {
+		cmap.Annotations[constants.ExpiresAtAnnotation] = data.ExpiresAt.UTC().Format(constants.TimeFormat)
+	}
+
 	if data.Repository != "" {
 		cmap.Data[filenameField] = data.Filename
 	}
 
+	if data.Commit != "" {
+		cmap.Data[commitField] = data.Commit
+	}
+
+	if repositories := translateRepositories(data.Repositories); repositories != "" {
+		cmap.Data[repositoriesField] = repositories
+	}
+
 	output := oktetoLog.GetOutputBuffer()
 	outputData := translateOutput(output)
 	cmap.Data[outputField] = base64.StdEncoding.EncodeToString(outputData)
@@ -307,6 +612,9 @@ func updateCmap(cmap *apiv1.ConfigMap, data *CfgData) error {
 		cmap.Annotations = map[string]string{}
 	}
 	cmap.Annotations[constants.LastUpdatedAnnotation] = time.Now().UTC().Format(constants.TimeFormat)
+	if !data.ExpiresAt.IsZero() {
+		cmap.Annotations[constants.ExpiresAtAnnotation] = data.ExpiresAt.UTC().Format(constants.TimeFormat)
+	}
 
 	actionName := os.Getenv(model.OktetoActionNameEnvVar)
 	if actionName == "" {
@@ -316,6 +624,9 @@ func updateCmap(cmap *apiv1.ConfigMap, data *CfgData) error {
 		return errors.New("There is a pipeline operation already running")
 	}
 	cmap.ObjectMeta.Labels[model.GitDeployLabel] = "true"
+	if data.Owner != "" {
+		cmap.ObjectMeta.Labels[model.OwnerLabel] = data.Owner
+	}
 	cmap.Data[nameField] = data.Name
 	cmap.Data[statusField] = data.Status
 	cmap.Data[yamlField] = base64.StdEncoding.EncodeToString(data.Manifest)
@@ -329,15 +640,23 @@ func updateCmap(cmap *apiv1.ConfigMap, data *CfgData) error {
 		cmap.Data[repoField] = data.Repository
 	}
 
-	// if repository is empty, force empty branch
+	// if repository is empty, force empty branch and commit
 	if data.Repository == "" {
 		data.Branch = ""
+		data.Commit = ""
 	}
 
 	if data.Branch != "" {
 		cmap.Data[branchField] = data.Branch
 	}
 
+	// the commit is only kept when it's known, otherwise it would point to a previous deployment
+	if data.Commit != "" {
+		cmap.Data[commitField] = data.Commit
+	} else {
+		delete(cmap.Data, commitField)
+	}
+
 	// only update field when variables exist
 	if len(data.Variables) > 0 {
 		cmap.Data[variablesField] = translateVariables(data.Variables)
@@ -346,6 +665,12 @@ func updateCmap(cmap *apiv1.ConfigMap, data *CfgData) error {
 		delete(cmap.Data, variablesField)
 	}
 
+	if repositories := translateRepositories(data.Repositories); repositories != "" {
+		cmap.Data[repositoriesField] = repositories
+	} else {
+		delete(cmap.Data, repositoriesField)
+	}
+
 	output := oktetoLog.GetOutputBuffer()
 	outputData := translateOutput(output)
 	cmap.Data[outputField] = base64.StdEncoding.EncodeToString(outputData)
@@ -390,6 +715,36 @@ func removeSensitiveDataFromGitURL(gitURL string) string {
 	return parsedRepo.String()
 }
 
+// GetRepositories returns the sibling repositories stored in the configmap of a development environment
+func GetRepositories(ctx context.Context, name, namespace string, c kubernetes.Interface) ([]Repository, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+
+	val, ok := cmap.Data[repositoriesField]
+	if !ok {
+		return []Repository{}, nil
+	}
+	var repositories []Repository
+	if err := json.Unmarshal([]byte(val), &repositories); err != nil {
+		return nil, err
+	}
+	return repositories, nil
+}
+
+func translateRepositories(repositories []Repository) string {
+	if len(repositories) == 0 {
+		return ""
+	}
+	encoded, err := json.Marshal(repositories)
+	if err != nil {
+		oktetoLog.Infof("could not encode sibling repositories: %s", err)
+		return ""
+	}
+	return string(encoded)
+}
+
 func translateVariables(variables []string) string {
 	var v []types.DeployVariable
 	for _, item := range variables {
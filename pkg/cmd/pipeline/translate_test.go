@@ -20,8 +20,10 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -82,6 +84,33 @@ func Test_translateConfigMap(t *testing.T) {
 	}
 }
 
+func Test_translateConfigMapWithCommit(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	data := &CfgData{
+		Name:       "test",
+		Namespace:  "test",
+		Status:     ProgressingStatus,
+		Repository: "https://github.com/okteto/movies",
+		Branch:     "main",
+		Commit:     "0123456789abcdef0123456789abcdef01234567",
+	}
+	cfg, err := TranslateConfigMapAndDeploy(ctx, data, fakeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", cfg.Data[commitField])
+
+	data.Commit = ""
+	cfg, err = TranslateConfigMapAndDeploy(ctx, data, fakeClient)
+	assert.NoError(t, err)
+	assert.NotContains(t, cfg.Data, commitField)
+
+	data.Repository = ""
+	data.Commit = "0123456789abcdef0123456789abcdef01234567"
+	cfg, err = TranslateConfigMapAndDeploy(ctx, data, fakeClient)
+	assert.NoError(t, err)
+	assert.NotContains(t, cfg.Data, commitField)
+}
+
 func Test_updateEnvsWithoutError(t *testing.T) {
 	ctx := context.Background()
 	namespace := "test"
@@ -336,3 +365,273 @@ func decodePhases(encodedPhases string) []phaseJSON {
 	_ = json.Unmarshal([]byte(encodedPhases), &phases)
 	return phases
 }
+
+func Test_UpdateOutputs(t *testing.T) {
+	ctx := context.Background()
+	name := "test"
+	namespace := "test-namespace"
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName(name),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			statusField: DeployedStatus,
+		},
+	})
+
+	err := UpdateOutputs(ctx, name, namespace, map[string]string{"API_URL": "https://api.okteto.dev"}, c)
+	assert.NoError(t, err)
+
+	outputs, err := GetOutputs(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_URL": "https://api.okteto.dev"}, outputs)
+
+	err = UpdateOutputs(ctx, name, namespace, nil, c)
+	assert.NoError(t, err)
+
+	cmap, err := c.CoreV1().ConfigMaps(namespace).Get(ctx, TranslatePipelineName(name), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, cmap.Data, OutputsField)
+	assert.Equal(t, DeployedStatus, cmap.Data[statusField])
+
+	outputs, err = GetOutputs(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Empty(t, outputs)
+}
+
+func Test_UpdateResources(t *testing.T) {
+	ctx := context.Background()
+	name := "test"
+	namespace := "test-namespace"
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName(name),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			statusField: DeployedStatus,
+		},
+	})
+
+	resources, err := GetResources(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Empty(t, resources)
+
+	err = UpdateResources(ctx, name, namespace, []string{"services/api", "deployments/api"}, c)
+	assert.NoError(t, err)
+
+	resources, err = GetResources(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"deployments/api", "services/api"}, resources)
+
+	err = UpdateResources(ctx, name, namespace, nil, c)
+	assert.NoError(t, err)
+
+	cmap, err := c.CoreV1().ConfigMaps(namespace).Get(ctx, TranslatePipelineName(name), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, cmap.Data, ResourcesField)
+	assert.Equal(t, DeployedStatus, cmap.Data[statusField])
+}
+
+func Test_GetResourcesErrors(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test-namespace"
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("invalid"),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			ResourcesField: "not-json",
+		},
+	})
+
+	_, err := GetResources(ctx, "invalid", namespace, c)
+	assert.Error(t, err)
+
+	_, err = GetResources(ctx, "not-found", namespace, c)
+	assert.True(t, oktetoErrors.IsNotFound(err))
+}
+
+func Test_LastSuccessfulDeploy(t *testing.T) {
+	ctx := context.Background()
+	name := "test"
+	namespace := "test-namespace"
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName(name),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			statusField: DeployedStatus,
+		},
+	})
+
+	state, err := GetLastSuccessfulDeploy(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	expected := DeployedState{
+		Images:    map[string]string{"OKTETO_BUILD_API_IMAGE": "okteto.dev/api:1234"},
+		Commands:  []model.DeployCommand{{Name: "deploy", Command: "helm upgrade --install api chart"}},
+		Variables: []string{"PASSWORD=secret"},
+		Resources: []string{"deployments/api"},
+	}
+	err = UpdateLastSuccessfulDeploy(ctx, name, namespace, expected, c)
+	assert.NoError(t, err)
+
+	cmap, err := c.CoreV1().ConfigMaps(namespace).Get(ctx, TranslatePipelineName(name), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, cmap.Data, LastSuccessfulDeployField)
+
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, translateLastSuccessfulDeployName(name), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, string(secret.Data[LastSuccessfulDeployField]), "PASSWORD=secret")
+
+	expected.Images["OKTETO_BUILD_API_IMAGE"] = "okteto.dev/api:5678"
+	err = UpdateLastSuccessfulDeploy(ctx, name, namespace, expected, c)
+	assert.NoError(t, err)
+
+	state, err = GetLastSuccessfulDeploy(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, state)
+
+	_, err = GetLastSuccessfulDeploy(ctx, "not-found", namespace, c)
+	assert.True(t, oktetoErrors.IsNotFound(err))
+}
+
+func Test_GetOutputsErrors(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test-namespace"
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("invalid"),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			OutputsField: "not-json",
+		},
+	})
+
+	_, err := GetOutputs(ctx, "invalid", namespace, c)
+	assert.Error(t, err)
+
+	_, err = GetOutputs(ctx, "not-found", namespace, c)
+	assert.True(t, oktetoErrors.IsNotFound(err))
+
+	err = UpdateOutputs(ctx, "not-found", namespace, map[string]string{"KEY": "value"}, c)
+	assert.Error(t, err)
+}
+
+func Test_translateConfigMapWithOwner(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	data := &CfgData{
+		Name:      "movies-cindy",
+		Namespace: "test",
+		Status:    ProgressingStatus,
+		Owner:     "cindy",
+	}
+	cfg, err := TranslateConfigMapAndDeploy(ctx, data, fakeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, "okteto-git-movies-cindy", cfg.Name)
+	assert.Equal(t, "cindy", cfg.Labels[model.OwnerLabel])
+}
+
+func Test_SeedChecksums(t *testing.T) {
+	ctx := context.Background()
+	name := "test"
+	namespace := "test-namespace"
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName(name),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			statusField: DeployedStatus,
+		},
+	})
+
+	checksums, err := GetSeedChecksums(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Empty(t, checksums)
+
+	err = UpdateSeedChecksums(ctx, name, namespace, map[string]string{"db": "abc"}, c)
+	assert.NoError(t, err)
+
+	checksums, err = GetSeedChecksums(ctx, name, namespace, c)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"db": "abc"}, checksums)
+
+	cmap, err := c.CoreV1().ConfigMaps(namespace).Get(ctx, TranslatePipelineName(name), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, DeployedStatus, cmap.Data[statusField])
+
+	_, err = GetSeedChecksums(ctx, "unknown", namespace, c)
+	assert.Error(t, err)
+}
+
+func Test_translateConfigMapWithRepositories(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	data := &CfgData{
+		Name:       "movies",
+		Namespace:  "test",
+		Status:     ProgressingStatus,
+		Repository: "https://github.com/okteto/movies",
+		Repositories: []Repository{
+			{
+				Path:   "../api",
+				URL:    "https://github.com/okteto/api",
+				Branch: "main",
+				Commit: "0123456789abcdef0123456789abcdef01234567",
+			},
+		},
+	}
+	cfg, err := TranslateConfigMapAndDeploy(ctx, data, fakeClient)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"path":"../api","url":"https://github.com/okteto/api","branch":"main","commit":"0123456789abcdef0123456789abcdef01234567"}]`, cfg.Data[repositoriesField])
+
+	repositories, err := GetRepositories(ctx, "movies", "test", fakeClient)
+	require.NoError(t, err)
+	assert.Equal(t, data.Repositories, repositories)
+
+	data.Repositories = nil
+	cfg, err = TranslateConfigMapAndDeploy(ctx, data, fakeClient)
+	require.NoError(t, err)
+	assert.NotContains(t, cfg.Data, repositoriesField)
+
+	repositories, err = GetRepositories(ctx, "movies", "test", fakeClient)
+	require.NoError(t, err)
+	assert.Empty(t, repositories)
+}
+
+func Test_UpdateAttestations(t *testing.T) {
+	ctx := context.Background()
+	name := "test"
+	namespace := "test-namespace"
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName(name),
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			statusField:       DeployedStatus,
+			AttestationsField: `{"api":{"image":"okteto.dev/api@sha256:1","digests":["sha256:a"]},"web":{"image":"okteto.dev/web@sha256:2","digests":["sha256:b"]}}`,
+		},
+	})
+
+	err := UpdateAttestations(ctx, name, namespace, map[string]Attestation{
+		"api": {Image: "okteto.dev/api@sha256:3", Commit: "abc", Digests: []string{"sha256:c"}},
+	}, c)
+	assert.NoError(t, err)
+
+	cmap, err := c.CoreV1().ConfigMaps(namespace).Get(ctx, TranslatePipelineName(name), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, DeployedStatus, cmap.Data[statusField])
+	assert.JSONEq(t, `{"api":{"image":"okteto.dev/api@sha256:3","commit":"abc","digests":["sha256:c"]},"web":{"image":"okteto.dev/web@sha256:2","digests":["sha256:b"]}}`, cmap.Data[AttestationsField])
+
+	err = UpdateAttestations(ctx, "unknown", namespace, map[string]Attestation{}, c)
+	assert.Error(t, err)
+}
@@ -814,10 +814,10 @@ func DisplayWarnings(s *model.Stack) {
 func DisplayNotSupportedFieldsWarnings(warnings []string) {
 	if len(warnings) > 0 {
 		if len(warnings) == 1 {
-			oktetoLog.Warning("'%s' field is not currently supported and will be ignored.", warnings[0])
+			oktetoLog.WarningWithID(oktetoLog.WarnComposeFieldsNotSupported, "'%s' field is not currently supported and will be ignored.", warnings[0])
 		} else {
 			notSupportedFields := strings.Join(model.GroupWarningsBySvc(warnings), "\n  - ")
-			oktetoLog.Warning("The following fields are not currently supported and will be ignored: \n  - %s", notSupportedFields)
+			oktetoLog.WarningWithID(oktetoLog.WarnComposeFieldsNotSupported, "The following fields are not currently supported and will be ignored: \n  - %s", notSupportedFields)
 		}
 		oktetoLog.Yellow("Help us to decide which fields to implement next by filing an issue in https://github.com/okteto/okteto/issues/new")
 	}
@@ -831,7 +831,7 @@ func DisplayVolumeMountWarnings(warnings []string) {
 
 func DisplaySanitizedServicesWarnings(previousToNewNameMap map[string]string) {
 	for previousName, newName := range previousToNewNameMap {
-		oktetoLog.Warning("Service '%s' specified in compose file has been sanitized into '%s'. This may affect discovery service.", previousName, newName)
+		oktetoLog.WarningWithID(oktetoLog.WarnServiceNameSanitized, "Service '%s' specified in compose file has been sanitized into '%s'. This may affect discovery service.", previousName, newName)
 	}
 }
 
@@ -897,7 +897,7 @@ func AddDependentServicesIfNotPresent(ctx context.Context, s *model.Stack, svcsT
 	if len(initialSvcsToDeploy) != len(svcsToDeploy) {
 		added := getAddedSvcs(initialSvcsToDeploy, svcsToDeployWithDependencies)
 
-		oktetoLog.Warning("The following services need to be deployed because the services passed as arguments depend on them: [%s]", strings.Join(added, ", "))
+		oktetoLog.WarningWithID(oktetoLog.WarnDependantServicesDeployed, "The following services need to be deployed because the services passed as arguments depend on them: [%s]", strings.Join(added, ", "))
 	}
 	return svcsToDeployWithDependencies
 }
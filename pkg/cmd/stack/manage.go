@@ -0,0 +1,297 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ServiceStatusNotDeployed is the status of a service without workload in the cluster
+	ServiceStatusNotDeployed = "not deployed"
+	// ServiceStatusRunning is the status of a service with all its replicas ready
+	ServiceStatusRunning = "running"
+	// ServiceStatusStarting is the status of a service with replicas not ready yet
+	ServiceStatusStarting = "starting"
+	// ServiceStatusStopped is the status of a service scaled to zero replicas
+	ServiceStatusStopped = "stopped"
+	// ServiceStatusCompleted is the status of a job service that finished successfully
+	ServiceStatusCompleted = "completed"
+	// ServiceStatusFailed is the status of a job service that failed
+	ServiceStatusFailed = "failed"
+
+	serviceKindDeployment  = "deployment"
+	serviceKindStatefulset = "statefulset"
+	serviceKindJob         = "job"
+)
+
+// ServiceStatus is the status of the kubernetes workload of a compose service
+type ServiceStatus struct {
+	Name     string
+	Kind     string
+	Status   string
+	Ready    int32
+	Replicas int32
+}
+
+// LogsOptions defines the options to fetch the logs of the compose services
+type LogsOptions struct {
+	Tail       int64
+	Follow     bool
+	Timestamps bool
+}
+
+// GetServicesStatus returns the status of the workloads of the compose services.
+// If svcNames is empty, it returns the status of all the services
+func GetServicesStatus(ctx context.Context, s *model.Stack, svcNames []string, c kubernetes.Interface) ([]ServiceStatus, error) {
+	svcNames, err := getSelectedServices(s, svcNames)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ServiceStatus{}
+	for _, svcName := range svcNames {
+		status, err := getServiceStatus(ctx, s, svcName, c)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+func getServiceStatus(ctx context.Context, s *model.Stack, svcName string, c kubernetes.Interface) (ServiceStatus, error) {
+	svc := s.Services[svcName]
+	status := ServiceStatus{Name: svcName, Status: ServiceStatusNotDeployed}
+
+	switch {
+	case svc.IsDeployment():
+		status.Kind = serviceKindDeployment
+		d, err := c.AppsV1().Deployments(s.Namespace).Get(ctx, svcName, metav1.GetOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return status, nil
+			}
+			return status, fmt.Errorf("failed to get deployment '%s': %w", svcName, err)
+		}
+		if !isStackResource(s, d.Labels) {
+			return status, nil
+		}
+		status.Ready = d.Status.ReadyReplicas
+		status.Replicas = getReplicas(d.Spec.Replicas)
+		status.Status = getReplicatedStatus(status.Ready, status.Replicas)
+	case svc.IsStatefulset():
+		status.Kind = serviceKindStatefulset
+		sfs, err := c.AppsV1().StatefulSets(s.Namespace).Get(ctx, svcName, metav1.GetOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return status, nil
+			}
+			return status, fmt.Errorf("failed to get statefulset '%s': %w", svcName, err)
+		}
+		if !isStackResource(s, sfs.Labels) {
+			return status, nil
+		}
+		status.Ready = sfs.Status.ReadyReplicas
+		status.Replicas = getReplicas(sfs.Spec.Replicas)
+		status.Status = getReplicatedStatus(status.Ready, status.Replicas)
+	case svc.IsJob():
+		status.Kind = serviceKindJob
+		job, err := c.BatchV1().Jobs(s.Namespace).Get(ctx, svcName, metav1.GetOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return status, nil
+			}
+			return status, fmt.Errorf("failed to get job '%s': %w", svcName, err)
+		}
+		if !isStackResource(s, job.Labels) {
+			return status, nil
+		}
+		status.Ready = job.Status.Active
+		status.Replicas = getReplicas(job.Spec.Completions)
+		switch {
+		case job.Status.Succeeded >= status.Replicas:
+			status.Status = ServiceStatusCompleted
+		case job.Spec.BackoffLimit != nil && job.Status.Failed > *job.Spec.BackoffLimit:
+			status.Status = ServiceStatusFailed
+		default:
+			status.Status = ServiceStatusRunning
+		}
+	}
+	return status, nil
+}
+
+// RestartServices recreates the pods of the compose services by updating their pod template.
+// If svcNames is empty, it restarts all the services
+func RestartServices(ctx context.Context, s *model.Stack, svcNames []string, c kubernetes.Interface) error {
+	svcNames, err := getSelectedServices(s, svcNames)
+	if err != nil {
+		return err
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, model.OktetoRestartAnnotation, time.Now().UTC().Format(time.RFC3339)))
+	for _, svcName := range svcNames {
+		svc := s.Services[svcName]
+		switch {
+		case svc.IsDeployment():
+			_, err = c.AppsV1().Deployments(s.Namespace).Patch(ctx, svcName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		case svc.IsStatefulset():
+			_, err = c.AppsV1().StatefulSets(s.Namespace).Patch(ctx, svcName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		default:
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("service '%s' can't be restarted because it runs as a job", svcName),
+				Hint: "Redeploy the service to run it again",
+			}
+		}
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("service '%s' is not deployed", svcName),
+					Hint: "Deploy your compose services before restarting them",
+				}
+			}
+			return fmt.Errorf("failed to restart service '%s': %w", svcName, err)
+		}
+		oktetoLog.Success("Service '%s' restarted", svcName)
+	}
+	return nil
+}
+
+// StreamServicesLogs writes the logs of the pods of the compose services to w, prefixing each line with the service name.
+// If svcNames is empty, it fetches the logs of all the services
+func StreamServicesLogs(ctx context.Context, s *model.Stack, svcNames []string, opts LogsOptions, w io.Writer, c kubernetes.Interface) error {
+	svcNames, err := getSelectedServices(s, svcNames)
+	if err != nil {
+		return err
+	}
+
+	type podLogs struct {
+		svcName string
+		pod     string
+	}
+	targets := []podLogs{}
+	podsBySvc := map[string]int{}
+	for _, svcName := range svcNames {
+		selector := fmt.Sprintf("%s,%s=%s", s.GetLabelSelector(), model.StackServiceNameLabel, svcName)
+		podList, err := c.CoreV1().Pods(s.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return fmt.Errorf("failed to list the pods of service '%s': %w", svcName, err)
+		}
+		sort.Slice(podList.Items, func(i, j int) bool {
+			return podList.Items[i].Name < podList.Items[j].Name
+		})
+		for _, pod := range podList.Items {
+			targets = append(targets, podLogs{svcName: svcName, pod: pod.Name})
+			podsBySvc[svcName]++
+		}
+	}
+	if len(targets) == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("no pods found for the selected services"),
+			Hint: "Deploy your compose services before fetching their logs",
+		}
+	}
+
+	logOptions := &apiv1.PodLogOptions{
+		Follow:     opts.Follow,
+		Timestamps: opts.Timestamps,
+	}
+	if opts.Tail >= 0 {
+		logOptions.TailLines = &opts.Tail
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(chan error, len(targets))
+	for _, target := range targets {
+		wg.Add(1)
+		go func(svcName, pod string) {
+			defer wg.Done()
+			prefix := svcName
+			if podsBySvc[svcName] > 1 {
+				prefix = pod
+			}
+			stream, err := c.CoreV1().Pods(s.Namespace).GetLogs(pod, logOptions).Stream(ctx)
+			if err != nil {
+				errs <- fmt.Errorf("failed to get the logs of pod '%s': %w", pod, err)
+				return
+			}
+			defer stream.Close()
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				mu.Lock()
+				fmt.Fprintf(w, "%s | %s\n", prefix, scanner.Text())
+				mu.Unlock()
+			}
+			if err := scanner.Err(); err != nil && ctx.Err() == nil {
+				oktetoLog.Infof("error reading the logs of pod '%s': %s", pod, err)
+			}
+		}(target.svcName, target.pod)
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
+// getSelectedServices validates svcNames against the compose services and returns them sorted, or all the services if empty
+func getSelectedServices(s *model.Stack, svcNames []string) ([]string, error) {
+	if len(svcNames) == 0 {
+		for svcName := range s.Services {
+			svcNames = append(svcNames, svcName)
+		}
+	} else if err := ValidateDefinedServices(s, svcNames); err != nil {
+		return nil, err
+	}
+	result := append([]string{}, svcNames...)
+	sort.Strings(result)
+	return result, nil
+}
+
+func isStackResource(s *model.Stack, labels map[string]string) bool {
+	return labels[model.StackNameLabel] == format.ResourceK8sMetaString(s.Name)
+}
+
+func getReplicatedStatus(ready, replicas int32) string {
+	switch {
+	case replicas == 0:
+		return ServiceStatusStopped
+	case ready >= replicas:
+		return ServiceStatusRunning
+	default:
+		return ServiceStatusStarting
+	}
+}
+
+func getReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
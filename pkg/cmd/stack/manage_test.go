@@ -0,0 +1,140 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func getManageTestStack() *model.Stack {
+	return &model.Stack{
+		Name:      "stack-test",
+		Namespace: "ns",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:         "api",
+				RestartPolicy: corev1.RestartPolicyAlways,
+			},
+			"db": {
+				Image:         "db",
+				RestartPolicy: corev1.RestartPolicyAlways,
+				Volumes:       []build.VolumeMounts{{RemotePath: "/data"}},
+			},
+			"migrate": {
+				Image:         "migrate",
+				RestartPolicy: corev1.RestartPolicyNever,
+			},
+			"worker": {
+				Image:         "worker",
+				RestartPolicy: corev1.RestartPolicyAlways,
+			},
+		},
+	}
+}
+
+func getStackObjectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "ns",
+		Labels: map[string]string{
+			model.StackNameLabel:        "stack-test",
+			model.StackServiceNameLabel: name,
+		},
+	}
+}
+
+func Test_GetServicesStatus(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: getStackObjectMeta("api"),
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: getStackObjectMeta("db"),
+			Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(1)},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 1},
+		},
+		&batchv1.Job{
+			ObjectMeta: getStackObjectMeta("migrate"),
+			Spec:       batchv1.JobSpec{Completions: pointer.Int32(1)},
+			Status:     batchv1.JobStatus{Succeeded: 1},
+		},
+	)
+
+	statuses, err := GetServicesStatus(ctx, getManageTestStack(), nil, c)
+	require.NoError(t, err)
+	expected := []ServiceStatus{
+		{Name: "api", Kind: serviceKindDeployment, Status: ServiceStatusStarting, Ready: 1, Replicas: 2},
+		{Name: "db", Kind: serviceKindStatefulset, Status: ServiceStatusRunning, Ready: 1, Replicas: 1},
+		{Name: "migrate", Kind: serviceKindJob, Status: ServiceStatusCompleted, Replicas: 1},
+		{Name: "worker", Kind: serviceKindDeployment, Status: ServiceStatusNotDeployed},
+	}
+	assert.Equal(t, expected, statuses)
+
+	statuses, err = GetServicesStatus(ctx, getManageTestStack(), []string{"db"}, c)
+	require.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	_, err = GetServicesStatus(ctx, getManageTestStack(), []string{"unknown"}, c)
+	assert.Error(t, err)
+}
+
+func Test_RestartServices(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: getStackObjectMeta("api")},
+		&appsv1.StatefulSet{ObjectMeta: getStackObjectMeta("db")},
+	)
+	s := getManageTestStack()
+
+	require.NoError(t, RestartServices(ctx, s, []string{"api", "db"}, c))
+	d, err := c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, d.Spec.Template.Annotations[model.OktetoRestartAnnotation])
+	sfs, err := c.AppsV1().StatefulSets("ns").Get(ctx, "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, sfs.Spec.Template.Annotations[model.OktetoRestartAnnotation])
+
+	assert.Error(t, RestartServices(ctx, s, []string{"migrate"}, c))
+	assert.Error(t, RestartServices(ctx, s, []string{"worker"}, c))
+}
+
+func Test_StreamServicesLogs(t *testing.T) {
+	ctx := context.Background()
+	s := getManageTestStack()
+	c := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: getStackObjectMeta("api")},
+	)
+
+	var out bytes.Buffer
+	require.NoError(t, StreamServicesLogs(ctx, s, []string{"api"}, LogsOptions{Tail: -1}, &out, c))
+	assert.Equal(t, "api | fake logs\n", out.String())
+
+	assert.Error(t, StreamServicesLogs(ctx, s, []string{"worker"}, LogsOptions{}, &out, c))
+}
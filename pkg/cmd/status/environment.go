@@ -0,0 +1,363 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/helmrelease"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/k8s/ingresses"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// RolloutAvailable indicates all the replicas of a service are updated and available
+	RolloutAvailable = "available"
+	// RolloutProgressing indicates a service is being rolled out
+	RolloutProgressing = "progressing"
+	// RolloutFailed indicates the rollout of a service exceeded its progress deadline
+	RolloutFailed = "failed"
+	// RolloutScaledDown indicates a service has no replicas
+	RolloutScaledDown = "scaled down"
+
+	// ImageMatch indicates the running image is the one expected by the okteto manifest
+	ImageMatch = "match"
+	// ImageMismatch indicates the running image is not the one expected by the okteto manifest
+	ImageMismatch = "mismatch"
+
+	endpointHealthTimeout = 5 * time.Second
+)
+
+// EnvironmentStatus is the health summary of all the resources of a namespace
+type EnvironmentStatus struct {
+	Namespace   string           `json:"namespace"`
+	Pipelines   []PipelineStatus `json:"pipelines"`
+	Services    []ServiceStatus  `json:"services"`
+	Endpoints   []EndpointStatus `json:"endpoints"`
+	DevSessions []DevSession     `json:"devSessions"`
+	// HelmReleases are the helm releases required by the okteto manifest and their drift
+	HelmReleases []helmrelease.Status `json:"helmReleases,omitempty"`
+}
+
+// PipelineStatus is the status of a pipeline deployed in the namespace
+type PipelineStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// ServiceStatus is the rollout status of a deployment or statefulset
+type ServiceStatus struct {
+	Name          string `json:"name"`
+	Kind          string `json:"kind"`
+	Rollout       string `json:"rollout"`
+	Image         string `json:"image"`
+	Digest        string `json:"digest,omitempty"`
+	ExpectedImage string `json:"expectedImage,omitempty"`
+	ImageStatus   string `json:"imageStatus,omitempty"`
+	Ready         int32  `json:"ready"`
+	Desired       int32  `json:"desired"`
+	InDevMode     bool   `json:"inDevMode"`
+}
+
+// EndpointStatus is the health of an endpoint of the namespace
+type EndpointStatus struct {
+	URL        string `json:"url"`
+	Error      string `json:"error,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Healthy    bool   `json:"healthy"`
+}
+
+// DevSession is a development container running in the namespace
+type DevSession struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Ready bool   `json:"ready"`
+}
+
+// EndpointChecker returns the status code of a request to an endpoint
+type EndpointChecker func(ctx context.Context, url string) (int, error)
+
+// EnvironmentStatusGetter collects the status of all the resources of a namespace
+type EnvironmentStatusGetter struct {
+	k8sClient     kubernetes.Interface
+	ingressClient *ingresses.Client
+	checkEndpoint EndpointChecker
+}
+
+// NewEnvironmentStatusGetter returns an EnvironmentStatusGetter that checks the endpoints with an http client
+func NewEnvironmentStatusGetter(c kubernetes.Interface, ic *ingresses.Client) *EnvironmentStatusGetter {
+	return &EnvironmentStatusGetter{
+		k8sClient:     c,
+		ingressClient: ic,
+		checkEndpoint: checkEndpointWithHTTP,
+	}
+}
+
+// Get returns the status of the namespace. The manifest is optional, and when provided the running images are
+// compared with the images defined in its build section, and the helm releases it requires are checked
+func (g *EnvironmentStatusGetter) Get(ctx context.Context, namespace string, manifest *model.Manifest) (*EnvironmentStatus, error) {
+	result := &EnvironmentStatus{
+		Namespace:   namespace,
+		Pipelines:   []PipelineStatus{},
+		Services:    []ServiceStatus{},
+		Endpoints:   []EndpointStatus{},
+		DevSessions: []DevSession{},
+	}
+
+	pipelines, err := g.getPipelines(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+	result.Pipelines = append(result.Pipelines, pipelines...)
+
+	if err := g.addDeployments(ctx, namespace, manifest, result); err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	if err := g.addStatefulsets(ctx, namespace, manifest, result); err != nil {
+		return nil, fmt.Errorf("failed to get statefulsets: %w", err)
+	}
+	sort.Slice(result.Services, func(i, j int) bool {
+		return result.Services[i].Name < result.Services[j].Name
+	})
+	sort.Slice(result.DevSessions, func(i, j int) bool {
+		return result.DevSessions[i].Name < result.DevSessions[j].Name
+	})
+
+	if manifest != nil && len(manifest.HelmReleases) > 0 {
+		releases, err := helmrelease.Check(ctx, manifest.HelmReleases, namespace, g.k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get helm releases: %w", err)
+		}
+		result.HelmReleases = releases
+	}
+
+	if g.ingressClient != nil {
+		endpoints, err := g.ingressClient.GetEndpointsBySelector(ctx, namespace, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get endpoints: %w", err)
+		}
+		sort.Strings(endpoints)
+		for _, url := range endpoints {
+			result.Endpoints = append(result.Endpoints, g.getEndpointStatus(ctx, url))
+		}
+	}
+	return result, nil
+}
+
+func (g *EnvironmentStatusGetter) getPipelines(ctx context.Context, namespace string) ([]PipelineStatus, error) {
+	cmList, err := configmaps.List(ctx, namespace, model.GitDeployLabel, g.k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	result := []PipelineStatus{}
+	for _, cm := range cmList {
+		result = append(result, PipelineStatus{Name: cm.Data["name"], Status: cm.Data["status"]})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (g *EnvironmentStatusGetter) addDeployments(ctx context.Context, namespace string, manifest *model.Manifest, result *EnvironmentStatus) error {
+	dList, err := g.k8sClient.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range dList.Items {
+		d := &dList.Items[i]
+		if d.Labels[model.DevCloneLabel] != "" {
+			result.DevSessions = append(result.DevSessions, DevSession{
+				Name:  d.Name,
+				Kind:  "Deployment",
+				Ready: d.Status.ReadyReplicas > 0,
+			})
+			continue
+		}
+		svc := ServiceStatus{
+			Name:      d.Name,
+			Kind:      "Deployment",
+			Rollout:   getDeploymentRollout(d),
+			Ready:     d.Status.ReadyReplicas,
+			Desired:   getReplicas(d.Spec.Replicas),
+			InDevMode: d.Labels[constants.DevLabel] == "true",
+		}
+		g.setImageStatus(ctx, &svc, namespace, d.Spec.Selector, d.Spec.Template.Spec.Containers, manifest)
+		result.Services = append(result.Services, svc)
+	}
+	return nil
+}
+
+func (g *EnvironmentStatusGetter) addStatefulsets(ctx context.Context, namespace string, manifest *model.Manifest, result *EnvironmentStatus) error {
+	sfsList, err := g.k8sClient.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range sfsList.Items {
+		sfs := &sfsList.Items[i]
+		if sfs.Labels[model.DevCloneLabel] != "" {
+			result.DevSessions = append(result.DevSessions, DevSession{
+				Name:  sfs.Name,
+				Kind:  "StatefulSet",
+				Ready: sfs.Status.ReadyReplicas > 0,
+			})
+			continue
+		}
+		svc := ServiceStatus{
+			Name:      sfs.Name,
+			Kind:      "StatefulSet",
+			Rollout:   getStatefulSetRollout(sfs),
+			Ready:     sfs.Status.ReadyReplicas,
+			Desired:   getReplicas(sfs.Spec.Replicas),
+			InDevMode: sfs.Labels[constants.DevLabel] == "true",
+		}
+		g.setImageStatus(ctx, &svc, namespace, sfs.Spec.Selector, sfs.Spec.Template.Spec.Containers, manifest)
+		result.Services = append(result.Services, svc)
+	}
+	return nil
+}
+
+// setImageStatus sets the image of the first container of the service, the digest running in its pods
+// and whether it matches the image defined in the build section of the manifest for the same service name
+func (g *EnvironmentStatusGetter) setImageStatus(ctx context.Context, svc *ServiceStatus, namespace string, selector *metav1.LabelSelector, containers []apiv1.Container, manifest *model.Manifest) {
+	if len(containers) == 0 {
+		return
+	}
+	svc.Image = containers[0].Image
+	svc.Digest = g.getRunningDigest(ctx, namespace, selector, containers[0].Name)
+
+	if manifest == nil {
+		return
+	}
+	info, ok := manifest.Build[svc.Name]
+	if !ok || info.Image == "" {
+		return
+	}
+	svc.ExpectedImage = info.Image
+	svc.ImageStatus = ImageMismatch
+	if getImageRepository(svc.ExpectedImage) == getImageRepository(svc.Image) {
+		svc.ImageStatus = ImageMatch
+	}
+}
+
+func (g *EnvironmentStatusGetter) getRunningDigest(ctx context.Context, namespace string, selector *metav1.LabelSelector, container string) string {
+	if selector == nil {
+		return ""
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		oktetoLog.Infof("invalid label selector: %s", err)
+		return ""
+	}
+	pods, err := g.k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		oktetoLog.Infof("failed to list pods: %s", err)
+		return ""
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != container || status.ImageID == "" {
+				continue
+			}
+			if _, digest, found := strings.Cut(status.ImageID, "@"); found {
+				return digest
+			}
+			return status.ImageID
+		}
+	}
+	return ""
+}
+
+func (g *EnvironmentStatusGetter) getEndpointStatus(ctx context.Context, url string) EndpointStatus {
+	result := EndpointStatus{URL: url}
+	code, err := g.checkEndpoint(ctx, url)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.StatusCode = code
+	result.Healthy = code < http.StatusInternalServerError
+	return result
+}
+
+func checkEndpointWithHTTP(ctx context.Context, url string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, endpointHealthTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func getDeploymentRollout(d *appsv1.Deployment) string {
+	desired := getReplicas(d.Spec.Replicas)
+	if desired == 0 {
+		return RolloutScaledDown
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return RolloutFailed
+		}
+	}
+	if d.Status.ObservedGeneration >= d.Generation && d.Status.UpdatedReplicas == desired && d.Status.AvailableReplicas == desired {
+		return RolloutAvailable
+	}
+	return RolloutProgressing
+}
+
+func getStatefulSetRollout(sfs *appsv1.StatefulSet) string {
+	desired := getReplicas(sfs.Spec.Replicas)
+	if desired == 0 {
+		return RolloutScaledDown
+	}
+	if sfs.Status.ObservedGeneration >= sfs.Generation && sfs.Status.UpdateRevision == sfs.Status.CurrentRevision && sfs.Status.ReadyReplicas == desired {
+		return RolloutAvailable
+	}
+	return RolloutProgressing
+}
+
+func getReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// getImageRepository returns the image without tag or digest
+func getImageRepository(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	lastSlash := strings.LastIndex(image, "/")
+	if idx := strings.LastIndex(image, ":"); idx > lastSlash {
+		return image[:idx]
+	}
+	return image
+}
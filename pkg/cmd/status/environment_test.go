@@ -0,0 +1,193 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/helmrelease"
+	"github.com/okteto/okteto/pkg/k8s/ingresses"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func TestEnvironmentStatusGetter(t *testing.T) {
+	ns := "test"
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	objects := []runtime.Object{
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "okteto-git-app", Namespace: ns, Labels: map[string]string{model.GitDeployLabel: "true"}},
+			Data:       map[string]string{"name": "app", "status": "deployed"},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: ns, Labels: map[string]string{constants.DevLabel: "true"}},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(1),
+				Selector: selector,
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: "api", Image: "registry/api:1.0"}}}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-okteto", Namespace: ns, Labels: map[string]string{model.DevCloneLabel: "uid"}},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: ns},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: int32Ptr(1),
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: "db", Image: "postgres:14"}}}},
+			},
+			Status: appsv1.StatefulSetStatus{CurrentRevision: "1", UpdateRevision: "2"},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: ns, Labels: map[string]string{"app": "api"}},
+			Status: apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{
+				{Name: "api", ImageID: "docker-pullable://registry/api@sha256:123"},
+			}},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: ns},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host: "api.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Path: "/"}, {Path: "/broken"}},
+				}},
+			}}},
+		},
+	}
+	c := fake.NewSimpleClientset(objects...)
+	g := &EnvironmentStatusGetter{
+		k8sClient:     c,
+		ingressClient: ingresses.NewIngressClient(c, true),
+		checkEndpoint: func(_ context.Context, url string) (int, error) {
+			if url == "https://api.example.com/broken" {
+				return 0, errors.New("connection refused")
+			}
+			return 200, nil
+		},
+	}
+	manifest := &model.Manifest{Build: build.ManifestBuild{"api": {Image: "registry/api:2.0"}, "db": {Image: "mysql:8"}}}
+
+	result, err := g.Get(context.Background(), ns, manifest)
+	require.NoError(t, err)
+
+	expected := &EnvironmentStatus{
+		Namespace: ns,
+		Pipelines: []PipelineStatus{{Name: "app", Status: "deployed"}},
+		Services: []ServiceStatus{
+			{
+				Name:          "api",
+				Kind:          "Deployment",
+				Rollout:       RolloutAvailable,
+				Image:         "registry/api:1.0",
+				Digest:        "sha256:123",
+				ExpectedImage: "registry/api:2.0",
+				ImageStatus:   ImageMatch,
+				Ready:         1,
+				Desired:       1,
+				InDevMode:     true,
+			},
+			{
+				Name:          "db",
+				Kind:          "StatefulSet",
+				Rollout:       RolloutProgressing,
+				Image:         "postgres:14",
+				ExpectedImage: "mysql:8",
+				ImageStatus:   ImageMismatch,
+				Desired:       1,
+			},
+		},
+		Endpoints: []EndpointStatus{
+			{URL: "https://api.example.com/", StatusCode: 200, Healthy: true},
+			{URL: "https://api.example.com/broken", Error: "connection refused"},
+		},
+		DevSessions: []DevSession{{Name: "api-okteto", Kind: "Deployment", Ready: true}},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestEnvironmentStatusGetterWithHelmReleases(t *testing.T) {
+	ns := "test"
+	g := &EnvironmentStatusGetter{
+		k8sClient: fake.NewSimpleClientset(),
+	}
+	manifest := &model.Manifest{
+		HelmReleases: helmrelease.Section{{Name: "postgresql", Version: "12.0.0"}},
+	}
+
+	result, err := g.Get(context.Background(), ns, manifest)
+
+	require.NoError(t, err)
+	expected := []helmrelease.Status{{Name: "postgresql", Namespace: ns, RequiredVersion: "12.0.0", State: helmrelease.StateMissing}}
+	assert.Equal(t, expected, result.HelmReleases)
+}
+
+func TestGetDeploymentRollout(t *testing.T) {
+	tests := []struct {
+		deployment *appsv1.Deployment
+		name       string
+		expected   string
+	}{
+		{
+			name:       "scaled down",
+			deployment: &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(0)}},
+			expected:   RolloutScaledDown,
+		},
+		{
+			name: "failed",
+			deployment: &appsv1.Deployment{Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"},
+			}}},
+			expected: RolloutFailed,
+		},
+		{
+			name: "progressing",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+			},
+			expected: RolloutProgressing,
+		},
+		{
+			name:       "available",
+			deployment: &appsv1.Deployment{Status: appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 1}},
+			expected:   RolloutAvailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getDeploymentRollout(tt.deployment))
+		})
+	}
+}
+
+func TestGetImageRepository(t *testing.T) {
+	assert.Equal(t, "registry:5000/api", getImageRepository("registry:5000/api:1.0"))
+	assert.Equal(t, "registry:5000/api", getImageRepository("registry:5000/api"))
+	assert.Equal(t, "api", getImageRepository("api@sha256:123"))
+}
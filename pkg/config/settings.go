@@ -0,0 +1,326 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	settingsFile = "config.yaml"
+
+	// LogLevelSetting is the default value of the --log-level flag
+	LogLevelSetting = "log-level"
+
+	// LogOutputSetting is the default value of the --log-output flag, which defines the progress style
+	LogOutputSetting = "log-output"
+
+	// NamespacePrefixSetting is the prefix added to the namespaces created by 'okteto namespace create'
+	NamespacePrefixSetting = "namespace-prefix"
+
+	// TimeoutSetting is the default timeout of the okteto commands
+	TimeoutSetting = "timeout"
+
+	// TelemetrySetting enables or disables the okteto analytics
+	TelemetrySetting = "telemetry"
+
+	// PerUserNamesSetting suffixes the dev environment names with the username to share namespaces with other users
+	PerUserNamesSetting = "per-user-names"
+
+	// ManageHostsSetting adds the hostnames of the forwarded services to the hosts file during 'okteto up'
+	ManageHostsSetting = "manage-hosts"
+
+	// PluginIndexSetting is the location of the plugin index used by 'okteto plugin install'
+	PluginIndexSetting = "plugin-index"
+
+	// SourceEnv means the setting value comes from an environment variable
+	SourceEnv = "env"
+	// SourceContext means the setting value comes from the overrides of the current context
+	SourceContext = "context"
+	// SourceGlobal means the setting value comes from the user defaults
+	SourceGlobal = "global"
+	// SourceDefault means the setting is not configured
+	SourceDefault = "default"
+)
+
+var namespacePrefixRegex = regexp.MustCompile(`^[a-z0-9][-a-z0-9]*$`)
+
+// setting defines a key of the user configuration file
+type setting struct {
+	validate     func(string) error
+	envVar       string
+	defaultValue string
+}
+
+var settings = map[string]setting{
+	LogLevelSetting: {
+		envVar:       "OKTETO_LOG_LEVEL",
+		defaultValue: "warn",
+		validate:     oneOf("debug", "info", "warn", "error"),
+	},
+	LogOutputSetting: {
+		envVar:       "OKTETO_LOG_OUTPUT",
+		defaultValue: "tty",
+		validate:     oneOf("tty", "plain", "json"),
+	},
+	NamespacePrefixSetting: {
+		envVar: "OKTETO_NAMESPACE_PREFIX",
+		validate: func(v string) error {
+			if !namespacePrefixRegex.MatchString(v) {
+				return fmt.Errorf("it must consist of lower case alphanumeric characters or '-', and must start with an alphanumeric character")
+			}
+			return nil
+		},
+	},
+	TimeoutSetting: {
+		envVar: "OKTETO_TIMEOUT",
+		validate: func(v string) error {
+			if _, err := time.ParseDuration(v); err != nil {
+				return fmt.Errorf("it must be a valid duration (e.g. 5m)")
+			}
+			return nil
+		},
+	},
+	TelemetrySetting: {
+		envVar:   "OKTETO_TELEMETRY",
+		validate: isBool,
+	},
+	PerUserNamesSetting: {
+		envVar:   "OKTETO_PER_USER_NAMES",
+		validate: isBool,
+	},
+	ManageHostsSetting: {
+		envVar:   "OKTETO_MANAGE_HOSTS",
+		validate: isBool,
+	},
+	PluginIndexSetting: {
+		envVar: "OKTETO_PLUGIN_INDEX",
+		validate: func(v string) error {
+			if strings.TrimSpace(v) == "" {
+				return fmt.Errorf("it must be a path or a URL")
+			}
+			return nil
+		},
+	},
+}
+
+// Settings is the user-level okteto CLI configuration stored in $OKTETO_HOME/config.yaml.
+// Values in Contexts override the defaults for a given okteto context
+type Settings struct {
+	Defaults map[string]string            `yaml:",inline"`
+	Contexts map[string]map[string]string `yaml:"contexts,omitempty"`
+}
+
+// SettingValue is the resolved value of a setting and where it comes from
+type SettingValue struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// GetSettingsPath returns the path of the user configuration file
+func GetSettingsPath() string {
+	return filepath.Join(GetOktetoHome(), settingsFile)
+}
+
+// GetSettingKeys returns the sorted keys supported by the user configuration file
+func GetSettingKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LoadSettings reads the user configuration file. It returns empty settings if the file doesn't exist
+func LoadSettings(fs afero.Fs, path string) (*Settings, error) {
+	s := &Settings{
+		Defaults: map[string]string{},
+		Contexts: map[string]map[string]string{},
+	}
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+	if err := yaml.UnmarshalStrict(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", path, err)
+	}
+	if s.Defaults == nil {
+		s.Defaults = map[string]string{}
+	}
+	if s.Contexts == nil {
+		s.Contexts = map[string]map[string]string{}
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration in '%s': %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the settings to path
+func (s *Settings) Save(fs afero.Fs, path string) error {
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := fs.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, path, b, 0600)
+}
+
+// Set stores the value of key for octx, or as a default if octx is empty
+func (s *Settings) Set(octx, key, value string) error {
+	if err := validateSetting(key, value); err != nil {
+		return err
+	}
+	if octx == "" {
+		s.Defaults[key] = value
+		return nil
+	}
+	if s.Contexts[octx] == nil {
+		s.Contexts[octx] = map[string]string{}
+	}
+	s.Contexts[octx][key] = value
+	return nil
+}
+
+// Unset removes the value of key for octx, or the default if octx is empty
+func (s *Settings) Unset(octx, key string) error {
+	if _, ok := settings[key]; !ok {
+		return unknownSettingError(key)
+	}
+	if octx == "" {
+		delete(s.Defaults, key)
+		return nil
+	}
+	delete(s.Contexts[octx], key)
+	if len(s.Contexts[octx]) == 0 {
+		delete(s.Contexts, octx)
+	}
+	return nil
+}
+
+// Get resolves the value of key for octx. Environment variables take precedence over the overrides
+// of the context, which take precedence over the user defaults
+func (s *Settings) Get(octx, key string) (SettingValue, error) {
+	def, ok := settings[key]
+	if !ok {
+		return SettingValue{}, unknownSettingError(key)
+	}
+	if v := os.Getenv(def.envVar); v != "" {
+		return SettingValue{Key: key, Value: v, Source: SourceEnv}, nil
+	}
+	if v, ok := s.Contexts[octx][key]; ok && octx != "" {
+		return SettingValue{Key: key, Value: v, Source: SourceContext}, nil
+	}
+	if v, ok := s.Defaults[key]; ok {
+		return SettingValue{Key: key, Value: v, Source: SourceGlobal}, nil
+	}
+	return SettingValue{Key: key, Value: def.defaultValue, Source: SourceDefault}, nil
+}
+
+// List resolves all the settings for octx
+func (s *Settings) List(octx string) []SettingValue {
+	result := []SettingValue{}
+	for _, key := range GetSettingKeys() {
+		v, err := s.Get(octx, key)
+		if err != nil {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func (s *Settings) validate() error {
+	for key, value := range s.Defaults {
+		if err := validateSetting(key, value); err != nil {
+			return err
+		}
+	}
+	for octx, values := range s.Contexts {
+		for key, value := range values {
+			if err := validateSetting(key, value); err != nil {
+				return fmt.Errorf("context '%s': %w", octx, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetSetting returns the value of key for octx from the user configuration file.
+// It returns the built-in default if the file can't be loaded
+func GetSetting(octx, key string) string {
+	s, err := LoadSettings(afero.NewOsFs(), GetSettingsPath())
+	if err != nil {
+		oktetoLog.Infof("ignoring user configuration: %s", err)
+		s = &Settings{}
+	}
+	v, err := s.Get(octx, key)
+	if err != nil {
+		oktetoLog.Infof("failed to get setting: %s", err)
+		return ""
+	}
+	return v.Value
+}
+
+func validateSetting(key, value string) error {
+	def, ok := settings[key]
+	if !ok {
+		return unknownSettingError(key)
+	}
+	if err := def.validate(value); err != nil {
+		return fmt.Errorf("invalid value '%s' for '%s': %w", value, key, err)
+	}
+	return nil
+}
+
+func unknownSettingError(key string) error {
+	return fmt.Errorf("unknown setting '%s', supported settings are: %s", key, strings.Join(GetSettingKeys(), ", "))
+}
+
+func isBool(v string) error {
+	if _, err := strconv.ParseBool(v); err != nil {
+		return fmt.Errorf("it must be 'true' or 'false'")
+	}
+	return nil
+}
+
+func oneOf(values ...string) func(string) error {
+	return func(v string) error {
+		for _, allowed := range values {
+			if v == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("it must be one of: %s", strings.Join(values, ", "))
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSettings(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/home/.okteto/config.yaml", []byte(`log-level: info
+timeout: 5m
+contexts:
+  https://okteto.example.com:
+    namespace-prefix: cindy-
+    telemetry: "false"
+`), 0600))
+
+	s, err := LoadSettings(fs, "/home/.okteto/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"log-level": "info", "timeout": "5m"}, s.Defaults)
+	assert.Equal(t, map[string]map[string]string{
+		"https://okteto.example.com": {"namespace-prefix": "cindy-", "telemetry": "false"},
+	}, s.Contexts)
+}
+
+func TestLoadSettingsNotFound(t *testing.T) {
+	s, err := LoadSettings(afero.NewMemMapFs(), "/home/.okteto/config.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, s.Defaults)
+	assert.Empty(t, s.Contexts)
+}
+
+func TestLoadSettingsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "unknown key",
+			content: "color: blue\n",
+		},
+		{
+			name:    "invalid default",
+			content: "log-level: verbose\n",
+		},
+		{
+			name:    "invalid context override",
+			content: "contexts:\n  minikube:\n    timeout: forever\n",
+		},
+		{
+			name:    "malformed",
+			content: "contexts: [\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "config.yaml", []byte(tt.content), 0600))
+			_, err := LoadSettings(fs, "config.yaml")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSettingsSetAndSave(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s, err := LoadSettings(fs, "/home/.okteto/config.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, s.Set("", LogOutputSetting, "plain"))
+	require.NoError(t, s.Set("minikube", TimeoutSetting, "10m"))
+	assert.Error(t, s.Set("", LogOutputSetting, "fancy"))
+	assert.Error(t, s.Set("", NamespacePrefixSetting, "Cindy"))
+	assert.Error(t, s.Set("", TelemetrySetting, "maybe"))
+	assert.Error(t, s.Set("", "color", "blue"))
+	require.NoError(t, s.Save(fs, "/home/.okteto/config.yaml"))
+
+	loaded, err := LoadSettings(fs, "/home/.okteto/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, s, loaded)
+
+	require.NoError(t, loaded.Unset("minikube", TimeoutSetting))
+	require.NoError(t, loaded.Unset("", LogOutputSetting))
+	assert.Empty(t, loaded.Defaults)
+	assert.Empty(t, loaded.Contexts)
+	assert.Error(t, loaded.Unset("", "color"))
+}
+
+func TestSettingsGet(t *testing.T) {
+	s := &Settings{
+		Defaults: map[string]string{
+			LogLevelSetting: "info",
+			TimeoutSetting:  "5m",
+		},
+		Contexts: map[string]map[string]string{
+			"minikube": {
+				LogLevelSetting: "debug",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		octx     string
+		key      string
+		env      map[string]string
+		expected SettingValue
+	}{
+		{
+			name:     "built-in default",
+			key:      LogOutputSetting,
+			expected: SettingValue{Key: LogOutputSetting, Value: "tty", Source: SourceDefault},
+		},
+		{
+			name:     "global",
+			octx:     "other",
+			key:      LogLevelSetting,
+			expected: SettingValue{Key: LogLevelSetting, Value: "info", Source: SourceGlobal},
+		},
+		{
+			name:     "context override",
+			octx:     "minikube",
+			key:      LogLevelSetting,
+			expected: SettingValue{Key: LogLevelSetting, Value: "debug", Source: SourceContext},
+		},
+		{
+			name:     "env var takes precedence",
+			octx:     "minikube",
+			key:      LogLevelSetting,
+			env:      map[string]string{"OKTETO_LOG_LEVEL": "error"},
+			expected: SettingValue{Key: LogLevelSetting, Value: "error", Source: SourceEnv},
+		},
+		{
+			name:     "timeout env var",
+			key:      TimeoutSetting,
+			env:      map[string]string{"OKTETO_TIMEOUT": "1m"},
+			expected: SettingValue{Key: TimeoutSetting, Value: "1m", Source: SourceEnv},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"OKTETO_LOG_LEVEL", "OKTETO_LOG_OUTPUT", "OKTETO_TIMEOUT"} {
+				t.Setenv(k, tt.env[k])
+			}
+			v, err := s.Get(tt.octx, tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+
+	_, err := s.Get("", "color")
+	assert.Error(t, err)
+}
+
+func TestSettingsList(t *testing.T) {
+	t.Setenv("OKTETO_TELEMETRY", "")
+	s := &Settings{Defaults: map[string]string{TelemetrySetting: "false"}}
+	list := s.List("")
+	require.Len(t, list, len(GetSettingKeys()))
+	for _, v := range list {
+		if v.Key == TelemetrySetting {
+			assert.Equal(t, SettingValue{Key: TelemetrySetting, Value: "false", Source: SourceGlobal}, v)
+		}
+	}
+}
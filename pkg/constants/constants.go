@@ -51,6 +51,27 @@ const (
 	// LastUpdatedAnnotation indicates update timestamp
 	LastUpdatedAnnotation = "dev.okteto.com/last-updated"
 
+	// ExpiresAtAnnotation indicates the timestamp when the time to live of a development environment expires
+	ExpiresAtAnnotation = "dev.okteto.com/expires-at"
+
+	// OktetoDeployTTLEnvVar defines the time to live of the development environment deployed
+	OktetoDeployTTLEnvVar = "OKTETO_DEPLOY_TTL"
+
+	// DeployLockHolderAnnotation identifies the deploy holding the lease on the configmap of a development environment
+	DeployLockHolderAnnotation = "dev.okteto.com/deploy-lock-holder"
+
+	// DeployLockExpiresAtAnnotation indicates the timestamp when the lease of the deploy holding the lock expires if it isn't renewed
+	DeployLockExpiresAtAnnotation = "dev.okteto.com/deploy-lock-expires-at"
+
+	// IdempotencyKeyAnnotation is the idempotency key of the last deploy that succeeded
+	IdempotencyKeyAnnotation = "dev.okteto.com/idempotency-key"
+
+	// OktetoDeployLockHolderEnvVar is set by the deploy holding the lock, so the okteto commands run by its deploy commands can reuse it
+	OktetoDeployLockHolderEnvVar = "OKTETO_DEPLOY_LOCK_HOLDER"
+
+	// OktetoIdempotencyKeyEnvVar defines the idempotency key of the deploy, e.g. the id of the CI pipeline
+	OktetoIdempotencyKeyEnvVar = "OKTETO_IDEMPOTENCY_KEY"
+
 	// TimeFormat is the format to use when storing timestamps as a string
 	TimeFormat = "2006-01-02T15:04:05"
 
@@ -81,6 +102,9 @@ const (
 	// OktetoEnvFile defines the name for okteto env file
 	OktetoEnvFile = "OKTETO_ENV"
 
+	// OktetoOutputsFile defines the name for the file where deploy commands publish their outputs
+	OktetoOutputsFile = "OKTETO_OUTPUTS"
+
 	// NamespaceStatusLabel label added to namespaces to indicate its status
 	NamespaceStatusLabel = "space.okteto.com/status"
 
@@ -143,4 +167,7 @@ const (
 
 	// OktetoIsPreviewEnvVar Env variable containing a boolean indicating if the environment is a preview environment
 	OktetoIsPreviewEnvVar = "OKTETO_IS_PREVIEW_ENVIRONMENT"
+
+	// OktetoOfflineEnvVar disables telemetry and release checks for air-gapped installs
+	OktetoOfflineEnvVar = "OKTETO_OFFLINE"
 )
@@ -0,0 +1,144 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// RequireSignedImagesEnvVar enables the verification of the signatures of the images used by okteto
+	RequireSignedImagesEnvVar = "OKTETO_REQUIRE_SIGNED_IMAGES"
+
+	// PublicKeyEnvVar is the cosign public key used to verify the signatures of the images
+	PublicKeyEnvVar = "OKTETO_COSIGN_PUBLIC_KEY"
+
+	// CertificateIdentityEnvVar is the identity of the keyless signatures, used when there is no public key
+	CertificateIdentityEnvVar = "OKTETO_COSIGN_CERTIFICATE_IDENTITY"
+
+	// CertificateOIDCIssuerEnvVar is the OIDC issuer of the keyless signatures, used when there is no public key
+	CertificateOIDCIssuerEnvVar = "OKTETO_COSIGN_CERTIFICATE_OIDC_ISSUER"
+)
+
+// runner executes a cosign command and returns its combined output
+type runner func(ctx context.Context, args ...string) ([]byte, error)
+
+// Cosign signs and verifies images using the cosign binary
+type Cosign struct {
+	run runner
+}
+
+// Policy defines how the signatures of the images are verified
+type Policy struct {
+	// Key is the public key used to verify the signatures. Empty means keyless verification
+	Key string
+	// CertificateIdentity is the identity of the keyless signatures
+	CertificateIdentity string
+	// CertificateOIDCIssuer is the OIDC issuer of the keyless signatures
+	CertificateOIDCIssuer string
+}
+
+// New returns a cosign client, failing if the cosign binary is not available
+func New() (*Cosign, error) {
+	binPath, err := exec.LookPath("cosign")
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the 'cosign' binary is not available in your PATH"),
+			Hint: "Install cosign following https://docs.sigstore.dev/system_config/installation",
+		}
+	}
+	return &Cosign{
+		run: func(ctx context.Context, args ...string) ([]byte, error) {
+			cmd := exec.CommandContext(ctx, binPath, args...)
+			output, err := cmd.CombinedOutput()
+			oktetoLog.Infof("cosign %s: %s", strings.Join(args, " "), string(output))
+			return output, err
+		},
+	}, nil
+}
+
+// GetPolicyFromEnv returns the signature policy defined by the environment. It returns nil if signed images are not required
+func GetPolicyFromEnv() *Policy {
+	if os.Getenv(RequireSignedImagesEnvVar) != "true" {
+		return nil
+	}
+	return &Policy{
+		Key:                   os.Getenv(PublicKeyEnvVar),
+		CertificateIdentity:   os.Getenv(CertificateIdentityEnvVar),
+		CertificateOIDCIssuer: os.Getenv(CertificateOIDCIssuerEnvVar),
+	}
+}
+
+// Validate checks that the policy can verify signatures
+func (p *Policy) Validate() error {
+	if p.Key != "" {
+		return nil
+	}
+	if p.CertificateIdentity == "" || p.CertificateOIDCIssuer == "" {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("signed images are required but the signature policy is not complete"),
+			Hint: fmt.Sprintf("Set '%s' to verify signatures with a public key, or '%s' and '%s' to verify keyless signatures", PublicKeyEnvVar, CertificateIdentityEnvVar, CertificateOIDCIssuerEnvVar),
+		}
+	}
+	return nil
+}
+
+// Sign signs an image with a cosign key. An empty key signs the image keyless using OIDC
+func (c *Cosign) Sign(ctx context.Context, image, key string) error {
+	output, err := c.run(ctx, getSignArgs(image, key)...)
+	if err != nil {
+		return fmt.Errorf("failed to sign image '%s': %s", image, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Verify checks that an image has a valid signature according to the policy
+func (c *Cosign) Verify(ctx context.Context, image string, policy Policy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	output, err := c.run(ctx, getVerifyArgs(image, policy)...)
+	if err != nil {
+		return fmt.Errorf("image '%s' doesn't have a valid signature: %s", image, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func getSignArgs(image, key string) []string {
+	args := []string{"sign", "--yes"}
+	if key != "" {
+		args = append(args, fmt.Sprintf("--key=%s", key))
+	}
+	return append(args, image)
+}
+
+func getVerifyArgs(image string, policy Policy) []string {
+	args := []string{"verify"}
+	if policy.Key != "" {
+		args = append(args, fmt.Sprintf("--key=%s", policy.Key))
+	} else {
+		args = append(args,
+			fmt.Sprintf("--certificate-identity=%s", policy.CertificateIdentity),
+			fmt.Sprintf("--certificate-oidc-issuer=%s", policy.CertificateOIDCIssuer),
+		)
+	}
+	return append(args, image)
+}
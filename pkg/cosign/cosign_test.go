@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPolicyFromEnv(t *testing.T) {
+	t.Setenv(RequireSignedImagesEnvVar, "")
+	assert.Nil(t, GetPolicyFromEnv())
+
+	t.Setenv(RequireSignedImagesEnvVar, "true")
+	t.Setenv(PublicKeyEnvVar, "cosign.pub")
+	assert.Equal(t, &Policy{Key: "cosign.pub"}, GetPolicyFromEnv())
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := []struct {
+		policy    Policy
+		name      string
+		expectErr bool
+	}{
+		{
+			name:   "public key",
+			policy: Policy{Key: "cosign.pub"},
+		},
+		{
+			name: "keyless",
+			policy: Policy{
+				CertificateIdentity:   "user@okteto.com",
+				CertificateOIDCIssuer: "https://accounts.google.com",
+			},
+		},
+		{
+			name:      "keyless without issuer",
+			policy:    Policy{CertificateIdentity: "user@okteto.com"},
+			expectErr: true,
+		},
+		{
+			name:      "empty",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.expectErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	var got []string
+	c := &Cosign{
+		run: func(_ context.Context, args ...string) ([]byte, error) {
+			got = args
+			return nil, nil
+		},
+	}
+
+	require.NoError(t, c.Sign(context.Background(), "okteto/test:1", "cosign.key"))
+	assert.Equal(t, []string{"sign", "--yes", "--key=cosign.key", "okteto/test:1"}, got)
+
+	require.NoError(t, c.Sign(context.Background(), "okteto/test:1", ""))
+	assert.Equal(t, []string{"sign", "--yes", "okteto/test:1"}, got)
+}
+
+func TestVerify(t *testing.T) {
+	var got []string
+	c := &Cosign{
+		run: func(_ context.Context, args ...string) ([]byte, error) {
+			got = args
+			return []byte("no matching signatures\n"), assert.AnError
+		},
+	}
+
+	policy := Policy{
+		CertificateIdentity:   "user@okteto.com",
+		CertificateOIDCIssuer: "https://accounts.google.com",
+	}
+	err := c.Verify(context.Background(), "okteto/test@sha256:123", policy)
+	assert.EqualError(t, err, "image 'okteto/test@sha256:123' doesn't have a valid signature: no matching signatures")
+	assert.Equal(t, []string{"verify", "--certificate-identity=user@okteto.com", "--certificate-oidc-issuer=https://accounts.google.com", "okteto/test@sha256:123"}, got)
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/compose-spec/godotenv"
 	"github.com/okteto/okteto/cmd/utils/executor"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
@@ -53,6 +54,9 @@ type ProxyInterface interface {
 	GetToken() string
 	SetName(name string)
 	SetDivert(driver divert.Driver)
+	SetDiff(confirm bool)
+	SetReadOnly()
+	GetResources() []string
 }
 
 // KubeConfigHandler defines the operations to handle the kubeconfig file
@@ -66,6 +70,8 @@ type KubeConfigHandler interface {
 // information related to the development environment
 type ConfigMapHandler interface {
 	UpdateEnvsFromCommands(context.Context, string, string, []string) error
+	UpdateOutputs(context.Context, string, string, map[string]string) error
+	UpdateResources(context.Context, string, string, []string) error
 	AddPhaseDuration(context.Context, string, string, string, time.Duration) error
 }
 
@@ -74,6 +80,16 @@ type ExternalResourceInterface interface {
 	Deploy(ctx context.Context, name string, ns string, externalInfo *externalresource.ExternalResource) error
 }
 
+// ResourcesWaiter waits for the deployed resources to be running before the post-deploy hooks are executed
+type ResourcesWaiter interface {
+	WaitForResources(ctx context.Context, name, namespace string) error
+}
+
+// ExternalValidatorInterface defines the operations to check that the endpoints of the external resources are ready
+type ExternalValidatorInterface interface {
+	Validate(ctx context.Context, name string, externalInfo *externalresource.ExternalResource) error
+}
+
 // DeployRunner is responsible for running the commands defined in a manifest, deploy the divert
 // information and deploy external resources.
 // This DeployRunner has the common functionality to deal with the mentioned resources when deploy is
@@ -88,6 +104,9 @@ type DeployRunner struct {
 	Fs                 afero.Fs
 	DivertDeployer     DivertDeployer
 	GetExternalControl func(cfg *rest.Config) ExternalResourceInterface
+	ExternalValidator  ExternalValidatorInterface
+	// ResourcesWaiter is used before the post-deploy hooks. If nil, they are executed right after the commands
+	ResourcesWaiter    ResourcesWaiter
 	k8sLogger          *io.K8sLogger
 	TempKubeconfigFile string
 }
@@ -96,7 +115,9 @@ type DeployRunner struct {
 type Entity struct {
 	External externalresource.Section
 	Divert   *model.DivertDeploy
+	Hooks    *model.Hooks
 	Commands []model.DeployCommand
+	Outputs  model.ManifestOutputs
 }
 
 // DeployParameters represents the parameters for deploying a remote entity
@@ -106,6 +127,12 @@ type DeployParameters struct {
 	ManifestPath string
 	Deployable   Entity
 	Variables    []string
+	// Diff shows the changes to the resources before they are applied
+	Diff bool
+	// Confirm asks the user to accept the changes to each resource before they are applied
+	Confirm bool
+	// DryRun renders the manifests of the kubectl and helm commands without applying them to the cluster
+	DryRun bool
 }
 
 // PortGetterFunc is a function that retrieves a free port the port for specified interface
@@ -120,6 +147,7 @@ func newDeployExternalK8sControl(cfg *rest.Config) ExternalResourceInterface {
 func NewDeployRunnerForRemote(
 	name string,
 	runWithoutBash bool,
+	rawOutput bool,
 	cmapHandler ConfigMapHandler,
 	k8sProvider okteto.K8sClientProviderWithLogger,
 	portGetter PortGetterFunc,
@@ -136,12 +164,13 @@ func NewDeployRunnerForRemote(
 
 	return &DeployRunner{
 		Kubeconfig:         kubeconfig,
-		Executor:           executor.NewExecutor(oktetoLog.GetOutputFormat(), runWithoutBash, ""),
+		Executor:           executor.NewExecutor(oktetoLog.GetOutputFormat(), runWithoutBash, "", !rawOutput),
 		ConfigMapHandler:   cmapHandler,
 		Proxy:              proxy,
 		TempKubeconfigFile: GetTempKubeConfigFile(tempKubeconfigName),
 		K8sClientProvider:  k8sProvider,
 		GetExternalControl: newDeployExternalK8sControl,
+		ExternalValidator:  externalresource.NewValidator(),
 		Fs:                 afero.NewOsFs(),
 		k8sLogger:          k8sLogger,
 	}, nil
@@ -153,6 +182,7 @@ func NewDeployRunnerForLocal(
 	ctx context.Context,
 	name string,
 	runWithoutBash bool,
+	rawOutput bool,
 	manifestPathFlag string,
 	cmapHandler ConfigMapHandler,
 	k8sProvider okteto.K8sClientProviderWithLogger,
@@ -182,12 +212,13 @@ func NewDeployRunnerForLocal(
 
 	return &DeployRunner{
 		Kubeconfig:         kubeconfig,
-		Executor:           executor.NewExecutor(oktetoLog.GetOutputFormat(), runWithoutBash, ""),
+		Executor:           executor.NewExecutor(oktetoLog.GetOutputFormat(), runWithoutBash, "", !rawOutput),
 		ConfigMapHandler:   cmapHandler,
 		Proxy:              proxy,
 		TempKubeconfigFile: GetTempKubeConfigFile(tempKubeconfigName),
 		K8sClientProvider:  k8sProvider,
 		GetExternalControl: newDeployExternalK8sControl,
+		ExternalValidator:  externalresource.NewValidator(),
 		Fs:                 afero.NewOsFs(),
 		k8sLogger:          k8sLogger,
 	}, nil
@@ -219,6 +250,13 @@ func (r *DeployRunner) RunDeploy(ctx context.Context, params DeployParameters) e
 		r.DivertDeployer = driver
 	}
 
+	if params.Diff || params.Confirm {
+		r.Proxy.SetDiff(params.Confirm)
+	}
+	if params.DryRun {
+		r.Proxy.SetReadOnly()
+	}
+
 	os.Setenv(constants.OktetoNameEnvVar, params.Name)
 
 	oktetoLog.SetStage("")
@@ -266,8 +304,19 @@ func (r *DeployRunner) RunDeploy(ctx context.Context, params DeployParameters) e
 		)
 	}
 	oktetoLog.EnableMasking()
-	err = r.runCommandsSection(ctx, params)
-	return err
+	if params.DryRun {
+		return r.runDryRunCommandsSection(ctx, params, c)
+	}
+	if err := r.runHooks(ctx, params, "pre-deploy hook", preDeployHooksPhaseName, params.Deployable.Hooks.GetPre()); err != nil {
+		return err
+	}
+	if err := r.runCommandsSection(ctx, params); err != nil {
+		return err
+	}
+	if err := r.ConfigMapHandler.UpdateResources(ctx, params.Name, params.Namespace, r.Proxy.GetResources()); err != nil {
+		oktetoLog.Infof("could not update config map with the applied resources: %s", err)
+	}
+	return r.runPostDeployHooks(ctx, params)
 }
 
 // runCommandsSection runs the commands defined in the command section of the deployable entity
@@ -279,6 +328,13 @@ func (r *DeployRunner) runCommandsSection(ctx context.Context, params DeployPara
 
 	defer unlinkEnv()
 
+	oktetoOutputsFile, unlinkOutputs, err := createTempOktetoOutputsFile(r.Fs)
+	if err != nil {
+		return err
+	}
+
+	defer unlinkOutputs()
+
 	envStepper := NewEnvStepper(oktetoEnvFile.Name())
 
 	if len(params.Deployable.Commands) != 0 {
@@ -323,6 +379,14 @@ func (r *DeployRunner) runCommandsSection(ctx context.Context, params DeployPara
 		return fmt.Errorf("could not update config map with environment variables: %w", err)
 	}
 
+	outputs, err := r.getOutputs(oktetoOutputsFile.Name(), params)
+	if err != nil {
+		return err
+	}
+	if err := r.ConfigMapHandler.UpdateOutputs(ctx, params.Name, params.Namespace, outputs); err != nil {
+		return fmt.Errorf("could not update config map with outputs: %w", err)
+	}
+
 	// deploy divert if any
 	if params.Deployable.Divert != nil && params.Deployable.Divert.Namespace != params.Namespace {
 		oktetoLog.SetStage("Deploy Divert")
@@ -337,7 +401,7 @@ func (r *DeployRunner) runCommandsSection(ctx context.Context, params DeployPara
 	if len(params.Deployable.External) > 0 {
 		oktetoLog.SetStage("External configuration")
 		if !okteto.IsOkteto() {
-			oktetoLog.Warning("external resources cannot be deployed on a context not managed by okteto")
+			oktetoLog.WarningWithID(oktetoLog.WarnExternalResourcesNotSupported, "external resources cannot be deployed on a context not managed by okteto")
 			return nil
 		}
 
@@ -351,6 +415,51 @@ func (r *DeployRunner) runCommandsSection(ctx context.Context, params DeployPara
 	return nil
 }
 
+// getOutputs reads the outputs published by the deploy commands in the $OKTETO_OUTPUTS file.
+// If the deployable declares an outputs section, only the declared outputs are returned and
+// the declared values are expanded with the deploy variables and the published outputs
+func (r *DeployRunner) getOutputs(filename string, params DeployParameters) (map[string]string, error) {
+	data, err := afero.ReadFile(r.Fs, filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the okteto outputs file: %w", err)
+	}
+
+	published, err := godotenv.Unmarshal(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("no valid format used in the okteto outputs file: %w", err)
+	}
+
+	if len(params.Deployable.Outputs) == 0 {
+		return published, nil
+	}
+
+	vars := make(map[string]string, len(params.Variables)+len(published))
+	for _, v := range params.Variables {
+		key, value, _ := strings.Cut(v, "=")
+		vars[key] = value
+	}
+	for k, v := range published {
+		vars[k] = v
+	}
+
+	outputs := make(map[string]string, len(params.Deployable.Outputs))
+	for name, output := range params.Deployable.Outputs {
+		if output != nil && output.Value != "" {
+			outputs[name] = os.Expand(output.Value, func(key string) string {
+				return vars[key]
+			})
+			continue
+		}
+		value, ok := published[name]
+		if !ok {
+			oktetoLog.Warning("output '%s' was not published by the deploy commands", name)
+			continue
+		}
+		outputs[name] = value
+	}
+	return outputs, nil
+}
+
 // deployExternals deploys the external resources defined in the deployable entity
 func (r *DeployRunner) deployExternals(ctx context.Context, params DeployParameters, dynamicEnvs map[string]string) error {
 	_, cfg, err := r.K8sClientProvider.ProvideWithLogger(kconfig.Get([]string{r.TempKubeconfigFile}), r.k8sLogger)
@@ -383,6 +492,16 @@ func (r *DeployRunner) deployExternals(ctx context.Context, params DeployParamet
 		}
 	}
 
+	if r.ExternalValidator == nil {
+		return nil
+	}
+	for externalName, externalInfo := range params.Deployable.External {
+		oktetoLog.Spinner(fmt.Sprintf("Validating endpoints of external resource '%s'...", externalName))
+		if err := r.ExternalValidator.Validate(ctx, externalName, externalInfo); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -406,22 +525,32 @@ func (r *DeployRunner) CleanUp(ctx context.Context, err error) {
 
 // createTempOktetoEnvFile creates a temporal file use to store the environment variables
 func createTempOktetoEnvFile(fs afero.Fs) (afero.File, func(), error) {
-	oktetoEnvFileDir, err := afero.TempDir(fs, "", "")
+	return createTempFileForEnvVar(fs, constants.OktetoEnvFile, ".env")
+}
+
+// createTempOktetoOutputsFile creates a temporal file use to store the outputs published by the commands
+func createTempOktetoOutputsFile(fs afero.Fs) (afero.File, func(), error) {
+	return createTempFileForEnvVar(fs, constants.OktetoOutputsFile, ".outputs")
+}
+
+// createTempFileForEnvVar creates a temporal file and exposes its path in the given environment variable
+func createTempFileForEnvVar(fs afero.Fs, envVar, filename string) (afero.File, func(), error) {
+	dir, err := afero.TempDir(fs, "", "")
 	if err != nil {
 		return nil, func() {}, err
 	}
 
-	oktetoEnvFile, err := fs.Create(filepath.Join(oktetoEnvFileDir, ".env"))
+	file, err := fs.Create(filepath.Join(dir, filename))
 	if err != nil {
 		return nil, func() {}, err
 	}
 
-	os.Setenv(constants.OktetoEnvFile, oktetoEnvFile.Name())
-	oktetoLog.Debug(fmt.Sprintf("using %s as env file for deploy command", oktetoEnvFile.Name()))
+	os.Setenv(envVar, file.Name())
+	oktetoLog.Debug(fmt.Sprintf("using %s as %s file for deploy command", file.Name(), envVar))
 
-	return oktetoEnvFile, func() {
-		if err := fs.RemoveAll(filepath.Dir(oktetoEnvFile.Name())); err != nil {
-			oktetoLog.Infof("error removing okteto env file dir: %s", err)
+	return file, func() {
+		if err := fs.RemoveAll(filepath.Dir(file.Name())); err != nil {
+			oktetoLog.Infof("error removing %s file dir: %s", envVar, err)
 		}
 	}, nil
 }
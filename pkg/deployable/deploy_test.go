@@ -15,6 +15,7 @@ package deployable
 
 import (
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -34,12 +35,23 @@ import (
 type fakeCmapHandler struct {
 	errUpdatingWithEnvs error
 	errAddingPhase      error
+	errUpdatingOutputs  error
+	outputs             map[string]string
 }
 
 func (f *fakeCmapHandler) UpdateEnvsFromCommands(context.Context, string, string, []string) error {
 	return f.errUpdatingWithEnvs
 }
 
+func (f *fakeCmapHandler) UpdateOutputs(_ context.Context, _ string, _ string, outputs map[string]string) error {
+	f.outputs = outputs
+	return f.errUpdatingOutputs
+}
+
+func (*fakeCmapHandler) UpdateResources(context.Context, string, string, []string) error {
+	return nil
+}
+
 func (f *fakeCmapHandler) AddPhaseDuration(context.Context, string, string, string, time.Duration) error {
 	return f.errAddingPhase
 }
@@ -89,6 +101,16 @@ func (f *fakeProxy) SetDivert(driver divert.Driver) {
 	f.Called(driver)
 }
 
+func (*fakeProxy) SetReadOnly() {}
+
+func (*fakeProxy) GetResources() []string {
+	return nil
+}
+
+func (f *fakeProxy) SetDiff(confirm bool) {
+	f.Called(confirm)
+}
+
 type fakeExecutor struct {
 	mock.Mock
 }
@@ -120,6 +142,15 @@ func (f *fakeExternalResource) Deploy(ctx context.Context, name, ns string, exte
 	return args.Error(0)
 }
 
+type fakeExternalValidator struct {
+	mock.Mock
+}
+
+func (f *fakeExternalValidator) Validate(ctx context.Context, name string, externalInfo *externalresource.ExternalResource) error {
+	args := f.Called(ctx, name, externalInfo)
+	return args.Error(0)
+}
+
 func TestDeployNotRemovingEnvFile(t *testing.T) {
 	fs := afero.NewMemMapFs()
 
@@ -303,6 +334,126 @@ func TestRunCommandsSectionWithCommands(t *testing.T) {
 	executor.AssertExpectations(t)
 }
 
+func TestRunCommandsSectionStoresOutputs(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+	fs := afero.NewMemMapFs()
+	executor := &fakeExecutor{}
+	cmapHandler := &fakeCmapHandler{}
+	r := DeployRunner{
+		TempKubeconfigFile: "temp-kubeconfig",
+		Fs:                 fs,
+		ConfigMapHandler:   cmapHandler,
+		Executor:           executor,
+	}
+
+	command := model.DeployCommand{
+		Name:    "publish outputs",
+		Command: "echo API_URL=https://api.okteto.dev >> $OKTETO_OUTPUTS",
+	}
+	params := DeployParameters{
+		Deployable: Entity{
+			Commands: []model.DeployCommand{command},
+		},
+	}
+	executor.On("Execute", command, []string(nil)).Run(func(mock.Arguments) {
+		err := afero.WriteFile(fs, os.Getenv(constants.OktetoOutputsFile), []byte("API_URL=https://api.okteto.dev\n"), 0600)
+		require.NoError(t, err)
+	}).Return(nil).Once()
+
+	err := r.runCommandsSection(context.Background(), params)
+
+	require.NoError(t, err)
+	executor.AssertExpectations(t)
+	require.Equal(t, map[string]string{"API_URL": "https://api.okteto.dev"}, cmapHandler.outputs)
+}
+
+func TestRunCommandsSectionWithErrorUpdatingOutputs(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+	r := DeployRunner{
+		TempKubeconfigFile: "temp-kubeconfig",
+		Fs:                 afero.NewMemMapFs(),
+		ConfigMapHandler: &fakeCmapHandler{
+			errUpdatingOutputs: assert.AnError,
+		},
+		Executor: &fakeExecutor{},
+	}
+
+	err := r.runCommandsSection(context.Background(), DeployParameters{})
+
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGetOutputs(t *testing.T) {
+	tests := []struct {
+		outputs   model.ManifestOutputs
+		expected  map[string]string
+		name      string
+		content   string
+		variables []string
+	}{
+		{
+			name:     "no outputs section returns all published outputs",
+			content:  "API_URL=https://api.okteto.dev\nDB_HOST=postgres",
+			expected: map[string]string{"API_URL": "https://api.okteto.dev", "DB_HOST": "postgres"},
+		},
+		{
+			name:    "outputs section filters published outputs",
+			content: "API_URL=https://api.okteto.dev\nDB_HOST=postgres",
+			outputs: model.ManifestOutputs{
+				"API_URL": &model.Output{Description: "the api url"},
+				"MISSING": &model.Output{},
+			},
+			expected: map[string]string{"API_URL": "https://api.okteto.dev"},
+		},
+		{
+			name:      "outputs with value are expanded",
+			content:   "HOST=api.okteto.dev",
+			variables: []string{"OKTETO_NAMESPACE=cindy"},
+			outputs: model.ManifestOutputs{
+				"API_URL": &model.Output{Value: "https://${HOST}/${OKTETO_NAMESPACE}"},
+			},
+			expected: map[string]string{"API_URL": "https://api.okteto.dev/cindy"},
+		},
+		{
+			name:     "empty file",
+			expected: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "/outputs", []byte(tt.content), 0600))
+			r := DeployRunner{Fs: fs}
+
+			outputs, err := r.getOutputs("/outputs", DeployParameters{
+				Variables: tt.variables,
+				Deployable: Entity{
+					Outputs: tt.outputs,
+				},
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, outputs)
+		})
+	}
+}
+
 func TestRunCommandsSectionWithErrorInCommands(t *testing.T) {
 	okteto.CurrentStore = &okteto.ContextStore{
 		Contexts: map[string]*okteto.Context{
@@ -577,6 +728,63 @@ func TestRunCommandsSectionWithErrorDeployingExternal(t *testing.T) {
 	externalResource.AssertExpectations(t)
 }
 
+func TestRunCommandsSectionWithErrorValidatingExternal(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+	k8sProvider := test.NewFakeK8sProvider()
+	externalResource := &fakeExternalResource{}
+	validator := &fakeExternalValidator{}
+	r := DeployRunner{
+		TempKubeconfigFile: "temp-kubeconfig",
+		Fs:                 afero.NewMemMapFs(),
+		ConfigMapHandler:   &fakeCmapHandler{},
+		Executor:           &fakeExecutor{},
+		DivertDeployer:     &fakeDivert{},
+		K8sClientProvider:  k8sProvider,
+		GetExternalControl: func(_ *rest.Config) ExternalResourceInterface {
+			return externalResource
+		},
+		ExternalValidator: validator,
+	}
+
+	externalInfo := &externalresource.ExternalResource{
+		Icon: "icon",
+		Endpoints: []*externalresource.ExternalEndpoint{
+			{
+				Name: "name",
+				Url:  "https://api.example.com",
+				Expect: &externalresource.Expectation{
+					Reachable: true,
+				},
+			},
+		},
+	}
+	params := DeployParameters{
+		Namespace: "test1",
+		Deployable: Entity{
+			External: externalresource.Section{
+				"db": externalInfo,
+			},
+		},
+	}
+
+	externalResource.On("Deploy", mock.Anything, "db", "test1", externalInfo).Return(nil).Once()
+	validator.On("Validate", mock.Anything, "db", externalInfo).Return(assert.AnError).Once()
+
+	err := r.runCommandsSection(context.Background(), params)
+
+	require.ErrorIs(t, err, assert.AnError)
+	externalResource.AssertExpectations(t)
+	validator.AssertExpectations(t)
+}
+
 func TestDeployExternalWithErrorGettingClient(t *testing.T) {
 	okteto.CurrentStore = &okteto.ContextStore{
 		Contexts: map[string]*okteto.Context{
@@ -0,0 +1,299 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// changesRejectedMessage is the message returned to the deploy commands when the user rejects the changes to a resource
+const changesRejectedMessage = "the changes to the resource were rejected by the user"
+
+// resourceDiffer shows the changes that a request of the deploy commands applies to a resource.
+// The changes are computed with a server-side dry run of the request, so they include the defaults
+// and the mutations of the cluster. When confirm is set, the user accepts the changes of each resource
+type resourceDiffer struct {
+	transport   http.RoundTripper
+	destination *url.URL
+	out         io.Writer
+	ask         func(question string) (bool, error)
+	confirm     bool
+
+	// lock serializes the reviews so the diffs and the questions of concurrent requests don't interleave
+	lock sync.Mutex
+}
+
+// resourcePath is the path of a resource of the kubernetes API
+type resourcePath struct {
+	resource    string
+	name        string
+	subresource string
+}
+
+func newResourceDiffer(transport http.RoundTripper, destination *url.URL, confirm bool) *resourceDiffer {
+	return &resourceDiffer{
+		transport:   transport,
+		destination: destination,
+		confirm:     confirm,
+		out:         os.Stdout,
+		ask: func(question string) (bool, error) {
+			return utils.AskYesNo(question, utils.YesNoDefault_No)
+		},
+	}
+}
+
+// parseResourcePath returns the resource, name and subresource of a path of the kubernetes API
+func parseResourcePath(path string) (resourcePath, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:]
+	default:
+		return resourcePath{}, false
+	}
+	// namespaced resources, except the namespaces themselves
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		parts = parts[2:]
+	}
+
+	switch len(parts) {
+	case 1:
+		return resourcePath{resource: parts[0]}, true
+	case 2:
+		return resourcePath{resource: parts[0], name: parts[1]}, true
+	case 3:
+		return resourcePath{resource: parts[0], name: parts[1], subresource: parts[2]}, true
+	}
+	return resourcePath{}, false
+}
+
+// shouldReview returns if the request changes a resource and it can be reviewed
+func shouldReview(r *http.Request) bool {
+	if r.URL.Query().Has("dryRun") {
+		return false
+	}
+	path, ok := parseResourcePath(r.URL.Path)
+	if !ok || path.subresource != "" {
+		return false
+	}
+	switch r.Method {
+	case http.MethodPost:
+		return path.name == ""
+	case http.MethodPut, http.MethodPatch:
+		return path.name != ""
+	}
+	return false
+}
+
+// reviewRequest reviews the changes of a request of the deploy commands. It returns false if the request
+// must not be forwarded to the cluster because the user rejected the changes
+func (d *resourceDiffer) reviewRequest(rw http.ResponseWriter, r *http.Request) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		oktetoLog.Infof("could not read the request body: %s", err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	accepted, err := d.review(r, body)
+	if err != nil {
+		// the changes can't be shown, but the deploy goes on
+		oktetoLog.Infof("could not review the changes of %s %s: %s", r.Method, r.URL.Path, err)
+		return true
+	}
+	if accepted {
+		return true
+	}
+
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  changesRejectedMessage,
+		Reason:   metav1.StatusReasonForbidden,
+		Code:     http.StatusForbidden,
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusForbidden)
+	if err := json.NewEncoder(rw).Encode(status); err != nil {
+		oktetoLog.Infof("could not write the response: %s", err)
+	}
+	return false
+}
+
+// review shows the changes of the request and returns if they can be applied
+func (d *resourceDiffer) review(r *http.Request, body []byte) (bool, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	merged, err := d.dryRun(r, body)
+	if err != nil {
+		return true, err
+	}
+	// the request is invalid, the cluster returns the error when it is applied
+	if merged == nil {
+		return true, nil
+	}
+
+	kind, name := getKindAndName(merged)
+	objectPath := r.URL.Path
+	if r.Method == http.MethodPost {
+		objectPath = fmt.Sprintf("%s/%s", strings.TrimSuffix(objectPath, "/"), name)
+	}
+	live, err := d.get(r, objectPath)
+	if err != nil {
+		return true, err
+	}
+
+	diff, err := getResourceDiff(live, merged)
+	if err != nil {
+		return true, err
+	}
+	if diff == "" {
+		oktetoLog.Infof("no changes for %s '%s'", kind, name)
+		return true, nil
+	}
+
+	fmt.Fprintf(d.out, "Changes to %s '%s':\n%s\n", kind, name, diff)
+	if !d.confirm {
+		return true, nil
+	}
+	return d.ask(fmt.Sprintf("Apply the changes to %s '%s'?", kind, name))
+}
+
+// showDeletion shows the resource that a request of the deploy commands deletes
+func (d *resourceDiffer) showDeletion(r *http.Request) {
+	path, ok := parseResourcePath(r.URL.Path)
+	if !ok || path.name == "" || path.subresource != "" {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	fmt.Fprintf(d.out, "Deletion of %s '%s'\n", path.resource, path.name)
+}
+
+// dryRun sends the request to the cluster in dry run mode and returns the resulting object.
+// It returns nil if the cluster rejects the request
+func (d *resourceDiffer) dryRun(r *http.Request, body []byte) (map[string]interface{}, error) {
+	query := r.URL.Query()
+	query.Set("dryRun", "All")
+	req, err := d.newRequest(r, r.Method, r.URL.Path, query.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	return d.do(req)
+}
+
+// get returns the object of the cluster in the path. It returns nil if the object doesn't exist
+func (d *resourceDiffer) get(r *http.Request, path string) (map[string]interface{}, error) {
+	req, err := d.newRequest(r, http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("Content-Type")
+	return d.do(req)
+}
+
+func (d *resourceDiffer) newRequest(r *http.Request, method, path, query string, body []byte) (*http.Request, error) {
+	u := *d.destination
+	u.Path = path
+	u.RawQuery = query
+	req, err := http.NewRequestWithContext(r.Context(), method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = r.Header.Clone()
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+func (d *resourceDiffer) do(req *http.Request) (map[string]interface{}, error) {
+	resp, err := d.transport.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the changes of the resource: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		oktetoLog.Infof("%s %s returned status %d", req.Method, req.URL.Path, resp.StatusCode)
+		return nil, nil
+	}
+	var obj map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("failed to decode the resource: %w", err)
+	}
+	return obj, nil
+}
+
+func getKindAndName(obj map[string]interface{}) (string, string) {
+	kind, _ := obj["kind"].(string)
+	name := ""
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		name, _ = metadata["name"].(string)
+	}
+	return kind, name
+}
+
+// getResourceDiff returns the unified diff between the live and the merged objects, ignoring the fields set by the cluster
+func getResourceDiff(live, merged map[string]interface{}) (string, error) {
+	from, err := marshalForDiff(live)
+	if err != nil {
+		return "", err
+	}
+	to, err := marshalForDiff(merged)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "live",
+		ToFile:   "merged",
+		Context:  3,
+	})
+}
+
+func marshalForDiff(obj map[string]interface{}) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp"} {
+			delete(metadata, field)
+		}
+	}
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the resource: %w", err)
+	}
+	return string(b), nil
+}
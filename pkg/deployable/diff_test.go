@@ -0,0 +1,247 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourcePath(t *testing.T) {
+	var tests = []struct {
+		path     string
+		expected resourcePath
+		ok       bool
+	}{
+		{path: "/api/v1/namespaces/test/configmaps", expected: resourcePath{resource: "configmaps"}, ok: true},
+		{path: "/api/v1/namespaces/test/configmaps/settings", expected: resourcePath{resource: "configmaps", name: "settings"}, ok: true},
+		{path: "/apis/apps/v1/namespaces/test/deployments/api/scale", expected: resourcePath{resource: "deployments", name: "api", subresource: "scale"}, ok: true},
+		{path: "/api/v1/namespaces/test", expected: resourcePath{resource: "namespaces", name: "test"}, ok: true},
+		{path: "/apis/rbac.authorization.k8s.io/v1/clusterroles/admin", expected: resourcePath{resource: "clusterroles", name: "admin"}, ok: true},
+		{path: "/version"},
+		{path: "/apis/apps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, ok := parseResourcePath(tt.path)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
+
+func TestShouldReview(t *testing.T) {
+	var tests = []struct {
+		name     string
+		method   string
+		url      string
+		expected bool
+	}{
+		{name: "create", method: http.MethodPost, url: "/apis/apps/v1/namespaces/test/deployments", expected: true},
+		{name: "update", method: http.MethodPut, url: "/apis/apps/v1/namespaces/test/deployments/api", expected: true},
+		{name: "patch", method: http.MethodPatch, url: "/apis/apps/v1/namespaces/test/deployments/api", expected: true},
+		{name: "get", method: http.MethodGet, url: "/apis/apps/v1/namespaces/test/deployments/api"},
+		{name: "delete", method: http.MethodDelete, url: "/apis/apps/v1/namespaces/test/deployments/api"},
+		{name: "subresource", method: http.MethodPost, url: "/api/v1/namespaces/test/pods/api/exec"},
+		{name: "dry run", method: http.MethodPatch, url: "/apis/apps/v1/namespaces/test/deployments/api?dryRun=All"},
+		{name: "not a resource", method: http.MethodPost, url: "/version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.url, nil)
+			assert.Equal(t, tt.expected, shouldReview(r))
+		})
+	}
+}
+
+func TestGetResourceDiff(t *testing.T) {
+	live := map[string]interface{}{
+		"kind": "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "settings",
+			"resourceVersion": "1",
+			"managedFields":   []interface{}{"kubectl"},
+		},
+		"data": map[string]interface{}{"LOG_LEVEL": "info"},
+	}
+	merged := map[string]interface{}{
+		"kind": "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "settings",
+			"resourceVersion": "2",
+		},
+		"data": map[string]interface{}{"LOG_LEVEL": "debug"},
+	}
+
+	diff, err := getResourceDiff(live, merged)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "-  LOG_LEVEL: info\n")
+	assert.Contains(t, diff, "+  LOG_LEVEL: debug\n")
+	assert.NotContains(t, diff, "resourceVersion")
+	assert.NotContains(t, diff, "managedFields")
+
+	diff, err = getResourceDiff(merged, merged)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+// newFakeAPIServer returns a server that returns the live object for the GET requests and
+// the merged object for the dry run requests. The requests that are not dry runs are recorded
+func newFakeAPIServer(t *testing.T, live, merged string, applied *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && live == "":
+			rw.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			rw.Write([]byte(live))
+		case r.URL.Query().Get("dryRun") == "All":
+			rw.Write([]byte(merged))
+		default:
+			*applied = append(*applied, r.Method+" "+r.URL.Path)
+			rw.Write([]byte(merged))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReviewRequest(t *testing.T) {
+	live := `{"kind":"ConfigMap","metadata":{"name":"settings","resourceVersion":"1"},"data":{"LOG_LEVEL":"info"}}`
+	merged := `{"kind":"ConfigMap","metadata":{"name":"settings","resourceVersion":"2"},"data":{"LOG_LEVEL":"debug"}}`
+
+	var tests = []struct {
+		name           string
+		method         string
+		path           string
+		live           string
+		confirm        bool
+		answer         bool
+		expectedOut    []string
+		expectedAsked  bool
+		expectedResult bool
+	}{
+		{
+			name:           "diff of an update",
+			method:         http.MethodPatch,
+			path:           "/api/v1/namespaces/test/configmaps/settings",
+			live:           live,
+			expectedOut:    []string{"Changes to ConfigMap 'settings':", "-  LOG_LEVEL: info", "+  LOG_LEVEL: debug"},
+			expectedResult: true,
+		},
+		{
+			name:           "diff of a new resource",
+			method:         http.MethodPost,
+			path:           "/api/v1/namespaces/test/configmaps",
+			expectedOut:    []string{"Changes to ConfigMap 'settings':", "+kind: ConfigMap"},
+			expectedResult: true,
+		},
+		{
+			name:           "no changes",
+			method:         http.MethodPut,
+			path:           "/api/v1/namespaces/test/configmaps/settings",
+			live:           merged,
+			confirm:        true,
+			expectedResult: true,
+		},
+		{
+			name:           "changes accepted",
+			method:         http.MethodPatch,
+			path:           "/api/v1/namespaces/test/configmaps/settings",
+			live:           live,
+			confirm:        true,
+			answer:         true,
+			expectedOut:    []string{"Changes to ConfigMap 'settings':"},
+			expectedAsked:  true,
+			expectedResult: true,
+		},
+		{
+			name:           "changes rejected",
+			method:         http.MethodPatch,
+			path:           "/api/v1/namespaces/test/configmaps/settings",
+			live:           live,
+			confirm:        true,
+			expectedOut:    []string{"Changes to ConfigMap 'settings':"},
+			expectedAsked:  true,
+			expectedResult: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied := []string{}
+			server := newFakeAPIServer(t, tt.live, merged, &applied)
+			destination, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			asked := false
+			d := newResourceDiffer(http.DefaultTransport, destination, tt.confirm)
+			d.out = out
+			d.ask = func(string) (bool, error) {
+				asked = true
+				return tt.answer, nil
+			}
+
+			body := `{"data":{"LOG_LEVEL":"debug"}}`
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(body))
+			rw := httptest.NewRecorder()
+			result := d.reviewRequest(rw, r)
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedAsked, asked)
+			for _, expected := range tt.expectedOut {
+				assert.Contains(t, out.String(), expected)
+			}
+			if len(tt.expectedOut) == 0 {
+				assert.Empty(t, out.String())
+			}
+			// the dry run and the get requests never change the resource
+			assert.Empty(t, applied)
+
+			// the body is still available to forward the request to the cluster
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(b))
+
+			if !tt.expectedResult {
+				assert.Equal(t, http.StatusForbidden, rw.Code)
+				var status map[string]interface{}
+				require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &status))
+				assert.Equal(t, changesRejectedMessage, status["message"])
+			}
+		})
+	}
+}
+
+func TestShowDeletion(t *testing.T) {
+	out := &bytes.Buffer{}
+	d := newResourceDiffer(http.DefaultTransport, &url.URL{}, false)
+	d.out = out
+
+	d.showDeletion(httptest.NewRequest(http.MethodDelete, "/apis/apps/v1/namespaces/test/deployments/api", nil))
+	assert.Equal(t, "Deletion of deployments 'api'\n", out.String())
+
+	out.Reset()
+	d.showDeletion(httptest.NewRequest(http.MethodDelete, "/api/v1/namespaces/test/pods", nil))
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// dryRunRejectedMessage is the message returned to the deploy commands when they try to change the cluster in dry-run mode
+const dryRunRejectedMessage = "the request was rejected because the deploy runs in dry-run mode"
+
+// shellOperators can't be rewritten to render the manifests of a command: the dry-run flags would only apply to its last part
+var shellOperators = []string{"\n", "&&", "||", "|", ";", ">", "<"}
+
+// dryRunSuffixes are the flags that render the manifests of the kubectl and helm subcommands without applying them.
+// The subcommands that only render manifests or change local files run as they are
+var dryRunSuffixes = map[string]map[string]string{
+	"kubectl": {
+		"apply":     "--dry-run=client -o yaml",
+		"create":    "--dry-run=client -o yaml",
+		"replace":   "--dry-run=client -o yaml",
+		"patch":     "--dry-run=client -o yaml",
+		"label":     "--dry-run=client -o yaml",
+		"annotate":  "--dry-run=client -o yaml",
+		"set":       "--dry-run=client -o yaml",
+		"scale":     "--dry-run=client -o yaml",
+		"expose":    "--dry-run=client -o yaml",
+		"run":       "--dry-run=client -o yaml",
+		"autoscale": "--dry-run=client -o yaml",
+		"delete":    "--dry-run=client",
+		"kustomize": "",
+	},
+	"helm": {
+		"install":    "--dry-run",
+		"upgrade":    "--dry-run",
+		"uninstall":  "--dry-run",
+		"template":   "",
+		"repo":       "",
+		"dependency": "",
+		"dep":        "",
+	},
+}
+
+// GetDryRunCommand returns the command that renders the manifests of a deploy command without applying them.
+// It returns false if the command can't run in dry-run mode
+func GetDryRunCommand(command string) (string, bool) {
+	command = strings.TrimSpace(command)
+	for _, op := range shellOperators {
+		if strings.Contains(command, op) {
+			return "", false
+		}
+	}
+	fields := strings.Fields(command)
+	if len(fields) < 2 {
+		return "", false
+	}
+	subcommands, ok := dryRunSuffixes[filepath.Base(fields[0])]
+	if !ok {
+		return "", false
+	}
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		suffix, ok := subcommands[field]
+		if !ok {
+			continue
+		}
+		if suffix == "" {
+			return command, true
+		}
+		return fmt.Sprintf("%s %s", command, suffix), true
+	}
+	return "", false
+}
+
+// isMutatingRequest returns if a request of the deploy commands changes the cluster.
+// Server-side dry runs and reviews, like 'kubectl auth can-i', don't change it
+func isMutatingRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return false
+	}
+	if r.URL.Query().Has("dryRun") {
+		return false
+	}
+	if path, ok := parseResourcePath(r.URL.Path); ok && strings.HasSuffix(path.resource, "reviews") {
+		return false
+	}
+	return true
+}
+
+// rejectMutatingRequest returns a forbidden status to the deploy commands
+func rejectMutatingRequest(rw http.ResponseWriter, r *http.Request) {
+	oktetoLog.Infof("dry-run: rejected %s %s", r.Method, r.URL.Path)
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  dryRunRejectedMessage,
+		Reason:   metav1.StatusReasonForbidden,
+		Code:     http.StatusForbidden,
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusForbidden)
+	if err := json.NewEncoder(rw).Encode(status); err != nil {
+		oktetoLog.Infof("could not write the response: %s", err)
+	}
+}
+
+// runDryRunCommandsSection runs the commands of the deployable entity that can render their manifests without applying them.
+// The other commands are skipped and nothing is recorded in the configmap of the development environment.
+// If the changes are shown, the commands run as they are and the proxy sends their changes to the cluster as a server-side dry run
+func (r *DeployRunner) runDryRunCommandsSection(ctx context.Context, params DeployParameters, c kubernetes.Interface) error {
+	skipped := false
+	for _, command := range params.Deployable.Commands {
+		dryRunCommand, ok := GetDryRunCommand(command.Command)
+		if !ok {
+			oktetoLog.Warning("Skipping '%s': only kubectl and helm commands can run in dry-run mode", command.Name)
+			skipped = true
+			continue
+		}
+		if params.Diff {
+			oktetoLog.Information("Comparing '%s' with the resources of the namespace", command.Name)
+		} else {
+			oktetoLog.Information("Rendering '%s'", command.Name)
+			command.Command = dryRunCommand
+		}
+		if err := r.Executor.Execute(command, params.Variables); err != nil {
+			return fmt.Errorf("error executing command '%s' in dry-run mode: %w", command.Name, err)
+		}
+	}
+	if params.Diff {
+		r.showRemovedResources(ctx, params, c, skipped)
+	}
+	if params.Deployable.Divert != nil {
+		oktetoLog.Warning("The divert of '%s' is not rendered in dry-run mode", params.Name)
+	}
+	if len(params.Deployable.External) > 0 {
+		oktetoLog.Warning("The external resources of '%s' are not rendered in dry-run mode", params.Name)
+	}
+	return nil
+}
+
+// showRemovedResources shows the resources recorded in the configmap by the last deploy that the deploy commands don't apply anymore.
+// They would be kept in the namespace as they are
+func (r *DeployRunner) showRemovedResources(ctx context.Context, params DeployParameters, c kubernetes.Interface, skipped bool) {
+	previous, err := pipeline.GetResources(ctx, params.Name, params.Namespace, c)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("could not get the resources of the last deploy: %s", err)
+		}
+		return
+	}
+	removed := getRemovedResources(previous, r.Proxy.GetResources())
+	if len(removed) == 0 {
+		return
+	}
+	if skipped {
+		oktetoLog.Infof("resources of the last deploy not applied by the commands run in dry-run mode: %v", removed)
+		return
+	}
+	oktetoLog.Information("Resources of the last deploy of '%s' that are not applied anymore:", params.Name)
+	for _, resource := range removed {
+		oktetoLog.Println(fmt.Sprintf("  - %s", resource))
+	}
+}
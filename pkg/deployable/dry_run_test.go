@@ -0,0 +1,222 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetDryRunCommand(t *testing.T) {
+	tt := []struct {
+		name     string
+		command  string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "kubectl apply",
+			command:  "kubectl apply -f k8s.yml",
+			expected: "kubectl apply -f k8s.yml --dry-run=client -o yaml",
+			ok:       true,
+		},
+		{
+			name:     "kubectl with global flags",
+			command:  "kubectl -n test delete deployment api",
+			expected: "kubectl -n test delete deployment api --dry-run=client",
+			ok:       true,
+		},
+		{
+			name:     "kubectl kustomize",
+			command:  "kubectl kustomize overlays/dev",
+			expected: "kubectl kustomize overlays/dev",
+			ok:       true,
+		},
+		{
+			name:     "helm upgrade with absolute path",
+			command:  "/usr/local/bin/helm upgrade --install api chart",
+			expected: "/usr/local/bin/helm upgrade --install api chart --dry-run",
+			ok:       true,
+		},
+		{
+			name:     "helm template",
+			command:  "helm template api chart",
+			expected: "helm template api chart",
+			ok:       true,
+		},
+		{
+			name:    "unknown binary",
+			command: "okteto build",
+		},
+		{
+			name:    "unknown subcommand",
+			command: "kubectl rollout status deployment/api",
+		},
+		{
+			name:    "shell operators",
+			command: "kubectl apply -f k8s.yml && kubectl rollout status deployment/api",
+		},
+		{
+			name:    "no subcommand",
+			command: "kubectl",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			result, ok := GetDryRunCommand(tc.command)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestIsMutatingRequest(t *testing.T) {
+	tt := []struct {
+		name     string
+		method   string
+		url      string
+		expected bool
+	}{
+		{
+			name:   "get",
+			method: http.MethodGet,
+			url:    "/api/v1/namespaces/test/configmaps",
+		},
+		{
+			name:     "create",
+			method:   http.MethodPost,
+			url:      "/api/v1/namespaces/test/configmaps",
+			expected: true,
+		},
+		{
+			name:     "delete",
+			method:   http.MethodDelete,
+			url:      "/apis/apps/v1/namespaces/test/deployments/api",
+			expected: true,
+		},
+		{
+			name:   "server-side dry run",
+			method: http.MethodPatch,
+			url:    "/apis/apps/v1/namespaces/test/deployments/api?dryRun=All",
+		},
+		{
+			name:   "access review",
+			method: http.MethodPost,
+			url:    "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.url, nil)
+			assert.Equal(t, tc.expected, isMutatingRequest(r))
+		})
+	}
+}
+
+func TestRejectMutatingRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/test/configmaps", nil)
+	rw := httptest.NewRecorder()
+
+	rejectMutatingRequest(rw, r)
+
+	require.Equal(t, http.StatusForbidden, rw.Code)
+	var status metav1.Status
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(&status))
+	assert.Equal(t, metav1.StatusReasonForbidden, status.Reason)
+	assert.Equal(t, dryRunRejectedMessage, status.Message)
+}
+
+func TestRunDryRunCommandsSection(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := DeployRunner{
+		Executor: executor,
+	}
+	params := DeployParameters{
+		Name: "test",
+		Deployable: Entity{
+			Commands: []model.DeployCommand{
+				{Name: "apply", Command: "kubectl apply -f k8s.yml"},
+				{Name: "script", Command: "./deploy.sh"},
+			},
+		},
+		Variables: []string{"A=1"},
+		DryRun:    true,
+	}
+
+	executor.On("Execute", model.DeployCommand{Name: "apply", Command: "kubectl apply -f k8s.yml --dry-run=client -o yaml"}, []string{"A=1"}).Return(nil)
+
+	err := r.runDryRunCommandsSection(context.Background(), params, fake.NewSimpleClientset())
+
+	require.NoError(t, err)
+	executor.AssertExpectations(t)
+	executor.AssertNumberOfCalls(t, "Execute", 1)
+}
+
+func TestRunDryRunCommandsSectionError(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := DeployRunner{
+		Executor: executor,
+	}
+	params := DeployParameters{
+		Name: "test",
+		Deployable: Entity{
+			Commands: []model.DeployCommand{
+				{Name: "install", Command: "helm upgrade --install api chart"},
+			},
+		},
+		DryRun: true,
+	}
+
+	executor.On("Execute", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	err := r.runDryRunCommandsSection(context.Background(), params, fake.NewSimpleClientset())
+
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestRunDryRunCommandsSectionWithDiff(t *testing.T) {
+	executor := &fakeExecutor{}
+	r := DeployRunner{
+		Proxy:    &fakeProxy{},
+		Executor: executor,
+	}
+	params := DeployParameters{
+		Name:      "test",
+		Namespace: "test",
+		Deployable: Entity{
+			Commands: []model.DeployCommand{
+				{Name: "apply", Command: "kubectl apply -f k8s.yml"},
+			},
+		},
+		Diff:   true,
+		DryRun: true,
+	}
+
+	executor.On("Execute", model.DeployCommand{Name: "apply", Command: "kubectl apply -f k8s.yml"}, []string(nil)).Return(nil)
+
+	err := r.runDryRunCommandsSection(context.Background(), params, fake.NewSimpleClientset())
+
+	require.NoError(t, err)
+	executor.AssertExpectations(t)
+}
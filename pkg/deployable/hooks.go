@@ -0,0 +1,84 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils/executor"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	preDeployHooksPhaseName  = "preDeployHooks"
+	postDeployHooksPhaseName = "postDeployHooks"
+)
+
+// HooksRunner is responsible for running the pre and post hooks of the deploy and destroy sections
+type HooksRunner struct {
+	Executor executor.ManifestExecutor
+}
+
+// RunHooks executes the hooks in order and stops at the first one failing.
+// kind identifies the hooks in the logs, e.g. "pre-deploy hook"
+func (hr *HooksRunner) RunHooks(kind string, hooks []model.DeployCommand, variables []string) error {
+	for _, hook := range hooks {
+		oktetoLog.SetStage(fmt.Sprintf("Running %s '%s'", kind, hook.Name))
+		oktetoLog.Information("Running %s '%s'", kind, hook.Name)
+		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Executing %s '%s'...", kind, hook.Name)
+		if err := hr.Executor.Execute(hook, variables); err != nil {
+			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error executing %s '%s': %s", kind, hook.Name, err.Error())
+			return fmt.Errorf("error executing %s '%s': %w", kind, hook.Name, err)
+		}
+		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "The %s '%s' was successfully executed", kind, hook.Name)
+	}
+	oktetoLog.SetStage("")
+	return nil
+}
+
+// runPostDeployHooks runs the post-deploy hooks once the deployed resources are running,
+// so they can rely on them (e.g. to run the database migrations)
+func (r *DeployRunner) runPostDeployHooks(ctx context.Context, params DeployParameters) error {
+	hooks := params.Deployable.Hooks.GetPost()
+	if len(hooks) == 0 {
+		return nil
+	}
+	if r.ResourcesWaiter != nil {
+		if err := r.ResourcesWaiter.WaitForResources(ctx, params.Name, params.Namespace); err != nil {
+			return err
+		}
+	}
+	return r.runHooks(ctx, params, "post-deploy hook", postDeployHooksPhaseName, hooks)
+}
+
+// runHooks runs the hooks of the deploy section with the same environment as the deploy commands
+func (r *DeployRunner) runHooks(ctx context.Context, params DeployParameters, kind, phase string, hooks []model.DeployCommand) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+	runner := &HooksRunner{
+		Executor: r.Executor,
+	}
+
+	startTime := time.Now()
+	err := runner.RunHooks(kind, hooks, params.Variables)
+	elapsedTime := time.Since(startTime)
+	if addPhaseErr := r.ConfigMapHandler.AddPhaseDuration(ctx, params.Name, params.Namespace, phase, elapsedTime); addPhaseErr != nil {
+		oktetoLog.Info("error adding phase to configmap: %s", addPhaseErr)
+	}
+	return err
+}
@@ -0,0 +1,222 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHooks(t *testing.T) {
+	hooks := []model.DeployCommand{
+		{
+			Name:    "migrate",
+			Command: "make migrate",
+		},
+		{
+			Name:    "seed",
+			Command: "make seed",
+		},
+	}
+
+	tests := []struct {
+		expectedErr      error
+		name             string
+		expectedExecuted []model.DeployCommand
+		hooks            []model.DeployCommand
+		executorErr      error
+	}{
+		{
+			name: "no hooks",
+		},
+		{
+			name:             "all hooks executed",
+			hooks:            hooks,
+			expectedExecuted: hooks,
+		},
+		{
+			name:             "stops at the first failing hook",
+			hooks:            hooks,
+			executorErr:      assert.AnError,
+			expectedExecuted: hooks[:1],
+			expectedErr:      assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &fakeDestroyExecutor{
+				err: tt.executorErr,
+			}
+			runner := &HooksRunner{
+				Executor: executor,
+			}
+
+			err := runner.RunHooks("post-deploy hook", tt.hooks, []string{})
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				require.ErrorContains(t, err, "post-deploy hook 'migrate'")
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedExecuted, executor.executed)
+		})
+	}
+}
+
+type fakeResourcesWaiter struct {
+	err    error
+	waited bool
+}
+
+func (f *fakeResourcesWaiter) WaitForResources(context.Context, string, string) error {
+	f.waited = true
+	return f.err
+}
+
+func TestRunDeployHooks(t *testing.T) {
+	pre := model.DeployCommand{Name: "backup", Command: "make backup"}
+	command := model.DeployCommand{Name: "deploy", Command: "make deploy"}
+	post := model.DeployCommand{Name: "migrate", Command: "make migrate"}
+	variables := []string{"A=value1", "KUBECONFIG=temp-kubeconfig"}
+
+	tests := []struct {
+		waiterErr      error
+		preErr         error
+		name           string
+		expectedErr    error
+		expectedWaited bool
+	}{
+		{
+			name:           "hooks executed around the commands",
+			expectedWaited: true,
+		},
+		{
+			name:        "failing pre-deploy hook",
+			preErr:      assert.AnError,
+			expectedErr: assert.AnError,
+		},
+		{
+			name:           "resources not running",
+			waiterErr:      assert.AnError,
+			expectedErr:    assert.AnError,
+			expectedWaited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &fakeExecutor{}
+			executor.On("Execute", pre, variables).Return(tt.preErr).Once()
+			if tt.preErr == nil {
+				executor.On("Execute", command, variables).Return(nil).Once()
+			}
+			if tt.preErr == nil && tt.waiterErr == nil {
+				executor.On("Execute", post, variables).Return(nil).Once()
+			}
+			waiter := &fakeResourcesWaiter{err: tt.waiterErr}
+			r := DeployRunner{
+				Fs:               afero.NewMemMapFs(),
+				ConfigMapHandler: &fakeCmapHandler{},
+				Executor:         executor,
+				ResourcesWaiter:  waiter,
+			}
+			params := DeployParameters{
+				Variables: variables,
+				Deployable: Entity{
+					Commands: []model.DeployCommand{command},
+					Hooks: &model.Hooks{
+						Pre:  []model.DeployCommand{pre},
+						Post: []model.DeployCommand{post},
+					},
+				},
+			}
+
+			err := r.runHooks(context.Background(), params, "pre-deploy hook", preDeployHooksPhaseName, params.Deployable.Hooks.GetPre())
+			if err == nil {
+				err = r.runCommandsSection(context.Background(), params)
+			}
+			if err == nil {
+				err = r.runPostDeployHooks(context.Background(), params)
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedWaited, waiter.waited)
+			executor.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRunDeployRunsHooksWithTheEnvironmentOfTheCommands(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+	proxy := &fakeProxy{}
+	proxy.On("GetPort").Return(80)
+	proxy.On("GetToken").Return("fake-token")
+	proxy.On("SetName", "test").Return().Once()
+	proxy.On("Start").Return().Once()
+	proxy.On("Shutdown", mock.Anything).Return(nil).Once()
+	kubeconfigHandler := &fakeKubeconfigHandler{}
+	kubeconfigHandler.On("Modify", 80, "fake-token", "temp-kubeconfig").Return(nil)
+
+	hasDeployEnv := mock.MatchedBy(func(env []string) bool {
+		return slices.Contains(env, "KUBECONFIG=temp-kubeconfig") &&
+			slices.Contains(env, "OKTETO_WITHIN_DEPLOY_COMMAND_CONTEXT=true") &&
+			slices.Contains(env, "OKTETO_NAMESPACE=test")
+	})
+	pre := model.DeployCommand{Name: "backup", Command: "make backup"}
+	post := model.DeployCommand{Name: "migrate", Command: "make migrate"}
+	executor := &fakeExecutor{}
+	executor.On("Execute", pre, hasDeployEnv).Return(nil).Once()
+	executor.On("Execute", post, hasDeployEnv).Return(nil).Once()
+	executor.On("CleanUp", nil).Return().Once()
+
+	r := DeployRunner{
+		K8sClientProvider:  test.NewFakeK8sProvider(),
+		Proxy:              proxy,
+		Kubeconfig:         kubeconfigHandler,
+		TempKubeconfigFile: "temp-kubeconfig",
+		Fs:                 afero.NewMemMapFs(),
+		ConfigMapHandler:   &fakeCmapHandler{},
+		Executor:           executor,
+		ResourcesWaiter:    &fakeResourcesWaiter{},
+	}
+	params := DeployParameters{
+		Name:      "test",
+		Namespace: "test",
+		Deployable: Entity{
+			Hooks: &model.Hooks{
+				Pre:  []model.DeployCommand{pre},
+				Post: []model.DeployCommand{post},
+			},
+		},
+	}
+
+	require.NoError(t, r.RunDeploy(context.Background(), params))
+	executor.AssertExpectations(t)
+}
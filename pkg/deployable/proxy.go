@@ -63,6 +63,14 @@ type Proxy struct {
 
 type proxyHandler struct {
 	DivertDriver divert.Driver
+	transport    http.RoundTripper
+	destination  *url.URL
+	// differ shows the changes to the resources before they are applied. It is nil if the changes are not shown
+	differ *resourceDiffer
+	// applied tracks the resources applied by the deploy commands
+	applied *appliedResources
+	// readOnly rejects the requests that change the cluster, like in dry-run mode
+	readOnly bool
 	// Name is sanitized version of the pipeline name
 	Name string
 }
@@ -92,7 +100,9 @@ func NewProxy(kubeconfig KubeConfigHandler, portGetter PortGetterFunc) (*Proxy,
 		return nil, err
 	}
 
-	ph := &proxyHandler{}
+	ph := &proxyHandler{
+		applied: newAppliedResources(),
+	}
 	handler, err := ph.getProxyHandler(sessionToken, clusterConfig)
 	if err != nil {
 		oktetoLog.Errorf("could not configure local proxy: %s", err)
@@ -182,6 +192,23 @@ func (p *Proxy) SetDivert(driver divert.Driver) {
 	p.proxyHandler.SetDivert(driver)
 }
 
+// SetDiff shows the changes to the resources before they are applied. If confirm is set, the user
+// has to accept the changes of each resource
+func (p *Proxy) SetDiff(confirm bool) {
+	p.proxyHandler.SetDiff(confirm)
+}
+
+// SetReadOnly rejects the requests of the deploy commands that change the cluster.
+// If the changes are shown, the requests are sent to the cluster as a server-side dry run instead
+func (p *Proxy) SetReadOnly() {
+	p.proxyHandler.readOnly = true
+}
+
+// GetResources returns the resources applied by the deploy commands
+func (p *Proxy) GetResources() []string {
+	return p.proxyHandler.applied.list()
+}
+
 func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config) (http.Handler, error) {
 	// By default we don't disable HTTP/2
 	trans, err := newProtocolTransport(clusterConfig, false)
@@ -198,6 +225,8 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 	}
 	proxy := httputil.NewSingleHostReverseProxy(destinationURL)
 	proxy.Transport = trans
+	ph.transport = trans
+	ph.destination = destinationURL
 
 	oktetoLog.Debugf("forwarding host: %s", clusterConfig.Host)
 
@@ -233,6 +262,17 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 		}
 
 		r.Host = destinationURL.Host
+		preview := false
+		if ph.readOnly && isMutatingRequest(r) {
+			if ph.differ == nil {
+				rejectMutatingRequest(rw, r)
+				return
+			}
+			// the changes are shown and sent to the cluster as a server-side dry run
+			preview = true
+		}
+
+		var body []byte
 		// Modify all resources updated or created to include the label.
 		if r.Method == "PUT" || r.Method == "POST" {
 			b, err := io.ReadAll(r.Body)
@@ -257,10 +297,34 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 			// Needed to set the new Content-Length
 			r.ContentLength = int64(len(b))
 			r.Body = io.NopCloser(bytes.NewBuffer(b))
+			body = b
 		}
 
-		// Redirect request to the k8s server (based on the transport HTTP generated from the config)
-		reverseProxy.ServeHTTP(rw, r)
+		if ph.differ != nil && shouldReview(r) && !ph.differ.reviewRequest(rw, r) {
+			return
+		}
+
+		resource, tracked := getAppliedResource(r, body)
+		if preview {
+			if r.Method == http.MethodDelete {
+				ph.differ.showDeletion(r)
+			}
+			query := r.URL.Query()
+			query.Set("dryRun", "All")
+			r.URL.RawQuery = query.Encode()
+		}
+
+		if !tracked {
+			// Redirect request to the k8s server (based on the transport HTTP generated from the config)
+			reverseProxy.ServeHTTP(rw, r)
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: rw, status: http.StatusOK}
+		reverseProxy.ServeHTTP(recorder, r)
+		if recorder.status < http.StatusBadRequest {
+			ph.applied.record(r.Method, resource)
+		}
 	})
 
 	return handler, nil
@@ -275,6 +339,10 @@ func (ph *proxyHandler) SetDivert(driver divert.Driver) {
 	ph.DivertDriver = driver
 }
 
+func (ph *proxyHandler) SetDiff(confirm bool) {
+	ph.differ = newResourceDiffer(ph.transport, ph.destination, confirm)
+}
+
 func (ph *proxyHandler) translateBody(b []byte) ([]byte, error) {
 	var body map[string]json.RawMessage
 	if err := json.Unmarshal(b, &body); err != nil {
@@ -0,0 +1,132 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// appliedResources are the resources applied by the deploy commands, identified as '<resource>/<name>'
+type appliedResources struct {
+	resources map[string]bool
+	lock      sync.Mutex
+}
+
+func newAppliedResources() *appliedResources {
+	return &appliedResources{
+		resources: map[string]bool{},
+	}
+}
+
+// record tracks a resource changed by a request of the deploy commands. Deleted resources are not tracked anymore
+func (a *appliedResources) record(method, resource string) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if method == http.MethodDelete {
+		delete(a.resources, resource)
+		return
+	}
+	a.resources[resource] = true
+}
+
+// list returns the sorted resources applied by the deploy commands
+func (a *appliedResources) list() []string {
+	if a == nil {
+		return nil
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	result := make([]string, 0, len(a.resources))
+	for resource := range a.resources {
+		result = append(result, resource)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getAppliedResource returns the resource changed by a request of the deploy commands.
+// It returns false if the request doesn't create, update or delete a resource
+func getAppliedResource(r *http.Request, body []byte) (string, bool) {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return "", false
+	}
+	if r.URL.Query().Has("dryRun") {
+		return "", false
+	}
+	path, ok := parseResourcePath(r.URL.Path)
+	if !ok || path.subresource != "" {
+		return "", false
+	}
+
+	name := path.name
+	if r.Method == http.MethodPost {
+		if name != "" {
+			return "", false
+		}
+		var obj struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return "", false
+		}
+		name = obj.Metadata.Name
+	}
+	if name == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s", path.resource, name), true
+}
+
+// getRemovedResources returns the resources of the previous deploy that are not applied by the current one
+func getRemovedResources(previous, current []string) []string {
+	applied := map[string]bool{}
+	for _, resource := range current {
+		applied[resource] = true
+	}
+	result := []string{}
+	for _, resource := range previous {
+		if !applied[resource] {
+			result = append(result, resource)
+		}
+	}
+	return result
+}
+
+// statusRecorder keeps the status code of the response sent to the deploy commands
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
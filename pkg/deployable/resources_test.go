@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAppliedResource(t *testing.T) {
+	tt := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		expected   string
+		expectedOk bool
+	}{
+		{
+			name:       "create",
+			method:     http.MethodPost,
+			url:        "/apis/apps/v1/namespaces/test/deployments",
+			body:       `{"metadata":{"name":"api"}}`,
+			expected:   "deployments/api",
+			expectedOk: true,
+		},
+		{
+			name:   "create with generated name",
+			method: http.MethodPost,
+			url:    "/api/v1/namespaces/test/pods",
+			body:   `{"metadata":{"generateName":"api-"}}`,
+		},
+		{
+			name:       "patch",
+			method:     http.MethodPatch,
+			url:        "/api/v1/namespaces/test/services/api",
+			expected:   "services/api",
+			expectedOk: true,
+		},
+		{
+			name:       "delete",
+			method:     http.MethodDelete,
+			url:        "/api/v1/namespaces/test/configmaps/api",
+			expected:   "configmaps/api",
+			expectedOk: true,
+		},
+		{
+			name:   "subresource",
+			method: http.MethodPut,
+			url:    "/apis/apps/v1/namespaces/test/deployments/api/scale",
+		},
+		{
+			name:   "dry run",
+			method: http.MethodPatch,
+			url:    "/api/v1/namespaces/test/services/api?dryRun=All",
+		},
+		{
+			name:   "get",
+			method: http.MethodGet,
+			url:    "/api/v1/namespaces/test/services/api",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			resource, ok := getAppliedResource(r, []byte(tc.body))
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expected, resource)
+		})
+	}
+}
+
+func TestAppliedResources(t *testing.T) {
+	applied := newAppliedResources()
+	applied.record(http.MethodPost, "services/api")
+	applied.record(http.MethodPatch, "deployments/api")
+	applied.record(http.MethodPost, "configmaps/api")
+	applied.record(http.MethodDelete, "configmaps/api")
+
+	assert.Equal(t, []string{"deployments/api", "services/api"}, applied.list())
+
+	var empty *appliedResources
+	empty.record(http.MethodPost, "services/api")
+	assert.Empty(t, empty.list())
+}
+
+func TestGetRemovedResources(t *testing.T) {
+	previous := []string{"deployments/api", "deployments/worker", "services/api"}
+	current := []string{"deployments/api", "services/api", "services/frontend"}
+
+	assert.Equal(t, []string{"deployments/worker"}, getRemovedResources(previous, current))
+	assert.Empty(t, getRemovedResources(nil, current))
+}
@@ -37,6 +37,8 @@ type Dependency struct {
 	Variables    env.Environment `json:"variables,omitempty" yaml:"variables,omitempty"`
 	Timeout      time.Duration   `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 	Wait         bool            `json:"wait,omitempty" yaml:"wait,omitempty"`
+	Schedule     string          `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	WaitFor      []WaitCondition `json:"waitFor,omitempty" yaml:"waitFor,omitempty"`
 }
 
 // GetTimeout returns dependency.Timeout if it's set or the one passed as arg if it's not
@@ -108,6 +110,19 @@ func (d *Dependency) ExpandVars(variables []string) error {
 	}
 	d.Variables = expandedVariables
 
+	for i, c := range d.WaitFor {
+		expandedEndpoint, err := parser.Parse(c.Endpoint)
+		if err != nil {
+			return fmt.Errorf("error expanding 'waitFor' endpoint: %w", err)
+		}
+		expandedJob, err := parser.Parse(c.Job)
+		if err != nil {
+			return fmt.Errorf("error expanding 'waitFor' job: %w", err)
+		}
+		d.WaitFor[i].Endpoint = expandedEndpoint
+		d.WaitFor[i].Job = expandedJob
+	}
+
 	return nil
 }
 
@@ -169,5 +184,15 @@ func (d *Dependency) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 	*d = Dependency(dependencyRaw)
 
+	if d.Schedule != "" {
+		if err := ValidateSchedule(d.Schedule); err != nil {
+			return err
+		}
+	}
+	for _, c := range d.WaitFor {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
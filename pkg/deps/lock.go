@@ -0,0 +1,90 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// LockFileName is the name of the file that locks the commits of the dependencies, stored next to the okteto manifest
+const LockFileName = "okteto.lock"
+
+// Lock represents the commits the dependencies of a manifest are locked to
+type Lock struct {
+	Dependencies map[string]*LockedDependency `yaml:"dependencies,omitempty"`
+}
+
+// LockedDependency represents the commit a dependency is deployed from
+type LockedDependency struct {
+	Repository string `yaml:"repository"`
+	// Branch is the ref of the dependency in the manifest. The lock is ignored if it doesn't match
+	Branch string `yaml:"branch,omitempty"`
+	// Tag is the newest tag the dependency was bumped to when its ref is a semver tag
+	Tag    string `yaml:"tag,omitempty"`
+	Commit string `yaml:"commit"`
+}
+
+// GetLockPath returns the path of the lock file of a manifest
+func GetLockPath(manifestPath string) string {
+	if manifestPath == "" {
+		return LockFileName
+	}
+	return filepath.Join(filepath.Dir(manifestPath), LockFileName)
+}
+
+// ReadLock reads the lock file at path. A missing file returns an empty lock
+func ReadLock(fs afero.Fs, path string) (*Lock, error) {
+	l := &Lock{Dependencies: map[string]*LockedDependency{}}
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return l, nil
+		}
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+	if err := yaml.UnmarshalStrict(b, l); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", path, err)
+	}
+	if l.Dependencies == nil {
+		l.Dependencies = map[string]*LockedDependency{}
+	}
+	return l, nil
+}
+
+// Save writes the lock file at path
+func (l *Lock) Save(fs afero.Fs, path string) error {
+	b, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(fs, path, b, 0600)
+}
+
+// Get returns the locked commit of a dependency if the lock still matches its repository and ref
+func (l *Lock) Get(name string, d *Dependency) (*LockedDependency, bool) {
+	locked, ok := l.Dependencies[name]
+	if !ok || locked == nil || locked.Commit == "" {
+		return nil, false
+	}
+	if locked.Repository != d.Repository || locked.Branch != d.Branch {
+		return nil, false
+	}
+	return locked, true
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLockPath(t *testing.T) {
+	assert.Equal(t, "okteto.lock", GetLockPath(""))
+	assert.Equal(t, "okteto.lock", GetLockPath("okteto.yml"))
+	assert.Equal(t, "/app/okteto.lock", GetLockPath("/app/okteto.yml"))
+}
+
+func TestReadLockNotFound(t *testing.T) {
+	l, err := ReadLock(afero.NewMemMapFs(), "okteto.lock")
+	require.NoError(t, err)
+	assert.Empty(t, l.Dependencies)
+}
+
+func TestReadLockInvalid(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "okteto.lock", []byte("dependencies:\n  api:\n    unknown: value\n"), 0600))
+	_, err := ReadLock(fs, "okteto.lock")
+	require.Error(t, err)
+}
+
+func TestLockSaveAndGet(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	l := &Lock{
+		Dependencies: map[string]*LockedDependency{
+			"api": {
+				Repository: "https://github.com/okteto/api",
+				Branch:     "v1.0.0",
+				Tag:        "v1.2.0",
+				Commit:     "0123456789abcdef0123456789abcdef01234567",
+			},
+		},
+	}
+	require.NoError(t, l.Save(fs, "okteto.lock"))
+
+	loaded, err := ReadLock(fs, "okteto.lock")
+	require.NoError(t, err)
+	assert.Equal(t, l, loaded)
+
+	locked, ok := loaded.Get("api", &Dependency{Repository: "https://github.com/okteto/api", Branch: "v1.0.0"})
+	require.True(t, ok)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", locked.Commit)
+
+	_, ok = loaded.Get("api", &Dependency{Repository: "https://github.com/okteto/api", Branch: "main"})
+	assert.False(t, ok)
+
+	_, ok = loaded.Get("frontend", &Dependency{Repository: "https://github.com/okteto/frontend"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/okteto/okteto/pkg/repository"
+)
+
+// RefLister lists the references of a remote repository
+type RefLister interface {
+	ListRefs(ctx context.Context, repository string) ([]*plumbing.Reference, error)
+}
+
+// GitRefLister lists the references of a remote repository using the git protocol
+type GitRefLister struct{}
+
+// ListRefs returns the references of a remote repository
+func (GitRefLister) ListRefs(ctx context.Context, repositoryURL string) ([]*plumbing.Reference, error) {
+	return repository.ListRemoteRefs(ctx, repositoryURL)
+}
+
+// Status represents how a dependency compares to its upstream repository
+type Status struct {
+	Name       string
+	Repository string
+	// Ref is the branch, tag or commit of the dependency in the manifest
+	Ref string
+	// Current is the locked revision of the dependency. Empty when the dependency is not locked
+	Current string
+	// Latest is the newest revision of the ref: the head of a branch or the newest semver tag
+	Latest string
+	// LatestTag is set when the ref is a semver tag and there is a newer one
+	LatestTag string
+	// LatestCommit is the commit of the latest revision
+	LatestCommit string
+	Outdated     bool
+	// Lockable is false for dependencies pinned to a commit, which have to be updated in the manifest
+	Lockable bool
+}
+
+// CheckOutdated compares the ref of a dependency and its locked commit with the references of its upstream repository
+func CheckOutdated(ctx context.Context, lister RefLister, name string, d *Dependency, locked *LockedDependency) (*Status, error) {
+	refs, err := lister.ListRefs(ctx, d.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the references of repository '%s': %w", d.Repository, err)
+	}
+
+	s := &Status{
+		Name:       name,
+		Repository: d.Repository,
+		Ref:        d.Branch,
+		Lockable:   true,
+	}
+	if locked != nil {
+		s.Current = locked.Commit
+		if locked.Tag != "" {
+			s.Current = locked.Tag
+		}
+	}
+
+	if d.Branch == "" {
+		head, ok := findHeadCommit(refs)
+		if !ok {
+			return nil, fmt.Errorf("default branch of repository '%s' not found", d.Repository)
+		}
+		s.setBranchHead(head, locked)
+		return s, nil
+	}
+
+	if commit, ok := repository.FindRefCommit(refs, repository.TagRefPrefix+d.Branch); ok {
+		s.setTag(refs, commit, locked)
+		return s, nil
+	}
+
+	if head, ok := repository.FindRefCommit(refs, repository.HeadRefPrefix+d.Branch); ok {
+		s.setBranchHead(head, locked)
+		return s, nil
+	}
+
+	if repository.IsCommitSHA(d.Branch) {
+		head, ok := findHeadCommit(refs)
+		if !ok {
+			return nil, fmt.Errorf("default branch of repository '%s' not found", d.Repository)
+		}
+		s.Lockable = false
+		s.Current = d.Branch
+		s.Latest = head
+		s.LatestCommit = head
+		s.Outdated = !strings.HasPrefix(head, d.Branch)
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("ref '%s' not found in repository '%s'", d.Branch, d.Repository)
+}
+
+// Lock returns the lock of the latest revision of the dependency
+func (s *Status) Lock() *LockedDependency {
+	return &LockedDependency{
+		Repository: s.Repository,
+		Branch:     s.Ref,
+		Tag:        s.LatestTag,
+		Commit:     s.LatestCommit,
+	}
+}
+
+func (s *Status) setBranchHead(head string, locked *LockedDependency) {
+	s.Latest = head
+	s.LatestCommit = head
+	s.Outdated = locked != nil && locked.Commit != head
+}
+
+func (s *Status) setTag(refs []*plumbing.Reference, commit string, locked *LockedDependency) {
+	s.Latest = s.Ref
+	s.LatestCommit = commit
+
+	if latest, latestCommit, ok := findNewerTag(refs, s.Ref); ok {
+		s.Latest = latest
+		s.LatestTag = latest
+		s.LatestCommit = latestCommit
+	}
+
+	switch {
+	case locked == nil:
+		s.Current = s.Ref
+		s.Outdated = s.LatestTag != ""
+	case locked.Tag != "":
+		s.Outdated = locked.Tag != s.Latest
+	default:
+		// the tag was moved after the dependency was locked
+		s.Outdated = locked.Commit != s.LatestCommit
+	}
+}
+
+// findNewerTag returns the newest semver tag greater than tag, ignoring pre-releases unless tag is a pre-release
+func findNewerTag(refs []*plumbing.Reference, tag string) (string, string, bool) {
+	current, err := semver.NewVersion(tag)
+	if err != nil {
+		return "", "", false
+	}
+	hasPrefix := strings.HasPrefix(tag, "v")
+
+	var newest *semver.Version
+	newestTag := ""
+	for _, ref := range refs {
+		name := ref.Name().String()
+		if !strings.HasPrefix(name, repository.TagRefPrefix) || strings.HasSuffix(name, repository.PeeledRefSuffix) {
+			continue
+		}
+		candidate := strings.TrimPrefix(name, repository.TagRefPrefix)
+		if strings.HasPrefix(candidate, "v") != hasPrefix {
+			continue
+		}
+		v, err := semver.NewVersion(candidate)
+		if err != nil {
+			continue
+		}
+		if v.Prerelease() != "" && current.Prerelease() == "" {
+			continue
+		}
+		if !v.GreaterThan(current) {
+			continue
+		}
+		if newest == nil || v.GreaterThan(newest) {
+			newest = v
+			newestTag = candidate
+		}
+	}
+	if newest == nil {
+		return "", "", false
+	}
+	commit, _ := repository.FindRefCommit(refs, repository.TagRefPrefix+newestTag)
+	return newestTag, commit, true
+}
+
+// findHeadCommit returns the commit of the default branch of the repository
+func findHeadCommit(refs []*plumbing.Reference) (string, bool) {
+	for _, ref := range refs {
+		if ref.Name() != plumbing.HEAD {
+			continue
+		}
+		if ref.Type() == plumbing.SymbolicReference {
+			return repository.FindRefCommit(refs, ref.Target().String())
+		}
+		return ref.Hash().String(), true
+	}
+	return "", false
+}
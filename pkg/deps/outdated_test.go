@@ -0,0 +1,128 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mainCommit    = "1111111111111111111111111111111111111111"
+	oldCommit     = "2222222222222222222222222222222222222222"
+	v100Commit    = "3333333333333333333333333333333333333333"
+	v120Commit    = "4444444444444444444444444444444444444444"
+	v120TagObject = "5555555555555555555555555555555555555555"
+	rcCommit      = "6666666666666666666666666666666666666666"
+)
+
+type fakeRefLister struct {
+	err  error
+	refs []*plumbing.Reference
+}
+
+func (f fakeRefLister) ListRefs(context.Context, string) ([]*plumbing.Reference, error) {
+	return f.refs, f.err
+}
+
+func newFakeRefLister() fakeRefLister {
+	return fakeRefLister{
+		refs: []*plumbing.Reference{
+			plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main"),
+			plumbing.NewHashReference("refs/heads/main", plumbing.NewHash(mainCommit)),
+			plumbing.NewHashReference("refs/tags/v1.0.0", plumbing.NewHash(v100Commit)),
+			plumbing.NewHashReference("refs/tags/v1.2.0", plumbing.NewHash(v120TagObject)),
+			plumbing.NewHashReference("refs/tags/v1.2.0^{}", plumbing.NewHash(v120Commit)),
+			plumbing.NewHashReference("refs/tags/v1.3.0-rc1", plumbing.NewHash(rcCommit)),
+			plumbing.NewHashReference("refs/tags/2.0.0", plumbing.NewHash(rcCommit)),
+		},
+	}
+}
+
+func TestCheckOutdated(t *testing.T) {
+	tests := []struct {
+		name       string
+		dependency *Dependency
+		locked     *LockedDependency
+		expected   *Status
+	}{
+		{
+			name:       "branch not locked",
+			dependency: &Dependency{Branch: "main"},
+			expected:   &Status{Ref: "main", Latest: mainCommit, LatestCommit: mainCommit, Lockable: true},
+		},
+		{
+			name:       "branch locked to an old commit",
+			dependency: &Dependency{Branch: "main"},
+			locked:     &LockedDependency{Branch: "main", Commit: oldCommit},
+			expected:   &Status{Ref: "main", Current: oldCommit, Latest: mainCommit, LatestCommit: mainCommit, Outdated: true, Lockable: true},
+		},
+		{
+			name:       "default branch up to date",
+			dependency: &Dependency{},
+			locked:     &LockedDependency{Commit: mainCommit},
+			expected:   &Status{Current: mainCommit, Latest: mainCommit, LatestCommit: mainCommit, Lockable: true},
+		},
+		{
+			name:       "tag with newer release",
+			dependency: &Dependency{Branch: "v1.0.0"},
+			expected:   &Status{Ref: "v1.0.0", Current: "v1.0.0", Latest: "v1.2.0", LatestTag: "v1.2.0", LatestCommit: v120Commit, Outdated: true, Lockable: true},
+		},
+		{
+			name:       "tag locked to the newest release",
+			dependency: &Dependency{Branch: "v1.0.0"},
+			locked:     &LockedDependency{Branch: "v1.0.0", Tag: "v1.2.0", Commit: v120Commit},
+			expected:   &Status{Ref: "v1.0.0", Current: "v1.2.0", Latest: "v1.2.0", LatestTag: "v1.2.0", LatestCommit: v120Commit, Lockable: true},
+		},
+		{
+			name:       "newest tag",
+			dependency: &Dependency{Branch: "v1.2.0"},
+			expected:   &Status{Ref: "v1.2.0", Current: "v1.2.0", Latest: "v1.2.0", LatestCommit: v120Commit, Lockable: true},
+		},
+		{
+			name:       "commit",
+			dependency: &Dependency{Branch: "2222222"},
+			expected:   &Status{Ref: "2222222", Current: "2222222", Latest: mainCommit, LatestCommit: mainCommit, Outdated: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckOutdated(context.Background(), newFakeRefLister(), "", tt.dependency, tt.locked)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCheckOutdatedErrors(t *testing.T) {
+	_, err := CheckOutdated(context.Background(), newFakeRefLister(), "api", &Dependency{Branch: "unknown"}, nil)
+	require.Error(t, err)
+
+	_, err = CheckOutdated(context.Background(), fakeRefLister{err: assert.AnError}, "api", &Dependency{}, nil)
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestStatusLock(t *testing.T) {
+	s := &Status{Repository: "https://github.com/okteto/api", Ref: "v1.0.0", LatestTag: "v1.2.0", LatestCommit: v120Commit}
+	assert.Equal(t, &LockedDependency{
+		Repository: "https://github.com/okteto/api",
+		Branch:     "v1.0.0",
+		Tag:        "v1.2.0",
+		Commit:     v120Commit,
+	}, s.Lock())
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// scheduleDescriptors are the predefined schedules accepted instead of a cron expression
+var scheduleDescriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// cronField is the name and the range of values of each field of a cron expression
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// ValidateSchedule returns an error if schedule is not a valid cron expression, e.g. '0 3 * * *'
+func ValidateSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if scheduleDescriptors[schedule] {
+		return nil
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("invalid schedule '%s': expected 5 fields (minute, hour, day of month, month, day of week)", schedule)
+	}
+	for i, f := range fields {
+		if err := validateCronField(f, cronFields[i]); err != nil {
+			return fmt.Errorf("invalid schedule '%s': %w", schedule, err)
+		}
+	}
+	return nil
+}
+
+func validateCronField(value string, field cronField) error {
+	for _, item := range strings.Split(value, ",") {
+		rangeValue, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step '%s' in %s", step, field.name)
+			}
+		}
+		if rangeValue == "*" {
+			continue
+		}
+		start, end, isRange := strings.Cut(rangeValue, "-")
+		first, err := parseCronValue(start, field)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		last, err := parseCronValue(end, field)
+		if err != nil {
+			return err
+		}
+		if first > last {
+			return fmt.Errorf("invalid range '%s' in %s", rangeValue, field.name)
+		}
+	}
+	return nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < field.min || n > field.max {
+		return 0, fmt.Errorf("%s must be a value between %d and %d, got '%s'", field.name, field.min, field.max, value)
+	}
+	return n, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	var tests = []struct {
+		name      string
+		schedule  string
+		expectErr bool
+	}{
+		{name: "every night", schedule: "0 3 * * *"},
+		{name: "descriptor", schedule: "@weekly"},
+		{name: "ranges, lists and steps", schedule: "*/15 9-18 1,15 * 1-5"},
+		{name: "missing fields", schedule: "0 3 * *", expectErr: true},
+		{name: "out of range", schedule: "60 3 * * *", expectErr: true},
+		{name: "invalid step", schedule: "*/0 * * * *", expectErr: true},
+		{name: "inverted range", schedule: "0 18-9 * * *", expectErr: true},
+		{name: "unknown descriptor", schedule: "@sometimes", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchedule(tt.schedule)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDependencyScheduleUnmarshalling(t *testing.T) {
+	var d Dependency
+	err := yaml.Unmarshal([]byte("repository: https://github.com/okteto/movies\nschedule: 0 3 * * *"), &d)
+	assert.NoError(t, err)
+	assert.Equal(t, "0 3 * * *", d.Schedule)
+
+	err = yaml.Unmarshal([]byte("repository: https://github.com/okteto/movies\nschedule: nightly"), &d)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// WaitCondition is a condition that must be met before deploying the next dependency, in addition to
+// or instead of waiting for the whole dependency to be deployed. Only one of its fields can be set
+type WaitCondition struct {
+	// Variable is the name of a variable exported by the dependency
+	Variable string `json:"variable,omitempty" yaml:"variable,omitempty"`
+	// Endpoint is an url that must respond without a server error
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	// Job is the name of a job of the dependency namespace that must complete
+	Job string `json:"job,omitempty" yaml:"job,omitempty"`
+}
+
+// Validate returns an error if the condition doesn't define exactly one of variable, endpoint or job
+func (c WaitCondition) Validate() error {
+	defined := 0
+	for _, v := range []string{c.Variable, c.Endpoint, c.Job} {
+		if v != "" {
+			defined++
+		}
+	}
+	if defined != 1 {
+		return fmt.Errorf("invalid 'waitFor' condition: exactly one of 'variable', 'endpoint' or 'job' must be defined")
+	}
+	return nil
+}
+
+// ValidateEndpoint returns an error if the endpoint of the condition is not an http or https url.
+// It must be called once the variables of the endpoint are expanded
+func (c WaitCondition) ValidateEndpoint() error {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid 'waitFor' endpoint '%s': must be an http or https url", c.Endpoint)
+	}
+	return nil
+}
+
+// String returns a description of the condition for the user
+func (c WaitCondition) String() string {
+	switch {
+	case c.Variable != "":
+		return fmt.Sprintf("variable '%s'", c.Variable)
+	case c.Endpoint != "":
+		return fmt.Sprintf("endpoint '%s'", c.Endpoint)
+	default:
+		return fmt.Sprintf("job '%s'", c.Job)
+	}
+}
+
+// HasVariableCondition returns if the dependency waits for a variable, which is only exported when its deployment finishes
+func (d *Dependency) HasVariableCondition() bool {
+	for _, c := range d.WaitFor {
+		if c.Variable != "" {
+			return true
+		}
+	}
+	return false
+}
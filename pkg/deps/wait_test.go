@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestWaitConditionValidate(t *testing.T) {
+	var tests = []struct {
+		name      string
+		condition WaitCondition
+		expectErr bool
+	}{
+		{name: "variable", condition: WaitCondition{Variable: "API_URL"}},
+		{name: "endpoint", condition: WaitCondition{Endpoint: "https://api.okteto.dev/healthz"}},
+		{name: "job", condition: WaitCondition{Job: "migrations"}},
+		{name: "empty", condition: WaitCondition{}, expectErr: true},
+		{name: "several", condition: WaitCondition{Variable: "API_URL", Job: "migrations"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.condition.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWaitConditionValidateEndpoint(t *testing.T) {
+	assert.NoError(t, WaitCondition{Endpoint: "http://api:8080/healthz"}.ValidateEndpoint())
+	assert.Error(t, WaitCondition{Endpoint: "api:8080/healthz"}.ValidateEndpoint())
+	assert.Error(t, WaitCondition{Endpoint: "ftp://api/healthz"}.ValidateEndpoint())
+}
+
+func TestDependencyWaitForUnmarshalling(t *testing.T) {
+	manifest := []byte(`repository: https://github.com/okteto/movies
+waitFor:
+  - variable: API_URL
+  - endpoint: https://movies-${OKTETO_NAMESPACE}.okteto.dev/healthz
+  - job: migrations`)
+	var d Dependency
+	require.NoError(t, yaml.Unmarshal(manifest, &d))
+	assert.Equal(t, []WaitCondition{
+		{Variable: "API_URL"},
+		{Endpoint: "https://movies-${OKTETO_NAMESPACE}.okteto.dev/healthz"},
+		{Job: "migrations"},
+	}, d.WaitFor)
+	assert.True(t, d.HasVariableCondition())
+
+	err := yaml.Unmarshal([]byte("repository: https://github.com/okteto/movies\nwaitFor:\n  - variable: API_URL\n    job: migrations"), &d)
+	assert.Error(t, err)
+}
+
+func TestDependencyWaitForExpandVars(t *testing.T) {
+	d := &Dependency{
+		WaitFor: []WaitCondition{
+			{Endpoint: "https://movies-${NAMESPACE}.okteto.dev/healthz"},
+			{Job: "${JOB}"},
+		},
+	}
+	require.NoError(t, d.ExpandVars([]string{"NAMESPACE=cindy", "JOB=migrations"}))
+	assert.Equal(t, "https://movies-cindy.okteto.dev/healthz", d.WaitFor[0].Endpoint)
+	assert.Equal(t, "migrations", d.WaitFor[1].Job)
+	assert.False(t, d.HasVariableCondition())
+}
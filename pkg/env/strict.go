@@ -0,0 +1,199 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const oktetoVarPrefix = "OKTETO_"
+
+var (
+	// referenceRegex matches "$$", "$VAR", "${VAR}" and "${VAR<modifier>}"
+	referenceRegex = regexp.MustCompile(`\$(\$|\{([A-Za-z_][A-Za-z0-9_]*)([^}]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+	// defaultModifierRegex matches the modifiers that provide a value when the variable is not set
+	defaultModifierRegex = regexp.MustCompile(`^:?[-=]`)
+
+	// commandKeys are the manifest keys whose values are executed by a shell, where variables
+	// might be defined by the commands themselves
+	commandKeys = map[string]bool{
+		"command":  true,
+		"commands": true,
+	}
+
+	// commandListKeys are the manifest keys that accept a list of commands
+	commandListKeys = map[string]bool{
+		"deploy":  true,
+		"destroy": true,
+	}
+)
+
+// Reference is a variable referenced in a manifest
+type Reference struct {
+	Name string
+	// Line is the line of the manifest where the variable is referenced
+	Line int
+	// Column is the column of the manifest where the variable is referenced. It is 0 when it can't be determined
+	Column int
+	// HasDefault is true when the reference provides a default value, like "${VAR:-default}"
+	HasDefault bool
+	// InCommand is true when the reference is part of a command executed by a shell
+	InCommand bool
+}
+
+// Location returns the position of the reference in the manifest
+func (r Reference) Location() string {
+	if r.Column == 0 {
+		return fmt.Sprintf("%d", r.Line)
+	}
+	return fmt.Sprintf("%d:%d", r.Line, r.Column)
+}
+
+// StrictVarsError is returned when a manifest references undefined variables or when provided variables are not used
+type StrictVarsError struct {
+	File      string
+	Undefined []Reference
+	Unused    []string
+}
+
+// Error returns the error message listing every undefined and unused variable
+func (e StrictVarsError) Error() string {
+	var sb strings.Builder
+	if len(e.Undefined) > 0 {
+		sb.WriteString("the manifest references undefined variables:")
+		for _, ref := range e.Undefined {
+			sb.WriteString(fmt.Sprintf("\n    - %s at %s:%s", ref.Name, e.File, ref.Location()))
+		}
+	}
+	if len(e.Unused) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("the following variables are not used by the manifest:")
+		for _, name := range e.Unused {
+			sb.WriteString(fmt.Sprintf("\n    - %s", name))
+		}
+	}
+	return sb.String()
+}
+
+// FindReferences returns the variables referenced in the values of a yaml document
+func FindReferences(content []byte) ([]Reference, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	refs := []Reference{}
+	collectReferences(&doc, false, &refs)
+	return refs, nil
+}
+
+// CheckStrict validates that every variable referenced by the manifest outside of commands is defined
+// and that every provided variable is referenced by the manifest. Provided variables are considered defined,
+// and isDefined reports if any other variable has a value.
+// Variables with the OKTETO_ prefix are set by okteto at runtime and are always considered defined
+func CheckStrict(content []byte, file string, provided []string, isDefined func(string) bool) error {
+	refs, err := FindReferences(content)
+	if err != nil {
+		return err
+	}
+
+	providedVars, err := Parse(provided)
+	if err != nil {
+		return err
+	}
+	providedNames := map[string]bool{}
+	for _, v := range providedVars {
+		providedNames[v.Name] = true
+	}
+
+	used := map[string]bool{}
+	strictErr := StrictVarsError{File: file}
+	for _, ref := range refs {
+		used[ref.Name] = true
+		if ref.InCommand || ref.HasDefault || providedNames[ref.Name] || strings.HasPrefix(ref.Name, oktetoVarPrefix) || isDefined(ref.Name) {
+			continue
+		}
+		strictErr.Undefined = append(strictErr.Undefined, ref)
+	}
+
+	for _, v := range providedVars {
+		if !used[v.Name] {
+			strictErr.Unused = append(strictErr.Unused, v.Name)
+		}
+	}
+	sort.Strings(strictErr.Unused)
+
+	if len(strictErr.Undefined) > 0 || len(strictErr.Unused) > 0 {
+		return strictErr
+	}
+	return nil
+}
+
+func collectReferences(node *yaml.Node, inCommand bool, refs *[]Reference) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			collectReferences(child, inCommand, refs)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childInCommand := inCommand || commandKeys[key.Value] || (commandListKeys[key.Value] && value.Kind == yaml.SequenceNode)
+			collectReferences(value, childInCommand, refs)
+		}
+	case yaml.ScalarNode:
+		*refs = append(*refs, scalarReferences(node, inCommand)...)
+	}
+}
+
+func scalarReferences(node *yaml.Node, inCommand bool) []Reference {
+	result := []Reference{}
+	isBlock := node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0
+	for _, match := range referenceRegex.FindAllStringSubmatchIndex(node.Value, -1) {
+		if node.Value[match[2]:match[3]] == "$" {
+			// "$$" escapes the dollar sign
+			continue
+		}
+
+		ref := Reference{InCommand: inCommand}
+		if match[4] != -1 {
+			ref.Name = node.Value[match[4]:match[5]]
+			ref.HasDefault = defaultModifierRegex.MatchString(node.Value[match[6]:match[7]])
+		} else {
+			ref.Name = node.Value[match[8]:match[9]]
+		}
+
+		before := node.Value[:match[0]]
+		lineOffset := strings.Count(before, "\n")
+		ref.Line = node.Line + lineOffset
+		if isBlock {
+			// block scalars start on the line after the indicator
+			ref.Line++
+		} else if lineOffset == 0 {
+			ref.Column = node.Column + len(before)
+			if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+				ref.Column++
+			}
+		}
+		result = append(result, ref)
+	}
+	return result
+}
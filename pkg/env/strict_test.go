@@ -0,0 +1,130 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindReferences(t *testing.T) {
+	manifest := []byte(`build:
+  api:
+    image: okteto.dev/api:${TAG}
+    args:
+      KEY: "$$ESCAPED-$VALUE"
+deploy:
+  - helm upgrade --install api chart --set image=$IMAGE
+dev:
+  api:
+    image: ${DEV_IMAGE:-alpine}
+    command: |
+      echo ok
+      echo $LOCAL
+`)
+
+	refs, err := FindReferences(manifest)
+	require.NoError(t, err)
+
+	expected := []Reference{
+		{Name: "TAG", Line: 3, Column: 27},
+		{Name: "VALUE", Line: 5, Column: 23},
+		{Name: "IMAGE", Line: 7, InCommand: true, Column: 50},
+		{Name: "DEV_IMAGE", Line: 10, Column: 12, HasDefault: true},
+		{Name: "LOCAL", Line: 13, InCommand: true},
+	}
+	assert.Equal(t, expected, refs)
+}
+
+func TestFindReferencesInvalidYaml(t *testing.T) {
+	_, err := FindReferences([]byte("deploy: ["))
+	assert.Error(t, err)
+}
+
+func TestCheckStrict(t *testing.T) {
+	manifest := []byte(`build:
+  api:
+    image: okteto.dev/api:${TAG}
+    context: ${CONTEXT:-.}
+deploy:
+  - kubectl apply -f $K8S_FILE
+  - echo $OKTETO_NAMESPACE
+dev:
+  api:
+    image: $DEV_IMAGE
+    environment:
+      BRANCH: ${OKTETO_GIT_BRANCH}
+`)
+	defined := map[string]bool{"DEV_IMAGE": true}
+	isDefined := func(name string) bool { return defined[name] }
+
+	tests := []struct {
+		expected error
+		name     string
+		provided []string
+	}{
+		{
+			name:     "all variables defined and used",
+			provided: []string{"TAG=1.0", "K8S_FILE=k8s.yml"},
+			expected: nil,
+		},
+		{
+			name:     "undefined variable",
+			provided: []string{"K8S_FILE=k8s.yml"},
+			expected: StrictVarsError{
+				File:      "okteto.yml",
+				Undefined: []Reference{{Name: "TAG", Line: 3, Column: 27}},
+			},
+		},
+		{
+			name:     "unused variables",
+			provided: []string{"TAG=1.0", "UNUSED=value", "ANOTHER=value"},
+			expected: StrictVarsError{
+				File:   "okteto.yml",
+				Unused: []string{"ANOTHER", "UNUSED"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStrict(manifest, "okteto.yml", tt.provided, isDefined)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestCheckStrictInvalidVariable(t *testing.T) {
+	err := CheckStrict([]byte("deploy: []"), "okteto.yml", []string{"INVALID"}, func(string) bool { return false })
+	assert.Error(t, err)
+}
+
+func TestStrictVarsErrorMessage(t *testing.T) {
+	err := StrictVarsError{
+		File: "okteto.yml",
+		Undefined: []Reference{
+			{Name: "TAG", Line: 3, Column: 27},
+			{Name: "SCRIPT", Line: 9},
+		},
+		Unused: []string{"UNUSED"},
+	}
+	expected := `the manifest references undefined variables:
+    - TAG at okteto.yml:3:27
+    - SCRIPT at okteto.yml:9
+the following variables are not used by the manifest:
+    - UNUSED`
+	assert.Equal(t, expected, err.Error())
+}
@@ -79,6 +79,9 @@ var (
 	// ErrNotFound is raised when an object is not found
 	ErrNotFound = fmt.Errorf("not found")
 
+	// ErrRegistryUnavailable is raised when the registry can't be reached after retrying the operation
+	ErrRegistryUnavailable = errors.New("registry unavailable")
+
 	// ErrInternalServerError is raised when an internal server error or similar is received
 	ErrInternalServerError = fmt.Errorf("internal server error, please try again")
 
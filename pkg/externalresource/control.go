@@ -101,7 +101,7 @@ func (c *K8sControl) List(ctx context.Context, ns string, labelSelector string)
 func translate(name, namespace string, externalResource *ExternalResource, now time.Time) *k8s.External {
 	var externalEndpointsSpec []k8s.Endpoint
 	for _, endpoint := range externalResource.Endpoints {
-		externalEndpointsSpec = append(externalEndpointsSpec, k8s.Endpoint(*endpoint))
+		externalEndpointsSpec = append(externalEndpointsSpec, k8s.Endpoint{Name: endpoint.Name, Url: endpoint.Url})
 	}
 
 	var notes *k8s.Notes
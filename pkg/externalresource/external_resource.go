@@ -46,8 +46,9 @@ type Notes struct {
 
 // ExternalEndpoint represents information about an endpoint
 type ExternalEndpoint struct {
-	Name string
-	Url  string
+	Expect *Expectation
+	Name   string
+	Url    string
 }
 
 // ERFilesystemManager represents ExternalResource information with the filesystem injected
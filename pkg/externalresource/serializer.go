@@ -15,6 +15,8 @@ package externalresource
 
 import (
 	"fmt"
+	"net"
+	"strings"
 
 	"github.com/a8m/envsubst"
 )
@@ -26,8 +28,19 @@ type externalResourceUnmarshaller struct {
 }
 
 type externalEndpointUnmarshaller struct {
-	Name string `yaml:"name,omitempty"`
-	Url  string `yaml:"url,omitempty"`
+	Expect *expectationUnmarshaller `yaml:"expect,omitempty"`
+	Name   string                   `yaml:"name,omitempty"`
+	Url    string                   `yaml:"url,omitempty"`
+}
+
+type expectationUnmarshaller struct {
+	DNS       []dnsRecordUnmarshaller `yaml:"dns,omitempty"`
+	Reachable bool                    `yaml:"reachable,omitempty"`
+}
+
+type dnsRecordUnmarshaller struct {
+	Type  string `yaml:"type,omitempty"`
+	Value string `yaml:"value,omitempty"`
 }
 
 func (er *ExternalResource) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -67,15 +80,61 @@ func (er *ExternalResource) UnmarshalYAML(unmarshal func(interface{}) error) err
 		if err != nil {
 			return fmt.Errorf("error expanding environment on '%s': %w", endpoint.Name, err)
 		}
+		expect, err := endpoint.Expect.toExpectation(name)
+		if err != nil {
+			return err
+		}
 		er.Endpoints = append(er.Endpoints, &ExternalEndpoint{
-			Name: name,
-			Url:  url,
+			Name:   name,
+			Url:    url,
+			Expect: expect,
 		})
 	}
 
 	return nil
 }
 
+func (e *expectationUnmarshaller) toExpectation(endpointName string) (*Expectation, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	result := &Expectation{
+		Reachable: e.Reachable,
+	}
+	for _, record := range e.DNS {
+		value, err := envsubst.String(record.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding environment on '%s': %w", record.Value, err)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("the dns records of the endpoint '%s' must have a value", endpointName)
+		}
+
+		recordType := strings.ToUpper(record.Type)
+		switch recordType {
+		case DNSRecordTypeA:
+			if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+				return nil, fmt.Errorf("the value '%s' of the dns record of the endpoint '%s' is not a valid IPv4 address", value, endpointName)
+			}
+		case DNSRecordTypeAAAA:
+			if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+				return nil, fmt.Errorf("the value '%s' of the dns record of the endpoint '%s' is not a valid IPv6 address", value, endpointName)
+			}
+		case DNSRecordTypeCNAME:
+		default:
+			return nil, fmt.Errorf("the dns record type '%s' of the endpoint '%s' is not supported. Supported types are: '%s', '%s' and '%s'", record.Type, endpointName, DNSRecordTypeA, DNSRecordTypeAAAA, DNSRecordTypeCNAME)
+		}
+
+		result.DNS = append(result.DNS, DNSRecord{
+			Type:  recordType,
+			Value: value,
+		})
+	}
+
+	return result, nil
+}
+
 func (notes *Notes) MarshalYAML() (interface{}, error) {
 	return notes.Path, nil
 }
@@ -59,6 +59,73 @@ icon: default
 notes: /path/to/file`),
 			expectedErr: true,
 		},
+		{
+			name: "invalid external resource: unsupported dns record type",
+			data: []byte(`
+endpoints:
+- name: endpoint1
+  url: https://api.example.com
+  expect:
+    dns:
+    - type: MX
+      value: mail.example.com`),
+			expectedErr: true,
+		},
+		{
+			name: "invalid external resource: invalid A record",
+			data: []byte(`
+endpoints:
+- name: endpoint1
+  url: https://api.example.com
+  expect:
+    dns:
+    - type: A
+      value: api.example.com`),
+			expectedErr: true,
+		},
+		{
+			name: "invalid external resource: dns record without value",
+			data: []byte(`
+endpoints:
+- name: endpoint1
+  url: https://api.example.com
+  expect:
+    dns:
+    - type: CNAME`),
+			expectedErr: true,
+		},
+		{
+			name: "valid external resource with expectations",
+			data: []byte(`
+endpoints:
+- name: endpoint1
+  url: https://api.example.com
+  expect:
+    reachable: true
+    dns:
+    - type: a
+      value: 10.0.0.1
+    - type: AAAA
+      value: "2001:db8::1"
+    - type: CNAME
+      value: ${NAME}.example.com`),
+			expected: ExternalResource{
+				Endpoints: []*ExternalEndpoint{
+					{
+						Name: "endpoint1",
+						Url:  "https://api.example.com",
+						Expect: &Expectation{
+							Reachable: true,
+							DNS: []DNSRecord{
+								{Type: DNSRecordTypeA, Value: "10.0.0.1"},
+								{Type: DNSRecordTypeAAAA, Value: "2001:db8::1"},
+								{Type: DNSRecordTypeCNAME, Value: "test.example.com"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "valid external resource with property 'notes' empty",
 			data: []byte(`
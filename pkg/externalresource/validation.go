@@ -0,0 +1,187 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externalresource
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// DNSRecordTypeA represents a DNS record pointing to an IPv4 address
+	DNSRecordTypeA = "A"
+
+	// DNSRecordTypeAAAA represents a DNS record pointing to an IPv6 address
+	DNSRecordTypeAAAA = "AAAA"
+
+	// DNSRecordTypeCNAME represents a DNS record pointing to another domain name
+	DNSRecordTypeCNAME = "CNAME"
+
+	defaultValidationTimeout  = 2 * time.Minute
+	defaultValidationInterval = 5 * time.Second
+	reachableRequestTimeout   = 10 * time.Second
+)
+
+// Expectation represents the checks an endpoint has to pass before the development environment is ready
+type Expectation struct {
+	DNS       []DNSRecord
+	Reachable bool
+}
+
+// DNSRecord represents a DNS record the host of an endpoint is expected to resolve to
+type DNSRecord struct {
+	Type  string
+	Value string
+}
+
+type resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// Validator checks that the endpoints of an external resource meet their expectations
+type Validator struct {
+	resolver resolver
+	client   *http.Client
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewValidator returns a validator using the system resolver
+func NewValidator() *Validator {
+	return &Validator{
+		resolver: net.DefaultResolver,
+		client:   &http.Client{Timeout: reachableRequestTimeout},
+		timeout:  defaultValidationTimeout,
+		interval: defaultValidationInterval,
+	}
+}
+
+// Validate waits until every endpoint of the external resource meets its expectations
+func (v *Validator) Validate(ctx context.Context, name string, er *ExternalResource) error {
+	for _, endpoint := range er.Endpoints {
+		if endpoint.Expect == nil {
+			continue
+		}
+		if err := v.waitForEndpoint(ctx, name, endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Validator) waitForEndpoint(ctx context.Context, name string, endpoint *ExternalEndpoint) error {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		err := v.checkEndpoint(ctx, endpoint)
+		if err == nil {
+			return nil
+		}
+		oktetoLog.Infof("endpoint '%s' of the external resource '%s' is not ready: %s", endpoint.Name, name, err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("endpoint '%s' of the external resource '%s' is not ready: %w", endpoint.Name, name, err),
+				Hint: "Check the DNS configuration of the endpoint or update the 'expect' section of the external resource in your okteto manifest",
+			}
+		}
+	}
+}
+
+func (v *Validator) checkEndpoint(ctx context.Context, endpoint *ExternalEndpoint) error {
+	u, err := url.Parse(endpoint.Url)
+	if err != nil {
+		return fmt.Errorf("invalid url '%s': %w", endpoint.Url, err)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("invalid url '%s': missing host", endpoint.Url)
+	}
+
+	for _, record := range endpoint.Expect.DNS {
+		if err := v.checkDNSRecord(ctx, host, record); err != nil {
+			return err
+		}
+	}
+
+	if endpoint.Expect.Reachable {
+		return v.checkReachable(ctx, endpoint.Url)
+	}
+	return nil
+}
+
+func (v *Validator) checkDNSRecord(ctx context.Context, host string, record DNSRecord) error {
+	if record.Type == DNSRecordTypeCNAME {
+		cname, err := v.resolver.LookupCNAME(ctx, host)
+		if err != nil {
+			return fmt.Errorf("could not resolve '%s': %w", host, err)
+		}
+		if !strings.EqualFold(strings.TrimSuffix(cname, "."), strings.TrimSuffix(record.Value, ".")) {
+			return fmt.Errorf("'%s' is a CNAME of '%s' instead of '%s'", host, strings.TrimSuffix(cname, "."), record.Value)
+		}
+		return nil
+	}
+
+	addrs, err := v.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return fmt.Errorf("could not resolve '%s': %w", host, err)
+	}
+	expected := net.ParseIP(record.Value)
+	for _, addr := range addrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			continue
+		}
+		// only the addresses of the family of the record are compared: IPv4 for A records and IPv6 for AAAA records
+		if isIPv4 := ip.To4() != nil; isIPv4 != (record.Type == DNSRecordTypeA) {
+			continue
+		}
+		if expected.Equal(ip) {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' doesn't have an %s record with the value '%s'", host, record.Type, record.Value)
+}
+
+func (v *Validator) checkReachable(ctx context.Context, endpointURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid url '%s': %w", endpointURL, err)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("'%s' is not reachable: %w", endpointURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("'%s' is not reachable: it returned status code %d", endpointURL, resp.StatusCode)
+	}
+	return nil
+}
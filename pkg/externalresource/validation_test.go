@@ -0,0 +1,182 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externalresource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	hosts map[string][]string
+	cname map[string]string
+}
+
+func (f fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	addrs, ok := f.hosts[host]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return addrs, nil
+}
+
+func (f fakeResolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	cname, ok := f.cname[host]
+	if !ok {
+		return "", assert.AnError
+	}
+	return cname, nil
+}
+
+func newTestValidator(r resolver) *Validator {
+	return &Validator{
+		resolver: r,
+		client:   http.DefaultClient,
+		timeout:  50 * time.Millisecond,
+		interval: 10 * time.Millisecond,
+	}
+}
+
+func TestValidatorDNS(t *testing.T) {
+	r := fakeResolver{
+		hosts: map[string][]string{
+			"api.example.com": {"10.0.0.1", "2001:db8::1"},
+		},
+		cname: map[string]string{
+			"api.example.com": "Ingress.Example.com.",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		records     []DNSRecord
+		expectedErr bool
+	}{
+		{
+			name: "records match",
+			url:  "https://api.example.com:8443/path",
+			records: []DNSRecord{
+				{Type: DNSRecordTypeA, Value: "10.0.0.1"},
+				{Type: DNSRecordTypeAAAA, Value: "2001:db8:0::1"},
+				{Type: DNSRecordTypeCNAME, Value: "ingress.example.com"},
+			},
+		},
+		{
+			name: "address doesn't match",
+			url:  "https://api.example.com",
+			records: []DNSRecord{
+				{Type: DNSRecordTypeA, Value: "10.0.0.2"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "address of a different family",
+			url:  "https://api.example.com",
+			records: []DNSRecord{
+				{Type: DNSRecordTypeAAAA, Value: "::ffff:10.0.0.1"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "cname doesn't match",
+			url:  "https://api.example.com",
+			records: []DNSRecord{
+				{Type: DNSRecordTypeCNAME, Value: "other.example.com"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "host doesn't resolve",
+			url:  "https://unknown.example.com",
+			records: []DNSRecord{
+				{Type: DNSRecordTypeA, Value: "10.0.0.1"},
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "url without host",
+			url:         "/some/path",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			er := &ExternalResource{
+				Endpoints: []*ExternalEndpoint{
+					{
+						Name:   "api",
+						Url:    tt.url,
+						Expect: &Expectation{DNS: tt.records},
+					},
+				},
+			}
+			err := newTestValidator(r).Validate(context.Background(), "db", er)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidatorReachable(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	er := &ExternalResource{
+		Endpoints: []*ExternalEndpoint{
+			{
+				Name:   "api",
+				Url:    server.URL,
+				Expect: &Expectation{Reachable: true},
+			},
+		},
+	}
+	v := newTestValidator(fakeResolver{})
+
+	require.NoError(t, v.Validate(context.Background(), "db", er))
+
+	status.Store(http.StatusNotFound)
+	require.NoError(t, v.Validate(context.Background(), "db", er))
+
+	status.Store(http.StatusBadGateway)
+	require.Error(t, v.Validate(context.Background(), "db", er))
+}
+
+func TestValidatorSkipsEndpointsWithoutExpectations(t *testing.T) {
+	er := &ExternalResource{
+		Endpoints: []*ExternalEndpoint{
+			{
+				Name: "api",
+				Url:  "https://unknown.example.com",
+			},
+		},
+	}
+
+	require.NoError(t, newTestValidator(fakeResolver{}).Validate(context.Background(), "db", er))
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// StateOK indicates the release is deployed with a valid version
+	StateOK = "ok"
+	// StateMissing indicates the release is not found
+	StateMissing = "missing"
+	// StateOutdated indicates the version of the chart of the release is lower than the required one
+	StateOutdated = "outdated"
+	// StateFailed indicates the last revision of the release is not deployed
+	StateFailed = "failed"
+
+	// helmReleaseSecretType is the type of the secrets where helm stores the revisions of a release
+	helmReleaseSecretType = "helm.sh/release.v1"
+	helmDeployedStatus    = "deployed"
+)
+
+// gzipMagic are the first bytes of the gzip compressed releases
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// Status is the state of a helm release required by the dev environment
+type Status struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	RequiredVersion string `json:"requiredVersion,omitempty"`
+	Chart           string `json:"chart,omitempty"`
+	Version         string `json:"version,omitempty"`
+	ReleaseStatus   string `json:"releaseStatus,omitempty"`
+	State           string `json:"state"`
+}
+
+// release is the subset of the fields of a helm release used to check it
+type release struct {
+	Info struct {
+		Status string `json:"status"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"metadata"`
+	} `json:"chart"`
+}
+
+// Check returns the state of the helm releases of the section. Releases are read from the secrets created by helm,
+// the default storage driver of helm
+func Check(ctx context.Context, s Section, namespace string, c kubernetes.Interface) ([]Status, error) {
+	result := make([]Status, 0, len(s))
+	for _, r := range s {
+		status, err := check(ctx, r, namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+func check(ctx context.Context, r *Requirement, namespace string, c kubernetes.Interface) (Status, error) {
+	status := Status{
+		Name:            r.Name,
+		Namespace:       r.GetNamespace(namespace),
+		RequiredVersion: r.Version,
+		State:           StateMissing,
+	}
+
+	secret, err := getLastRevision(ctx, r.Name, status.Namespace, c)
+	if err != nil {
+		return status, err
+	}
+	if secret == nil {
+		return status, nil
+	}
+
+	rel, err := decodeRelease(secret.Data["release"])
+	if err != nil {
+		return status, fmt.Errorf("could not read helm release '%s' in namespace '%s': %w", r.Name, status.Namespace, err)
+	}
+	status.Chart = rel.Chart.Metadata.Name
+	status.Version = rel.Chart.Metadata.Version
+	status.ReleaseStatus = rel.Info.Status
+
+	switch {
+	case rel.Info.Status != helmDeployedStatus:
+		status.State = StateFailed
+	case !isVersionAllowed(status.Version, r.Version):
+		status.State = StateOutdated
+	default:
+		status.State = StateOK
+	}
+	return status, nil
+}
+
+// getLastRevision returns the secret of the last revision of a release, or nil if the release doesn't exist
+func getLastRevision(ctx context.Context, name, namespace string, c kubernetes.Interface) (*apiv1.Secret, error) {
+	list, err := c.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("owner=helm,name=%s", name),
+	})
+	if err != nil {
+		if oktetoErrors.IsForbidden(err) {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("could not read helm release '%s' in namespace '%s': %w", name, namespace, err),
+				Hint: "Check that you have access to the namespace of the helm release",
+			}
+		}
+		return nil, err
+	}
+
+	var last *apiv1.Secret
+	lastRevision := -1
+	for i := range list.Items {
+		secret := &list.Items[i]
+		if secret.Type != helmReleaseSecretType {
+			continue
+		}
+		revision, err := strconv.Atoi(secret.Labels["version"])
+		if err != nil {
+			continue
+		}
+		if revision > lastRevision {
+			last = secret
+			lastRevision = revision
+		}
+	}
+	return last, nil
+}
+
+// decodeRelease decodes a release stored by helm: a base64 encoded json that is usually gzip compressed
+func decodeRelease(data []byte) (*release, error) {
+	b, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(b, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		b, err = io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rel := &release{}
+	if err := json.Unmarshal(b, rel); err != nil {
+		return nil, err
+	}
+	return rel, nil
+}
+
+// isVersionAllowed returns if the version of a chart is greater than or equal to the minimum one
+func isVersionAllowed(version, minimum string) bool {
+	if minimum == "" {
+		return true
+	}
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	required, err := semver.NewVersion(minimum)
+	if err != nil {
+		return false
+	}
+	return !current.LessThan(required)
+}
+
+// Verify returns an error if any of the helm releases is not deployed with a valid version
+func Verify(statuses []Status) error {
+	problems := []string{}
+	for _, s := range statuses {
+		switch s.State {
+		case StateMissing:
+			problems = append(problems, fmt.Sprintf("'%s' is not deployed in namespace '%s'", s.Name, s.Namespace))
+		case StateFailed:
+			problems = append(problems, fmt.Sprintf("'%s' in namespace '%s' is in '%s' status", s.Name, s.Namespace, s.ReleaseStatus))
+		case StateOutdated:
+			problems = append(problems, fmt.Sprintf("'%s' in namespace '%s' has chart version '%s', but '%s' or later is required", s.Name, s.Namespace, s.Version, s.RequiredVersion))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("the helm releases required by the okteto manifest are not ready: %s", strings.Join(problems, ", ")),
+		Hint: "Deploy or upgrade the helm releases listed in the 'helmReleases' section of your okteto manifest and try again",
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newReleaseSecret returns a secret with a revision of a helm release as helm stores it
+func newReleaseSecret(t *testing.T, name, namespace string, revision int, status, chartVersion string, compress bool) *apiv1.Secret {
+	t.Helper()
+	data := []byte(fmt.Sprintf(`{"name":%q,"info":{"status":%q},"chart":{"metadata":{"name":"%s-chart","version":%q}}}`, name, status, name, chartVersion))
+	if compress {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		data = buf.Bytes()
+	}
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, revision),
+			Namespace: namespace,
+			Labels: map[string]string{
+				"owner":   "helm",
+				"name":    name,
+				"version": fmt.Sprintf("%d", revision),
+				"status":  status,
+			},
+		},
+		Type: helmReleaseSecretType,
+		Data: map[string][]byte{
+			"release": []byte(base64.StdEncoding.EncodeToString(data)),
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		newReleaseSecret(t, "postgresql", "infra", 1, "superseded", "11.0.0", true),
+		newReleaseSecret(t, "postgresql", "infra", 2, "deployed", "12.1.0", true),
+		newReleaseSecret(t, "redis", "dev", 1, "deployed", "17.0.0", false),
+		newReleaseSecret(t, "kafka", "infra", 1, "failed", "20.0.0", true),
+	)
+	section := Section{
+		{Name: "postgresql", Namespace: "infra", Version: "12.0.0"},
+		{Name: "redis", Version: "18.0.0"},
+		{Name: "kafka", Namespace: "infra"},
+		{Name: "mongodb", Namespace: "infra"},
+	}
+
+	statuses, err := Check(context.Background(), section, "dev", c)
+
+	require.NoError(t, err)
+	expected := []Status{
+		{Name: "postgresql", Namespace: "infra", RequiredVersion: "12.0.0", Chart: "postgresql-chart", Version: "12.1.0", ReleaseStatus: "deployed", State: StateOK},
+		{Name: "redis", Namespace: "dev", RequiredVersion: "18.0.0", Chart: "redis-chart", Version: "17.0.0", ReleaseStatus: "deployed", State: StateOutdated},
+		{Name: "kafka", Namespace: "infra", Chart: "kafka-chart", Version: "20.0.0", ReleaseStatus: "failed", State: StateFailed},
+		{Name: "mongodb", Namespace: "infra", State: StateMissing},
+	}
+	assert.Equal(t, expected, statuses)
+}
+
+func TestCheckInvalidRelease(t *testing.T) {
+	secret := newReleaseSecret(t, "postgresql", "infra", 1, "deployed", "12.1.0", true)
+	secret.Data["release"] = []byte("not-base64")
+	c := fake.NewSimpleClientset(secret)
+
+	_, err := Check(context.Background(), Section{{Name: "postgresql", Namespace: "infra"}}, "dev", c)
+
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	assert.NoError(t, Verify([]Status{{Name: "postgresql", Namespace: "infra", State: StateOK}}))
+
+	err := Verify([]Status{
+		{Name: "postgresql", Namespace: "infra", State: StateOK},
+		{Name: "redis", Namespace: "dev", Version: "17.0.0", RequiredVersion: "18.0.0", State: StateOutdated},
+		{Name: "mongodb", Namespace: "infra", State: StateMissing},
+	})
+
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Contains(t, err.Error(), "'redis' in namespace 'dev' has chart version '17.0.0', but '18.0.0' or later is required")
+	assert.Contains(t, err.Error(), "'mongodb' is not deployed in namespace 'infra'")
+	assert.NotContains(t, err.Error(), "postgresql")
+}
+
+func TestIsVersionAllowed(t *testing.T) {
+	assert.True(t, isVersionAllowed("1.2.3", ""))
+	assert.True(t, isVersionAllowed("1.2.3", "1.2.3"))
+	assert.True(t, isVersionAllowed("v2.0.0", "1.2.3"))
+	assert.False(t, isVersionAllowed("1.2.2", "1.2.3"))
+	assert.False(t, isVersionAllowed("invalid", "1.2.3"))
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Section represents the helmReleases section of the okteto manifest.
+// It declares the helm releases managed outside of the dev environment that it depends on, like shared infrastructure charts
+type Section []*Requirement
+
+// Requirement is a helm release that must be deployed before the dev environment
+type Requirement struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Namespace is the namespace of the release. It defaults to the namespace of the dev environment
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Version is the minimum version of the chart of the release
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// Validate returns an error if any helm release of the section is not valid
+func (s Section) Validate() error {
+	keys := map[string]bool{}
+	for _, r := range s {
+		if r == nil {
+			return fmt.Errorf("invalid 'helmReleases' section: helm releases cannot be empty")
+		}
+		if r.Name == "" {
+			return fmt.Errorf("invalid 'helmReleases' section: 'name' is required")
+		}
+		if r.Version != "" {
+			if _, err := semver.NewVersion(r.Version); err != nil {
+				return fmt.Errorf("invalid helm release '%s': '%s' is not a valid version", r.Name, r.Version)
+			}
+		}
+		key := fmt.Sprintf("%s/%s", r.Namespace, r.Name)
+		if keys[key] {
+			return fmt.Errorf("invalid helm release '%s': the release is duplicated", r.Name)
+		}
+		keys[key] = true
+	}
+	return nil
+}
+
+// GetNamespace returns the namespace of the release, or the given namespace if it is not set
+func (r *Requirement) GetNamespace(namespace string) string {
+	if r.Namespace != "" {
+		return r.Namespace
+	}
+	return namespace
+}
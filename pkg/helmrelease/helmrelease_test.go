@@ -0,0 +1,74 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSectionValidate(t *testing.T) {
+	tt := []struct {
+		name        string
+		section     Section
+		expectedErr bool
+	}{
+		{
+			name: "valid",
+			section: Section{
+				{Name: "postgresql", Namespace: "infra", Version: "12.1.0"},
+				{Name: "postgresql"},
+			},
+		},
+		{
+			name:        "empty release",
+			section:     Section{nil},
+			expectedErr: true,
+		},
+		{
+			name:        "missing name",
+			section:     Section{{Namespace: "infra"}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid version",
+			section:     Section{{Name: "postgresql", Version: "latest"}},
+			expectedErr: true,
+		},
+		{
+			name: "duplicated",
+			section: Section{
+				{Name: "postgresql", Namespace: "infra"},
+				{Name: "postgresql", Namespace: "infra", Version: "12.1.0"},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.section.Validate()
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetNamespace(t *testing.T) {
+	assert.Equal(t, "infra", (&Requirement{Namespace: "infra"}).GetNamespace("dev"))
+	assert.Equal(t, "dev", (&Requirement{}).GetNamespace("dev"))
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hosts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/textblock"
+	"github.com/spf13/afero"
+)
+
+const (
+	// Domain is the domain of the hostnames resolved locally by okteto
+	Domain = "local.okteto"
+
+	// FileEnvVar overrides the path of the hosts file managed by okteto
+	FileEnvVar = "OKTETO_HOSTS_FILE"
+
+	// LoopbackIP is the address of the hostnames resolved to the local forwards
+	LoopbackIP = "127.0.0.1"
+)
+
+// Entry maps a set of hostnames to an IP address
+type Entry struct {
+	IP        string
+	Hostnames []string
+}
+
+// Manager resolves the hostnames of a development environment locally
+type Manager interface {
+	// Set replaces the entries of a section
+	Set(section string, entries []Entry) error
+	// Remove deletes the entries of a section
+	Remove(section string) error
+}
+
+// NewManager returns the manager of the hosts file if the 'manage-hosts' setting is enabled for the okteto context, nil otherwise
+func NewManager(fs afero.Fs, octx string) Manager {
+	enabled, err := strconv.ParseBool(config.GetSetting(octx, config.ManageHostsSetting))
+	if err != nil || !enabled {
+		return nil
+	}
+	return NewFile(fs, GetPath())
+}
+
+// Section returns the section of the hosts file that holds the entries of a development container
+func Section(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// Hostname returns the local hostname of a service
+func Hostname(service, namespace string) string {
+	return fmt.Sprintf("%s.%s.%s", service, namespace, Domain)
+}
+
+// GetPath returns the path of the hosts file of the system
+func GetPath() string {
+	if path := os.Getenv(FileEnvVar); path != "" {
+		return path
+	}
+	if runtime.GOOS == "windows" {
+		root := os.Getenv("SystemRoot")
+		if root == "" {
+			root = `C:\Windows`
+		}
+		return filepath.Join(root, "System32", "drivers", "etc", "hosts")
+	}
+	return "/etc/hosts"
+}
+
+// File manages the entries of a development environment in a hosts file.
+// Each section is wrapped by okteto markers, so the rest of the file is never modified
+type File struct {
+	fs   afero.Fs
+	path string
+}
+
+// NewFile returns a manager for the hosts file in path
+func NewFile(fs afero.Fs, path string) *File {
+	return &File{fs: fs, path: path}
+}
+
+// Set replaces the entries of a section in the hosts file
+func (f *File) Set(section string, entries []Entry) error {
+	content, perm, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	block := newSectionBlock(section)
+	content, err = block.RemoveBlocks(content)
+	if err != nil {
+		return fmt.Errorf("invalid okteto section '%s' in '%s': %w", section, f.path, err)
+	}
+	if len(entries) == 0 {
+		return f.write(content, perm)
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += block.WriteBlock(formatEntries(entries)) + "\n"
+	return f.write(content, perm)
+}
+
+// Remove deletes the entries of a section from the hosts file
+func (f *File) Remove(section string) error {
+	content, perm, err := f.read()
+	if err != nil {
+		return err
+	}
+	result, err := newSectionBlock(section).RemoveBlocks(content)
+	if err != nil {
+		return fmt.Errorf("invalid okteto section '%s' in '%s': %w", section, f.path, err)
+	}
+	if result == content {
+		return nil
+	}
+	return f.write(result, perm)
+}
+
+func (f *File) read() (string, os.FileMode, error) {
+	info, err := f.fs.Stat(f.path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read the hosts file '%s': %w", f.path, err)
+	}
+	b, err := afero.ReadFile(f.fs, f.path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read the hosts file '%s': %w", f.path, err)
+	}
+	return string(b), info.Mode().Perm(), nil
+}
+
+func (f *File) write(content string, perm os.FileMode) error {
+	if err := afero.WriteFile(f.fs, f.path, []byte(content), perm); err != nil {
+		return fmt.Errorf("failed to write the hosts file '%s': %w", f.path, err)
+	}
+	return nil
+}
+
+func newSectionBlock(section string) *textblock.TextBlock {
+	return textblock.NewTextBlock(
+		fmt.Sprintf("# ---- BEGIN OKTETO %s ----", section),
+		fmt.Sprintf("# ---- END OKTETO %s ----", section),
+	)
+}
+
+func formatEntries(entries []Entry) string {
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		hostnames := append([]string{}, e.Hostnames...)
+		sort.Strings(hostnames)
+		lines = append(lines, fmt.Sprintf("%s %s", e.IP, strings.Join(hostnames, " ")))
+	}
+	return strings.Join(lines, "\n")
+}
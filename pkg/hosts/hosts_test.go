@@ -0,0 +1,92 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hosts
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const systemHosts = "127.0.0.1 localhost\n::1 localhost\n"
+
+func TestFileSetAndRemove(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/etc/hosts", []byte(systemHosts), 0644))
+	f := NewFile(fs, "/etc/hosts")
+
+	err := f.Set("cindy/api", []Entry{{IP: "127.0.0.1", Hostnames: []string{"db.cindy.local.okteto", "api.cindy.local.okteto"}}})
+	require.NoError(t, err)
+	err = f.Set("cindy/web", []Entry{{IP: "127.0.0.1", Hostnames: []string{"web.cindy.local.okteto"}}})
+	require.NoError(t, err)
+
+	expected := systemHosts +
+		"# ---- BEGIN OKTETO cindy/api ----\n127.0.0.1 api.cindy.local.okteto db.cindy.local.okteto\n# ---- END OKTETO cindy/api ----\n" +
+		"# ---- BEGIN OKTETO cindy/web ----\n127.0.0.1 web.cindy.local.okteto\n# ---- END OKTETO cindy/web ----\n"
+	b, err := afero.ReadFile(fs, "/etc/hosts")
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(b))
+
+	// updating a section replaces its entries
+	err = f.Set("cindy/api", []Entry{{IP: "127.0.0.1", Hostnames: []string{"api.cindy.local.okteto"}}})
+	require.NoError(t, err)
+	expected = systemHosts +
+		"# ---- BEGIN OKTETO cindy/web ----\n127.0.0.1 web.cindy.local.okteto\n# ---- END OKTETO cindy/web ----\n" +
+		"# ---- BEGIN OKTETO cindy/api ----\n127.0.0.1 api.cindy.local.okteto\n# ---- END OKTETO cindy/api ----\n"
+	b, err = afero.ReadFile(fs, "/etc/hosts")
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(b))
+
+	require.NoError(t, f.Remove("cindy/api"))
+	require.NoError(t, f.Remove("cindy/web"))
+	require.NoError(t, f.Remove("cindy/unknown"))
+	b, err = afero.ReadFile(fs, "/etc/hosts")
+	require.NoError(t, err)
+	assert.Equal(t, systemHosts, string(b))
+
+	info, err := fs.Stat("/etc/hosts")
+	require.NoError(t, err)
+	assert.Equal(t, "-rw-r--r--", info.Mode().Perm().String())
+}
+
+func TestFileMissing(t *testing.T) {
+	f := NewFile(afero.NewMemMapFs(), "/etc/hosts")
+	assert.Error(t, f.Set("cindy/api", []Entry{{IP: "127.0.0.1", Hostnames: []string{"api.cindy.local.okteto"}}}))
+	assert.Error(t, f.Remove("cindy/api"))
+}
+
+func TestHostname(t *testing.T) {
+	assert.Equal(t, "api.cindy.local.okteto", Hostname("api", "cindy"))
+}
+
+func TestGetPath(t *testing.T) {
+	t.Setenv(FileEnvVar, "/tmp/hosts")
+	assert.Equal(t, "/tmp/hosts", GetPath())
+}
+
+func TestNewManager(t *testing.T) {
+	t.Setenv("OKTETO_MANAGE_HOSTS", "false")
+	assert.Nil(t, NewManager(afero.NewMemMapFs(), "https://okteto.example.com"))
+
+	t.Setenv("OKTETO_MANAGE_HOSTS", "true")
+	t.Setenv(FileEnvVar, "/tmp/hosts")
+	m := NewManager(afero.NewMemMapFs(), "https://okteto.example.com")
+	assert.Equal(t, "/tmp/hosts", m.(*File).path)
+}
+
+func TestSection(t *testing.T) {
+	assert.Equal(t, "cindy/api", Section("cindy", "api"))
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+// maxBodySize is the size of the request and response bodies kept in the records
+const maxBodySize = 64 * 1024
+
+type recordContextKey struct{}
+
+// Inspector records the HTTP traffic of the forwards defined with 'inspect: true'.
+// The forward listens on an internal port and the inspector proxies the original local port to it
+type Inspector struct {
+	fs      afero.Fs
+	folder  string
+	ports   map[int]int
+	stores  map[int]*store
+	servers []*http.Server
+	lock    sync.Mutex
+}
+
+// NewInspector returns an inspector that stores the records in the records folder
+func NewInspector(fs afero.Fs) *Inspector {
+	return &Inspector{
+		fs:     fs,
+		folder: GetRecordsFolder(),
+		ports:  map[int]int{},
+		stores: map[int]*store{},
+	}
+}
+
+// GetInternalPort returns the port where the forward of an inspected port listens.
+// The same internal port is returned for a local port during the whole session
+func (i *Inspector) GetInternalPort(local int, getPort func() (int, error)) (int, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if port, ok := i.ports[local]; ok {
+		return port, nil
+	}
+	port, err := getPort()
+	if err != nil {
+		return 0, err
+	}
+	i.ports[local] = port
+	return port, nil
+}
+
+// Start proxies the local port to its internal port, recording the traffic until the context is done
+func (i *Inspector) Start(ctx context.Context, local int, iface string) error {
+	i.lock.Lock()
+	internal, ok := i.ports[local]
+	s := i.stores[local]
+	i.lock.Unlock()
+	if !ok {
+		return fmt.Errorf("port %d is not inspected", local)
+	}
+
+	if s == nil {
+		var err error
+		s, err = newStore(i.fs, i.folder, local)
+		if err != nil {
+			return err
+		}
+	}
+
+	address := net.JoinHostPort(iface, strconv.Itoa(local))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to inspect port %d: %w", local, err)
+	}
+	target := &url.URL{Scheme: "http", Host: net.JoinHostPort(iface, strconv.Itoa(internal))}
+	server := &http.Server{
+		Handler:           newRecordingProxy(target, local, s),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	i.lock.Lock()
+	i.stores[local] = s
+	i.servers = append(i.servers, server)
+	i.lock.Unlock()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			oktetoLog.Infof("inspected forward %s -> failed to serve: %s", address, err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			oktetoLog.Infof("inspected forward %s -> failed to close: %s", address, err)
+		}
+	}()
+	oktetoLog.Infof("inspected forward %s -> %s", address, target.Host)
+	return nil
+}
+
+// Stop closes the listeners of the inspected ports. The records are kept until the next session
+func (i *Inspector) Stop() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, server := range i.servers {
+		if err := server.Close(); err != nil {
+			oktetoLog.Infof("failed to stop inspected forward: %s", err)
+		}
+	}
+	i.servers = nil
+}
+
+// newRecordingProxy returns a reverse proxy to the target that stores every request/response pair.
+// The bodies are recorded while they are streamed, so long running responses like server-sent events are not buffered
+func newRecordingProxy(target *url.URL, port int, s *store) http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		r, ok := resp.Request.Context().Value(recordContextKey{}).(*recordInProgress)
+		if !ok {
+			return nil
+		}
+		r.Status = resp.StatusCode
+		r.ResponseHeaders = resp.Header.Clone()
+		if resp.Body != nil && resp.Body != http.NoBody {
+			r.responseBody = &bodyRecorder{ReadCloser: resp.Body}
+			resp.Body = r.responseBody
+		}
+		return nil
+	}
+	proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		if r, ok := req.Context().Value(recordContextKey{}).(*recordInProgress); ok {
+			r.Status = http.StatusBadGateway
+			r.Error = err.Error()
+		}
+		oktetoLog.Infof("inspected forward %d -> %s", port, err)
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := &recordInProgress{
+			Record: Record{
+				ID:             s.nextID(),
+				Time:           time.Now(),
+				Port:           port,
+				Method:         req.Method,
+				URL:            req.URL.String(),
+				RequestHeaders: req.Header.Clone(),
+			},
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			r.requestBody = &bodyRecorder{ReadCloser: req.Body}
+			req.Body = r.requestBody
+		}
+
+		proxy.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), recordContextKey{}, r)))
+		r.Duration = time.Since(r.Time)
+		r.RequestBody = r.requestBody.String()
+		r.ResponseBody = r.responseBody.String()
+		if err := s.add(r.Record); err != nil {
+			oktetoLog.Infof("failed to record request to port %d: %s", port, err)
+		}
+	})
+}
+
+// recordInProgress is a record whose bodies are still being streamed
+type recordInProgress struct {
+	requestBody  *bodyRecorder
+	responseBody *bodyRecorder
+	Record
+}
+
+// bodyRecorder keeps the first maxBodySize bytes of a body while it is read
+type bodyRecorder struct {
+	io.ReadCloser
+	buf  bytes.Buffer
+	size int
+}
+
+func (b *bodyRecorder) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += n
+	if room := maxBodySize - b.buf.Len(); room > 0 && n > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+// String returns the recorded body. Truncated bodies are marked, and binary bodies are not recorded
+func (b *bodyRecorder) String() string {
+	if b == nil || b.size == 0 {
+		return ""
+	}
+	recorded := b.buf.Bytes()
+	truncated := b.size > len(recorded)
+	if truncated {
+		// the body might be cut in the middle of a multi-byte character
+		for i := 0; i < utf8.UTFMax && len(recorded) > 0 && !utf8.Valid(recorded); i++ {
+			recorded = recorded[:len(recorded)-1]
+		}
+	}
+	if !utf8.Valid(recorded) {
+		return fmt.Sprintf("<binary content, %d bytes>", b.size)
+	}
+	if truncated {
+		return fmt.Sprintf("%s\n<truncated, %d bytes>", string(recorded), b.size)
+	}
+	return string(recorded)
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"received":"` + string(body) + `"}`))
+	}))
+	defer upstream.Close()
+	target, err := url.Parse(upstream.URL)
+	require.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	s, err := newStore(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	proxy := httptest.NewServer(newRecordingProxy(target, 8080, s))
+	defer proxy.Close()
+
+	resp, err := http.Post(proxy.URL+"/api/movies?page=1", "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, `{"received":"hello"}`, string(body))
+
+	records, err := ReadRecords(fs, "/inspect", 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, "8080-1", r.ID)
+	assert.Equal(t, 8080, r.Port)
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "/api/movies?page=1", r.URL)
+	assert.Equal(t, "hello", r.RequestBody)
+	assert.Equal(t, http.StatusCreated, r.Status)
+	assert.Equal(t, `{"received":"hello"}`, r.ResponseBody)
+	assert.Equal(t, "application/json", r.ResponseHeaders.Get("Content-Type"))
+}
+
+func TestRecordingProxyUnavailableTarget(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s, err := newStore(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	proxy := httptest.NewServer(newRecordingProxy(&url.URL{Scheme: "http", Host: "localhost:1"}, 8080, s))
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	records, err := ReadRecords(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, http.StatusBadGateway, records[0].Status)
+	assert.NotEmpty(t, records[0].Error)
+}
+
+func TestBodyRecorder(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "text",
+			body:     "hello",
+			expected: "hello",
+		},
+		{
+			name:     "binary",
+			body:     string([]byte{0xff, 0xfe, 0x00}),
+			expected: "<binary content, 3 bytes>",
+		},
+		{
+			name:     "truncated",
+			body:     strings.Repeat("a", maxBodySize+10),
+			expected: strings.Repeat("a", maxBodySize) + "\n<truncated, 65546 bytes>",
+		},
+		{
+			name:     "truncated in the middle of a character",
+			body:     strings.Repeat("a", maxBodySize-1) + "ñ",
+			expected: strings.Repeat("a", maxBodySize-1) + "\n<truncated, 65537 bytes>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bodyRecorder{ReadCloser: io.NopCloser(strings.NewReader(tt.body))}
+			content, err := io.ReadAll(b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(content))
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+
+	var empty *bodyRecorder
+	assert.Empty(t, empty.String())
+}
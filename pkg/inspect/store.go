@@ -0,0 +1,179 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/spf13/afero"
+)
+
+const (
+	// recordsExtension is the extension of the files with the records of each inspected port
+	recordsExtension = ".jsonl"
+
+	// maxRecordsFileSize is the size of the records file of a port that makes it start over, discarding the old records
+	maxRecordsFileSize = 50 * 1024 * 1024
+)
+
+// Record is a request/response pair that went through an inspected forward
+type Record struct {
+	Time            time.Time     `json:"time" yaml:"time"`
+	RequestHeaders  http.Header   `json:"requestHeaders,omitempty" yaml:"requestHeaders,omitempty"`
+	ResponseHeaders http.Header   `json:"responseHeaders,omitempty" yaml:"responseHeaders,omitempty"`
+	ID              string        `json:"id" yaml:"id"`
+	Method          string        `json:"method" yaml:"method"`
+	URL             string        `json:"url" yaml:"url"`
+	RequestBody     string        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	ResponseBody    string        `json:"responseBody,omitempty" yaml:"responseBody,omitempty"`
+	Error           string        `json:"error,omitempty" yaml:"error,omitempty"`
+	Port            int           `json:"port" yaml:"port"`
+	Status          int           `json:"status" yaml:"status"`
+	Duration        time.Duration `json:"duration" yaml:"duration"`
+}
+
+// GetRecordsFolder returns the folder where the records of the inspected forwards are stored
+func GetRecordsFolder() string {
+	return filepath.Join(config.GetOktetoHome(), "inspect")
+}
+
+// store appends the records of an inspected port to its records file
+type store struct {
+	fs    afero.Fs
+	path  string
+	port  int
+	count int
+	lock  sync.Mutex
+}
+
+// newStore returns the store of an inspected port, discarding the records of previous sessions
+func newStore(fs afero.Fs, folder string, port int) (*store, error) {
+	if err := fs.MkdirAll(folder, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", folder, err)
+	}
+	s := &store{
+		fs:   fs,
+		path: filepath.Join(folder, fmt.Sprintf("%d%s", port, recordsExtension)),
+		port: port,
+	}
+	if err := afero.WriteFile(fs, s.path, nil, 0600); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+// nextID returns the id of the next record of the port
+func (s *store) nextID() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.count++
+	return fmt.Sprintf("%d-%d", s.port, s.count)
+}
+
+// add appends a record to the records file
+func (s *store) add(r Record) error {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	flags := os.O_APPEND | os.O_CREATE | os.O_WRONLY
+	if info, err := s.fs.Stat(s.path); err == nil && info.Size() > maxRecordsFileSize {
+		flags = os.O_TRUNC | os.O_CREATE | os.O_WRONLY
+	}
+	f, err := s.fs.OpenFile(s.path, flags, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(bytes, '\n'))
+	return err
+}
+
+// ReadRecords returns the records of the inspected forwards sorted by time. A port equal to 0 returns the records of every port
+func ReadRecords(fs afero.Fs, folder string, port int) ([]Record, error) {
+	files, err := afero.ReadDir(fs, folder)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Record{}, nil
+		}
+		return nil, err
+	}
+
+	result := []Record{}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, recordsExtension) {
+			continue
+		}
+		if port != 0 && name != fmt.Sprintf("%d%s", port, recordsExtension) {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimSuffix(name, recordsExtension)); err != nil {
+			continue
+		}
+		records, err := readRecordsFile(fs, filepath.Join(folder, name))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, records...)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+	return result, nil
+}
+
+func readRecordsFile(fs afero.Fs, path string) ([]Record, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result := []Record{}
+	// lines are read without a size limit, the escaped bodies of a record can be several times maxBodySize
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var r Record
+			// a record might be partially written while the forward is in use
+			if jsonErr := json.Unmarshal(line, &r); jsonErr == nil {
+				result = append(result, r)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return result, nil
+			}
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRecords(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	now := time.Now()
+
+	api, err := newStore(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	web, err := newStore(fs, "/inspect", 3000)
+	require.NoError(t, err)
+	require.NoError(t, api.add(Record{ID: api.nextID(), Port: 8080, Time: now.Add(2 * time.Second)}))
+	require.NoError(t, web.add(Record{ID: web.nextID(), Port: 3000, Time: now}))
+	require.NoError(t, api.add(Record{ID: api.nextID(), Port: 8080, Time: now.Add(3 * time.Second)}))
+	require.NoError(t, afero.WriteFile(fs, "/inspect/notes.txt", []byte("ignored"), 0600))
+
+	records, err := ReadRecords(fs, "/inspect", 0)
+	require.NoError(t, err)
+	ids := []string{}
+	for _, r := range records {
+		ids = append(ids, r.ID)
+	}
+	assert.Equal(t, []string{"3000-1", "8080-1", "8080-2"}, ids)
+
+	records, err = ReadRecords(fs, "/inspect", 3000)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "3000-1", records[0].ID)
+
+	// a new session discards the previous records of the port
+	_, err = newStore(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	records, err = ReadRecords(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestReadRecordsWithEscapedBodies(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s, err := newStore(fs, "/inspect", 8080)
+	require.NoError(t, err)
+
+	// each control character is escaped as \u00XX in the records file
+	body := strings.Repeat("\x01", maxBodySize)
+	require.NoError(t, s.add(Record{ID: s.nextID(), Port: 8080, RequestBody: body, ResponseBody: body}))
+	require.NoError(t, s.add(Record{ID: s.nextID(), Port: 8080}))
+
+	records, err := ReadRecords(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, body, records[0].ResponseBody)
+	assert.Equal(t, "8080-2", records[1].ID)
+}
+
+func TestReadRecordsWithoutFolder(t *testing.T) {
+	records, err := ReadRecords(afero.NewMemMapFs(), "/inspect", 0)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestUIHandler(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s, err := newStore(fs, "/inspect", 8080)
+	require.NoError(t, err)
+	require.NoError(t, s.add(Record{ID: s.nextID(), Port: 8080, Method: http.MethodGet, URL: "/<script>", Status: http.StatusOK, Time: time.Now()}))
+	handler := NewUIHandler(fs, "/inspect")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "/&lt;script&gt;")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/records?port=8080", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":"8080-1"`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/records?port=api", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+var uiTemplate = template.Must(template.New("inspect").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="3">
+<title>Okteto Inspect</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+summary { cursor: pointer; font-family: monospace; padding: 0.3em 0; }
+pre { background: #f4f4f4; padding: 0.5em; overflow-x: auto; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Okteto Inspect</h1>
+{{if not .}}<p>No requests recorded yet. Send requests to the forwards defined with 'inspect: true'.</p>{{end}}
+{{range .}}
+<details>
+<summary>{{.Time.Format "15:04:05"}} :{{.Port}} {{.Method}} {{.URL}} <span{{if ge .Status 400}} class="error"{{end}}>{{.Status}}</span> {{.Duration}}</summary>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<h4>Request</h4>
+<pre>{{range $k, $v := .RequestHeaders}}{{$k}}: {{range $v}}{{.}} {{end}}
+{{end}}</pre>
+{{if .RequestBody}}<pre>{{.RequestBody}}</pre>{{end}}
+<h4>Response</h4>
+<pre>{{range $k, $v := .ResponseHeaders}}{{$k}}: {{range $v}}{{.}} {{end}}
+{{end}}</pre>
+{{if .ResponseBody}}<pre>{{.ResponseBody}}</pre>{{end}}
+</details>
+{{end}}
+</body>
+</html>
+`))
+
+// NewUIHandler returns the handler of the local web UI to review the records of the inspected forwards.
+// The records are also served as JSON on '/api/records'. Both accept the 'port' query parameter to filter by port
+func NewUIHandler(fs afero.Fs, folder string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/records", func(w http.ResponseWriter, r *http.Request) {
+		records, ok := readRecordsForRequest(w, r, fs, folder)
+		if !ok {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(records); err != nil {
+			oktetoLog.Infof("failed to write inspect records: %s", err)
+		}
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		records, ok := readRecordsForRequest(w, r, fs, folder)
+		if !ok {
+			return
+		}
+		// newest requests first
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := uiTemplate.Execute(w, records); err != nil {
+			oktetoLog.Infof("failed to render inspect records: %s", err)
+		}
+	})
+	return mux
+}
+
+func readRecordsForRequest(w http.ResponseWriter, r *http.Request, fs afero.Fs, folder string) ([]Record, bool) {
+	port := 0
+	if value := r.URL.Query().Get("port"); value != "" {
+		p, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "invalid port", http.StatusBadRequest)
+			return nil, false
+		}
+		port = p
+	}
+	records, err := ReadRecords(fs, folder, port)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return records, true
+}
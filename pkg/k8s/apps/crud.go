@@ -47,13 +47,22 @@ func Get(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Int
 	}
 
 	sfs, err := statefulsets.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		return &StatefulSetApp{sfs: sfs}, nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	app, err := getCustomApp(ctx, dev, namespace, c)
 	if err != nil {
 		if oktetoErrors.IsNotFound(err) {
 			return nil, ErrApplicationNotFound{Name: dev.Name}
 		}
 		return nil, err
 	}
-	return &StatefulSetApp{sfs: sfs}, nil
+	return app, nil
 }
 
 // IsDevModeOn returns if a statefulset is in devmode
@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// customAppDriver discovers and translates applications defined by custom resources
+type customAppDriver struct {
+	newApp func(ctx context.Context, obj *unstructured.Unstructured, dc dynamic.Interface, c kubernetes.Interface) (App, error)
+	gvr    schema.GroupVersionResource
+}
+
+// customAppDrivers are the drivers checked, in order, when no deployment or statefulset matches a development container
+var customAppDrivers = []customAppDriver{
+	{gvr: rolloutResource, newApp: newRolloutApp},
+	{gvr: knativeServiceResource, newApp: newKnativeServiceApp},
+}
+
+// getDynamicClient returns the client used to discover applications defined by custom resources
+var getDynamicClient = defaultGetDynamicClient
+
+func defaultGetDynamicClient() (dynamic.Interface, error) {
+	if !okteto.IsContextInitialized() {
+		return nil, fmt.Errorf("okteto context not initialized")
+	}
+	dc, _, err := okteto.GetDynamicClient()
+	return dc, err
+}
+
+// getCustomApp returns the application defined by a custom resource for a development container.
+// Discovery is best effort: if the custom resource is not installed in the cluster, the driver is skipped
+func getCustomApp(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) (App, error) {
+	dc, err := getDynamicClient()
+	if err != nil {
+		oktetoLog.Infof("skipping custom resources discovery: %s", err)
+		return nil, oktetoErrors.ErrNotFound
+	}
+
+	for _, driver := range customAppDrivers {
+		obj, err := getCustomResourceByDev(ctx, dev, namespace, driver.gvr, dc)
+		if err != nil {
+			if !oktetoErrors.IsNotFound(err) {
+				oktetoLog.Infof("error getting %s for '%s': %s", driver.gvr.Resource, dev.Name, err)
+			}
+			continue
+		}
+		return driver.newApp(ctx, obj, dc, c)
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
+
+// getCustomResourceByDev returns the custom resource matching the name or the selector of a development container
+func getCustomResourceByDev(ctx context.Context, dev *model.Dev, namespace string, gvr schema.GroupVersionResource, dc dynamic.Interface) (*unstructured.Unstructured, error) {
+	if len(dev.Selector) == 0 {
+		obj, err := dc.Resource(gvr).Namespace(namespace).Get(ctx, dev.Name, metav1.GetOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return nil, oktetoErrors.ErrNotFound
+			}
+			return nil, err
+		}
+		return obj, nil
+	}
+
+	list, err := dc.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: dev.LabelsSelector()})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, oktetoErrors.ErrNotFound
+		}
+		return nil, err
+	}
+	valid := []*unstructured.Unstructured{}
+	for i := range list.Items {
+		if list.Items[i].GetLabels()[model.DevCloneLabel] == "" {
+			valid = append(valid, &list.Items[i])
+		}
+	}
+	if len(valid) == 0 {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	if len(valid) > 1 {
+		return nil, fmt.Errorf("found '%d' %s for labels '%s' instead of 1", len(valid), gvr.Resource, dev.LabelsSelector())
+	}
+	return valid[0], nil
+}
+
+// customResource keeps the state shared by the applications defined by custom resources
+type customResource struct {
+	obj  *unstructured.Unstructured
+	dc   dynamic.Interface
+	meta metav1.ObjectMeta
+	gvr  schema.GroupVersionResource
+}
+
+func newCustomResource(obj *unstructured.Unstructured, dc dynamic.Interface, gvr schema.GroupVersionResource) customResource {
+	cr := customResource{dc: dc, gvr: gvr}
+	cr.load(obj)
+	return cr
+}
+
+func (cr *customResource) load(obj *unstructured.Unstructured) {
+	cr.obj = obj
+	cr.meta = metav1.ObjectMeta{
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		UID:         obj.GetUID(),
+		Generation:  obj.GetGeneration(),
+		Labels:      obj.GetLabels(),
+		Annotations: obj.GetAnnotations(),
+	}
+	if cr.meta.Labels == nil {
+		cr.meta.Labels = map[string]string{}
+	}
+	if cr.meta.Annotations == nil {
+		cr.meta.Annotations = map[string]string{}
+	}
+}
+
+func (cr *customResource) client() dynamic.ResourceInterface {
+	return cr.dc.Resource(cr.gvr).Namespace(cr.meta.Namespace)
+}
+
+func (cr *customResource) get(ctx context.Context) (*unstructured.Unstructured, error) {
+	return cr.client().Get(ctx, cr.meta.Name, metav1.GetOptions{})
+}
+
+// update persists the labels and annotations of the custom resource, together with the fields set by the driver
+func (cr *customResource) update(ctx context.Context, setFields func(obj *unstructured.Unstructured) error) (*unstructured.Unstructured, error) {
+	obj := cr.obj.DeepCopy()
+	obj.SetLabels(cr.meta.Labels)
+	obj.SetAnnotations(cr.meta.Annotations)
+	if err := setFields(obj); err != nil {
+		return nil, err
+	}
+	return cr.client().Update(ctx, obj, metav1.UpdateOptions{})
+}
+
+func (cr *customResource) patchAnnotations(ctx context.Context) error {
+	payload := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": cr.meta.Annotations,
+		},
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = cr.client().Patch(ctx, cr.meta.Name, types.MergePatchType, b, metav1.PatchOptions{})
+	return err
+}
+
+func (cr *customResource) destroy(ctx context.Context) error {
+	err := cr.client().Delete(ctx, cr.meta.Name, metav1.DeleteOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting %s '%s': %w", cr.gvr.Resource, cr.meta.Name, err)
+	}
+	return nil
+}
+
+// watch notifies when the custom resource is deleted or its spec is modified by a third party
+func (cr *customResource) watch(ctx context.Context, result chan error) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", cr.meta.Name),
+	}
+
+	watcher, err := cr.client().Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			oktetoLog.Debugf("Received %s '%s' event: %s", cr.gvr.Resource, cr.meta.Name, e)
+			if e.Object == nil {
+				oktetoLog.Debugf("Recreating %s '%s' watcher", cr.gvr.Resource, cr.meta.Name)
+				watcher, err = cr.client().Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			if e.Type == watch.Deleted {
+				result <- oktetoErrors.ErrDeleteToApp
+				return
+			} else if e.Type == watch.Modified {
+				obj, ok := e.Object.(*unstructured.Unstructured)
+				if !ok {
+					oktetoLog.Debugf("Failed to parse %s event: %s", cr.gvr.Resource, e)
+					continue
+				}
+				if obj.GetGeneration() > cr.meta.Generation {
+					result <- oktetoErrors.ErrApplyToApp
+					return
+				}
+			}
+		case err := <-ctx.Done():
+			oktetoLog.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
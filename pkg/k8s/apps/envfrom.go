@@ -0,0 +1,94 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	"github.com/okteto/okteto/pkg/model"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ValidateEnvFrom checks that the secrets and configmaps referenced by the envFrom field of dev and its services exist in the namespace
+func ValidateEnvFrom(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	devs := append([]*model.Dev{dev}, dev.Services...)
+	for _, d := range devs {
+		for i := range d.EnvFrom {
+			if err := validateEnvFromSource(ctx, &d.EnvFrom[i], dev.Namespace, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateEnvFromSource(ctx context.Context, envFrom *model.EnvFrom, namespace string, c kubernetes.Interface) error {
+	if envFrom.Optional {
+		return nil
+	}
+
+	keys := map[string]bool{}
+	if envFrom.Secret != "" {
+		secret, err := secrets.Get(ctx, envFrom.Secret, namespace, c)
+		if err != nil {
+			return translateEnvFromErr(err, envFrom, namespace)
+		}
+		for k := range secret.Data {
+			keys[k] = true
+		}
+		for k := range secret.StringData {
+			keys[k] = true
+		}
+	} else {
+		cm, err := configmaps.Get(ctx, envFrom.ConfigMap, namespace, c)
+		if err != nil {
+			return translateEnvFromErr(err, envFrom, namespace)
+		}
+		for k := range cm.Data {
+			keys[k] = true
+		}
+		for k := range cm.BinaryData {
+			keys[k] = true
+		}
+	}
+
+	missing := []string{}
+	for _, key := range envFrom.Keys {
+		if !keys[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("%s '%s' doesn't contain the keys: %s", envFrom.GetKind(), envFrom.GetName(), strings.Join(missing, ", ")),
+			Hint: "Update the 'envFrom' field in your okteto manifest or set 'optional: true'",
+		}
+	}
+	return nil
+}
+
+func translateEnvFromErr(err error, envFrom *model.EnvFrom, namespace string) error {
+	if oktetoErrors.IsNotFound(err) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("%s '%s' referenced by 'envFrom' doesn't exist in namespace '%s'", envFrom.GetKind(), envFrom.GetName(), namespace),
+			Hint: fmt.Sprintf("Create the %s or set 'optional: true' in the 'envFrom' field of your okteto manifest", envFrom.GetKind()),
+		}
+	}
+	return fmt.Errorf("failed to get %s '%s': %w", envFrom.GetKind(), envFrom.GetName(), err)
+}
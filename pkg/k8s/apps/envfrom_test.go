@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidateEnvFrom(t *testing.T) {
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "test"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+	}
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "test"},
+		Data:       map[string]string{"LEVEL": "debug"},
+	}
+	tests := []struct {
+		name     string
+		envFrom  []model.EnvFrom
+		services []*model.Dev
+		wantErr  bool
+	}{
+		{
+			name:    "existing secret and configmap",
+			envFrom: []model.EnvFrom{{Secret: "db-credentials", Keys: []string{"username"}}, {ConfigMap: "app-config"}},
+		},
+		{
+			name:    "missing secret",
+			envFrom: []model.EnvFrom{{Secret: "other"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing optional configmap",
+			envFrom: []model.EnvFrom{{ConfigMap: "other", Optional: true}},
+		},
+		{
+			name:    "missing key",
+			envFrom: []model.EnvFrom{{ConfigMap: "app-config", Keys: []string{"LEVEL", "FORMAT"}}},
+			wantErr: true,
+		},
+		{
+			name:     "missing configmap in service",
+			services: []*model.Dev{{Name: "worker", EnvFrom: []model.EnvFrom{{ConfigMap: "other"}}}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(secret, cm)
+			dev := &model.Dev{Name: "dev", Namespace: "test", EnvFrom: tt.envFrom, Services: tt.services}
+			err := ValidateEnvFrom(context.Background(), dev, c)
+			if tt.wantErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const (
+	oktetoServiceAccountTokensVolumeTemplate = "okteto-sa-tokens-%d" // skipcq GSC-G101  not a secret
+)
+
+// workloadIdentityAnnotationPrefixes are the prefixes of the annotations used by the cloud providers to bind a workload to a cloud identity
+var workloadIdentityAnnotationPrefixes = []string{
+	"eks.amazonaws.com/",
+	"iam.amazonaws.com/",
+	"iam.gke.io/",
+	"azure.workload.identity/",
+}
+
+// isWorkloadIdentityAnnotation returns true if the annotation binds the pod to a cloud identity (EKS, GKE or AKS workload identity)
+func isWorkloadIdentityAnnotation(key string) bool {
+	for _, prefix := range workloadIdentityAnnotationPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TranslateServiceAccountTokens mounts the projected service account tokens of the rule in the development container.
+// Tokens in the same folder share a projected volume so kubelet keeps rotating all of them
+func TranslateServiceAccountTokens(spec *apiv1.PodSpec, c *apiv1.Container, tokens []model.ServiceAccountToken) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	folders := []string{}
+	sources := map[string][]apiv1.VolumeProjection{}
+	for _, t := range tokens {
+		folder := path.Dir(t.Path)
+		if _, ok := sources[folder]; !ok {
+			folders = append(folders, folder)
+		}
+		sources[folder] = append(sources[folder], apiv1.VolumeProjection{
+			ServiceAccountToken: &apiv1.ServiceAccountTokenProjection{
+				Audience:          t.Audience,
+				ExpirationSeconds: t.ExpirationSeconds,
+				Path:              path.Base(t.Path),
+			},
+		})
+	}
+
+	for i, folder := range folders {
+		name := fmt.Sprintf(oktetoServiceAccountTokensVolumeTemplate, i)
+		spec.Volumes = upsertVolume(spec.Volumes, apiv1.Volume{
+			Name: name,
+			VolumeSource: apiv1.VolumeSource{
+				Projected: &apiv1.ProjectedVolumeSource{
+					Sources: sources[folder],
+				},
+			},
+		})
+		c.VolumeMounts = upsertVolumeMount(c.VolumeMounts, apiv1.VolumeMount{
+			Name:      name,
+			MountPath: folder,
+			ReadOnly:  true,
+		})
+	}
+}
+
+func upsertVolume(volumes []apiv1.Volume, volume apiv1.Volume) []apiv1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == volume.Name {
+			volumes[i] = volume
+			return volumes
+		}
+	}
+	return append(volumes, volume)
+}
+
+func upsertVolumeMount(mounts []apiv1.VolumeMount, mount apiv1.VolumeMount) []apiv1.VolumeMount {
+	for i := range mounts {
+		if mounts[i].Name == mount.Name {
+			mounts[i] = mount
+			return mounts
+		}
+	}
+	return append(mounts, mount)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestTranslateServiceAccountTokens(t *testing.T) {
+	spec := &apiv1.PodSpec{}
+	c := &apiv1.Container{}
+	tokens := []model.ServiceAccountToken{
+		{Audience: "sts.amazonaws.com", Path: "/var/run/secrets/tokens/aws"},
+		{Audience: "vault", Path: "/var/run/secrets/tokens/vault", ExpirationSeconds: pointer.Int64(3600)},
+		{Path: "/etc/identity/token"},
+	}
+
+	TranslateServiceAccountTokens(spec, c, tokens)
+	// translating again doesn't duplicate the volumes
+	TranslateServiceAccountTokens(spec, c, tokens)
+
+	expectedVolumes := []apiv1.Volume{
+		{
+			Name: "okteto-sa-tokens-0",
+			VolumeSource: apiv1.VolumeSource{
+				Projected: &apiv1.ProjectedVolumeSource{
+					Sources: []apiv1.VolumeProjection{
+						{ServiceAccountToken: &apiv1.ServiceAccountTokenProjection{Audience: "sts.amazonaws.com", Path: "aws"}},
+						{ServiceAccountToken: &apiv1.ServiceAccountTokenProjection{Audience: "vault", Path: "vault", ExpirationSeconds: pointer.Int64(3600)}},
+					},
+				},
+			},
+		},
+		{
+			Name: "okteto-sa-tokens-1",
+			VolumeSource: apiv1.VolumeSource{
+				Projected: &apiv1.ProjectedVolumeSource{
+					Sources: []apiv1.VolumeProjection{
+						{ServiceAccountToken: &apiv1.ServiceAccountTokenProjection{Path: "token"}},
+					},
+				},
+			},
+		},
+	}
+	expectedMounts := []apiv1.VolumeMount{
+		{Name: "okteto-sa-tokens-0", MountPath: "/var/run/secrets/tokens", ReadOnly: true},
+		{Name: "okteto-sa-tokens-1", MountPath: "/etc/identity", ReadOnly: true},
+	}
+	assert.Equal(t, expectedVolumes, spec.Volumes)
+	assert.Equal(t, expectedMounts, c.VolumeMounts)
+}
+
+func TestTranslateServiceAccountTokensEmpty(t *testing.T) {
+	spec := &apiv1.PodSpec{}
+	c := &apiv1.Container{}
+	TranslateServiceAccountTokens(spec, c, nil)
+	assert.Empty(t, spec.Volumes)
+	assert.Empty(t, c.VolumeMounts)
+}
+
+func TestTranslateKeepsWorkloadIdentity(t *testing.T) {
+	tr := Translation{
+		App: &DeploymentApp{
+			d: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: pointer.Int32(1),
+					Template: apiv1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"iam.amazonaws.com/role": "api-role",
+							},
+						},
+						Spec: apiv1.PodSpec{
+							ServiceAccountName: "api",
+						},
+					},
+				},
+			},
+		},
+		Dev: &model.Dev{
+			Metadata: &model.Metadata{
+				Annotations: model.Annotations{
+					"iam.amazonaws.com/role": "dev-role",
+					"key":                    "value",
+				},
+			},
+		},
+	}
+	tr.MainDev = tr.Dev
+
+	require.NoError(t, tr.translate())
+	assert.Equal(t, "dev-role", tr.DevApp.TemplateObjectMeta().Annotations["iam.amazonaws.com/role"])
+	assert.Equal(t, "api", tr.DevApp.PodSpec().ServiceAccountName)
+	assert.Equal(t, "api-role", tr.App.TemplateObjectMeta().Annotations["iam.amazonaws.com/role"])
+	assert.Equal(t, "value", tr.App.TemplateObjectMeta().Annotations["key"])
+
+	require.NoError(t, tr.DevModeOff())
+	assert.Equal(t, map[string]string{"iam.amazonaws.com/role": "api-role"}, tr.App.TemplateObjectMeta().Annotations)
+}
+
+func TestIsWorkloadIdentityAnnotation(t *testing.T) {
+	assert.True(t, isWorkloadIdentityAnnotation("eks.amazonaws.com/role-arn"))
+	assert.True(t, isWorkloadIdentityAnnotation("iam.gke.io/gcp-service-account"))
+	assert.True(t, isWorkloadIdentityAnnotation("azure.workload.identity/client-id"))
+	assert.False(t, isWorkloadIdentityAnnotation("dev.okteto.com/translation"))
+}
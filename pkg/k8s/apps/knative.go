@@ -0,0 +1,191 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/replicasets"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+var knativeServiceResource = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"}
+
+// KnativeServiceApp puts Knative Services in dev mode.
+// Knative owns the replicas of its revisions, so the service spec is never modified:
+// the dev clone is a deployment with the pod template of the latest ready revision,
+// which keeps the revision labels to receive the traffic routed to the revision
+type KnativeServiceApp struct {
+	customResource
+	revision *appsv1.Deployment
+}
+
+func newKnativeServiceApp(ctx context.Context, obj *unstructured.Unstructured, dc dynamic.Interface, c kubernetes.Interface) (App, error) {
+	return NewKnativeServiceApp(ctx, obj, dc, c)
+}
+
+// NewKnativeServiceApp returns the app for a knative service
+func NewKnativeServiceApp(ctx context.Context, obj *unstructured.Unstructured, dc dynamic.Interface, c kubernetes.Interface) (*KnativeServiceApp, error) {
+	i := &KnativeServiceApp{customResource: newCustomResource(obj, dc, knativeServiceResource)}
+	if err := i.loadRevision(ctx, c); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// loadRevision gets the deployment created by knative for the latest ready revision of the service
+func (i *KnativeServiceApp) loadRevision(ctx context.Context, c kubernetes.Interface) error {
+	revision, _, err := unstructured.NestedString(i.obj.Object, "status", "latestReadyRevisionName")
+	if err != nil {
+		return fmt.Errorf("malformed knative service '%s': %w", i.meta.Name, err)
+	}
+	if revision == "" {
+		return fmt.Errorf("knative service '%s' doesn't have a ready revision", i.meta.Name)
+	}
+	d, err := deployments.Get(ctx, fmt.Sprintf("%s-deployment", revision), i.meta.Namespace, c)
+	if err != nil {
+		return fmt.Errorf("error getting the deployment of the revision '%s': %w", revision, err)
+	}
+	i.revision = d
+	return nil
+}
+
+func (*KnativeServiceApp) Kind() string {
+	return okteto.KnativeService
+}
+
+func (i *KnativeServiceApp) ObjectMeta() metav1.ObjectMeta {
+	return i.meta
+}
+
+func (i *KnativeServiceApp) Replicas() int32 {
+	if i.revision.Spec.Replicas == nil {
+		return 1
+	}
+	return *i.revision.Spec.Replicas
+}
+
+// SetReplicas is a no-op: the knative autoscaler owns the replicas of the revision
+func (*KnativeServiceApp) SetReplicas(_ int32) {}
+
+// TemplateObjectMeta returns the pod metadata of the revision. It is never persisted,
+// as modifying the template of a knative service creates a new revision
+func (i *KnativeServiceApp) TemplateObjectMeta() metav1.ObjectMeta {
+	if i.revision.Spec.Template.ObjectMeta.Annotations == nil {
+		i.revision.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.revision.Spec.Template.ObjectMeta.Labels == nil {
+		i.revision.Spec.Template.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.revision.Spec.Template.ObjectMeta
+}
+
+func (i *KnativeServiceApp) PodSpec() *apiv1.PodSpec {
+	return &i.revision.Spec.Template.Spec
+}
+
+// DevClone returns a deployment with the pod template of the latest ready revision
+func (i *KnativeServiceApp) DevClone() App {
+	clone := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(i.meta.Name),
+			Namespace:   i.meta.Namespace,
+			Labels:      map[string]string{model.DevCloneLabel: string(i.meta.UID)},
+			Annotations: map[string]string{},
+		},
+		Spec: *i.revision.Spec.DeepCopy(),
+	}
+	for k, v := range i.meta.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range i.meta.Annotations {
+		clone.Annotations[k] = v
+	}
+	clone.Spec.Replicas = pointer.Int32(1)
+	clone.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RecreateDeploymentStrategyType,
+	}
+	return NewDeploymentApp(clone)
+}
+
+func (*KnativeServiceApp) CheckConditionErrors(_ *model.Dev) error {
+	return nil
+}
+
+// GetRunningPod returns a running pod of the latest ready revision
+func (i *KnativeServiceApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	rs, err := replicasets.GetReplicaSetByDeployment(ctx, i.revision, c)
+	if err != nil {
+		return nil, err
+	}
+	return pods.GetPodByReplicaSet(ctx, rs, c)
+}
+
+func (*KnativeServiceApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *KnativeServiceApp) Refresh(ctx context.Context, c kubernetes.Interface) error {
+	obj, err := i.get(ctx)
+	if err != nil {
+		return err
+	}
+	i.load(obj)
+	return i.loadRevision(ctx, c)
+}
+
+func (i *KnativeServiceApp) Watch(ctx context.Context, result chan error, _ kubernetes.Interface) {
+	i.watch(ctx, result)
+}
+
+// Deploy updates the labels and annotations of the knative service
+func (i *KnativeServiceApp) Deploy(ctx context.Context, _ kubernetes.Interface) error {
+	obj, err := i.update(ctx, func(_ *unstructured.Unstructured) error {
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	i.load(obj)
+	return nil
+}
+
+func (i *KnativeServiceApp) PatchAnnotations(ctx context.Context, _ kubernetes.Interface) error {
+	return i.patchAnnotations(ctx)
+}
+
+func (i *KnativeServiceApp) Destroy(ctx context.Context, _ kubernetes.Interface) error {
+	return i.destroy(ctx)
+}
+
+// GetDevClone returns from Kubernetes the deployment cloned from the knative service
+func (i *KnativeServiceApp) GetDevClone(ctx context.Context, c kubernetes.Interface) (App, error) {
+	d, err := deployments.Get(ctx, model.DevCloneName(i.meta.Name), i.meta.Namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	return NewDeploymentApp(d), nil
+}
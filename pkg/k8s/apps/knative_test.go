@@ -0,0 +1,127 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+)
+
+func newFakeKnativeService(name, revision string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "serving.knative.dev/v1",
+			"kind":       "Service",
+			"metadata": map[string]interface{}{
+				"name":       name,
+				"namespace":  "test",
+				"uid":        "ksvc-uid",
+				"generation": int64(1),
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"image": "api:1.0"},
+						},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"latestReadyRevisionName": revision,
+			},
+		},
+	}
+}
+
+func newFakeRevisionDeployment(revision string) *appsv1.Deployment {
+	labels := map[string]string{"serving.knative.dev/revision": revision}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revision + "-deployment",
+			Namespace: "test",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(3),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{
+						{Name: "user-container", Image: "api:1.0"},
+						{Name: "queue-proxy", Image: "queue:1.0"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewKnativeServiceApp(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewKnativeServiceApp(ctx, newFakeKnativeService("api", ""), newFakeDynamicClient(), fake.NewSimpleClientset())
+	require.Error(t, err)
+
+	_, err = NewKnativeServiceApp(ctx, newFakeKnativeService("api", "api-00001"), newFakeDynamicClient(), fake.NewSimpleClientset())
+	require.Error(t, err)
+
+	c := fake.NewSimpleClientset(newFakeRevisionDeployment("api-00001"))
+	app, err := NewKnativeServiceApp(ctx, newFakeKnativeService("api", "api-00001"), newFakeDynamicClient(), c)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), app.Replicas())
+	require.Equal(t, "user-container", app.PodSpec().Containers[0].Name)
+}
+
+func TestKnativeServiceDevMode(t *testing.T) {
+	ctx := context.Background()
+	dc := newFakeDynamicClient(newFakeKnativeService("api", "api-00001"))
+	c := fake.NewSimpleClientset(newFakeRevisionDeployment("api-00001"))
+	app, err := NewKnativeServiceApp(ctx, newFakeKnativeService("api", "api-00001"), dc, c)
+	require.NoError(t, err)
+
+	dev := &model.Dev{Name: "api", Metadata: &model.Metadata{}}
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     app,
+		Rules:   []*model.TranslationRule{{Container: "user-container"}},
+	}
+	require.NoError(t, tr.translate())
+
+	devApp, ok := tr.DevApp.(*DeploymentApp)
+	require.True(t, ok)
+	require.Equal(t, "api-okteto", devApp.d.Name)
+	require.Equal(t, "ksvc-uid", devApp.d.Labels[model.DevCloneLabel])
+	require.Equal(t, "api-00001", devApp.d.Spec.Template.Labels["serving.knative.dev/revision"])
+	require.Equal(t, int32(1), *devApp.d.Spec.Replicas)
+
+	require.NoError(t, app.Deploy(ctx, c))
+	obj, err := dc.Resource(knativeServiceResource).Namespace("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "true", obj.GetLabels()[constants.DevLabel])
+	require.Equal(t, int64(1), obj.GetGeneration())
+	_, found, _ := unstructured.NestedMap(obj.Object, "spec", "template", "metadata")
+	require.False(t, found)
+}
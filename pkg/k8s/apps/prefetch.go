@@ -0,0 +1,163 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+var (
+	prefetchPollInterval = time.Second
+
+	errPrefetchTimeout = errors.New("timeout pulling the development container images")
+)
+
+// PrefetchImages pre-pulls the images of the development container on the node where it is expected to run,
+// so starting the development container is not dominated by pulling large images on cold nodes.
+// The images are pulled by a short lived pod that is scheduled like the development container and
+// pinned to the node of the running application pod, if any
+func PrefetchImages(ctx context.Context, tr *Translation, c kubernetes.Interface) error {
+	pod := translatePrefetchPod(tr)
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	if runningPod, err := tr.App.GetRunningPod(ctx, c); err == nil && runningPod.Spec.NodeName != "" {
+		pod.Spec.NodeName = runningPod.Spec.NodeName
+	}
+
+	if err := pods.Destroy(ctx, pod.Name, pod.Namespace, c); err != nil {
+		return err
+	}
+	if _, err := c.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating prefetch pod: %w", err)
+	}
+	defer func() {
+		if err := pods.Destroy(context.Background(), pod.Name, pod.Namespace, c); err != nil {
+			oktetoLog.Infof("error deleting prefetch pod '%s': %s", pod.Name, err)
+		}
+	}()
+
+	return waitUntilImagesArePulled(ctx, pod.Name, pod.Namespace, tr.MainDev.Timeout.Resources, c)
+}
+
+// translatePrefetchPod returns a pod that pulls every image of the development container pod spec
+func translatePrefetchPod(tr *Translation) *apiv1.Pod {
+	spec := tr.DevApp.PodSpec()
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      format.ResourceK8sMetaString(fmt.Sprintf("%s-prefetch", tr.DevApp.ObjectMeta().Name)),
+			Namespace: tr.DevApp.ObjectMeta().Namespace,
+			Labels: map[string]string{
+				model.OktetoPrefetchLabel: tr.MainDev.Name,
+			},
+		},
+		Spec: apiv1.PodSpec{
+			RestartPolicy:                 apiv1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: pointer.Int64(0),
+			ServiceAccountName:            spec.ServiceAccountName,
+			ImagePullSecrets:              spec.ImagePullSecrets,
+			NodeSelector:                  spec.NodeSelector,
+			Affinity:                      spec.Affinity,
+			Tolerations:                   spec.Tolerations,
+			PriorityClassName:             spec.PriorityClassName,
+		},
+	}
+
+	images := map[string]bool{}
+	containers := make([]apiv1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range containers {
+		if container.Image == "" || images[container.Image] {
+			continue
+		}
+		images[container.Image] = true
+		pod.Spec.Containers = append(pod.Spec.Containers, apiv1.Container{
+			Name:            fmt.Sprintf("prefetch-%d", len(pod.Spec.Containers)),
+			Image:           container.Image,
+			ImagePullPolicy: container.ImagePullPolicy,
+			Command:         []string{"sh", "-c", "exit 0"},
+		})
+	}
+	return pod
+}
+
+// waitUntilImagesArePulled waits until every container of the prefetch pod has its image on the node
+func waitUntilImagesArePulled(ctx context.Context, name, namespace string, timeout time.Duration, c kubernetes.Interface) error {
+	ticker := time.NewTicker(prefetchPollInterval)
+	defer ticker.Stop()
+	to := time.Now().Add(timeout)
+
+	for {
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pulled, err := areImagesPulled(pod)
+		if err != nil {
+			return err
+		}
+		if pulled {
+			return nil
+		}
+
+		if time.Now().After(to) {
+			return errPrefetchTimeout
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			oktetoLog.Debug("call to apps.waitUntilImagesArePulled cancelled")
+			return ctx.Err()
+		}
+	}
+}
+
+// areImagesPulled returns if the images of all the containers of a pod are already present on its node.
+// A container that started, or failed for a reason not related to its image, has its image pulled
+func areImagesPulled(pod *apiv1.Pod) (bool, error) {
+	if len(pod.Status.ContainerStatuses) < len(pod.Spec.Containers) {
+		return false, nil
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.ImageID != "" || status.State.Running != nil || status.State.Terminated != nil {
+			continue
+		}
+		if status.State.Waiting == nil {
+			return false, nil
+		}
+		switch status.State.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+			return false, fmt.Errorf("error pulling image '%s': %s", status.Image, status.State.Waiting.Message)
+		case "", "ContainerCreating", "PodInitializing":
+			return false, nil
+		}
+	}
+	return true, nil
+}
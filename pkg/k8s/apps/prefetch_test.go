@@ -0,0 +1,167 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func newPrefetchTranslation() *Translation {
+	dev := &model.Dev{
+		Name:    "api",
+		Timeout: model.Timeout{Resources: time.Second},
+	}
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "test",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					NodeSelector:     map[string]string{"pool": "dev"},
+					ImagePullSecrets: []apiv1.LocalObjectReference{{Name: "registry"}},
+					InitContainers: []apiv1.Container{
+						{Name: "okteto-bin", Image: model.OktetoBinImageTag},
+					},
+					Containers: []apiv1.Container{
+						{Name: "api", Image: "okteto/dev:large", ImagePullPolicy: apiv1.PullAlways},
+						{Name: "sidecar", Image: model.OktetoBinImageTag},
+					},
+				},
+			},
+		},
+	}
+	return &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		DevApp:  NewDeploymentApp(d),
+	}
+}
+
+func TestTranslatePrefetchPod(t *testing.T) {
+	pod := translatePrefetchPod(newPrefetchTranslation())
+
+	assert.Equal(t, "api-prefetch", pod.Name)
+	assert.Equal(t, "test", pod.Namespace)
+	assert.Equal(t, map[string]string{model.OktetoPrefetchLabel: "api"}, pod.Labels)
+	assert.Equal(t, apiv1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	assert.Equal(t, map[string]string{"pool": "dev"}, pod.Spec.NodeSelector)
+	assert.Equal(t, []apiv1.LocalObjectReference{{Name: "registry"}}, pod.Spec.ImagePullSecrets)
+	require.Len(t, pod.Spec.Containers, 2)
+	assert.Equal(t, "prefetch-0", pod.Spec.Containers[0].Name)
+	assert.Equal(t, model.OktetoBinImageTag, pod.Spec.Containers[0].Image)
+	assert.Equal(t, "prefetch-1", pod.Spec.Containers[1].Name)
+	assert.Equal(t, "okteto/dev:large", pod.Spec.Containers[1].Image)
+	assert.Equal(t, apiv1.PullAlways, pod.Spec.Containers[1].ImagePullPolicy)
+}
+
+func TestAreImagesPulled(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []apiv1.ContainerStatus
+		expected bool
+		err      bool
+	}{
+		{
+			name: "missing statuses",
+		},
+		{
+			name: "pulling",
+			statuses: []apiv1.ContainerStatus{
+				{State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		},
+		{
+			name: "pulled and started",
+			statuses: []apiv1.ContainerStatus{
+				{ImageID: "docker.io/okteto/dev@sha256:123"},
+			},
+			expected: true,
+		},
+		{
+			name: "pulled but failed to start",
+			statuses: []apiv1.ContainerStatus{
+				{State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "RunContainerError"}}},
+			},
+			expected: true,
+		},
+		{
+			name: "error pulling",
+			statuses: []apiv1.ContainerStatus{
+				{State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+			},
+			err: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &apiv1.Pod{
+				Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "prefetch-0"}},
+				},
+				Status: apiv1.PodStatus{
+					ContainerStatuses: tt.statuses,
+				},
+			}
+			pulled, err := areImagesPulled(pod)
+			assert.Equal(t, tt.expected, pulled)
+			assert.Equal(t, tt.err, err != nil)
+		})
+	}
+}
+
+func TestPrefetchImages(t *testing.T) {
+	prefetchPollInterval = 10 * time.Millisecond
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("create", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8sTesting.CreateAction).GetObject().(*apiv1.Pod)
+		for _, container := range pod.Spec.Containers {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, apiv1.ContainerStatus{
+				Name:    container.Name,
+				ImageID: container.Image,
+			})
+		}
+		return false, nil, nil
+	})
+
+	err := PrefetchImages(context.Background(), newPrefetchTranslation(), c)
+	require.NoError(t, err)
+
+	podList, err := c.CoreV1().Pods("test").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, podList.Items)
+}
+
+func TestPrefetchImagesTimeout(t *testing.T) {
+	prefetchPollInterval = 10 * time.Millisecond
+	tr := newPrefetchTranslation()
+	tr.MainDev.Timeout.Resources = 50 * time.Millisecond
+
+	err := PrefetchImages(context.Background(), tr, fake.NewSimpleClientset())
+	assert.ErrorIs(t, err, errPrefetchTimeout)
+}
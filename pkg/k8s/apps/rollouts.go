@@ -0,0 +1,226 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// rolloutPodTemplateHashLabel is the label used by argo rollouts to select the pods of a replicaset
+	rolloutPodTemplateHashLabel = "rollouts-pod-template-hash"
+)
+
+var rolloutResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+
+// RolloutApp puts Argo Rollouts in dev mode.
+// The rollout is paused and scaled down, and the dev clone is a deployment whose pods
+// are labeled as part of the stable replicaset, so the stable service routes traffic to them
+type RolloutApp struct {
+	customResource
+	selector *metav1.LabelSelector
+	template apiv1.PodTemplateSpec
+	stableRS string
+	replicas int32
+}
+
+func newRolloutApp(_ context.Context, obj *unstructured.Unstructured, dc dynamic.Interface, _ kubernetes.Interface) (App, error) {
+	return NewRolloutApp(obj, dc)
+}
+
+// NewRolloutApp returns the app for an argo rollout
+func NewRolloutApp(obj *unstructured.Unstructured, dc dynamic.Interface) (*RolloutApp, error) {
+	i := &RolloutApp{customResource: newCustomResource(obj, dc, rolloutResource)}
+	if err := i.load(obj); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (i *RolloutApp) load(obj *unstructured.Unstructured) error {
+	rollout := struct {
+		Spec struct {
+			Replicas *int32                `json:"replicas"`
+			Selector *metav1.LabelSelector `json:"selector"`
+			Template apiv1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+		Status struct {
+			StableRS string `json:"stableRS"`
+		} `json:"status"`
+	}{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &rollout); err != nil {
+		return fmt.Errorf("malformed rollout '%s': %w", obj.GetName(), err)
+	}
+
+	i.customResource.load(obj)
+	i.replicas = 1
+	if rollout.Spec.Replicas != nil {
+		i.replicas = *rollout.Spec.Replicas
+	}
+	i.selector = rollout.Spec.Selector
+	i.template = rollout.Spec.Template
+	i.stableRS = rollout.Status.StableRS
+	return nil
+}
+
+func (*RolloutApp) Kind() string {
+	return okteto.Rollout
+}
+
+func (i *RolloutApp) ObjectMeta() metav1.ObjectMeta {
+	return i.meta
+}
+
+func (i *RolloutApp) Replicas() int32 {
+	return i.replicas
+}
+
+func (i *RolloutApp) SetReplicas(n int32) {
+	i.replicas = n
+}
+
+func (i *RolloutApp) TemplateObjectMeta() metav1.ObjectMeta {
+	if i.template.ObjectMeta.Annotations == nil {
+		i.template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.template.ObjectMeta.Labels == nil {
+		i.template.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.template.ObjectMeta
+}
+
+func (i *RolloutApp) PodSpec() *apiv1.PodSpec {
+	return &i.template.Spec
+}
+
+// DevClone returns a deployment with the pod template of the rollout targeting its stable replicaset
+func (i *RolloutApp) DevClone() App {
+	clone := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(i.meta.Name),
+			Namespace:   i.meta.Namespace,
+			Labels:      map[string]string{model.DevCloneLabel: string(i.meta.UID)},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(i.replicas),
+			Selector: i.selector.DeepCopy(),
+			Template: *i.template.DeepCopy(),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+	}
+	for k, v := range i.meta.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range i.meta.Annotations {
+		clone.Annotations[k] = v
+	}
+	if clone.Spec.Template.Labels == nil {
+		clone.Spec.Template.Labels = map[string]string{}
+	}
+	if i.stableRS != "" {
+		clone.Spec.Template.Labels[rolloutPodTemplateHashLabel] = i.stableRS
+	}
+	return NewDeploymentApp(clone)
+}
+
+func (*RolloutApp) CheckConditionErrors(_ *model.Dev) error {
+	return nil
+}
+
+// GetRunningPod returns a running pod of the stable replicaset of the rollout
+func (i *RolloutApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	if i.stableRS == "" {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	rsName := fmt.Sprintf("%s-%s", i.meta.Name, i.stableRS)
+	rs, err := c.AppsV1().ReplicaSets(i.meta.Namespace).Get(ctx, rsName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return pods.GetPodByReplicaSet(ctx, rs, c)
+}
+
+func (*RolloutApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *RolloutApp) Refresh(ctx context.Context, _ kubernetes.Interface) error {
+	obj, err := i.get(ctx)
+	if err != nil {
+		return err
+	}
+	return i.load(obj)
+}
+
+func (i *RolloutApp) Watch(ctx context.Context, result chan error, _ kubernetes.Interface) {
+	i.watch(ctx, result)
+}
+
+// Deploy updates the rollout. Reconciliation is paused while the rollout is in dev mode
+func (i *RolloutApp) Deploy(ctx context.Context, _ kubernetes.Interface) error {
+	obj, err := i.update(ctx, func(obj *unstructured.Unstructured) error {
+		template, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&i.template)
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(obj.Object, template, "spec", "template"); err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(obj.Object, int64(i.replicas), "spec", "replicas"); err != nil {
+			return err
+		}
+		return unstructured.SetNestedField(obj.Object, i.meta.Labels[constants.DevLabel] == "true", "spec", "paused")
+	})
+	if err != nil {
+		return err
+	}
+	return i.load(obj)
+}
+
+func (i *RolloutApp) PatchAnnotations(ctx context.Context, _ kubernetes.Interface) error {
+	return i.patchAnnotations(ctx)
+}
+
+func (i *RolloutApp) Destroy(ctx context.Context, _ kubernetes.Interface) error {
+	return i.destroy(ctx)
+}
+
+// GetDevClone returns from Kubernetes the deployment cloned from the rollout
+func (i *RolloutApp) GetDevClone(ctx context.Context, c kubernetes.Interface) (App, error) {
+	d, err := deployments.Get(ctx, model.DevCloneName(i.meta.Name), i.meta.Namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	return NewDeploymentApp(d), nil
+}
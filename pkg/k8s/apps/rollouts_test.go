@@ -0,0 +1,155 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			rolloutResource:        "RolloutList",
+			knativeServiceResource: "ServiceList",
+		},
+		objects...,
+	)
+}
+
+func newFakeRollout(name string, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test",
+				"uid":       "rollout-uid",
+			},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app": name},
+				},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{"app": name},
+					},
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "api", "image": "api:1.0"},
+						},
+					},
+				},
+				"strategy": map[string]interface{}{
+					"canary": map[string]interface{}{"stableService": "api-stable"},
+				},
+			},
+			"status": map[string]interface{}{
+				"stableRS": "abc123",
+			},
+		},
+	}
+}
+
+func TestGetRollout(t *testing.T) {
+	dc := newFakeDynamicClient(newFakeRollout("api", 2))
+	getDynamicClient = func() (dynamic.Interface, error) { return dc, nil }
+	defer func() { getDynamicClient = defaultGetDynamicClient }()
+
+	app, err := Get(context.Background(), &model.Dev{Name: "api"}, "test", fake.NewSimpleClientset())
+	require.NoError(t, err)
+	require.IsType(t, &RolloutApp{}, app)
+	require.Equal(t, int32(2), app.Replicas())
+
+	_, err = Get(context.Background(), &model.Dev{Name: "unknown"}, "test", fake.NewSimpleClientset())
+	require.ErrorIs(t, err, ErrApplicationNotFound{Name: "unknown"})
+}
+
+func TestRolloutDevMode(t *testing.T) {
+	ctx := context.Background()
+	dc := newFakeDynamicClient(newFakeRollout("api", 2))
+	app, err := NewRolloutApp(newFakeRollout("api", 2), dc)
+	require.NoError(t, err)
+
+	dev := &model.Dev{Name: "api", Image: &build.Info{}, Metadata: &model.Metadata{}}
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     app,
+		Rules:   []*model.TranslationRule{{Container: "api"}},
+	}
+	require.NoError(t, tr.translate())
+
+	devApp, ok := tr.DevApp.(*DeploymentApp)
+	require.True(t, ok)
+	require.Equal(t, "api-okteto", devApp.d.Name)
+	require.Equal(t, "rollout-uid", devApp.d.Labels[model.DevCloneLabel])
+	require.Equal(t, "abc123", devApp.d.Spec.Template.Labels[rolloutPodTemplateHashLabel])
+	require.Equal(t, map[string]string{"app": "api"}, devApp.d.Spec.Selector.MatchLabels)
+
+	require.NoError(t, app.Deploy(ctx, nil))
+	obj, err := dc.Resource(rolloutResource).Namespace("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "true", obj.GetLabels()[constants.DevLabel])
+	replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	require.Equal(t, int64(0), replicas)
+	paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused")
+	require.True(t, paused)
+	stableService, _, _ := unstructured.NestedString(obj.Object, "spec", "strategy", "canary", "stableService")
+	require.Equal(t, "api-stable", stableService)
+
+	require.NoError(t, tr.DevModeOff())
+	require.NoError(t, app.Deploy(ctx, nil))
+	obj, err = dc.Resource(rolloutResource).Namespace("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, obj.GetLabels()[constants.DevLabel])
+	replicas, _, _ = unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	require.Equal(t, int64(2), replicas)
+	paused, _, _ = unstructured.NestedBool(obj.Object, "spec", "paused")
+	require.False(t, paused)
+}
+
+func TestRolloutGetDevClone(t *testing.T) {
+	app, err := NewRolloutApp(newFakeRollout("api", 1), newFakeDynamicClient())
+	require.NoError(t, err)
+
+	_, err = app.GetDevClone(context.Background(), fake.NewSimpleClientset())
+	require.Error(t, err)
+
+	cloned := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-okteto",
+			Namespace: "test",
+		},
+	}
+	result, err := app.GetDevClone(context.Background(), fake.NewSimpleClientset(cloned))
+	require.NoError(t, err)
+	require.Equal(t, NewDeploymentApp(cloned), result)
+}
@@ -14,6 +14,7 @@
 package apps
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strconv"
@@ -25,6 +26,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/pointer"
 )
 
@@ -34,6 +36,11 @@ const (
 	OktetoBinName = "okteto-bin"
 	// OktetoInitVolumeContainerName name of the okteto init container that initializes the persistent colume from image content
 	OktetoInitVolumeContainerName = "okteto-init-volume"
+	// OktetoPrepopulateVolumeContainerName name of the okteto init container that seeds the persistent volume from 'persistentVolume.prepopulate'
+	OktetoPrepopulateVolumeContainerName = "okteto-prepopulate-volume"
+	// oktetoPrepopulateDownloadImage is the default image that downloads and extracts the archives that seed the persistent volume.
+	// curl verifies the certificates of the server, unlike the wget of busybox
+	oktetoPrepopulateDownloadImage = "curlimages/curl:8.10.1"
 
 	// syncthing
 	oktetoSyncSecretVolume = "okteto-sync-secret" // skipcq GSC-G101  not a secret
@@ -75,13 +82,23 @@ func (tr *Translation) translate() error {
 	tr.App.ObjectMeta().Labels[constants.DevLabel] = "true"
 	tr.App.ObjectMeta().Annotations[constants.OktetoDevModeAnnotation] = tr.Dev.Mode
 	tr.DevApp.ObjectMeta().Annotations[constants.OktetoDevModeAnnotation] = tr.Dev.Mode
-	tr.App.SetReplicas(0)
+	if tr.Dev.Username != "" {
+		tr.App.ObjectMeta().Annotations[model.DevModeOwnerAnnotation] = tr.Dev.Username
+		tr.DevApp.ObjectMeta().Annotations[model.DevModeOwnerAnnotation] = tr.Dev.Username
+	}
+	if !tr.keepsServingTraffic() {
+		tr.App.SetReplicas(0)
+	}
 
 	for k, v := range tr.Dev.Metadata.Annotations {
-		tr.App.ObjectMeta().Annotations[k] = v
-		tr.App.TemplateObjectMeta().Annotations[k] = v
 		tr.DevApp.ObjectMeta().Annotations[k] = v
 		tr.DevApp.TemplateObjectMeta().Annotations[k] = v
+		// the original app keeps its own workload identity
+		if isWorkloadIdentityAnnotation(k) {
+			continue
+		}
+		tr.App.ObjectMeta().Annotations[k] = v
+		tr.App.TemplateObjectMeta().Annotations[k] = v
 	}
 	for k, v := range tr.Dev.Metadata.Labels {
 		tr.DevApp.ObjectMeta().Labels[k] = v
@@ -109,18 +126,37 @@ func (tr *Translation) translate() error {
 		devContainer := GetDevContainer(tr.DevApp.PodSpec(), rule.Container)
 		TranslateDevContainer(devContainer, rule)
 		TranslatePodSpec(tr.DevApp.PodSpec(), rule)
+		TranslateServiceAccountTokens(tr.DevApp.PodSpec(), devContainer, rule.ServiceAccountTokens)
 		TranslateOktetoDevSecret(tr.DevApp.PodSpec(), tr.Dev.Name, rule.Secrets)
 
 		if rule.IsMainDevContainer() {
+			if tr.keepsServingTraffic() && devContainer.ReadinessProbe == nil {
+				TranslateZeroDowntimeReadinessProbe(devContainer)
+			}
 			TranslateOktetoBinVolumeMounts(devContainer)
 			TranslateOktetoInitBinContainer(rule, tr.DevApp.PodSpec())
 			TranslateOktetoBinVolume(tr.DevApp.PodSpec())
+			TranslateOktetoPrepopulateContainer(tr.DevApp.PodSpec(), rule)
 			TranslateOktetoInitFromImageContainer(tr.DevApp.PodSpec(), rule)
 		}
 	}
 	return nil
 }
 
+// keepsServingTraffic returns true if the original app keeps running until the development container is ready
+func (tr *Translation) keepsServingTraffic() bool {
+	return tr.MainDev == tr.Dev && tr.Dev.IsZeroDowntimeActivation()
+}
+
+// ScaleDownOriginal scales down the original app once the development container is ready to receive traffic
+func (tr *Translation) ScaleDownOriginal(ctx context.Context, c kubernetes.Interface) error {
+	if err := tr.App.Refresh(ctx, c); err != nil {
+		return err
+	}
+	tr.App.SetReplicas(0)
+	return tr.App.Deploy(ctx, c)
+}
+
 func (tr *Translation) DevModeOff() error {
 
 	if err := tr.App.RestoreOriginal(); err != nil {
@@ -136,8 +172,12 @@ func (tr *Translation) DevModeOff() error {
 	delete(tr.App.ObjectMeta().Annotations, model.OktetoSyncAnnotation)
 	delete(tr.App.TemplateObjectMeta().Annotations, model.OktetoSyncAnnotation)
 	delete(tr.App.ObjectMeta().Annotations, constants.OktetoDevModeAnnotation)
+	delete(tr.App.ObjectMeta().Annotations, model.DevModeOwnerAnnotation)
 
 	for k := range tr.Dev.Metadata.Annotations {
+		if isWorkloadIdentityAnnotation(k) {
+			continue
+		}
 		delete(tr.App.ObjectMeta().Annotations, k)
 		delete(tr.App.TemplateObjectMeta().Annotations, k)
 	}
@@ -202,6 +242,7 @@ func TranslateDevContainer(c *apiv1.Container, rule *model.TranslationRule) {
 	TranslateLifecycle(c, rule.Lifecycle)
 
 	TranslateResources(c, rule.Resources)
+	TranslateEnvFrom(c, rule)
 	TranslateEnvVars(c, rule)
 	TranslateVolumeMounts(c, rule)
 	TranslateContainerSecurityContext(c, rule.SecurityContext)
@@ -232,6 +273,29 @@ func TranslateProbes(c *apiv1.Container, p *model.Probes) {
 	}
 }
 
+// TranslateZeroDowntimeReadinessProbe makes the development container ready only after okteto creates the ready file,
+// so it doesn't receive traffic until the synchronization and the dev command are ready.
+// It is only used when the development container doesn't keep a readiness probe of its own
+func TranslateZeroDowntimeReadinessProbe(c *apiv1.Container) {
+	c.ReadinessProbe = &apiv1.Probe{
+		ProbeHandler: apiv1.ProbeHandler{
+			Exec: &apiv1.ExecAction{
+				Command: []string{"test", "-f", model.ZeroDowntimeReadyFile},
+			},
+		},
+		PeriodSeconds:    1,
+		FailureThreshold: 1,
+	}
+}
+
+// IsZeroDowntimeReadinessProbe returns true if the probe is the one added by TranslateZeroDowntimeReadinessProbe
+func IsZeroDowntimeReadinessProbe(p *apiv1.Probe) bool {
+	if p == nil || p.Exec == nil {
+		return false
+	}
+	return strings.Join(p.Exec.Command, " ") == fmt.Sprintf("test -f %s", model.ZeroDowntimeReadyFile)
+}
+
 // TranslateLifecycle translates the lifecycle events attached to a container
 func TranslateLifecycle(c *apiv1.Container, l *model.Lifecycle) {
 	if l == nil {
@@ -304,6 +368,30 @@ func TranslateEnvVars(c *apiv1.Container, rule *model.TranslationRule) {
 	}
 }
 
+// TranslateEnvFrom translates the secrets and configmaps whose keys are injected as environment variables in a container
+func TranslateEnvFrom(c *apiv1.Container, rule *model.TranslationRule) {
+	for i := range rule.EnvFrom {
+		envFrom := rule.EnvFrom[i]
+		if len(envFrom.Keys) == 0 {
+			c.EnvFrom = append(c.EnvFrom, envFrom.ToEnvFromSource())
+			continue
+		}
+		for _, envvar := range envFrom.ToEnvVars() {
+			c.Env = upsertEnvVar(c.Env, envvar)
+		}
+	}
+}
+
+func upsertEnvVar(envs []apiv1.EnvVar, envvar apiv1.EnvVar) []apiv1.EnvVar {
+	for i := range envs {
+		if envs[i].Name == envvar.Name {
+			envs[i] = envvar
+			return envs
+		}
+	}
+	return append(envs, envvar)
+}
+
 // TranslateVolumeMounts translates the volumes attached to a container
 func TranslateVolumeMounts(c *apiv1.Container, rule *model.TranslationRule) {
 	if c.VolumeMounts == nil {
@@ -561,6 +649,71 @@ func TranslateOktetoInitFromImageContainer(spec *apiv1.PodSpec, rule *model.Tran
 	spec.InitContainers = append(spec.InitContainers, *c)
 }
 
+// TranslateOktetoPrepopulateContainer translates the init container that seeds the volumes of the dev container.
+// They are seeded only while all of them are empty, that is, the first time the persistent volume is used.
+// It runs before the init container that initializes the volumes from the dev image, which skips the seeded volumes
+func TranslateOktetoPrepopulateContainer(spec *apiv1.PodSpec, rule *model.TranslationRule) {
+	if !rule.PersistentVolume || rule.Prepopulate == nil {
+		return
+	}
+
+	c := &apiv1.Container{
+		Name:            OktetoPrepopulateVolumeContainerName,
+		Image:           rule.Prepopulate.Image,
+		ImagePullPolicy: apiv1.PullIfNotPresent,
+		VolumeMounts:    []apiv1.VolumeMount{},
+	}
+	emptyCheck := ""
+	copyCommands := []string{}
+	iVolume := 1
+	for _, v := range rule.Volumes {
+		if !strings.HasPrefix(v.SubPath, model.DataSubPath) {
+			continue
+		}
+		// archives are extracted at the root of the container, so each volume is mounted at its remote path
+		mountPath := path.Join("/prepopulate", v.MountPath)
+		if rule.Prepopulate.URL == "" {
+			mountPath = fmt.Sprintf("/prepopulate/%d", iVolume)
+			copyCommands = append(copyCommands, fmt.Sprintf("(cp -R %s/. %s 2>/dev/null || true)", path.Join(v.MountPath, "."), mountPath))
+		}
+		c.VolumeMounts = append(
+			c.VolumeMounts,
+			apiv1.VolumeMount{
+				Name:      v.Name,
+				MountPath: mountPath,
+				SubPath:   v.SubPath,
+			},
+		)
+		emptyCheck = fmt.Sprintf("%s$(ls -A %s)", emptyCheck, mountPath)
+		iVolume++
+	}
+	if len(c.VolumeMounts) == 0 {
+		return
+	}
+
+	if rule.Prepopulate.URL != "" {
+		c.Image = rule.Prepopulate.DownloadImage
+		if c.Image == "" {
+			c.Image = oktetoPrepopulateDownloadImage
+		}
+		archiveURL := strings.ReplaceAll(rule.Prepopulate.GetURL(), "'", `'\''`)
+		copyCommands = []string{fmt.Sprintf("curl -sSLf --retry 3 -o /tmp/prepopulate.tar '%s' && (tar -xzf /tmp/prepopulate.tar -C /prepopulate 2>/dev/null || tar -xf /tmp/prepopulate.tar -C /prepopulate) && rm /tmp/prepopulate.tar", archiveURL)}
+	}
+	command := fmt.Sprintf("if [ -z \"%s\" ]; then echo prepopulating... && %s && echo prepopulation completed.; else echo volumes already initialized, skipping prepopulation.; fi", emptyCheck, strings.Join(copyCommands, " && "))
+
+	shOpts := "-c"
+	if oktetoLog.GetLevel() == oktetoLog.DebugLevel {
+		shOpts = shOpts + "x"
+	}
+	c.Command = []string{"sh", shOpts, command}
+	translateInitResources(c, rule.InitContainer.Resources)
+	TranslateContainerSecurityContext(c, rule.SecurityContext)
+	if spec.InitContainers == nil {
+		spec.InitContainers = []apiv1.Container{}
+	}
+	spec.InitContainers = append(spec.InitContainers, *c)
+}
+
 // TranslateOktetoSyncSecret translates the syncthing secret container of a pod
 func TranslateOktetoSyncSecret(spec *apiv1.PodSpec, name string) {
 	if spec.Volumes == nil {
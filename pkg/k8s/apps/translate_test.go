@@ -1390,6 +1390,94 @@ func TestTranslateOktetoVolumes(t *testing.T) {
 	}
 }
 
+func TestTranslateOktetoPrepopulateContainer(t *testing.T) {
+	volumes := []model.VolumeMount{
+		{Name: "okteto", MountPath: model.OktetoSyncthingMountPath, SubPath: model.SyncthingSubPath},
+		{Name: "okteto", MountPath: "/src", SubPath: model.SourceCodeSubPath},
+		{Name: "okteto", MountPath: "/root/.npm", SubPath: "data/root/.npm"},
+		{Name: "okteto", MountPath: "/data", SubPath: "data/data"},
+	}
+	var tests = []struct {
+		rule            *model.TranslationRule
+		name            string
+		expectedImage   string
+		expectedMounts  []apiv1.VolumeMount
+		expectedCommand string
+	}{
+		{
+			name: "from image",
+			rule: &model.TranslationRule{
+				PersistentVolume: true,
+				Prepopulate:      &model.PersistentVolumePrepopulate{Image: "okteto/cache"},
+				Volumes:          volumes,
+			},
+			expectedImage: "okteto/cache",
+			expectedMounts: []apiv1.VolumeMount{
+				{Name: "okteto", MountPath: "/prepopulate/1", SubPath: "data/root/.npm"},
+				{Name: "okteto", MountPath: "/prepopulate/2", SubPath: "data/data"},
+			},
+			expectedCommand: `if [ -z "$(ls -A /prepopulate/1)$(ls -A /prepopulate/2)" ]; then echo prepopulating... && (cp -R /root/.npm/. /prepopulate/1 2>/dev/null || true) && (cp -R /data/. /prepopulate/2 2>/dev/null || true) && echo prepopulation completed.; else echo volumes already initialized, skipping prepopulation.; fi`,
+		},
+		{
+			name: "from object store",
+			rule: &model.TranslationRule{
+				PersistentVolume: true,
+				Prepopulate:      &model.PersistentVolumePrepopulate{URL: "gs://datasets/seed.tar.gz"},
+				Volumes:          volumes,
+			},
+			expectedImage: oktetoPrepopulateDownloadImage,
+			expectedMounts: []apiv1.VolumeMount{
+				{Name: "okteto", MountPath: "/prepopulate/root/.npm", SubPath: "data/root/.npm"},
+				{Name: "okteto", MountPath: "/prepopulate/data", SubPath: "data/data"},
+			},
+			expectedCommand: `if [ -z "$(ls -A /prepopulate/root/.npm)$(ls -A /prepopulate/data)" ]; then echo prepopulating... && curl -sSLf --retry 3 -o /tmp/prepopulate.tar 'https://storage.googleapis.com/datasets/seed.tar.gz' && (tar -xzf /tmp/prepopulate.tar -C /prepopulate 2>/dev/null || tar -xf /tmp/prepopulate.tar -C /prepopulate) && rm /tmp/prepopulate.tar && echo prepopulation completed.; else echo volumes already initialized, skipping prepopulation.; fi`,
+		},
+		{
+			name: "from url with download image",
+			rule: &model.TranslationRule{
+				PersistentVolume: true,
+				Prepopulate:      &model.PersistentVolumePrepopulate{URL: "https://example.com/seed.tar", DownloadImage: "registry.example.com/curl:8"},
+				Volumes:          volumes,
+			},
+			expectedImage: "registry.example.com/curl:8",
+			expectedMounts: []apiv1.VolumeMount{
+				{Name: "okteto", MountPath: "/prepopulate/root/.npm", SubPath: "data/root/.npm"},
+				{Name: "okteto", MountPath: "/prepopulate/data", SubPath: "data/data"},
+			},
+			expectedCommand: `if [ -z "$(ls -A /prepopulate/root/.npm)$(ls -A /prepopulate/data)" ]; then echo prepopulating... && curl -sSLf --retry 3 -o /tmp/prepopulate.tar 'https://example.com/seed.tar' && (tar -xzf /tmp/prepopulate.tar -C /prepopulate 2>/dev/null || tar -xf /tmp/prepopulate.tar -C /prepopulate) && rm /tmp/prepopulate.tar && echo prepopulation completed.; else echo volumes already initialized, skipping prepopulation.; fi`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiv1.PodSpec{}
+			TranslateOktetoPrepopulateContainer(spec, tt.rule)
+			require.Len(t, spec.InitContainers, 1)
+			c := spec.InitContainers[0]
+			assert.Equal(t, OktetoPrepopulateVolumeContainerName, c.Name)
+			assert.Equal(t, tt.expectedImage, c.Image)
+			assert.Equal(t, tt.expectedMounts, c.VolumeMounts)
+			assert.Equal(t, []string{"sh", "-c", tt.expectedCommand}, c.Command)
+		})
+	}
+}
+
+func TestTranslateOktetoPrepopulateContainerWithoutPrepopulate(t *testing.T) {
+	spec := &apiv1.PodSpec{}
+	TranslateOktetoPrepopulateContainer(spec, &model.TranslationRule{
+		PersistentVolume: true,
+		Volumes:          []model.VolumeMount{{Name: "okteto", MountPath: "/data", SubPath: "data/data"}},
+	})
+	assert.Empty(t, spec.InitContainers)
+
+	// without persistent volume there is nothing to seed
+	TranslateOktetoPrepopulateContainer(spec, &model.TranslationRule{
+		Prepopulate: &model.PersistentVolumePrepopulate{Image: "okteto/cache"},
+		Volumes:     []model.VolumeMount{{Name: "okteto", MountPath: "/data", SubPath: "data/data"}},
+	})
+	assert.Empty(t, spec.InitContainers)
+}
+
 func Test_translateMultipleEnvVars(t *testing.T) {
 	manifestBytes := []byte(`name: web
 namespace: n
@@ -1464,6 +1552,51 @@ environment:
 	}
 }
 
+func Test_translateEnvFrom(t *testing.T) {
+	manifestBytes := []byte(`name: web
+namespace: n
+image: web:latest
+sync:
+  - .:/app
+environment:
+  DB_password: local
+envFrom:
+  - secret: db-credentials
+    prefix: DB_
+    keys:
+      - username
+      - password
+  - configMap: app-config
+`)
+
+	manifest, err := model.Read(manifestBytes)
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+
+	d := deployments.Sandbox(dev)
+	rule := dev.ToTranslationRule(dev, false)
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		Rules:   []*model.TranslationRule{rule},
+	}
+	require.NoError(t, tr.translate())
+
+	notOptional := false
+	c := tr.DevApp.PodSpec().Containers[0]
+	assert.Equal(t, []apiv1.EnvFromSource{
+		{ConfigMapRef: &apiv1.ConfigMapEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "app-config"}, Optional: &notOptional}},
+	}, c.EnvFrom)
+	assert.Contains(t, c.Env, apiv1.EnvVar{
+		Name: "DB_username",
+		ValueFrom: &apiv1.EnvVarSource{
+			SecretKeyRef: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "db-credentials"}, Key: "username", Optional: &notOptional},
+		},
+	})
+	assert.Contains(t, c.Env, apiv1.EnvVar{Name: "DB_password", Value: "local"})
+}
+
 func Test_translateSfsWithVolumes(t *testing.T) {
 	file, err := os.CreateTemp("", "okteto-secret-test")
 	require.NoError(t, err)
@@ -2138,3 +2271,108 @@ func Test_getDevName(t *testing.T) {
 		})
 	}
 }
+
+func Test_translateZeroDowntimeActivation(t *testing.T) {
+	manifestBytes := []byte(`name: web
+namespace: n
+image: web:latest
+activation: zero-downtime
+sync:
+  - .:/okteto`)
+
+	manifest, err := model.Read(manifestBytes)
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+
+	d := deployments.Sandbox(dev)
+	d.Spec.Replicas = pointer.Int32(2)
+	d.UID = types.UID("1234")
+	delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+	rule := dev.ToTranslationRule(dev, true)
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		Rules:   []*model.TranslationRule{rule},
+	}
+	require.NoError(t, tr.translate())
+
+	assert.Equal(t, int32(2), tr.App.Replicas())
+	assert.Equal(t, "2", tr.App.ObjectMeta().Annotations[model.AppReplicasAnnotation])
+
+	devContainer := GetDevContainer(tr.DevApp.PodSpec(), rule.Container)
+	require.NotNil(t, devContainer.ReadinessProbe)
+	assert.Equal(t, []string{"test", "-f", model.ZeroDowntimeReadyFile}, devContainer.ReadinessProbe.Exec.Command)
+
+	assert.True(t, IsZeroDowntimeReadinessProbe(devContainer.ReadinessProbe))
+
+	c := fake.NewSimpleClientset(d)
+	require.NoError(t, tr.ScaleDownOriginal(context.Background(), c))
+	result, err := c.AppsV1().Deployments(d.Namespace).Get(context.Background(), d.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *result.Spec.Replicas)
+}
+
+func Test_translateDevModeOwner(t *testing.T) {
+	manifestBytes := []byte(`name: web
+namespace: n
+image: web:latest
+sync:
+  - .:/okteto`)
+
+	manifest, err := model.Read(manifestBytes)
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+	dev.Username = "cindy"
+
+	d := deployments.Sandbox(dev)
+	d.UID = types.UID("1234")
+	delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		Rules:   []*model.TranslationRule{dev.ToTranslationRule(dev, true)},
+	}
+	require.NoError(t, tr.translate())
+
+	assert.Equal(t, "cindy", tr.App.ObjectMeta().Annotations[model.DevModeOwnerAnnotation])
+	assert.Equal(t, "cindy", tr.DevApp.ObjectMeta().Annotations[model.DevModeOwnerAnnotation])
+
+	require.NoError(t, tr.DevModeOff())
+	assert.NotContains(t, tr.App.ObjectMeta().Annotations, model.DevModeOwnerAnnotation)
+}
+
+func Test_translateZeroDowntimeActivationKeepsReadinessProbe(t *testing.T) {
+	manifestBytes := []byte(`name: web
+namespace: n
+image: web:latest
+activation: zero-downtime
+probes:
+  readiness: true
+sync:
+  - .:/okteto`)
+
+	manifest, err := model.Read(manifestBytes)
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+
+	d := deployments.Sandbox(dev)
+	d.UID = types.UID("1234")
+	delete(d.Annotations, model.OktetoAutoCreateAnnotation)
+	readinessProbe := &apiv1.Probe{
+		ProbeHandler: apiv1.ProbeHandler{HTTPGet: &apiv1.HTTPGetAction{Path: "/healthz"}},
+	}
+	d.Spec.Template.Spec.Containers[0].ReadinessProbe = readinessProbe
+	rule := dev.ToTranslationRule(dev, true)
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		Rules:   []*model.TranslationRule{rule},
+	}
+	require.NoError(t, tr.translate())
+
+	devContainer := GetDevContainer(tr.DevApp.PodSpec(), rule.Container)
+	assert.Equal(t, readinessProbe, devContainer.ReadinessProbe)
+}
@@ -25,6 +25,9 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// listChunkSize is the maximum number of configmaps returned by each request of a list
+const listChunkSize = 500
+
 // Get returns a configmap
 func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*apiv1.ConfigMap, error) {
 	cf, err := c.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -36,18 +39,28 @@ func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*
 
 // List returns a list of configmap that match labelselector
 func List(ctx context.Context, namespace, labelSelector string, c kubernetes.Interface) ([]apiv1.ConfigMap, error) {
-	cm, err := c.CoreV1().ConfigMaps(namespace).List(
-		ctx,
-		metav1.ListOptions{
-			LabelSelector: labelSelector,
-		},
-	)
+	result := []apiv1.ConfigMap{}
+	continueToken := ""
+	for {
+		// configmaps are requested in chunks, so namespaces with thousands of them don't time out
+		cm, err := c.CoreV1().ConfigMaps(namespace).List(
+			ctx,
+			metav1.ListOptions{
+				LabelSelector: labelSelector,
+				Limit:         listChunkSize,
+				Continue:      continueToken,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cm.Items...)
 
-	if err != nil {
-		return nil, err
+		continueToken = cm.Continue
+		if continueToken == "" {
+			return result, nil
+		}
 	}
-
-	return cm.Items, nil
 }
 
 // Deploy creates or updates a configmap
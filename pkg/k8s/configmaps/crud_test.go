@@ -191,3 +191,30 @@ func TestList_Err(t *testing.T) {
 	assert.Nil(t, cfg)
 	assert.ErrorIs(t, err, mockErr)
 }
+
+func TestListInChunks(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	pages := []*apiv1.ConfigMapList{
+		{
+			ListMeta: metav1.ListMeta{Continue: "page-2"},
+			Items:    []apiv1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "cm-1", Labels: map[string]string{"app": "test"}}}},
+		},
+		{
+			Items: []apiv1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "cm-2", Labels: map[string]string{"app": "test"}}}},
+		},
+	}
+	requests := 0
+	c.PrependReactor("list", "configmaps", func(_ k8sTesting.Action) (bool, runtime.Object, error) {
+		page := pages[requests]
+		requests++
+		return true, page, nil
+	})
+
+	result, err := configmaps.List(ctx, "test", "app=test", c)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "cm-1", result[0].Name)
+	assert.Equal(t, "cm-2", result[1].Name)
+	assert.Equal(t, 2, requests)
+}
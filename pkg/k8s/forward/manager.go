@@ -167,6 +167,23 @@ func (p *PortForwardManager) Start(devPod, namespace string) error {
 	return nil
 }
 
+// StartServices starts the port forwarders to the services, without a development container.
+// Each service is forwarded in the background and reconnected if its pod is restarted
+func (p *PortForwardManager) StartServices(namespace string) {
+	p.stopped = false
+	for svc := range p.services {
+		go p.forwardService(p.ctx, namespace, svc)
+	}
+}
+
+// Pause is not supported by the kubernetes port forwards, they keep accepting connections
+func (*PortForwardManager) Pause(_ ...int) {
+	oktetoLog.Infof("k8s forwards can't be paused")
+}
+
+// Resume is not supported by the kubernetes port forwards
+func (*PortForwardManager) Resume() {}
+
 // Stop stops all the port forwarders
 func (p *PortForwardManager) Stop() {
 	p.stopped = true
@@ -0,0 +1,82 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// VariablesConfigMapName is the name of the configmap with the default variables of the deploys into a namespace
+	VariablesConfigMapName = "okteto-namespace-variables"
+
+	// variablesLabel identifies the configmap with the default variables of a namespace
+	variablesLabel = "dev.okteto.com/namespace-variables"
+)
+
+// GetVariables returns the default variables of a namespace sorted by name
+func GetVariables(ctx context.Context, namespace string, c kubernetes.Interface) ([]env.Var, error) {
+	cmap, err := configmaps.Get(ctx, VariablesConfigMapName, namespace, c)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return []env.Var{}, nil
+		}
+		return nil, err
+	}
+
+	result := make([]env.Var, 0, len(cmap.Data))
+	for name, value := range cmap.Data {
+		result = append(result, env.Var{Name: name, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// SetVariables adds the variables to the default variables of a namespace, overriding the ones with the same name
+func SetVariables(ctx context.Context, namespace string, variables []env.Var, c kubernetes.Interface) error {
+	current, err := GetVariables(ctx, namespace, c)
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]string, len(current)+len(variables))
+	for _, v := range current {
+		data[v.Name] = v.Value
+	}
+	for _, v := range variables {
+		data[v.Name] = v.Value
+	}
+
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      VariablesConfigMapName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				variablesLabel: "true",
+			},
+		},
+		Data: data,
+	}
+	return configmaps.Deploy(ctx, cmap, namespace, c)
+}
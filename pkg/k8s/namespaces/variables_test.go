@@ -0,0 +1,68 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetVariablesWithoutConfigMap(t *testing.T) {
+	c := fake.NewSimpleClientset()
+
+	vars, err := GetVariables(context.Background(), "test", c)
+	require.NoError(t, err)
+	assert.Empty(t, vars)
+}
+
+func TestSetVariables(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: VariablesConfigMapName, Namespace: "test"},
+		Data:       map[string]string{"REGION": "us-east-1", "LOG_LEVEL": "info"},
+	})
+
+	err := SetVariables(ctx, "test", []env.Var{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "CLUSTER", Value: "dev"}}, c)
+	require.NoError(t, err)
+
+	vars, err := GetVariables(ctx, "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, []env.Var{
+		{Name: "CLUSTER", Value: "dev"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "REGION", Value: "us-east-1"},
+	}, vars)
+
+	cmap, err := c.CoreV1().ConfigMaps("test").Get(ctx, VariablesConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", cmap.Labels[variablesLabel])
+}
+
+func TestSetVariablesCreatesConfigMap(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+
+	require.NoError(t, SetVariables(ctx, "test", []env.Var{{Name: "REGION", Value: "eu-west-1"}}, c))
+
+	vars, err := GetVariables(ctx, "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, []env.Var{{Name: "REGION", Value: "eu-west-1"}}, vars)
+}
@@ -172,6 +172,11 @@ func CheckIfBashIsAvailable(ctx context.Context, p *apiv1.Pod, container string,
 	return err == nil
 }
 
+// RunCommand executes cmd in the given container of a running pod and returns its output
+func RunCommand(ctx context.Context, p *apiv1.Pod, container string, cmd []string, config *rest.Config, c *kubernetes.Clientset) (string, error) {
+	return execCommandInPod(ctx, p, container, cmd, config, c)
+}
+
 func execCommandInPod(ctx context.Context, p *apiv1.Pod, container string, cmd []string, config *rest.Config, c *kubernetes.Clientset) (string, error) {
 	in := strings.NewReader("\n")
 	var out bytes.Buffer
@@ -47,6 +47,12 @@ func List(ctx context.Context, namespace, labels string, c kubernetes.Interface)
 // CreateForDev deploys the volume claim for a given development container
 func CreateForDev(ctx context.Context, dev *model.Dev, c kubernetes.Interface, devPath string) error {
 	vClient := c.CoreV1().PersistentVolumeClaims(dev.Namespace)
+	if dev.HasExistingPersistentVolumeClaim() {
+		return checkExistingClaim(ctx, dev, c)
+	}
+	if err := checkStorageClass(ctx, dev.PersistentVolumeStorageClass(), c); err != nil {
+		return err
+	}
 	pvcForDev := translate(dev)
 	k8Volume, err := vClient.Get(ctx, pvcForDev.Name, metav1.GetOptions{})
 	if err != nil && !strings.Contains(err.Error(), "not found") {
@@ -76,12 +82,56 @@ func CreateForDev(ctx context.Context, dev *model.Dev, c kubernetes.Interface, d
 			oktetoLog.Warning(`Could not increase the size of the dev volume from %s to %s:
 try running 'okteto down -v' and 'okteto up', or talk to your administrator
 (the PVC's storage class must support 'allowVolumeExpansion' to be able to upscale dev volumes).`,
-				k8Volume.Spec.Resources.Requests[apiv1.ResourceStorage], pvcForDev.Spec.Resources.Requests[apiv1.ResourceStorage])
+				k8Volume.Spec.Resources.Requests.Storage(), pvcForDev.Spec.Resources.Requests.Storage())
+		}
+	}
+	return nil
+}
+
+// checkExistingClaim verifies that the claim referenced by 'persistentVolume.claimName' exists
+func checkExistingClaim(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	if _, err := c.CoreV1().PersistentVolumeClaims(dev.Namespace).Get(ctx, dev.GetVolumeName(), metav1.GetOptions{}); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("persistent volume claim '%s' not found in namespace '%s'", dev.GetVolumeName(), dev.Namespace),
+				Hint: "Create the claim or remove 'persistentVolume.claimName' from your okteto manifest",
+			}
 		}
+		return fmt.Errorf("error getting kubernetes volume claim: %w", err)
 	}
+	oktetoLog.Infof("using existing volume claim '%s'", dev.GetVolumeName())
 	return nil
 }
 
+// checkStorageClass verifies that storageClass is available in the cluster before creating the volume claim
+func checkStorageClass(ctx context.Context, storageClass string, c kubernetes.Interface) error {
+	if storageClass == "" {
+		return nil
+	}
+	_, err := c.StorageV1().StorageClasses().Get(ctx, storageClass, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !oktetoErrors.IsNotFound(err) {
+		// users might not have permissions to read cluster scoped resources
+		oktetoLog.Infof("could not validate storage class '%s': %s", storageClass, err)
+		return nil
+	}
+
+	hint := "Update 'persistentVolume.storageClass' in your okteto manifest"
+	if scList, err := c.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{}); err == nil && len(scList.Items) > 0 {
+		names := []string{}
+		for _, sc := range scList.Items {
+			names = append(names, sc.Name)
+		}
+		hint = fmt.Sprintf("%s. Available storage classes: %s", hint, strings.Join(names, ", "))
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("storage class '%s' not found", storageClass),
+		Hint: hint,
+	}
+}
+
 func isDynamicallyProvisionedPVCError(err error, pvcName string) bool {
 	errorString := fmt.Sprintf("persistentvolumeclaims \"%s\" is forbidden: only dynamically provisioned pvc can be resized and the storageclass that provisions the pvc must support resize", pvcName)
 	return strings.Contains(err.Error(), errorString)
@@ -161,6 +211,10 @@ func checkPVCValues(pvc *apiv1.PersistentVolumeClaim, dev *model.Dev, devPath st
 
 // DestroyDev destroys the persistent volume claim for a given development container
 func DestroyDev(ctx context.Context, dev *model.Dev, c kubernetes.Interface) error {
+	if dev.HasExistingPersistentVolumeClaim() {
+		oktetoLog.Infof("volume claim '%s' is not managed by okteto, skipping its deletion", dev.GetVolumeName())
+		return nil
+	}
 	return Destroy(ctx, dev.GetVolumeName(), dev.Namespace, c, dev.Timeout.Default)
 }
 
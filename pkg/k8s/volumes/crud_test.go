@@ -18,9 +18,11 @@ import (
 	"fmt"
 	"testing"
 
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -315,3 +317,41 @@ func TestCreateForDev(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateForDevWithExistingClaim(t *testing.T) {
+	dev := &model.Dev{
+		Name:                 "test",
+		Namespace:            "test",
+		PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: true, ClaimName: "shared"},
+	}
+
+	c := fake.NewSimpleClientset()
+	err := CreateForDev(context.Background(), dev, c, "")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	c = fake.NewSimpleClientset(&apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "test"},
+	})
+	assert.NoError(t, CreateForDev(context.Background(), dev, c, ""))
+
+	_, err = c.CoreV1().PersistentVolumeClaims("test").Get(context.Background(), "test-okteto", metav1.GetOptions{})
+	assert.True(t, oktetoErrors.IsNotFound(err))
+
+	assert.NoError(t, DestroyDev(context.Background(), dev, c))
+	_, err = c.CoreV1().PersistentVolumeClaims("test").Get(context.Background(), "shared", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func Test_checkStorageClass(t *testing.T) {
+	c := fake.NewSimpleClientset(&storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "standard"},
+	})
+
+	assert.NoError(t, checkStorageClass(context.Background(), "", c))
+	assert.NoError(t, checkStorageClass(context.Background(), "standard", c))
+
+	err := checkStorageClass(context.Background(), "fast", c)
+	userErr := oktetoErrors.UserError{}
+	assert.ErrorAs(t, err, &userErr)
+	assert.Contains(t, userErr.Hint, "standard")
+}
@@ -30,7 +30,7 @@ func translate(dev *model.Dev) *apiv1.PersistentVolumeClaim {
 			},
 		},
 		Spec: apiv1.PersistentVolumeClaimSpec{
-			AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
+			AccessModes: dev.PersistentVolumeAccessModes(),
 			Resources: apiv1.ResourceRequirements{
 				Requests: apiv1.ResourceList{
 					"storage": resource.MustParse(dev.PersistentVolumeSize()),
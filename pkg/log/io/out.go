@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/okteto/okteto/pkg/env"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
 // OutputController manages the output for the CLI
@@ -133,6 +134,7 @@ func (oc *OutputController) Success(format string, args ...any) {
 // Warning prints a warning message to the user
 func (oc *OutputController) Warning(format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
+	oktetoLog.AddWarning(msg)
 	msg = oc.decorator.Warning(msg)
 	bytes, err := oc.formatter.format(msg)
 	if err != nil {
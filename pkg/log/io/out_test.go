@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -202,6 +203,17 @@ func TestSuccess(t *testing.T) {
 	require.Equal(t, "", buffer.String())
 }
 
+func TestWarningIsRecorded(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	l := newOutputController(buffer)
+	l.spinner = newNoSpinner("test", l)
+
+	l.SetOutputFormat("plain")
+	l.Warning("Skipping '%s'", "api")
+	require.Equal(t, "WARNING: Skipping 'api'\n", buffer.String())
+	require.Contains(t, oktetoLog.GetWarnings(), "Skipping 'api'")
+}
+
 type fakeSpinner struct {
 	message string
 	on      bool
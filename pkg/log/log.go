@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/google/uuid"
@@ -78,6 +79,10 @@ type logger struct {
 
 	maskedWords []string
 	isMasked    bool
+
+	// warnings stores the warnings shown during the execution
+	warnings   []string
+	warningsMu sync.Mutex
 }
 
 var log = &logger{
@@ -236,6 +241,16 @@ func RedString(format string, args ...interface{}) string {
 	return redString(format, args...)
 }
 
+// GreenString returns a string in green
+func GreenString(format string, args ...interface{}) string {
+	return greenString(format, args...)
+}
+
+// YellowString returns a string in yellow
+func YellowString(format string, args ...interface{}) string {
+	return yellowString(format, args...)
+}
+
 // BlueBackgroundString returns a string in a blue background
 func BlueBackgroundString(format string, args ...interface{}) string {
 	return blueString(format, args...)
@@ -258,9 +273,24 @@ func Question(format string, args ...interface{}) error {
 
 // Warning prints a message with the warning symbol first, and the text in yellow
 func Warning(format string, args ...interface{}) {
+	AddWarning(fmt.Sprintf(format, args...))
 	log.writer.Warning(format, args...)
 }
 
+// AddWarning records a warning shown during the execution by other writers, so it is returned by GetWarnings
+func AddWarning(msg string) {
+	log.warningsMu.Lock()
+	defer log.warningsMu.Unlock()
+	log.warnings = append(log.warnings, redactMessage(msg))
+}
+
+// GetWarnings returns the warnings shown during the execution
+func GetWarnings() []string {
+	log.warningsMu.Lock()
+	defer log.warningsMu.Unlock()
+	return append([]string{}, log.warnings...)
+}
+
 // FWarning prints a message with the warning symbol first, and the text in yellow to a specific writer
 func FWarning(w io.Writer, format string, args ...interface{}) {
 	log.writer.FWarning(w, format, args...)
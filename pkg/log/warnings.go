@@ -0,0 +1,119 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WarningID identifies a warning that can be suppressed through 'suppressWarnings'
+type WarningID string
+
+const (
+	// WarnRepositoryNotDetected is shown when the git repository of a deploy can't be detected
+	WarnRepositoryNotDetected WarningID = "W001"
+	// WarnServicesToDeployOverridden is shown when the services to deploy are overridden by command line arguments
+	WarnServicesToDeployOverridden WarningID = "W002"
+	// WarnGitFolderSynchronized is shown when the '.git' folder is synchronized to the dev container
+	WarnGitFolderSynchronized WarningID = "W003"
+	// WarnContextFromEnvVars is shown when the context is initialized from environment variables
+	WarnContextFromEnvVars WarningID = "W004"
+	// WarnVariableOverridden is shown when an okteto variable is overridden by a local environment variable
+	WarnVariableOverridden WarningID = "W005"
+	// WarnDependantBuildImages is shown when images are built because other images depend on them
+	WarnDependantBuildImages WarningID = "W006"
+	// WarnDependantServicesDeployed is shown when compose services are deployed because other services depend on them
+	WarnDependantServicesDeployed WarningID = "W007"
+	// WarnServiceNameSanitized is shown when a service name is sanitized to be a valid kubernetes name
+	WarnServiceNameSanitized WarningID = "W008"
+	// WarnComposeFieldsNotSupported is shown when a compose file uses fields not supported by okteto
+	WarnComposeFieldsNotSupported WarningID = "W009"
+	// WarnSelfSignedCertificates is shown when the okteto instance uses self-signed certificates
+	WarnSelfSignedCertificates WarningID = "W010"
+	// WarnOktetoDeployIgnoreDeprecated is shown when files are ignored through the deprecated .oktetodeployignore file
+	WarnOktetoDeployIgnoreDeprecated WarningID = "W011"
+	// WarnExternalResourcesNotSupported is shown when external resources are deployed on a context not managed by okteto
+	WarnExternalResourcesNotSupported WarningID = "W012"
+	// WarnRemoteExecutionNotSupported is shown when remote execution is requested on a context not managed by okteto
+	WarnRemoteExecutionNotSupported WarningID = "W013"
+)
+
+// knownWarnings are the warnings that can be suppressed
+var knownWarnings = map[WarningID]bool{
+	WarnRepositoryNotDetected:         true,
+	WarnServicesToDeployOverridden:    true,
+	WarnGitFolderSynchronized:         true,
+	WarnContextFromEnvVars:            true,
+	WarnVariableOverridden:            true,
+	WarnDependantBuildImages:          true,
+	WarnDependantServicesDeployed:     true,
+	WarnServiceNameSanitized:          true,
+	WarnComposeFieldsNotSupported:     true,
+	WarnSelfSignedCertificates:        true,
+	WarnOktetoDeployIgnoreDeprecated:  true,
+	WarnExternalResourcesNotSupported: true,
+	WarnRemoteExecutionNotSupported:   true,
+}
+
+var (
+	suppressedWarnings   = map[WarningID]bool{}
+	suppressedWarningsMu sync.RWMutex
+)
+
+// WarningWithID prints a warning prefixed with its ID, unless the ID has been suppressed
+func WarningWithID(id WarningID, format string, args ...interface{}) {
+	if IsWarningSuppressed(id) {
+		Infof("suppressed warning %s: %s", id, fmt.Sprintf(format, args...))
+		return
+	}
+	Warning("[%s] %s", id, fmt.Sprintf(format, args...))
+}
+
+// SuppressWarnings prevents the warnings with the given IDs from being shown
+func SuppressWarnings(ids ...string) {
+	suppressedWarningsMu.Lock()
+	defer suppressedWarningsMu.Unlock()
+	for _, id := range ids {
+		suppressedWarnings[WarningID(strings.ToUpper(strings.TrimSpace(id)))] = true
+	}
+}
+
+// IsWarningSuppressed returns true if the warning with the given ID must not be shown
+func IsWarningSuppressed(id WarningID) bool {
+	suppressedWarningsMu.RLock()
+	defer suppressedWarningsMu.RUnlock()
+	return suppressedWarnings[id]
+}
+
+// ValidateWarningIDs returns an error if any of ids is not a known warning ID
+func ValidateWarningIDs(ids []string) error {
+	unknown := []string{}
+	for _, id := range ids {
+		if !knownWarnings[WarningID(strings.ToUpper(strings.TrimSpace(id)))] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	known := []string{}
+	for id := range knownWarnings {
+		known = append(known, string(id))
+	}
+	sort.Strings(known)
+	return fmt.Errorf("unknown warning IDs: %s. Valid IDs are: %s", strings.Join(unknown, ", "), strings.Join(known, ", "))
+}
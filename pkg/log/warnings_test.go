@@ -0,0 +1,64 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SuppressWarnings(t *testing.T) {
+	defer func() {
+		suppressedWarnings = map[WarningID]bool{}
+	}()
+
+	assert.False(t, IsWarningSuppressed(WarnSelfSignedCertificates))
+
+	SuppressWarnings(" w010")
+	assert.True(t, IsWarningSuppressed(WarnSelfSignedCertificates))
+	assert.False(t, IsWarningSuppressed(WarnRepositoryNotDetected))
+}
+
+func Test_ValidateWarningIDs(t *testing.T) {
+	var tests = []struct {
+		name      string
+		ids       []string
+		expectErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "known ids",
+			ids:  []string{"W001", "w012"},
+		},
+		{
+			name:      "unknown id",
+			ids:       []string{"W001", "W100"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWarningIDs(tt.ids)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+const (
+	bakeDefaultGroup = "default"
+
+	// bakeTargetContextPrefix is the prefix of the named contexts that reference other targets of the bake file
+	bakeTargetContextPrefix = "target:"
+)
+
+// BakeType represents a buildx bake file used as the build section of a manifest
+var BakeType Archetype = "bake"
+
+// bakeFile represents the json definition of a buildx bake file
+type bakeFile struct {
+	Group  map[string]*bakeGroup  `json:"group"`
+	Target map[string]*bakeTarget `json:"target"`
+}
+
+type bakeGroup struct {
+	Targets []string `json:"targets"`
+}
+
+type bakeTarget struct {
+	Args             map[string]*string `json:"args"`
+	Contexts         map[string]string  `json:"contexts"`
+	Context          *string            `json:"context"`
+	Dockerfile       *string            `json:"dockerfile"`
+	DockerfileInline *string            `json:"dockerfile-inline"`
+	Target           *string            `json:"target"`
+	Inherits         []string           `json:"inherits"`
+	Tags             []string           `json:"tags"`
+	CacheFrom        []string           `json:"cache-from"`
+	CacheTo          []string           `json:"cache-to"`
+	Platforms        []string           `json:"platforms"`
+	Secret           []string           `json:"secret"`
+	SSH              []string           `json:"ssh"`
+}
+
+// printBakeDefinition returns the json definition of a bake file resolved by docker buildx
+var printBakeDefinition = func(path string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker", "buildx", "bake", "--print", "-f", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to read the bake file '%s': %w: %s", path, err, strings.TrimSpace(stderr.String())),
+			Hint: "HCL bake files are resolved with 'docker buildx bake --print'. Install docker buildx or convert your bake file to json",
+		}
+	}
+	return stdout.Bytes(), nil
+}
+
+// IsBakeFile returns if the path is a buildx bake file
+func IsBakeFile(path string) bool {
+	ext := filepath.Ext(path)
+	if ext == ".hcl" {
+		return true
+	}
+	return ext == ".json" && strings.HasPrefix(filepath.Base(path), "docker-bake")
+}
+
+// getManifestFromBakeFile returns a manifest with a build section translated from the targets of a bake file.
+// The images of the targets are pushed to the okteto registry, so the tags of the bake file are ignored
+func getManifestFromBakeFile(cwd, bakePath string, fs afero.Fs) (*Manifest, error) {
+	var (
+		content []byte
+		err     error
+	)
+	if filepath.Ext(bakePath) == ".hcl" {
+		content, err = printBakeDefinition(bakePath)
+	} else {
+		content, err = afero.ReadFile(fs, bakePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bf := &bakeFile{}
+	if err := json.Unmarshal(content, bf); err != nil {
+		return nil, fmt.Errorf("%w: invalid bake file '%s': %w", oktetoErrors.ErrInvalidManifest, bakePath, err)
+	}
+
+	targets, err := bf.getDefaultTargets()
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid bake file '%s': %w", oktetoErrors.ErrInvalidManifest, bakePath, err)
+	}
+
+	bakeDir, err := filepath.Rel(cwd, filepath.Dir(bakePath))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := NewManifest()
+	manifest.Type = BakeType
+	manifest.IsV2 = true
+	manifest.Fs = fs
+	manifest.Manifest = content
+	for _, name := range targets {
+		t, err := bf.resolveTarget(name, map[string]bool{})
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid bake file '%s': %w", oktetoErrors.ErrInvalidManifest, bakePath, err)
+		}
+		manifest.Build[name] = t.toBuildInfo(name, bakeDir)
+	}
+	if len(manifest.Build) == 0 {
+		return nil, fmt.Errorf("%w: bake file '%s' has no targets", oktetoErrors.ErrInvalidManifest, bakePath)
+	}
+	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Bake file unmarshalled successfully")
+	return manifest, nil
+}
+
+// getDefaultTargets returns the targets of the default group, or every target when there is no default group
+func (bf *bakeFile) getDefaultTargets() ([]string, error) {
+	if _, ok := bf.Group[bakeDefaultGroup]; !ok {
+		targets := make([]string, 0, len(bf.Target))
+		for name := range bf.Target {
+			targets = append(targets, name)
+		}
+		sort.Strings(targets)
+		return targets, nil
+	}
+
+	result := []string{}
+	added := map[string]bool{}
+	var visit func(name string, visited map[string]bool) error
+	visit = func(name string, visited map[string]bool) error {
+		if visited[name] {
+			return fmt.Errorf("group '%s' has a cycle", name)
+		}
+		if g, ok := bf.Group[name]; ok {
+			visited[name] = true
+			for _, t := range g.Targets {
+				if err := visit(t, visited); err != nil {
+					return err
+				}
+			}
+			delete(visited, name)
+			return nil
+		}
+		if _, ok := bf.Target[name]; !ok {
+			return fmt.Errorf("target '%s' is not defined", name)
+		}
+		if !added[name] {
+			added[name] = true
+			result = append(result, name)
+		}
+		return nil
+	}
+	if err := visit(bakeDefaultGroup, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resolveTarget returns the target merged with the targets it inherits from
+func (bf *bakeFile) resolveTarget(name string, visited map[string]bool) (*bakeTarget, error) {
+	t, ok := bf.Target[name]
+	if !ok || t == nil {
+		return nil, fmt.Errorf("target '%s' is not defined", name)
+	}
+	if visited[name] {
+		return nil, fmt.Errorf("target '%s' has a cycle in its 'inherits' field", name)
+	}
+	visited[name] = true
+	defer delete(visited, name)
+
+	result := &bakeTarget{}
+	for _, parentName := range t.Inherits {
+		parent, err := bf.resolveTarget(parentName, visited)
+		if err != nil {
+			return nil, err
+		}
+		result.merge(parent)
+	}
+	result.merge(t)
+	result.Inherits = nil
+	return result, nil
+}
+
+// merge overrides the fields of the target with the fields defined by other
+func (t *bakeTarget) merge(other *bakeTarget) {
+	if other.Context != nil {
+		t.Context = other.Context
+	}
+	if other.Dockerfile != nil {
+		t.Dockerfile = other.Dockerfile
+	}
+	if other.DockerfileInline != nil {
+		t.DockerfileInline = other.DockerfileInline
+	}
+	if other.Target != nil {
+		t.Target = other.Target
+	}
+	for k, v := range other.Args {
+		if t.Args == nil {
+			t.Args = map[string]*string{}
+		}
+		t.Args[k] = v
+	}
+	for k, v := range other.Contexts {
+		if t.Contexts == nil {
+			t.Contexts = map[string]string{}
+		}
+		t.Contexts[k] = v
+	}
+	if other.Tags != nil {
+		t.Tags = other.Tags
+	}
+	if other.CacheFrom != nil {
+		t.CacheFrom = other.CacheFrom
+	}
+	if other.CacheTo != nil {
+		t.CacheTo = other.CacheTo
+	}
+	if other.Platforms != nil {
+		t.Platforms = other.Platforms
+	}
+	if other.Secret != nil {
+		t.Secret = other.Secret
+	}
+	if other.SSH != nil {
+		t.SSH = other.SSH
+	}
+}
+
+// toBuildInfo translates a bake target to the build info of a service. Paths are relative to the bake file
+func (t *bakeTarget) toBuildInfo(name, bakeDir string) *build.Info {
+	info := &build.Info{
+		Context: bakeDir,
+		SSH:     t.SSH,
+	}
+	if t.Context != nil {
+		info.Context = *t.Context
+		if !filepath.IsAbs(info.Context) && !build.IsRemoteContext(info.Context) {
+			info.Context = filepath.Join(bakeDir, info.Context)
+		}
+	}
+	if t.Dockerfile != nil {
+		info.Dockerfile = *t.Dockerfile
+	}
+	if t.DockerfileInline != nil {
+		info.DockerfileInline = *t.DockerfileInline
+	}
+	if t.Target != nil {
+		info.Target = *t.Target
+	}
+	if len(t.Tags) > 0 {
+		oktetoLog.Infof("ignoring the tags of the bake target '%s', the image is pushed to the okteto registry", name)
+	}
+
+	argNames := make([]string, 0, len(t.Args))
+	for k := range t.Args {
+		argNames = append(argNames, k)
+	}
+	sort.Strings(argNames)
+	for _, k := range argNames {
+		// args without value are taken from the environment by buildx, they are not set in the build
+		if t.Args[k] == nil {
+			continue
+		}
+		info.Args = append(info.Args, build.Arg{Name: k, Value: *t.Args[k]})
+	}
+
+	contextNames := make([]string, 0, len(t.Contexts))
+	for k := range t.Contexts {
+		contextNames = append(contextNames, k)
+	}
+	sort.Strings(contextNames)
+	for _, k := range contextNames {
+		if dependency, ok := strings.CutPrefix(t.Contexts[k], bakeTargetContextPrefix); ok {
+			info.DependsOn = append(info.DependsOn, dependency)
+			continue
+		}
+		oktetoLog.Infof("ignoring the named context '%s' of the bake target '%s'", k, name)
+	}
+
+	for _, c := range t.CacheFrom {
+		if ref := getBakeCacheRef(c); ref != "" {
+			info.CacheFrom = append(info.CacheFrom, ref)
+		}
+	}
+	for _, c := range t.CacheTo {
+		if ref := getBakeCacheRef(c); ref != "" {
+			info.ExportCache = append(info.ExportCache, ref)
+		}
+	}
+	info.Platforms = t.Platforms
+
+	for _, s := range t.Secret {
+		id, src := parseBakeSecret(s)
+		if id == "" || src == "" {
+			oktetoLog.Infof("ignoring the secret '%s' of the bake target '%s': only file secrets are supported", s, name)
+			continue
+		}
+		if info.Secrets == nil {
+			info.Secrets = build.Secrets{}
+		}
+		info.Secrets[id] = src
+	}
+	return info
+}
+
+// getBakeCacheRef returns the image of a registry cache entry. Other cache backends are not supported
+func getBakeCacheRef(entry string) string {
+	if !strings.Contains(entry, "=") {
+		return entry
+	}
+	attrs := parseBakeAttributes(entry)
+	if attrs["type"] != "" && attrs["type"] != "registry" {
+		oktetoLog.Infof("ignoring the cache '%s': only registry caches are supported", entry)
+		return ""
+	}
+	return attrs["ref"]
+}
+
+// parseBakeSecret returns the id and the source file of a secret entry
+func parseBakeSecret(entry string) (string, string) {
+	attrs := parseBakeAttributes(entry)
+	if attrs["type"] != "" && attrs["type"] != "file" {
+		return "", ""
+	}
+	src := attrs["src"]
+	if src == "" {
+		src = attrs["source"]
+	}
+	return attrs["id"], src
+}
+
+func parseBakeAttributes(entry string) map[string]string {
+	result := map[string]string{}
+	for _, field := range strings.Split(entry, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(field), "=")
+		result[k] = v
+	}
+	return result
+}
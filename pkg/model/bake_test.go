@@ -0,0 +1,185 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cache"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBakeFile(t *testing.T) {
+	assert.True(t, IsBakeFile("docker-bake.hcl"))
+	assert.True(t, IsBakeFile(filepath.Join("build", "services.hcl")))
+	assert.True(t, IsBakeFile("docker-bake.json"))
+	assert.True(t, IsBakeFile("docker-bake.override.json"))
+	assert.False(t, IsBakeFile("package.json"))
+	assert.False(t, IsBakeFile("okteto.yml"))
+	assert.False(t, IsBakeFile("docker-compose.yml"))
+}
+
+func TestGetManifestFromBakeFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	content := `{
+  "group": {
+    "default": {"targets": ["frontend", "backend"]},
+    "backend": {"targets": ["api", "worker"]}
+  },
+  "target": {
+    "_common": {
+      "args": {"NODE_ENV": "production", "FROM_ENV": null},
+      "platforms": ["linux/amd64", "linux/arm64"]
+    },
+    "frontend": {
+      "inherits": ["_common"],
+      "context": "frontend",
+      "dockerfile": "Dockerfile.prod",
+      "tags": ["docker.io/okteto/frontend:latest"],
+      "args": {"API_IMAGE": "${OKTETO_BUILD_API_IMAGE}"},
+      "contexts": {"api": "target:api", "assets": "../assets"},
+      "cache-from": ["type=registry,ref=okteto/frontend:cache", "type=gha"],
+      "cache-to": ["type=registry,ref=okteto/frontend:cache,mode=max"],
+      "secret": ["id=npmrc,src=.npmrc", "id=token,env=TOKEN"]
+    },
+    "api": {
+      "context": "api",
+      "target": "prod",
+      "cache-from": ["okteto/api:cache"],
+      "ssh": ["default"]
+    },
+    "worker": {
+      "inherits": ["api"],
+      "target": "worker"
+    },
+    "unused": {
+      "context": "unused"
+    }
+  }
+}`
+	bakePath := filepath.Join("/app", "build", "docker-bake.json")
+	require.NoError(t, afero.WriteFile(fs, bakePath, []byte(content), 0600))
+
+	m, err := getManifestFromBakeFile("/app", bakePath, fs)
+	require.NoError(t, err)
+
+	assert.Equal(t, BakeType, m.Type)
+	assert.True(t, m.IsV2)
+	assert.Equal(t, []byte(content), m.Manifest)
+	assert.Equal(t, build.ManifestBuild{
+		"frontend": {
+			Context:    filepath.Join("build", "frontend"),
+			Dockerfile: "Dockerfile.prod",
+			Args: build.Args{
+				{Name: "API_IMAGE", Value: "${OKTETO_BUILD_API_IMAGE}"},
+				{Name: "NODE_ENV", Value: "production"},
+			},
+			DependsOn:   build.DependsOn{"api"},
+			CacheFrom:   cache.From{"okteto/frontend:cache"},
+			ExportCache: cache.ExportCache{"okteto/frontend:cache"},
+			Platforms:   []string{"linux/amd64", "linux/arm64"},
+			Secrets:     build.Secrets{"npmrc": ".npmrc"},
+		},
+		"api": {
+			Context:   filepath.Join("build", "api"),
+			Target:    "prod",
+			CacheFrom: cache.From{"okteto/api:cache"},
+			SSH:       []string{"default"},
+		},
+		"worker": {
+			Context:   filepath.Join("build", "api"),
+			Target:    "worker",
+			CacheFrom: cache.From{"okteto/api:cache"},
+			SSH:       []string{"default"},
+		},
+	}, m.Build)
+}
+
+func TestGetManifestFromBakeFileWithoutDefaultGroup(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	content := `{"target": {"web": {}, "api": {"context": "/src/api"}}}`
+	require.NoError(t, afero.WriteFile(fs, "/app/docker-bake.json", []byte(content), 0600))
+
+	m, err := getManifestFromBakeFile("/app", "/app/docker-bake.json", fs)
+	require.NoError(t, err)
+	assert.Equal(t, build.ManifestBuild{
+		"web": {Context: "."},
+		"api": {Context: "/src/api"},
+	}, m.Build)
+}
+
+func TestGetManifestFromBakeFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "invalid json",
+			content: `target "api" {}`,
+		},
+		{
+			name:    "no targets",
+			content: `{"target": {}}`,
+		},
+		{
+			name:    "undefined target in group",
+			content: `{"group": {"default": {"targets": ["api"]}}, "target": {"web": {}}}`,
+		},
+		{
+			name:    "group cycle",
+			content: `{"group": {"default": {"targets": ["all"]}, "all": {"targets": ["default"]}}, "target": {"web": {}}}`,
+		},
+		{
+			name:    "inherits cycle",
+			content: `{"target": {"web": {"inherits": ["api"]}, "api": {"inherits": ["web"]}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "/app/docker-bake.json", []byte(tt.content), 0600))
+
+			_, err := getManifestFromBakeFile("/app", "/app/docker-bake.json", fs)
+			assert.ErrorIs(t, err, oktetoErrors.ErrInvalidManifest)
+		})
+	}
+}
+
+func TestGetManifestFromBakeFileHCL(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	original := printBakeDefinition
+	t.Cleanup(func() {
+		printBakeDefinition = original
+	})
+
+	printBakeDefinition = func(path string) ([]byte, error) {
+		assert.Equal(t, "/app/docker-bake.hcl", path)
+		return []byte(`{"target": {"api": {"context": "api"}}}`), nil
+	}
+	m, err := getManifestFromBakeFile("/app", "/app/docker-bake.hcl", fs)
+	require.NoError(t, err)
+	assert.Equal(t, build.ManifestBuild{"api": {Context: "api"}}, m.Build)
+
+	printBakeDefinition = func(string) ([]byte, error) {
+		return nil, errors.New("docker not found")
+	}
+	_, err = getManifestFromBakeFile("/app", "/app/docker-bake.hcl", fs)
+	assert.Error(t, err)
+}
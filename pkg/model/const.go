@@ -54,6 +54,9 @@ const (
 	// OktetoRepositoryAnnotation indicates the git repo url with the source code of this component
 	OktetoRepositoryAnnotation = "dev.okteto.com/repository"
 
+	// DevModeOwnerAnnotation indicates the user that activated the development container
+	DevModeOwnerAnnotation = "dev.okteto.com/dev-mode-owner"
+
 	// OktetoDevNameAnnotation indicates the name of the dev to be deployed
 	OktetoDevNameAnnotation = "dev.okteto.com/name"
 
@@ -72,6 +75,9 @@ const (
 	// GitDeployLabel indicates the object is an app
 	GitDeployLabel = "dev.okteto.com/git-deploy"
 
+	// OwnerLabel indicates the user that deployed a per-user dev environment
+	OwnerLabel = "dev.okteto.com/owner"
+
 	// StackLabel indicates the object is a stack
 	StackLabel = "stack.okteto.com"
 
@@ -96,6 +102,9 @@ const (
 	// OktetoInstallerRunningLabel indicates the okteto installer is running on this resource
 	OktetoInstallerRunningLabel = "dev.okteto.com/installer-running"
 
+	// OktetoPrefetchLabel indicates the pod pre-pulls the images of a development container
+	OktetoPrefetchLabel = "dev.okteto.com/prefetch"
+
 	// StackVolumeNameLabel indicates the name of the stack volume an object belongs to
 	StackVolumeNameLabel = "stack.okteto.com/volume"
 
@@ -122,6 +131,16 @@ const (
 	RemoteMountPath = "/var/okteto/remote"
 	// SyncthingSubPath subpath in the development container persistent volume for the syncthing data
 	SyncthingSubPath = "syncthing"
+	// SyncEngineSyncthing synchronizes the files using syncthing, the default sync engine
+	SyncEngineSyncthing = "syncthing"
+	// SyncEngineMutagen synchronizes the files using mutagen sessions over SSH
+	SyncEngineMutagen = "mutagen"
+	// ActivationRecreate scales down the original workload before starting the development container, the default activation
+	ActivationRecreate = "recreate"
+	// ActivationZeroDowntime keeps the original workload serving traffic until the development container is ready
+	ActivationZeroDowntime = "zero-downtime"
+	// ZeroDowntimeReadyFile is created in the development container when it is ready to receive traffic
+	ZeroDowntimeReadyFile = "/var/okteto/bin/.okteto-ready"
 	// DefaultSyncthingRescanInterval default syncthing re-scan interval
 	DefaultSyncthingRescanInterval = 300
 	// RemoteSubPath subpath in the development container persistent volume for the remote data
@@ -66,6 +66,8 @@ type Dev struct {
 	Image                *build.Info           `json:"image,omitempty" yaml:"image,omitempty"`
 	Push                 *build.Info           `json:"-" yaml:"push,omitempty"`
 	Lifecycle            *Lifecycle            `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	Down                 *DownHooks            `json:"down,omitempty" yaml:"down,omitempty"`
+	Ready                *ReadyProbe           `json:"ready,omitempty" yaml:"ready,omitempty"`
 	Replicas             *int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	InitContainer        InitContainer         `json:"initContainer,omitempty" yaml:"initContainer,omitempty"`
 	Workdir              string                `json:"workdir,omitempty" yaml:"workdir,omitempty"`
@@ -79,6 +81,7 @@ type Dev struct {
 	parentSyncFolder     string
 	Interface            string           `json:"interface,omitempty" yaml:"interface,omitempty"`
 	Mode                 string           `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Activation           string           `json:"activation,omitempty" yaml:"activation,omitempty"`
 	ImagePullPolicy      apiv1.PullPolicy `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
 
 	Tolerations     []apiv1.Toleration `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
@@ -90,6 +93,8 @@ type Dev struct {
 	Volumes         []Volume           `json:"volumes,omitempty" yaml:"volumes,omitempty"`
 	EnvFiles        env.Files          `json:"envFiles,omitempty" yaml:"envFiles,omitempty"`
 	Environment     env.Environment    `json:"environment,omitempty" yaml:"environment,omitempty"`
+	EnvFrom         []EnvFrom          `json:"envFrom,omitempty" yaml:"envFrom,omitempty"`
+	EnvRequired     []string           `json:"envRequired,omitempty" yaml:"envRequired,omitempty"`
 	Services        []*Dev             `json:"services,omitempty" yaml:"services,omitempty"`
 	Args            Command            `json:"args,omitempty" yaml:"args,omitempty"`
 	Sync            Sync               `json:"sync,omitempty" yaml:"sync,omitempty"`
@@ -97,10 +102,20 @@ type Dev struct {
 	RemotePort      int                `json:"remote,omitempty" yaml:"remote,omitempty"`
 	SSHServerPort   int                `json:"sshServerPort,omitempty" yaml:"sshServerPort,omitempty"`
 
+	// ServiceAccountTokens are the projected service account tokens mounted in the development container
+	ServiceAccountTokens []ServiceAccountToken `json:"serviceAccountTokens,omitempty" yaml:"serviceAccountTokens,omitempty"`
+
 	EmptyImage    bool `json:"-" yaml:"-"`
 	InitFromImage bool `json:"initFromImage,omitempty" yaml:"initFromImage,omitempty"`
 	Autocreate    bool `json:"autocreate,omitempty" yaml:"autocreate,omitempty"`
+	X11           bool `json:"x11,omitempty" yaml:"x11,omitempty"`
+	Clipboard     bool `json:"clipboard,omitempty" yaml:"clipboard,omitempty"`
+	Prefetch      bool `json:"prefetch,omitempty" yaml:"prefetch,omitempty"`
 	Healthchecks  bool `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"` // Deprecated field
+
+	// PersistentSession runs the dev command in a tmux or screen session of the development container,
+	// so reconnecting after a network loss reattaches to the same running process instead of restarting it
+	PersistentSession bool `json:"persistentSession,omitempty" yaml:"persistentSession,omitempty"`
 }
 
 type Affinity apiv1.Affinity
@@ -130,12 +145,18 @@ type Volume struct {
 type Sync struct {
 	LocalPath      string       `json:"-" yaml:"-"`
 	RemotePath     string       `json:"-" yaml:"-"`
+	Engine         string       `json:"engine,omitempty" yaml:"engine,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
 }
 
+// IsMutagenEngine returns true if the files are synchronized using mutagen
+func (s *Sync) IsMutagenEngine() bool {
+	return s.Engine == SyncEngineMutagen
+}
+
 // SyncFolder represents a sync folder in the development container
 type SyncFolder struct {
 	LocalPath  string `json:"localPath,omitempty" yaml:"localPath,omitempty"`
@@ -151,9 +172,22 @@ type ExternalVolume struct {
 
 // PersistentVolumeInfo info about the persistent volume
 type PersistentVolumeInfo struct {
-	StorageClass string `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
-	Size         string `json:"size,omitempty" yaml:"size,omitempty"`
-	Enabled      bool   `json:"enabled,omitempty" yaml:"enabled"`
+	StorageClass string                             `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
+	Size         string                             `json:"size,omitempty" yaml:"size,omitempty"`
+	ClaimName    string                             `json:"claimName,omitempty" yaml:"claimName,omitempty"`
+	AccessModes  []apiv1.PersistentVolumeAccessMode `json:"accessModes,omitempty" yaml:"accessModes,omitempty"`
+	Prepopulate  *PersistentVolumePrepopulate       `json:"prepopulate,omitempty" yaml:"prepopulate,omitempty"`
+	Enabled      bool                               `json:"enabled,omitempty" yaml:"enabled"`
+}
+
+// PersistentVolumePrepopulate is the data that seeds the volumes of the development container while they are empty.
+// The content at the remote path of each volume is copied from the image or the archive
+type PersistentVolumePrepopulate struct {
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+	// URL is a tar archive, optionally gzipped. 's3://' and 'gs://' URLs are downloaded from the public endpoint of the bucket
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// DownloadImage is the image that downloads and extracts the archive. It must provide 'sh', 'curl' and 'tar'
+	DownloadImage string `json:"downloadImage,omitempty" yaml:"downloadImage,omitempty"`
 }
 
 // InitContainer represents the initial container
@@ -199,6 +233,13 @@ type Secret struct {
 	Mode       int32
 }
 
+// ServiceAccountToken represents a projected service account token with a custom audience
+type ServiceAccountToken struct {
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty" yaml:"expirationSeconds,omitempty"`
+	Audience          string `json:"audience,omitempty" yaml:"audience,omitempty"`
+	Path              string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
 // Reverse represents a remote forward port
 type Reverse struct {
 	Remote int
@@ -224,6 +265,42 @@ type Lifecycle struct {
 	PostStop  bool `json:"postStop,omitempty" yaml:"postStop,omitempty"`
 }
 
+// DownHooks defines the commands run in the development container before it is deactivated by 'okteto down'
+type DownHooks struct {
+	Commands    []string      `json:"commands,omitempty" yaml:"commands,omitempty"`
+	GracePeriod time.Duration `json:"gracePeriod,omitempty" yaml:"gracePeriod,omitempty"`
+}
+
+// ReadyProbe defines the check 'okteto up' waits on after the synchronization and before running the dev command
+type ReadyProbe struct {
+	Command  string        `json:"command,omitempty" yaml:"command,omitempty"`
+	Port     int           `json:"port,omitempty" yaml:"port,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+func (r *ReadyProbe) validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.Command == "" && r.Port == 0 {
+		return fmt.Errorf("'ready' must define a 'command' or a 'port'")
+	}
+	if r.Command != "" && r.Port != 0 {
+		return fmt.Errorf("'ready.command' and 'ready.port' cannot be defined at the same time")
+	}
+	if r.Port < 0 || r.Port > 65535 {
+		return fmt.Errorf("'ready.port' must be between 1 and 65535")
+	}
+	if r.Timeout < 0 {
+		return fmt.Errorf("'ready.timeout' cannot be negative")
+	}
+	if r.Interval < 0 {
+		return fmt.Errorf("'ready.interval' cannot be negative")
+	}
+	return nil
+}
+
 // ResourceList is a set of (resource name, quantity) pairs.
 type ResourceList map[apiv1.ResourceName]resource.Quantity
 
@@ -424,6 +501,11 @@ func (dev *Dev) IsHybridModeEnabled() bool {
 	return dev.Mode == constants.OktetoHybridModeFieldValue
 }
 
+// IsZeroDowntimeActivation returns true if the original workload keeps serving traffic until the development container is ready
+func (dev *Dev) IsZeroDowntimeActivation() bool {
+	return dev.Activation == ActivationZeroDowntime
+}
+
 func (dev *Dev) SetDefaults() error {
 	if dev.Command.Values == nil {
 		dev.Command.Values = []string{"sh"}
@@ -655,6 +737,12 @@ func (dev *Dev) Validate() error {
 	if err := validateSecrets(dev.Secrets); err != nil {
 		return err
 	}
+	if err := validateEnvFrom(dev.EnvFrom); err != nil {
+		return err
+	}
+	if err := validateServiceAccountTokens(dev.ServiceAccountTokens); err != nil {
+		return err
+	}
 	if err := dev.validateSecurityContext(); err != nil {
 		return err
 	}
@@ -674,6 +762,14 @@ func (dev *Dev) Validate() error {
 		return err
 	}
 
+	if err := dev.validateForwards(); err != nil {
+		return err
+	}
+
+	if err := dev.validateActivation(); err != nil {
+		return err
+	}
+
 	if _, err := resource.ParseQuantity(dev.PersistentVolumeSize()); err != nil {
 		return fmt.Errorf("'persistentVolume.size' is not valid. A sample value would be '10Gi'")
 	}
@@ -682,6 +778,14 @@ func (dev *Dev) Validate() error {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
 
+	if dev.Down != nil && dev.Down.GracePeriod < 0 {
+		return fmt.Errorf("'down.gracePeriod' cannot be negative")
+	}
+
+	if err := dev.Ready.validate(); err != nil {
+		return err
+	}
+
 	for _, s := range dev.Services {
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
@@ -689,6 +793,12 @@ func (dev *Dev) Validate() error {
 		if err := s.validateVolumes(dev); err != nil {
 			return err
 		}
+		if err := validateEnvFrom(s.EnvFrom); err != nil {
+			return err
+		}
+		if err := validateServiceAccountTokens(s.ServiceAccountTokens); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -709,7 +819,52 @@ func (dev *Dev) PreparePathsAndExpandEnvFiles(manifestPath string, fs afero.Fs)
 	return nil
 }
 
+// validateForwards checks that forwards are only shared on the local network when the local interface is not already public
+func (dev *Dev) validateForwards() error {
+	for _, f := range dev.Forward {
+		if f.IsExposedOnLAN() && dev.Interface != "" && dev.Interface != Localhost {
+			return fmt.Errorf("port-forward %d can't define 'expose: %s' when 'interface' is '%s': it is already reachable without an access token", f.Local, forward.ExposeLAN, dev.Interface)
+		}
+	}
+	return nil
+}
+
+func (dev *Dev) validateActivation() error {
+	switch dev.Activation {
+	case "", ActivationRecreate:
+	case ActivationZeroDowntime:
+		if dev.IsHybridModeEnabled() {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the '%s' activation is not supported in hybrid mode", ActivationZeroDowntime),
+				Hint: "Remove the 'activation' field from your okteto manifest",
+			}
+		}
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("activation '%s' is not supported", dev.Activation),
+			Hint: fmt.Sprintf("Update the 'activation' field in your okteto manifest to one of: ['%s', '%s']", ActivationRecreate, ActivationZeroDowntime),
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) validateSync() error {
+	switch dev.Sync.Engine {
+	case "", SyncEngineSyncthing:
+	case SyncEngineMutagen:
+		if dev.IsHybridModeEnabled() {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the '%s' sync engine is not supported in hybrid mode", SyncEngineMutagen),
+				Hint: "Remove the 'sync.engine' field from your okteto manifest",
+			}
+		}
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("sync engine '%s' is not supported", dev.Sync.Engine),
+			Hint: fmt.Sprintf("Update the 'sync.engine' field in your okteto manifest to one of: ['%s', '%s']", SyncEngineSyncthing, SyncEngineMutagen),
+		}
+	}
+
 	for _, folder := range dev.Sync.Folders {
 		validPath, err := os.Stat(folder.LocalPath)
 
@@ -764,6 +919,27 @@ func validateSecrets(secrets []Secret) error {
 	return nil
 }
 
+func validateServiceAccountTokens(tokens []ServiceAccountToken) error {
+	seen := map[string]bool{}
+	for _, t := range tokens {
+		if !strings.HasPrefix(t.Path, "/") {
+			return fmt.Errorf("'serviceAccountTokens.path' must be an absolute path, got '%s'", t.Path)
+		}
+		if t.Path == "/" || strings.HasSuffix(t.Path, "/") {
+			return fmt.Errorf("'serviceAccountTokens.path' must be the path of the token file, got '%s'", t.Path)
+		}
+		if seen[t.Path] {
+			return fmt.Errorf("'serviceAccountTokens.path' '%s' is duplicated", t.Path)
+		}
+		seen[t.Path] = true
+		// minimum value accepted by the kubernetes api
+		if t.ExpirationSeconds != nil && *t.ExpirationSeconds < 600 {
+			return fmt.Errorf("'serviceAccountTokens.expirationSeconds' must be at least 600 seconds")
+		}
+	}
+	return nil
+}
+
 // RunAsNonRoot returns true if the development container must run as a non-root user
 func (dev *Dev) RunAsNonRoot() bool {
 	if dev.SecurityContext == nil {
@@ -873,6 +1049,9 @@ func (dev *Dev) SetLastBuiltAnnotation() {
 
 // GetVolumeName returns the okteto volume name for a given development container
 func (dev *Dev) GetVolumeName() string {
+	if dev.HasExistingPersistentVolumeClaim() {
+		return dev.PersistentVolumeInfo.ClaimName
+	}
 	return fmt.Sprintf(OktetoVolumeNameTemplate, dev.Name)
 }
 
@@ -895,9 +1074,11 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 		Container:        dev.Container,
 		ImagePullPolicy:  dev.ImagePullPolicy,
 		Environment:      dev.Environment,
+		EnvFrom:          dev.EnvFrom,
 		Secrets:          dev.Secrets,
 		WorkDir:          dev.Workdir,
 		PersistentVolume: main.PersistentVolumeEnabled(),
+		Prepopulate:      main.PersistentVolumePrepopulate(),
 		Volumes:          []VolumeMount{},
 		SecurityContext:  dev.SecurityContext,
 		ServiceAccount:   dev.ServiceAccount,
@@ -909,6 +1090,7 @@ func (dev *Dev) ToTranslationRule(main *Dev, reset bool) *TranslationRule {
 		NodeSelector:     dev.NodeSelector,
 		Affinity:         (*apiv1.Affinity)(dev.Affinity),
 	}
+	rule.ServiceAccountTokens = dev.ServiceAccountTokens
 
 	if dev.IsHybridModeEnabled() {
 		rule.WorkDir = "/okteto"
@@ -1180,6 +1362,15 @@ func (service *Dev) validateForExtraFields() error {
 	if service.Autocreate {
 		return fmt.Errorf(errorMessage, "autocreate")
 	}
+	if service.Prefetch {
+		return fmt.Errorf(errorMessage, "prefetch")
+	}
+	if service.PersistentSession {
+		return fmt.Errorf(errorMessage, "persistentSession")
+	}
+	if service.Activation != "" {
+		return fmt.Errorf(errorMessage, "activation")
+	}
 	if service.Context != "" {
 		return fmt.Errorf(errorMessage, "context")
 	}
@@ -22,10 +22,12 @@ import (
 
 	"github.com/compose-spec/godotenv"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -781,6 +783,31 @@ func Test_validate(t *testing.T) {
             - .:/app`),
 			expectErr: false,
 		},
+		{
+			name: "forward-exposed-on-lan",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      forward:
+        - localPort: 8080
+          remotePort: 8080
+          expose: lan`),
+			expectErr: false,
+		},
+		{
+			name: "forward-exposed-on-lan-with-public-interface",
+			manifest: []byte(`
+      name: deployment
+      interface: 0.0.0.0
+      sync:
+        - .:/app
+      forward:
+        - localPort: 8080
+          remotePort: 8080
+          expose: lan`),
+			expectErr: true,
+		},
 		{
 			name: "pvc-size",
 			manifest: []byte(`
@@ -985,6 +1012,93 @@ func Test_validate(t *testing.T) {
         runAsGroup: 0`),
 			expectErr: false,
 		},
+		{
+			name: "down-hooks",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      down:
+        commands:
+          - redis-cli save
+        gracePeriod: 1m`),
+			expectErr: false,
+		},
+		{
+			name: "ready-command",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      ready:
+        command: test -f /app/node_modules/.ready
+        timeout: 2m`),
+			expectErr: false,
+		},
+		{
+			name: "ready-port",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      ready:
+        port: 8080`),
+			expectErr: false,
+		},
+		{
+			name: "ready-without-command-or-port",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      ready:
+        timeout: 2m`),
+			expectErr: true,
+		},
+		{
+			name: "ready-command-and-port",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      ready:
+        command: go version
+        port: 8080`),
+			expectErr: true,
+		},
+		{
+			name: "ready-invalid-port",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      ready:
+        port: 70000`),
+			expectErr: true,
+		},
+		{
+			name: "ready-negative-interval",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      ready:
+        port: 8080
+        interval: -1s`),
+			expectErr: true,
+		},
+		{
+			name: "down-hooks-negative-grace-period",
+			manifest: []byte(`
+      name: deployment
+      sync:
+        - .:/app
+      down:
+        commands:
+          - redis-cli save
+        gracePeriod: -1s`),
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1550,3 +1664,136 @@ func TestPrepare(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateSyncEngine(t *testing.T) {
+	var tests = []struct {
+		name      string
+		engine    string
+		mode      string
+		expectErr bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:   "syncthing",
+			engine: SyncEngineSyncthing,
+		},
+		{
+			name:   "mutagen",
+			engine: SyncEngineMutagen,
+		},
+		{
+			name:      "mutagen in hybrid mode",
+			engine:    SyncEngineMutagen,
+			mode:      constants.OktetoHybridModeFieldValue,
+			expectErr: true,
+		},
+		{
+			name:      "unknown",
+			engine:    "rsync",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{Mode: tt.mode, Sync: Sync{Engine: tt.engine}}
+			err := dev.validateSync()
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_validateServiceAccountTokens(t *testing.T) {
+	short := int64(60)
+	valid := int64(3600)
+	var tests = []struct {
+		name      string
+		tokens    []ServiceAccountToken
+		expectErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			tokens: []ServiceAccountToken{
+				{Audience: "sts.amazonaws.com", Path: "/var/run/secrets/tokens/aws", ExpirationSeconds: &valid},
+				{Path: "/var/run/secrets/tokens/default"},
+			},
+		},
+		{
+			name:      "relative path",
+			tokens:    []ServiceAccountToken{{Path: "tokens/aws"}},
+			expectErr: true,
+		},
+		{
+			name:      "folder path",
+			tokens:    []ServiceAccountToken{{Path: "/var/run/secrets/tokens/"}},
+			expectErr: true,
+		},
+		{
+			name: "duplicated path",
+			tokens: []ServiceAccountToken{
+				{Audience: "a", Path: "/tokens/token"},
+				{Audience: "b", Path: "/tokens/token"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "short expiration",
+			tokens:    []ServiceAccountToken{{Path: "/tokens/token", ExpirationSeconds: &short}},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateServiceAccountTokens(tt.tokens)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDownHooksUnmarshalling(t *testing.T) {
+	manifest, err := Read([]byte(`
+name: deployment
+sync:
+  - .:/app
+down:
+  commands:
+    - redis-cli save
+    - pkill -TERM watcher
+  gracePeriod: 45s`))
+	require.NoError(t, err)
+
+	dev := manifest.Dev["deployment"]
+	require.NotNil(t, dev.Down)
+	assert.Equal(t, []string{"redis-cli save", "pkill -TERM watcher"}, dev.Down.Commands)
+	assert.Equal(t, 45*time.Second, dev.Down.GracePeriod)
+}
+
+func TestReadyProbeUnmarshalling(t *testing.T) {
+	manifest, err := Read([]byte(`
+name: deployment
+sync:
+  - .:/app
+ready:
+  port: 3000
+  timeout: 90s
+  interval: 500ms`))
+	require.NoError(t, err)
+
+	dev := manifest.Dev["deployment"]
+	require.NotNil(t, dev.Ready)
+	assert.Equal(t, 3000, dev.Ready.Port)
+	assert.Equal(t, 90*time.Second, dev.Ready.Timeout)
+	assert.Equal(t, 500*time.Millisecond, dev.Ready.Interval)
+}
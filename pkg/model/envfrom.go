@@ -0,0 +1,115 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// EnvFrom represents a secret or a configmap of the namespace whose keys are injected as environment variables in the development container
+type EnvFrom struct {
+	Secret    string   `json:"secret,omitempty" yaml:"secret,omitempty"`
+	ConfigMap string   `json:"configMap,omitempty" yaml:"configMap,omitempty"`
+	Prefix    string   `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Keys      []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Optional  bool     `json:"optional,omitempty" yaml:"optional,omitempty"`
+}
+
+// GetName returns the name of the secret or configmap referenced by the envFrom entry
+func (e *EnvFrom) GetName() string {
+	if e.Secret != "" {
+		return e.Secret
+	}
+	return e.ConfigMap
+}
+
+// GetKind returns the kind of the object referenced by the envFrom entry
+func (e *EnvFrom) GetKind() string {
+	if e.Secret != "" {
+		return "secret"
+	}
+	return "configmap"
+}
+
+func (e *EnvFrom) validate() error {
+	if (e.Secret == "") == (e.ConfigMap == "") {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'envFrom' entries must define either 'secret' or 'configMap'"),
+			Hint: "Update the 'envFrom' field in your okteto manifest",
+		}
+	}
+	if errs := validation.IsDNS1123Subdomain(e.GetName()); len(errs) > 0 {
+		return fmt.Errorf("'envFrom' %s name '%s' is not valid: %s", e.GetKind(), e.GetName(), strings.Join(errs, ", "))
+	}
+	if e.Prefix != "" {
+		if errs := validation.IsEnvVarName(e.Prefix); len(errs) > 0 {
+			return fmt.Errorf("'envFrom' prefix '%s' is not valid: %s", e.Prefix, strings.Join(errs, ", "))
+		}
+	}
+	seen := map[string]bool{}
+	for _, key := range e.Keys {
+		if errs := validation.IsEnvVarName(e.Prefix + key); len(errs) > 0 {
+			return fmt.Errorf("'envFrom' key '%s' of %s '%s' is not a valid environment variable name: %s", key, e.GetKind(), e.GetName(), strings.Join(errs, ", "))
+		}
+		if seen[key] {
+			return fmt.Errorf("'envFrom' key '%s' of %s '%s' is duplicated", key, e.GetKind(), e.GetName())
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func validateEnvFrom(envFrom []EnvFrom) error {
+	for i := range envFrom {
+		if err := envFrom[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToEnvFromSource returns the kubernetes representation of an envFrom entry without key filtering
+func (e *EnvFrom) ToEnvFromSource() apiv1.EnvFromSource {
+	result := apiv1.EnvFromSource{Prefix: e.Prefix}
+	optional := e.Optional
+	reference := apiv1.LocalObjectReference{Name: e.GetName()}
+	if e.Secret != "" {
+		result.SecretRef = &apiv1.SecretEnvSource{LocalObjectReference: reference, Optional: &optional}
+	} else {
+		result.ConfigMapRef = &apiv1.ConfigMapEnvSource{LocalObjectReference: reference, Optional: &optional}
+	}
+	return result
+}
+
+// ToEnvVars returns the kubernetes environment variables of an envFrom entry that filters its keys
+func (e *EnvFrom) ToEnvVars() []apiv1.EnvVar {
+	result := []apiv1.EnvVar{}
+	reference := apiv1.LocalObjectReference{Name: e.GetName()}
+	for _, key := range e.Keys {
+		optional := e.Optional
+		source := &apiv1.EnvVarSource{}
+		if e.Secret != "" {
+			source.SecretKeyRef = &apiv1.SecretKeySelector{LocalObjectReference: reference, Key: key, Optional: &optional}
+		} else {
+			source.ConfigMapKeyRef = &apiv1.ConfigMapKeySelector{LocalObjectReference: reference, Key: key, Optional: &optional}
+		}
+		result = append(result, apiv1.EnvVar{Name: e.Prefix + key, ValueFrom: source})
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestReadEnvFrom(t *testing.T) {
+	manifest := []byte(`name: deployment
+image: okteto/dev
+envFrom:
+  - secret: db-credentials
+    prefix: DB_
+    keys:
+      - username
+      - password
+  - configMap: app-config
+    optional: true
+services:
+  - name: worker
+    envFrom:
+      - configMap: worker-config
+`)
+	m, err := Read(manifest)
+	require.NoError(t, err)
+	dev := m.Dev["deployment"]
+
+	assert.Equal(t, []EnvFrom{
+		{Secret: "db-credentials", Prefix: "DB_", Keys: []string{"username", "password"}},
+		{ConfigMap: "app-config", Optional: true},
+	}, dev.EnvFrom)
+	assert.Equal(t, []EnvFrom{{ConfigMap: "worker-config"}}, dev.Services[0].EnvFrom)
+	assert.Equal(t, dev.EnvFrom, dev.ToTranslationRule(dev, false).EnvFrom)
+}
+
+func TestEnvFromValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		envFrom EnvFrom
+		wantErr bool
+	}{
+		{
+			name:    "secret",
+			envFrom: EnvFrom{Secret: "db-credentials", Prefix: "DB_", Keys: []string{"username"}},
+		},
+		{
+			name:    "configmap",
+			envFrom: EnvFrom{ConfigMap: "app-config"},
+		},
+		{
+			name:    "empty",
+			envFrom: EnvFrom{},
+			wantErr: true,
+		},
+		{
+			name:    "secret and configmap",
+			envFrom: EnvFrom{Secret: "db-credentials", ConfigMap: "app-config"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			envFrom: EnvFrom{Secret: "DB_credentials"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid prefix",
+			envFrom: EnvFrom{Secret: "db-credentials", Prefix: "1DB"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid key",
+			envFrom: EnvFrom{ConfigMap: "app-config", Keys: []string{"log level"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated key",
+			envFrom: EnvFrom{ConfigMap: "app-config", Keys: []string{"level", "level"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.envFrom.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEnvFromToEnvFromSource(t *testing.T) {
+	optional := true
+	secret := EnvFrom{Secret: "db-credentials", Prefix: "DB_", Optional: true}
+	assert.Equal(t, apiv1.EnvFromSource{
+		Prefix:    "DB_",
+		SecretRef: &apiv1.SecretEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "db-credentials"}, Optional: &optional},
+	}, secret.ToEnvFromSource())
+
+	notOptional := false
+	cm := EnvFrom{ConfigMap: "app-config"}
+	assert.Equal(t, apiv1.EnvFromSource{
+		ConfigMapRef: &apiv1.ConfigMapEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "app-config"}, Optional: &notOptional},
+	}, cm.ToEnvFromSource())
+}
+
+func TestEnvFromToEnvVars(t *testing.T) {
+	optional := false
+	secret := EnvFrom{Secret: "db-credentials", Prefix: "DB_", Keys: []string{"username", "password"}}
+	assert.Equal(t, []apiv1.EnvVar{
+		{
+			Name: "DB_username",
+			ValueFrom: &apiv1.EnvVarSource{
+				SecretKeyRef: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "db-credentials"}, Key: "username", Optional: &optional},
+			},
+		},
+		{
+			Name: "DB_password",
+			ValueFrom: &apiv1.EnvVarSource{
+				SecretKeyRef: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "db-credentials"}, Key: "password", Optional: &optional},
+			},
+		},
+	}, secret.ToEnvVars())
+
+	cm := EnvFrom{ConfigMap: "app-config", Keys: []string{"LEVEL"}}
+	assert.Equal(t, []apiv1.EnvVar{
+		{
+			Name: "LEVEL",
+			ValueFrom: &apiv1.EnvVarSource{
+				ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "app-config"}, Key: "LEVEL", Optional: &optional},
+			},
+		},
+	}, cm.ToEnvVars())
+}
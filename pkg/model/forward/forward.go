@@ -17,16 +17,23 @@ import (
 	"fmt"
 )
 
-const MalformedPortForward = "wrong port-forward syntax '%s', must be of the form 'localPort:remotePort' or 'localPort:serviceName:remotePort'"
+const (
+	MalformedPortForward = "wrong port-forward syntax '%s', must be of the form 'localPort:remotePort' or 'localPort:serviceName:remotePort'"
+
+	// ExposeLAN shares the forward on the local network, protected by an access token
+	ExposeLAN = "lan"
+)
 
 // Forward represents a port forwarding definition
 type Forward struct {
 	Labels      map[string]string `json:"labels" yaml:"labels"`
 	ServiceName string            `json:"name" yaml:"name"`
+	Expose      string            `json:"expose,omitempty" yaml:"expose,omitempty"`
 	Local       int               `json:"localPort" yaml:"localPort"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	Service     bool              `json:"-" yaml:"-"`
 	IsGlobal    bool              `json:"-" yaml:"-"`
+	Inspect     bool              `json:"inspect,omitempty" yaml:"inspect,omitempty"`
 }
 
 func (f Forward) String() string {
@@ -37,6 +44,11 @@ func (f Forward) String() string {
 	return fmt.Sprintf("%d:%d", f.Local, f.Remote)
 }
 
+// IsExposedOnLAN returns true if the forward is shared on the local network
+func (f Forward) IsExposedOnLAN() bool {
+	return f.Expose == ExposeLAN
+}
+
 func (f *Forward) Less(c *Forward) bool {
 	if !f.Service && !c.Service {
 		return f.Local < c.Local
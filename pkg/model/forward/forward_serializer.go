@@ -20,11 +20,13 @@ import (
 )
 
 type Raw struct {
-	Labels      map[string]string `json:"labels" yaml:"labels"`
-	ServiceName string            `json:"name" yaml:"name"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	ServiceName string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Expose      string            `json:"expose,omitempty" yaml:"expose,omitempty"`
 	Local       int               `json:"localPort" yaml:"localPort"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	Service     bool              `json:"-" yaml:"-"`
+	Inspect     bool              `json:"inspect,omitempty" yaml:"inspect,omitempty"`
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg for port forwards.
@@ -75,6 +77,9 @@ func (f *Forward) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (f Forward) MarshalYAML() (interface{}, error) {
+	if f.IsExposedOnLAN() || f.Inspect {
+		return Raw{Labels: f.Labels, ServiceName: f.ServiceName, Expose: f.Expose, Local: f.Local, Remote: f.Remote, Inspect: f.Inspect}, nil
+	}
 	return f.String(), nil
 }
 
@@ -88,6 +93,11 @@ func (f *Forward) UnmarshalExtendedForm(unmarshal func(interface{}) error) error
 	f.Remote = rawForward.Remote
 	f.ServiceName = rawForward.ServiceName
 	f.Labels = rawForward.Labels
+	f.Expose = rawForward.Expose
+	f.Inspect = rawForward.Inspect
+	if f.Expose != "" && f.Expose != ExposeLAN {
+		return fmt.Errorf("invalid value '%s' for 'expose' in port-forward %d: the only supported value is '%s'", f.Expose, f.Local, ExposeLAN)
+	}
 	if len(rawForward.Labels) != 0 || rawForward.ServiceName != "" {
 		f.Service = true
 	}
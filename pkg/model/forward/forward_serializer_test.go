@@ -177,3 +177,65 @@ func TestForwardExtended_MarshalYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestForwardExpose_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expected  Forward
+		expectErr bool
+	}{
+		{
+			name:     "expose-lan",
+			data:     "localPort: 8080\nremotePort: 9090\nexpose: lan",
+			expected: Forward{Local: 8080, Remote: 9090, Expose: ExposeLAN},
+		},
+		{
+			name:     "service-expose-lan",
+			data:     "localPort: 8080\nremotePort: 9090\nname: svc\nexpose: lan",
+			expected: Forward{Local: 8080, Remote: 9090, ServiceName: "svc", Service: true, Expose: ExposeLAN},
+		},
+		{
+			name:     "inspect",
+			data:     "localPort: 8080\nremotePort: 9090\ninspect: true",
+			expected: Forward{Local: 8080, Remote: 9090, Inspect: true},
+		},
+		{
+			name:      "invalid-expose",
+			data:      "localPort: 8080\nremotePort: 9090\nexpose: internet",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Forward
+			err := yaml.Unmarshal([]byte(tt.data), &result)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't got expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("didn't unmarshal correctly. Actual '%+v', Expected '%+v'", result, tt.expected)
+			}
+
+			out, err := yaml.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var roundTrip Forward
+			if err := yaml.Unmarshal(out, &roundTrip); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(roundTrip, tt.expected) {
+				t.Errorf("didn't marshal correctly. Actual '%+v', Expected '%+v'", roundTrip, tt.expected)
+			}
+		})
+	}
+}
@@ -32,8 +32,10 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/helmrelease"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/seed"
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
 	yaml3 "gopkg.in/yaml.v3"
@@ -107,6 +109,13 @@ type Manifest struct {
 	ManifestPath string                   `json:"-" yaml:"-"`
 	Destroy      *DestroyInfo             `json:"destroy,omitempty" yaml:"destroy,omitempty"`
 	Test         ManifestTests            `json:"test,omitempty" yaml:"test,omitempty"`
+	Outputs      ManifestOutputs          `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Seed         seed.Section             `json:"seed,omitempty" yaml:"seed,omitempty"`
+	HelmReleases helmrelease.Section      `json:"helmReleases,omitempty" yaml:"helmReleases,omitempty"`
+	Plugins      *PluginsInfo             `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+
+	SuppressWarnings []string `json:"suppressWarnings,omitempty" yaml:"suppressWarnings,omitempty"`
+	TagStrategy      string   `json:"tagStrategy,omitempty" yaml:"tagStrategy,omitempty"`
 
 	Type          Archetype               `json:"-" yaml:"-"`
 	GlobalForward []forward.GlobalForward `json:"forward,omitempty" yaml:"forward,omitempty"`
@@ -120,6 +129,29 @@ type ManifestDevs map[string]*Dev
 // ManifestTests defines all the test sections
 type ManifestTests map[string]*Test
 
+// ManifestOutputs defines the outputs published by the deploy commands
+type ManifestOutputs map[string]*Output
+
+// Output represents a value published by the deploy commands that is consumable by other tools
+type Output struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Value       string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// PluginsInfo represents the plugin registry of the repository
+type PluginsInfo struct {
+	// Index is the path or url of the plugin index used by 'okteto plugin install'
+	Index string `json:"index,omitempty" yaml:"index,omitempty"`
+}
+
+// GetPluginIndex returns the plugin index declared in the manifest
+func (m *Manifest) GetPluginIndex() string {
+	if m == nil || m.Plugins == nil {
+		return ""
+	}
+	return m.Plugins.Index
+}
+
 // ImageFromManifest is a thunk that returns an image value from a parsed manifest
 // This allows to implement general purpose logic on images without necessarily
 // referencing a specific image, for eg manifest.Deploy.Image or manifest.Destroy.Image
@@ -189,21 +221,101 @@ func NewManifestFromDev(dev *Dev) *Manifest {
 
 // DeployInfo represents what must be deployed for the app to work
 type DeployInfo struct {
-	ComposeSection *ComposeSectionInfo `json:"compose,omitempty" yaml:"compose,omitempty"`
-	Endpoints      EndpointSpec        `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
-	Divert         *DivertDeploy       `json:"divert,omitempty" yaml:"divert,omitempty"`
-	Image          string              `json:"image,omitempty" yaml:"image,omitempty"`
-	Commands       []DeployCommand     `json:"commands,omitempty" yaml:"commands,omitempty"`
-	Remote         bool                `json:"remote,omitempty" yaml:"remote,omitempty"`
+	ComposeSection *ComposeSectionInfo   `json:"compose,omitempty" yaml:"compose,omitempty"`
+	Endpoints      EndpointSpec          `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	Divert         *DivertDeploy         `json:"divert,omitempty" yaml:"divert,omitempty"`
+	Image          string                `json:"image,omitempty" yaml:"image,omitempty"`
+	Commands       []DeployCommand       `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Hooks          *Hooks                `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+	Rollback       string                `json:"rollback,omitempty" yaml:"rollback,omitempty"`
+	Healthchecks   []EndpointHealthcheck `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"`
+	Remote         bool                  `json:"remote,omitempty" yaml:"remote,omitempty"`
+}
+
+// EndpointHealthcheck represents the path probed on an endpoint by 'okteto deploy --wait'
+type EndpointHealthcheck struct {
+	// Endpoint is the name of the endpoint: the first label of its host, with or without the namespace suffix
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// GetHealthcheckPath returns the path probed for an endpoint, and false if the endpoint doesn't declare a healthcheck.
+// The path defaults to "/"
+func (d *DeployInfo) GetHealthcheckPath(endpoint string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	for _, hc := range d.Healthchecks {
+		if hc.Endpoint != endpoint {
+			continue
+		}
+		if hc.Path == "" {
+			return "/", true
+		}
+		return hc.Path, true
+	}
+	return "", false
+}
+
+const (
+	// RollbackAuto rolls back a failed deploy to the state of the last successful deploy
+	RollbackAuto = "auto"
+	// RollbackNever leaves the resources of a failed deploy as they are. It is the default behavior
+	RollbackNever = "never"
+)
+
+// IsRollbackAuto returns if a failed deploy has to be rolled back to the last successful deploy
+func (d *DeployInfo) IsRollbackAuto() bool {
+	return d != nil && d.Rollback == RollbackAuto
 }
 
 // DestroyInfo represents what must be destroyed for the app
 type DestroyInfo struct {
 	Image    string          `json:"image,omitempty" yaml:"image,omitempty"`
 	Commands []DeployCommand `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Hooks    *Hooks          `json:"hooks,omitempty" yaml:"hooks,omitempty"`
 	Remote   bool            `json:"remote,omitempty" yaml:"remote,omitempty"`
 }
 
+// GetPreHooks returns the hooks executed before the destroy commands
+func (d *DestroyInfo) GetPreHooks() []DeployCommand {
+	if d == nil {
+		return nil
+	}
+	return d.Hooks.GetPre()
+}
+
+// GetPostHooks returns the hooks executed once the resources of the dev environment are destroyed
+func (d *DestroyInfo) GetPostHooks() []DeployCommand {
+	if d == nil {
+		return nil
+	}
+	return d.Hooks.GetPost()
+}
+
+// Hooks are the commands executed before and after the main phase of a deploy or a destroy.
+// Deploy hooks run with the deploy commands, locally or remotely. Destroy hooks always run from the main execution of the okteto CLI
+type Hooks struct {
+	Pre  []DeployCommand `json:"pre,omitempty" yaml:"pre,omitempty"`
+	Post []DeployCommand `json:"post,omitempty" yaml:"post,omitempty"`
+}
+
+// GetPre returns the commands executed before the main phase
+func (h *Hooks) GetPre() []DeployCommand {
+	if h == nil {
+		return nil
+	}
+	return h.Pre
+}
+
+// GetPost returns the commands executed after the main phase
+func (h *Hooks) GetPost() []DeployCommand {
+	if h == nil {
+		return nil
+	}
+	return h.Post
+}
+
 // DivertDeploy represents information about the deploy divert configuration
 type DivertDeploy struct {
 	Driver               string                 `json:"driver,omitempty" yaml:"driver,omitempty"`
@@ -330,6 +442,17 @@ func pathExistsAndDir(path string) bool {
 
 // GetManifestV2 gets a manifest from a path or search for the files to generate it
 func GetManifestV2(manifestPath string, fs afero.Fs) (*Manifest, error) {
+	manifest, err := getManifestV2(manifestPath, fs)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		manifest.suppressWarnings()
+	}
+	return manifest, nil
+}
+
+func getManifestV2(manifestPath string, fs afero.Fs) (*Manifest, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, err
@@ -409,6 +532,9 @@ func GetManifestV2(manifestPath string, fs afero.Fs) (*Manifest, error) {
 
 // getManifestFromFile retrieves the manifest from a given file, okteto manifest or docker-compose
 func getManifestFromFile(cwd, manifestPath string, fs afero.Fs) (*Manifest, error) {
+	if IsBakeFile(manifestPath) {
+		return getManifestFromBakeFile(cwd, manifestPath, fs)
+	}
 	devManifest, err := getOktetoManifest(manifestPath)
 	if err != nil {
 		oktetoLog.Info("devManifest err, fallback to stack unmarshall")
@@ -684,7 +810,12 @@ func Read(bytes []byte) (*Manifest, error) {
 					return nil, oktetoErrors.ErrNotManifestContentDetected
 				}
 			}
-			return nil, err
+			// the error might be on a section not used by the running command
+			usedManifest, usedErr := readUsedSections(bytes)
+			if usedErr != nil {
+				return nil, err
+			}
+			manifest = usedManifest
 		}
 	}
 
@@ -711,10 +842,34 @@ func Read(bytes []byte) (*Manifest, error) {
 }
 
 func (m *Manifest) validate() error {
-	if err := m.Build.Validate(); err != nil {
-		return err
+	if isSectionInUse(BuildSection) {
+		if err := m.Build.Validate(); err != nil {
+			return err
+		}
+		if err := build.ValidateTagStrategy(m.TagStrategy); err != nil {
+			return err
+		}
+	}
+	if isSectionInUse(SeedSection) {
+		if err := m.Seed.Validate(); err != nil {
+			return err
+		}
 	}
-	return m.validateDivert()
+	if isSectionInUse(HelmReleasesSection) {
+		if err := m.HelmReleases.Validate(); err != nil {
+			return err
+		}
+	}
+	if isSectionInUse(DeploySection) {
+		if err := m.validateRollback(); err != nil {
+			return err
+		}
+		if err := m.validateHealthchecks(); err != nil {
+			return err
+		}
+		return m.validateDivert()
+	}
+	return nil
 }
 
 func (s *Secret) validate() error {
@@ -771,12 +926,39 @@ func (m *Manifest) SanitizeSvcNames() error {
 	}
 
 	for previousName, newName := range sanitizedServicesNames {
-		oktetoLog.Warning("Service '%s' specified in okteto manifest has been sanitized into '%s'.", previousName, newName)
+		oktetoLog.WarningWithID(oktetoLog.WarnServiceNameSanitized, "Service '%s' specified in okteto manifest has been sanitized into '%s'.", previousName, newName)
 	}
 
 	return nil
 }
 
+func (m *Manifest) validateRollback() error {
+	if m.Deploy == nil {
+		return nil
+	}
+	switch m.Deploy.Rollback {
+	case "", RollbackAuto, RollbackNever:
+		return nil
+	default:
+		return fmt.Errorf("the field 'deploy.rollback' must be '%s' or '%s'", RollbackAuto, RollbackNever)
+	}
+}
+
+func (m *Manifest) validateHealthchecks() error {
+	if m.Deploy == nil {
+		return nil
+	}
+	for _, hc := range m.Deploy.Healthchecks {
+		if hc.Endpoint == "" {
+			return fmt.Errorf("the field 'deploy.healthchecks.endpoint' is required")
+		}
+		if hc.Path != "" && !strings.HasPrefix(hc.Path, "/") {
+			return fmt.Errorf("the healthcheck path '%s' of endpoint '%s' must start with '/'", hc.Path, hc.Endpoint)
+		}
+	}
+	return nil
+}
+
 func (m *Manifest) validateDivert() error {
 	if m.Deploy == nil {
 		return nil
@@ -850,37 +1032,12 @@ func (m *Manifest) setDefaults() error {
 		}
 	}
 	for dName, d := range m.Dev {
-		if d.Name == "" {
-			d.Name = dName
-		}
-		if err := d.expandEnvVars(); err != nil {
-			return fmt.Errorf("error on dev '%s': %w", d.Name, err)
-		}
-		for _, s := range d.Services {
-			if err := s.expandEnvVars(); err != nil {
-				return fmt.Errorf("error on dev '%s': %w", d.Name, err)
-			}
-			if err := s.validateForExtraFields(); err != nil {
-				return fmt.Errorf("error on dev '%s': %w", d.Name, err)
+		if err := setDevDefaults(dName, d); err != nil {
+			if isSectionInUse(DevSection) {
+				return err
 			}
-		}
-
-		if err := d.SetDefaults(); err != nil {
-			return fmt.Errorf("error on dev '%s': %w", d.Name, err)
-		}
-
-		d.translateDeprecatedMetadataFields()
-
-		sort.SliceStable(d.Forward, func(i, j int) bool {
-			return d.Forward[i].Less(&d.Forward[j])
-		})
-
-		sort.SliceStable(d.Reverse, func(i, j int) bool {
-			return d.Reverse[i].Local < d.Reverse[j].Local
-		})
-
-		if err := d.translateDeprecatedVolumeFields(); err != nil {
-			return err
+			oktetoLog.Warning("The dev container '%s' of your okteto manifest is ignored because it's not valid: %s", dName, err)
+			delete(m.Dev, dName)
 		}
 	}
 
@@ -905,10 +1062,57 @@ func (m *Manifest) setDefaults() error {
 	return nil
 }
 
+func setDevDefaults(dName string, d *Dev) error {
+	if d.Name == "" {
+		d.Name = dName
+	}
+	if err := d.expandEnvVars(); err != nil {
+		return fmt.Errorf("error on dev '%s': %w", d.Name, err)
+	}
+	for _, s := range d.Services {
+		if err := s.expandEnvVars(); err != nil {
+			return fmt.Errorf("error on dev '%s': %w", d.Name, err)
+		}
+		if err := s.validateForExtraFields(); err != nil {
+			return fmt.Errorf("error on dev '%s': %w", d.Name, err)
+		}
+	}
+
+	if err := d.SetDefaults(); err != nil {
+		return fmt.Errorf("error on dev '%s': %w", d.Name, err)
+	}
+
+	d.translateDeprecatedMetadataFields()
+
+	sort.SliceStable(d.Forward, func(i, j int) bool {
+		return d.Forward[i].Less(&d.Forward[j])
+	})
+
+	sort.SliceStable(d.Reverse, func(i, j int) bool {
+		return d.Reverse[i].Local < d.Reverse[j].Local
+	})
+
+	return d.translateDeprecatedVolumeFields()
+}
+
 func (m *Manifest) mergeWithOktetoManifest(other *Manifest) {
 	if len(m.Dev) == 0 && len(other.Dev) != 0 {
 		m.Dev = other.Dev
 	}
+	if len(m.SuppressWarnings) == 0 && len(other.SuppressWarnings) != 0 {
+		m.SuppressWarnings = other.SuppressWarnings
+	}
+}
+
+// suppressWarnings prevents the warnings listed in 'suppressWarnings' from being shown
+func (m *Manifest) suppressWarnings() {
+	if len(m.SuppressWarnings) == 0 {
+		return
+	}
+	if err := oktetoLog.ValidateWarningIDs(m.SuppressWarnings); err != nil {
+		oktetoLog.Warning("Invalid 'suppressWarnings' in your okteto manifest: %s", err)
+	}
+	oktetoLog.SuppressWarnings(m.SuppressWarnings...)
 }
 
 // ExpandEnvVars expands env vars to be set on the manifest
@@ -1338,6 +1542,28 @@ func (m *Manifest) HasDependencies() bool {
 	return len(m.Dependencies) > 0
 }
 
+// CheckStrictVars returns an error when the manifest references variables that are not defined
+// or when any of the provided variables is not referenced by the manifest
+func (m *Manifest) CheckStrictVars(provided []string) error {
+	if m.Type != OktetoManifestType || len(m.Manifest) == 0 {
+		oktetoLog.Warning("variables are only validated for okteto manifests, skipping strict variables validation")
+		return nil
+	}
+	isDefined := func(name string) bool {
+		_, ok := os.LookupEnv(name)
+		return ok
+	}
+	err := env.CheckStrict(m.Manifest, m.ManifestPath, provided, isDefined)
+	var strictErr env.StrictVarsError
+	if errors.As(err, &strictErr) {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Set the undefined variables using '--var' or your environment, use '${VAR:-default}' to provide a default value, and remove the variables that are not used",
+		}
+	}
+	return err
+}
+
 // HasDev checks if manifestDevs has a dev name as key
 func (d ManifestDevs) HasDev(name string) bool {
 	_, ok := d[name]
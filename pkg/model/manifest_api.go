@@ -0,0 +1,227 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// This file contains the stable API to load, validate, mutate and serialize okteto manifests
+// from Go code, so tools can generate manifests programmatically instead of templating yaml.
+
+// LoadOptions configures how Load reads an okteto manifest
+type LoadOptions struct {
+	// Fs is the filesystem the manifest is read from. Defaults to the OS filesystem
+	Fs afero.Fs
+	// Path is the path to the okteto manifest file
+	Path string
+}
+
+// LoadError is returned when an okteto manifest can't be read or parsed
+type LoadError struct {
+	Err  error
+	Path string
+}
+
+// Error returns the error message including the path of the manifest
+func (e *LoadError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("failed to load okteto manifest: %s", e.Err)
+	}
+	return fmt.Sprintf("failed to load okteto manifest '%s': %s", e.Path, e.Err)
+}
+
+// Unwrap returns the cause of the error
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when a field of an okteto manifest is not valid
+type ValidationError struct {
+	Err error
+	// Field is the path of the invalid field, like 'build' or 'dev.api'
+	Field string
+}
+
+// Error returns the error message including the invalid field
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid okteto manifest field '%s': %s", e.Field, e.Err)
+}
+
+// Unwrap returns the cause of the error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Load reads, parses and validates the okteto manifest defined in opts.
+// Environment variables referenced by the manifest are expanded from the current process environment
+func Load(opts LoadOptions) (*Manifest, error) {
+	fs := opts.Fs
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	content, err := afero.ReadFile(fs, opts.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, &LoadError{Path: opts.Path, Err: discovery.ErrOktetoManifestNotFound}
+		}
+		return nil, &LoadError{Path: opts.Path, Err: err}
+	}
+
+	m, err := Parse(content)
+	if err != nil {
+		var loadErr *LoadError
+		if errors.As(err, &loadErr) {
+			loadErr.Path = opts.Path
+		}
+		return nil, err
+	}
+	m.ManifestPath = opts.Path
+	m.Fs = fs
+	return m, nil
+}
+
+// Parse parses and validates the content of an okteto manifest
+func Parse(content []byte) (*Manifest, error) {
+	if isEmptyManifestFile(content) {
+		return nil, &LoadError{Err: oktetoErrors.ErrEmptyManifest}
+	}
+
+	m, err := Read(content)
+	if err != nil {
+		return nil, &LoadError{Err: err}
+	}
+	for name, external := range m.External {
+		external.SetDefaults(name)
+	}
+	return m, nil
+}
+
+// Validate checks that a manifest created or modified programmatically is valid.
+// It returns a ValidationError pointing to the first invalid field
+func (m *Manifest) Validate() error {
+	if err := m.Build.Validate(); err != nil {
+		return &ValidationError{Field: "build", Err: err}
+	}
+	if err := m.validateDivert(); err != nil {
+		return &ValidationError{Field: "deploy.divert", Err: err}
+	}
+	for name := range m.Build {
+		if err := validateManifestKey(name); err != nil {
+			return &ValidationError{Field: fmt.Sprintf("build.%s", name), Err: err}
+		}
+	}
+	for name, dev := range m.Dev {
+		if err := validateManifestKey(name); err != nil {
+			return &ValidationError{Field: fmt.Sprintf("dev.%s", name), Err: err}
+		}
+		if dev == nil {
+			return &ValidationError{Field: fmt.Sprintf("dev.%s", name), Err: errors.New("development container can't be empty")}
+		}
+		for _, service := range dev.Services {
+			if err := service.validateForExtraFields(); err != nil {
+				return &ValidationError{Field: fmt.Sprintf("dev.%s.services", name), Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// SetBuild adds or replaces the image named name in the build section
+func (m *Manifest) SetBuild(name string, info *build.Info) error {
+	if err := validateManifestKey(name); err != nil {
+		return &ValidationError{Field: fmt.Sprintf("build.%s", name), Err: err}
+	}
+	if m.Build == nil {
+		m.Build = build.ManifestBuild{}
+	}
+	info.SetBuildDefaults()
+	m.Build[name] = info
+	return nil
+}
+
+// SetDev adds or replaces the development container named name in the dev section
+func (m *Manifest) SetDev(name string, dev *Dev) error {
+	if err := validateManifestKey(name); err != nil {
+		return &ValidationError{Field: fmt.Sprintf("dev.%s", name), Err: err}
+	}
+	if m.Dev == nil {
+		m.Dev = ManifestDevs{}
+	}
+	dev.Name = name
+	if err := dev.SetDefaults(); err != nil {
+		return &ValidationError{Field: fmt.Sprintf("dev.%s", name), Err: err}
+	}
+	m.Dev[name] = dev
+	return nil
+}
+
+// AddDeployCommand appends a command to the deploy section
+func (m *Manifest) AddDeployCommand(name, command string) error {
+	if strings.TrimSpace(command) == "" {
+		return &ValidationError{Field: "deploy.commands", Err: errors.New("command can't be empty")}
+	}
+	if m.Deploy == nil {
+		m.Deploy = NewDeployInfo()
+	}
+	if name == "" {
+		name = command
+	}
+	m.Deploy.Commands = append(m.Deploy.Commands, DeployCommand{Name: name, Command: command})
+	return nil
+}
+
+// Marshal returns the yaml representation of the manifest, leaving out the values set by default
+// when a manifest is loaded. The manifest is not modified
+func (m *Manifest) Marshal() ([]byte, error) {
+	out := *m
+	if m.Dev != nil {
+		out.Dev = make(ManifestDevs, len(m.Dev))
+		for name, d := range m.Dev {
+			dev := *d
+			dev.Name = ""
+			dev.Namespace = ""
+			dev.Context = ""
+			dev.Image = marshalableImage(d.Image)
+			dev.Push = marshalableImage(d.Push)
+			out.Dev[name] = &dev
+		}
+	}
+	return yaml.Marshal(out)
+}
+
+// marshalableImage returns the image reference without the build defaults, or nil if there is no image name
+func marshalableImage(info *build.Info) *build.Info {
+	if info == nil || info.Name == "" {
+		return nil
+	}
+	return &build.Info{Name: info.Name}
+}
+
+func validateManifestKey(name string) error {
+	if name == "" || ValidKubeNameRegex.MatchString(name) || strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+		return errBadName
+	}
+	return nil
+}
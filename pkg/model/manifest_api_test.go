@@ -0,0 +1,150 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/discovery"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "okteto.yml", []byte(`build:
+  api:
+    context: api
+deploy:
+  - kubectl apply -f k8s.yml
+dev:
+  api:
+    image: okteto/golang:1
+    command: bash
+`), 0600))
+	require.NoError(t, afero.WriteFile(fs, "empty.yml", []byte(""), 0600))
+	require.NoError(t, afero.WriteFile(fs, "invalid.yml", []byte("dev: ["), 0600))
+
+	m, err := Load(LoadOptions{Fs: fs, Path: "okteto.yml"})
+	require.NoError(t, err)
+	assert.Equal(t, "okteto.yml", m.ManifestPath)
+	assert.Equal(t, "api", m.Build["api"].Context)
+	assert.Equal(t, "okteto/golang:1", m.Dev["api"].Image.Name)
+	require.Len(t, m.Deploy.Commands, 1)
+
+	_, err = Load(LoadOptions{Fs: fs, Path: "not-found.yml"})
+	var loadErr *LoadError
+	require.ErrorAs(t, err, &loadErr)
+	assert.Equal(t, "not-found.yml", loadErr.Path)
+	assert.ErrorIs(t, err, discovery.ErrOktetoManifestNotFound)
+
+	_, err = Load(LoadOptions{Fs: fs, Path: "empty.yml"})
+	assert.ErrorIs(t, err, oktetoErrors.ErrEmptyManifest)
+
+	_, err = Load(LoadOptions{Fs: fs, Path: "invalid.yml"})
+	require.ErrorAs(t, err, &loadErr)
+	assert.Equal(t, "invalid.yml", loadErr.Path)
+}
+
+func TestManifestValidate(t *testing.T) {
+	tests := []struct {
+		manifest      *Manifest
+		name          string
+		expectedField string
+	}{
+		{
+			name: "valid manifest",
+			manifest: &Manifest{
+				Build: build.ManifestBuild{"api": {Context: "api"}},
+				Dev:   ManifestDevs{"api": {}},
+			},
+		},
+		{
+			name: "invalid build name",
+			manifest: &Manifest{
+				Build: build.ManifestBuild{"API": {Context: "api"}},
+			},
+			expectedField: "build.API",
+		},
+		{
+			name: "invalid dev name",
+			manifest: &Manifest{
+				Dev: ManifestDevs{"api-": {}},
+			},
+			expectedField: "dev.api-",
+		},
+		{
+			name: "empty dev",
+			manifest: &Manifest{
+				Dev: ManifestDevs{"api": nil},
+			},
+			expectedField: "dev.api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if tt.expectedField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.expectedField, validationErr.Field)
+		})
+	}
+}
+
+func TestManifestMutators(t *testing.T) {
+	m := NewManifest()
+
+	require.NoError(t, m.SetBuild("api", &build.Info{Context: "api"}))
+	assert.Equal(t, "Dockerfile", m.Build["api"].Dockerfile)
+
+	dev := &Dev{Image: &build.Info{Name: "okteto/golang:1"}}
+	require.NoError(t, m.SetDev("api", dev))
+	assert.Equal(t, "api", m.Dev["api"].Name)
+
+	require.NoError(t, m.AddDeployCommand("", "kubectl apply -f k8s.yml"))
+	assert.Equal(t, []DeployCommand{{Name: "kubectl apply -f k8s.yml", Command: "kubectl apply -f k8s.yml"}}, m.Deploy.Commands)
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, m.SetDev("Invalid", &Dev{}), &validationErr)
+	assert.ErrorAs(t, m.SetBuild("", &build.Info{}), &validationErr)
+	assert.ErrorAs(t, m.AddDeployCommand("empty", " "), &validationErr)
+	assert.NoError(t, m.Validate())
+}
+
+func TestManifestMarshal(t *testing.T) {
+	m := NewManifest()
+	require.NoError(t, m.SetBuild("api", &build.Info{Context: "api"}))
+	require.NoError(t, m.SetDev("api", &Dev{Image: &build.Info{Name: "okteto/golang:1"}, Workdir: "/usr/src/app"}))
+	require.NoError(t, m.AddDeployCommand("deploy", "kubectl apply -f k8s.yml"))
+
+	content, err := m.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, "api", m.Dev["api"].Name, "marshal must not modify the manifest")
+
+	parsed, err := Parse(content)
+	require.NoError(t, err)
+	assert.Equal(t, "api", parsed.Build["api"].Context)
+	assert.Equal(t, "okteto/golang:1", parsed.Dev["api"].Image.Name)
+	assert.Equal(t, "/usr/src/app", parsed.Dev["api"].Workdir)
+	assert.Equal(t, m.Deploy.Commands, parsed.Deploy.Commands)
+}
@@ -336,6 +336,113 @@ func Test_validateDivert(t *testing.T) {
 	}
 }
 
+func Test_validateRollback(t *testing.T) {
+	tests := []struct {
+		deploy      *DeployInfo
+		name        string
+		expectedErr bool
+	}{
+		{
+			name: "no deploy section",
+		},
+		{
+			name:   "default",
+			deploy: &DeployInfo{},
+		},
+		{
+			name:   "auto",
+			deploy: &DeployInfo{Rollback: RollbackAuto},
+		},
+		{
+			name:   "never",
+			deploy: &DeployInfo{Rollback: RollbackNever},
+		},
+		{
+			name:        "invalid",
+			deploy:      &DeployInfo{Rollback: "always"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{
+				Deploy: tt.deploy,
+			}
+			err := m.validateRollback()
+			if tt.expectedErr {
+				assert.ErrorContains(t, err, "deploy.rollback")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_validateHealthchecks(t *testing.T) {
+	tests := []struct {
+		deploy      *DeployInfo
+		name        string
+		expectedErr string
+	}{
+		{
+			name: "no deploy section",
+		},
+		{
+			name: "valid",
+			deploy: &DeployInfo{Healthchecks: []EndpointHealthcheck{
+				{Endpoint: "api", Path: "/healthz"},
+				{Endpoint: "frontend"},
+			}},
+		},
+		{
+			name:        "missing endpoint",
+			deploy:      &DeployInfo{Healthchecks: []EndpointHealthcheck{{Path: "/healthz"}}},
+			expectedErr: "deploy.healthchecks.endpoint",
+		},
+		{
+			name:        "relative path",
+			deploy:      &DeployInfo{Healthchecks: []EndpointHealthcheck{{Endpoint: "api", Path: "healthz"}}},
+			expectedErr: "must start with '/'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{
+				Deploy: tt.deploy,
+			}
+			err := m.validateHealthchecks()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_GetHealthcheckPath(t *testing.T) {
+	d := &DeployInfo{Healthchecks: []EndpointHealthcheck{
+		{Endpoint: "api", Path: "/healthz"},
+		{Endpoint: "frontend"},
+	}}
+	path, ok := d.GetHealthcheckPath("api")
+	assert.True(t, ok)
+	assert.Equal(t, "/healthz", path)
+
+	path, ok = d.GetHealthcheckPath("frontend")
+	assert.True(t, ok)
+	assert.Equal(t, "/", path)
+
+	_, ok = d.GetHealthcheckPath("other")
+	assert.False(t, ok)
+
+	var nilDeploy *DeployInfo
+	_, ok = nilDeploy.GetHealthcheckPath("api")
+	assert.False(t, ok)
+}
+
 func Test_validateManifestBuild(t *testing.T) {
 	tests := []struct {
 		buildSection build.ManifestBuild
@@ -2060,3 +2167,59 @@ func TestGetBuildContextForComposeWithVolumeMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestReadSuppressWarnings(t *testing.T) {
+	manifest, err := Read([]byte(`suppressWarnings:
+  - W010
+  - W003
+deploy:
+  - okteto build`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"W010", "W003"}, manifest.SuppressWarnings)
+}
+
+func TestReadTagStrategy(t *testing.T) {
+	manifest, err := Read([]byte(`tagStrategy: git-sha
+deploy:
+  - okteto build`))
+	assert.NoError(t, err)
+	assert.Equal(t, build.TagStrategyGitSHA, manifest.TagStrategy)
+
+	_, err = Read([]byte(`tagStrategy: latest
+deploy:
+  - okteto build`))
+	assert.Error(t, err)
+}
+
+func TestCheckStrictVars(t *testing.T) {
+	t.Setenv("STRICT_DEFINED_IMAGE", "alpine")
+	content := []byte(`deploy:
+  - kubectl apply -f $K8S_FILE
+dev:
+  api:
+    image: $STRICT_DEFINED_IMAGE
+    workdir: ${STRICT_WORKDIR}
+`)
+
+	t.Run("not an okteto manifest", func(t *testing.T) {
+		m := &Manifest{Type: StackType, Manifest: content}
+		assert.NoError(t, m.CheckStrictVars([]string{"UNUSED=value"}))
+	})
+
+	t.Run("undefined and unused variables", func(t *testing.T) {
+		m := &Manifest{Type: OktetoManifestType, Manifest: content, ManifestPath: "okteto.yml"}
+		err := m.CheckStrictVars([]string{"K8S_FILE=k8s.yml", "UNUSED=value"})
+		var userErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &userErr)
+		assert.Equal(t, env.StrictVarsError{
+			File:      "okteto.yml",
+			Undefined: []env.Reference{{Name: "STRICT_WORKDIR", Line: 6, Column: 14}},
+			Unused:    []string{"UNUSED"},
+		}, userErr.E)
+	})
+
+	t.Run("all variables defined and used", func(t *testing.T) {
+		m := &Manifest{Type: OktetoManifestType, Manifest: content, ManifestPath: "okteto.yml"}
+		assert.NoError(t, m.CheckStrictVars([]string{"K8S_FILE=k8s.yml", "STRICT_WORKDIR=/app"}))
+	})
+}
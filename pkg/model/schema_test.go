@@ -182,39 +182,48 @@ func Test_getStructKeys(t *testing.T) {
 			name:  "okteto manifest",
 			input: Manifest{},
 			expected: map[string][]string{
-				"deps.Dependency":            {"repository", "manifest", "branch", "namespace", "variables", "timeout", "wait"},
-				"env.Var":                    {"name", "value"},
-				"forward.Forward":            {"labels", "name", "localPort", "remotePort"},
-				"forward.GlobalForward":      {"labels", "name", "localPort", "remotePort"},
-				"build.Info":                 {"secrets", "name", "context", "dockerfile", "target", "image", "cache_from", "args", "export_cache", "depends_on"},
-				"build.VolumeMounts":         {"local_path", "remote_path"},
-				"model.Capabilities":         {"add", "drop"},
-				"model.ComposeInfo":          {"file", "services"},
-				"model.DeployCommand":        {"name", "command"},
-				"model.DeployInfo":           {"compose", "endpoints", "divert", "image", "commands", "remote"},
-				"model.DestroyInfo":          {"image", "commands", "remote"},
-				"model.Dev":                  {"resources", "selector", "persistentVolume", "securityContext", "annotations", "labels", "probes", "nodeSelector", "metadata", "affinity", "image", "push", "lifecycle", "replicas", "initContainer", "workdir", "name", "context", "namespace", "container", "serviceAccount", "interface", "mode", "imagePullPolicy", "tolerations", "command", "forward", "reverse", "externalVolumes", "secrets", "volumes", "envFiles", "environment", "services", "args", "sync", "timeout", "remote", "sshServerPort", "initFromImage", "autocreate", "healthchecks"},
-				"model.DivertDeploy":         {"driver", "namespace", "service", "deployment", "virtualServices", "hosts", "port"},
-				"model.DivertHost":           {"virtualService", "namespace"},
-				"model.DivertVirtualService": {"name", "namespace", "routes"},
-				"model.HTTPHealtcheck":       {"path", "port"},
-				"model.HealthCheck":          {"http", "test", "interval", "timeout", "retries", "start_period", "disable", "x-okteto-liveness", "x-okteto-readiness"},
-				"model.InitContainer":        {"resources", "image"},
-				"model.Lifecycle":            {"postStart", "postStop"},
-				"model.Manifest":             {"name", "namespace", "context", "icon", "dev", "build", "deploy", "destroy", "dependencies", "external", "forward", "test"},
-				"model.Metadata":             {"labels", "annotations"},
-				"model.PersistentVolumeInfo": {"storageClass", "size", "enabled"},
-				"model.Probes":               {"liveness", "readiness", "startup"},
-				"model.ResourceRequirements": {"limits", "requests"},
-				"model.SecurityContext":      {"runAsUser", "runAsGroup", "fsGroup", "capabilities", "runAsNonRoot", "allowPrivilegeEscalation"},
-				"model.Service":              {"healthcheck", "labels", "resources", "x-node-selector", "user", "depends_on", "build", "workdir", "image", "restart", "environment", "ports", "volumes", "cap_add", "cap_drop", "env_file", "command", "annotations", "entrypoint", "stop_grace_period", "replicas", "max_attempts", "public"},
-				"model.Stack":                {"volumes", "services", "endpoints", "name", "namespace", "context"},
-				"model.StackSecurityContext": {"runAsUser", "runAsGroup"},
-				"model.StorageResource":      {"size", "class"},
-				"model.Sync":                 {"folders", "rescanInterval", "compression", "verbose"},
-				"model.Timeout":              {"default", "resources"},
-				"model.VolumeSpec":           {"labels", "annotations", "size", "class"},
-				"model.Test":                 {"image", "context", "commands", "depends_on", "caches", "artifacts"},
+				"deps.Dependency":                   {"repository", "manifest", "branch", "namespace", "variables", "timeout", "wait", "schedule", "waitFor"},
+				"env.Var":                           {"name", "value"},
+				"forward.Forward":                   {"labels", "name", "expose", "localPort", "remotePort", "inspect"},
+				"forward.GlobalForward":             {"labels", "name", "localPort", "remotePort"},
+				"build.CustomBuilder":               {"type", "image", "path"},
+				"build.Info":                        {"secrets", "name", "context", "dockerfile", "dockerfile_inline", "target", "image", "cache_from", "args", "export_cache", "depends_on", "ssh", "reproducible", "test_target", "test_results", "platforms", "sign", "sign_key", "ignore", "builder"},
+				"build.VolumeMounts":                {"local_path", "remote_path"},
+				"model.Capabilities":                {"add", "drop"},
+				"model.ComposeInfo":                 {"file", "services"},
+				"model.DeployCommand":               {"name", "command"},
+				"model.DeployInfo":                  {"compose", "endpoints", "divert", "image", "commands", "hooks", "rollback", "healthchecks", "remote"},
+				"model.EndpointHealthcheck":         {"endpoint", "path"},
+				"model.DestroyInfo":                 {"image", "commands", "hooks", "remote"},
+				"model.Hooks":                       {"pre", "post"},
+				"model.Dev":                         {"resources", "selector", "persistentVolume", "securityContext", "annotations", "labels", "probes", "nodeSelector", "metadata", "affinity", "image", "push", "lifecycle", "replicas", "initContainer", "workdir", "name", "context", "namespace", "container", "serviceAccount", "interface", "mode", "activation", "imagePullPolicy", "tolerations", "command", "forward", "reverse", "externalVolumes", "secrets", "serviceAccountTokens", "volumes", "envFiles", "environment", "envFrom", "envRequired", "services", "args", "sync", "timeout", "remote", "sshServerPort", "initFromImage", "autocreate", "x11", "clipboard", "prefetch", "healthchecks", "persistentSession", "down", "ready"},
+				"model.DivertDeploy":                {"driver", "namespace", "service", "deployment", "virtualServices", "hosts", "port"},
+				"model.DownHooks":                   {"commands", "gracePeriod"},
+				"model.DivertHost":                  {"virtualService", "namespace"},
+				"model.DivertVirtualService":        {"name", "namespace", "routes"},
+				"model.HTTPHealtcheck":              {"path", "port"},
+				"model.HealthCheck":                 {"http", "test", "interval", "timeout", "retries", "start_period", "disable", "x-okteto-liveness", "x-okteto-readiness"},
+				"model.InitContainer":               {"resources", "image"},
+				"model.Lifecycle":                   {"postStart", "postStop"},
+				"model.Manifest":                    {"name", "namespace", "context", "icon", "dev", "build", "deploy", "destroy", "dependencies", "external", "forward", "test", "outputs", "seed", "helmReleases", "plugins", "suppressWarnings", "tagStrategy"},
+				"model.PluginsInfo":                 {"index"},
+				"model.Metadata":                    {"labels", "annotations"},
+				"model.Output":                      {"description", "value"},
+				"model.PersistentVolumeInfo":        {"storageClass", "size", "claimName", "accessModes", "prepopulate", "enabled"},
+				"model.PersistentVolumePrepopulate": {"image", "url", "downloadImage"},
+				"model.Probes":                      {"liveness", "readiness", "startup"},
+				"model.ReadyProbe":                  {"command", "port", "timeout", "interval"},
+				"model.ResourceRequirements":        {"limits", "requests"},
+				"model.SecurityContext":             {"runAsUser", "runAsGroup", "fsGroup", "capabilities", "runAsNonRoot", "allowPrivilegeEscalation"},
+				"model.Service":                     {"healthcheck", "labels", "resources", "x-node-selector", "user", "depends_on", "build", "workdir", "image", "restart", "environment", "ports", "volumes", "cap_add", "cap_drop", "env_file", "command", "annotations", "entrypoint", "stop_grace_period", "replicas", "max_attempts", "public"},
+				"model.ServiceAccountToken":         {"expirationSeconds", "audience", "path"},
+				"model.Stack":                       {"volumes", "services", "endpoints", "name", "namespace", "context"},
+				"model.StackSecurityContext":        {"runAsUser", "runAsGroup"},
+				"model.StorageResource":             {"size", "class"},
+				"model.Sync":                        {"engine", "folders", "rescanInterval", "compression", "verbose"},
+				"model.Timeout":                     {"default", "resources"},
+				"model.VolumeSpec":                  {"labels", "annotations", "size", "class"},
+				"model.Test":                        {"image", "context", "commands", "depends_on", "caches", "artifacts"},
 			},
 		},
 	}
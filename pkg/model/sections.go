@@ -0,0 +1,143 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// BuildSection is the 'build' section of the okteto manifest
+	BuildSection = "build"
+	// DeploySection is the 'deploy' section of the okteto manifest
+	DeploySection = "deploy"
+	// DestroySection is the 'destroy' section of the okteto manifest
+	DestroySection = "destroy"
+	// DevSection is the 'dev' section of the okteto manifest
+	DevSection = "dev"
+	// TestSection is the 'test' section of the okteto manifest
+	TestSection = "test"
+	// DependenciesSection is the 'dependencies' section of the okteto manifest
+	DependenciesSection = "dependencies"
+	// ExternalSection is the 'external' section of the okteto manifest
+	ExternalSection = "external"
+	// ForwardSection is the 'forward' section of the okteto manifest
+	ForwardSection = "forward"
+	// OutputsSection is the 'outputs' section of the okteto manifest
+	OutputsSection = "outputs"
+	// SeedSection is the 'seed' section of the okteto manifest
+	SeedSection = "seed"
+	// HelmReleasesSection is the 'helmReleases' section of the okteto manifest
+	HelmReleasesSection = "helmReleases"
+	// PluginsSection is the 'plugins' section of the okteto manifest
+	PluginsSection = "plugins"
+)
+
+// skippableSections are the sections that can be ignored when the running command doesn't use them.
+// The rest of the fields (name, namespace, context...) are always parsed
+var skippableSections = map[string]bool{
+	BuildSection:        true,
+	DeploySection:       true,
+	DestroySection:      true,
+	DevSection:          true,
+	TestSection:         true,
+	DependenciesSection: true,
+	ExternalSection:     true,
+	ForwardSection:      true,
+	OutputsSection:      true,
+	SeedSection:         true,
+	HelmReleasesSection: true,
+	PluginsSection:      true,
+}
+
+var (
+	// sectionsInUse are the sections of the okteto manifest used by the running command. Empty means all of them
+	sectionsInUse = map[string]bool{}
+
+	// validateAllSections forces parsing and validating every section of the okteto manifest
+	validateAllSections bool
+)
+
+// UseSections declares the sections of the okteto manifest used by the running command.
+// Errors on the other sections are shown as warnings instead of failing the command
+func UseSections(sections ...string) {
+	sectionsInUse = map[string]bool{}
+	for _, s := range sections {
+		sectionsInUse[s] = true
+	}
+}
+
+// SetValidateAllSections parses and validates every section of the okteto manifest, regardless of the sections in use
+func SetValidateAllSections(value bool) {
+	validateAllSections = value
+}
+
+// isSectionInUse returns true if the section of the okteto manifest has to be fully parsed and validated
+func isSectionInUse(section string) bool {
+	if validateAllSections || len(sectionsInUse) == 0 {
+		return true
+	}
+	if !skippableSections[section] {
+		return true
+	}
+	return sectionsInUse[section]
+}
+
+// readUsedSections parses an okteto manifest ignoring the sections not used by the running command that have errors
+func readUsedSections(bytes []byte) (*Manifest, error) {
+	if validateAllSections || len(sectionsInUse) == 0 {
+		return nil, fmt.Errorf("all the sections of the okteto manifest are in use")
+	}
+
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal(bytes, &doc); err != nil {
+		return nil, err
+	}
+
+	used := yaml.MapSlice{}
+	for _, item := range doc {
+		key, ok := item.Key.(string)
+		if !ok || isSectionInUse(key) {
+			used = append(used, item)
+			continue
+		}
+		if err := validateSectionSyntax(item); err != nil {
+			oktetoLog.Warning("The '%s' section of your okteto manifest is ignored because it's not valid: %s", key, err)
+			continue
+		}
+		used = append(used, item)
+	}
+
+	usedBytes, err := yaml.Marshal(used)
+	if err != nil {
+		return nil, err
+	}
+	manifest := NewManifest()
+	if err := yaml.UnmarshalStrict(usedBytes, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// validateSectionSyntax parses a single section of the okteto manifest
+func validateSectionSyntax(section yaml.MapItem) error {
+	b, err := yaml.Marshal(yaml.MapSlice{section})
+	if err != nil {
+		return err
+	}
+	return yaml.UnmarshalStrict(b, NewManifest())
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useSectionsForTest(t *testing.T, validateAll bool, sections ...string) {
+	t.Helper()
+	UseSections(sections...)
+	SetValidateAllSections(validateAll)
+	t.Cleanup(func() {
+		UseSections()
+		SetValidateAllSections(false)
+	})
+}
+
+func TestReadIgnoresUnusedSections(t *testing.T) {
+	manifest := []byte(`build:
+  api:
+    context: api
+dev:
+  api:
+    command: bash
+    unknownField: value
+`)
+	tests := []struct {
+		name        string
+		sections    []string
+		validateAll bool
+		expectErr   bool
+	}{
+		{
+			name:      "all sections in use",
+			expectErr: true,
+		},
+		{
+			name:     "only build in use",
+			sections: []string{BuildSection},
+		},
+		{
+			name:        "only build in use with validate all",
+			sections:    []string{BuildSection},
+			validateAll: true,
+			expectErr:   true,
+		},
+		{
+			name:      "dev in use",
+			sections:  []string{BuildSection, DevSection},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useSectionsForTest(t, tt.validateAll, tt.sections...)
+			m, err := Read(manifest)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, m.Build, "api")
+			assert.Empty(t, m.Dev)
+		})
+	}
+}
+
+func TestReadValidatesUsedSections(t *testing.T) {
+	manifest := []byte(`build:
+  api:
+    context: api
+    test_results: /results
+dev:
+  api:
+    command: bash
+`)
+	useSectionsForTest(t, false, DevSection)
+	_, err := Read(manifest)
+	require.NoError(t, err)
+
+	useSectionsForTest(t, false, BuildSection)
+	_, err = Read(manifest)
+	require.Error(t, err)
+}
+
+func TestIsSectionInUse(t *testing.T) {
+	useSectionsForTest(t, false, BuildSection)
+	assert.True(t, isSectionInUse(BuildSection))
+	assert.False(t, isSectionInUse(DevSection))
+	assert.True(t, isSectionInUse("namespace"))
+
+	SetValidateAllSections(true)
+	assert.True(t, isSectionInUse(DevSection))
+}
@@ -31,8 +31,10 @@ import (
 	"github.com/okteto/okteto/pkg/deps"
 	"github.com/okteto/okteto/pkg/env"
 	"github.com/okteto/okteto/pkg/externalresource"
+	"github.com/okteto/okteto/pkg/helmrelease"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/seed"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,6 +56,7 @@ var (
 type syncRaw struct {
 	LocalPath      string
 	RemotePath     string
+	Engine         string       `json:"engine,omitempty" yaml:"engine,omitempty"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Compression    bool         `json:"compression" yaml:"compression"`
@@ -252,13 +255,14 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Compression = rawSync.Compression
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
+	sync.Engine = rawSync.Engine
 	sync.Folders = rawSync.Folders
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && sync.Engine == "" {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -640,6 +644,7 @@ func (hc *hybridCommand) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 var hybridUnsupportedFields = []string{
 	"affinity",
+	"clipboard",
 	"context",
 	"externalVolumes",
 	"image",
@@ -649,16 +654,19 @@ var hybridUnsupportedFields = []string{
 	"lifecycle",
 	"namespace",
 	"nodeSelector",
+	"persistentSession",
 	"persistentVolume",
 	"push",
 	"replicas",
 	"secrets",
 	"securityContext",
 	"serviceAccount",
+	"serviceAccountTokens",
 	"sshServerPort",
 	"sync",
 	"tolerations",
 	"volumes",
+	"x11",
 }
 
 func (h *hybridModeInfo) warnHybridUnsupportedFields() string {
@@ -759,8 +767,14 @@ type manifestRaw struct {
 	Dependencies  deps.ManifestSection     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.Section `json:"external,omitempty" yaml:"external,omitempty"`
+	Outputs       ManifestOutputs          `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Seed          seed.Section             `json:"seed,omitempty" yaml:"seed,omitempty"`
+	HelmReleases  helmrelease.Section      `json:"helmReleases,omitempty" yaml:"helmReleases,omitempty"`
+	Plugins       *PluginsInfo             `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 
-	DeprecatedDevs []string `yaml:"devs"`
+	SuppressWarnings []string `json:"suppressWarnings,omitempty" yaml:"suppressWarnings,omitempty"`
+	TagStrategy      string   `json:"tagStrategy,omitempty" yaml:"tagStrategy,omitempty"`
+	DeprecatedDevs   []string `yaml:"devs"`
 }
 
 func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -797,6 +811,12 @@ func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	m.GlobalForward = manifest.GlobalForward
 	m.External = manifest.External
 	m.Test = manifest.Test
+	m.Outputs = manifest.Outputs
+	m.Seed = manifest.Seed
+	m.HelmReleases = manifest.HelmReleases
+	m.Plugins = manifest.Plugins
+	m.SuppressWarnings = manifest.SuppressWarnings
+	m.TagStrategy = manifest.TagStrategy
 
 	err = m.SanitizeSvcNames()
 	if err != nil {
@@ -830,7 +850,7 @@ func (d *DeployInfo) MarshalYAML() (interface{}, error) {
 	if d.ComposeSection != nil && len(d.ComposeSection.ComposesInfo) != 0 {
 		return d, nil
 	}
-	isCommandList := true
+	isCommandList := d.Hooks == nil && d.Rollback == "" && len(d.Healthchecks) == 0
 	for _, cmd := range d.Commands {
 		if cmd.Command != cmd.Name {
 			isCommandList = false
@@ -1002,7 +1022,7 @@ func (d *ManifestDevs) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 func isManifestFieldNotFound(err error) bool {
-	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies"}
+	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies", "outputs", "suppressWarnings", "tagStrategy"}
 	for _, field := range manifestFields {
 		if strings.Contains(err.Error(), fmt.Sprintf("field %s not found", field)) {
 			return true
@@ -1042,7 +1062,7 @@ func (d *DestroyInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 func (d *DestroyInfo) MarshalYAML() (interface{}, error) {
-	isCommandList := true
+	isCommandList := d.Hooks == nil
 	for _, cmd := range d.Commands {
 		if cmd.Command != cmd.Name {
 			isCommandList = false
@@ -1059,7 +1079,7 @@ func (d *DestroyInfo) MarshalYAML() (interface{}, error) {
 }
 
 func (m *Manifest) MarshalYAML() (interface{}, error) {
-	if m.Destroy == nil || len(m.Destroy.Commands) == 0 {
+	if m.Destroy == nil || (len(m.Destroy.Commands) == 0 && m.Destroy.Hooks == nil) {
 		m.Destroy = nil
 		return m, nil
 	}
@@ -1225,6 +1245,28 @@ func getKeyValue(unmarshal func(interface{}) error) (map[string]string, error) {
 	return result, nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// The short notation is the URL of an archive or the name of an image
+func (p *PersistentVolumePrepopulate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var reducedNotation string
+	if err := unmarshal(&reducedNotation); err == nil {
+		if strings.Contains(reducedNotation, "://") {
+			p.URL = reducedNotation
+		} else {
+			p.Image = reducedNotation
+		}
+		return nil
+	}
+
+	type prepopulate PersistentVolumePrepopulate // prevent recursion
+	var extendedNotation prepopulate
+	if err := unmarshal(&extendedNotation); err != nil {
+		return err
+	}
+	*p = PersistentVolumePrepopulate(extendedNotation)
+	return nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (t *Timeout) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type timeout Timeout // prevent recursion
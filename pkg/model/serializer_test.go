@@ -961,6 +961,20 @@ rescanInterval: 10`),
 				RescanInterval: 10,
 			},
 		},
+		{
+			name: "engine",
+			data: []byte(`folders:
+  - .:/usr/src/app
+engine: mutagen`),
+			expected: Sync{
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+				},
+				Engine: SyncEngineMutagen,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1074,6 +1088,40 @@ deploy:
 			},
 			isErrorExpected: false,
 		},
+		{
+			name: "manifest with outputs",
+			manifest: []byte(`
+deploy:
+  - okteto stack deploy
+outputs:
+  API_URL:
+    description: the public url of the api
+  DB_URL:
+    value: postgres://${DB_HOST}:5432`),
+			expected: &Manifest{
+				Build: map[string]*build.Info{},
+				Deploy: &DeployInfo{
+					Commands: []DeployCommand{
+						{
+							Name:    "okteto stack deploy",
+							Command: "okteto stack deploy",
+						},
+					},
+				},
+				Destroy:      &DestroyInfo{},
+				Dev:          map[string]*Dev{},
+				Dependencies: map[string]*deps.Dependency{},
+				External:     externalresource.Section{},
+				Outputs: ManifestOutputs{
+					"API_URL": &Output{Description: "the public url of the api"},
+					"DB_URL":  &Output{Value: "postgres://${DB_HOST}:5432"},
+				},
+				IsV2: true,
+				Type: OktetoManifestType,
+				Fs:   afero.NewOsFs(),
+			},
+			isErrorExpected: false,
+		},
 		{
 			name: "dev manifest with dev sanitized and deploy",
 			manifest: []byte(`
@@ -2298,6 +2346,39 @@ func TestDestroyInfoUnmarshalling(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "hooks",
+			input: []byte(`commands:
+- okteto stack destroy
+hooks:
+  pre:
+  - name: backup database
+    command: ./backup.sh
+  post:
+  - ./cleanup.sh`),
+			expected: &DestroyInfo{
+				Commands: []DeployCommand{
+					{
+						Name:    "okteto stack destroy",
+						Command: "okteto stack destroy",
+					},
+				},
+				Hooks: &Hooks{
+					Pre: []DeployCommand{
+						{
+							Name:    "backup database",
+							Command: "./backup.sh",
+						},
+					},
+					Post: []DeployCommand{
+						{
+							Name:    "./cleanup.sh",
+							Command: "./cleanup.sh",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "compose with endpoints",
 			input: []byte(`compose:
@@ -2396,6 +2477,31 @@ func TestDeployInfoUnmarshalling(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "hooks",
+			deployInfoManifest: []byte(`commands:
+- okteto stack deploy
+hooks:
+  post:
+  - name: run migrations
+    command: make migrate`),
+			expected: &DeployInfo{
+				Commands: []DeployCommand{
+					{
+						Name:    "okteto stack deploy",
+						Command: "okteto stack deploy",
+					},
+				},
+				Hooks: &Hooks{
+					Post: []DeployCommand{
+						{
+							Name:    "run migrations",
+							Command: "make migrate",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "compose with endpoints",
 			deployInfoManifest: []byte(`compose:
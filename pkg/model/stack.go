@@ -294,6 +294,11 @@ func GetStackFromPath(name, stackPath string, isCompose bool, fs afero.Fs) (*Sta
 			svc.Build.Context = loadAbsPath(stackDir, svc.Build.Context, fs)
 			svc.Build.Dockerfile = loadAbsPath(svc.Build.Context, svc.Build.Dockerfile, fs)
 		}
+		for id, src := range svc.Build.Secrets {
+			if !strings.HasPrefix(src, "~/") {
+				svc.Build.Secrets[id] = loadAbsPath(stackDir, src, fs)
+			}
+		}
 		copy(svc.Build.VolumesToInclude, svc.Volumes)
 	}
 	return s, nil
@@ -18,6 +18,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model/forward"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
@@ -55,7 +57,8 @@ type StackRaw struct {
 	Networks *WarningType `yaml:"networks,omitempty"`
 
 	Configs *WarningType `yaml:"configs,omitempty"`
-	Secrets *WarningType `yaml:"secrets,omitempty"`
+
+	Secrets map[string]*SecretTopLevel `yaml:"secrets,omitempty"`
 
 	Warnings StackWarnings
 }
@@ -228,29 +231,198 @@ type composeBuildInfo struct {
 	Name             string               `yaml:"name,omitempty"`
 	Context          string               `yaml:"context,omitempty"`
 	Dockerfile       string               `yaml:"dockerfile,omitempty"`
+	DockerfileInline string               `yaml:"dockerfile_inline,omitempty"`
 	CacheFrom        cache.From           `yaml:"cache_from,omitempty"`
+	CacheTo          cache.ExportCache    `yaml:"cache_to,omitempty"`
 	Target           string               `yaml:"target,omitempty"`
-	Args             build.Args           `yaml:"args,omitempty"`
+	Args             composeBuildArgs     `yaml:"args,omitempty"`
 	Image            string               `yaml:"image,omitempty"`
 	VolumesToInclude []build.VolumeMounts `yaml:"-"`
 	ExportCache      cache.ExportCache    `yaml:"export_cache,omitempty"`
+	Secrets          []composeBuildSecret `yaml:"secrets,omitempty"`
+	SSH              composeBuildSSH      `yaml:"ssh,omitempty"`
+}
+
+// composeBuildArgs represents the build args of a compose service. Args without a value take it from the environment
+type composeBuildArgs build.Args
+
+// composeBuildSecret represents a reference from the build section of a compose service to a top-level secret
+type composeBuildSecret struct {
+	Source string `yaml:"source"`
+	Target string `yaml:"target,omitempty"`
+}
+
+// composeBuildSSH represents the ssh authentications exposed to the build of a compose service
+type composeBuildSSH []string
+
+// SecretTopLevel represents a top-level secret of a compose file
+type SecretTopLevel struct {
+	External    *WarningType           `yaml:"external,omitempty"`
+	Extensions  map[string]interface{} `yaml:",inline" json:"-"`
+	Name        string                 `yaml:"name,omitempty"`
+	File        string                 `yaml:"file,omitempty"`
+	Environment string                 `yaml:"environment,omitempty"`
 }
 
-func (c *composeBuildInfo) toBuildInfo() *build.Info {
+func (c *composeBuildInfo) toBuildInfo(svcName string, secrets map[string]*SecretTopLevel) (*build.Info, error) {
 	if c == nil {
-		return nil
+		return nil, nil
 	}
-	return &build.Info{
+	result := &build.Info{
 		Name:             c.Name,
 		Context:          c.Context,
 		Dockerfile:       c.Dockerfile,
-		CacheFrom:        c.CacheFrom,
+		DockerfileInline: c.DockerfileInline,
+		CacheFrom:        getComposeCacheRefs(svcName, "cache_from", c.CacheFrom),
 		Target:           c.Target,
-		Args:             c.Args,
+		Args:             build.Args(c.Args),
 		Image:            c.Image,
 		VolumesToInclude: c.VolumesToInclude,
 		ExportCache:      c.ExportCache,
+		SSH:              c.SSH,
+	}
+	result.ExportCache = append(result.ExportCache, getComposeCacheRefs(svcName, "cache_to", c.CacheTo)...)
+
+	for _, secret := range c.Secrets {
+		topLevel, ok := secrets[secret.Source]
+		if !ok || topLevel == nil {
+			return nil, fmt.Errorf("services.%s.build.secrets: secret '%s' is not defined in the top-level 'secrets' section", svcName, secret.Source)
+		}
+		if topLevel.File == "" {
+			return nil, fmt.Errorf("services.%s.build.secrets: secret '%s' must define 'file'. Only file secrets are supported in builds", svcName, secret.Source)
+		}
+		id := secret.Source
+		if secret.Target != "" {
+			id = secret.Target
+		}
+		if result.Secrets == nil {
+			result.Secrets = build.Secrets{}
+		}
+		result.Secrets[id] = topLevel.File
+	}
+	return result, nil
+}
+
+// getComposeCacheRefs translates the cache entries of a compose build into image references.
+// Entries can be image references or use the buildx format 'type=registry,ref=<image>'
+func getComposeCacheRefs(svcName, field string, entries []string) []string {
+	var result []string
+	for _, entry := range entries {
+		if !strings.Contains(entry, "=") {
+			result = append(result, entry)
+			continue
+		}
+		attrs := map[string]string{}
+		for _, attr := range strings.Split(entry, ",") {
+			key, value, _ := strings.Cut(attr, "=")
+			attrs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		if cacheType, ok := attrs["type"]; ok && cacheType != "registry" {
+			oktetoLog.Warning("services.%s.build.%s: cache type '%s' is not supported and will be ignored. Only 'registry' is supported", svcName, field, cacheType)
+			continue
+		}
+		if attrs["ref"] == "" {
+			oktetoLog.Warning("services.%s.build.%s: '%s' doesn't define 'ref' and will be ignored", svcName, field, entry)
+			continue
+		}
+		result = append(result, attrs["ref"])
 	}
+	return result
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (a *composeBuildArgs) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	result := map[string]string{}
+
+	var rawList []string
+	if err := unmarshal(&rawList); err == nil {
+		for _, raw := range rawList {
+			name, value, found := strings.Cut(raw, "=")
+			if !found {
+				envValue, ok := os.LookupEnv(name)
+				if !ok {
+					continue
+				}
+				value = envValue
+			}
+			result[name] = value
+		}
+	} else {
+		var rawMap map[string]*string
+		if err := unmarshal(&rawMap); err != nil {
+			return err
+		}
+		for name, value := range rawMap {
+			if value != nil {
+				result[name] = *value
+				continue
+			}
+			if envValue, ok := os.LookupEnv(name); ok {
+				result[name] = envValue
+			}
+		}
+	}
+
+	args := composeBuildArgs{}
+	for name, value := range result {
+		args = append(args, build.Arg{Name: name, Value: value})
+	}
+	sort.SliceStable(args, func(i, j int) bool {
+		return args[i].Name < args[j].Name
+	})
+	*a = args
+	return nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (s *composeBuildSecret) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var source string
+	if err := unmarshal(&source); err == nil {
+		s.Source = source
+		return nil
+	}
+
+	type composeBuildSecretExtended composeBuildSecret // prevent recursion
+	var secret composeBuildSecretExtended
+	if err := unmarshal(&secret); err != nil {
+		return err
+	}
+	if secret.Source == "" {
+		return fmt.Errorf("build secrets must define 'source'")
+	}
+	*s = composeBuildSecret(secret)
+	return nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (s *composeBuildSSH) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*s = composeBuildSSH{single}
+		return nil
+	}
+
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*s = list
+		return nil
+	}
+
+	var rawMap map[string]string
+	if err := unmarshal(&rawMap); err != nil {
+		return err
+	}
+	result := composeBuildSSH{}
+	for id, path := range rawMap {
+		if path == "" {
+			result = append(result, id)
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s=%s", id, path))
+	}
+	sort.Strings(result)
+	*s = result
+	return nil
 }
 
 func (c *composeBuildInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -305,7 +477,7 @@ func (s *Stack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			sanitizedServicesNames[svcName] = newName
 			svcName = newName
 		}
-		s.Services[svcName], err = svcRaw.ToService(svcName, s)
+		s.Services[svcName], err = svcRaw.ToService(svcName, s, stackRaw.Secrets)
 		if err != nil {
 			return err
 		}
@@ -378,7 +550,7 @@ func getAccessiblePorts(ports []PortRaw) []PortRaw {
 	return accessiblePorts
 }
 
-func (serviceRaw *ServiceRaw) ToService(svcName string, stack *Stack) (*Service, error) {
+func (serviceRaw *ServiceRaw) ToService(svcName string, stack *Stack, secrets map[string]*SecretTopLevel) (*Service, error) {
 	svc := &Service{}
 	var err error
 
@@ -387,7 +559,10 @@ func (serviceRaw *ServiceRaw) ToService(svcName string, stack *Stack) (*Service,
 	svc.Replicas = unmarshalDeployReplicas(serviceRaw.Deploy, serviceRaw.Scale, serviceRaw.Replicas)
 
 	svc.Image = serviceRaw.Image
-	svc.Build = serviceRaw.Build.toBuildInfo()
+	svc.Build, err = serviceRaw.Build.toBuildInfo(svcName, secrets)
+	if err != nil {
+		return nil, err
+	}
 
 	svc.CapAdd = serviceRaw.CapAdd
 	if len(serviceRaw.CapAddSneakCase) > 0 {
@@ -1292,9 +1467,6 @@ func getTopLevelNotSupportedFields(s *StackRaw) []string {
 	if s.Configs != nil {
 		notSupported = append(notSupported, "configs")
 	}
-	if s.Secrets != nil {
-		notSupported = append(notSupported, "secrets")
-	}
 	return notSupported
 }
 
@@ -22,8 +22,10 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/cache"
 	"github.com/okteto/okteto/pkg/env"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -529,6 +531,141 @@ image: nginx`),
 	}
 }
 
+func TestComposeBuildParityUnmarshalling(t *testing.T) {
+	t.Setenv("FROM_ENV", "env-value")
+	tests := []struct {
+		expected *build.Info
+		name     string
+		manifest []byte
+		wantErr  bool
+	}{
+		{
+			name: "cache, target and list args",
+			manifest: []byte(`services:
+  api:
+    build:
+      context: .
+      target: dev
+      cache_from:
+        - okteto.dev/api:cache
+        - type=registry,ref=okteto.dev/api:buildcache
+        - type=gha
+      cache_to:
+        - type=registry,ref=okteto.dev/api:buildcache,mode=max
+      args:
+        - KEY=value
+        - FROM_ENV
+        - NOT_IN_ENV
+      ssh:
+        - default
+        - github=~/.ssh/github.pem`),
+			expected: &build.Info{
+				Context:     ".",
+				Dockerfile:  "Dockerfile",
+				Target:      "dev",
+				CacheFrom:   cache.From{"okteto.dev/api:cache", "okteto.dev/api:buildcache"},
+				ExportCache: cache.ExportCache{"okteto.dev/api:buildcache"},
+				Args: build.Args{
+					{Name: "FROM_ENV", Value: "env-value"},
+					{Name: "KEY", Value: "value"},
+				},
+				SSH: []string{"default", "github=~/.ssh/github.pem"},
+			},
+		},
+		{
+			name: "map args without value",
+			manifest: []byte(`services:
+  api:
+    build:
+      context: .
+      args:
+        KEY: value
+        FROM_ENV:
+        NOT_IN_ENV:`),
+			expected: &build.Info{
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+				Args: build.Args{
+					{Name: "FROM_ENV", Value: "env-value"},
+					{Name: "KEY", Value: "value"},
+				},
+			},
+		},
+		{
+			name: "dockerfile inline",
+			manifest: []byte(`services:
+  api:
+    build:
+      context: .
+      dockerfile_inline: |
+        FROM alpine
+        RUN echo inline`),
+			expected: &build.Info{
+				Context:          ".",
+				DockerfileInline: "FROM alpine\nRUN echo inline",
+			},
+		},
+		{
+			name: "secrets",
+			manifest: []byte(`services:
+  api:
+    build:
+      context: .
+      secrets:
+        - npm_token
+        - source: pip_conf
+          target: pip
+secrets:
+  npm_token:
+    file: ./npm_token.txt
+  pip_conf:
+    file: /etc/pip.conf`),
+			expected: &build.Info{
+				Context:    ".",
+				Dockerfile: "Dockerfile",
+				Secrets: build.Secrets{
+					"npm_token": "./npm_token.txt",
+					"pip":       "/etc/pip.conf",
+				},
+			},
+		},
+		{
+			name: "undefined secret",
+			manifest: []byte(`services:
+  api:
+    build:
+      context: .
+      secrets:
+        - npm_token`),
+			wantErr: true,
+		},
+		{
+			name: "environment secret",
+			manifest: []byte(`services:
+  api:
+    build:
+      context: .
+      secrets:
+        - npm_token
+secrets:
+  npm_token:
+    environment: NPM_TOKEN`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack(tt.manifest, true)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Services["api"].Build)
+		})
+	}
+}
+
 func Test_HealthcheckTestUnmarshalling(t *testing.T) {
 	tests := []struct {
 		name            string
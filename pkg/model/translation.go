@@ -20,29 +20,33 @@ import (
 
 // TranslationRule represents how to apply a container translation in a deployment
 type TranslationRule struct {
-	InitContainer     InitContainer        `json:"initContainers,omitempty"`
-	Resources         ResourceRequirements `json:"resources,omitempty"`
-	SecurityContext   *SecurityContext     `json:"securityContext,omitempty"`
-	Probes            *Probes              `json:"probes" yaml:"probes"`
-	Lifecycle         *Lifecycle           `json:"lifecycle" yaml:"lifecycle"`
-	Labels            Labels               `json:"labels,omitempty"`
-	NodeSelector      map[string]string    `json:"nodeSelector" yaml:"nodeSelector"`
-	Affinity          *apiv1.Affinity      `json:"affinity" yaml:"affinity"`
-	ServiceAccount    string               `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
-	WorkDir           string               `json:"workdir"`
-	Marker            string               `json:"marker"`
-	OktetoBinImageTag string               `json:"oktetoBinImageTag"`
-	Node              string               `json:"node,omitempty"`
-	Container         string               `json:"container,omitempty"`
-	Image             string               `json:"image,omitempty"`
-	ImagePullPolicy   apiv1.PullPolicy     `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
-	Environment       env.Environment      `json:"environment,omitempty"`
-	Secrets           []Secret             `json:"secrets,omitempty"`
-	Command           []string             `json:"command,omitempty"`
-	Args              []string             `json:"args,omitempty"`
-	Volumes           []VolumeMount        `json:"volumes,omitempty"`
-	Healthchecks      bool                 `json:"healthchecks" yaml:"healthchecks"`
-	PersistentVolume  bool                 `json:"persistentVolume" yaml:"persistentVolume"`
+	InitContainer     InitContainer                `json:"initContainers,omitempty"`
+	Resources         ResourceRequirements         `json:"resources,omitempty"`
+	SecurityContext   *SecurityContext             `json:"securityContext,omitempty"`
+	Probes            *Probes                      `json:"probes" yaml:"probes"`
+	Lifecycle         *Lifecycle                   `json:"lifecycle" yaml:"lifecycle"`
+	Labels            Labels                       `json:"labels,omitempty"`
+	NodeSelector      map[string]string            `json:"nodeSelector" yaml:"nodeSelector"`
+	Affinity          *apiv1.Affinity              `json:"affinity" yaml:"affinity"`
+	ServiceAccount    string                       `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+	WorkDir           string                       `json:"workdir"`
+	Marker            string                       `json:"marker"`
+	OktetoBinImageTag string                       `json:"oktetoBinImageTag"`
+	Node              string                       `json:"node,omitempty"`
+	Container         string                       `json:"container,omitempty"`
+	Image             string                       `json:"image,omitempty"`
+	ImagePullPolicy   apiv1.PullPolicy             `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
+	Environment       env.Environment              `json:"environment,omitempty"`
+	EnvFrom           []EnvFrom                    `json:"envFrom,omitempty"`
+	Secrets           []Secret                     `json:"secrets,omitempty"`
+	Command           []string                     `json:"command,omitempty"`
+	Args              []string                     `json:"args,omitempty"`
+	Volumes           []VolumeMount                `json:"volumes,omitempty"`
+	Healthchecks      bool                         `json:"healthchecks" yaml:"healthchecks"`
+	PersistentVolume  bool                         `json:"persistentVolume" yaml:"persistentVolume"`
+	Prepopulate       *PersistentVolumePrepopulate `json:"prepopulate,omitempty" yaml:"prepopulate,omitempty"`
+
+	ServiceAccountTokens []ServiceAccountToken `json:"serviceAccountTokens,omitempty"`
 }
 
 // IsMainDevContainer returns true if the translation rule applies to the main dev container of the okteto manifest
@@ -15,11 +15,13 @@ package model
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strings"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -189,9 +191,68 @@ func (dev *Dev) PersistentVolumeStorageClass() string {
 	return dev.PersistentVolumeInfo.StorageClass
 }
 
+// PersistentVolumeAccessModes returns the persistent volume access modes
+func (dev *Dev) PersistentVolumeAccessModes() []apiv1.PersistentVolumeAccessMode {
+	if dev.PersistentVolumeInfo == nil || len(dev.PersistentVolumeInfo.AccessModes) == 0 {
+		return []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce}
+	}
+	return dev.PersistentVolumeInfo.AccessModes
+}
+
+// PersistentVolumePrepopulate returns the data that seeds the volumes of the dev, nil when it is not defined
+func (dev *Dev) PersistentVolumePrepopulate() *PersistentVolumePrepopulate {
+	if !dev.PersistentVolumeEnabled() || dev.PersistentVolumeInfo == nil {
+		return nil
+	}
+	return dev.PersistentVolumeInfo.Prepopulate
+}
+
+// GetURL returns the URL to download the archive from. Object store URLs are translated to their public https endpoint
+func (p *PersistentVolumePrepopulate) GetURL() string {
+	switch {
+	case strings.HasPrefix(p.URL, "s3://"):
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(p.URL, "s3://"), "/")
+		return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", bucket, key)
+	case strings.HasPrefix(p.URL, "gs://"):
+		return fmt.Sprintf("https://storage.googleapis.com/%s", strings.TrimPrefix(p.URL, "gs://"))
+	}
+	return p.URL
+}
+
+func (p *PersistentVolumePrepopulate) validate() error {
+	if (p.Image == "") == (p.URL == "") {
+		return fmt.Errorf("'persistentVolume.prepopulate' must define either 'image' or 'url'")
+	}
+	if p.URL == "" {
+		if p.DownloadImage != "" {
+			return fmt.Errorf("'persistentVolume.prepopulate.downloadImage' is only supported with 'url'")
+		}
+		return nil
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("'persistentVolume.prepopulate.url' value '%s' is not a valid URL", p.URL)
+	}
+	switch u.Scheme {
+	case "http", "https", "s3", "gs":
+	default:
+		return fmt.Errorf("'persistentVolume.prepopulate.url' scheme '%s' is not supported. Supported schemes are 'https', 'http', 's3' and 'gs'", u.Scheme)
+	}
+	if (u.Scheme == "s3" || u.Scheme == "gs") && strings.Trim(u.Path, "/") == "" {
+		return fmt.Errorf("'persistentVolume.prepopulate.url' must include the key of the archive in the bucket")
+	}
+	return nil
+}
+
+// HasExistingPersistentVolumeClaim returns true if dev uses a persistent volume claim not managed by okteto
+func (dev *Dev) HasExistingPersistentVolumeClaim() bool {
+	return dev.PersistentVolumeInfo != nil && dev.PersistentVolumeInfo.ClaimName != ""
+}
+
 func (dev *Dev) AreDefaultPersistentVolumeValues() bool {
 	if dev.PersistentVolumeInfo != nil {
-		if dev.HasDefaultPersistentVolumeSize() && dev.PersistentVolumeStorageClass() == "" && dev.PersistentVolumeEnabled() {
+		if dev.HasDefaultPersistentVolumeSize() && dev.PersistentVolumeStorageClass() == "" && dev.PersistentVolumeEnabled() &&
+			len(dev.PersistentVolumeInfo.AccessModes) == 0 && !dev.HasExistingPersistentVolumeClaim() && dev.PersistentVolumeInfo.Prepopulate == nil {
 			return true
 		}
 	}
@@ -201,7 +262,13 @@ func (dev *Dev) AreDefaultPersistentVolumeValues() bool {
 
 func (dev *Dev) validatePersistentVolume() error {
 	if dev.PersistentVolumeEnabled() {
-		return nil
+		return dev.validatePersistentVolumeSettings()
+	}
+	if dev.HasExistingPersistentVolumeClaim() {
+		return fmt.Errorf("'persistentVolume.enabled' must be set to true to use 'persistentVolume.claimName'")
+	}
+	if dev.PersistentVolumeInfo != nil && dev.PersistentVolumeInfo.Prepopulate != nil {
+		return fmt.Errorf("'persistentVolume.enabled' must be set to true to use 'persistentVolume.prepopulate'")
 	}
 	if len(dev.Services) > 0 {
 		return fmt.Errorf("'persistentVolume.enabled' must be set to true to work with services")
@@ -221,6 +288,35 @@ func (dev *Dev) validatePersistentVolume() error {
 	return nil
 }
 
+func (dev *Dev) validatePersistentVolumeSettings() error {
+	if dev.PersistentVolumeInfo == nil {
+		return nil
+	}
+	for _, mode := range dev.PersistentVolumeInfo.AccessModes {
+		switch mode {
+		case apiv1.ReadWriteOnce, apiv1.ReadWriteMany, apiv1.ReadWriteOncePod:
+		case apiv1.ReadOnlyMany:
+			return fmt.Errorf("'persistentVolume.accessModes' can't be '%s': the development container needs to write to its persistent volume", mode)
+		default:
+			return fmt.Errorf("'persistentVolume.accessModes' value '%s' is not valid. Supported values are '%s', '%s' and '%s'", mode, apiv1.ReadWriteOnce, apiv1.ReadWriteMany, apiv1.ReadWriteOncePod)
+		}
+	}
+	if dev.HasExistingPersistentVolumeClaim() {
+		if dev.PersistentVolumeInfo.Size != "" || dev.PersistentVolumeInfo.StorageClass != "" || len(dev.PersistentVolumeInfo.AccessModes) > 0 {
+			return fmt.Errorf("'persistentVolume.claimName' can't be combined with 'size', 'storageClass' or 'accessModes': they are defined by the existing claim")
+		}
+	}
+	if dev.PersistentVolumeInfo.Prepopulate != nil {
+		if err := dev.PersistentVolumeInfo.Prepopulate.validate(); err != nil {
+			return err
+		}
+		if len(dev.Volumes) == 0 {
+			return fmt.Errorf("'persistentVolume.prepopulate' requires 'volumes': it seeds the content of the volumes of the development container")
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) validateRemotePaths() error {
 	for _, v := range dev.Volumes {
 		if !strings.HasPrefix(v.RemotePath, "/") {
@@ -20,6 +20,10 @@ import (
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 func TestDev_translateDeprecatedVolumeFields(t *testing.T) {
@@ -687,6 +691,142 @@ func Test_validatePersistentVolume(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "not-enabled-and-claim-name",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:   false,
+					ClaimName: "shared",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ok-claim-name",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:   true,
+					ClaimName: "shared",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "claim-name-and-size",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:   true,
+					ClaimName: "shared",
+					Size:      "20Gi",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ok-access-modes",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteMany},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "read-only-access-mode",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadOnlyMany},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-access-mode",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					AccessModes: []apiv1.PersistentVolumeAccessMode{"ReadSometimes"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ok-prepopulate",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					Prepopulate: &PersistentVolumePrepopulate{URL: "s3://datasets/seed.tar.gz"},
+				},
+				Volumes: []Volume{{RemotePath: "/data"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "prepopulate-without-volumes",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					Prepopulate: &PersistentVolumePrepopulate{Image: "okteto/cache"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prepopulate-with-image-and-url",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					Prepopulate: &PersistentVolumePrepopulate{Image: "okteto/cache", URL: "https://example.com/seed.tar"},
+				},
+				Volumes: []Volume{{RemotePath: "/data"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prepopulate-download-image-without-url",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					Prepopulate: &PersistentVolumePrepopulate{Image: "okteto/cache", DownloadImage: "curlimages/curl"},
+				},
+				Volumes: []Volume{{RemotePath: "/data"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prepopulate-unsupported-scheme",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					Prepopulate: &PersistentVolumePrepopulate{URL: "ftp://example.com/seed.tar"},
+				},
+				Volumes: []Volume{{RemotePath: "/data"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prepopulate-bucket-without-key",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     true,
+					Prepopulate: &PersistentVolumePrepopulate{URL: "gs://datasets"},
+				},
+				Volumes: []Volume{{RemotePath: "/data"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not-enabled-and-prepopulate",
+			dev: &Dev{
+				PersistentVolumeInfo: &PersistentVolumeInfo{
+					Enabled:     false,
+					Prepopulate: &PersistentVolumePrepopulate{Image: "okteto/cache"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -819,3 +959,41 @@ func Test_validateVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestPersistentVolumePrepopulateGetURL(t *testing.T) {
+	assert.Equal(t, "https://datasets.s3.amazonaws.com/seeds/seed.tar.gz", (&PersistentVolumePrepopulate{URL: "s3://datasets/seeds/seed.tar.gz"}).GetURL())
+	assert.Equal(t, "https://storage.googleapis.com/datasets/seed.tar.gz", (&PersistentVolumePrepopulate{URL: "gs://datasets/seed.tar.gz"}).GetURL())
+	assert.Equal(t, "https://example.com/seed.tar?sig=abc", (&PersistentVolumePrepopulate{URL: "https://example.com/seed.tar?sig=abc"}).GetURL())
+}
+
+func TestPersistentVolumePrepopulateUnmarshal(t *testing.T) {
+	var tests = []struct {
+		name     string
+		manifest string
+		expected PersistentVolumePrepopulate
+	}{
+		{
+			name:     "image",
+			manifest: "prepopulate: okteto/node-cache:20",
+			expected: PersistentVolumePrepopulate{Image: "okteto/node-cache:20"},
+		},
+		{
+			name:     "url",
+			manifest: "prepopulate: s3://datasets/seed.tar.gz",
+			expected: PersistentVolumePrepopulate{URL: "s3://datasets/seed.tar.gz"},
+		},
+		{
+			name:     "extended",
+			manifest: "prepopulate:\n  url: https://example.com/seed.tar",
+			expected: PersistentVolumePrepopulate{URL: "https://example.com/seed.tar"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result PersistentVolumeInfo
+			require.NoError(t, yaml.Unmarshal([]byte(tt.manifest), &result))
+			require.NotNil(t, result.Prepopulate)
+			assert.Equal(t, tt.expected, *result.Prepopulate)
+		})
+	}
+}
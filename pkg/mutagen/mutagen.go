@@ -0,0 +1,282 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutagen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+const (
+	// sessionLabel is the label used to select the mutagen sessions of a development container
+	sessionLabel = "okteto-dev"
+
+	// syncMode keeps both sides in sync, the local changes win on conflicts
+	syncMode = "two-way-resolved"
+
+	maxRetries = 3
+)
+
+// runner executes a mutagen command and returns its combined output
+type runner func(ctx context.Context, args ...string) ([]byte, error)
+
+// Mutagen synchronizes the local folders of a development container using mutagen sessions over SSH
+type Mutagen struct {
+	fs        afero.Fs
+	run       runner
+	host      string
+	selector  string
+	namespace string
+	name      string
+	folders   []model.SyncFolder
+	paused    atomic.Bool
+}
+
+// Session represents the state of a mutagen synchronization session
+type Session struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LastError string `json:"lastError"`
+	Paused    bool   `json:"paused"`
+}
+
+// New returns the mutagen engine for a development container
+func New(dev *model.Dev, fs afero.Fs) (*Mutagen, error) {
+	binPath, err := exec.LookPath(getBinaryName())
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the 'mutagen' binary is not available in your PATH"),
+			Hint: "Install mutagen following https://mutagen.io/documentation/introduction/installation or remove the 'sync.engine' field from your okteto manifest",
+		}
+	}
+
+	m := newMutagen(dev, fs)
+	m.run = func(ctx context.Context, args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, binPath, args...)
+		output, err := cmd.CombinedOutput()
+		m.log(args, output)
+		return output, err
+	}
+	return m, nil
+}
+
+func newMutagen(dev *model.Dev, fs afero.Fs) *Mutagen {
+	return &Mutagen{
+		fs:        fs,
+		host:      fmt.Sprintf("%s.okteto", dev.Name),
+		selector:  fmt.Sprintf("%s=%s", sessionLabel, getSessionID(dev.Namespace, dev.Name)),
+		namespace: dev.Namespace,
+		name:      dev.Name,
+		folders:   dev.Sync.Folders,
+	}
+}
+
+// Start creates the synchronization sessions of the development container, terminating the previous ones
+func (m *Mutagen) Start(ctx context.Context) error {
+	if err := m.Terminate(ctx); err != nil {
+		oktetoLog.Infof("failed to terminate previous mutagen sessions: %s", err)
+	}
+
+	for i, folder := range m.folders {
+		args := []string{
+			"sync", "create",
+			fmt.Sprintf("--name=%s-%d", getSessionID(m.namespace, m.name), i),
+			fmt.Sprintf("--label=%s", m.selector),
+			fmt.Sprintf("--sync-mode=%s", syncMode),
+		}
+		for _, ignore := range m.getIgnores(folder.LocalPath) {
+			args = append(args, fmt.Sprintf("--ignore=%s", ignore))
+		}
+		args = append(args, folder.LocalPath, fmt.Sprintf("%s:%s", m.host, folder.RemotePath))
+
+		if output, err := m.run(ctx, args...); err != nil {
+			return fmt.Errorf("failed to create the mutagen session for '%s': %s", folder.LocalPath, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// WaitForCompletion waits until the sessions complete a full synchronization cycle
+func (m *Mutagen) WaitForCompletion(ctx context.Context, reporter chan float64) error {
+	defer close(reporter)
+	if output, err := m.run(ctx, "sync", "flush", fmt.Sprintf("--label-selector=%s", m.selector)); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to synchronize your files: %s", strings.TrimSpace(string(output)))
+	}
+	reporter <- 100
+	return nil
+}
+
+// GetSessions returns the synchronization sessions of the development container
+func (m *Mutagen) GetSessions(ctx context.Context) ([]Session, error) {
+	output, err := m.run(ctx, "sync", "list", fmt.Sprintf("--label-selector=%s", m.selector), "--template={{ json . }}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the mutagen sessions: %s", strings.TrimSpace(string(output)))
+	}
+	sessions := []Session{}
+	if err := json.Unmarshal(bytes.TrimSpace(output), &sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse the mutagen sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// IsConnected returns true if all the sessions are connected to the development container
+func (m *Mutagen) IsConnected(ctx context.Context) bool {
+	sessions, err := m.GetSessions(ctx)
+	if err != nil {
+		oktetoLog.Infof("failed to get mutagen sessions: %s", err)
+		return false
+	}
+	if len(sessions) == 0 {
+		return false
+	}
+	for _, s := range sessions {
+		if s.Paused || strings.HasPrefix(s.Status, "disconnected") || strings.HasPrefix(s.Status, "halted") {
+			oktetoLog.Infof("mutagen session '%s' is not connected: status=%s error=%s", s.Name, s.Status, s.LastError)
+			return false
+		}
+	}
+	return true
+}
+
+// Monitor will send a message to disconnect if the sessions are disconnected for more than 30 seconds.
+// Paused sessions are not checked
+func (m *Mutagen) Monitor(ctx context.Context, disconnect chan error) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	retries := 0
+	for {
+		select {
+		case <-ticker.C:
+			if m.paused.Load() || m.IsConnected(ctx) {
+				retries = 0
+				continue
+			}
+			oktetoLog.Infof("mutagen connection error %d", retries)
+			if retries >= maxRetries {
+				oktetoLog.Infof("mutagen connection error, sending disconnect signal")
+				disconnect <- oktetoErrors.ErrLostSyncthing
+				return
+			}
+			retries++
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Pause pauses the synchronization sessions of the development container until Resume is called
+func (m *Mutagen) Pause(ctx context.Context) error {
+	if output, err := m.run(ctx, "sync", "pause", fmt.Sprintf("--label-selector=%s", m.selector)); err != nil {
+		return fmt.Errorf("failed to pause the mutagen sessions: %s", strings.TrimSpace(string(output)))
+	}
+	m.paused.Store(true)
+	return nil
+}
+
+// Resume resumes the synchronization sessions of the development container
+func (m *Mutagen) Resume(ctx context.Context) error {
+	if output, err := m.run(ctx, "sync", "resume", fmt.Sprintf("--label-selector=%s", m.selector)); err != nil {
+		return fmt.Errorf("failed to resume the mutagen sessions: %s", strings.TrimSpace(string(output)))
+	}
+	m.paused.Store(false)
+	return nil
+}
+
+// Terminate terminates the synchronization sessions of the development container
+func (m *Mutagen) Terminate(ctx context.Context) error {
+	if output, err := m.run(ctx, "sync", "terminate", fmt.Sprintf("--label-selector=%s", m.selector)); err != nil {
+		return fmt.Errorf("failed to terminate the mutagen sessions: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// getIgnores translates the .stignore file of a sync folder to mutagen ignore patterns
+func (m *Mutagen) getIgnores(localPath string) []string {
+	result := []string{}
+	f, err := m.fs.Open(filepath.Join(localPath, ".stignore"))
+	if err != nil {
+		return result
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, prefix := range []string{"(?d)", "(?i)"} {
+			line = strings.TrimPrefix(line, prefix)
+		}
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func (m *Mutagen) log(args []string, output []byte) {
+	f, err := os.OpenFile(GetLogFile(m.namespace, m.name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		oktetoLog.Infof("failed to open mutagen log file: %s", err)
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "%s mutagen %s\n%s\n", time.Now().Format(time.RFC3339), strings.Join(args, " "), output)
+}
+
+// getSessionID returns a valid mutagen identifier for the development container
+func getSessionID(namespace, name string) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", namespace, name)))
+	return fmt.Sprintf("okteto-%s", hex.EncodeToString(h[:])[:12])
+}
+
+// IsInstalled checks if the mutagen binary is available in the PATH
+func IsInstalled() bool {
+	_, err := exec.LookPath(getBinaryName())
+	return err == nil
+}
+
+func getBinaryName() string {
+	if runtime.GOOS == "windows" {
+		return "mutagen.exe"
+	}
+	return "mutagen"
+}
+
+// GetLogFile returns the path to the mutagen log file
+func GetLogFile(namespace, name string) string {
+	return filepath.Join(config.GetAppHome(namespace, name), "mutagen.log")
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutagen
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunner struct {
+	outputs map[string]string
+	errors  map[string]error
+	calls   [][]string
+}
+
+func (f *fakeRunner) run(_ context.Context, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	key := strings.Join(args[:2], " ")
+	return []byte(f.outputs[key]), f.errors[key]
+}
+
+func newFakeMutagen(fs afero.Fs, r *fakeRunner) *Mutagen {
+	dev := &model.Dev{
+		Name:      "api",
+		Namespace: "test",
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{{LocalPath: "/app", RemotePath: "/usr/src/app"}},
+		},
+	}
+	m := newMutagen(dev, fs)
+	m.run = r.run
+	return m
+}
+
+func TestStart(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/app/.stignore", []byte("// comment\n.git\n(?d)node_modules\n\n#include other\n"), 0600))
+	r := &fakeRunner{}
+	m := newFakeMutagen(fs, r)
+
+	require.NoError(t, m.Start(context.Background()))
+	require.Len(t, r.calls, 2)
+	assert.Equal(t, []string{"sync", "terminate", "--label-selector=" + m.selector}, r.calls[0])
+
+	id := getSessionID("test", "api")
+	expected := []string{
+		"sync", "create",
+		"--name=" + id + "-0",
+		"--label=okteto-dev=" + id,
+		"--sync-mode=two-way-resolved",
+		"--ignore=.git",
+		"--ignore=node_modules",
+		"/app",
+		"api.okteto:/usr/src/app",
+	}
+	assert.Equal(t, expected, r.calls[1])
+}
+
+func TestStartError(t *testing.T) {
+	r := &fakeRunner{
+		outputs: map[string]string{"sync create": "connection refused\n"},
+		errors:  map[string]error{"sync create": errors.New("exit status 1")},
+	}
+	m := newFakeMutagen(afero.NewMemMapFs(), r)
+	err := m.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestIsConnected(t *testing.T) {
+	var tests = []struct {
+		name     string
+		output   string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "watching",
+			output:   `[{"name":"s-0","status":"watching"}]`,
+			expected: true,
+		},
+		{
+			name:     "disconnected",
+			output:   `[{"name":"s-0","status":"watching"},{"name":"s-1","status":"disconnected","lastError":"ssh error"}]`,
+			expected: false,
+		},
+		{
+			name:     "paused",
+			output:   `[{"name":"s-0","status":"watching","paused":true}]`,
+			expected: false,
+		},
+		{
+			name:     "no sessions",
+			output:   `[]`,
+			expected: false,
+		},
+		{
+			name:     "error",
+			err:      errors.New("exit status 1"),
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &fakeRunner{
+				outputs: map[string]string{"sync list": tt.output},
+				errors:  map[string]error{"sync list": tt.err},
+			}
+			m := newFakeMutagen(afero.NewMemMapFs(), r)
+			assert.Equal(t, tt.expected, m.IsConnected(context.Background()))
+		})
+	}
+}
+
+func TestWaitForCompletion(t *testing.T) {
+	r := &fakeRunner{}
+	m := newFakeMutagen(afero.NewMemMapFs(), r)
+	reporter := make(chan float64, 1)
+	require.NoError(t, m.WaitForCompletion(context.Background(), reporter))
+	assert.Equal(t, float64(100), <-reporter)
+	_, ok := <-reporter
+	assert.False(t, ok)
+}
+
+func Test_getSessionID(t *testing.T) {
+	id := getSessionID("test", "api")
+	assert.True(t, strings.HasPrefix(id, "okteto-"))
+	assert.Equal(t, id, getSessionID("test", "api"))
+	assert.NotEqual(t, id, getSessionID("other", "api"))
+}
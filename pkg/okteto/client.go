@@ -46,6 +46,8 @@ type Client struct {
 	stream    types.StreamInterface
 	kubetoken types.KubetokenInterface
 	endpoint  types.EndpointClientInterface
+	share     types.ShareInterface
+	variables types.VariablesInterface
 }
 
 type ClientProvider struct{}
@@ -308,6 +310,8 @@ func newOktetoClientFromGraphqlClient(url string, httpClient *http.Client) (*Cli
 	c.stream = newStreamClient(httpClient)
 	c.kubetoken = newKubeTokenClient(httpClient)
 	c.endpoint = newEndpointClient(c.client)
+	c.share = newShareClient(c.client)
+	c.variables = newVariablesClient(c.client)
 	return c, nil
 }
 
@@ -477,6 +481,16 @@ func (c *Client) Endpoint() types.EndpointClientInterface {
 	return c.endpoint
 }
 
+// Share retrieves the share links client
+func (c *Client) Share() types.ShareInterface {
+	return c.share
+}
+
+// Variables retrieves the Okteto variables client
+func (c *Client) Variables() types.VariablesInterface {
+	return c.variables
+}
+
 func SetInsecureSkipTLSVerifyPolicy(isInsecure bool) {
 	onceInsecureWarning.Do(func() {
 		oktetoLog.Debugf("insecure mode: %t", isInsecure)
@@ -29,6 +29,9 @@ type ConfigStateless struct {
 	Cert                        string
 	ServerNameOverride          string
 	ContextName                 string
+	RegistryTemplates           map[string]string
+	RegistryMirrors             map[string][]string
+	RegistryRetry               *RegistryRetry
 	InsecureSkipTLSVerifyPolicy bool
 	IsOkteto                    bool
 }
@@ -45,6 +48,15 @@ func (c ConfigStateless) GetContextCertificate() (*x509.Certificate, error) {
 func (c ConfigStateless) IsInsecureSkipTLSVerifyPolicy() bool { return c.InsecureSkipTLSVerifyPolicy }
 func (ConfigStateless) GetServerNameOverride() string         { return GetServerNameOverride() }
 func (c ConfigStateless) GetContextName() string              { return c.ContextName }
+func (c ConfigStateless) GetRegistryTemplate(registryType string) string {
+	return c.RegistryTemplates[registryType]
+}
+func (c ConfigStateless) GetRegistryMirrors(registry string) []string {
+	return c.RegistryMirrors[registry]
+}
+func (c ConfigStateless) GetRegistryRetries() int         { return c.RegistryRetry.GetRetries() }
+func (c ConfigStateless) GetRegistryRetryBackoff() string { return c.RegistryRetry.GetBackoff() }
+func (c ConfigStateless) GetRegistryTimeout() string      { return c.RegistryRetry.GetTimeout() }
 func (c ConfigStateless) GetExternalRegistryCredentials(registryHost string) (string, string, error) {
 	ocfg := &ClientCfg{
 		CtxName: c.ContextName,
@@ -71,6 +83,15 @@ func (Config) GetContextCertificate() (*x509.Certificate, error) { return GetCon
 func (Config) IsInsecureSkipTLSVerifyPolicy() bool               { return GetContext().IsInsecure }
 func (Config) GetServerNameOverride() string                     { return GetServerNameOverride() }
 func (Config) GetContextName() string                            { return GetContext().Name }
+func (Config) GetRegistryTemplate(registryType string) string {
+	return GetContext().RegistryTemplates[registryType]
+}
+func (Config) GetRegistryMirrors(registry string) []string {
+	return GetContext().RegistryMirrors[registry]
+}
+func (Config) GetRegistryRetries() int         { return GetContext().RegistryRetry.GetRetries() }
+func (Config) GetRegistryRetryBackoff() string { return GetContext().RegistryRetry.GetBackoff() }
+func (Config) GetRegistryTimeout() string      { return GetContext().RegistryRetry.GetTimeout() }
 func (Config) GetExternalRegistryCredentials(registryHost string) (string, string, error) {
 	return GetExternalRegistryCredentials(registryHost)
 }
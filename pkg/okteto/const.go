@@ -22,4 +22,8 @@ const (
 	Job = "job"
 	// CronJob k8s CronJob kind
 	CronJob = "CronJob"
+	// Rollout argo rollouts Rollout kind
+	Rollout = "Rollout"
+	// KnativeService knative serving Service kind
+	KnativeService = "KnativeService"
 )
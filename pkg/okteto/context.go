@@ -74,6 +74,14 @@ type Context struct {
 	Registry           string               `json:"registry,omitempty" yaml:"registry,omitempty"`
 	Certificate        string               `json:"certificate,omitempty" yaml:"certificate,omitempty"`
 	PersonalNamespace  string               `json:"personalNamespace,omitempty" yaml:"personalNamespace,omitempty"`
+	RegistryTemplates  map[string]string    `json:"registryTemplates,omitempty" yaml:"registryTemplates,omitempty"`
+	RegistryMirrors    map[string][]string  `json:"registryMirrors,omitempty" yaml:"registryMirrors,omitempty"`
+	RegistryRetry      *RegistryRetry       `json:"registryRetry,omitempty" yaml:"registryRetry,omitempty"`
+	Pricing            *Pricing             `json:"pricing,omitempty" yaml:"pricing,omitempty"`
+	SuppressWarnings   []string             `json:"suppressWarnings,omitempty" yaml:"suppressWarnings,omitempty"`
+	TagStrategy        string               `json:"tagStrategy,omitempty" yaml:"tagStrategy,omitempty"`
+	BuildBackend       string               `json:"buildBackend,omitempty" yaml:"buildBackend,omitempty"`
+	License            *License             `json:"license,omitempty" yaml:"license,omitempty"`
 	GlobalNamespace    string               `json:"-" yaml:"-"`
 	ClusterType        string               `json:"-" yaml:"-"`
 	CompanyName        string               `json:"-" yaml:"-"`
@@ -84,6 +92,46 @@ type Context struct {
 	IsTrial            bool                 `json:"-" yaml:"-"`
 }
 
+// Pricing is the monthly price of the cluster resources used to estimate the cost of a dev environment
+type Pricing struct {
+	CPU    float64 `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory float64 `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// RegistryRetry configures the retries of the registry operations. Durations use the Go format, e.g. '500ms'
+type RegistryRetry struct {
+	// Backoff is the wait before the first retry. It is doubled on every retry
+	Backoff string `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// Timeout is how long to wait for the response of each request to the registry
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Retries is how many times an operation is retried after a transient error
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty"`
+}
+
+// GetRetries returns the retries of the registry operations, zero when they are not configured
+func (r *RegistryRetry) GetRetries() int {
+	if r == nil {
+		return 0
+	}
+	return r.Retries
+}
+
+// GetBackoff returns the wait before the first retry of the registry operations, empty when it is not configured
+func (r *RegistryRetry) GetBackoff() string {
+	if r == nil {
+		return ""
+	}
+	return r.Backoff
+}
+
+// GetTimeout returns the timeout of the requests to the registry, empty when it is not configured
+func (r *RegistryRetry) GetTimeout() string {
+	if r == nil {
+		return ""
+	}
+	return r.Timeout
+}
+
 // ContextViewer contains info to show
 type ContextViewer struct {
 	Name      string `json:"name" yaml:"name,omitempty"`
@@ -592,7 +640,7 @@ func GetContextCertificate() (*x509.Certificate, error) {
 				case hoursSinceInstall <= hoursInADay: // less than 1 day
 					oktetoLog.Information("Your Okteto installation is using selfsigned certificates. Please switch to your own certificates before production use.")
 				case hoursSinceInstall <= hoursInAWeek: // less than 1 week
-					oktetoLog.Warning("Your Okteto installation has been using selfsigned certificates for more than a day. It's important to use your own certificates before production use.")
+					oktetoLog.WarningWithID(oktetoLog.WarnSelfSignedCertificates, "Your Okteto installation has been using selfsigned certificates for more than a day. It's important to use your own certificates before production use.")
 				default: // more than 1 week
 					oktetoLog.Fail("[PLEASE READ] Your Okteto installation has been using selfsigned certificates for more than a week. It's important to use your own certificates before production use.")
 				}
@@ -640,7 +688,7 @@ func GetContextCertificateStateless(certB64 string) (*x509.Certificate, error) {
 				case hoursSinceInstall <= float64(hoursInADay): // less than 1 day
 					oktetoLog.Information("Your Okteto installation is using selfsigned certificates. Please switch to your own certificates before production use.")
 				case hoursSinceInstall <= float64(hoursInAWeek): // less than 1 week
-					oktetoLog.Warning("Your Okteto installation has been using selfsigned certificates for more than a day. It's important to use your own certificates before production use.")
+					oktetoLog.WarningWithID(oktetoLog.WarnSelfSignedCertificates, "Your Okteto installation has been using selfsigned certificates for more than a day. It's important to use your own certificates before production use.")
 				default: // more than 1 week
 					oktetoLog.Fail("[PLEASE READ] Your Okteto installation has been using selfsigned certificates for more than a week. It's important to use your own certificates before production use.")
 				}
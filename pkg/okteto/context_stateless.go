@@ -38,6 +38,11 @@ type ContextInterface interface {
 	UseContextByBuilder()
 	GetTokenByContextName(name string) (string, error)
 	GetRegistryURL() string
+	GetRegistryTemplates() map[string]string
+	GetRegistryMirrors() map[string][]string
+	GetRegistryRetry() *RegistryRetry
+	GetTagStrategy() string
+	GetBuildBackend() string
 }
 
 type ContextStateless struct {
@@ -106,6 +111,36 @@ func (oc *ContextStateless) GetRegistryURL() string {
 	return oc.getCurrentOktetoContext().Registry
 }
 
+func (oc *ContextStateless) GetRegistryTemplates() map[string]string {
+	return oc.getCurrentOktetoContext().RegistryTemplates
+}
+
+// GetTagStrategy returns the tag strategy of the current context. It is optional, so no context is not an error
+func (oc *ContextStateless) GetTagStrategy() string {
+	octx, ok := oc.Store.Contexts[oc.Store.CurrentContext]
+	if !ok {
+		return ""
+	}
+	return octx.TagStrategy
+}
+
+// GetBuildBackend returns the default builder backend of the current context. It is optional, so no context is not an error
+func (oc *ContextStateless) GetBuildBackend() string {
+	octx, ok := oc.Store.Contexts[oc.Store.CurrentContext]
+	if !ok {
+		return ""
+	}
+	return octx.BuildBackend
+}
+
+func (oc *ContextStateless) GetRegistryMirrors() map[string][]string {
+	return oc.getCurrentOktetoContext().RegistryMirrors
+}
+
+func (oc *ContextStateless) GetRegistryRetry() *RegistryRetry {
+	return oc.getCurrentOktetoContext().RegistryRetry
+}
+
 func (oc *ContextStateless) GetGlobalNamespace() string {
 	return oc.getCurrentOktetoContext().GlobalNamespace
 }
@@ -25,6 +25,21 @@ var (
 
 	// ErrUnauthorizedGlobalCreation is raised when the user try to create a global preview without permission
 	ErrUnauthorizedGlobalCreation = errors.New("you are not authorized to create a global preview env")
+
+	// ErrPreviewScopeUpdateNotSupported is raised when the okteto instance doesn't support changing the scope of a preview
+	ErrPreviewScopeUpdateNotSupported = errors.New("changing the scope of a preview environment requires a more recent version of Okteto")
+
+	// ErrDeployPipelineCommitNotSupported is raised when the okteto instance doesn't support deploying a pipeline from a commit
+	ErrDeployPipelineCommitNotSupported = errors.New("deploying a pipeline from a specific commit or tag requires a more recent version of Okteto")
+
+	// ErrPipelineScheduleNotSupported is raised when the okteto instance doesn't support scheduled redeploys
+	ErrPipelineScheduleNotSupported = errors.New("scheduling the redeploy of a pipeline requires a more recent version of Okteto")
+
+	// ErrShareNotSupported is raised when the okteto instance doesn't support share links
+	ErrShareNotSupported = errors.New("sharing endpoints requires a more recent version of Okteto")
+
+	// ErrVariablesNotSupported is raised when the okteto instance doesn't support managing variables from the CLI
+	ErrVariablesNotSupported = errors.New("managing Okteto variables from the CLI requires a more recent version of Okteto")
 )
 
 type pipelineTimeoutError struct {
@@ -0,0 +1,69 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Impersonation is the user and groups the requests to the Kubernetes API are made as
+type Impersonation struct {
+	User   string
+	Groups []string
+}
+
+var impersonation Impersonation
+
+// SetImpersonation sets the user and groups the requests to the Kubernetes API are made as
+func SetImpersonation(user string, groups []string) {
+	impersonation = Impersonation{User: user, Groups: groups}
+	if impersonation.IsEnabled() {
+		oktetoLog.Debugf("impersonating user %q and groups [%s]", user, strings.Join(groups, ", "))
+	}
+}
+
+// GetImpersonation returns the user and groups the requests to the Kubernetes API are made as
+func GetImpersonation() Impersonation {
+	return impersonation
+}
+
+// IsEnabled returns true if the requests to the Kubernetes API are impersonated
+func (i Impersonation) IsEnabled() bool {
+	return i.User != "" || len(i.Groups) != 0
+}
+
+// ApplyImpersonation sets the impersonation to the credentials of the current context of a kubeconfig.
+// Clients created from the kubeconfig and kubeconfig files written from it impersonate the user and groups
+func ApplyImpersonation(cfg *clientcmdapi.Config) {
+	if cfg == nil || !impersonation.IsEnabled() {
+		return
+	}
+	kubeCtx, ok := cfg.Contexts[cfg.CurrentContext]
+	if !ok {
+		return
+	}
+	if cfg.AuthInfos == nil {
+		cfg.AuthInfos = map[string]*clientcmdapi.AuthInfo{}
+	}
+	authInfo, ok := cfg.AuthInfos[kubeCtx.AuthInfo]
+	if !ok {
+		authInfo = clientcmdapi.NewAuthInfo()
+		cfg.AuthInfos[kubeCtx.AuthInfo] = authInfo
+	}
+	authInfo.Impersonate = impersonation.User
+	authInfo.ImpersonateGroups = impersonation.Groups
+}
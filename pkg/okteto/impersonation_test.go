@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func newImpersonationCfg() *clientcmdapi.Config {
+	return &clientcmdapi.Config{
+		CurrentContext: "cluster",
+		Contexts: map[string]*clientcmdapi.Context{
+			"cluster": {Cluster: "cluster", AuthInfo: "admin", Namespace: "test"},
+		},
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"cluster": {Server: "https://cluster.okteto.dev"},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"admin": {Token: "token"},
+		},
+	}
+}
+
+func TestApplyImpersonation(t *testing.T) {
+	t.Cleanup(func() { SetImpersonation("", nil) })
+
+	cfg := newImpersonationCfg()
+	ApplyImpersonation(cfg)
+	assert.Empty(t, cfg.AuthInfos["admin"].Impersonate)
+	assert.False(t, GetImpersonation().IsEnabled())
+
+	SetImpersonation("cindy@okteto.com", []string{"developers", "qa"})
+	ApplyImpersonation(cfg)
+	assert.Equal(t, "cindy@okteto.com", cfg.AuthInfos["admin"].Impersonate)
+	assert.Equal(t, []string{"developers", "qa"}, cfg.AuthInfos["admin"].ImpersonateGroups)
+	assert.Equal(t, "token", cfg.AuthInfos["admin"].Token)
+
+	_, restConfig, err := getK8sClientWithApiConfig(cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "cindy@okteto.com", restConfig.Impersonate.UserName)
+	assert.Equal(t, []string{"developers", "qa"}, restConfig.Impersonate.Groups)
+}
+
+func TestApplyImpersonationWithoutAuthInfo(t *testing.T) {
+	t.Cleanup(func() { SetImpersonation("", nil) })
+	SetImpersonation("", []string{"developers"})
+
+	cfg := newImpersonationCfg()
+	cfg.Contexts["cluster"].AuthInfo = "missing"
+	ApplyImpersonation(cfg)
+	assert.Equal(t, []string{"developers"}, cfg.AuthInfos["missing"].ImpersonateGroups)
+
+	cfg.CurrentContext = "unknown"
+	assert.NotPanics(t, func() { ApplyImpersonation(cfg) })
+	assert.NotPanics(t, func() { ApplyImpersonation(nil) })
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/spf13/afero"
+)
+
+var (
+	errLicenseWithoutID = errors.New("the license file doesn't have an 'id'")
+	errLicenseExpired   = errors.New("the license has expired")
+)
+
+// License is the license of an enterprise install. Offline licenses are used by air-gapped installs,
+// where the okteto CLI doesn't send telemetry nor check for new releases
+type License struct {
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	ID        string     `json:"id" yaml:"id"`
+	Company   string     `json:"company,omitempty" yaml:"company,omitempty"`
+	Offline   bool       `json:"offline,omitempty" yaml:"offline,omitempty"`
+}
+
+// ReadLicenseFile reads and validates a license or offline activation file
+func ReadLicenseFile(fs afero.Fs, path string) (*License, error) {
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read license file '%s': %w", path, err)
+	}
+	license := &License{}
+	if err := json.Unmarshal(b, license); err != nil {
+		return nil, fmt.Errorf("failed to parse license file '%s': %w", path, err)
+	}
+	if err := license.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+	return license, nil
+}
+
+// Validate checks that the license has an id and it's not expired
+func (l *License) Validate(now time.Time) error {
+	if l.ID == "" {
+		return errLicenseWithoutID
+	}
+	if l.ExpiresAt != nil && now.After(*l.ExpiresAt) {
+		return fmt.Errorf("%w on %s", errLicenseExpired, l.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// IsOffline returns if the okteto CLI runs in an air-gapped install. It is enabled by the 'OKTETO_OFFLINE'
+// environment variable, so it's available on first boot, or by an offline license in the current context
+func IsOffline() bool {
+	if env.LoadBoolean(constants.OktetoOfflineEnvVar) {
+		return true
+	}
+	ctxStore := GetContextStore()
+	okCtx, ok := ctxStore.Contexts[ctxStore.CurrentContext]
+	if !ok {
+		return false
+	}
+	return okCtx.License != nil && okCtx.License.Offline
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLicenseFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "license.json", []byte(`{"id": "lic-1", "company": "acme", "offline": true, "expiresAt": "2100-01-01T00:00:00Z"}`), 0600))
+	require.NoError(t, afero.WriteFile(fs, "expired.json", []byte(`{"id": "lic-1", "expiresAt": "2000-01-01T00:00:00Z"}`), 0600))
+	require.NoError(t, afero.WriteFile(fs, "no-id.json", []byte(`{"offline": true}`), 0600))
+	require.NoError(t, afero.WriteFile(fs, "invalid.json", []byte(`offline`), 0600))
+
+	license, err := ReadLicenseFile(fs, "license.json")
+	require.NoError(t, err)
+	assert.Equal(t, "lic-1", license.ID)
+	assert.Equal(t, "acme", license.Company)
+	assert.True(t, license.Offline)
+
+	_, err = ReadLicenseFile(fs, "expired.json")
+	assert.ErrorIs(t, err, errLicenseExpired)
+
+	_, err = ReadLicenseFile(fs, "no-id.json")
+	assert.ErrorIs(t, err, errLicenseWithoutID)
+
+	_, err = ReadLicenseFile(fs, "invalid.json")
+	assert.Error(t, err)
+
+	_, err = ReadLicenseFile(fs, "not-found.json")
+	assert.Error(t, err)
+}
+
+func TestLicenseValidate(t *testing.T) {
+	expiresAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	license := &License{ID: "lic-1", ExpiresAt: &expiresAt}
+
+	assert.NoError(t, license.Validate(expiresAt.Add(-time.Hour)))
+	assert.ErrorIs(t, license.Validate(expiresAt.Add(time.Hour)), errLicenseExpired)
+	assert.NoError(t, (&License{ID: "lic-1"}).Validate(time.Now()))
+}
+
+func TestIsOffline(t *testing.T) {
+	previousStore := CurrentStore
+	defer func() { CurrentStore = previousStore }()
+
+	CurrentStore = &ContextStore{
+		Contexts:       map[string]*Context{"test": {Name: "test"}},
+		CurrentContext: "test",
+	}
+	assert.False(t, IsOffline())
+
+	CurrentStore.Contexts["test"].License = &License{ID: "lic-1", Offline: true}
+	assert.True(t, IsOffline())
+
+	CurrentStore.Contexts["test"].License = &License{ID: "lic-1"}
+	assert.False(t, IsOffline())
+
+	t.Setenv(constants.OktetoOfflineEnvVar, "true")
+	assert.True(t, IsOffline())
+}
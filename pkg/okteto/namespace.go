@@ -55,6 +55,10 @@ type listNamespacesQuery struct {
 	Response []namespaceStatus `graphql:"spaces"`
 }
 
+type listNamespacesPageQuery struct {
+	Response []namespaceStatus `graphql:"spaces(limit: $limit, offset: $offset)"`
+}
+
 type getNamespaceQuery struct {
 	Response namespaceStatus `graphql:"space(id: $id)"`
 }
@@ -98,17 +102,28 @@ func (c *namespaceClient) Create(ctx context.Context, namespace string) (string,
 	return string(mutation.Response.Id), nil
 }
 
-// List lists the namespaces
+// List lists the namespaces. They are requested in pages, so the API doesn't time out with thousands of namespaces
 func (c *namespaceClient) List(ctx context.Context) ([]types.Namespace, error) {
-	var queryStruct listNamespacesQuery
-
-	err := query(ctx, &queryStruct, nil, c.client)
+	spaces, err := paginate(func(offset int) ([]namespaceStatus, error) {
+		var queryStruct listNamespacesPageQuery
+		variables := map[string]interface{}{
+			"limit":  graphql.Int(listPageSize),
+			"offset": graphql.Int(offset),
+		}
+		if err := query(ctx, &queryStruct, variables, c.client); err != nil {
+			return nil, err
+		}
+		return queryStruct.Response, nil
+	})
+	if isPaginationNotSupportedErr(err) {
+		spaces, err = c.listWithoutPagination(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]types.Namespace, 0)
-	for _, space := range queryStruct.Response {
+	for _, space := range spaces {
 		result = append(result, types.Namespace{
 			ID:     string(space.Id),
 			Status: string(space.Status),
@@ -118,6 +133,15 @@ func (c *namespaceClient) List(ctx context.Context) ([]types.Namespace, error) {
 	return result, nil
 }
 
+// TODO: Remove it when all the Okteto instances support paginated lists
+func (c *namespaceClient) listWithoutPagination(ctx context.Context) ([]namespaceStatus, error) {
+	var queryStruct listNamespacesQuery
+	if err := query(ctx, &queryStruct, nil, c.client); err != nil {
+		return nil, err
+	}
+	return queryStruct.Response, nil
+}
+
 // AddMembers adds members to a namespace
 func (c *namespaceClient) AddMembers(ctx context.Context, namespace string, members []string) error {
 	var mutation addMembersMutation
@@ -0,0 +1,41 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"strings"
+)
+
+// listPageSize is the number of items requested to the Okteto API in each page of a list
+const listPageSize = 100
+
+// isPaginationNotSupportedErr returns true if the Okteto API doesn't support paginated lists
+func isPaginationNotSupportedErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Unknown argument \"limit\"")
+}
+
+// paginate calls getPage until a page has less items than the page size and returns the items of every page
+func paginate[T any](getPage func(offset int) ([]T, error)) ([]T, error) {
+	result := make([]T, 0)
+	for offset := 0; ; offset += listPageSize {
+		page, err := getPage(offset)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page...)
+		if len(page) < listPageSize {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/shurcooL/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePaginatedGraphQLClient serves namespaces in pages, or fails paginated queries if pagination is not supported
+type fakePaginatedGraphQLClient struct {
+	offsets          *[]int
+	namespaces       []namespaceStatus
+	noPagination     bool
+	paginationErrMsg string
+}
+
+func (fc fakePaginatedGraphQLClient) Query(_ context.Context, q interface{}, variables map[string]interface{}) error {
+	switch query := q.(type) {
+	case *listNamespacesPageQuery:
+		if fc.noPagination {
+			return errors.New(fc.paginationErrMsg)
+		}
+		offset := int(variables["offset"].(graphql.Int))
+		*fc.offsets = append(*fc.offsets, offset)
+		end := offset + int(variables["limit"].(graphql.Int))
+		if end > len(fc.namespaces) {
+			end = len(fc.namespaces)
+		}
+		query.Response = fc.namespaces[offset:end]
+	case *listNamespacesQuery:
+		query.Response = fc.namespaces
+	}
+	return nil
+}
+
+func (fakePaginatedGraphQLClient) Mutate(context.Context, interface{}, map[string]interface{}) error {
+	return nil
+}
+
+func newFakeNamespaces(n int) ([]namespaceStatus, []types.Namespace) {
+	spaces := make([]namespaceStatus, 0, n)
+	expected := make([]types.Namespace, 0, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("ns-%d", i)
+		spaces = append(spaces, namespaceStatus{Id: graphql.String(id), Status: RunningStatus})
+		expected = append(expected, types.Namespace{ID: id, Status: RunningStatus})
+	}
+	return spaces, expected
+}
+
+func TestListNamespacesPaginated(t *testing.T) {
+	spaces, expected := newFakeNamespaces(2*listPageSize + 5)
+	offsets := []int{}
+	nc := &namespaceClient{
+		client: fakePaginatedGraphQLClient{namespaces: spaces, offsets: &offsets},
+	}
+
+	result, err := nc.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+	assert.Equal(t, []int{0, listPageSize, 2 * listPageSize}, offsets)
+}
+
+func TestListNamespacesWithoutPagination(t *testing.T) {
+	spaces, expected := newFakeNamespaces(3)
+	offsets := []int{}
+	nc := &namespaceClient{
+		client: fakePaginatedGraphQLClient{
+			namespaces:       spaces,
+			offsets:          &offsets,
+			noPagination:     true,
+			paginationErrMsg: "Unknown argument \"limit\" on field \"spaces\" of type \"Query\"",
+		},
+	}
+
+	result, err := nc.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+	assert.Empty(t, offsets)
+
+	nc.client = fakePaginatedGraphQLClient{
+		offsets:          &offsets,
+		noPagination:     true,
+		paginationErrMsg: "server error",
+	}
+	_, err = nc.List(context.Background())
+	assert.Error(t, err)
+}
+
+func TestPaginate(t *testing.T) {
+	result, err := paginate(func(offset int) ([]int, error) {
+		if offset == 0 {
+			return make([]int, listPageSize), nil
+		}
+		return []int{1}, nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, result, listPageSize+1)
+
+	_, err = paginate(func(int) ([]int, error) {
+		return nil, assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+}
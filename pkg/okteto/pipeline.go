@@ -60,6 +60,10 @@ type deployPipelineMutationWithLabels struct {
 	Response deployPipelineResponse `graphql:"deployGitRepository(name: $name, repository: $repository, space: $space, branch: $branch, variables: $variables, filename: $filename, labels: $labels)"`
 }
 
+type deployPipelineMutationWithCommit struct {
+	Response deployPipelineResponse `graphql:"deployGitRepository(name: $name, repository: $repository, space: $space, branch: $branch, variables: $variables, filename: $filename, labels: $labels, commit: $commit)"`
+}
+
 type getPipelineByNameQuery struct {
 	Response getPipelineByNameResponse `graphql:"space(id: $id)"`
 }
@@ -72,6 +76,18 @@ type destroyPipelineWithoutVolumesMutation struct {
 	Response destroyPipelineResponse `graphql:"destroyGitRepository(name: $name, space: $space)"`
 }
 
+type setPipelineScheduleMutation struct {
+	Response pipelineScheduleResponse `graphql:"setGitDeploySchedule(name: $name, space: $space, schedule: $schedule)"`
+}
+
+type removePipelineScheduleMutation struct {
+	Response pipelineScheduleResponse `graphql:"removeGitDeploySchedule(name: $name, space: $space)"`
+}
+
+type listPipelineSchedulesQuery struct {
+	Response listPipelineSchedulesResponse `graphql:"space(id: $id)"`
+}
+
 type getPipelineResources struct {
 	Response previewResourcesStatus `graphql:"space(id: $id)"`
 }
@@ -98,6 +114,21 @@ type gitDeployInfoIdNameStatus struct {
 	Status graphql.String
 }
 
+type pipelineScheduleResponse struct {
+	Name graphql.String
+}
+
+type listPipelineSchedulesResponse struct {
+	GitDeploySchedules []pipelineScheduleInfo
+}
+
+type pipelineScheduleInfo struct {
+	Name       graphql.String
+	Schedule   graphql.String
+	Repository graphql.String
+	Branch     graphql.String
+}
+
 type destroyPipelineResponse struct {
 	Action    actionStruct
 	GitDeploy gitDeployInfoWithRepoInfo
@@ -109,14 +140,25 @@ func (c *pipelineClient) Deploy(ctx context.Context, opts types.PipelineDeployOp
 
 	mutationVariables := c.getDeployVariables(opts)
 	var response deployPipelineResponse
-	if len(opts.Labels) == 0 {
+	switch {
+	case opts.Commit != "":
+		mutationStruct := &deployPipelineMutationWithCommit{}
+		err := mutate(ctx, mutationStruct, mutationVariables, c.client)
+		if err != nil {
+			if strings.Contains(err.Error(), "Unknown argument \"commit\" on field \"deployGitRepository\" of type \"Mutation\"") {
+				return nil, oktetoErrors.UserError{E: ErrDeployPipelineCommitNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
+			}
+			return nil, fmt.Errorf("failed to deploy pipeline: %w", err)
+		}
+		response = mutationStruct.Response
+	case len(opts.Labels) == 0:
 		mutationStruct := &deployPipelineMutation{}
 		err := mutate(ctx, mutationStruct, mutationVariables, c.client)
 		if err != nil {
 			return nil, fmt.Errorf("failed to deploy pipeline: %w", err)
 		}
 		response = mutationStruct.Response
-	} else {
+	default:
 		mutationStruct := &deployPipelineMutationWithLabels{}
 		err := mutate(ctx, mutationStruct, mutationVariables, c.client)
 
@@ -175,13 +217,16 @@ func (c *pipelineClient) getDeployVariables(opts types.PipelineDeployOptions) ma
 		"filename":   graphql.String(opts.Filename),
 	}
 
-	if len(opts.Labels) > 0 {
+	if len(opts.Labels) > 0 || opts.Commit != "" {
 		labelsVariable := make(labelList, 0)
 		for _, l := range opts.Labels {
 			labelsVariable = append(labelsVariable, graphql.String(l))
 		}
 		vars["labels"] = labelsVariable
 	}
+	if opts.Commit != "" {
+		vars["commit"] = graphql.String(opts.Commit)
+	}
 	return vars
 }
 
@@ -209,6 +254,68 @@ func (c *pipelineClient) GetByName(ctx context.Context, name, namespace string)
 	return nil, oktetoErrors.ErrNotFound
 }
 
+// SetSchedule registers a recurring redeploy of a pipeline. schedule is a cron expression
+func (c *pipelineClient) SetSchedule(ctx context.Context, name, namespace, schedule string) error {
+	oktetoLog.Infof("scheduling pipeline '%s' in namespace '%s' with '%s'", name, namespace, schedule)
+	var mutation setPipelineScheduleMutation
+	variables := map[string]interface{}{
+		"name":     graphql.String(name),
+		"space":    graphql.String(namespace),
+		"schedule": graphql.String(schedule),
+	}
+	if err := mutate(ctx, &mutation, variables, c.client); err != nil {
+		return translatePipelineScheduleErr(err, "setGitDeploySchedule", fmt.Sprintf("failed to schedule pipeline '%s'", name))
+	}
+	return nil
+}
+
+// ListSchedules lists the recurring redeploys of the pipelines of a namespace
+func (c *pipelineClient) ListSchedules(ctx context.Context, namespace string) ([]types.PipelineSchedule, error) {
+	var queryStruct listPipelineSchedulesQuery
+	variables := map[string]interface{}{
+		"id": graphql.String(namespace),
+	}
+	if err := query(ctx, &queryStruct, variables, c.client); err != nil {
+		return nil, translatePipelineScheduleErr(err, "gitDeploySchedules", "failed to list pipeline schedules")
+	}
+
+	result := make([]types.PipelineSchedule, 0)
+	for _, s := range queryStruct.Response.GitDeploySchedules {
+		result = append(result, types.PipelineSchedule{
+			Name:       string(s.Name),
+			Schedule:   string(s.Schedule),
+			Repository: string(s.Repository),
+			Branch:     string(s.Branch),
+		})
+	}
+	return result, nil
+}
+
+// RemoveSchedule removes the recurring redeploy of a pipeline
+func (c *pipelineClient) RemoveSchedule(ctx context.Context, name, namespace string) error {
+	oktetoLog.Infof("removing schedule of pipeline '%s' in namespace '%s'", name, namespace)
+	var mutation removePipelineScheduleMutation
+	variables := map[string]interface{}{
+		"name":  graphql.String(name),
+		"space": graphql.String(namespace),
+	}
+	if err := mutate(ctx, &mutation, variables, c.client); err != nil {
+		return translatePipelineScheduleErr(err, "removeGitDeploySchedule", fmt.Sprintf("failed to remove schedule of pipeline '%s'", name))
+	}
+	return nil
+}
+
+func translatePipelineScheduleErr(err error, field, msg string) error {
+	switch {
+	case strings.Contains(err.Error(), fmt.Sprintf("Cannot query field \"%s\"", field)):
+		return oktetoErrors.UserError{E: ErrPipelineScheduleNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
+	case oktetoErrors.IsNotFound(err):
+		return oktetoErrors.ErrNotFound
+	default:
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+}
+
 // Destroy destroys a pipeline
 func (c *pipelineClient) Destroy(ctx context.Context, name, namespace string, destroyVolumes bool) (*types.GitDeployResponse, error) {
 	oktetoLog.Infof("destroy pipeline: %s/%s", namespace, name)
@@ -20,6 +20,7 @@ import (
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/shurcooL/graphql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,6 +30,7 @@ func TestDeployPipeline(t *testing.T) {
 		name      string
 		variables []types.Variable
 		labels    []string
+		commit    string
 	}
 	type expected struct {
 		response *types.GitDeployResponse
@@ -233,6 +235,59 @@ func TestDeployPipeline(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with commit - deprecation error",
+			input: input{
+				client: &fakeGraphQLClient{
+					err: fmt.Errorf("Unknown argument \"commit\" on field \"deployGitRepository\" of type \"Mutation\""),
+				},
+				name:   "test",
+				commit: "0123456789abcdef0123456789abcdef01234567",
+			},
+			expected: expected{
+				response: nil,
+				err:      ErrDeployPipelineCommitNotSupported,
+			},
+		},
+		{
+			name: "with commit - no error",
+			input: input{
+				client: &fakeGraphQLClient{
+					mutationResult: &deployPipelineMutationWithCommit{
+						Response: deployPipelineResponse{
+							Action: actionStruct{
+								Id:     "test",
+								Name:   "test",
+								Status: ProgressingStatus,
+							},
+							GitDeploy: gitDeployInfoWithRepoInfo{
+								Id:         "test",
+								Name:       "test",
+								Status:     ProgressingStatus,
+								Repository: "my-repo",
+							},
+						},
+					},
+				},
+				name:   "test",
+				commit: "0123456789abcdef0123456789abcdef01234567",
+			},
+			expected: expected{
+				response: &types.GitDeployResponse{
+					Action: &types.Action{
+						ID:     "test",
+						Name:   "test",
+						Status: progressingStatus,
+					},
+					GitDeploy: &types.GitDeploy{
+						ID:         "test",
+						Name:       "test",
+						Repository: "my-repo",
+						Status:     progressingStatus,
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -243,6 +298,7 @@ func TestDeployPipeline(t *testing.T) {
 				Name:      tc.input.name,
 				Variables: tc.input.variables,
 				Labels:    tc.input.labels,
+				Commit:    tc.input.commit,
 			})
 			assert.ErrorIs(t, err, tc.expected.err)
 			assert.Equal(t, tc.expected.response, response)
@@ -250,6 +306,17 @@ func TestDeployPipeline(t *testing.T) {
 	}
 }
 
+func TestGetDeployVariablesWithCommit(t *testing.T) {
+	pc := pipelineClient{}
+	vars := pc.getDeployVariables(types.PipelineDeployOptions{Name: "test", Commit: "0123456"})
+	assert.Equal(t, graphql.String("0123456"), vars["commit"])
+	assert.Equal(t, labelList{}, vars["labels"])
+
+	vars = pc.getDeployVariables(types.PipelineDeployOptions{Name: "test"})
+	assert.NotContains(t, vars, "commit")
+	assert.NotContains(t, vars, "labels")
+}
+
 func TestGetPipelineByName(t *testing.T) {
 	type input struct {
 		client *fakeGraphQLClient
@@ -801,3 +868,78 @@ func Test_getResourceFullName(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPipelineSchedule(t *testing.T) {
+	testCases := []struct {
+		client      *fakeGraphQLClient
+		expectedErr error
+		name        string
+	}{
+		{
+			name: "no error",
+			client: &fakeGraphQLClient{
+				mutationResult: &setPipelineScheduleMutation{
+					Response: pipelineScheduleResponse{
+						Name: "test",
+					},
+				},
+			},
+		},
+		{
+			name: "not supported",
+			client: &fakeGraphQLClient{
+				err: fmt.Errorf("Cannot query field \"setGitDeploySchedule\" on type \"Mutation\""),
+			},
+			expectedErr: ErrPipelineScheduleNotSupported,
+		},
+		{
+			name: "error",
+			client: &fakeGraphQLClient{
+				err: assert.AnError,
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := newPipelineClient(tc.client, "")
+			err := pc.SetSchedule(context.Background(), "test", "ns", "0 3 * * *")
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestListPipelineSchedules(t *testing.T) {
+	pc := newPipelineClient(&fakeGraphQLClient{
+		queryResult: &listPipelineSchedulesQuery{
+			Response: listPipelineSchedulesResponse{
+				GitDeploySchedules: []pipelineScheduleInfo{
+					{
+						Name:       "test",
+						Schedule:   "@daily",
+						Repository: "https://github.com/okteto/movies",
+						Branch:     "main",
+					},
+				},
+			},
+		},
+	}, "")
+	schedules, err := pc.ListSchedules(context.Background(), "ns")
+	assert.NoError(t, err)
+	assert.Equal(t, []types.PipelineSchedule{
+		{
+			Name:       "test",
+			Schedule:   "@daily",
+			Repository: "https://github.com/okteto/movies",
+			Branch:     "main",
+		},
+	}, schedules)
+}
+
+func TestRemovePipelineSchedule(t *testing.T) {
+	pc := newPipelineClient(&fakeGraphQLClient{
+		err: fmt.Errorf("schedule not found"),
+	}, "")
+	err := pc.RemoveSchedule(context.Background(), "test", "ns")
+	assert.ErrorIs(t, err, oktetoErrors.ErrNotFound)
+}
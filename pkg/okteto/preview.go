@@ -77,6 +77,10 @@ func (d *deployPreviewMutationWithLabels) response() deployPreviewResponse {
 	return d.Response
 }
 
+type updatePreviewScopeMutation struct {
+	Response previewIDStruct `graphql:"updatePreviewScope(id: $id, scope: $scope)"`
+}
+
 type destroyPreviewMutation struct {
 	Response previewIDStruct `graphql:"destroyPreview(id: $id)"`
 }
@@ -85,6 +89,10 @@ type listPreviewQuery struct {
 	Response []previewEnv `graphql:"previews(labels: $labels)"`
 }
 
+type listPreviewPageQuery struct {
+	Response []previewEnv `graphql:"previews(labels: $labels, limit: $limit, offset: $offset)"`
+}
+
 type listPreviewQueryDeprecated struct {
 	Response []deprecatedPreviewEnv `graphql:"previews"`
 }
@@ -252,19 +260,60 @@ func (c *previewClient) Destroy(ctx context.Context, name string) error {
 	return err
 }
 
-// List lists preview environments
-func (c *previewClient) List(ctx context.Context, labels []string) ([]types.Preview, error) {
-	queryStruct := listPreviewQuery{}
+// UpdateScope changes the scope of a preview environment
+func (c *previewClient) UpdateScope(ctx context.Context, name, scope string) error {
+	mutationStruct := updatePreviewScopeMutation{}
+	variables := map[string]interface{}{
+		"id":    graphql.String(name),
+		"scope": PreviewScope(scope),
+	}
 
-	variables := map[string]interface{}{}
+	if err := mutate(ctx, &mutationStruct, variables, c.client); err != nil {
+		if strings.Contains(err.Error(), "Cannot query field \"updatePreviewScope\"") {
+			return oktetoErrors.UserError{E: ErrPreviewScopeUpdateNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
+		}
+		return c.translateErr(err, name)
+	}
+	return nil
+}
+
+// List lists preview environments. They are requested in pages, so the API doesn't time out with thousands of previews
+func (c *previewClient) List(ctx context.Context, labels []string) ([]types.Preview, error) {
 	labelsVariable := make(labelList, 0)
 	for _, l := range labels {
 		labelsVariable = append(labelsVariable, graphql.String(l))
 	}
-	variables["labels"] = labelsVariable
+
+	previews, err := paginate(func(offset int) ([]previewEnv, error) {
+		queryStruct := listPreviewPageQuery{}
+		variables := map[string]interface{}{
+			"labels": labelsVariable,
+			"limit":  graphql.Int(listPageSize),
+			"offset": graphql.Int(offset),
+		}
+		if err := query(ctx, &queryStruct, variables, c.client); err != nil {
+			return nil, err
+		}
+		return queryStruct.Response, nil
+	})
+	if isPaginationNotSupportedErr(err) || isLabelsNotSupportedErr(err) {
+		return c.listWithoutPagination(ctx, labelsVariable)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return translatePreviewList(previews), nil
+}
+
+// TODO: Remove it when all the Okteto instances support paginated lists
+func (c *previewClient) listWithoutPagination(ctx context.Context, labels labelList) ([]types.Preview, error) {
+	queryStruct := listPreviewQuery{}
+
+	variables := map[string]interface{}{}
+	variables["labels"] = labels
 	err := query(ctx, &queryStruct, variables, c.client)
 	if err != nil {
-		if strings.Contains(err.Error(), "Unknown argument \"labels\" on field \"previews\" of type \"Query\"") {
+		if isLabelsNotSupportedErr(err) {
 			if len(labels) > 0 {
 				return nil, oktetoErrors.UserError{E: ErrLabelsFeatureNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
 			}
@@ -272,9 +321,17 @@ func (c *previewClient) List(ctx context.Context, labels []string) ([]types.Prev
 		}
 		return nil, err
 	}
+	return translatePreviewList(queryStruct.Response), nil
+}
+
+// isLabelsNotSupportedErr returns true if the Okteto API doesn't support listing previews by labels
+func isLabelsNotSupportedErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Unknown argument \"labels\" on field \"previews\" of type \"Query\"")
+}
 
+func translatePreviewList(previews []previewEnv) []types.Preview {
 	result := make([]types.Preview, 0)
-	for _, previewEnv := range queryStruct.Response {
+	for _, previewEnv := range previews {
 		labels := make([]string, 0)
 		for _, l := range previewEnv.PreviewLabels {
 			labels = append(labels, string(l))
@@ -287,8 +344,7 @@ func (c *previewClient) List(ctx context.Context, labels []string) ([]types.Prev
 			Branch:        string(previewEnv.Branch),
 		})
 	}
-
-	return result, nil
+	return result
 }
 
 // TODO: Remove it when all charts are updated to 1.9
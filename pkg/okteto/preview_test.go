@@ -286,6 +286,55 @@ func TestDestroyPreview(t *testing.T) {
 	}
 }
 
+func TestUpdatePreviewScope(t *testing.T) {
+	testCases := []struct {
+		client      *fakeGraphQLClient
+		expectedErr error
+		name        string
+	}{
+		{
+			name: "no error",
+			client: &fakeGraphQLClient{
+				mutationResult: &updatePreviewScopeMutation{
+					Response: previewIDStruct{
+						Id: "test",
+					},
+				},
+			},
+		},
+		{
+			name: "not supported",
+			client: &fakeGraphQLClient{
+				err: errors.New("Cannot query field \"updatePreviewScope\" on type \"Mutation\""),
+			},
+			expectedErr: ErrPreviewScopeUpdateNotSupported,
+		},
+		{
+			name: "not permitted",
+			client: &fakeGraphQLClient{
+				err: errors.New("operation-not-permitted"),
+			},
+			expectedErr: ErrUnauthorizedGlobalCreation,
+		},
+		{
+			name: "error",
+			client: &fakeGraphQLClient{
+				err: assert.AnError,
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := previewClient{
+				client: tc.client,
+			}
+			err := pc.UpdateScope(context.Background(), "test", "global")
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
 func TestListPreview(t *testing.T) {
 	type input struct {
 		client *fakeGraphQLClient
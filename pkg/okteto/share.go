@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/shurcooL/graphql"
+)
+
+type shareClient struct {
+	client graphqlClientInterface
+}
+
+func newShareClient(client graphqlClientInterface) *shareClient {
+	return &shareClient{
+		client: client,
+	}
+}
+
+type createShareLinkMutation struct {
+	Response shareLinkInfo `graphql:"createShareLink(space: $space, endpoint: $endpoint, expiresIn: $expiresIn)"`
+}
+
+type revokeShareLinkMutation struct {
+	Response revokeShareLinkResponse `graphql:"revokeShareLink(space: $space, id: $id)"`
+}
+
+type listShareLinksQuery struct {
+	Response listShareLinksResponse `graphql:"space(id: $id)"`
+}
+
+type shareLinkInfo struct {
+	Id        graphql.String
+	Endpoint  graphql.String
+	Url       graphql.String
+	ExpiresAt graphql.String
+	CreatedBy graphql.String
+}
+
+type revokeShareLinkResponse struct {
+	Id graphql.String
+}
+
+type listShareLinksResponse struct {
+	ShareLinks []shareLinkInfo
+}
+
+// Create creates a share link that exposes an endpoint of a namespace until it expires
+func (c *shareClient) Create(ctx context.Context, namespace, endpoint string, expiresIn time.Duration) (*types.ShareLink, error) {
+	oktetoLog.Infof("sharing endpoint '%s' of namespace '%s' for %s", endpoint, namespace, expiresIn)
+	var mutation createShareLinkMutation
+	variables := map[string]interface{}{
+		"space":     graphql.String(namespace),
+		"endpoint":  graphql.String(endpoint),
+		"expiresIn": graphql.Int(expiresIn.Seconds()),
+	}
+	if err := mutate(ctx, &mutation, variables, c.client); err != nil {
+		return nil, translateShareErr(err, "createShareLink", fmt.Sprintf("failed to share endpoint '%s'", endpoint))
+	}
+	link := translateShareLink(mutation.Response)
+	return &link, nil
+}
+
+// List lists the share links of a namespace
+func (c *shareClient) List(ctx context.Context, namespace string) ([]types.ShareLink, error) {
+	var queryStruct listShareLinksQuery
+	variables := map[string]interface{}{
+		"id": graphql.String(namespace),
+	}
+	if err := query(ctx, &queryStruct, variables, c.client); err != nil {
+		return nil, translateShareErr(err, "shareLinks", "failed to list share links")
+	}
+
+	result := make([]types.ShareLink, 0)
+	for _, l := range queryStruct.Response.ShareLinks {
+		result = append(result, translateShareLink(l))
+	}
+	return result, nil
+}
+
+// Revoke revokes a share link before it expires
+func (c *shareClient) Revoke(ctx context.Context, namespace, id string) error {
+	oktetoLog.Infof("revoking share link '%s' of namespace '%s'", id, namespace)
+	var mutation revokeShareLinkMutation
+	variables := map[string]interface{}{
+		"space": graphql.String(namespace),
+		"id":    graphql.String(id),
+	}
+	if err := mutate(ctx, &mutation, variables, c.client); err != nil {
+		return translateShareErr(err, "revokeShareLink", fmt.Sprintf("failed to revoke share link '%s'", id))
+	}
+	return nil
+}
+
+func translateShareLink(l shareLinkInfo) types.ShareLink {
+	link := types.ShareLink{
+		ID:        string(l.Id),
+		Endpoint:  string(l.Endpoint),
+		URL:       string(l.Url),
+		CreatedBy: string(l.CreatedBy),
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, string(l.ExpiresAt)); err == nil {
+		link.ExpiresAt = expiresAt
+	} else {
+		oktetoLog.Infof("failed to parse expiration of share link '%s': %s", link.ID, err)
+	}
+	return link
+}
+
+func translateShareErr(err error, field, msg string) error {
+	switch {
+	case strings.Contains(err.Error(), fmt.Sprintf("Cannot query field \"%s\"", field)):
+		return oktetoErrors.UserError{E: ErrShareNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
+	case oktetoErrors.IsNotFound(err):
+		return oktetoErrors.ErrNotFound
+	default:
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateShareLink(t *testing.T) {
+	testCases := []struct {
+		client      *fakeGraphQLClient
+		expected    *types.ShareLink
+		expectedErr error
+		name        string
+	}{
+		{
+			name: "no error",
+			client: &fakeGraphQLClient{
+				mutationResult: &createShareLinkMutation{
+					Response: shareLinkInfo{
+						Id:        "abc",
+						Endpoint:  "https://api-test.okteto.example.com",
+						Url:       "https://share.okteto.example.com/abc",
+						ExpiresAt: "2026-10-16T10:00:00Z",
+						CreatedBy: "cindy",
+					},
+				},
+			},
+			expected: &types.ShareLink{
+				ID:        "abc",
+				Endpoint:  "https://api-test.okteto.example.com",
+				URL:       "https://share.okteto.example.com/abc",
+				ExpiresAt: time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC),
+				CreatedBy: "cindy",
+			},
+		},
+		{
+			name: "not supported",
+			client: &fakeGraphQLClient{
+				err: fmt.Errorf("Cannot query field \"createShareLink\" on type \"Mutation\""),
+			},
+			expectedErr: ErrShareNotSupported,
+		},
+		{
+			name: "error",
+			client: &fakeGraphQLClient{
+				err: assert.AnError,
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := newShareClient(tc.client)
+			link, err := sc.Create(context.Background(), "test", "https://api-test.okteto.example.com", time.Hour)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, link)
+		})
+	}
+}
+
+func TestListShareLinks(t *testing.T) {
+	sc := newShareClient(&fakeGraphQLClient{
+		queryResult: &listShareLinksQuery{
+			Response: listShareLinksResponse{
+				ShareLinks: []shareLinkInfo{
+					{
+						Id:        "abc",
+						Endpoint:  "https://api-test.okteto.example.com",
+						Url:       "https://share.okteto.example.com/abc",
+						ExpiresAt: "2026-10-16T10:00:00Z",
+					},
+				},
+			},
+		},
+	})
+	links, err := sc.List(context.Background(), "test")
+	require.NoError(t, err)
+	assert.Equal(t, []types.ShareLink{
+		{
+			ID:        "abc",
+			Endpoint:  "https://api-test.okteto.example.com",
+			URL:       "https://share.okteto.example.com/abc",
+			ExpiresAt: time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC),
+		},
+	}, links)
+}
+
+func TestRevokeShareLink(t *testing.T) {
+	sc := newShareClient(&fakeGraphQLClient{
+		err: fmt.Errorf("share link not found"),
+	})
+	err := sc.Revoke(context.Background(), "test", "abc")
+	assert.ErrorIs(t, err, oktetoErrors.ErrNotFound)
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/shurcooL/graphql"
+)
+
+type variablesClient struct {
+	client graphqlClientInterface
+}
+
+func newVariablesClient(client graphqlClientInterface) *variablesClient {
+	return &variablesClient{
+		client: client,
+	}
+}
+
+type listVariablesQuery struct {
+	Response []variablesQuery `graphql:"variables(scope: $scope, space: $space)"`
+}
+
+type setVariablesMutation struct {
+	Response []variableNameResponse `graphql:"setVariables(scope: $scope, space: $space, variables: $variables)"`
+}
+
+type deleteVariablesMutation struct {
+	Response []variableNameResponse `graphql:"deleteVariables(scope: $scope, space: $space, names: $names)"`
+}
+
+type variableNameResponse struct {
+	Name graphql.String
+}
+
+// List lists the variables of a scope. namespace is ignored for the personal scope
+func (c *variablesClient) List(ctx context.Context, scope, namespace string) ([]env.Var, error) {
+	var queryStruct listVariablesQuery
+	variables := map[string]interface{}{
+		"scope": graphql.String(scope),
+		"space": graphql.String(namespace),
+	}
+	if err := query(ctx, &queryStruct, variables, c.client); err != nil {
+		return nil, translateVariablesErr(err, "variables", "failed to list variables")
+	}
+
+	result := make([]env.Var, 0)
+	for _, v := range queryStruct.Response {
+		result = append(result, env.Var{
+			Name:  string(v.Name),
+			Value: string(v.Value),
+		})
+	}
+	return result, nil
+}
+
+// Set creates or updates the variables of a scope. namespace is ignored for the personal scope
+func (c *variablesClient) Set(ctx context.Context, scope, namespace string, vars []env.Var) error {
+	oktetoLog.Infof("setting %d %s variables", len(vars), scope)
+	input := make([]InputVariable, 0, len(vars))
+	for _, v := range vars {
+		input = append(input, InputVariable{
+			Name:  graphql.String(v.Name),
+			Value: graphql.String(v.Value),
+		})
+	}
+	var mutation setVariablesMutation
+	variables := map[string]interface{}{
+		"scope":     graphql.String(scope),
+		"space":     graphql.String(namespace),
+		"variables": input,
+	}
+	if err := mutate(ctx, &mutation, variables, c.client); err != nil {
+		return translateVariablesErr(err, "setVariables", "failed to set variables")
+	}
+	return nil
+}
+
+// Delete removes the variables of a scope. namespace is ignored for the personal scope
+func (c *variablesClient) Delete(ctx context.Context, scope, namespace string, names []string) error {
+	oktetoLog.Infof("deleting %d %s variables", len(names), scope)
+	input := make([]graphql.String, 0, len(names))
+	for _, name := range names {
+		input = append(input, graphql.String(name))
+	}
+	var mutation deleteVariablesMutation
+	variables := map[string]interface{}{
+		"scope": graphql.String(scope),
+		"space": graphql.String(namespace),
+		"names": input,
+	}
+	if err := mutate(ctx, &mutation, variables, c.client); err != nil {
+		return translateVariablesErr(err, "deleteVariables", "failed to delete variables")
+	}
+	return nil
+}
+
+func translateVariablesErr(err error, field, msg string) error {
+	switch {
+	case strings.Contains(err.Error(), fmt.Sprintf("Cannot query field \"%s\"", field)):
+		return oktetoErrors.UserError{E: ErrVariablesNotSupported, Hint: "Please upgrade to the latest version or ask your administrator"}
+	case oktetoErrors.IsNotFound(err):
+		return oktetoErrors.ErrNotFound
+	default:
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+}
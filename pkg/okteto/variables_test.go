@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListVariables(t *testing.T) {
+	testCases := []struct {
+		client      *fakeGraphQLClient
+		expectedErr error
+		name        string
+		expected    []env.Var
+	}{
+		{
+			name: "no error",
+			client: &fakeGraphQLClient{
+				queryResult: &listVariablesQuery{
+					Response: []variablesQuery{
+						{
+							Name:  "DB_PASSWORD",
+							Value: "secret",
+						},
+					},
+				},
+			},
+			expected: []env.Var{
+				{
+					Name:  "DB_PASSWORD",
+					Value: "secret",
+				},
+			},
+		},
+		{
+			name: "not supported",
+			client: &fakeGraphQLClient{
+				err: fmt.Errorf("Cannot query field \"variables\" on type \"Query\""),
+			},
+			expectedErr: ErrVariablesNotSupported,
+		},
+		{
+			name: "error",
+			client: &fakeGraphQLClient{
+				err: assert.AnError,
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vc := newVariablesClient(tc.client)
+			vars, err := vc.List(context.Background(), types.VariableScopeNamespace, "test")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, vars)
+		})
+	}
+}
+
+func TestSetVariables(t *testing.T) {
+	vc := newVariablesClient(&fakeGraphQLClient{
+		mutationResult: &setVariablesMutation{},
+	})
+	err := vc.Set(context.Background(), types.VariableScopeNamespace, "test", []env.Var{{Name: "A", Value: "1"}})
+	require.NoError(t, err)
+
+	vc = newVariablesClient(&fakeGraphQLClient{
+		err: fmt.Errorf("Cannot query field \"setVariables\" on type \"Mutation\""),
+	})
+	err = vc.Set(context.Background(), types.VariableScopeNamespace, "test", []env.Var{{Name: "A", Value: "1"}})
+	assert.ErrorIs(t, err, ErrVariablesNotSupported)
+}
+
+func TestDeleteVariables(t *testing.T) {
+	vc := newVariablesClient(&fakeGraphQLClient{
+		mutationResult: &deleteVariablesMutation{},
+	})
+	err := vc.Delete(context.Background(), types.VariableScopePersonal, "", []string{"A"})
+	require.NoError(t, err)
+
+	vc = newVariablesClient(&fakeGraphQLClient{
+		err: assert.AnError,
+	})
+	err = vc.Delete(context.Background(), types.VariableScopePersonal, "", []string{"A"})
+	assert.ErrorIs(t, err, assert.AnError)
+}
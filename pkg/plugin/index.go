@@ -0,0 +1,123 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	getter "github.com/hashicorp/go-getter"
+	"gopkg.in/yaml.v2"
+)
+
+const indexFile = "index.yaml"
+
+// Index is the list of plugins that can be installed with 'okteto plugin install'.
+// Platform teams publish it as a YAML file in a repository or web server to distribute their plugins
+type Index struct {
+	Plugins []IndexEntry `yaml:"plugins"`
+}
+
+// IndexEntry is a version of a plugin published in an index
+type IndexEntry struct {
+	Name        string     `yaml:"name"`
+	Version     string     `yaml:"version"`
+	Description string     `yaml:"description,omitempty"`
+	Platforms   []Platform `yaml:"platforms"`
+}
+
+// Platform is the artifact of a plugin for an operating system and architecture
+type Platform struct {
+	OS   string `yaml:"os"`
+	Arch string `yaml:"arch"`
+	// URL is the http, https or file url of the binary, or of an archive that contains it
+	URL string `yaml:"url"`
+	// SHA256 is the checksum of the artifact downloaded from URL. It is required
+	SHA256 string `yaml:"sha256"`
+	// Bin is the path of the binary inside the archive. It defaults to okteto-<name>
+	Bin string `yaml:"bin,omitempty"`
+}
+
+// LoadIndex downloads and parses the plugin index at src, which can be a local path or an http, https or file URL
+func LoadIndex(src string) (*Index, error) {
+	dir, err := os.MkdirTemp("", "okteto-plugin-index-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp download dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	dst := filepath.Join(dir, indexFile)
+	client := &getter.Client{
+		Src:     src,
+		Dst:     dst,
+		Pwd:     pwd,
+		Mode:    getter.ClientModeFile,
+		Getters: downloadGetters,
+	}
+	if err := client.Get(); err != nil {
+		return nil, fmt.Errorf("failed to download the plugin index from '%s': %w", src, err)
+	}
+
+	b, err := os.ReadFile(dst)
+	if err != nil {
+		return nil, err
+	}
+	return parseIndex(b)
+}
+
+func parseIndex(b []byte) (*Index, error) {
+	index := &Index{}
+	if err := yaml.UnmarshalStrict(b, index); err != nil {
+		return nil, fmt.Errorf("failed to parse the plugin index: %w", err)
+	}
+	for _, entry := range index.Plugins {
+		if !IsValidName(entry.Name) {
+			return nil, fmt.Errorf("invalid plugin index: '%s' is not a valid plugin name", entry.Name)
+		}
+		if entry.Version == "" {
+			return nil, fmt.Errorf("invalid plugin index: plugin '%s' doesn't have a version", entry.Name)
+		}
+		for _, p := range entry.Platforms {
+			if p.OS == "" || p.Arch == "" || p.URL == "" || p.SHA256 == "" {
+				return nil, fmt.Errorf("invalid plugin index: the platforms of plugin '%s' must define 'os', 'arch', 'url' and 'sha256'", entry.Name)
+			}
+		}
+	}
+	return index, nil
+}
+
+// Get returns the entry of the plugin name
+func (i *Index) Get(name string) (*IndexEntry, error) {
+	for idx := range i.Plugins {
+		if i.Plugins[idx].Name == name {
+			return &i.Plugins[idx], nil
+		}
+	}
+	return nil, fmt.Errorf("plugin '%s' is not in the plugin index", name)
+}
+
+// GetPlatform returns the artifact of the plugin for goos and goarch
+func (e *IndexEntry) GetPlatform(goos, goarch string) (*Platform, error) {
+	for idx := range e.Platforms {
+		if e.Platforms[idx].OS == goos && e.Platforms[idx].Arch == goarch {
+			return &e.Platforms[idx], nil
+		}
+	}
+	return nil, fmt.Errorf("plugin '%s' is not available for %s/%s", e.Name, goos, goarch)
+}
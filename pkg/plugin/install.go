@@ -0,0 +1,167 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	getter "github.com/hashicorp/go-getter"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// Install downloads the plugin of entry for the current platform and records it was installed from index
+func (m *Manager) Install(entry *IndexEntry, index string) error {
+	platform, err := entry.GetPlatform(runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "okteto-plugin-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp download dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	src, err := getDownloadSource(platform)
+	if err != nil {
+		return err
+	}
+	pwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	client := &getter.Client{
+		Src:     src,
+		Dst:     dir,
+		Pwd:     pwd,
+		Mode:    getter.ClientModeAny,
+		Getters: downloadGetters,
+	}
+	if err := client.Get(); err != nil {
+		return fmt.Errorf("failed to download plugin '%s' from '%s': %w", entry.Name, platform.URL, err)
+	}
+
+	bin, err := findBinary(dir, entry.Name, platform.Bin)
+	if err != nil {
+		return fmt.Errorf("'%s' didn't include the binary of plugin '%s': %w", platform.URL, entry.Name, err)
+	}
+
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create '%s': %w", m.dir, err)
+	}
+	dst := filepath.Join(m.dir, binaryName(entry.Name))
+	if filesystem.FileExists(dst) {
+		if err := os.Remove(dst); err != nil {
+			oktetoLog.Infof("failed to delete %s, will try to overwrite: %s", dst, err)
+		}
+	}
+	if err := filesystem.CopyFile(bin, dst); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", dst, err)
+	}
+	// skipcq GSC-G302 plugins are binaries so they need exec permissions
+	if err := os.Chmod(dst, 0700); err != nil {
+		return fmt.Errorf("failed to set permissions to '%s': %w", dst, err)
+	}
+
+	oktetoLog.Infof("installed plugin %s %s to %s", entry.Name, entry.Version, dst)
+	return m.saveReceipt(&Receipt{Name: entry.Name, Version: entry.Version, Index: index})
+}
+
+// downloadGetters are the only protocols allowed to download the plugin index and binaries
+var downloadGetters = map[string]getter.Getter{
+	"file":  new(getter.FileGetter),
+	"http":  &getter.HttpGetter{Netrc: true},
+	"https": &getter.HttpGetter{Netrc: true},
+}
+
+// getDownloadSource adds the checksum of the platform to its URL so go-getter verifies the download
+func getDownloadSource(p *Platform) (string, error) {
+	if p.SHA256 == "" {
+		return "", fmt.Errorf("plugin url '%s' doesn't have a sha256 checksum", p.URL)
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid plugin url '%s': %w", p.URL, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	case "file":
+		if info, err := os.Stat(u.Path); err == nil && info.IsDir() {
+			return "", fmt.Errorf("plugin url '%s' must be a file, not a directory", p.URL)
+		}
+	default:
+		return "", fmt.Errorf("plugin url '%s' must be an http, https or file url", p.URL)
+	}
+	q := u.Query()
+	q.Set("checksum", fmt.Sprintf("sha256:%s", p.SHA256))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// findBinary returns the path of the plugin binary in the download dir
+func findBinary(dir, name, bin string) (string, error) {
+	if bin != "" {
+		path := filepath.Join(dir, filepath.FromSlash(bin))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			return "", fmt.Errorf("invalid binary path '%s'", bin)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+
+	files := []string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if filepath.Base(f) == binaryName(name) {
+			return f, nil
+		}
+	}
+	if len(files) == 1 {
+		return files[0], nil
+	}
+	return "", fmt.Errorf("'%s' not found", binaryName(name))
+}
+
+// IsOutdated returns if the version published in the index is newer than the installed one.
+// Versions that aren't semantic versions are outdated when they are different
+func (r *Receipt) IsOutdated(entry *IndexEntry) bool {
+	current, err := semver.NewVersion(r.Version)
+	if err != nil {
+		return r.Version != entry.Version
+	}
+	candidate, err := semver.NewVersion(entry.Version)
+	if err != nil {
+		return r.Version != entry.Version
+	}
+	return candidate.GreaterThan(current)
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseIndex(t *testing.T) {
+	tests := []struct {
+		name        string
+		index       string
+		expectedErr bool
+	}{
+		{
+			name: "valid",
+			index: `plugins:
+- name: db
+  version: 1.0.0
+  description: Manage databases
+  platforms:
+  - os: linux
+    arch: amd64
+    url: https://example.com/okteto-db
+    sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08`,
+		},
+		{
+			name: "invalid name",
+			index: `plugins:
+- name: Db
+  version: 1.0.0`,
+			expectedErr: true,
+		},
+		{
+			name: "missing version",
+			index: `plugins:
+- name: db`,
+			expectedErr: true,
+		},
+		{
+			name: "missing url",
+			index: `plugins:
+- name: db
+  version: 1.0.0
+  platforms:
+  - os: linux
+    arch: amd64`,
+			expectedErr: true,
+		},
+		{
+			name: "missing sha256",
+			index: `plugins:
+- name: db
+  version: 1.0.0
+  platforms:
+  - os: linux
+    arch: amd64
+    url: https://example.com/okteto-db`,
+			expectedErr: true,
+		},
+		{
+			name:        "unknown field",
+			index:       `registry: foo`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIndex([]byte(tt.index))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIndexEntryGetPlatform(t *testing.T) {
+	entry := &IndexEntry{
+		Name: "db",
+		Platforms: []Platform{
+			{OS: "linux", Arch: "amd64", URL: "linux"},
+			{OS: "darwin", Arch: "arm64", URL: "darwin"},
+		},
+	}
+	p, err := entry.GetPlatform("darwin", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, "darwin", p.URL)
+
+	_, err = entry.GetPlatform("windows", "amd64")
+	assert.Error(t, err)
+}
+
+func TestReceiptIsOutdated(t *testing.T) {
+	tests := []struct {
+		installed string
+		published string
+		expected  bool
+	}{
+		{installed: "1.0.0", published: "1.1.0", expected: true},
+		{installed: "1.1.0", published: "1.0.0", expected: false},
+		{installed: "v1.0.0", published: "1.0.0", expected: false},
+		{installed: "nightly", published: "nightly", expected: false},
+		{installed: "nightly", published: "1.0.0", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.installed+"-"+tt.published, func(t *testing.T) {
+			r := &Receipt{Version: tt.installed}
+			assert.Equal(t, tt.expected, r.IsOutdated(&IndexEntry{Version: tt.published}))
+		})
+	}
+}
+
+func TestInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin binaries are shell scripts")
+	}
+	src := t.TempDir()
+	artifact := filepath.Join(src, "okteto-db")
+	writeExecutable(t, artifact)
+	b, err := os.ReadFile(artifact)
+	require.NoError(t, err)
+	sum := sha256.Sum256(b)
+
+	index := filepath.Join(src, "index.yaml")
+	require.NoError(t, os.WriteFile(index, []byte(`plugins:
+- name: db
+  version: 1.0.0
+  platforms:
+  - os: `+runtime.GOOS+`
+    arch: `+runtime.GOARCH+`
+    url: file://`+filepath.ToSlash(artifact)+`
+    sha256: `+hex.EncodeToString(sum[:])), 0600))
+
+	i, err := LoadIndex(index)
+	require.NoError(t, err)
+	_, err = LoadIndex("git::" + src)
+	assert.Error(t, err)
+	entry, err := i.Get("db")
+	require.NoError(t, err)
+
+	m := &Manager{dir: filepath.Join(t.TempDir(), "plugins")}
+	require.NoError(t, m.Install(entry, index))
+
+	p, err := m.Find("db")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", p.Version)
+
+	entry.Platforms[0].SHA256 = "0000"
+	assert.Error(t, m.Install(entry, index))
+}
+
+func Test_getDownloadSource(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name        string
+		platform    Platform
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "https",
+			platform: Platform{URL: "https://example.com/okteto-db.tar.gz", SHA256: "abc"},
+			expected: "https://example.com/okteto-db.tar.gz?checksum=sha256%3Aabc",
+		},
+		{
+			name:     "file",
+			platform: Platform{URL: "file:///tmp/okteto-db", SHA256: "abc"},
+			expected: "file:///tmp/okteto-db?checksum=sha256%3Aabc",
+		},
+		{
+			name:        "missing checksum",
+			platform:    Platform{URL: "https://example.com/okteto-db"},
+			expectedErr: true,
+		},
+		{
+			name:        "git",
+			platform:    Platform{URL: "git::https://github.com/okteto/okteto-db", SHA256: "abc"},
+			expectedErr: true,
+		},
+		{
+			name:        "s3",
+			platform:    Platform{URL: "s3://bucket/okteto-db", SHA256: "abc"},
+			expectedErr: true,
+		},
+		{
+			name:        "local path",
+			platform:    Platform{URL: "/tmp/okteto-db", SHA256: "abc"},
+			expectedErr: true,
+		},
+		{
+			name:        "local dir",
+			platform:    Platform{URL: "file://" + filepath.ToSlash(dir), SHA256: "abc"},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := getDownloadSource(&tt.platform)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, src)
+		})
+	}
+}
+
+func Test_findBinary(t *testing.T) {
+	dir := t.TempDir()
+	writeExecutable(t, filepath.Join(dir, "bin", binaryName("db")))
+	writeExecutable(t, filepath.Join(dir, "README"))
+
+	path, err := findBinary(dir, "db", "")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "bin", binaryName("db")), path)
+
+	path, err = findBinary(dir, "db", "README")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "README"), path)
+
+	_, err = findBinary(dir, "db", "../README")
+	assert.Error(t, err)
+
+	_, err = findBinary(dir, "lint", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,271 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// BinaryPrefix is the prefix of the executables that implement okteto subcommands
+	BinaryPrefix = "okteto-"
+
+	pluginsFolder  = "plugins"
+	receiptsFolder = "receipts"
+)
+
+var (
+	// ErrPluginNotFound is raised when there isn't an executable for a plugin
+	ErrPluginNotFound = errors.New("plugin not found")
+
+	validNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// Plugin is an executable that implements an okteto subcommand
+type Plugin struct {
+	Name string
+	Path string
+	// Version is empty for the plugins not installed with 'okteto plugin install'
+	Version string
+}
+
+// Receipt records a plugin installed from a plugin index
+type Receipt struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Index   string `yaml:"index,omitempty"`
+}
+
+// Manager discovers the plugins installed in the okteto home and in the PATH
+type Manager struct {
+	dir  string
+	path string
+}
+
+// NewManager returns a manager for the plugins installed in $OKTETO_HOME/plugins
+func NewManager() *Manager {
+	return &Manager{
+		dir:  filepath.Join(config.GetOktetoHome(), pluginsFolder),
+		path: os.Getenv("PATH"),
+	}
+}
+
+// IsValidName returns if name can be used as an okteto subcommand
+func IsValidName(name string) bool {
+	return validNameRegex.MatchString(name)
+}
+
+// Find returns the plugin that implements the subcommand name.
+// The plugins installed with 'okteto plugin install' take precedence over the ones in the PATH
+func (m *Manager) Find(name string) (*Plugin, error) {
+	if !IsValidName(name) {
+		return nil, ErrPluginNotFound
+	}
+	for _, dir := range m.searchDirs() {
+		path := filepath.Join(dir, binaryName(name))
+		if isExecutable(path) {
+			return m.newPlugin(name, path), nil
+		}
+	}
+	return nil, ErrPluginNotFound
+}
+
+// List returns the plugins available sorted by name. A plugin is listed once even if it's in several folders
+func (m *Manager) List() []Plugin {
+	found := map[string]Plugin{}
+	for _, dir := range m.searchDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			if _, ok := found[name]; ok {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			found[name] = *m.newPlugin(name, path)
+		}
+	}
+
+	result := make([]Plugin, 0, len(found))
+	for _, p := range found {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// GetReceipts returns the receipts of the plugins installed with 'okteto plugin install'
+func (m *Manager) GetReceipts() ([]Receipt, error) {
+	entries, err := os.ReadDir(m.receiptsDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	result := []Receipt{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".yaml")
+		if name == entry.Name() {
+			continue
+		}
+		r, err := m.getReceipt(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *r)
+	}
+	return result, nil
+}
+
+func (m *Manager) newPlugin(name, path string) *Plugin {
+	p := &Plugin{Name: name, Path: path}
+	if filepath.Dir(path) != m.dir {
+		return p
+	}
+	if r, err := m.getReceipt(name); err == nil {
+		p.Version = r.Version
+	}
+	return p
+}
+
+func (m *Manager) getReceipt(name string) (*Receipt, error) {
+	b, err := os.ReadFile(filepath.Join(m.receiptsDir(), fmt.Sprintf("%s.yaml", name)))
+	if err != nil {
+		return nil, err
+	}
+	r := &Receipt{}
+	if err := yaml.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("failed to parse the receipt of plugin '%s': %w", name, err)
+	}
+	return r, nil
+}
+
+func (m *Manager) saveReceipt(r *Receipt) error {
+	b, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.receiptsDir(), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.receiptsDir(), fmt.Sprintf("%s.yaml", r.Name)), b, 0600)
+}
+
+func (m *Manager) receiptsDir() string {
+	return filepath.Join(m.dir, receiptsFolder)
+}
+
+func (m *Manager) searchDirs() []string {
+	dirs := []string{m.dir}
+	for _, dir := range filepath.SplitList(m.path) {
+		if dir == "" || dir == m.dir {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// Env returns the environment variables that share the current okteto context with the plugins.
+// Variables already defined by the user are not overridden
+func Env(environ []string) []string {
+	env := map[string]string{}
+	if bin, err := os.Executable(); err == nil {
+		env[model.OktetoBinEnvVar] = bin
+	}
+	env[constants.OktetoHomeEnvVar] = config.GetOktetoHome()
+
+	store := okteto.GetContextStore()
+	if octx, ok := store.Contexts[store.CurrentContext]; ok {
+		env[model.OktetoContextEnvVar] = octx.Name
+		env[model.OktetoNamespaceEnvVar] = octx.Namespace
+		if octx.IsOkteto {
+			env[model.OktetoURLEnvVar] = octx.Name
+			env[model.OktetoTokenEnvVar] = octx.Token
+			env[model.OktetoUserNameEnvVar] = octx.Username
+		}
+	}
+
+	defined := map[string]bool{}
+	for _, e := range environ {
+		k, _, _ := strings.Cut(e, "=")
+		defined[k] = true
+	}
+	result := append([]string{}, environ...)
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if defined[k] || env[k] == "" {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s=%s", k, env[k]))
+	}
+	return result
+}
+
+func binaryName(name string) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("%s%s.exe", BinaryPrefix, name)
+	}
+	return BinaryPrefix + name
+}
+
+func pluginName(filename string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		filename = strings.TrimSuffix(filename, ".exe")
+	}
+	name, ok := strings.CutPrefix(filename, BinaryPrefix)
+	if !ok || !IsValidName(name) {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode()&0111 != 0
+}
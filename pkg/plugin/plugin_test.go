@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeExecutable(t *testing.T, path string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho plugin\n"), 0700))
+}
+
+func TestManagerFind(t *testing.T) {
+	home := t.TempDir()
+	path := t.TempDir()
+	m := &Manager{dir: filepath.Join(home, "plugins"), path: path}
+
+	writeExecutable(t, filepath.Join(m.dir, binaryName("db")))
+	writeExecutable(t, filepath.Join(path, binaryName("db")))
+	writeExecutable(t, filepath.Join(path, binaryName("lint")))
+	require.NoError(t, os.WriteFile(filepath.Join(path, binaryName("notexec")), []byte(""), 0600))
+
+	p, err := m.Find("db")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(m.dir, binaryName("db")), p.Path)
+
+	p, err = m.Find("lint")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(path, binaryName("lint")), p.Path)
+
+	_, err = m.Find("notexec")
+	assert.ErrorIs(t, err, ErrPluginNotFound)
+
+	_, err = m.Find("../lint")
+	assert.ErrorIs(t, err, ErrPluginNotFound)
+}
+
+func TestManagerList(t *testing.T) {
+	home := t.TempDir()
+	path := t.TempDir()
+	m := &Manager{dir: filepath.Join(home, "plugins"), path: path}
+
+	writeExecutable(t, filepath.Join(m.dir, binaryName("db")))
+	require.NoError(t, m.saveReceipt(&Receipt{Name: "db", Version: "1.0.0", Index: "index.yaml"}))
+	writeExecutable(t, filepath.Join(path, binaryName("db")))
+	writeExecutable(t, filepath.Join(path, binaryName("lint")))
+	writeExecutable(t, filepath.Join(path, "kubectl"))
+
+	assert.Equal(t, []Plugin{
+		{Name: "db", Path: filepath.Join(m.dir, binaryName("db")), Version: "1.0.0"},
+		{Name: "lint", Path: filepath.Join(path, binaryName("lint"))},
+	}, m.List())
+
+	receipts, err := m.GetReceipts()
+	require.NoError(t, err)
+	assert.Equal(t, []Receipt{{Name: "db", Version: "1.0.0", Index: "index.yaml"}}, receipts)
+}
+
+func TestGetReceiptsWithoutPlugins(t *testing.T) {
+	m := &Manager{dir: filepath.Join(t.TempDir(), "plugins")}
+	receipts, err := m.GetReceipts()
+	require.NoError(t, err)
+	assert.Empty(t, receipts)
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("OKTETO_HOME", t.TempDir())
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"https://okteto.example.com": {
+				Name:      "https://okteto.example.com",
+				Namespace: "cindy",
+				Token:     "token",
+				Username:  "cindy",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "https://okteto.example.com",
+	}
+	defer func() {
+		okteto.CurrentStore = nil
+	}()
+
+	env := Env([]string{"PATH=/bin", "OKTETO_NAMESPACE=staging"})
+
+	assert.Contains(t, env, "PATH=/bin")
+	assert.Contains(t, env, "OKTETO_NAMESPACE=staging")
+	assert.NotContains(t, env, "OKTETO_NAMESPACE=cindy")
+	assert.Contains(t, env, "OKTETO_CONTEXT=https://okteto.example.com")
+	assert.Contains(t, env, "OKTETO_URL=https://okteto.example.com")
+	assert.Contains(t, env, "OKTETO_TOKEN=token")
+	assert.Contains(t, env, "OKTETO_USERNAME=cindy")
+
+	found := false
+	for _, e := range env {
+		if strings.HasPrefix(e, "OKTETO_BIN=") {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
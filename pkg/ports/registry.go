@@ -0,0 +1,215 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/shirou/gopsutil/process"
+)
+
+const (
+	registryFile = "ports.json"
+
+	lockTimeout    = 10 * time.Second
+	lockRetryDelay = 50 * time.Millisecond
+
+	// maxAttempts is the number of random ports tried before giving up
+	maxAttempts = 20
+)
+
+// reservation is a local port assigned to a service of an okteto session
+type reservation struct {
+	Owner   string `json:"owner"`
+	Service string `json:"service"`
+	Port    int    `json:"port"`
+	PID     int    `json:"pid"`
+}
+
+// Registry coordinates the local ports assigned by the okteto sessions running in the same machine
+// through a file shared by all of them
+type Registry struct {
+	isAlive func(pid int) bool
+	getPort func(iface string) (int, error)
+	path    string
+	pid     int
+}
+
+// NewRegistry returns a registry stored in path
+func NewRegistry(path string) *Registry {
+	return &Registry{
+		path:    path,
+		pid:     os.Getpid(),
+		isAlive: isProcessAlive,
+		getPort: model.GetAvailablePort,
+	}
+}
+
+// Owner returns the owner of the ports reserved by a development container
+func Owner(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// Reserve returns an available port in iface not reserved by other okteto sessions.
+// If the registry is not available, it falls back to a random available port
+func Reserve(owner, service, iface string) (int, error) {
+	port, err := getRegistry().Reserve(owner, service, iface)
+	if err != nil {
+		oktetoLog.Infof("failed to reserve port for '%s': %s", service, err)
+		return model.GetAvailablePort(iface)
+	}
+	return port, nil
+}
+
+// Release frees the ports reserved by owner
+func Release(owner string) {
+	if err := getRegistry().Release(owner); err != nil {
+		oktetoLog.Infof("failed to release ports of '%s': %s", owner, err)
+	}
+}
+
+func getRegistry() *Registry {
+	return NewRegistry(filepath.Join(config.GetOktetoHome(), registryFile))
+}
+
+// Reserve reserves an available port in iface for the service of owner, replacing its previous reservation
+func (r *Registry) Reserve(owner, service, iface string) (int, error) {
+	port := 0
+	err := r.update(func(reservations []reservation) ([]reservation, error) {
+		reserved := map[int]bool{}
+		result := []reservation{}
+		for _, res := range reservations {
+			if res.Owner == owner && res.Service == service {
+				continue
+			}
+			reserved[res.Port] = true
+			result = append(result, res)
+		}
+
+		for i := 0; i < maxAttempts; i++ {
+			p, err := r.getPort(iface)
+			if err != nil {
+				return nil, err
+			}
+			if !reserved[p] {
+				port = p
+				break
+			}
+		}
+		if port == 0 {
+			return nil, fmt.Errorf("all the available ports are reserved by other okteto sessions")
+		}
+		return append(result, reservation{Owner: owner, Service: service, Port: port, PID: r.pid}), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return port, nil
+}
+
+// Release removes all the reservations of owner
+func (r *Registry) Release(owner string) error {
+	return r.update(func(reservations []reservation) ([]reservation, error) {
+		result := []reservation{}
+		for _, res := range reservations {
+			if res.Owner != owner {
+				result = append(result, res)
+			}
+		}
+		return result, nil
+	})
+}
+
+// update applies fn to the reservations of live processes while holding the registry lock
+func (r *Registry) update(fn func([]reservation) ([]reservation, error)) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0700); err != nil {
+		return err
+	}
+
+	lock := flock.New(fmt.Sprintf("%s.lock", r.path))
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+	locked, err := lock.TryLockContext(ctx, lockRetryDelay)
+	if err != nil {
+		return fmt.Errorf("failed to lock the ports registry: %w", err)
+	}
+	if !locked {
+		return fmt.Errorf("failed to lock the ports registry")
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			oktetoLog.Infof("failed to unlock the ports registry: %s", err)
+		}
+	}()
+
+	reservations := []reservation{}
+	for _, res := range r.load() {
+		if r.isAlive(res.PID) {
+			reservations = append(reservations, res)
+		}
+	}
+
+	reservations, err = fn(reservations)
+	if err != nil {
+		return err
+	}
+	return r.save(reservations)
+}
+
+func (r *Registry) load() []reservation {
+	reservations := []reservation{}
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			oktetoLog.Infof("failed to read the ports registry: %s", err)
+		}
+		return reservations
+	}
+	if err := json.Unmarshal(b, &reservations); err != nil {
+		oktetoLog.Infof("ignoring malformed ports registry: %s", err)
+		return []reservation{}
+	}
+	return reservations
+}
+
+func (r *Registry) save(reservations []reservation) error {
+	b, err := json.Marshal(reservations)
+	if err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.tmp", r.path)
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
+
+func isProcessAlive(pid int) bool {
+	alive, err := process.PidExists(int32(pid))
+	if err != nil {
+		oktetoLog.Infof("failed to check if process %d is running: %s", pid, err)
+		return true
+	}
+	return alive
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ports
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistry returns a registry whose port getter returns ports in sequence
+func newTestRegistry(t *testing.T, path string, pid int, ports ...int) *Registry {
+	t.Helper()
+	i := 0
+	return &Registry{
+		path: path,
+		pid:  pid,
+		isAlive: func(pid int) bool {
+			return pid != 0
+		},
+		getPort: func(_ string) (int, error) {
+			p := ports[i%len(ports)]
+			i++
+			return p, nil
+		},
+	}
+}
+
+func TestReserveSkipsPortsReservedByOtherSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+
+	first := newTestRegistry(t, path, 1, 8000)
+	p, err := first.Reserve(Owner("ns", "api"), "ssh", "localhost")
+	require.NoError(t, err)
+	assert.Equal(t, 8000, p)
+
+	second := newTestRegistry(t, path, 2, 8000, 8001)
+	p, err = second.Reserve(Owner("ns", "frontend"), "ssh", "localhost")
+	require.NoError(t, err)
+	assert.Equal(t, 8001, p)
+
+	assert.ElementsMatch(t, []reservation{
+		{Owner: "ns/api", Service: "ssh", Port: 8000, PID: 1},
+		{Owner: "ns/frontend", Service: "ssh", Port: 8001, PID: 2},
+	}, first.load())
+}
+
+func TestReserveReplacesPreviousReservation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	r := newTestRegistry(t, path, 1, 8000, 8001)
+
+	_, err := r.Reserve("ns/api", "ssh", "localhost")
+	require.NoError(t, err)
+	p, err := r.Reserve("ns/api", "ssh", "localhost")
+	require.NoError(t, err)
+	assert.Equal(t, 8001, p)
+	assert.Equal(t, []reservation{{Owner: "ns/api", Service: "ssh", Port: 8001, PID: 1}}, r.load())
+}
+
+func TestReserveFailsIfAllPortsAreReserved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	_, err := newTestRegistry(t, path, 1, 8000).Reserve("ns/api", "ssh", "localhost")
+	require.NoError(t, err)
+
+	_, err = newTestRegistry(t, path, 2, 8000).Reserve("ns/frontend", "ssh", "localhost")
+	assert.Error(t, err)
+}
+
+func TestReservePrunesDeadSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	require.NoError(t, os.WriteFile(path, []byte(`[{"owner":"ns/old","service":"ssh","port":8000,"pid":0}]`), 0600))
+
+	r := newTestRegistry(t, path, 1, 8000)
+	p, err := r.Reserve("ns/api", "ssh", "localhost")
+	require.NoError(t, err)
+	assert.Equal(t, 8000, p)
+	assert.Equal(t, []reservation{{Owner: "ns/api", Service: "ssh", Port: 8000, PID: 1}}, r.load())
+}
+
+func TestReserveIgnoresMalformedRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	require.NoError(t, os.WriteFile(path, []byte(`not json`), 0600))
+
+	p, err := newTestRegistry(t, path, 1, 8000).Reserve("ns/api", "ssh", "localhost")
+	require.NoError(t, err)
+	assert.Equal(t, 8000, p)
+}
+
+func TestRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	r := newTestRegistry(t, path, 1, 8000, 8001, 8002)
+	reservations := []reservation{
+		{Owner: "ns/api", Service: "ssh"},
+		{Owner: "ns/api", Service: "syncthing-gui"},
+		{Owner: "ns/frontend", Service: "ssh"},
+	}
+	for _, res := range reservations {
+		_, err := r.Reserve(res.Owner, res.Service, "localhost")
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, r.Release("ns/api"))
+	reservations = r.load()
+	require.Len(t, reservations, 1)
+	assert.Equal(t, "ns/frontend", reservations[0].Owner)
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prompt resolves the okteto context, namespace and active development sessions
+// to show them in shell prompts. It only reads local files, so it is fast enough to run on every prompt
+package prompt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/shirou/gopsutil/process"
+	"github.com/spf13/afero"
+)
+
+const (
+	cacheFile       = ".prompt.json"
+	contextsStore   = "context/config.json"
+	devPIDFilename  = "okteto.pid"
+	defaultCacheTTL = 5 * time.Second
+
+	// DefaultFormat is the default template used to render the prompt
+	DefaultFormat = `{{.Context}}:{{.Namespace}}{{if .DevSessions}} [{{join .DevSessions ","}}]{{end}}`
+)
+
+// Info is the information shown in the shell prompt
+type Info struct {
+	// Context is the current okteto context, without the url scheme
+	Context string `json:"context"`
+	// Namespace is the current namespace
+	Namespace string `json:"namespace"`
+	// DevSessions are the development containers with an 'okteto up' session running in this machine
+	DevSessions []string `json:"devSessions,omitempty"`
+}
+
+type cachedInfo struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Key       string    `json:"key"`
+	Info      Info      `json:"info"`
+}
+
+// Resolver gets the prompt information from the okteto home folder
+type Resolver struct {
+	fs         afero.Fs
+	isAlive    func(pid int) bool
+	now        func() time.Time
+	oktetoHome string
+	cacheTTL   time.Duration
+}
+
+// NewResolver returns a Resolver reading the files of the okteto home folder
+func NewResolver(fs afero.Fs, oktetoHome string) *Resolver {
+	return &Resolver{
+		fs:         fs,
+		oktetoHome: oktetoHome,
+		isAlive:    isProcessAlive,
+		now:        time.Now,
+		cacheTTL:   defaultCacheTTL,
+	}
+}
+
+// Get returns the prompt information. The result is cached until the okteto context changes
+// or the cache expires, since active development sessions can start or finish at any time
+func (r *Resolver) Get() (Info, error) {
+	storePath := filepath.Join(r.oktetoHome, contextsStore)
+	stat, err := r.fs.Stat(storePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Info{}, nil
+		}
+		return Info{}, err
+	}
+
+	key := fmt.Sprintf("%d:%s:%s", stat.ModTime().UnixNano(), os.Getenv(model.OktetoContextEnvVar), os.Getenv(model.OktetoNamespaceEnvVar))
+	if cached, ok := r.readCache(key); ok {
+		return cached, nil
+	}
+
+	info, err := r.resolve(storePath)
+	if err != nil {
+		return Info{}, err
+	}
+	r.writeCache(key, info)
+	return info, nil
+}
+
+func (r *Resolver) resolve(storePath string) (Info, error) {
+	b, err := afero.ReadFile(r.fs, storePath)
+	if err != nil {
+		return Info{}, err
+	}
+	store := &okteto.ContextStore{}
+	if err := json.Unmarshal(b, store); err != nil {
+		return Info{}, fmt.Errorf("failed to read okteto contexts: %w", err)
+	}
+
+	contextName := store.CurrentContext
+	if v := os.Getenv(model.OktetoContextEnvVar); v != "" {
+		contextName = v
+	}
+	namespace := ""
+	if okCtx, ok := store.Contexts[contextName]; ok {
+		namespace = okCtx.Namespace
+	}
+	if v := os.Getenv(model.OktetoNamespaceEnvVar); v != "" {
+		namespace = v
+	}
+	if contextName == "" {
+		return Info{}, nil
+	}
+
+	return Info{
+		Context:     strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(contextName, "https://"), "http://"), "/"),
+		Namespace:   namespace,
+		DevSessions: r.getDevSessions(namespace),
+	}, nil
+}
+
+// getDevSessions returns the development containers of the namespace with a running 'okteto up' process
+func (r *Resolver) getDevSessions(namespace string) []string {
+	if namespace == "" {
+		return nil
+	}
+	pidFiles, err := afero.Glob(r.fs, filepath.Join(r.oktetoHome, namespace, "*", devPIDFilename))
+	if err != nil {
+		return nil
+	}
+
+	var sessions []string
+	for _, pidFile := range pidFiles {
+		b, err := afero.ReadFile(r.fs, pidFile)
+		if err != nil {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+		if err != nil || !r.isAlive(pid) {
+			continue
+		}
+		sessions = append(sessions, filepath.Base(filepath.Dir(pidFile)))
+	}
+	sort.Strings(sessions)
+	return sessions
+}
+
+func (r *Resolver) readCache(key string) (Info, bool) {
+	b, err := afero.ReadFile(r.fs, filepath.Join(r.oktetoHome, cacheFile))
+	if err != nil {
+		return Info{}, false
+	}
+	cached := cachedInfo{}
+	if err := json.Unmarshal(b, &cached); err != nil {
+		return Info{}, false
+	}
+	if cached.Key != key || r.now().Sub(cached.CreatedAt) > r.cacheTTL {
+		return Info{}, false
+	}
+	return cached.Info, true
+}
+
+// writeCache stores the prompt information. Errors are ignored, the cache is only an optimization
+func (r *Resolver) writeCache(key string, info Info) {
+	b, err := json.Marshal(cachedInfo{Key: key, Info: info, CreatedAt: r.now()})
+	if err != nil {
+		return
+	}
+	path := filepath.Join(r.oktetoHome, cacheFile)
+	tmp := fmt.Sprintf("%s.tmp", path)
+	if err := afero.WriteFile(r.fs, tmp, b, 0600); err != nil {
+		return
+	}
+	_ = r.fs.Rename(tmp, path)
+}
+
+// Render renders the prompt information with a Go template. Nothing is rendered if there is no okteto context
+func Render(info Info, format string) (string, error) {
+	if info.Context == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("prompt").Funcs(template.FuncMap{"join": strings.Join}).Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt format: %w", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, info); err != nil {
+		return "", fmt.Errorf("invalid prompt format: %w", err)
+	}
+	return b.String(), nil
+}
+
+func isProcessAlive(pid int) bool {
+	alive, err := process.PidExists(int32(pid))
+	return err == nil && alive
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContextStore = `{
+  "contexts": {
+    "https://okteto.example.com": {"name": "https://okteto.example.com", "namespace": "cindy", "isOkteto": true},
+    "minikube": {"name": "minikube", "namespace": "default"}
+  },
+  "current-context": "https://okteto.example.com"
+}`
+
+func newTestResolver(t *testing.T, fs afero.Fs, now time.Time) *Resolver {
+	t.Helper()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("/okteto", contextsStore), []byte(testContextStore), 0600))
+	r := NewResolver(fs, "/okteto")
+	r.now = func() time.Time { return now }
+	r.isAlive = func(pid int) bool { return pid == 1234 }
+	return r
+}
+
+func TestResolverGet(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	r := newTestResolver(t, fs, time.Now())
+	require.NoError(t, afero.WriteFile(fs, "/okteto/cindy/api/okteto.pid", []byte("1234"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/okteto/cindy/frontend/okteto.pid", []byte("5678"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/okteto/david/worker/okteto.pid", []byte("1234"), 0600))
+
+	info, err := r.Get()
+	require.NoError(t, err)
+	assert.Equal(t, Info{Context: "okteto.example.com", Namespace: "cindy", DevSessions: []string{"api"}}, info)
+}
+
+func TestResolverGetEnvOverrides(t *testing.T) {
+	t.Setenv(model.OktetoContextEnvVar, "minikube")
+	t.Setenv(model.OktetoNamespaceEnvVar, "staging")
+	r := newTestResolver(t, afero.NewMemMapFs(), time.Now())
+
+	info, err := r.Get()
+	require.NoError(t, err)
+	assert.Equal(t, Info{Context: "minikube", Namespace: "staging"}, info)
+}
+
+func TestResolverGetWithoutContext(t *testing.T) {
+	r := NewResolver(afero.NewMemMapFs(), "/okteto")
+	info, err := r.Get()
+	require.NoError(t, err)
+	assert.Equal(t, Info{}, info)
+}
+
+func TestResolverGetCache(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	now := time.Now()
+	r := newTestResolver(t, fs, now)
+
+	info, err := r.Get()
+	require.NoError(t, err)
+	assert.Empty(t, info.DevSessions)
+
+	// a new session is not shown until the cache expires
+	require.NoError(t, afero.WriteFile(fs, "/okteto/cindy/api/okteto.pid", []byte("1234"), 0600))
+	info, err = r.Get()
+	require.NoError(t, err)
+	assert.Empty(t, info.DevSessions)
+
+	r.now = func() time.Time { return now.Add(defaultCacheTTL + time.Second) }
+	info, err = r.Get()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api"}, info.DevSessions)
+}
+
+func TestRender(t *testing.T) {
+	var tests = []struct {
+		name      string
+		format    string
+		expected  string
+		info      Info
+		expectErr bool
+	}{
+		{
+			name:     "default format",
+			format:   DefaultFormat,
+			info:     Info{Context: "okteto.example.com", Namespace: "cindy"},
+			expected: "okteto.example.com:cindy",
+		},
+		{
+			name:     "default format with dev sessions",
+			format:   DefaultFormat,
+			info:     Info{Context: "okteto.example.com", Namespace: "cindy", DevSessions: []string{"api", "frontend"}},
+			expected: "okteto.example.com:cindy [api,frontend]",
+		},
+		{
+			name:     "custom format",
+			format:   "({{.Namespace}})",
+			info:     Info{Context: "okteto.example.com", Namespace: "cindy"},
+			expected: "(cindy)",
+		},
+		{
+			name:     "no context",
+			format:   DefaultFormat,
+			expected: "",
+		},
+		{
+			name:      "invalid format",
+			format:    "{{.Namespace",
+			info:      Info{Context: "okteto.example.com", Namespace: "cindy"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Render(tt.info, tt.format)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+)
+
+const (
+	// referenceTypeAnnotation is the annotation BuildKit sets in the manifests of the image index that aren't images
+	referenceTypeAnnotation = "vnd.docker.reference.type"
+
+	// attestationManifestType is the reference type of the attestation manifests
+	attestationManifestType = "attestation-manifest"
+)
+
+// GetAttestationDigests returns the digests of the attestation manifests that BuildKit attached to the image index of image.
+// It returns an empty list if the image is not an index
+func (or OktetoRegistry) GetAttestationDigests(image string) ([]string, error) {
+	expandedImage := or.imageCtrl.expandImageRegistries(image)
+	descriptor, err := or.client.GetDescriptor(expandedImage)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the attestations of '%s': %w", image, err)
+	}
+	if !descriptor.MediaType.IsIndex() {
+		return nil, nil
+	}
+
+	index, err := descriptor.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the attestations of '%s': %w", image, err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the attestations of '%s': %w", image, err)
+	}
+
+	digests := []string{}
+	for _, m := range manifest.Manifests {
+		if m.Annotations[referenceTypeAnnotation] == attestationManifestType {
+			digests = append(digests, m.Digest.String())
+		}
+	}
+	return digests, nil
+}
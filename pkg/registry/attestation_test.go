@@ -0,0 +1,90 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const attestedIndex = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {
+      "mediaType": "application/vnd.oci.image.manifest.v1+json",
+      "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+      "size": 100,
+      "platform": {"architecture": "amd64", "os": "linux"}
+    },
+    {
+      "mediaType": "application/vnd.oci.image.manifest.v1+json",
+      "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+      "size": 100,
+      "annotations": {
+        "vnd.docker.reference.digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+        "vnd.docker.reference.type": "attestation-manifest"
+      },
+      "platform": {"architecture": "unknown", "os": "unknown"}
+    }
+  ]
+}`
+
+func TestGetAttestationDigests(t *testing.T) {
+	descriptor := &remote.Descriptor{
+		Manifest: []byte(attestedIndex),
+	}
+	descriptor.MediaType = types.OCIImageIndex
+
+	or := OktetoRegistry{
+		imageCtrl: NewImageCtrl(FakeConfig{ContextCertificate: &x509.Certificate{}}),
+		client: fakeClient{
+			MockGetDescriptor: mockGetDescriptor{Result: descriptor},
+		},
+	}
+	digests, err := or.GetAttestationDigests("registry.okteto.dev/test/api@sha256:aaaa")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sha256:2222222222222222222222222222222222222222222222222222222222222222"}, digests)
+}
+
+func TestGetAttestationDigestsWithoutIndex(t *testing.T) {
+	descriptor := &remote.Descriptor{}
+	descriptor.MediaType = types.OCIManifestSchema1
+
+	or := OktetoRegistry{
+		imageCtrl: NewImageCtrl(FakeConfig{ContextCertificate: &x509.Certificate{}}),
+		client: fakeClient{
+			MockGetDescriptor: mockGetDescriptor{Result: descriptor},
+		},
+	}
+	digests, err := or.GetAttestationDigests("registry.okteto.dev/test/api@sha256:aaaa")
+	require.NoError(t, err)
+	assert.Empty(t, digests)
+}
+
+func TestGetAttestationDigestsError(t *testing.T) {
+	or := OktetoRegistry{
+		imageCtrl: NewImageCtrl(FakeConfig{ContextCertificate: &x509.Certificate{}}),
+		client: fakeClient{
+			MockGetDescriptor: mockGetDescriptor{Err: assert.AnError},
+		},
+	}
+	_, err := or.GetAttestationDigests("registry.okteto.dev/test/api@sha256:aaaa")
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -14,16 +14,19 @@
 package registry
 
 import (
+	"context"
 	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/okteto/okteto/pkg/auth/dockercredentials"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoHttp "github.com/okteto/okteto/pkg/http"
@@ -35,9 +38,13 @@ const oktetoLocalRegistryStoreEnabledEnvVarKey = "OKTETO_LOCAL_REGISTRY_STORE_EN
 type clientInterface interface {
 	GetDigest(image string) (string, error)
 	GetImageConfig(image string) (*v1.ConfigFile, error)
+	GetImageSize(image string) (int64, error)
 	HasPushAccess(image string) (bool, error)
 	GetDescriptor(image string) (*remote.Descriptor, error)
 	Write(ref name.Reference, image v1.Image) error
+	ListRepositories(registry string) ([]string, error)
+	ListTags(repository string) ([]string, error)
+	Delete(image string) error
 }
 
 type ClientConfigInterface interface {
@@ -75,7 +82,12 @@ type client struct {
 	config  ClientConfigInterface
 	get     func(ref name.Reference, options ...remote.Option) (*remote.Descriptor, error)
 	write   func(ref name.Reference, image v1.Image, options ...remote.Option) error
+	catalog func(ctx context.Context, target name.Registry, options ...remote.Option) ([]string, error)
+	list    func(repo name.Repository, options ...remote.Option) ([]string, error)
+	delete  func(ref name.Reference, options ...remote.Option) error
 	tlsDial oktetoHttp.TLSDialFunc
+	// sleep waits between the retries of the registry operations. It is nil to use time.Sleep
+	sleep func(d time.Duration)
 }
 
 func newOktetoRegistryClient(config ClientConfigInterface) client {
@@ -83,6 +95,9 @@ func newOktetoRegistryClient(config ClientConfigInterface) client {
 		config:  config,
 		get:     remote.Get,
 		write:   remote.Write,
+		catalog: remote.Catalog,
+		list:    remote.List,
+		delete:  remote.Delete,
 		tlsDial: oktetoHttp.DefaultTLSDial,
 	}
 }
@@ -94,9 +109,19 @@ func (c client) GetDescriptor(image string) (*remote.Descriptor, error) {
 		return nil, err
 	}
 
+	// pull-through caches are queried first, the upstream registry is the fallback on a miss
+	if _, descriptor, ok := c.getFromMirrors(ref); ok {
+		return descriptor, nil
+	}
+
 	options := c.getOptions(ref)
 
-	descriptor, err := c.get(ref, options...)
+	var descriptor *remote.Descriptor
+	err = c.withRetries(fmt.Sprintf("getting the descriptor of '%s'", image), func() error {
+		var getErr error
+		descriptor, getErr = c.get(ref, options...)
+		return getErr
+	})
 	if err != nil {
 		if c.isNotFound(err) {
 			return nil, fmt.Errorf("error getting image descriptor: %w", oktetoErrors.ErrNotFound)
@@ -112,6 +137,59 @@ func (c client) Write(ref name.Reference, image v1.Image) error {
 	return c.write(ref, image, options...)
 }
 
+// ListRepositories returns the repositories of a registry
+func (c client) ListRepositories(registry string) ([]string, error) {
+	reg, err := name.NewRegistry(registry)
+	if err != nil {
+		return nil, err
+	}
+	// the authentication is resolved from a reference of the registry
+	ref, err := name.ParseReference(fmt.Sprintf("%s/catalog", registry))
+	if err != nil {
+		return nil, err
+	}
+	var repositories []string
+	err = c.withRetries(fmt.Sprintf("listing the repositories of '%s'", registry), func() error {
+		var catalogErr error
+		repositories, catalogErr = c.catalog(context.Background(), reg, c.getOptions(ref)...)
+		return catalogErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing repositories: %w", err)
+	}
+	return repositories, nil
+}
+
+// ListTags returns the tags of a repository
+func (c client) ListTags(repository string) ([]string, error) {
+	repo, err := name.NewRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	err = c.withRetries(fmt.Sprintf("listing the tags of '%s'", repository), func() error {
+		var listErr error
+		tags, listErr = c.list(repo, c.getOptions(repo.Tag("latest"))...)
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	return tags, nil
+}
+
+// Delete deletes an image from the registry
+func (c client) Delete(image string) error {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	if err := c.delete(ref, c.getOptions(ref)...); err != nil {
+		return fmt.Errorf("error deleting image: %w", err)
+	}
+	return nil
+}
+
 // GetDigest returns the digest of an image
 func (c client) GetDigest(image string) (string, error) {
 	descriptor, err := c.GetDescriptor(image)
@@ -139,6 +217,28 @@ func (c client) GetImageConfig(image string) (*v1.ConfigFile, error) {
 	return cfg, nil
 }
 
+// GetImageSize returns the compressed size of an image: its manifest, config and layers
+func (c client) GetImageSize(image string) (int64, error) {
+	descriptor, err := c.GetDescriptor(image)
+	if err != nil {
+		return 0, fmt.Errorf("error getting image size: %w", err)
+	}
+
+	img, err := descriptor.Image()
+	if err != nil {
+		return 0, fmt.Errorf("error getting image size: %w", err)
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return 0, fmt.Errorf("error getting image size: %w", err)
+	}
+	size := descriptor.Size + manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+	return size, nil
+}
+
 func (c client) HasPushAccess(image string) (bool, error) {
 	ref, err := name.ParseReference(image)
 	if err != nil {
@@ -182,14 +282,17 @@ func (c client) getAuthentication(ref name.Reference) remote.Option {
 		return remote.WithAuth(authenticator)
 	}
 
+	// the credential helpers of the cloud registries issue short-lived tokens when config.json has no credentials for them
 	kc := authn.NewMultiKeychain(
 		authn.DefaultKeychain,
+		authn.NewKeychainFromHelper(inlineHelper(dockercredentials.GetCloudCredentials)),
 		authn.NewKeychainFromHelper(inlineHelper(c.config.GetExternalRegistryCredentials)),
 	)
 	if !env.LoadBooleanOrDefault(oktetoLocalRegistryStoreEnabledEnvVarKey, true) {
 		kc = authn.NewMultiKeychain(
 			authn.NewKeychainFromHelper(inlineHelper(c.config.GetExternalRegistryCredentials)),
 			authn.DefaultKeychain,
+			authn.NewKeychainFromHelper(inlineHelper(dockercredentials.GetCloudCredentials)),
 		)
 	}
 
@@ -197,7 +300,7 @@ func (c client) getAuthentication(ref name.Reference) remote.Option {
 }
 
 func (c client) getTransportOption() remote.Option {
-	return remote.WithTransport(c.getTransport())
+	return remote.WithTransport(timeoutTransport{base: c.getTransport(), timeout: getRetryPolicy(c.config).timeout})
 }
 func (c client) getTransport() http.RoundTripper {
 	sslTransportOption := &oktetoHttp.SSLTransportOption{
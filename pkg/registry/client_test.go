@@ -17,6 +17,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -84,11 +85,15 @@ func (t *fakeTLSDial) tlsDial(network string, addr string, config *tls.Config) (
 
 // FakeClient has everything needed to set up a test faking API calls
 type fakeClient struct {
-	GetImageDigest    getDigest
-	GetConfig         getConfig
-	MockGetDescriptor mockGetDescriptor
-	MockWrite         mockWrite
-	HasPushAcces      hasPushAccess
+	GetImageDigest       getDigest
+	GetConfig            getConfig
+	GetSize              getSize
+	MockGetDescriptor    mockGetDescriptor
+	MockWrite            mockWrite
+	HasPushAcces         hasPushAccess
+	MockListRepositories mockListRepositories
+	MockListTags         mockListTags
+	MockDelete           mockDelete
 }
 
 // GetDigest has everything needed to mock a getDigest API call
@@ -103,6 +108,11 @@ type getConfig struct {
 	Err    error
 }
 
+type getSize struct {
+	Err    error
+	Result int64
+}
+
 type mockGetDescriptor struct {
 	Result *remote.Descriptor
 	Err    error
@@ -117,6 +127,21 @@ type hasPushAccess struct {
 	Result bool
 }
 
+type mockListRepositories struct {
+	Err    error
+	Result []string
+}
+
+type mockListTags struct {
+	Err    error
+	Result map[string][]string
+}
+
+type mockDelete struct {
+	Err     error
+	Deleted *[]string
+}
+
 func (fc fakeClient) GetDigest(_ string) (string, error) {
 	return fc.GetImageDigest.Result, fc.GetImageDigest.Err
 }
@@ -125,6 +150,10 @@ func (fc fakeClient) GetImageConfig(_ string) (*containerv1.ConfigFile, error) {
 	return fc.GetConfig.Result, fc.GetConfig.Err
 }
 
+func (fc fakeClient) GetImageSize(_ string) (int64, error) {
+	return fc.GetSize.Result, fc.GetSize.Err
+}
+
 func (fc fakeClient) HasPushAccess(_ string) (bool, error) {
 	return fc.HasPushAcces.Result, fc.HasPushAcces.Err
 }
@@ -137,6 +166,21 @@ func (fc fakeClient) Write(_ name.Reference, _ containerv1.Image) error {
 	return fc.MockWrite.Err
 }
 
+func (fc fakeClient) ListRepositories(_ string) ([]string, error) {
+	return fc.MockListRepositories.Result, fc.MockListRepositories.Err
+}
+
+func (fc fakeClient) ListTags(repository string) ([]string, error) {
+	return fc.MockListTags.Result[repository], fc.MockListTags.Err
+}
+
+func (fc fakeClient) Delete(image string) error {
+	if fc.MockDelete.Deleted != nil {
+		*fc.MockDelete.Deleted = append(*fc.MockDelete.Deleted, image)
+	}
+	return fc.MockDelete.Err
+}
+
 type fakeClientConfig struct {
 	err                         error
 	cert                        *x509.Certificate
@@ -598,3 +642,15 @@ func Test_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestGetImageSizeError(t *testing.T) {
+	errGet := errors.New("manifest not available")
+	c := client{
+		config: fakeClientConfig{},
+		get: func(_ name.Reference, _ ...remote.Option) (*remote.Descriptor, error) {
+			return nil, errGet
+		},
+	}
+	_, err := c.GetImageSize("okteto/test:latest")
+	assert.ErrorIs(t, err, errGet)
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// DockerHubRegistry is the name used to configure the mirrors of Docker Hub
+const DockerHubRegistry = "docker.io"
+
+// registryMirrorsConfig is implemented by the configs that define pull-through caches of the registries
+type registryMirrorsConfig interface {
+	GetRegistryMirrors(registry string) []string
+}
+
+// NormalizeMirroredRegistry returns the name used to configure the mirrors of a registry
+func NormalizeMirroredRegistry(registry string) string {
+	registry = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(registry), "/"))
+	switch registry {
+	case name.DefaultRegistry, "registry-1.docker.io":
+		return DockerHubRegistry
+	}
+	return registry
+}
+
+// ValidateRegistryMirror checks that mirror is a registry host optionally followed by a repository prefix
+func ValidateRegistryMirror(mirror string) error {
+	if mirror == "" || strings.Contains(mirror, "://") {
+		return fmt.Errorf("invalid registry mirror '%s': it must be a registry host optionally followed by a path, e.g. 'mirror.example.com/dockerhub'", mirror)
+	}
+	if _, err := name.NewRepository(fmt.Sprintf("%s/image", strings.TrimSuffix(mirror, "/")), name.StrictValidation); err != nil {
+		return fmt.Errorf("invalid registry mirror '%s': %w", mirror, err)
+	}
+	return nil
+}
+
+// getMirrorReferences returns the references of the image in the mirrors of its registry, in the order they are configured
+func getMirrorReferences(config interface{}, ref name.Reference) []name.Reference {
+	mirrorsConfig, ok := config.(registryMirrorsConfig)
+	if !ok {
+		return nil
+	}
+	mirrors := mirrorsConfig.GetRegistryMirrors(NormalizeMirroredRegistry(ref.Context().RegistryStr()))
+	result := make([]name.Reference, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		image := fmt.Sprintf("%s/%s", strings.TrimSuffix(mirror, "/"), ref.Context().RepositoryStr())
+		switch r := ref.(type) {
+		case name.Digest:
+			image = fmt.Sprintf("%s@%s", image, r.DigestStr())
+		case name.Tag:
+			image = fmt.Sprintf("%s:%s", image, r.TagStr())
+		}
+		mirrorRef, err := name.ParseReference(image)
+		if err != nil {
+			oktetoLog.Infof("ignoring registry mirror '%s': %s", mirror, err)
+			continue
+		}
+		result = append(result, mirrorRef)
+	}
+	return result
+}
+
+// getFromMirrors returns the descriptor of the image from the first mirror that has it
+func (c client) getFromMirrors(ref name.Reference) (name.Reference, *remote.Descriptor, bool) {
+	for _, mirrorRef := range getMirrorReferences(c.config, ref) {
+		descriptor, err := c.get(mirrorRef, c.getOptions(mirrorRef)...)
+		if err != nil {
+			oktetoLog.Infof("image '%s' not available in mirror '%s', trying next source: %s", ref.String(), mirrorRef.String(), err)
+			continue
+		}
+		oktetoLog.Infof("image '%s' resolved from mirror '%s'", ref.String(), mirrorRef.String())
+		return mirrorRef, descriptor, true
+	}
+	return nil, nil, false
+}
+
+// getMirroredImage returns the image in the first mirror that has it, or the image itself when no mirror has it
+func (c client) getMirroredImage(image string) string {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return image
+	}
+	mirrorRef, _, ok := c.getFromMirrors(ref)
+	if !ok {
+		return image
+	}
+	return mirrorRef.String()
+}
+
+// GetMirroredImage returns the image in the first mirror of its registry that has it.
+// The image is returned as is when its registry has no mirrors or none of them has the image
+func GetMirroredImage(config ClientConfigInterface, image string) string {
+	return newOktetoRegistryClient(config).getMirroredImage(image)
+}
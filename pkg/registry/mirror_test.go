@@ -0,0 +1,177 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMirrorsClientConfig struct {
+	fakeClientConfig
+	mirrors map[string][]string
+}
+
+func (f fakeMirrorsClientConfig) GetRegistryMirrors(registry string) []string {
+	return f.mirrors[registry]
+}
+
+func TestNormalizeMirroredRegistry(t *testing.T) {
+	assert.Equal(t, "docker.io", NormalizeMirroredRegistry("index.docker.io"))
+	assert.Equal(t, "docker.io", NormalizeMirroredRegistry("registry-1.docker.io/"))
+	assert.Equal(t, "docker.io", NormalizeMirroredRegistry("docker.io"))
+	assert.Equal(t, "ghcr.io", NormalizeMirroredRegistry(" GHCR.io "))
+}
+
+func TestValidateRegistryMirror(t *testing.T) {
+	assert.NoError(t, ValidateRegistryMirror("mirror.example.com"))
+	assert.NoError(t, ValidateRegistryMirror("mirror.example.com:5000/dockerhub/"))
+	assert.Error(t, ValidateRegistryMirror(""))
+	assert.Error(t, ValidateRegistryMirror("https://mirror.example.com"))
+	assert.Error(t, ValidateRegistryMirror("mirror.example.com/Invalid Path"))
+}
+
+func TestGetMirrorReferences(t *testing.T) {
+	config := fakeMirrorsClientConfig{
+		mirrors: map[string][]string{
+			"docker.io": {"mirror.example.com/dockerhub/", "backup.example.com"},
+			"ghcr.io":   {"mirror.example.com/ghcr"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		image    string
+		expected []string
+	}{
+		{
+			name:     "docker hub official image",
+			image:    "node:18",
+			expected: []string{"mirror.example.com/dockerhub/library/node:18", "backup.example.com/library/node:18"},
+		},
+		{
+			name:     "other registry with digest",
+			image:    "ghcr.io/okteto/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			expected: []string{"mirror.example.com/ghcr/okteto/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+		},
+		{
+			name:     "registry without mirrors",
+			image:    "quay.io/okteto/app:1.0",
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := name.ParseReference(tt.image)
+			require.NoError(t, err)
+			result := []string{}
+			for _, r := range getMirrorReferences(config, ref) {
+				result = append(result, r.String())
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	ref, err := name.ParseReference("node:18")
+	require.NoError(t, err)
+	assert.Empty(t, getMirrorReferences(fakeClientConfig{}, ref))
+}
+
+func TestGetDigestWithMirrors(t *testing.T) {
+	mirrorDescriptor := &remote.Descriptor{Descriptor: containerv1.Descriptor{Digest: containerv1.Hash{Algorithm: "sha256", Hex: "mirror"}}}
+	upstreamDescriptor := &remote.Descriptor{Descriptor: containerv1.Descriptor{Digest: containerv1.Hash{Algorithm: "sha256", Hex: "upstream"}}}
+
+	tests := []struct {
+		available map[string]*remote.Descriptor
+		name      string
+		expected  string
+		requested []string
+	}{
+		{
+			name: "resolved from the first mirror",
+			available: map[string]*remote.Descriptor{
+				"mirror.example.com/dockerhub/library/node:18": mirrorDescriptor,
+			},
+			expected:  "sha256:mirror",
+			requested: []string{"mirror.example.com/dockerhub/library/node:18"},
+		},
+		{
+			name: "resolved from the second mirror",
+			available: map[string]*remote.Descriptor{
+				"backup.example.com/library/node:18": mirrorDescriptor,
+			},
+			expected:  "sha256:mirror",
+			requested: []string{"mirror.example.com/dockerhub/library/node:18", "backup.example.com/library/node:18"},
+		},
+		{
+			name: "fallback to the upstream registry",
+			available: map[string]*remote.Descriptor{
+				"index.docker.io/library/node:18": upstreamDescriptor,
+			},
+			expected:  "sha256:upstream",
+			requested: []string{"mirror.example.com/dockerhub/library/node:18", "backup.example.com/library/node:18", "index.docker.io/library/node:18"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := []string{}
+			c := client{
+				config: fakeMirrorsClientConfig{
+					fakeClientConfig: fakeClientConfig{cert: &x509.Certificate{}},
+					mirrors: map[string][]string{
+						"docker.io": {"mirror.example.com/dockerhub", "backup.example.com"},
+					},
+				},
+				get: func(ref name.Reference, _ ...remote.Option) (*remote.Descriptor, error) {
+					requested = append(requested, ref.Name())
+					if d, ok := tt.available[ref.Name()]; ok {
+						return d, nil
+					}
+					return nil, errors.New("not found")
+				},
+			}
+			digest, err := c.GetDigest("node:18")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, digest)
+			assert.Equal(t, tt.requested, requested)
+		})
+	}
+}
+
+func TestGetMirroredImage(t *testing.T) {
+	c := client{
+		config: fakeMirrorsClientConfig{
+			fakeClientConfig: fakeClientConfig{cert: &x509.Certificate{}},
+			mirrors: map[string][]string{
+				"docker.io": {"mirror.example.com/dockerhub"},
+			},
+		},
+		get: func(ref name.Reference, _ ...remote.Option) (*remote.Descriptor, error) {
+			if ref.Name() == "mirror.example.com/dockerhub/library/node:18" {
+				return &remote.Descriptor{}, nil
+			}
+			return nil, errors.New("not found")
+		},
+	}
+	assert.Equal(t, "mirror.example.com/dockerhub/library/node:18", c.getMirroredImage("node:18"))
+	assert.Equal(t, "golang:1.21", c.getMirroredImage("golang:1.21"))
+	assert.Equal(t, "ghcr.io/okteto/app", c.getMirroredImage("ghcr.io/okteto/app"))
+}
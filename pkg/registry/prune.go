@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DevImage is an image pushed to the okteto dev registry
+type DevImage struct {
+	// Created is the time the image was built
+	Created time.Time
+	// Image is the image reference with the tag
+	Image string
+	// Digest is the digest of the image the tag points to
+	Digest string
+	// Size is the compressed size of the image in the registry
+	Size int64
+}
+
+// ListDevImages returns the images pushed to the okteto dev registry of a namespace
+func (or OktetoRegistry) ListDevImages(namespace string) ([]DevImage, error) {
+	registry := or.config.GetRegistryURL()
+	repositories, err := or.client.ListRepositories(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("%s/", namespace)
+	result := []DevImage{}
+	for _, repo := range repositories {
+		if !strings.HasPrefix(repo, prefix) {
+			continue
+		}
+		repository := fmt.Sprintf("%s/%s", registry, repo)
+		tags, err := or.client.ListTags(repository)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			image := fmt.Sprintf("%s:%s", repository, tag)
+			digest, err := or.client.GetDigest(image)
+			if err != nil {
+				return nil, err
+			}
+			cfg, err := or.client.GetImageConfig(image)
+			if err != nil {
+				return nil, err
+			}
+			size, err := or.client.GetImageSize(image)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, DevImage{
+				Image:   image,
+				Digest:  digest,
+				Created: cfg.Created.Time,
+				Size:    size,
+			})
+		}
+	}
+	return result, nil
+}
+
+// DeleteDevImage deletes an image of the okteto dev registry. The manifest is deleted by digest,
+// so every tag pointing to the same digest is removed too
+func (or OktetoRegistry) DeleteDevImage(image DevImage) error {
+	repository, _ := or.imageCtrl.GetRepoNameAndTag(image.Image)
+	return or.client.Delete(fmt.Sprintf("%s@%s", repository, image.Digest))
+}
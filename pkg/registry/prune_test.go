@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDevImages(t *testing.T) {
+	created := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	config := FakeConfig{RegistryURL: "registry.okteto.dev", Namespace: "cindy"}
+	or := OktetoRegistry{
+		imageCtrl: NewImageCtrl(config),
+		config:    config,
+		client: fakeClient{
+			MockListRepositories: mockListRepositories{
+				Result: []string{"cindy/api", "cindy/frontend", "david/api"},
+			},
+			MockListTags: mockListTags{
+				Result: map[string][]string{
+					"registry.okteto.dev/cindy/api":      {"okteto", "1234"},
+					"registry.okteto.dev/cindy/frontend": {"okteto"},
+					"registry.okteto.dev/david/api":      {"okteto"},
+				},
+			},
+			GetImageDigest: getDigest{Result: "sha256:abc"},
+			GetConfig:      getConfig{Result: &v1.ConfigFile{Created: v1.Time{Time: created}}},
+			GetSize:        getSize{Result: 2048},
+		},
+	}
+
+	images, err := or.ListDevImages("cindy")
+	require.NoError(t, err)
+	assert.Equal(t, []DevImage{
+		{Image: "registry.okteto.dev/cindy/api:okteto", Digest: "sha256:abc", Created: created, Size: 2048},
+		{Image: "registry.okteto.dev/cindy/api:1234", Digest: "sha256:abc", Created: created, Size: 2048},
+		{Image: "registry.okteto.dev/cindy/frontend:okteto", Digest: "sha256:abc", Created: created, Size: 2048},
+	}, images)
+}
+
+func TestListDevImagesError(t *testing.T) {
+	errCatalog := errors.New("catalog not allowed")
+	or := OktetoRegistry{
+		config: FakeConfig{RegistryURL: "registry.okteto.dev"},
+		client: fakeClient{
+			MockListRepositories: mockListRepositories{Err: errCatalog},
+		},
+	}
+	_, err := or.ListDevImages("cindy")
+	assert.ErrorIs(t, err, errCatalog)
+}
+
+func TestDeleteDevImage(t *testing.T) {
+	deleted := []string{}
+	config := FakeConfig{RegistryURL: "registry.okteto.dev"}
+	or := OktetoRegistry{
+		imageCtrl: NewImageCtrl(config),
+		config:    config,
+		client: fakeClient{
+			MockDelete: mockDelete{Deleted: &deleted},
+		},
+	}
+	err := or.DeleteDevImage(DevImage{Image: "registry.okteto.dev/cindy/api:1234", Digest: "sha256:abc"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"registry.okteto.dev/cindy/api@sha256:abc"}, deleted)
+}
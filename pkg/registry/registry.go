@@ -19,6 +19,8 @@ import (
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/cosign"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 )
@@ -44,6 +46,9 @@ type OktetoRegistry struct {
 	client    clientInterface
 	imageCtrl ImageCtrl
 	config    configInterface
+
+	// verifySignature checks the signature of the images. It is nil if signed images are not required
+	verifySignature func(image string) error
 }
 
 type OktetoImageReference struct {
@@ -54,14 +59,32 @@ type OktetoImageReference struct {
 }
 
 func NewOktetoRegistry(config configInterface) OktetoRegistry {
-	return OktetoRegistry{
+	or := OktetoRegistry{
 		client:    newOktetoRegistryClient(config),
 		imageCtrl: NewImageCtrl(config),
 		config:    config,
 	}
+	if policy := cosign.GetPolicyFromEnv(); policy != nil {
+		or.verifySignature = newSignatureVerifier(*policy)
+	}
+	return or
 }
 
 func (or OktetoRegistry) GetImageTagWithDigest(image string) (string, error) {
+	imageTag, err := or.ResolveImageTagWithDigest(image)
+	if err != nil {
+		return "", err
+	}
+	if or.verifySignature != nil {
+		if err := or.verifySignature(imageTag); err != nil {
+			return "", err
+		}
+	}
+	return imageTag, nil
+}
+
+// ResolveImageTagWithDigest returns the image with its registry expanded and its digest, without verifying its signature
+func (or OktetoRegistry) ResolveImageTagWithDigest(image string) (string, error) {
 	expandedImage := or.imageCtrl.expandImageRegistries(image)
 
 	registry, repositoryWithTag := or.imageCtrl.GetRegistryAndRepo(expandedImage)
@@ -102,13 +125,21 @@ func (or OktetoRegistry) GetImageMetadata(image string) (ImageMetadata, error) {
 
 // IsOktetoRegistry returns if an image tag is pointing to the okteto registry
 func (or OktetoRegistry) IsOktetoRegistry(image string) bool {
+	if !or.config.IsOktetoCluster() {
+		return false
+	}
 	expandedImage := or.imageCtrl.expandImageRegistries(image)
-	return or.config.IsOktetoCluster() && strings.HasPrefix(expandedImage, or.config.GetRegistryURL())
+	if strings.HasPrefix(expandedImage, or.config.GetRegistryURL()) {
+		return true
+	}
+	// registry templates might expand the okteto registries to a different registry
+	expandedDevPrefix := or.imageCtrl.ExpandOktetoDevRegistry(fmt.Sprintf("%s/", constants.DevRegistry))
+	return strings.HasPrefix(expandedImage, expandedDevPrefix) || or.IsGlobalRegistry(image)
 }
 
 func (or OktetoRegistry) IsGlobalRegistry(image string) bool {
 	expandedImage := or.imageCtrl.expandImageRegistries(image)
-	expandedGlobalImage := fmt.Sprintf("%s/%s/", or.config.GetRegistryURL(), or.imageCtrl.config.GetGlobalNamespace())
+	expandedGlobalImage := or.imageCtrl.ExpandOktetoGlobalRegistry(fmt.Sprintf("%s/", constants.GlobalRegistry))
 	return strings.HasPrefix(expandedImage, expandedGlobalImage)
 }
 
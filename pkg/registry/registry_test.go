@@ -19,6 +19,7 @@ import (
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -128,6 +129,37 @@ func TestGetImageTagWithDigest(t *testing.T) {
 	}
 }
 
+func TestGetImageTagWithDigestVerifiesSignature(t *testing.T) {
+	cfg := FakeConfig{
+		ContextCertificate: &x509.Certificate{},
+	}
+	verified := []string{}
+	or := OktetoRegistry{
+		imageCtrl: NewImageCtrl(cfg),
+		client: fakeClient{
+			GetImageDigest: getDigest{
+				Result: "sha256:123",
+			},
+		},
+		verifySignature: func(image string) error {
+			verified = append(verified, image)
+			return nil
+		},
+	}
+
+	result, err := or.GetImageTagWithDigest("okteto/test")
+	require.NoError(t, err)
+	assert.Equal(t, "docker.io/okteto/test@sha256:123", result)
+	assert.Equal(t, []string{"docker.io/okteto/test@sha256:123"}, verified)
+
+	or.verifySignature = func(string) error {
+		return assert.AnError
+	}
+	result, err = or.GetImageTagWithDigest("okteto/test")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, result)
+}
+
 func TestHasPushAccess(t *testing.T) {
 	type expected struct {
 		err       error
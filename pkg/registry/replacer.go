@@ -14,9 +14,14 @@
 package registry
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"strings"
+	"text/template"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
 type Replacer struct {
@@ -27,6 +32,17 @@ type ReplacerConfigInterface interface {
 	GetRegistryURL() string
 }
 
+// registryTemplateConfig is implemented by the configs that customize how the okteto registries are expanded
+type registryTemplateConfig interface {
+	GetRegistryTemplate(registryType string) string
+}
+
+// registryTemplateData is the data available in the registry templates
+type registryTemplateData struct {
+	Registry  string
+	Namespace string
+}
+
 func NewRegistryReplacer(config ReplacerConfigInterface) Replacer {
 	return Replacer{
 		config: config,
@@ -37,7 +53,58 @@ func (r Replacer) Replace(image, registryType, namespace string) string {
 	// Check if the registryType is the start of the sentence or has a whitespace before it
 	var re = regexp.MustCompile(fmt.Sprintf(`(^|\s)(%s)`, registryType))
 	if re.MatchString(image) {
-		return strings.Replace(image, registryType, fmt.Sprintf("%s/%s", r.config.GetRegistryURL(), namespace), 1)
+		return strings.Replace(image, registryType, r.getRegistryPrefix(registryType, namespace), 1)
 	}
 	return image
 }
+
+// getRegistryPrefix returns the value registryType is expanded to, using the template configured for the registry if any
+func (r Replacer) getRegistryPrefix(registryType, namespace string) string {
+	data := registryTemplateData{
+		Registry:  r.config.GetRegistryURL(),
+		Namespace: namespace,
+	}
+	defaultPrefix := fmt.Sprintf("%s/%s", data.Registry, data.Namespace)
+
+	templateConfig, ok := r.config.(registryTemplateConfig)
+	if !ok {
+		return defaultPrefix
+	}
+	tpl := templateConfig.GetRegistryTemplate(registryType)
+	if tpl == "" {
+		return defaultPrefix
+	}
+	prefix, err := renderRegistryTemplate(tpl, data)
+	if err != nil {
+		oktetoLog.Infof("invalid template for '%s', using default expansion: %s", registryType, err)
+		return defaultPrefix
+	}
+	return prefix
+}
+
+func renderRegistryTemplate(tpl string, data registryTemplateData) (string, error) {
+	t, err := template.New("registry").Option("missingkey=error").Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "/"), nil
+}
+
+// ValidateRegistryTemplate checks that tpl is a valid template to expand registryType
+func ValidateRegistryTemplate(registryType, tpl string) error {
+	if registryType != constants.DevRegistry && registryType != constants.GlobalRegistry {
+		return fmt.Errorf("registry templates are only supported for '%s' and '%s'", constants.DevRegistry, constants.GlobalRegistry)
+	}
+	result, err := renderRegistryTemplate(tpl, registryTemplateData{Registry: "registry", Namespace: "namespace"})
+	if err != nil {
+		return fmt.Errorf("invalid template for '%s': %w", registryType, err)
+	}
+	if result == "" {
+		return fmt.Errorf("invalid template for '%s': it expands to an empty value", registryType)
+	}
+	return nil
+}
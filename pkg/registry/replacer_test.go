@@ -79,3 +79,74 @@ func TestReplace(t *testing.T) {
 	}
 
 }
+
+type fakeTemplateReplacerConfig struct {
+	templates   map[string]string
+	registryURL string
+}
+
+func (f fakeTemplateReplacerConfig) GetRegistryURL() string { return f.registryURL }
+
+func (f fakeTemplateReplacerConfig) GetRegistryTemplate(registryType string) string {
+	return f.templates[registryType]
+}
+
+func TestReplaceWithTemplate(t *testing.T) {
+	var tests = []struct {
+		templates    map[string]string
+		name         string
+		image        string
+		registryType string
+		expected     string
+	}{
+		{
+			name:         "no template",
+			image:        "okteto.dev/hello",
+			registryType: constants.DevRegistry,
+			expected:     "my-registry.com/test/hello",
+		},
+		{
+			name:         "dev template",
+			image:        "okteto.dev/hello",
+			registryType: constants.DevRegistry,
+			templates:    map[string]string{constants.DevRegistry: "{{ .Registry }}/dev-{{ .Namespace }}"},
+			expected:     "my-registry.com/dev-test/hello",
+		},
+		{
+			name:         "global template with trailing slash",
+			image:        "okteto.global/hello",
+			registryType: constants.GlobalRegistry,
+			templates:    map[string]string{constants.GlobalRegistry: "ecr.aws.com/shared/"},
+			expected:     "ecr.aws.com/shared/hello",
+		},
+		{
+			name:         "template for other registry",
+			image:        "okteto.dev/hello",
+			registryType: constants.DevRegistry,
+			templates:    map[string]string{constants.GlobalRegistry: "ecr.aws.com/shared"},
+			expected:     "my-registry.com/test/hello",
+		},
+		{
+			name:         "invalid template uses default",
+			image:        "okteto.dev/hello",
+			registryType: constants.DevRegistry,
+			templates:    map[string]string{constants.DevRegistry: "{{ .Unknown }}"},
+			expected:     "my-registry.com/test/hello",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replacer := NewRegistryReplacer(fakeTemplateReplacerConfig{registryURL: "my-registry.com", templates: tt.templates})
+			assert.Equal(t, tt.expected, replacer.Replace(tt.image, tt.registryType, "test"))
+		})
+	}
+}
+
+func TestValidateRegistryTemplate(t *testing.T) {
+	assert.NoError(t, ValidateRegistryTemplate(constants.DevRegistry, "{{ .Registry }}/{{ .Namespace }}"))
+	assert.NoError(t, ValidateRegistryTemplate(constants.GlobalRegistry, "ecr.aws.com/shared"))
+	assert.Error(t, ValidateRegistryTemplate("docker.io", "{{ .Registry }}"))
+	assert.Error(t, ValidateRegistryTemplate(constants.DevRegistry, "{{ .Registry"))
+	assert.Error(t, ValidateRegistryTemplate(constants.DevRegistry, "{{ .Unknown }}"))
+	assert.Error(t, ValidateRegistryTemplate(constants.DevRegistry, "/"))
+}
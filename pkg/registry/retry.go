@@ -0,0 +1,187 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// registryRetriesEnvVar defines how many times a registry operation is retried after a transient error
+	registryRetriesEnvVar = "OKTETO_REGISTRY_RETRIES"
+
+	// registryRetryBackoffEnvVar defines the wait before the first retry. It is doubled on every retry
+	registryRetryBackoffEnvVar = "OKTETO_REGISTRY_RETRY_BACKOFF"
+
+	// registryTimeoutEnvVar defines how long to wait for the response of each request to the registry
+	registryTimeoutEnvVar = "OKTETO_REGISTRY_TIMEOUT"
+
+	defaultRegistryRetries      = 3
+	defaultRegistryRetryBackoff = 500 * time.Millisecond
+	defaultRegistryTimeout      = 60 * time.Second
+	maxRegistryRetryBackoff     = 10 * time.Second
+)
+
+// registryRetryConfig is implemented by the configs that define the retries of the registry operations.
+// Zero values use the defaults, and the environment variables take precedence over them
+type registryRetryConfig interface {
+	GetRegistryRetries() int
+	GetRegistryRetryBackoff() string
+	GetRegistryTimeout() string
+}
+
+// retryPolicy defines how the registry operations are retried
+type retryPolicy struct {
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+}
+
+// getRetryPolicy returns the retry policy of the registry operations from the environment and the config
+func getRetryPolicy(config interface{}) retryPolicy {
+	policy := retryPolicy{
+		retries: defaultRegistryRetries,
+		backoff: defaultRegistryRetryBackoff,
+		timeout: defaultRegistryTimeout,
+	}
+
+	var retries int
+	var backoff, timeout string
+	if retryConfig, ok := config.(registryRetryConfig); ok {
+		retries = retryConfig.GetRegistryRetries()
+		backoff = retryConfig.GetRegistryRetryBackoff()
+		timeout = retryConfig.GetRegistryTimeout()
+	}
+	if retries > 0 {
+		policy.retries = retries
+	}
+	if v, ok := os.LookupEnv(registryRetriesEnvVar); ok {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			oktetoLog.Infof("'%s' is not a valid number of retries, ignoring", v)
+		} else {
+			policy.retries = parsed
+		}
+	}
+	if v, ok := os.LookupEnv(registryRetryBackoffEnvVar); ok {
+		backoff = v
+	}
+	if v, ok := os.LookupEnv(registryTimeoutEnvVar); ok {
+		timeout = v
+	}
+	policy.backoff = parsePositiveDuration(backoff, policy.backoff)
+	policy.timeout = parsePositiveDuration(timeout, policy.timeout)
+	return policy
+}
+
+func parsePositiveDuration(value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		oktetoLog.Infof("'%s' is not a valid duration, ignoring", value)
+		return defaultValue
+	}
+	return parsed
+}
+
+// withRetries runs a registry operation until it succeeds, fails with an error that is not transient or runs out of retries.
+// Running out of retries is reported as ErrRegistryUnavailable, so callers can tell it apart from a missing image
+func (c client) withRetries(operation string, fn func() error) error {
+	policy := getRetryPolicy(c.config)
+	sleep := c.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	backoff := policy.backoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !isTransientError(err) {
+			return err
+		}
+		if attempt >= policy.retries {
+			return fmt.Errorf("%w: %s failed after %d attempts: %w", oktetoErrors.ErrRegistryUnavailable, operation, attempt+1, err)
+		}
+		oktetoLog.Infof("%s failed, retrying in %s: %s", operation, backoff, err)
+		sleep(backoff)
+		backoff = min(2*backoff, maxRegistryRetryBackoff)
+	}
+}
+
+// isTransientError returns true for the network errors and the registry responses that may succeed if retried
+func isTransientError(err error) bool {
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		return transportErr.Temporary() || transportErr.StatusCode == http.StatusTooManyRequests || transportErr.StatusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// timeoutTransport cancels the requests to the registry that don't get a response in time.
+// Reading the body is not limited, so big blobs can be transferred over slow connections
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(t.timeout, cancel)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if !timer.Stop() && err != nil {
+		cancel()
+		return nil, fmt.Errorf("no response from the registry after %s: %w", t.timeout, context.DeadlineExceeded)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the context of a request when its body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRetryConfig is a client config that defines the retries of the registry operations
+type fakeRetryConfig struct {
+	fakeClientConfig
+	backoff string
+	timeout string
+	retries int
+}
+
+func (f fakeRetryConfig) GetRegistryRetries() int         { return f.retries }
+func (f fakeRetryConfig) GetRegistryRetryBackoff() string { return f.backoff }
+func (f fakeRetryConfig) GetRegistryTimeout() string      { return f.timeout }
+
+func TestGetRetryPolicy(t *testing.T) {
+	tests := []struct {
+		config   interface{}
+		env      map[string]string
+		name     string
+		expected retryPolicy
+	}{
+		{
+			name:     "defaults",
+			config:   fakeClientConfig{},
+			expected: retryPolicy{retries: defaultRegistryRetries, backoff: defaultRegistryRetryBackoff, timeout: defaultRegistryTimeout},
+		},
+		{
+			name:     "context config",
+			config:   fakeRetryConfig{retries: 5, backoff: "1s", timeout: "2m"},
+			expected: retryPolicy{retries: 5, backoff: time.Second, timeout: 2 * time.Minute},
+		},
+		{
+			name:   "env vars take precedence over the context config",
+			config: fakeRetryConfig{retries: 5, backoff: "1s", timeout: "2m"},
+			env: map[string]string{
+				registryRetriesEnvVar:      "0",
+				registryRetryBackoffEnvVar: "100ms",
+				registryTimeoutEnvVar:      "10s",
+			},
+			expected: retryPolicy{retries: 0, backoff: 100 * time.Millisecond, timeout: 10 * time.Second},
+		},
+		{
+			name:   "invalid values are ignored",
+			config: fakeRetryConfig{timeout: "-1s"},
+			env: map[string]string{
+				registryRetriesEnvVar:      "many",
+				registryRetryBackoffEnvVar: "soon",
+			},
+			expected: retryPolicy{retries: defaultRegistryRetries, backoff: defaultRegistryRetryBackoff, timeout: defaultRegistryTimeout},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			assert.Equal(t, tt.expected, getRetryPolicy(tt.config))
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, isTransientError(&transport.Error{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, isTransientError(&transport.Error{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, isTransientError(io.ErrUnexpectedEOF))
+	assert.False(t, isTransientError(&transport.Error{StatusCode: http.StatusUnauthorized}))
+	assert.False(t, isTransientError(&transport.Error{
+		StatusCode: http.StatusNotFound,
+		Errors:     []transport.Diagnostic{{Code: transport.ManifestUnknownErrorCode}},
+	}))
+	assert.False(t, isTransientError(errors.New("invalid reference")))
+}
+
+func TestGetDescriptorRetries(t *testing.T) {
+	unavailable := &transport.Error{StatusCode: http.StatusBadGateway}
+	tests := []struct {
+		errs          []error
+		expectedErr   error
+		name          string
+		expectedCalls int
+		expectedWaits []time.Duration
+	}{
+		{
+			name:          "recovers from transient errors",
+			errs:          []error{unavailable, unavailable, nil},
+			expectedCalls: 3,
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:          "runs out of retries",
+			errs:          []error{unavailable, unavailable, unavailable, unavailable},
+			expectedErr:   oktetoErrors.ErrRegistryUnavailable,
+			expectedCalls: 3,
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name: "not found is not retried",
+			errs: []error{&transport.Error{
+				StatusCode: http.StatusNotFound,
+				Errors:     []transport.Diagnostic{{Code: transport.ManifestUnknownErrorCode}},
+			}},
+			expectedErr:   oktetoErrors.ErrNotFound,
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var waits []time.Duration
+			c := client{
+				config: fakeRetryConfig{retries: 2, backoff: "1s"},
+				get: func(_ name.Reference, _ ...remote.Option) (*remote.Descriptor, error) {
+					err := tt.errs[calls]
+					calls++
+					if err != nil {
+						return nil, err
+					}
+					return &remote.Descriptor{}, nil
+				},
+				sleep: func(d time.Duration) {
+					waits = append(waits, d)
+				},
+			}
+			_, err := c.GetDescriptor("okteto/test:latest")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedWaits, waits)
+			// a missing image is never reported as an unavailable registry
+			if errors.Is(err, oktetoErrors.ErrNotFound) {
+				assert.NotErrorIs(t, err, oktetoErrors.ErrRegistryUnavailable)
+			}
+		})
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	httpClient := &http.Client{Transport: timeoutTransport{base: http.DefaultTransport, timeout: 100 * time.Millisecond}}
+
+	resp, err := httpClient.Get(server.URL + "/fast")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "ok", string(body))
+
+	_, err = httpClient.Get(server.URL + "/slow")
+	require.Error(t, err)
+	assert.True(t, isTransientError(err))
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/cosign"
+)
+
+// newSignatureVerifier returns a function that verifies the signature of an image with cosign according to the policy
+func newSignatureVerifier(policy cosign.Policy) func(image string) error {
+	return func(image string) error {
+		c, err := cosign.New()
+		if err != nil {
+			return err
+		}
+		return c.Verify(context.Background(), image, policy)
+	}
+}
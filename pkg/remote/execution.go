@@ -0,0 +1,177 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// ExecutionLocal means that a phase runs in the machine executing the okteto CLI
+	ExecutionLocal = "local"
+	// ExecutionRemote means that a phase runs in the cluster
+	ExecutionRemote = "remote"
+
+	// CommandsPhase is the phase running the commands of the okteto manifest
+	CommandsPhase = "commands"
+
+	// WhereFormatText prints the execution report as text
+	WhereFormatText = "text"
+	// WhereFormatJSON prints the execution report as json
+	WhereFormatJSON = "json"
+)
+
+var (
+	errRequireLocalAndRemote = errors.New("the '--require-local' and '--require-remote' flags can't be used at the same time")
+)
+
+// ExecutionRequest has the information used to decide where the commands of an operation run
+type ExecutionRequest struct {
+	// Command is the operation requested: deploy or destroy
+	Command string
+	// ManifestImage is the image defined in the section of the okteto manifest of the command
+	ManifestImage string
+	// RemoteFlag is true when the '--remote' flag is set
+	RemoteFlag bool
+	// ManifestRemote is true when 'remote' is enabled in the section of the okteto manifest of the command
+	ManifestRemote bool
+	// RequireLocal fails the command if it would run remotely
+	RequireLocal bool
+	// RequireRemote fails the command if it can't run remotely
+	RequireRemote bool
+	// IsOkteto is true when the current context has Okteto installed
+	IsOkteto bool
+}
+
+// Phase represents where a phase of a command runs and why
+type Phase struct {
+	Name   string `json:"name"`
+	Where  string `json:"where"`
+	Reason string `json:"reason"`
+}
+
+// ExecutionReport represents where each phase of a command runs
+type ExecutionReport struct {
+	Command string  `json:"command"`
+	Phases  []Phase `json:"phases"`
+}
+
+// ValidateWhereFormat checks the value of the '--where' flag
+func ValidateWhereFormat(format string) error {
+	switch format {
+	case "", WhereFormatText, WhereFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("'--where' format is not accepted. Value must be one of: ['%s', '%s']", WhereFormatText, WhereFormatJSON)
+	}
+}
+
+// IsRemoteRequested returns if the commands are requested to run remotely, regardless of the context capabilities
+func IsRemoteRequested(req ExecutionRequest) bool {
+	return getRemoteReason(req) != ""
+}
+
+// getRemoteReason returns why the commands are requested to run remotely. It returns an empty string if they are not
+func getRemoteReason(req ExecutionRequest) string {
+	// already in remote so the commands run locally in the remote runner
+	if env.LoadBoolean(constants.OktetoDeployRemote) {
+		return ""
+	}
+	if req.RemoteFlag {
+		return "the '--remote' flag is set"
+	}
+	if req.RequireRemote {
+		return "the '--require-remote' flag is set"
+	}
+	if req.ManifestImage != "" {
+		return fmt.Sprintf("'%s.image' is set in the okteto manifest", req.Command)
+	}
+	if req.ManifestRemote {
+		return fmt.Sprintf("'%s.remote' is set in the okteto manifest", req.Command)
+	}
+	if env.LoadBoolean(constants.OktetoForceRemote) {
+		return fmt.Sprintf("the '%s' environment variable is set", constants.OktetoForceRemote)
+	}
+	return ""
+}
+
+// ResolveCommandsExecution returns where the commands of an operation run and why.
+// It fails if the result doesn't satisfy the '--require-local' or '--require-remote' flags
+func ResolveCommandsExecution(req ExecutionRequest) (Phase, error) {
+	if req.RequireLocal && req.RequireRemote {
+		return Phase{}, errRequireLocalAndRemote
+	}
+
+	phase := Phase{Name: CommandsPhase}
+	if env.LoadBoolean(constants.OktetoDeployRemote) {
+		phase.Where = ExecutionLocal
+		phase.Reason = "already running in the remote runner"
+		return phase, nil
+	}
+
+	reason := getRemoteReason(req)
+	switch {
+	case reason == "":
+		phase.Where = ExecutionLocal
+		phase.Reason = "remote execution is not enabled"
+	case !req.IsOkteto:
+		if req.RequireRemote {
+			return Phase{}, oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s' can't run remotely: remote execution is only supported in contexts with Okteto installed", req.Command),
+				Hint: "Remove the '--require-remote' flag or switch to a context with Okteto installed",
+			}
+		}
+		phase.Where = ExecutionLocal
+		phase.Reason = fmt.Sprintf("%s, but remote execution is only supported in contexts with Okteto installed", reason)
+	default:
+		phase.Where = ExecutionRemote
+		phase.Reason = reason
+	}
+
+	if req.RequireLocal && phase.Where == ExecutionRemote {
+		return Phase{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' can't run locally: %s", req.Command, phase.Reason),
+			Hint: "Remove the '--require-local' flag or disable remote execution",
+		}
+	}
+	return phase, nil
+}
+
+// Print shows the execution report in the given format
+func (r ExecutionReport) Print(format string) error {
+	if format == WhereFormatJSON {
+		bytes, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return err
+		}
+		oktetoLog.Println(string(bytes))
+		return nil
+	}
+
+	lines := make([]string, 0, len(r.Phases))
+	for _, phase := range r.Phases {
+		lines = append(lines, fmt.Sprintf("  - %s: %s (%s)", phase.Name, phase.Where, phase.Reason))
+	}
+	oktetoLog.Information("Execution plan for 'okteto %s':", r.Command)
+	oktetoLog.Println(strings.Join(lines, "\n"))
+	return nil
+}
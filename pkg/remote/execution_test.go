@@ -0,0 +1,117 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCommandsExecution(t *testing.T) {
+	tests := []struct {
+		name        string
+		inRemote    string
+		forceRemote string
+		expected    Phase
+		req         ExecutionRequest
+		expectedErr bool
+	}{
+		{
+			name:     "local by default",
+			req:      ExecutionRequest{Command: DeployCommand, IsOkteto: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionLocal, Reason: "remote execution is not enabled"},
+		},
+		{
+			name:     "already in the remote runner",
+			inRemote: "true",
+			req:      ExecutionRequest{Command: DeployCommand, RemoteFlag: true, RequireRemote: true, IsOkteto: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionLocal, Reason: "already running in the remote runner"},
+		},
+		{
+			name:     "remote flag",
+			req:      ExecutionRequest{Command: DeployCommand, RemoteFlag: true, IsOkteto: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionRemote, Reason: "the '--remote' flag is set"},
+		},
+		{
+			name:     "manifest image",
+			req:      ExecutionRequest{Command: DestroyCommand, ManifestImage: "okteto/bin", IsOkteto: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionRemote, Reason: "'destroy.image' is set in the okteto manifest"},
+		},
+		{
+			name:     "manifest remote",
+			req:      ExecutionRequest{Command: DeployCommand, ManifestRemote: true, IsOkteto: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionRemote, Reason: "'deploy.remote' is set in the okteto manifest"},
+		},
+		{
+			name:        "force remote env var",
+			forceRemote: "true",
+			req:         ExecutionRequest{Command: DeployCommand, IsOkteto: true},
+			expected:    Phase{Name: CommandsPhase, Where: ExecutionRemote, Reason: "the 'OKTETO_FORCE_REMOTE' environment variable is set"},
+		},
+		{
+			name:     "remote requested in a context without okteto",
+			req:      ExecutionRequest{Command: DeployCommand, RemoteFlag: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionLocal, Reason: "the '--remote' flag is set, but remote execution is only supported in contexts with Okteto installed"},
+		},
+		{
+			name:     "require remote",
+			req:      ExecutionRequest{Command: DeployCommand, RequireRemote: true, IsOkteto: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionRemote, Reason: "the '--require-remote' flag is set"},
+		},
+		{
+			name:        "require remote in a context without okteto",
+			req:         ExecutionRequest{Command: DeployCommand, RequireRemote: true},
+			expectedErr: true,
+		},
+		{
+			name:        "require local with remote manifest",
+			req:         ExecutionRequest{Command: DeployCommand, ManifestRemote: true, RequireLocal: true, IsOkteto: true},
+			expectedErr: true,
+		},
+		{
+			name:     "require local",
+			req:      ExecutionRequest{Command: DeployCommand, RequireLocal: true, IsOkteto: true},
+			expected: Phase{Name: CommandsPhase, Where: ExecutionLocal, Reason: "remote execution is not enabled"},
+		},
+		{
+			name:        "require local and remote",
+			req:         ExecutionRequest{Command: DeployCommand, RequireLocal: true, RequireRemote: true, IsOkteto: true},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(constants.OktetoDeployRemote, tt.inRemote)
+			t.Setenv(constants.OktetoForceRemote, tt.forceRemote)
+
+			phase, err := ResolveCommandsExecution(tt.req)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, phase)
+		})
+	}
+}
+
+func TestValidateWhereFormat(t *testing.T) {
+	require.NoError(t, ValidateWhereFormat(""))
+	require.NoError(t, ValidateWhereFormat("text"))
+	require.NoError(t, ValidateWhereFormat("json"))
+	require.Error(t, ValidateWhereFormat("yaml"))
+}
@@ -224,7 +224,8 @@ func NewRunner(ioCtrl *io.Controller, builder Builder) *Runner {
 }
 
 // Run This function is the one in charge of creating the Dockerfile needed to run
-// remote execution of commands like destroy and deploy and triggers the build
+// remote execution of commands like destroy and deploy and triggers the build.
+// The local build context, including uncommitted changes, is sent to the runner: sources are never cloned from git
 func (r *Runner) Run(ctx context.Context, params *Params) error {
 	home, err := homedir.Dir()
 	if err != nil {
@@ -500,7 +501,7 @@ func createDockerignoreFileWithFilesystem(cwd, tmpDir string, rules []string, us
 			if err != nil {
 				return err
 			}
-			oktetoLog.Warning("Ignoring files through %s is deprecated and will be removed in future versions. Please use .oktetoignore. More info here: https://www.okteto.com/docs/core/remote-execution/#ignoring-files", oktetoDockerignoreName)
+			oktetoLog.WarningWithID(oktetoLog.WarnOktetoDeployIgnoreDeprecated, "Ignoring files through %s is deprecated and will be removed in future versions. Please use .oktetoignore. More info here: https://www.okteto.com/docs/core/remote-execution/#ignoring-files", oktetoDockerignoreName)
 			dockerignoreContent = append(dockerignoreContent, []byte("\n")...)
 		}
 	}
@@ -14,13 +14,21 @@
 package remote
 
 import (
+	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
+	goio "io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/pkg/archive"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
 	"github.com/okteto/okteto/internal/test/client"
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -37,15 +45,59 @@ import (
 type fakeBuilder struct {
 	err           error
 	assertOptions func(o *types.BuildOptions)
+	// contextFiles captures the files of the build context sent to the builder
+	contextFiles map[string]string
 }
 
 func (f fakeBuilder) Run(_ context.Context, opts *types.BuildOptions, _ *io.Controller) error {
 	if f.assertOptions != nil {
 		f.assertOptions(opts)
 	}
+	if f.contextFiles != nil {
+		if err := readBuildContext(opts, f.contextFiles); err != nil {
+			return err
+		}
+	}
 	return f.err
 }
 
+// readBuildContext reads the files of the tarball created from the build context, excluding the ones in the .dockerignore of the Dockerfile
+func readBuildContext(opts *types.BuildOptions, files map[string]string) error {
+	var excludes []string
+	f, err := os.Open(filepath.Join(filepath.Dir(opts.File), ".dockerignore"))
+	if err == nil {
+		excludes, err = dockerignore.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	tarball, err := archive.TarWithOptions(opts.Path, &archive.TarOptions{ExcludePatterns: excludes})
+	if err != nil {
+		return err
+	}
+	defer tarball.Close()
+	tr := tar.NewReader(tarball)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, goio.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := goio.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		files[header.Name] = string(content)
+	}
+}
+
 func TestRemoteTest(t *testing.T) {
 	ctx := context.Background()
 	fakeManifest := &model.Manifest{
@@ -245,6 +297,70 @@ func TestRemoteDeployWithSshAgent(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRemoteDeploySendsUncommittedChanges(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "namespace",
+			},
+		},
+	}
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "okteto.yml"), []byte("deploy:\n  - ./deploy.sh\n"), 0600))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add("okteto.yml")
+	require.NoError(t, err)
+	_, err = wt.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "okteto", Email: "okteto@okteto.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	// modified and untracked files that are not committed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "okteto.yml"), []byte("deploy:\n  - ./deploy.sh --local\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deploy.sh"), []byte("echo deploy"), 0600))
+
+	assertFn := func(o *types.BuildOptions) {
+		t.Cleanup(func() {
+			os.RemoveAll(filepath.Dir(o.File))
+		})
+
+		dockerfile, err := os.ReadFile(o.File)
+		require.NoError(t, err)
+		assert.Contains(t, string(dockerfile), "COPY . /okteto/src")
+		assert.NotContains(t, string(dockerfile), "git clone")
+	}
+	contextFiles := map[string]string{}
+
+	fs := afero.NewOsFs()
+	oktetoClient := &client.FakeOktetoClient{
+		Users: client.NewFakeUsersClient(&types.User{}),
+	}
+	rdc := Runner{
+		builder:              fakeBuilder{assertOptions: assertFn, contextFiles: contextFiles},
+		fs:                   fs,
+		workingDirectoryCtrl: filesystem.NewFakeWorkingDirectoryCtrl(dir),
+		temporalCtrl:         filesystem.NewTemporalDirectoryCtrl(fs),
+		oktetoClientProvider: client.NewFakeOktetoClientProvider(oktetoClient),
+		ioCtrl:               io.NewIOController(),
+	}
+
+	err = rdc.Run(context.Background(), &Params{
+		DockerfileName: "Dockerfile.deploy",
+		Manifest: &model.Manifest{
+			Deploy: &model.DeployInfo{
+				Image: "test-image",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "deploy:\n  - ./deploy.sh --local\n", contextFiles["okteto.yml"])
+	assert.Equal(t, "echo deploy", contextFiles["deploy.sh"])
+}
+
 func TestRemoteDeployWithBadSshAgent(t *testing.T) {
 	fs := afero.NewMemMapFs()
 
@@ -480,31 +480,15 @@ func (ogr oktetoGitWorktree) ListUntrackedFiles(ctx context.Context, workdir str
 
 // GetLatestSHA calculates a SHA of a repo for the specified directory (dirpath). The result depends on having
 // git installed locally or not.
-// - If git is not installed locally, it will use the latest commit of that directory.
+// - If git is not installed locally, it will use the hash of the git tree of that directory at HEAD.
 // - If git is installed it will get a SHA from the files tracked within that directory.
 func (ogr oktetoGitRepository) GetLatestSHA(ctx context.Context, dirpath string, localGit LocalGitInterface) (string, error) {
 	// using git directly is faster, so we check if it's available
 	_, err := localGit.Exists()
 	if err != nil {
 		// git is not available, so we fall back on git-go
-		oktetoLog.Debug("Calculating git latest commit: git is not installed, for better performances consider installing it")
-		cIter, err := ogr.Log(&git.LogOptions{
-			PathFilter: func(s string) bool {
-				return s == dirpath
-			},
-		})
-		if err != nil {
-			return "", fmt.Errorf("failed to get git log: %w", err)
-		}
-		commit := ""
-		err = cIter.ForEach(func(c *object.Commit) error {
-			commit = c.Hash.String()
-			return nil
-		})
-		if err != nil {
-			return "", fmt.Errorf("failed to get git log: %w", err)
-		}
-		return commit, nil
+		oktetoLog.Debug("Calculating git tree hash: git is not installed, for better performances consider installing it")
+		return getDirTreeHash(ogr.repo, ogr.root, dirpath)
 	}
 
 	commit, err := localGit.GetDirContentSHA(ctx, ogr.root, dirpath, 0)
@@ -515,6 +499,44 @@ func (ogr oktetoGitRepository) GetLatestSHA(ctx context.Context, dirpath string,
 	return commit, nil
 }
 
+// getDirTreeHash returns the hash of the git tree of dirpath at HEAD. Unlike the commit that last modified dirpath,
+// the tree hash only depends on its content, so it is the same on every branch with the same files
+func getDirTreeHash(repo *git.Repository, root, dirpath string) (string, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git head: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", fmt.Errorf("failed to get git head commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git tree: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to infer the git repo's current worktree: %w", err)
+	}
+	absDir, err := filepath.Abs(filepath.Join(root, dirpath))
+	if err != nil {
+		return "", err
+	}
+	relDir, err := filepath.Rel(worktree.Filesystem.Root(), absDir)
+	if err != nil {
+		return "", fmt.Errorf("'%s' is not inside the git repo: %w", dirpath, err)
+	}
+	if relDir == "." {
+		return tree.Hash.String(), nil
+	}
+	subtree, err := tree.Tree(filepath.ToSlash(relDir))
+	if err != nil {
+		return "", fmt.Errorf("failed to get git tree of '%s': %w", dirpath, err)
+	}
+	return subtree.Hash.String(), nil
+}
+
 // GetDiff returns the diff between the current state of the repo and the last commit
 func (ogr oktetoGitRepository) GetDiff(ctx context.Context, dirpath string, localGit LocalGitInterface) (string, error) {
 	// using git directly is faster, so we check if it's available
@@ -19,9 +19,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -580,3 +582,48 @@ func Test_gitRepoController_sanitiseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDirTreeHash(t *testing.T) {
+	commitFiles := func(t *testing.T, branch string, files map[string]string) (*git.Repository, string) {
+		t.Helper()
+		dir := t.TempDir()
+		repo, err := git.PlainInit(dir, false)
+		assert.NoError(t, err)
+		wt, err := repo.Worktree()
+		assert.NoError(t, err)
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+			assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			_, err = wt.Add(name)
+			assert.NoError(t, err)
+		}
+		_, err = wt.Commit(fmt.Sprintf("commit on %s", branch), &git.CommitOptions{
+			Author: &object.Signature{Name: branch, Email: "okteto@okteto.com", When: time.Now()},
+		})
+		assert.NoError(t, err)
+		return repo, dir
+	}
+
+	repoA, dirA := commitFiles(t, "main", map[string]string{"api/Dockerfile": "FROM alpine", "frontend/Dockerfile": "FROM node"})
+	repoB, dirB := commitFiles(t, "feature", map[string]string{"api/Dockerfile": "FROM alpine", "frontend/Dockerfile": "FROM node:20"})
+
+	apiA, err := getDirTreeHash(repoA, dirA, "api")
+	assert.NoError(t, err)
+	apiB, err := getDirTreeHash(repoB, dirB, "api")
+	assert.NoError(t, err)
+	assert.Equal(t, apiA, apiB)
+
+	frontendA, err := getDirTreeHash(repoA, dirA, "frontend")
+	assert.NoError(t, err)
+	frontendB, err := getDirTreeHash(repoB, dirB, "frontend")
+	assert.NoError(t, err)
+	assert.NotEqual(t, frontendA, frontendB)
+
+	rootA, err := getDirTreeHash(repoA, dirA, ".")
+	assert.NoError(t, err)
+	assert.NotEqual(t, apiA, rootA)
+
+	_, err = getDirTreeHash(repoA, dirA, "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	// HeadRefPrefix is the prefix of the branch references
+	HeadRefPrefix = "refs/heads/"
+	// TagRefPrefix is the prefix of the tag references
+	TagRefPrefix = "refs/tags/"
+	// PeeledRefSuffix is the suffix of the references that point to the commit of an annotated tag
+	PeeledRefSuffix = "^{}"
+)
+
+var commitSHARegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// IsCommitSHA returns if s is a full or abbreviated commit SHA
+func IsCommitSHA(s string) bool {
+	return commitSHARegex.MatchString(s)
+}
+
+// ListRemoteRefs returns the references of a remote repository, including the peeled references of annotated tags
+func ListRemoteRefs(ctx context.Context, repositoryURL string) ([]*plumbing.Reference, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{repositoryURL},
+	})
+	return remote.ListContext(ctx, &git.ListOptions{PeelingOption: git.AppendPeeled})
+}
+
+// FindRefCommit returns the commit of a reference. Annotated tags are resolved to the commit they reference
+func FindRefCommit(refs []*plumbing.Reference, name string) (string, bool) {
+	commit := ""
+	for _, ref := range refs {
+		switch ref.Name().String() {
+		case name + PeeledRefSuffix:
+			return ref.Hash().String(), true
+		case name:
+			commit = ref.Hash().String()
+		}
+	}
+	return commit, commit != ""
+}
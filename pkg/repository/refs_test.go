@@ -0,0 +1,48 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCommitSHA(t *testing.T) {
+	assert.True(t, IsCommitSHA("a1b2c3d"))
+	assert.True(t, IsCommitSHA("0123456789abcdef0123456789abcdef01234567"))
+	assert.False(t, IsCommitSHA("a1b2c3"))
+	assert.False(t, IsCommitSHA("main"))
+	assert.False(t, IsCommitSHA("A1B2C3D"))
+}
+
+func TestFindRefCommit(t *testing.T) {
+	refs := []*plumbing.Reference{
+		plumbing.NewHashReference("refs/heads/main", plumbing.NewHash("1111111111111111111111111111111111111111")),
+		plumbing.NewHashReference("refs/tags/v1.0.0", plumbing.NewHash("2222222222222222222222222222222222222222")),
+		plumbing.NewHashReference("refs/tags/v1.0.0^{}", plumbing.NewHash("3333333333333333333333333333333333333333")),
+	}
+
+	commit, ok := FindRefCommit(refs, HeadRefPrefix+"main")
+	assert.True(t, ok)
+	assert.Equal(t, "1111111111111111111111111111111111111111", commit)
+
+	commit, ok = FindRefCommit(refs, TagRefPrefix+"v1.0.0")
+	assert.True(t, ok)
+	assert.Equal(t, "3333333333333333333333333333333333333333", commit)
+
+	_, ok = FindRefCommit(refs, TagRefPrefix+"v2.0.0")
+	assert.False(t, ok)
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// Checksum returns the checksum of the definition of a seed and the content of its files
+func Checksum(fs afero.Fs, s *Seed) (string, error) {
+	h := sha256.New()
+	definition, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	h.Write(definition)
+
+	for _, f := range s.files() {
+		err := afero.Walk(fs, f, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			h.Write([]byte(filepath.ToSlash(path)))
+			file, err := fs.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.Copy(h, file)
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("could not read the files of seed '%s': %w", s.Name, err)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
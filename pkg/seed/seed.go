@@ -0,0 +1,172 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	s3Scheme  = "s3://"
+	gcsScheme = "gs://"
+)
+
+// Section represents the seed section of the okteto manifest.
+// Seeds load test data into the dev environment and are executed in order after the deploy
+type Section []*Seed
+
+// Seed loads test data into the dev environment. Only one of SQL, Command or Upload can be set
+type Seed struct {
+	// SQL loads a sql file with a database client
+	SQL *SQL `json:"sql,omitempty" yaml:"sql,omitempty"`
+	// Upload copies local files to a bucket
+	Upload *Upload `json:"upload,omitempty" yaml:"upload,omitempty"`
+	Name   string  `json:"name,omitempty" yaml:"name,omitempty"`
+	// Command is a fixture command
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	// Files are the files and folders used by the seed. A seed is only executed again when they change
+	Files []string `json:"files,omitempty" yaml:"files,omitempty"`
+}
+
+// SQL defines a sql file loaded with a database client
+type SQL struct {
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+	// Command is the database client that reads the sql file from its standard input, e.g. 'psql $DATABASE_URL'
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+// Upload defines local files copied to a s3 or gcs bucket
+type Upload struct {
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// Bucket is the destination in the format s3://bucket/prefix or gs://bucket/prefix
+	Bucket string `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+}
+
+// Validate returns an error if any seed of the section is not valid
+func (s Section) Validate() error {
+	names := map[string]bool{}
+	for _, seed := range s {
+		if seed == nil {
+			return fmt.Errorf("invalid 'seed' section: seeds cannot be empty")
+		}
+		if err := seed.validate(); err != nil {
+			return err
+		}
+		if names[seed.Name] {
+			return fmt.Errorf("invalid seed '%s': the name is duplicated", seed.Name)
+		}
+		names[seed.Name] = true
+	}
+	return nil
+}
+
+// Filter returns the seeds with the given names in the given order. All the seeds are returned if names is empty
+func (s Section) Filter(names []string) (Section, error) {
+	if len(names) == 0 {
+		return s, nil
+	}
+	result := Section{}
+	for _, name := range names {
+		seed, ok := s.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("seed '%s' is not defined in the okteto manifest", name)
+		}
+		result = append(result, seed)
+	}
+	return result, nil
+}
+
+// Get returns the seed with the given name
+func (s Section) Get(name string) (*Seed, bool) {
+	for _, seed := range s {
+		if seed.Name == name {
+			return seed, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Seed) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("invalid 'seed' section: the field 'name' is mandatory")
+	}
+
+	defined := 0
+	if s.SQL != nil {
+		defined++
+	}
+	if s.Upload != nil {
+		defined++
+	}
+	if s.Command != "" {
+		defined++
+	}
+	if defined != 1 {
+		return fmt.Errorf("invalid seed '%s': exactly one of 'sql', 'command' or 'upload' must be defined", s.Name)
+	}
+
+	if s.SQL != nil && (s.SQL.File == "" || s.SQL.Command == "") {
+		return fmt.Errorf("invalid seed '%s': the fields 'sql.file' and 'sql.command' are mandatory", s.Name)
+	}
+
+	if s.Upload != nil {
+		if s.Upload.Source == "" {
+			return fmt.Errorf("invalid seed '%s': the field 'upload.source' is mandatory", s.Name)
+		}
+		if !strings.HasPrefix(s.Upload.Bucket, s3Scheme) && !strings.HasPrefix(s.Upload.Bucket, gcsScheme) {
+			return fmt.Errorf("invalid seed '%s': 'upload.bucket' must start with '%s' or '%s'", s.Name, s3Scheme, gcsScheme)
+		}
+	}
+	return nil
+}
+
+// files returns the local files and folders whose content is part of the seed checksum
+func (s *Seed) files() []string {
+	files := append([]string{}, s.Files...)
+	if s.SQL != nil {
+		files = append(files, s.SQL.File)
+	}
+	if s.Upload != nil {
+		files = append(files, s.Upload.Source)
+	}
+	return files
+}
+
+// ShellCommand returns the shell command that runs the seed. isDir is used to choose how the upload source is copied
+func (s *Seed) ShellCommand(isDir bool) string {
+	switch {
+	case s.SQL != nil:
+		return fmt.Sprintf("%s < %s", s.SQL.Command, quote(s.SQL.File))
+	case s.Upload != nil:
+		src, dst := quote(s.Upload.Source), quote(s.Upload.Bucket)
+		if strings.HasPrefix(s.Upload.Bucket, gcsScheme) {
+			if isDir {
+				return fmt.Sprintf("gsutil -m rsync -r %s %s", src, dst)
+			}
+			return fmt.Sprintf("gsutil cp %s %s", src, dst)
+		}
+		if isDir {
+			return fmt.Sprintf("aws s3 sync %s %s", src, dst)
+		}
+		return fmt.Sprintf("aws s3 cp %s %s", src, dst)
+	default:
+		return s.Command
+	}
+}
+
+// quote quotes a value to be used as a single argument of a shell command
+func quote(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", `'\''`))
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSectionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		section Section
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			section: Section{
+				{Name: "db", SQL: &SQL{File: "seed.sql", Command: "psql"}},
+				{Name: "fixtures", Command: "make fixtures"},
+				{Name: "assets", Upload: &Upload{Source: "assets", Bucket: "s3://bucket/assets"}},
+			},
+		},
+		{
+			name:    "missing name",
+			section: Section{{Command: "make fixtures"}},
+			wantErr: true,
+		},
+		{
+			name: "duplicated name",
+			section: Section{
+				{Name: "db", Command: "make db"},
+				{Name: "db", Command: "make fixtures"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no type",
+			section: Section{{Name: "db"}},
+			wantErr: true,
+		},
+		{
+			name:    "several types",
+			section: Section{{Name: "db", Command: "make db", SQL: &SQL{File: "seed.sql", Command: "psql"}}},
+			wantErr: true,
+		},
+		{
+			name:    "sql without command",
+			section: Section{{Name: "db", SQL: &SQL{File: "seed.sql"}}},
+			wantErr: true,
+		},
+		{
+			name:    "upload with invalid bucket",
+			section: Section{{Name: "assets", Upload: &Upload{Source: "assets", Bucket: "bucket"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.section.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     *Seed
+		isDir    bool
+		expected string
+	}{
+		{
+			name:     "sql",
+			seed:     &Seed{Name: "db", SQL: &SQL{File: "seeds/it's.sql", Command: "psql $DATABASE_URL"}},
+			expected: `psql $DATABASE_URL < 'seeds/it'\''s.sql'`,
+		},
+		{
+			name:     "command",
+			seed:     &Seed{Name: "fixtures", Command: "make fixtures"},
+			expected: "make fixtures",
+		},
+		{
+			name:     "s3 file",
+			seed:     &Seed{Name: "assets", Upload: &Upload{Source: "logo.png", Bucket: "s3://bucket/logo.png"}},
+			expected: "aws s3 cp 'logo.png' 's3://bucket/logo.png'",
+		},
+		{
+			name:     "s3 folder",
+			seed:     &Seed{Name: "assets", Upload: &Upload{Source: "assets", Bucket: "s3://bucket/assets"}},
+			isDir:    true,
+			expected: "aws s3 sync 'assets' 's3://bucket/assets'",
+		},
+		{
+			name:     "gcs folder",
+			seed:     &Seed{Name: "assets", Upload: &Upload{Source: "assets", Bucket: "gs://bucket/assets"}},
+			isDir:    true,
+			expected: "gsutil -m rsync -r 'assets' 'gs://bucket/assets'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.seed.ShellCommand(tt.isDir))
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	section := Section{
+		{Name: "db", Command: "make db"},
+		{Name: "fixtures", Command: "make fixtures"},
+	}
+
+	all, err := section.Filter(nil)
+	require.NoError(t, err)
+	assert.Equal(t, section, all)
+
+	filtered, err := section.Filter([]string{"fixtures"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "fixtures", filtered[0].Name)
+
+	_, err = section.Filter([]string{"unknown"})
+	assert.Error(t, err)
+}
+
+func TestChecksum(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "seeds/users.sql", []byte("INSERT INTO users VALUES (1);"), 0600))
+	s := &Seed{Name: "db", SQL: &SQL{File: "seeds/users.sql", Command: "psql"}}
+
+	first, err := Checksum(fs, s)
+	require.NoError(t, err)
+	second, err := Checksum(fs, s)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	require.NoError(t, afero.WriteFile(fs, "seeds/users.sql", []byte("INSERT INTO users VALUES (2);"), 0600))
+	changedFile, err := Checksum(fs, s)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, changedFile)
+
+	s.SQL.Command = "psql -v ON_ERROR_STOP=1"
+	changedDefinition, err := Checksum(fs, s)
+	require.NoError(t, err)
+	assert.NotEqual(t, changedFile, changedDefinition)
+
+	_, err = Checksum(fs, &Seed{Name: "db", SQL: &SQL{File: "missing.sql", Command: "psql"}})
+	assert.Error(t, err)
+}
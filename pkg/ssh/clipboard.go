@@ -0,0 +1,158 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+var (
+	osc52Prefix = []byte("\x1b]52;")
+	osc52BEL    = []byte("\x07")
+	osc52ST     = []byte("\x1b\\")
+)
+
+// maxOSC52Size is the maximum size of a clipboard sequence. Longer sequences are written as they are
+const maxOSC52Size = 1024 * 1024
+
+// osc52Writer relays the OSC 52 sequences written by the programs of the dev container to the local clipboard,
+// and writes the rest of the output to w
+type osc52Writer struct {
+	w       io.Writer
+	copy    func([]byte) error
+	pending []byte
+}
+
+func newOSC52Writer(w io.Writer, copyFn func([]byte) error) *osc52Writer {
+	return &osc52Writer{w: w, copy: copyFn}
+}
+
+// Write implements the io.Writer interface. Incomplete sequences are kept until the next write
+func (o *osc52Writer) Write(p []byte) (int, error) {
+	data := append(o.pending, p...)
+	o.pending = nil
+
+	for len(data) > 0 {
+		start := bytes.Index(data, osc52Prefix)
+		if start == -1 {
+			keep := partialPrefixLen(data, osc52Prefix)
+			if _, err := o.w.Write(data[:len(data)-keep]); err != nil {
+				return 0, err
+			}
+			o.pending = append(o.pending, data[len(data)-keep:]...)
+			break
+		}
+
+		if _, err := o.w.Write(data[:start]); err != nil {
+			return 0, err
+		}
+		data = data[start:]
+
+		body := data[len(osc52Prefix):]
+		end, terminatorLen := findOSC52Terminator(body)
+		if end == -1 {
+			if len(data) > maxOSC52Size {
+				if _, err := o.w.Write(data); err != nil {
+					return 0, err
+				}
+			} else {
+				o.pending = append(o.pending, data...)
+			}
+			break
+		}
+
+		o.relay(body[:end])
+		data = body[end+terminatorLen:]
+	}
+	return len(p), nil
+}
+
+// relay copies the content of an OSC 52 sequence ("<selection>;<base64 data>") to the local clipboard
+func (o *osc52Writer) relay(sequence []byte) {
+	_, encoded, found := bytes.Cut(sequence, []byte(";"))
+	if !found || string(encoded) == "?" {
+		return
+	}
+	content, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil {
+		oktetoLog.Infof("ignoring invalid clipboard sequence: %s", err)
+		return
+	}
+	if err := o.copy(content); err != nil {
+		oktetoLog.Infof("failed to copy to the local clipboard: %s", err)
+	}
+}
+
+func findOSC52Terminator(data []byte) (int, int) {
+	bel := bytes.Index(data, osc52BEL)
+	st := bytes.Index(data, osc52ST)
+	switch {
+	case bel == -1 && st == -1:
+		return -1, 0
+	case st == -1 || (bel != -1 && bel < st):
+		return bel, len(osc52BEL)
+	default:
+		return st, len(osc52ST)
+	}
+}
+
+// partialPrefixLen returns the length of the longest suffix of data that is a prefix of prefix
+func partialPrefixLen(data, prefix []byte) int {
+	for n := len(prefix) - 1; n > 0; n-- {
+		if len(data) >= n && bytes.Equal(data[len(data)-n:], prefix[:n]) {
+			return n
+		}
+	}
+	return 0
+}
+
+// copyToClipboard writes content to the clipboard of the local machine
+func copyToClipboard(content []byte) error {
+	name, args, err := getClipboardCommand()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(content)
+	return cmd.Run()
+}
+
+func getClipboardCommand() (string, []string, error) {
+	candidates := [][]string{}
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = append(candidates, []string{"pbcopy"})
+	case "windows":
+		candidates = append(candidates, []string{"clip.exe"})
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		candidates = append(candidates, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+	}
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err == nil {
+			return c[0], c[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("no clipboard command found")
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_osc52Writer(t *testing.T) {
+	tests := []struct {
+		name           string
+		writes         []string
+		expectedOutput string
+		expectedCopies []string
+	}{
+		{
+			name:           "no sequences",
+			writes:         []string{"hello ", "world\n"},
+			expectedOutput: "hello world\n",
+		},
+		{
+			name:           "sequence terminated with BEL",
+			writes:         []string{"before\x1b]52;c;aGVsbG8=\x07after"},
+			expectedOutput: "beforeafter",
+			expectedCopies: []string{"hello"},
+		},
+		{
+			name:           "sequence terminated with ST",
+			writes:         []string{"\x1b]52;c;d29ybGQ=\x1b\\done"},
+			expectedOutput: "done",
+			expectedCopies: []string{"world"},
+		},
+		{
+			name:           "sequence split across writes",
+			writes:         []string{"a\x1b", "]52;c;aGVs", "bG8=\x07b"},
+			expectedOutput: "ab",
+			expectedCopies: []string{"hello"},
+		},
+		{
+			name:           "clipboard query is ignored",
+			writes:         []string{"\x1b]52;c;?\x07"},
+			expectedOutput: "",
+		},
+		{
+			name:           "other escape sequences are kept",
+			writes:         []string{"\x1b]0;title\x07\x1b[31mred"},
+			expectedOutput: "\x1b]0;title\x07\x1b[31mred",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			var copies []string
+			w := newOSC52Writer(&out, func(content []byte) error {
+				copies = append(copies, string(content))
+				return nil
+			})
+			for _, s := range tt.writes {
+				n, err := w.Write([]byte(s))
+				assert.NoError(t, err)
+				assert.Equal(t, len(s), n)
+			}
+			assert.Equal(t, tt.expectedOutput, out.String())
+			assert.Equal(t, tt.expectedCopies, copies)
+		})
+	}
+}
@@ -15,19 +15,60 @@ package ssh
 
 import (
 	"io"
+	"sync"
 	"time"
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
+// EscapeChar starts an escape sequence when it is typed at the beginning of a line
+const EscapeChar = '~'
+
+var (
+	escapeHandlers   = map[byte]func(){}
+	escapeHandlersMu sync.RWMutex
+)
+
 // Copier copies from local to remote terminalhandles the lifecycle of all the forwards
 type Copier struct {
 	Local  io.Reader
 	Remote io.WriteCloser
+	// midLine is true when the last byte sent to the remote terminal was not a line break
+	midLine bool
+	// pendingEscape is true when the escape character was typed and the next byte selects the handler
+	pendingEscape bool
 }
 
 var copier *Copier
 
+// RegisterEscapeHandler registers a handler that is executed instead of forwarding the input to the remote terminal
+// when EscapeChar followed by key is typed at the beginning of a line
+func RegisterEscapeHandler(key byte, handler func()) {
+	escapeHandlersMu.Lock()
+	defer escapeHandlersMu.Unlock()
+	escapeHandlers[key] = handler
+}
+
+// UnregisterEscapeHandler removes the handler registered for key
+func UnregisterEscapeHandler(key byte) {
+	escapeHandlersMu.Lock()
+	defer escapeHandlersMu.Unlock()
+	delete(escapeHandlers, key)
+}
+
+func getEscapeHandler(key byte) (func(), bool) {
+	escapeHandlersMu.RLock()
+	defer escapeHandlersMu.RUnlock()
+	handler, ok := escapeHandlers[key]
+	return handler, ok
+}
+
+func hasEscapeHandlers() bool {
+	escapeHandlersMu.RLock()
+	defer escapeHandlersMu.RUnlock()
+	return len(escapeHandlers) > 0
+}
+
 func Copy(local io.Reader, remote io.WriteCloser) {
 	if copier == nil {
 		copier = &Copier{Local: local, Remote: remote}
@@ -42,9 +83,10 @@ func (c *Copier) Copy() {
 	t := time.NewTicker(100 * time.Millisecond)
 	for {
 		nr, er := c.Local.Read(buf)
+		out := c.filterEscapeSequences(buf[:nr])
 		write := 0
-		for write < nr {
-			nw, ew := c.Remote.Write(buf[write:nr])
+		for write < len(out) {
+			nw, ew := c.Remote.Write(out[write:])
 			write += nw
 			if ew != nil {
 				oktetoLog.Infof("write to remote terminal error: %s", ew.Error())
@@ -58,3 +100,41 @@ func (c *Copier) Copy() {
 		}
 	}
 }
+
+// filterEscapeSequences runs the handlers of the escape sequences found in the input and returns the bytes to be sent to the remote terminal
+func (c *Copier) filterEscapeSequences(in []byte) []byte {
+	if !c.pendingEscape && !hasEscapeHandlers() {
+		if len(in) > 0 {
+			c.midLine = !isLineBreak(in[len(in)-1])
+		}
+		return in
+	}
+	out := make([]byte, 0, len(in))
+	for _, b := range in {
+		if c.pendingEscape {
+			c.pendingEscape = false
+			if handler, ok := getEscapeHandler(b); ok {
+				handler()
+				continue
+			}
+			// typing the escape character twice sends it once
+			if b != EscapeChar {
+				out = append(out, EscapeChar)
+			}
+			out = append(out, b)
+			c.midLine = !isLineBreak(b)
+			continue
+		}
+		if !c.midLine && b == EscapeChar {
+			c.pendingEscape = true
+			continue
+		}
+		out = append(out, b)
+		c.midLine = !isLineBreak(b)
+	}
+	return out
+}
+
+func isLineBreak(b byte) bool {
+	return b == '\r' || b == '\n'
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterEscapeSequences(t *testing.T) {
+	calls := 0
+	RegisterEscapeHandler('h', func() { calls++ })
+	defer UnregisterEscapeHandler('h')
+
+	tests := []struct {
+		name          string
+		input         []string
+		expected      string
+		expectedCalls int
+	}{
+		{
+			name:          "escape sequence at the beginning",
+			input:         []string{"~hls\r"},
+			expected:      "ls\r",
+			expectedCalls: 1,
+		},
+		{
+			name:          "escape sequence after a line break split between reads",
+			input:         []string{"ls\r~", "h"},
+			expected:      "ls\r",
+			expectedCalls: 1,
+		},
+		{
+			name:     "escape char in the middle of a line",
+			input:    []string{"cd ~h"},
+			expected: "cd ~h",
+		},
+		{
+			name:     "unknown escape sequence",
+			input:    []string{"~x"},
+			expected: "~x",
+		},
+		{
+			name:     "escape char typed twice",
+			input:    []string{"~~h"},
+			expected: "~h",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			c := &Copier{}
+			result := ""
+			for _, in := range tt.input {
+				result += string(c.filterEscapeSequences([]byte(in)))
+			}
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
@@ -32,8 +32,16 @@ import (
 	"golang.org/x/term"
 )
 
+// Forwards are the local features forwarded to the dev container through the SSH session
+type Forwards struct {
+	// X11 forwards the X11 connections opened in the dev container to the local DISPLAY
+	X11 bool
+	// Clipboard copies the OSC 52 sequences written in the dev container to the local clipboard
+	Clipboard bool
+}
+
 // Exec executes the command over SSH
-func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Reader, outW, errW io.Writer, command []string) error {
+func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Reader, outW, errW io.Writer, forwards Forwards, command []string) error {
 	sshConfig, err := getSSHClientConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get SSH configuration: %w", err)
@@ -137,6 +145,19 @@ func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Re
 		}
 	}
 
+	if forwards.X11 {
+		display := os.Getenv("DISPLAY")
+		if display == "" {
+			oktetoLog.Warning("X11 forwarding is enabled but DISPLAY is not set")
+		} else if err := forwardX11(connection, session, display); err != nil {
+			oktetoLog.Warning("Failed to enable X11 forwarding: %s", err)
+		}
+	}
+
+	if forwards.Clipboard {
+		outW = newOSC52Writer(outW, copyToClipboard)
+	}
+
 	stdin, err := session.StdinPipe()
 	if err != nil {
 		return fmt.Errorf("unable to setup stdin for session: %w", err)
@@ -31,6 +31,7 @@ type forward struct {
 	remoteAddress string
 	lock          sync.Mutex
 	c             bool
+	paused        bool
 }
 
 func (f *forward) connected() bool {
@@ -45,6 +46,18 @@ func (f *forward) setConnected() {
 	f.c = true
 }
 
+func (f *forward) isPaused() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.paused
+}
+
+func (f *forward) setPaused(paused bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.paused = paused
+}
+
 func (f *forward) setDisconnected() {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -93,6 +106,11 @@ func (f *forward) handle(local net.Conn) {
 		}
 	}()
 
+	if f.isPaused() {
+		oktetoLog.Infof("%s -> paused, rejecting connection", f.String())
+		return
+	}
+
 	remote, err := f.pool.get(f.remoteAddress)
 	if err != nil {
 		oktetoLog.Infof("%s -> failed to dial remote connection: %s", f.String(), err)
@@ -184,6 +184,29 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 	return nil
 }
 
+// Pause rejects the new connections of the forwards, except the ones listening on the given local ports.
+// Established connections are kept open
+func (fm *ForwardManager) Pause(except ...int) {
+	skip := map[int]bool{}
+	for _, port := range except {
+		skip[port] = true
+	}
+	for port, f := range fm.forwards {
+		if !skip[port] {
+			f.setPaused(true)
+		}
+	}
+	oktetoLog.Info("paused SSH forwards")
+}
+
+// Resume accepts again the connections of the paused forwards
+func (fm *ForwardManager) Resume() {
+	for _, f := range fm.forwards {
+		f.setPaused(false)
+	}
+	oktetoLog.Info("resumed SSH forwards")
+}
+
 // Stop sends a stop signal to all the connections
 func (fm *ForwardManager) Stop() {
 
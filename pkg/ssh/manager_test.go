@@ -367,3 +367,28 @@ func TestAdd(t *testing.T) {
 		t.Fatalf("expected 'svc:15123', got '%s'", pf.forwards[1012].remoteAddress)
 	}
 }
+
+func TestPauseAndResume(t *testing.T) {
+	pf := NewForwardManager(context.Background(), "0.0.0.0:22000", "0.0.0.0", "0.0.0.0", nil, "")
+	if err := pf.Add(forwardModel.Forward{Local: 10010, Remote: 1010}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pf.Add(forwardModel.Forward{Local: 10011, Remote: 1011}); err != nil {
+		t.Fatal(err)
+	}
+
+	pf.Pause(10011)
+	if !pf.forwards[10010].isPaused() {
+		t.Fatal("forward 10010 wasn't paused")
+	}
+
+	if pf.forwards[10011].isPaused() {
+		t.Fatal("excluded forward 10011 was paused")
+	}
+
+	pf.Resume()
+	if pf.forwards[10010].isPaused() {
+		t.Fatal("forward 10010 wasn't resumed")
+	}
+}
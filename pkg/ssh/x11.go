@@ -0,0 +1,177 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	x11ChannelType   = "x11"
+	x11RequestType   = "x11-req"
+	x11AuthProtocol  = "MIT-MAGIC-COOKIE-1"
+	x11BaseTCPPort   = 6000
+	x11UnixSocketDir = "/tmp/.X11-unix"
+)
+
+// x11Request is the payload of the x11-req request defined in RFC 4254, section 6.3.1
+type x11Request struct {
+	AuthProtocol     string
+	AuthCookie       string
+	ScreenNumber     uint32
+	SingleConnection bool
+}
+
+// x11Display is the local X server the connections opened in the dev container are forwarded to
+type x11Display struct {
+	network string
+	address string
+	screen  uint32
+}
+
+// parseDisplay translates the value of DISPLAY to the address of the local X server
+func parseDisplay(display string) (*x11Display, error) {
+	colon := strings.LastIndex(display, ":")
+	if colon == -1 {
+		return nil, fmt.Errorf("invalid DISPLAY '%s'", display)
+	}
+	host := display[:colon]
+	number, screen, _ := strings.Cut(display[colon+1:], ".")
+	if _, err := strconv.Atoi(number); err != nil {
+		return nil, fmt.Errorf("invalid DISPLAY '%s'", display)
+	}
+
+	result := &x11Display{}
+	if screen != "" {
+		s, err := strconv.ParseUint(screen, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid screen in DISPLAY '%s'", display)
+		}
+		result.screen = uint32(s)
+	}
+
+	switch {
+	case strings.HasPrefix(host, "/"):
+		// launchd sockets, used by XQuartz on macOS
+		result.network = "unix"
+		result.address = fmt.Sprintf("%s:%s", host, number)
+	case host == "" || host == "unix":
+		result.network = "unix"
+		result.address = filepath.Join(x11UnixSocketDir, fmt.Sprintf("X%s", number))
+	default:
+		n, _ := strconv.Atoi(number)
+		result.network = "tcp"
+		result.address = net.JoinHostPort(host, strconv.Itoa(x11BaseTCPPort+n))
+	}
+	return result, nil
+}
+
+// getXAuthCookie returns the cookie to authenticate with the local X server.
+// If xauth is not available, it returns a random cookie, valid for X servers without access control
+func getXAuthCookie(display string) string {
+	output, err := exec.Command("xauth", "list", display).Output()
+	if err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 3 && fields[1] == x11AuthProtocol {
+				return fields[2]
+			}
+		}
+	}
+	oktetoLog.Infof("no xauth cookie found for display '%s', using a random one", display)
+	cookie := make([]byte, 16)
+	if _, err := rand.Read(cookie); err != nil {
+		oktetoLog.Infof("failed to generate x11 cookie: %s", err)
+	}
+	return hex.EncodeToString(cookie)
+}
+
+// forwardX11 requests X11 forwarding for the session and relays the X11 connections opened in the dev container to the local display
+func forwardX11(client *ssh.Client, session *ssh.Session, display string) error {
+	d, err := parseDisplay(display)
+	if err != nil {
+		return err
+	}
+
+	channels := client.HandleChannelOpen(x11ChannelType)
+	if channels == nil {
+		return fmt.Errorf("x11 forwarding is already enabled for this connection")
+	}
+
+	req := x11Request{
+		AuthProtocol: x11AuthProtocol,
+		AuthCookie:   getXAuthCookie(display),
+		ScreenNumber: d.screen,
+	}
+	ok, err := session.SendRequest(x11RequestType, true, ssh.Marshal(&req))
+	if err != nil {
+		return fmt.Errorf("failed to request x11 forwarding: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("x11 forwarding was rejected by the development container")
+	}
+
+	go func() {
+		for ch := range channels {
+			go handleX11Channel(ch, d)
+		}
+	}()
+	return nil
+}
+
+func handleX11Channel(newChannel ssh.NewChannel, d *x11Display) {
+	local, err := net.Dial(d.network, d.address)
+	if err != nil {
+		oktetoLog.Infof("failed to connect to the local X server at %s: %s", d.address, err)
+		if err := newChannel.Reject(ssh.ConnectionFailed, err.Error()); err != nil {
+			oktetoLog.Infof("failed to reject x11 channel: %s", err)
+		}
+		return
+	}
+	defer local.Close()
+
+	channel, reqs, err := newChannel.Accept()
+	if err != nil {
+		oktetoLog.Infof("failed to accept x11 channel: %s", err)
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(reqs)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		if _, err := io.Copy(channel, local); err != nil {
+			oktetoLog.Infof("error copying from the local X server: %s", err)
+		}
+		done <- struct{}{}
+	}()
+	go func() {
+		if _, err := io.Copy(local, channel); err != nil {
+			oktetoLog.Infof("error copying to the local X server: %s", err)
+		}
+		done <- struct{}{}
+	}()
+	<-done
+}
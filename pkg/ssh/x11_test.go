@@ -0,0 +1,71 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseDisplay(t *testing.T) {
+	tests := []struct {
+		expected    *x11Display
+		name        string
+		display     string
+		expectedErr bool
+	}{
+		{
+			name:     "local display",
+			display:  ":0",
+			expected: &x11Display{network: "unix", address: "/tmp/.X11-unix/X0"},
+		},
+		{
+			name:     "local display with screen",
+			display:  "unix:1.2",
+			expected: &x11Display{network: "unix", address: "/tmp/.X11-unix/X1", screen: 2},
+		},
+		{
+			name:     "remote display",
+			display:  "localhost:10.0",
+			expected: &x11Display{network: "tcp", address: "localhost:6010"},
+		},
+		{
+			name:     "xquartz socket",
+			display:  "/private/tmp/com.apple.launchd.abc/org.xquartz:0",
+			expected: &x11Display{network: "unix", address: "/private/tmp/com.apple.launchd.abc/org.xquartz:0"},
+		},
+		{
+			name:        "missing display number",
+			display:     "localhost",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid display number",
+			display:     ":abc",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseDisplay(tt.display)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/shirou/gopsutil/process"
+)
+
+const (
+	registryFile = "processes.json"
+
+	lockTimeout    = 10 * time.Second
+	lockRetryDelay = 50 * time.Millisecond
+
+	terminateTimeout = 3 * time.Second
+)
+
+// Status is the state of a helper process tracked by the supervisor
+type Status string
+
+const (
+	// StatusRunning is a helper process whose okteto session is still alive
+	StatusRunning Status = "running"
+
+	// StatusOrphan is a helper process still running after its okteto session died
+	StatusOrphan Status = "orphan"
+
+	// StatusStale is a record of a helper process that is no longer running but left its resources behind
+	StatusStale Status = "stale"
+)
+
+// Process is a helper process (e.g. syncthing) started by an okteto session
+type Process struct {
+	StartedAt time.Time `json:"startedAt"`
+	Name      string    `json:"name"`
+	Session   string    `json:"session"`
+	Ports     []int     `json:"ports,omitempty"`
+	TempDirs  []string  `json:"tempDirs,omitempty"`
+	PID       int       `json:"pid"`
+	OwnerPID  int       `json:"ownerPid"`
+	Status    Status    `json:"-"`
+}
+
+// Supervisor tracks the helper processes started by the okteto sessions running in the same machine
+// through a file shared by all of them, so the ones left behind by crashed sessions can be cleaned up
+type Supervisor struct {
+	isAlive   func(pid int) bool
+	isRunning func(pid int, name string) bool
+	terminate func(pid int) error
+	removeAll func(path string) error
+	path      string
+	pid       int
+}
+
+// New returns a supervisor stored in path
+func New(path string) *Supervisor {
+	return &Supervisor{
+		path:      path,
+		pid:       os.Getpid(),
+		isAlive:   isProcessAlive,
+		isRunning: isProcessRunning,
+		terminate: terminateProcess,
+		removeAll: os.RemoveAll,
+	}
+}
+
+// Default returns the supervisor stored in the okteto home
+func Default() *Supervisor {
+	return New(filepath.Join(config.GetOktetoHome(), registryFile))
+}
+
+// Register tracks a helper process started by the current okteto session
+func Register(p Process) {
+	if err := Default().Register(p); err != nil {
+		oktetoLog.Infof("failed to register %s process %d: %s", p.Name, p.PID, err)
+	}
+}
+
+// Unregister stops tracking a helper process that was stopped by the current okteto session
+func Unregister(pid int) {
+	if err := Default().Unregister(pid); err != nil {
+		oktetoLog.Infof("failed to unregister process %d: %s", pid, err)
+	}
+}
+
+// CleanOrphans terminates the helper processes left behind by crashed okteto sessions and removes their resources
+func CleanOrphans() {
+	cleaned, err := Default().CleanOrphans()
+	if err != nil {
+		oktetoLog.Infof("failed to clean orphan processes: %s", err)
+		return
+	}
+	for _, p := range cleaned {
+		oktetoLog.Infof("cleaned %s process %d from session '%s'", p.Name, p.PID, p.Session)
+	}
+}
+
+// Register tracks p as a helper process owned by the current process
+func (s *Supervisor) Register(p Process) error {
+	if p.PID == 0 {
+		return fmt.Errorf("missing pid")
+	}
+	p.OwnerPID = s.pid
+	if p.StartedAt.IsZero() {
+		p.StartedAt = time.Now()
+	}
+	return s.update(func(processes []Process) ([]Process, error) {
+		result := []Process{}
+		for _, existing := range processes {
+			if existing.PID != p.PID {
+				result = append(result, existing)
+			}
+		}
+		return append(result, p), nil
+	})
+}
+
+// Unregister stops tracking the helper process with the given pid
+func (s *Supervisor) Unregister(pid int) error {
+	return s.update(func(processes []Process) ([]Process, error) {
+		result := []Process{}
+		for _, p := range processes {
+			if p.PID != pid {
+				result = append(result, p)
+			}
+		}
+		return result, nil
+	})
+}
+
+// List returns the tracked helper processes with their current status
+func (s *Supervisor) List() []Process {
+	processes := s.load()
+	for i := range processes {
+		processes[i].Status = s.getStatus(processes[i])
+	}
+	return processes
+}
+
+// CleanOrphans terminates the helper processes whose okteto session is no longer running,
+// removes their temporary directories and stops tracking them. Their ports are freed once the processes exit
+func (s *Supervisor) CleanOrphans() ([]Process, error) {
+	cleaned := []Process{}
+	err := s.update(func(processes []Process) ([]Process, error) {
+		result := []Process{}
+		for _, p := range processes {
+			p.Status = s.getStatus(p)
+			if p.Status == StatusRunning {
+				result = append(result, p)
+				continue
+			}
+
+			if p.Status == StatusOrphan {
+				if err := s.terminate(p.PID); err != nil {
+					oktetoLog.Infof("failed to terminate orphan %s process %d: %s", p.Name, p.PID, err)
+					result = append(result, p)
+					continue
+				}
+			}
+
+			for _, dir := range p.TempDirs {
+				if err := s.removeAll(dir); err != nil {
+					oktetoLog.Infof("failed to remove temporary directory '%s' of %s process %d: %s", dir, p.Name, p.PID, err)
+				}
+			}
+			cleaned = append(cleaned, p)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cleaned, nil
+}
+
+func (s *Supervisor) getStatus(p Process) Status {
+	if s.isAlive(p.OwnerPID) {
+		return StatusRunning
+	}
+	if s.isRunning(p.PID, p.Name) {
+		return StatusOrphan
+	}
+	return StatusStale
+}
+
+// update applies fn to the tracked processes while holding the registry lock
+func (s *Supervisor) update(fn func([]Process) ([]Process, error)) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	lock := flock.New(fmt.Sprintf("%s.lock", s.path))
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+	locked, err := lock.TryLockContext(ctx, lockRetryDelay)
+	if err != nil {
+		return fmt.Errorf("failed to lock the processes registry: %w", err)
+	}
+	if !locked {
+		return fmt.Errorf("failed to lock the processes registry")
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			oktetoLog.Infof("failed to unlock the processes registry: %s", err)
+		}
+	}()
+
+	processes, err := fn(s.load())
+	if err != nil {
+		return err
+	}
+	return s.save(processes)
+}
+
+func (s *Supervisor) load() []Process {
+	processes := []Process{}
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			oktetoLog.Infof("failed to read the processes registry: %s", err)
+		}
+		return processes
+	}
+	if err := json.Unmarshal(b, &processes); err != nil {
+		oktetoLog.Infof("ignoring malformed processes registry: %s", err)
+		return []Process{}
+	}
+	return processes
+}
+
+func (s *Supervisor) save(processes []Process) error {
+	b, err := json.Marshal(processes)
+	if err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.tmp", s.path)
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func isProcessAlive(pid int) bool {
+	alive, err := process.PidExists(int32(pid))
+	if err != nil {
+		oktetoLog.Infof("failed to check if process %d is running: %s", pid, err)
+		return true
+	}
+	return alive
+}
+
+// isProcessRunning checks the process name to avoid terminating an unrelated process that reused the pid
+func isProcessRunning(pid int, name string) bool {
+	if !isProcessAlive(pid) {
+		return false
+	}
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return false
+	}
+	pName, err := p.Name()
+	if err != nil {
+		oktetoLog.Infof("failed to get the name of process %d: %s", pid, err)
+		return false
+	}
+	return strings.Contains(pName, name)
+}
+
+// terminateProcess terminates the process and kills it if it is still running after a timeout
+func terminateProcess(pid int) error {
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return nil
+	}
+	if err := p.Terminate(); err != nil {
+		return err
+	}
+
+	to := time.Now().Add(terminateTimeout)
+	for time.Now().Before(to) {
+		running, err := p.IsRunning()
+		if err != nil || !running {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return p.Kill()
+}
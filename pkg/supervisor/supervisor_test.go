@@ -0,0 +1,142 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSupervisor returns a supervisor where only the pids in alive are running
+func newTestSupervisor(t *testing.T, path string, pid int, alive ...int) (*Supervisor, *[]int, *[]string) {
+	t.Helper()
+	running := map[int]bool{}
+	for _, p := range alive {
+		running[p] = true
+	}
+	terminated := []int{}
+	removed := []string{}
+	return &Supervisor{
+		path: path,
+		pid:  pid,
+		isAlive: func(pid int) bool {
+			return running[pid]
+		},
+		isRunning: func(pid int, _ string) bool {
+			return running[pid]
+		},
+		terminate: func(pid int) error {
+			terminated = append(terminated, pid)
+			return nil
+		},
+		removeAll: func(path string) error {
+			removed = append(removed, path)
+			return nil
+		},
+	}, &terminated, &removed
+}
+
+func TestRegisterAndUnregister(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	s, _, _ := newTestSupervisor(t, path, 1, 1, 100, 101)
+
+	require.NoError(t, s.Register(Process{Name: "syncthing", Session: "ns/api", PID: 100, Ports: []int{8384}}))
+	require.NoError(t, s.Register(Process{Name: "syncthing", Session: "ns/frontend", PID: 101}))
+	require.NoError(t, s.Register(Process{Name: "syncthing", Session: "ns/api", PID: 100, Ports: []int{8385}}))
+
+	list := s.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, 101, list[0].PID)
+	assert.Equal(t, 100, list[1].PID)
+	assert.Equal(t, []int{8385}, list[1].Ports)
+	assert.Equal(t, 1, list[1].OwnerPID)
+	assert.Equal(t, StatusRunning, list[1].Status)
+	assert.False(t, list[1].StartedAt.IsZero())
+
+	require.NoError(t, s.Unregister(101))
+	list = s.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, 100, list[0].PID)
+}
+
+func TestRegisterWithoutPID(t *testing.T) {
+	s, _, _ := newTestSupervisor(t, filepath.Join(t.TempDir(), registryFile), 1)
+	assert.Error(t, s.Register(Process{Name: "syncthing"}))
+}
+
+func TestListStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name":"syncthing","session":"ns/running","pid":100,"ownerPid":1},
+		{"name":"syncthing","session":"ns/orphan","pid":101,"ownerPid":2},
+		{"name":"syncthing","session":"ns/stale","pid":102,"ownerPid":3}
+	]`), 0600))
+
+	s, _, _ := newTestSupervisor(t, path, 1, 1, 100, 101)
+	list := s.List()
+	require.Len(t, list, 3)
+	assert.Equal(t, StatusRunning, list[0].Status)
+	assert.Equal(t, StatusOrphan, list[1].Status)
+	assert.Equal(t, StatusStale, list[2].Status)
+}
+
+func TestCleanOrphans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name":"syncthing","session":"ns/running","pid":100,"ownerPid":1,"tempDirs":["/tmp/running"]},
+		{"name":"syncthing","session":"ns/orphan","pid":101,"ownerPid":2,"tempDirs":["/tmp/orphan"]},
+		{"name":"syncthing","session":"ns/stale","pid":102,"ownerPid":3,"tempDirs":["/tmp/stale"]}
+	]`), 0600))
+
+	s, terminated, removed := newTestSupervisor(t, path, 1, 1, 100, 101)
+	cleaned, err := s.CleanOrphans()
+	require.NoError(t, err)
+
+	require.Len(t, cleaned, 2)
+	assert.Equal(t, "ns/orphan", cleaned[0].Session)
+	assert.Equal(t, "ns/stale", cleaned[1].Session)
+	assert.Equal(t, []int{101}, *terminated)
+	assert.Equal(t, []string{"/tmp/orphan", "/tmp/stale"}, *removed)
+
+	list := s.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, "ns/running", list[0].Session)
+}
+
+func TestCleanOrphansKeepsProcessesThatCannotBeTerminated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	s, _, removed := newTestSupervisor(t, path, 1, 1, 101)
+	require.NoError(t, s.save([]Process{{Name: "syncthing", Session: "ns/orphan", PID: 101, OwnerPID: 2, TempDirs: []string{"/tmp/orphan"}}}))
+	s.terminate = func(_ int) error {
+		return assert.AnError
+	}
+
+	cleaned, err := s.CleanOrphans()
+	require.NoError(t, err)
+	assert.Empty(t, cleaned)
+	assert.Empty(t, *removed)
+	assert.Len(t, s.List(), 1)
+}
+
+func TestListIgnoresMalformedRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFile)
+	require.NoError(t, os.WriteFile(path, []byte(`not json`), 0600))
+
+	s, _, _ := newTestSupervisor(t, path, 1)
+	assert.Empty(t, s.List())
+}
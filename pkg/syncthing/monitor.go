@@ -45,12 +45,16 @@ func (s *Syncthing) Monitor(ctx context.Context, disconnect chan error) {
 	}
 }
 
-// MonitorStatus will send a message to disconnected if there is a synchronization error
+// MonitorStatus will send a message to disconnected if there is a synchronization error.
+// The status is not checked while the synchronization is paused
 func (s *Syncthing) MonitorStatus(ctx context.Context, disconnect chan error) {
 	ticker := time.NewTicker(60 * time.Second)
 	for {
 		select {
 		case <-ticker.C:
+			if s.IsPaused() {
+				continue
+			}
 			err := s.checkLocalAndRemoteStatus(ctx)
 			switch err {
 			case nil, oktetoErrors.ErrBusySyncthing, oktetoErrors.ErrLostSyncthing:
@@ -28,6 +28,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"text/template"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	"github.com/okteto/okteto/pkg/filesystem"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ports"
 	"github.com/shirou/gopsutil/process"
 	"github.com/spf13/afero"
 	"golang.org/x/crypto/bcrypt"
@@ -108,6 +110,7 @@ type Syncthing struct {
 	LocalPort        int           `yaml:"-"`
 	pid              int           `yaml:"-"`
 	timeout          time.Duration `yaml:"-"`
+	paused           int32         `yaml:"-"`
 	ForceSendOnly    bool          `yaml:"-"`
 	ResetDatabase    bool          `yaml:"-"`
 	IgnoreDelete     bool          `yaml:"-"`
@@ -204,22 +207,23 @@ type DownloadProgressData struct {
 func New(dev *model.Dev, fs afero.Fs) (*Syncthing, error) {
 	fullPath := getInstallPath()
 
-	remotePort, err := model.GetAvailablePort(dev.Interface)
+	owner := ports.Owner(dev.Namespace, dev.Name)
+	remotePort, err := ports.Reserve(owner, "syncthing-remote", dev.Interface)
 	if err != nil {
 		return nil, err
 	}
 
-	remoteGUIPort, err := model.GetAvailablePort(dev.Interface)
+	remoteGUIPort, err := ports.Reserve(owner, "syncthing-remote-gui", dev.Interface)
 	if err != nil {
 		return nil, err
 	}
 
-	guiPort, err := model.GetAvailablePort(dev.Interface)
+	guiPort, err := ports.Reserve(owner, "syncthing-gui", dev.Interface)
 	if err != nil {
 		return nil, err
 	}
 
-	listenPort, err := model.GetAvailablePort(dev.Interface)
+	listenPort, err := ports.Reserve(owner, "syncthing-listen", dev.Interface)
 	if err != nil {
 		return nil, err
 	}
@@ -880,6 +884,31 @@ func getInSynchronizationLargestFile(e ItemEvent) string {
 	return result
 }
 
+// Pause stops the synchronization with the remote syncthing until Resume is called
+func (s *Syncthing) Pause(ctx context.Context) error {
+	params := map[string]string{"device": s.RemoteDeviceID}
+	if _, err := s.APICall(ctx, "rest/system/pause", "POST", http.StatusOK, params, true, nil, false, maxRetries); err != nil {
+		return fmt.Errorf("failed to pause the file synchronization: %w", err)
+	}
+	atomic.StoreInt32(&s.paused, 1)
+	return nil
+}
+
+// Resume restarts the synchronization with the remote syncthing
+func (s *Syncthing) Resume(ctx context.Context) error {
+	params := map[string]string{"device": s.RemoteDeviceID}
+	if _, err := s.APICall(ctx, "rest/system/resume", "POST", http.StatusOK, params, true, nil, false, maxRetries); err != nil {
+		return fmt.Errorf("failed to resume the file synchronization: %w", err)
+	}
+	atomic.StoreInt32(&s.paused, 0)
+	return nil
+}
+
+// IsPaused returns true if the synchronization is paused
+func (s *Syncthing) IsPaused() bool {
+	return atomic.LoadInt32(&s.paused) == 1
+}
+
 // Restart restarts the syncthing process
 func (s *Syncthing) Restart(ctx context.Context) error {
 	_, err := s.APICall(ctx, "rest/system/restart", "POST", http.StatusOK, nil, true, nil, false, maxRetries)
@@ -938,6 +967,11 @@ func (s *Syncthing) HardTerminate() error {
 	return nil
 }
 
+// PID returns the pid of the local syncthing process started by Run
+func (s *Syncthing) PID() int {
+	return s.pid
+}
+
 // SoftTerminate halts the background process
 func (s *Syncthing) SoftTerminate() error {
 	if s.pid == 0 {
@@ -70,3 +70,36 @@ func (b *TextBlock) FindBlocks(input string) ([]string, error) {
 
 	return blocks, nil
 }
+
+// RemoveBlocks receives an input string and returns it without the blocks
+// wrapped by the header and footer configured in the TextBlock instance,
+// including the header and footer lines
+func (b *TextBlock) RemoveBlocks(input string) (string, error) {
+	result, startFound, startLine := []string{}, false, -1
+	lines := strings.Split(input, "\n")
+	for i, l := range lines {
+		switch l {
+		case b.start:
+			if startFound {
+				return "", &ErrorUnexpectedStart{Line: startLine}
+			}
+			startFound, startLine = true, i
+			continue
+		case b.end:
+			if !startFound {
+				return "", &ErrorUnexpectedEnd{Line: i}
+			}
+			startFound, startLine = false, -1
+			continue
+		}
+		if !startFound {
+			result = append(result, l)
+		}
+	}
+
+	if startFound {
+		return "", &ErrorMissingEnd{Line: startLine}
+	}
+
+	return strings.Join(result, "\n"), nil
+}
@@ -135,3 +135,44 @@ func Test_Write(t *testing.T) {
 		})
 	}
 }
+
+func Test_Remove(t *testing.T) {
+	tests := []struct {
+		isValidErrFunc func(err error) bool
+		name           string
+		data           string
+		want           string
+	}{
+		{
+			name:           "no-blocks",
+			data:           "something here\nsomething there",
+			want:           "something here\nsomething there",
+			isValidErrFunc: IsErrorNil,
+		},
+		{
+			name:           "multiple-blocks",
+			data:           "something here\n---- BEGIN ----\nhello world\n---- END ----\nsomething in the middle\n---- BEGIN ----\nhow are you today?\n---- END ----\nsomething there",
+			want:           "something here\nsomething in the middle\nsomething there",
+			isValidErrFunc: IsErrorNil,
+		},
+		{
+			name:           "error-missing-end",
+			data:           "something here\n---- BEGIN ----\nthis may\nbe wrong",
+			want:           "",
+			isValidErrFunc: IsErrorMissingEnd,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewTextBlock("---- BEGIN ----", "---- END ----")
+			output, err := parser.RemoveBlocks(tt.data)
+			if !tt.isValidErrFunc(err) {
+				t.Errorf("error got: %v", err)
+			}
+			if output != tt.want {
+				t.Errorf("got: %v, expected: %v", output, tt.want)
+			}
+		})
+	}
+}
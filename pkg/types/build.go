@@ -27,6 +27,14 @@ type BuildSshSession struct {
 	Target string
 }
 
+// LocalExport defines the local file where the image built is written instead of pushing it
+type LocalExport struct {
+	// Type is the format of the exported image: oci or docker
+	Type string
+	// Dest is the path of the tarball with the image
+	Dest string
+}
+
 type HostMap struct {
 	Hostname string
 	IP       string
@@ -57,4 +65,26 @@ type BuildOptions struct {
 	BuildToGlobal bool
 	NoCache       bool
 	EnableStages  bool
+	// Reproducible sets SOURCE_DATE_EPOCH and normalizes the image metadata so the same source yields the same digest
+	Reproducible bool
+	// SBOM is the format of the software bill of materials attached to the pushed image as an attestation
+	SBOM string
+	// Provenance is the mode of the SLSA provenance attestation attached to the pushed image: min or max. Empty disables it
+	Provenance string
+	// VCSRevision is the git commit recorded in the provenance attestation
+	VCSRevision string
+	// Parallelism is the maximum number of images of the manifest built at the same time
+	Parallelism int
+	// DryRun prints the tags, build args and cache configuration of the images without building them
+	DryRun bool
+	// Builder is the backend that builds the images: auto, buildkit, docker or buildx[:<name>]. Empty uses the default of the context
+	Builder string
+	// Output is the format of the build output: plain or json. The json output emits an event per service and a final document with the digests of the images
+	Output string
+	// CacheWarm builds the images only to export their cache: the images are neither pushed nor tagged
+	CacheWarm bool
+	// LocalExport writes the image to a local tarball instead of pushing it. Nil pushes the image
+	LocalExport *LocalExport
+	// IgnoreRules exclude files from the build context in addition to the rules of its .dockerignore file
+	IgnoreRules []string
 }
@@ -18,12 +18,21 @@ type PipelineDeployOptions struct {
 	Name       string
 	Repository string
 	Branch     string
+	Commit     string
 	Filename   string
 	Variables  []Variable
 	Namespace  string
 	Labels     []string
 }
 
+// PipelineSchedule represents a recurring redeploy of an Okteto pipeline
+type PipelineSchedule struct {
+	Name       string `json:"name" yaml:"name"`
+	Schedule   string `json:"schedule" yaml:"schedule"`
+	Repository string `json:"repository" yaml:"repository"`
+	Branch     string `json:"branch" yaml:"branch"`
+}
+
 // SpaceBody top body answer
 type SpaceBody struct {
 	Space Space `json:"space"`
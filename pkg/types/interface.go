@@ -29,6 +29,8 @@ type OktetoInterface interface {
 	Pipeline() PipelineInterface
 	Stream() StreamInterface
 	Kubetoken() KubetokenInterface
+	Share() ShareInterface
+	Variables() VariablesInterface
 }
 
 // UserInterface represents the client that connects to the user functions
@@ -58,6 +60,7 @@ type PreviewInterface interface {
 	DeployPreview(ctx context.Context, name, scope, repository, branch, sourceUrl, filename string, variables []Variable, labels []string) (*PreviewResponse, error)
 	GetResourcesStatus(ctx context.Context, previewName, devName string) (map[string]string, error)
 	Destroy(ctx context.Context, previewName string) error
+	UpdateScope(ctx context.Context, previewName, scope string) error
 	ListEndpoints(ctx context.Context, previewName string) ([]Endpoint, error)
 	Get(ctx context.Context, previewName string) (*Preview, error)
 }
@@ -70,6 +73,9 @@ type PipelineInterface interface {
 	GetResourcesStatus(ctx context.Context, name, namespace string) (map[string]string, error)
 	GetByName(ctx context.Context, name, namespace string) (*GitDeploy, error)
 	WaitForActionProgressing(ctx context.Context, pipelineName, namespace, actionName string, timeout time.Duration) error
+	SetSchedule(ctx context.Context, name, namespace, schedule string) error
+	ListSchedules(ctx context.Context, namespace string) ([]PipelineSchedule, error)
+	RemoveSchedule(ctx context.Context, name, namespace string) error
 }
 
 // OktetoClientProvider provides an okteto client ready to use or fail
@@ -89,6 +95,20 @@ type KubetokenInterface interface {
 	CheckService(baseURL, namespace string) error
 }
 
+// ShareInterface represents the client that connects to the share link functions
+type ShareInterface interface {
+	Create(ctx context.Context, namespace, endpoint string, expiresIn time.Duration) (*ShareLink, error)
+	List(ctx context.Context, namespace string) ([]ShareLink, error)
+	Revoke(ctx context.Context, namespace, id string) error
+}
+
+// VariablesInterface represents the client that connects to the Okteto variables functions
+type VariablesInterface interface {
+	List(ctx context.Context, scope, namespace string) ([]env.Var, error)
+	Set(ctx context.Context, scope, namespace string, variables []env.Var) error
+	Delete(ctx context.Context, scope, namespace string, names []string) error
+}
+
 // EndpointClientInterface represents the endpoint client
 type EndpointClientInterface interface {
 	List(ctx context.Context, ns, label string) ([]string, error)
@@ -0,0 +1,25 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "time"
+
+// ShareLink represents a time-limited URL that exposes an endpoint of a namespace to external reviewers
+type ShareLink struct {
+	ExpiresAt time.Time `json:"expiresAt" yaml:"expiresAt"`
+	ID        string    `json:"id" yaml:"id"`
+	Endpoint  string    `json:"endpoint" yaml:"endpoint"`
+	URL       string    `json:"url" yaml:"url"`
+	CreatedBy string    `json:"createdBy" yaml:"createdBy"`
+}